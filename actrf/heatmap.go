@@ -0,0 +1,201 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package actrf
+
+import (
+	"fmt"
+	"math"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/etensor"
+)
+
+// Heatmaps accumulates spatial maps of activity, e.g., from a spatial
+// attention layer, across trials for each condition, into normalized
+// heatmaps that sum to 1, paralleling the fixation density heatmaps
+// from human eye-tracking studies. The activity is projected onto 2D
+// using the standard 2D projection of 4D tensors (as in the NetView),
+// optionally resampled to a given size (e.g., of the input image),
+// and smoothed with a Gaussian. Call Reset to restart the accumulation,
+// Add on each trial, and Norm to compute the Maps.
+type Heatmaps struct {
+
+	// Name of the heatmaps.
+	Name string
+
+	// Ny, Nx are the sizes of the heatmaps, e.g., that of the input image,
+	// to which the activity maps are resampled (bilinear) if > 0.
+	// Otherwise the size of the activity projection is used.
+	Ny, Nx int
+
+	// Sigma is the standard deviation of the Gaussian smoothing applied
+	// to the heatmaps in Norm, in heatmap units, with no smoothing if 0.
+	Sigma float32
+
+	// Thr is the threshold on activity below which values are not added.
+	Thr float32
+
+	// Conditions are the conditions, in the order first added.
+	Conditions []string
+
+	// N is the number of trials added for each condition.
+	N map[string]int `display:"-"`
+
+	// Sums are the accumulated sums of the activity maps, per condition.
+	Sums map[string]*tensor.Float32 `display:"-"`
+
+	// Maps are the normalized heatmaps per condition, computed by Norm.
+	Maps map[string]*tensor.Float32 `display:"-"`
+
+	// act is the projected activity map.
+	act tensor.Float32
+
+	// rs is the resampled activity map.
+	rs tensor.Float32
+}
+
+// Reset resets all of the accumulated data.
+func (hm *Heatmaps) Reset() {
+	hm.Conditions = nil
+	hm.N = make(map[string]int)
+	hm.Sums = make(map[string]*tensor.Float32)
+	hm.Maps = make(map[string]*tensor.Float32)
+}
+
+// Add adds given activity to the heatmap for given condition, multiplied
+// by given weight (e.g., 1, or the duration of the trial).
+func (hm *Heatmaps) Add(cond string, act tensor.Tensor, weight float32) {
+	if hm.Sums == nil {
+		hm.Reset()
+	}
+	ny, nx, _, _ := tensor.Projection2DShape(act.Shape(), false)
+	hm.act.SetShapeSizes(ny, nx)
+	for y := range ny {
+		for x := range nx {
+			v := float32(tensor.Projection2DValue(act, false, y, x))
+			if v < hm.Thr {
+				v = 0
+			}
+			hm.act.Set(v, y, x)
+		}
+	}
+	amap := &hm.act
+	if hm.Ny > 0 && hm.Nx > 0 && (hm.Ny != ny || hm.Nx != nx) {
+		etensor.Resample(&hm.act, &hm.rs, hm.Ny, hm.Nx, etensor.Bilinear)
+		amap = &hm.rs
+	}
+	sum, ok := hm.Sums[cond]
+	if !ok {
+		hm.Conditions = append(hm.Conditions, cond)
+		sum = tensor.NewFloat32(amap.ShapeSizes()...)
+		hm.Sums[cond] = sum
+	}
+	for i, v := range amap.Values {
+		sum.Values[i] += weight * v
+	}
+	hm.N[cond]++
+}
+
+// Norm computes the normalized heatmaps in Maps from the Sums, smoothing
+// with a Gaussian of Sigma if > 0, and normalizing to sum to 1.
+// Does not reset the Sums.
+func (hm *Heatmaps) Norm() {
+	for _, cond := range hm.Conditions {
+		sum := hm.Sums[cond]
+		mp, ok := hm.Maps[cond]
+		if !ok {
+			mp = tensor.NewFloat32()
+			hm.Maps[cond] = mp
+		}
+		tensor.SetShapeFrom(mp, sum)
+		copy(mp.Values, sum.Values)
+		if hm.Sigma > 0 {
+			gaussSmooth(mp, hm.Sigma)
+		}
+		tot := float32(0)
+		for _, v := range mp.Values {
+			tot += v
+		}
+		if tot == 0 {
+			continue
+		}
+		for i := range mp.Values {
+			mp.Values[i] /= tot
+		}
+	}
+}
+
+// gaussSmooth smooths the 2D map with a separable Gaussian kernel of
+// given sigma, normalizing by the kernel weights within the map at
+// the edges.
+func gaussSmooth(mp *tensor.Float32, sigma float32) {
+	ny, nx := mp.DimSize(0), mp.DimSize(1)
+	rad := int(math.Ceil(float64(3 * sigma)))
+	kern := make([]float32, 2*rad+1)
+	for i := range kern {
+		d := float32(i - rad)
+		kern[i] = float32(math.Exp(float64(-0.5 * d * d / (sigma * sigma))))
+	}
+	tmp := make([]float32, len(mp.Values))
+	for y := range ny {
+		for x := range nx {
+			var sum, wt float32
+			for k, kw := range kern {
+				if sx := x + k - rad; sx >= 0 && sx < nx {
+					sum += kw * mp.Values[y*nx+sx]
+					wt += kw
+				}
+			}
+			tmp[y*nx+x] = sum / wt
+		}
+	}
+	for y := range ny {
+		for x := range nx {
+			var sum, wt float32
+			for k, kw := range kern {
+				if sy := y + k - rad; sy >= 0 && sy < ny {
+					sum += kw * tmp[sy*nx+x]
+					wt += kw
+				}
+			}
+			mp.Values[y*nx+x] = sum / wt
+		}
+	}
+}
+
+// Map returns the normalized heatmap for given condition,
+// or nil if it is not found. Norm must have been called.
+func (hm *Heatmaps) Map(cond string) *tensor.Float32 {
+	return hm.Maps[cond]
+}
+
+// Table returns a table with a row for each condition, with columns
+// Condition, N (number of trials), and Heatmap (the normalized heatmap),
+// which can be viewed as a grid in a table view, and saved with SaveCSV.
+// Norm must have been called.
+func (hm *Heatmaps) Table() *table.Table {
+	dt := table.New()
+	metadata.SetName(dt, fmt.Sprintf("%s Heatmaps", hm.Name))
+	dt.AddStringColumn("Condition")
+	dt.AddIntColumn("N")
+	var cell []int
+	if len(hm.Conditions) > 0 {
+		cell = hm.Sums[hm.Conditions[0]].ShapeSizes()
+	}
+	hc := dt.AddFloat32Column("Heatmap", cell...)
+	dt.SetNumRows(len(hm.Conditions))
+	for ri, cond := range hm.Conditions {
+		dt.Column("Condition").SetStringRow(cond, ri, 0)
+		dt.Column("N").SetIntRow(hm.N[cond], ri, 0)
+		if mp := hm.Maps[cond]; mp != nil {
+			for i, v := range mp.Values {
+				hc.SetFloatRow(float64(v), ri, i)
+			}
+		}
+	}
+	return dt
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package actrf
+
+import (
+	"testing"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeatmaps(t *testing.T) {
+	hm := &Heatmaps{Name: "Attn", Thr: 0.1}
+	act := tensor.NewFloat32(2, 2)
+	act.Values = []float32{1, 0, 0, 0.05}
+	hm.Add("Left", act, 1)
+	hm.Add("Left", act, 2)
+	act.Values = []float32{0, 1, 0, 1}
+	hm.Add("Right", act, 1)
+	assert.Equal(t, []string{"Left", "Right"}, hm.Conditions)
+	assert.Equal(t, 2, hm.N["Left"])
+	assert.Equal(t, []float32{3, 0, 0, 0}, hm.Sums["Left"].Values) // 0.05 < Thr
+
+	assert.Nil(t, hm.Map("Left"))
+	hm.Norm()
+	assert.Equal(t, []float32{1, 0, 0, 0}, hm.Map("Left").Values)
+	assert.Equal(t, []float32{0, 0.5, 0, 0.5}, hm.Map("Right").Values)
+	assert.Nil(t, hm.Map("Up"))
+
+	dt := hm.Table()
+	assert.Equal(t, 2, dt.NumRows())
+	assert.Equal(t, "Right", dt.Column("Condition").StringRow(1, 0))
+	assert.Equal(t, 2, dt.Column("N").IntRow(0, 0))
+	assert.Equal(t, 0.5, dt.Column("Heatmap").FloatRow(1, 3))
+
+	hm.Reset()
+	assert.Empty(t, hm.Conditions)
+	assert.Empty(t, hm.Maps)
+}
+
+func TestHeatmapsSmooth(t *testing.T) {
+	hm := &Heatmaps{Ny: 5, Nx: 5, Sigma: 1}
+	act := tensor.NewFloat32(1, 1, 5, 5) // 4D pool shape, projected to 5x5
+	act.Set(1, 0, 0, 2, 2)
+	hm.Add("Center", act, 1)
+	hm.Norm()
+	mp := hm.Map("Center")
+	assert.Equal(t, []int{5, 5}, mp.ShapeSizes())
+	tot := float32(0)
+	for _, v := range mp.Values {
+		tot += v
+	}
+	assert.InDelta(t, 1, tot, 1.0e-6)
+	ctr := mp.Value(2, 2)
+	assert.Less(t, ctr, float32(1))
+	assert.Greater(t, ctr, mp.Value(2, 1))
+	assert.Greater(t, mp.Value(2, 1), mp.Value(2, 0))
+	assert.InDelta(t, mp.Value(1, 2), mp.Value(2, 1), 1.0e-6)
+
+	// resampled to the heatmap size
+	hm = &Heatmaps{Ny: 4, Nx: 4}
+	act = tensor.NewFloat32(2, 2)
+	act.Values = []float32{1, 1, 1, 1}
+	hm.Add("Flat", act, 1)
+	hm.Norm()
+	mp = hm.Map("Flat")
+	assert.Equal(t, []int{4, 4}, mp.ShapeSizes())
+	for _, v := range mp.Values {
+		assert.InDelta(t, 1.0/16, v, 1.0e-6)
+	}
+}
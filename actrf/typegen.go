@@ -9,3 +9,5 @@ import (
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/actrf.RF", IDName: "rf", Doc: "RF is used for computing an activation-based receptive field.\nIt simply computes the activation weighted average of other\n*source* patterns of activation -- i.e., sum(act * src) / sum(src)\nwhich then shows you the patterns of source activity for which\na given unit was active.\nYou must call Init to initialize everything, Reset to restart the accumulation of the data,\nand Avg to compute the resulting averages based an accumulated data.\nAvg does not erase the accumulated data so it can continue beyond that point.", Fields: []types.Field{{Name: "Name", Doc: "name of this RF -- used for management of multiple in RFs"}, {Name: "RF", Doc: "computed receptive field, as SumProd / SumSrc -- only after Avg has been called"}, {Name: "NormRF", Doc: "unit normalized version of RF per source (inner 2D dimensions) -- good for display"}, {Name: "NormSrc", Doc: "normalized version of SumSrc -- sum of each point in the source -- good for viewing the completeness and uniformity of the sampling of the source space"}, {Name: "SumProd", Doc: "sum of the products of act * src"}, {Name: "SumSrc", Doc: "sum of the sources (denomenator)"}, {Name: "MPITmp", Doc: "temporary destination sum for MPI -- only used when MPISum called"}}})
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/actrf.RFs", IDName: "r-fs", Doc: "RFs manages multiple named RF's -- each one must be initialized first\nbut functions like Avg, Norm, and Reset can be called generically on all.", Fields: []types.Field{{Name: "NameMap", Doc: "map of names to indexes of RFs"}, {Name: "RFs", Doc: "the RFs"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/actrf.Heatmaps", IDName: "heatmaps", Doc: "Heatmaps accumulates spatial maps of activity, e.g., from a spatial\nattention layer, across trials for each condition, into normalized\nheatmaps that sum to 1, paralleling the fixation density heatmaps\nfrom human eye-tracking studies. The activity is projected onto 2D\nusing the standard 2D projection of 4D tensors (as in the NetView),\noptionally resampled to a given size (e.g., of the input image),\nand smoothed with a Gaussian. Call Reset to restart the accumulation,\nAdd on each trial, and Norm to compute the Maps.", Fields: []types.Field{{Name: "Name", Doc: "Name of the heatmaps."}, {Name: "Ny", Doc: "Ny, Nx are the sizes of the heatmaps, e.g., that of the input image,\nto which the activity maps are resampled (bilinear) if > 0.\nOtherwise the size of the activity projection is used."}, {Name: "Nx", Doc: "Ny, Nx are the sizes of the heatmaps, e.g., that of the input image,\nto which the activity maps are resampled (bilinear) if > 0.\nOtherwise the size of the activity projection is used."}, {Name: "Sigma", Doc: "Sigma is the standard deviation of the Gaussian smoothing applied\nto the heatmaps in Norm, in heatmap units, with no smoothing if 0."}, {Name: "Thr", Doc: "Thr is the threshold on activity below which values are not added."}, {Name: "Conditions", Doc: "Conditions are the conditions, in the order first added."}, {Name: "N", Doc: "N is the number of trials added for each condition."}, {Name: "Sums", Doc: "Sums are the accumulated sums of the activity maps, per condition."}, {Name: "Maps", Doc: "Maps are the normalized heatmaps per condition, computed by Norm."}, {Name: "act", Doc: "act is the projected activity map."}, {Name: "rs", Doc: "rs is the resampled activity map."}}})
@@ -0,0 +1,142 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assets
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// Assets are the asset files of a sim (pattern tables, param files,
+// default weights), typically embedded in its binary via go:embed,
+// so that the sim runs as a single binary. Assets is itself an [fs.FS],
+// in which the files in the Override directory, if set, take precedence
+// over the embedded files, so that exported assets can be modified and
+// used without rebuilding.
+type Assets struct {
+
+	// FS is the file system with the assets, typically an embed.FS.
+	FS fs.FS
+
+	// Override is a directory whose files take precedence over
+	// those in FS, if set, typically the directory that the assets
+	// were exported to.
+	Override string
+}
+
+// New returns new [Assets] for given file system, typically an
+// embed.FS. If the files are embedded from a subdirectory, e.g.,
+// with "//go:embed assets/*", then the dir is that subdirectory,
+// so that the file names are relative to it, and otherwise it is "".
+func New(fsys fs.FS, dir string) (*Assets, error) {
+	if dir != "" && dir != "." {
+		sub, err := fs.Sub(fsys, dir)
+		if err != nil {
+			return nil, err
+		}
+		fsys = sub
+	}
+	return &Assets{FS: fsys}, nil
+}
+
+// Open opens the named file, from the Override directory if set
+// and it has the file, and otherwise from FS, implementing [fs.FS].
+func (as *Assets) Open(name string) (fs.File, error) {
+	if as.Override != "" {
+		f, err := os.DirFS(as.Override).Open(name)
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return as.FS.Open(name)
+}
+
+// ReadFile returns the contents of the named file, such as a param file,
+// from the Override directory if set and it has the file, and otherwise
+// from FS, implementing [fs.ReadFileFS].
+func (as *Assets) ReadFile(name string) ([]byte, error) {
+	if as.Override != "" {
+		b, err := fs.ReadFile(os.DirFS(as.Override), name)
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return b, err
+		}
+	}
+	return fs.ReadFile(as.FS, name)
+}
+
+// Files returns the names of all of the asset files, in lexical order.
+// The files in the Override directory are not included.
+func (as *Assets) Files() ([]string, error) {
+	var files []string
+	err := fs.WalkDir(as.FS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// OpenTable opens the named table file (e.g., patterns) into given table,
+// detecting tab or comma delimiters.
+func (as *Assets) OpenTable(dt *table.Table, name string) error {
+	return dt.OpenFS(as, name, tensor.Detect)
+}
+
+// OpenWeights opens the named weights file into given network,
+// which is gzip uncompressed if it has the .gz extension.
+// Any algorithm-specific updates after loading weights must be
+// done after this.
+func (as *Assets) OpenWeights(net emer.Network, name string) error {
+	return net.AsEmer().OpenWeightsFS(as, name)
+}
+
+// Export writes all of the asset files to given directory, creating
+// it and any subdirectories as needed, and returns the names of the
+// files written. Existing files are only overwritten if overwrite is
+// true, and otherwise are skipped, so that modified files are kept.
+func (as *Assets) Export(dir string, overwrite bool) ([]string, error) {
+	files, err := as.Files()
+	if err != nil {
+		return nil, err
+	}
+	var written []string
+	var errs []error
+	for _, fn := range files {
+		path := filepath.Join(dir, filepath.FromSlash(fn))
+		if !overwrite {
+			if _, err := os.Stat(path); err == nil {
+				continue
+			}
+		}
+		b, err := fs.ReadFile(as.FS, fn)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := os.WriteFile(path, b, 0644); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		written = append(written, fn)
+	}
+	return written, errors.Join(errs...)
+}
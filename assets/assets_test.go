@@ -0,0 +1,85 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"cogentcore.org/lab/table"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"sim/patterns/train.tsv": {Data: []byte("$Name\t%Input[2:0,0]<2:1,2>\t%Input[2:0,1]\nA\t1\t0\nB\t0\t1\n")},
+		"sim/params.toml":        {Data: []byte("LRate = 0.04\n")},
+		"sim/other.txt":          {Data: []byte("not an asset")},
+	}
+}
+
+func TestAssets(t *testing.T) {
+	as, err := New(testFS(), "sim")
+	assert.NoError(t, err)
+	files, err := as.Files()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"other.txt", "params.toml", "patterns/train.tsv"}, files)
+
+	dt := table.New()
+	assert.NoError(t, as.OpenTable(dt, "patterns/train.tsv"))
+	assert.Equal(t, 2, dt.NumRows())
+
+	dir := t.TempDir()
+	written, err := as.Export(dir, false)
+	assert.NoError(t, err)
+	assert.Equal(t, files, written)
+	b, err := os.ReadFile(filepath.Join(dir, "patterns", "train.tsv"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "$Name")
+
+	// modified exported files are kept, and take precedence
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "params.toml"), []byte("LRate = 0.1\n"), 0644))
+	written, err = as.Export(dir, false)
+	assert.NoError(t, err)
+	assert.Empty(t, written)
+	b, err = as.ReadFile("params.toml")
+	assert.NoError(t, err)
+	assert.Equal(t, "LRate = 0.04\n", string(b))
+	as.Override = dir
+	b, err = as.ReadFile("params.toml")
+	assert.NoError(t, err)
+	assert.Equal(t, "LRate = 0.1\n", string(b))
+}
+
+func TestExportArgs(t *testing.T) {
+	as, err := New(testFS(), "sim")
+	assert.NoError(t, err)
+	dir, files, err := as.ExportArgs([]string{"-epochs", "10"})
+	assert.NoError(t, err)
+	assert.Equal(t, "", dir)
+	assert.Nil(t, files)
+
+	out := t.TempDir()
+	dir, files, err = as.ExportArgs([]string{"-epochs", "10", "-export-assets", out})
+	assert.NoError(t, err)
+	assert.Equal(t, out, dir)
+	assert.Len(t, files, 3)
+
+	out2 := filepath.Join(t.TempDir(), "sub")
+	dir, files, err = as.ExportArgs([]string{"--export-assets=" + out2})
+	assert.NoError(t, err)
+	assert.Equal(t, out2, dir)
+	assert.Len(t, files, 3)
+	_, err = os.Stat(filepath.Join(out2, "patterns", "train.tsv"))
+	assert.NoError(t, err)
+
+	cfg := &Config{AssetsDir: out2}
+	exported, _, err := as.Apply(cfg)
+	assert.NoError(t, err)
+	assert.False(t, exported)
+	assert.Equal(t, out2, as.Override)
+}
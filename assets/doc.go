@@ -0,0 +1,15 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package assets supports the distribution of runnable models as a single
+binary, by embedding the pattern tables, param files and default weights
+of a sim in the binary via go:embed, and loading them from there, with a
+standard -export-assets flag that writes them out to a directory, where
+collaborators can inspect and modify them, and an override directory
+whose files take precedence over the embedded ones.
+*/
+package assets
+
+//go:generate core generate -add-types
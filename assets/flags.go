@@ -0,0 +1,71 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package assets
+
+import (
+	"strings"
+)
+
+// ExportFlag is the name of the standard flag for exporting the
+// assets of a sim to a directory: -export-assets <dir>.
+const ExportFlag = "export-assets"
+
+// Config has the standard asset options, to embed in the config of a sim,
+// for which the command line flags are -export-assets and -assets-dir.
+type Config struct {
+
+	// ExportAssets is a directory to write the embedded assets to,
+	// after which the sim exits, if set.
+	ExportAssets string
+
+	// AssetsDir is a directory with asset files that take precedence over
+	// the embedded assets, typically modified exported assets, if set.
+	AssetsDir string
+}
+
+// Apply applies the given config: the AssetsDir is set as the Override
+// directory, and if ExportAssets is set, the assets are exported to it,
+// without overwriting existing files, returning the names of the files
+// written. exported is true if the assets were exported, in which
+// case the sim should exit.
+func (as *Assets) Apply(cfg *Config) (exported bool, files []string, err error) {
+	if cfg.AssetsDir != "" {
+		as.Override = cfg.AssetsDir
+	}
+	if cfg.ExportAssets == "" {
+		return false, nil, nil
+	}
+	files, err = as.Export(cfg.ExportAssets, false)
+	return true, files, err
+}
+
+// ExportArgs exports the assets as in [Assets.Apply], if the
+// -export-assets flag is in given command line arguments
+// (e.g., os.Args[1:]), as -export-assets <dir> or -export-assets=<dir>,
+// with one or two dashes. This can be called at the start of main,
+// before the config of the sim is parsed, for sims that do not
+// embed the [Config]. The returned dir is the export directory,
+// which is "" if the flag was not given.
+func (as *Assets) ExportArgs(args []string) (dir string, files []string, err error) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if name == arg {
+			continue
+		}
+		if v, ok := strings.CutPrefix(name, ExportFlag+"="); ok {
+			dir = v
+		} else if name == ExportFlag && i+1 < len(args) {
+			dir = args[i+1]
+		}
+		if dir != "" {
+			_, files, err = as.Apply(&Config{ExportAssets: dir})
+			return dir, files, err
+		}
+	}
+	return "", nil, nil
+}
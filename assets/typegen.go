@@ -0,0 +1,11 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package assets
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/assets.Assets", IDName: "assets", Doc: "Assets are the asset files of a sim (pattern tables, param files,\ndefault weights), typically embedded in its binary via go:embed,\nso that the sim runs as a single binary. Assets is itself an [fs.FS],\nin which the files in the Override directory, if set, take precedence\nover the embedded files, so that exported assets can be modified and\nused without rebuilding.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Fields: []types.Field{{Name: "FS", Doc: "FS is the file system with the assets, typically an embed.FS."}, {Name: "Override", Doc: "Override is a directory whose files take precedence over\nthose in FS, if set, typically the directory that the assets\nwere exported to."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/assets.Config", IDName: "config", Doc: "Config has the standard asset options, to embed in the config of a sim,\nfor which the command line flags are -export-assets and -assets-dir.", Fields: []types.Field{{Name: "ExportAssets", Doc: "ExportAssets is a directory to write the embedded assets to,\nafter which the sim exits, if set."}, {Name: "AssetsDir", Doc: "AssetsDir is a directory with asset files that take precedence over\nthe embedded assets, typically modified exported assets, if set."}}})
@@ -0,0 +1,315 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package attractor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// SettleFunc seeds the network with given initial states of the seed
+// layers (by layer name, with the layer shapes), for given condition,
+// and lets it settle without learning, so that the states of the layers
+// are stable. How the initial states are applied (e.g., as initial
+// activities, or as a transient external input) is up to the model.
+type SettleFunc func(cond string, init map[string]tensor.Values) error
+
+// Inits are the ways of generating the initial states.
+type Inits int32 //enums:enum
+
+const (
+	// Random initial states have each unit active (1) with probability
+	// Activity, and inactive (0) otherwise.
+	Random Inits = iota
+
+	// Perturbed initial states are the base states of the condition,
+	// with each unit flipped (1 - value, for values in 0..1) with
+	// probability Noise.
+	Perturbed
+)
+
+// Condition is a condition for which the attractors are mapped,
+// e.g., with a given context input, or around a given stored pattern.
+type Condition struct {
+
+	// Name of the condition, passed to the SettleFunc.
+	Name string
+
+	// Init is how the initial states are generated.
+	Init Inits
+
+	// Base are the base states of the seed layers for Perturbed
+	// initial states, by layer name.
+	Base map[string]tensor.Values
+}
+
+// Basin is an attractor found for a condition, with the size of its basin.
+type Basin struct {
+
+	// State is the stable state of the attractor: the state of the layers
+	// for the first initial state that settled into it, concatenated in
+	// the order of the Layers.
+	State []float32
+
+	// Size is the number of initial states that settled into the attractor.
+	Size int
+}
+
+// Mapper maps the attractor basins of a network for each of its
+// Conditions, by seeding the network with NSeeds initial states per
+// condition, settling it with the Settle function, and clustering the
+// resulting stable states of the Layers: a state is in the same
+// attractor as a previous one if their cosine similarity is at least
+// Thr, and otherwise it is a new attractor.
+type Mapper struct {
+
+	// Net is the network.
+	Net emer.Network `display:"-"`
+
+	// Layers are the layers whose stable states define the attractors.
+	Layers []string
+
+	// SeedLayers are the layers that are seeded with initial states,
+	// which are the Layers if empty.
+	SeedLayers []string
+
+	// Var is the unit variable for the states of the Layers.
+	Var string `default:"Act"`
+
+	// NSeeds is the number of initial states per condition.
+	NSeeds int `default:"100"`
+
+	// Activity is the probability of each unit being active
+	// in Random initial states.
+	Activity float32 `default:"0.2"`
+
+	// Noise is the probability of flipping each unit
+	// in Perturbed initial states.
+	Noise float32 `default:"0.1"`
+
+	// Thr is the minimum cosine similarity of two stable states
+	// for them to be in the same attractor.
+	Thr float64 `default:"0.9"`
+
+	// Seed is the random seed for generating the initial states.
+	Seed int64 `default:"1"`
+
+	// Settle seeds and settles the network.
+	Settle SettleFunc `display:"-"`
+
+	// Conditions are the conditions.
+	Conditions []Condition
+
+	// Basins are the attractors found for each condition by the last Map,
+	// in order of decreasing basin size, by condition name.
+	Basins map[string][]Basin `display:"-"`
+
+	rand *randx.SysRand
+}
+
+// NewMapper returns a new [Mapper] for given network, layers whose states
+// define the attractors, and settle function, with default parameters.
+func NewMapper(net emer.Network, settle SettleFunc, layers ...string) *Mapper {
+	return &Mapper{Net: net, Layers: layers, Var: "Act", NSeeds: 100, Activity: 0.2, Noise: 0.1, Thr: 0.9, Seed: 1, Settle: settle}
+}
+
+// AddRandom adds a condition with Random initial states.
+// Returns the Mapper so calls can be chained.
+func (mp *Mapper) AddRandom(name string) *Mapper {
+	mp.Conditions = append(mp.Conditions, Condition{Name: name, Init: Random})
+	return mp
+}
+
+// AddPerturbed adds a condition with Perturbed initial states
+// around given base states of the seed layers, by layer name.
+// Returns the Mapper so calls can be chained.
+func (mp *Mapper) AddPerturbed(name string, base map[string]tensor.Values) *Mapper {
+	mp.Conditions = append(mp.Conditions, Condition{Name: name, Init: Perturbed, Base: base})
+	return mp
+}
+
+// seedLayers returns the layers that are seeded.
+func (mp *Mapper) seedLayers() []string {
+	if len(mp.SeedLayers) > 0 {
+		return mp.SeedLayers
+	}
+	return mp.Layers
+}
+
+// initState returns a new initial state for given condition.
+func (mp *Mapper) initState(cd *Condition) (map[string]tensor.Values, error) {
+	init := make(map[string]tensor.Values)
+	for _, lnm := range mp.seedLayers() {
+		ly, err := mp.Net.AsEmer().EmerLayerByName(lnm)
+		if err != nil {
+			return nil, err
+		}
+		st := tensor.NewFloat32(ly.AsEmer().Shape.Sizes...)
+		switch cd.Init {
+		case Random:
+			for i := range st.Values {
+				if mp.rand.Float32() < mp.Activity {
+					st.Values[i] = 1
+				}
+			}
+		case Perturbed:
+			base, ok := cd.Base[lnm]
+			if !ok || base.Len() != st.Len() {
+				return nil, fmt.Errorf("attractor.Map: condition %s has no base state with %d values for layer %s", cd.Name, st.Len(), lnm)
+			}
+			for i := range st.Values {
+				v := float32(base.Float1D(i))
+				if mp.rand.Float32() < mp.Noise {
+					v = 1 - v
+				}
+				st.Values[i] = v
+			}
+		}
+		init[lnm] = st
+	}
+	return init, nil
+}
+
+// state returns the current state of the Layers, concatenated.
+func (mp *Mapper) state() ([]float32, error) {
+	var st, vals []float32
+	for _, lnm := range mp.Layers {
+		ly, err := mp.Net.AsEmer().EmerLayerByName(lnm)
+		if err != nil {
+			return nil, err
+		}
+		if err := ly.AsEmer().UnitValues(&vals, mp.Var, 0); err != nil {
+			return nil, err
+		}
+		st = append(st, vals...)
+	}
+	return st, nil
+}
+
+// Map maps the attractors for each condition, into Basins.
+func (mp *Mapper) Map() error {
+	if mp.Settle == nil {
+		return errors.New("attractor.Map: Settle function is not set")
+	}
+	mp.rand = randx.NewSysRand(mp.Seed)
+	mp.Basins = make(map[string][]Basin)
+	for ci := range mp.Conditions {
+		cd := &mp.Conditions[ci]
+		var basins []Basin
+		for range mp.NSeeds {
+			init, err := mp.initState(cd)
+			if err != nil {
+				return err
+			}
+			if err := mp.Settle(cd.Name, init); err != nil {
+				return err
+			}
+			st, err := mp.state()
+			if err != nil {
+				return err
+			}
+			best, bsim := -1, mp.Thr
+			for bi := range basins {
+				if sim := Similarity(st, basins[bi].State); sim >= bsim {
+					best, bsim = bi, sim
+				}
+			}
+			if best < 0 {
+				basins = append(basins, Basin{State: st})
+				best = len(basins) - 1
+			}
+			basins[best].Size++
+		}
+		slices.SortStableFunc(basins, func(a, b Basin) int { return b.Size - a.Size })
+		mp.Basins[cd.Name] = basins
+	}
+	return nil
+}
+
+// Similarity returns the cosine similarity of given states,
+// where two states without any activity are the same (1),
+// and a state without activity is dissimilar (0) to any other.
+func Similarity(a, b []float32) float64 {
+	var ab, aa, bb float64
+	for i, av := range a {
+		bv := float64(b[i])
+		ab += float64(av) * bv
+		aa += float64(av) * float64(av)
+		bb += bv * bv
+	}
+	if aa == 0 && bb == 0 {
+		return 1
+	}
+	if aa == 0 || bb == 0 {
+		return 0
+	}
+	return ab / math.Sqrt(aa*bb)
+}
+
+// BasinsTable returns a table of the Basins from the last Map,
+// with one row per attractor of each condition, with columns Cond,
+// Basin (index in order of decreasing size), Size, and Frac (the
+// fraction of the initial states that settled into it).
+func (mp *Mapper) BasinsTable() *table.Table {
+	dt := table.New()
+	dt.AddStringColumn("Cond")
+	dt.AddIntColumn("Basin")
+	dt.AddIntColumn("Size")
+	dt.AddFloat64Column("Frac")
+	for _, cd := range mp.Conditions {
+		for bi, b := range mp.Basins[cd.Name] {
+			row := dt.NumRows()
+			dt.SetNumRows(row + 1)
+			dt.Column("Cond").SetString1D(cd.Name, row)
+			dt.Column("Basin").SetFloat1D(float64(bi), row)
+			dt.Column("Size").SetFloat1D(float64(b.Size), row)
+			dt.Column("Frac").SetFloat1D(float64(b.Size)/float64(max(mp.NSeeds, 1)), row)
+		}
+	}
+	return dt
+}
+
+// SummaryTable returns a table summarizing the Basins from the last Map,
+// with one row per condition, with columns Cond, NBasins (the number of
+// attractors), Largest (the fraction of the initial states in the largest
+// basin), Entropy (of the distribution of basin sizes, in bits), and
+// Effective (the effective number of basins: 2^Entropy).
+func (mp *Mapper) SummaryTable() *table.Table {
+	dt := table.New()
+	dt.AddStringColumn("Cond")
+	dt.AddIntColumn("NBasins")
+	dt.AddFloat64Column("Largest")
+	dt.AddFloat64Column("Entropy")
+	dt.AddFloat64Column("Effective")
+	dt.SetNumRows(len(mp.Conditions))
+	for ci, cd := range mp.Conditions {
+		basins := mp.Basins[cd.Name]
+		n := 0
+		for _, b := range basins {
+			n += b.Size
+		}
+		var largest, ent float64
+		for _, b := range basins {
+			p := float64(b.Size) / float64(n)
+			largest = max(largest, p)
+			if p > 0 {
+				ent -= p * math.Log2(p)
+			}
+		}
+		dt.Column("Cond").SetString1D(cd.Name, ci)
+		dt.Column("NBasins").SetFloat1D(float64(len(basins)), ci)
+		dt.Column("Largest").SetFloat1D(largest, ci)
+		dt.Column("Entropy").SetFloat1D(ent, ci)
+		dt.Column("Effective").SetFloat1D(math.Pow(2, ent), ci)
+	}
+	return dt
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package attractor
+
+import (
+	"testing"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/hebb"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+// testMapper returns a Mapper for a hebb network with an Input
+// layer seeded with the initial states, and a single winner Hidden layer,
+// which has a distinct attractor for each winning unit.
+func testMapper(t *testing.T) *Mapper {
+	net := hebb.NewNetwork("Test")
+	in := net.AddLayer2D("Input", 1, 4, hebb.InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 4, hebb.HiddenLayer)
+	net.ConnectLayers(in, hid, paths.NewOneToOne())
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+	net.InitWeights()
+	settle := func(cond string, init map[string]tensor.Values) error {
+		net.InitActs()
+		if err := net.ApplyExt("Input", init["Input"]); err != nil {
+			return err
+		}
+		net.Cycle(ctx)
+		return nil
+	}
+	mp := NewMapper(net, settle, "Hidden")
+	mp.SeedLayers = []string{"Input"}
+	mp.NSeeds = 50
+	mp.Activity = 0.5
+	return mp
+}
+
+func TestMapper(t *testing.T) {
+	mp := testMapper(t)
+	mp.AddRandom("Random")
+	mp.AddPerturbed("Pat2", map[string]tensor.Values{"Input": tensor.NewFloat32FromValues(0, 0, 1, 0)})
+	assert.NoError(t, mp.Map())
+
+	rnd := mp.Basins["Random"]
+	assert.Greater(t, len(rnd), 1)
+	assert.LessOrEqual(t, len(rnd), 4)
+	n := 0
+	for bi, b := range rnd {
+		n += b.Size
+		if bi > 0 {
+			assert.LessOrEqual(t, b.Size, rnd[bi-1].Size)
+		}
+	}
+	assert.Equal(t, 50, n)
+
+	pat := mp.Basins["Pat2"]
+	assert.Equal(t, []float32{0, 0, 1, 0}, pat[0].State)
+	assert.Greater(t, pat[0].Size, 25)
+
+	bt := mp.BasinsTable()
+	assert.Equal(t, len(rnd)+len(pat), bt.NumRows())
+	assert.Equal(t, "Random", bt.Column("Cond").String1D(0))
+	assert.InDelta(t, float64(rnd[0].Size)/50, bt.Column("Frac").Float1D(0), 1.0e-9)
+
+	st := mp.SummaryTable()
+	assert.Equal(t, 2, st.NumRows())
+	assert.Equal(t, float64(len(rnd)), st.Column("NBasins").Float1D(0))
+	assert.Greater(t, st.Column("Entropy").Float1D(0), st.Column("Entropy").Float1D(1))
+
+	// the same seed gives the same basins
+	sizes := []int{}
+	for _, b := range rnd {
+		sizes = append(sizes, b.Size)
+	}
+	assert.NoError(t, mp.Map())
+	for bi, b := range mp.Basins["Random"] {
+		assert.Equal(t, sizes[bi], b.Size)
+	}
+
+	mp.Noise = 0
+	assert.NoError(t, mp.Map())
+	assert.Equal(t, 1, len(mp.Basins["Pat2"]))
+	assert.Equal(t, 1.0, mp.SummaryTable().Column("Largest").Float1D(1))
+}
+
+func TestMapperErrors(t *testing.T) {
+	mp := testMapper(t)
+	mp.AddPerturbed("NoBase", nil)
+	assert.Error(t, mp.Map())
+	mp.Settle = nil
+	assert.Error(t, mp.Map())
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity([]float32{0, 0}, []float32{0, 0}))
+	assert.Equal(t, 0.0, Similarity([]float32{0, 0}, []float32{1, 0}))
+	assert.InDelta(t, 1.0, Similarity([]float32{2, 0}, []float32{1, 0}), 1.0e-9)
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package attractor maps the attractor basins of recurrent networks, for
+characterizing their memory capacity: the network is seeded with many
+random or perturbed initial states, settled without learning, and the
+resulting stable states are clustered into attractors, reporting the
+number and size of the basins for each condition.
+*/
+package attractor
+
+//go:generate core generate -add-types
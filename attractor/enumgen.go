@@ -0,0 +1,46 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package attractor
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _InitsValues = []Inits{0, 1}
+
+// InitsN is the highest valid value for type Inits, plus one.
+const InitsN Inits = 2
+
+var _InitsValueMap = map[string]Inits{`Random`: 0, `Perturbed`: 1}
+
+var _InitsDescMap = map[Inits]string{0: `Random initial states have each unit active (1) with probability Activity, and inactive (0) otherwise.`, 1: `Perturbed initial states are the base states of the condition, with each unit flipped (1 - value, for values in 0..1) with probability Noise.`}
+
+var _InitsMap = map[Inits]string{0: `Random`, 1: `Perturbed`}
+
+// String returns the string representation of this Inits value.
+func (i Inits) String() string { return enums.String(i, _InitsMap) }
+
+// SetString sets the Inits value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Inits) SetString(s string) error { return enums.SetString(i, s, _InitsValueMap, "Inits") }
+
+// Int64 returns the Inits value as an int64.
+func (i Inits) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Inits value from an int64.
+func (i *Inits) SetInt64(in int64) { *i = Inits(in) }
+
+// Desc returns the description of the Inits value.
+func (i Inits) Desc() string { return enums.Desc(i, _InitsDescMap) }
+
+// InitsValues returns all possible values for the type Inits.
+func InitsValues() []Inits { return _InitsValues }
+
+// Values returns all possible values for the type Inits.
+func (i Inits) Values() []enums.Enum { return enums.Values(_InitsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Inits) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Inits) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Inits") }
@@ -0,0 +1,17 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package attractor
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/attractor.SettleFunc", IDName: "settle-func", Doc: "SettleFunc seeds the network with given initial states of the seed\nlayers (by layer name, with the layer shapes), for given condition,\nand lets it settle without learning, so that the states of the layers\nare stable. How the initial states are applied (e.g., as initial\nactivities, or as a transient external input) is up to the model.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/attractor.Inits", IDName: "inits", Doc: "Inits are the ways of generating the initial states."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/attractor.Condition", IDName: "condition", Doc: "Condition is a condition for which the attractors are mapped,\ne.g., with a given context input, or around a given stored pattern.", Fields: []types.Field{{Name: "Name", Doc: "Name of the condition, passed to the SettleFunc."}, {Name: "Init", Doc: "Init is how the initial states are generated."}, {Name: "Base", Doc: "Base are the base states of the seed layers for Perturbed\ninitial states, by layer name."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/attractor.Basin", IDName: "basin", Doc: "Basin is an attractor found for a condition, with the size of its basin.", Fields: []types.Field{{Name: "State", Doc: "State is the stable state of the attractor: the state of the layers\nfor the first initial state that settled into it, concatenated in\nthe order of the Layers."}, {Name: "Size", Doc: "Size is the number of initial states that settled into the attractor."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/attractor.Mapper", IDName: "mapper", Doc: "Mapper maps the attractor basins of a network for each of its\nConditions, by seeding the network with NSeeds initial states per\ncondition, settling it with the Settle function, and clustering the\nresulting stable states of the Layers: a state is in the same\nattractor as a previous one if their cosine similarity is at least\nThr, and otherwise it is a new attractor.", Fields: []types.Field{{Name: "Net", Doc: "Net is the network."}, {Name: "Layers", Doc: "Layers are the layers whose stable states define the attractors."}, {Name: "SeedLayers", Doc: "SeedLayers are the layers that are seeded with initial states,\nwhich are the Layers if empty."}, {Name: "Var", Doc: "Var is the unit variable for the states of the Layers."}, {Name: "NSeeds", Doc: "NSeeds is the number of initial states per condition."}, {Name: "Activity", Doc: "Activity is the probability of each unit being active\nin Random initial states."}, {Name: "Noise", Doc: "Noise is the probability of flipping each unit\nin Perturbed initial states."}, {Name: "Thr", Doc: "Thr is the minimum cosine similarity of two stable states\nfor them to be in the same attractor."}, {Name: "Seed", Doc: "Seed is the random seed for generating the initial states."}, {Name: "Settle", Doc: "Settle seeds and settles the network."}, {Name: "Conditions", Doc: "Conditions are the conditions."}, {Name: "Basins", Doc: "Basins are the attractors found for each condition by the last Map,\nin order of decreasing basin size, by condition name."}, {Name: "rand"}}})
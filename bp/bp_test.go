@@ -0,0 +1,570 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bp
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"path/filepath"
+	"slices"
+	"testing"
+	"unsafe"
+
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/emer/emergent/v2/weights"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXOR(t *testing.T) {
+	net := NewNetwork("XOR")
+	in := net.AddLayer2D("Input", 1, 2, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 4, HiddenLayer)
+	out := net.AddLayer2D("Output", 1, 1, TargetLayer)
+	net.ConnectLayers(in, hid, paths.NewFull(), ForwardPath)
+	net.ConnectLayers(hid, out, paths.NewFull(), ForwardPath)
+	net.SetRandSeed(1)
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+
+	ins := [][]float32{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+	outs := []float32{0, 1, 1, 0}
+	trial := func(i int, learn bool) float64 {
+		net.InitSeq()
+		net.ApplyExt("Input", tensor.NewFloat32FromValues(ins[i]...))
+		net.ApplyExt("Output", tensor.NewFloat32FromValues(outs[i]))
+		net.Forward(ctx)
+		sse := net.SSE(0)
+		if learn {
+			net.Learn(ctx)
+		}
+		return sse
+	}
+	for range 5000 {
+		for i := range ins {
+			trial(i, true)
+		}
+	}
+	for i := range ins {
+		assert.Less(t, trial(i, false), 0.01)
+		assert.Equal(t, outs[i] > 0.5, out.Act[0] > 0.5)
+	}
+	// no learning when testing, but the history is reset
+	wts := slices.Clone(net.Paths[0].Wts)
+	ctx.Testing = true
+	trial(0, true)
+	assert.Equal(t, wts, net.Paths[0].Wts)
+	assert.Len(t, out.hist, 0)
+}
+
+func TestBuildErrors(t *testing.T) {
+	net := NewNetwork("Err")
+	in := net.AddLayer2D("Input", 1, 2, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 2, HiddenLayer)
+	net.ConnectLayers(hid, hid, paths.NewFull(), ForwardPath)
+	assert.ErrorContains(t, net.Build(), "use a RecurrentPath")
+	net.Paths[0].Type = RecurrentPath
+	assert.NoError(t, net.Build())
+	net.ConnectLayers(hid, in, paths.NewFull(), RecurrentPath)
+	assert.ErrorContains(t, net.Build(), "cannot receive")
+}
+
+// newSRN returns a simple recurrent network with a recurrent hidden layer.
+func newSRN(t *testing.T) *Network {
+	net := NewNetwork("SRN")
+	in := net.AddLayer2D("Input", 1, 2, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 4, HiddenLayer)
+	out := net.AddLayer2D("Output", 1, 2, TargetLayer)
+	net.ConnectLayers(in, hid, paths.NewFull(), ForwardPath)
+	net.ConnectLayers(hid, hid, paths.NewFull(), RecurrentPath)
+	net.ConnectLayers(hid, out, paths.NewFull(), ForwardPath)
+	net.ConnectLayers(out, hid, paths.NewFull(), RecurrentPath)
+	net.SetRandSeed(2)
+	assert.NoError(t, net.Build())
+	return net
+}
+
+// TestBPTTGradient checks the weight changes of backpropagation
+// through time against the numerical gradient of the error.
+func TestBPTTGradient(t *testing.T) {
+	net := newSRN(t)
+	ctx := net.NewContext()
+	ins := [][]float32{{1, 0}, {0, 1}, {1, 1}, {0, 0}}
+	outs := [][]float32{{0, 1}, {1, 1}, {1, 0}, {0, 0}}
+	out := net.Layers[2]
+	loss := func() float64 {
+		net.InitSeq()
+		l := 0.0
+		for i := range ins {
+			net.ApplyExt("Input", tensor.NewFloat32FromValues(ins[i]...))
+			net.ApplyExt("Output", tensor.NewFloat32FromValues(outs[i]...))
+			net.Forward(ctx)
+			l += 0.5 * out.SSE(0)
+		}
+		return l
+	}
+	loss()
+	net.Backward(ctx)
+	eps := float32(1e-2)
+	check := func(w *float32, dw float32) {
+		orig := *w
+		*w = orig + eps
+		lp := loss()
+		*w = orig - eps
+		lm := loss()
+		*w = orig
+		grad := -(lp - lm) / float64(2*eps)
+		assert.InDelta(t, grad, dw, 1e-3)
+	}
+	for _, pt := range net.Paths {
+		for syi := range pt.Wts {
+			check(&pt.Wts[syi], pt.DWts[syi])
+		}
+	}
+	for _, ly := range net.Layers[1:] {
+		for i := range ly.Bias {
+			check(&ly.Bias[i], ly.DBias[i])
+		}
+	}
+}
+
+// TestBPTT learns to output the input from the previous time step,
+// which requires the recurrent context.
+func TestBPTT(t *testing.T) {
+	net := newSRN(t)
+	ctx := net.NewContext()
+	seqs := [][]int{{0, 1, 1, 0, 0}, {1, 0, 0, 1, 1}, {1, 1, 0, 1, 0}, {0, 0, 1, 0, 1}}
+	pat := func(b int) *tensor.Float32 {
+		return tensor.NewFloat32FromValues(float32(b), float32(1-b))
+	}
+	run := func(seq []int, learn bool) float64 {
+		net.InitSeq()
+		sse := 0.0
+		for i, b := range seq {
+			net.ApplyExt("Input", pat(b))
+			if i == 0 {
+				net.ApplyExt("Output", tensor.NewFloat32FromValues(0.5, 0.5))
+			} else {
+				net.ApplyExt("Output", pat(seq[i-1]))
+			}
+			net.Forward(ctx)
+			if i > 0 {
+				sse += net.SSE(0.5)
+			}
+		}
+		if learn {
+			net.Learn(ctx)
+		}
+		return sse
+	}
+	for range 500 {
+		for _, seq := range seqs {
+			run(seq, true)
+		}
+	}
+	for _, seq := range seqs {
+		assert.Zero(t, run(seq, false))
+	}
+}
+
+// TestArenas checks that allocating the state in arenas
+// gives identical results.
+func TestArenas(t *testing.T) {
+	train := func(arenas bool) *Network {
+		net := newSRN(t)
+		net.UseArenas = arenas
+		assert.NoError(t, net.Build())
+		ctx := net.NewContext()
+		for i := range 20 {
+			net.InitSeq()
+			for j := range 3 {
+				b := float32((i + j) % 2)
+				net.ApplyExt("Input", tensor.NewFloat32FromValues(b, 1-b))
+				net.ApplyExt("Output", tensor.NewFloat32FromValues(1-b, b))
+				net.Forward(ctx)
+			}
+			net.Learn(ctx)
+		}
+		return net
+	}
+	net, anet := train(false), train(true)
+	for i, pt := range anet.Paths {
+		assert.Equal(t, net.Paths[i].RecvConIndex, pt.RecvConIndex)
+		assert.Equal(t, net.Paths[i].Wts, pt.Wts)
+		assert.Equal(t, len(pt.Wts), cap(pt.Wts))
+	}
+	for i, ly := range anet.Layers {
+		assert.Equal(t, net.Layers[i].Act, ly.Act)
+		assert.Equal(t, net.Layers[i].Bias, ly.Bias)
+		assert.Equal(t, len(ly.Act), cap(ly.Act))
+	}
+	// the synapse state is allocated contiguously
+	pt := anet.Paths[0]
+	addr := func(v *float32) uintptr { return uintptr(unsafe.Pointer(v)) }
+	assert.Equal(t, addr(&pt.Wts[0])+uintptr(4*len(pt.Wts)), addr(&pt.DWts[0]))
+}
+
+func TestWeights(t *testing.T) {
+	en, err := emer.NewNetwork("bp", "Weights")
+	assert.NoError(t, err)
+	net := en.(*Network)
+	alg, _ := emer.AlgorithmByName("bp")
+	in, _ := alg.AddLayer(net, "Input", []int{2, 2}, "InputLayer")
+	hid, _ := alg.AddLayer(net, "Hidden", []int{2, 2}, "HiddenLayer")
+	alg.AddLayer(net, "Output", []int{1, 2}, "TargetLayer")
+	alg.ConnectLayers(net, in, hid, paths.NewFull(), "ForwardPath")
+	rp, err := alg.ConnectLayers(net, hid, hid, paths.NewFull(), "RecurrentPath")
+	assert.NoError(t, err)
+	assert.Equal(t, "RecurrentPath", rp.TypeName())
+	assert.NoError(t, alg.Build(net))
+	for _, ly := range net.Layers {
+		for i := range ly.Bias {
+			ly.Bias[i] = float32(i) * 0.1
+		}
+	}
+	orig := append([]float32{}, rp.(*Path).Wts...)
+	var b bytes.Buffer
+	assert.NoError(t, net.WriteWeightsJSON(&b))
+	net.SetRandSeed(10)
+	net.InitWeights()
+	assert.NotEqual(t, orig, rp.(*Path).Wts)
+	assert.NoError(t, net.ReadWeightsJSON(&b))
+	assert.Equal(t, orig, rp.(*Path).Wts)
+	assert.Equal(t, []float32{0, 0.1, 0.2, 0.3}, net.Layers[1].Bias)
+	// empty input leaves the weights as they are
+	assert.NoError(t, net.ReadWeightsJSON(&b))
+	assert.NoError(t, rp.AsEmer().ReadWeightsJSON(&b))
+	assert.Equal(t, orig, rp.(*Path).Wts)
+
+	tsr, err := rp.AsEmer().SynVarByName("Wt")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 4}, tsr.Shape().Sizes)
+	assert.Equal(t, orig[rp.SynIndex(2, 1)], tsr.Value(1, 2))
+	tsr, err = rp.AsEmer().SynVarByName("DWt")
+	assert.NoError(t, err)
+	assert.Equal(t, float32(0), tsr.Value(3, 0))
+}
+
+func TestZeroAllocs(t *testing.T) {
+	net := newSRN(t)
+	ctx := net.NewContext()
+	in, out := []float32{1, 0}, []float32{0, 1}
+	trial := func() {
+		net.NewTrial(ctx)
+		for range 3 {
+			net.ApplyInput("Input", in)
+			net.ApplyInput("Output", out)
+			net.Forward(ctx)
+		}
+		net.Learn(ctx)
+	}
+	trial()
+	assert.Zero(t, testing.AllocsPerRun(10, trial))
+
+	net.Allocs.On = true
+	trial()
+	ci := slices.IndexFunc(net.Allocs.Counts, func(ac emer.AllocCount) bool { return ac.Func == "Forward" })
+	if assert.GreaterOrEqual(t, ci, 0) {
+		assert.Equal(t, 3, net.Allocs.Counts[ci].Calls)
+	}
+	assert.Nil(t, net.Allocs.EndTrial())
+}
+
+func TestResize(t *testing.T) {
+	net := newSRN(t)
+	ctx := net.NewContext()
+	hid := net.Layers[1]
+	for i := range hid.Bias {
+		hid.Bias[i] = float32(i + 1)
+	}
+	fw, rc := net.Paths[0], net.Paths[1] // Input -> Hidden, Hidden -> Hidden
+	w0, w1 := fw.SynValue("Wt", 1, 3), rc.SynValue("Wt", 3, 2)
+
+	assert.NoError(t, net.ResizeLayer("Hidden", 2, 4))
+	assert.Equal(t, []float32{1, 2, 3, 4, 0, 0, 0, 0}, hid.Bias)
+	assert.Len(t, rc.Wts, 8*7) // no self connections
+	assert.Equal(t, w0, fw.SynValue("Wt", 1, 3))
+	assert.Equal(t, w1, rc.SynValue("Wt", 3, 2))
+	assert.Len(t, net.Paths[2].Wts, 16)
+
+	aux := net.AddLayer2D("Aux", 1, 3, TargetLayer)
+	net.ConnectLayers(hid, aux, paths.NewFull(), ForwardPath)
+	assert.NoError(t, net.BuildNew())
+	assert.Equal(t, w0, fw.SynValue("Wt", 1, 3))
+	assert.Len(t, aux.Bias, 3)
+	for range 2 {
+		net.ApplyInput("Input", []float32{1, 0})
+		net.ApplyInput("Output", []float32{0, 1})
+		net.ApplyInput("Aux", []float32{0, 1, 0})
+		net.Forward(ctx)
+	}
+	net.Learn(ctx)
+	assert.NotZero(t, aux.Bias[1])
+
+	net.ConnectLayers(aux, net.Layers[0], paths.NewFull(), RecurrentPath)
+	assert.ErrorContains(t, net.BuildNew(), "cannot receive")
+}
+
+func TestResizeUnitGroups(t *testing.T) {
+	net := NewNetwork("Groups")
+	in := net.AddLayer2D("Input", 1, 2, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 8, HiddenLayer)
+	net.ConnectLayers(in, hid, paths.NewFull(), ForwardPath)
+	assert.NoError(t, net.Build())
+	assert.NoError(t, hid.AddUnitGroup("G", 6, 7))
+	assert.NoError(t, hid.AddUnitGroup("H", 1, 6))
+
+	assert.NoError(t, net.ResizeLayer("Hidden", 1, 4))
+	idxs, err := hid.UnitGroup("G")
+	assert.NoError(t, err)
+	assert.Empty(t, idxs)
+	mask, err := hid.UnitGroupMask("G")
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{0, 0, 0, 0}, mask.Values)
+
+	assert.NoError(t, net.ResizeLayer("Hidden", 2, 4))
+	idxs, err = hid.UnitGroup("H")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, idxs)
+	avg, err := hid.UnitGroupAvg("H", "Bias", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, hid.Bias[1], avg)
+}
+
+// assertTied checks that the weights of the synapses at each position
+// of the shared kernel are all the same.
+func assertTied(t *testing.T, pt *Path) {
+	kw := make(map[int32]float32)
+	for syi, ki := range pt.shared.Index {
+		if w, ok := kw[ki]; ok {
+			assert.Equal(t, w, pt.Wts[syi])
+		} else {
+			kw[ki] = pt.Wts[syi]
+		}
+	}
+	assert.Less(t, len(kw), len(pt.Wts))
+}
+
+func TestSharedWeights(t *testing.T) {
+	net := NewNetwork("Shared")
+	in := net.AddLayer("Input", []int{4, 4, 2, 2}, InputLayer)
+	hid := net.AddLayer("Hidden", []int{2, 2, 1, 2}, HiddenLayer)
+	net.AddLayer2D("Output", 1, 2, TargetLayer)
+	tile := paths.NewPoolTile()
+	tile.Size.Set(2, 2)
+	tile.Skip.Set(2, 2)
+	tile.Start.Set(0, 0)
+	tile.Wrap = false
+	tile.Shared = true
+	pt := net.ConnectLayers(in, hid, tile, ForwardPath)
+	net.ConnectLayers(hid, net.Layers[2], paths.NewFull(), ForwardPath)
+	assert.NoError(t, net.Build())
+	assert.NotNil(t, pt.shared)
+	assert.Nil(t, net.Paths[1].shared)
+	assertTied(t, pt)
+
+	ctx := net.NewContext()
+	inp := tensor.NewFloat32(4, 4, 2, 2)
+	for i := range inp.Values {
+		inp.Values[i] = float32(i%3) / 2
+	}
+	wts := slices.Clone(pt.Wts)
+	for range 5 {
+		net.InitSeq()
+		net.ApplyExt("Input", inp)
+		net.ApplyExt("Output", tensor.NewFloat32FromValues(1, 0))
+		net.Forward(ctx)
+		net.Learn(ctx)
+	}
+	assert.NotEqual(t, wts, pt.Wts)
+	assertTied(t, pt)
+
+	tile.Recip = true
+	assert.ErrorIs(t, net.Build(), paths.ErrRecipShared)
+}
+
+func TestVarAliases(t *testing.T) {
+	net := NewNetwork("Aliases")
+	in := net.AddLayer2D("Input", 1, 2, InputLayer)
+	out := net.AddLayer2D("Output", 1, 1, TargetLayer)
+	pt := net.ConnectLayers(in, out, paths.NewFull(), ForwardPath)
+	assert.NoError(t, net.Build())
+
+	emer.AddVarAliases("bp", map[string]string{"Ge": "Net"}, map[string]string{"LWt": "Wt"})
+	emer.AddVarAliases("other", map[string]string{"Vm": "Act"}, nil)
+	defer func() {
+		delete(emer.AlgoVarAliases, "bp")
+		delete(emer.AlgoVarAliases, "other")
+	}()
+
+	assert.Equal(t, "bp", emer.AlgorithmOf(out))
+	assert.Equal(t, "bp", emer.AlgorithmOf(pt))
+	vi, err := emer.UnitVarIndex(out, "Ge")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, vi)
+	vi, err = emer.UnitVarIndex(out, "Act")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, vi)
+	vi, err = emer.SynVarIndex(pt, "LWt")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, vi)
+
+	// aliases of other algorithms are not used
+	_, err = emer.UnitVarIndex(out, "Vm")
+	assert.Error(t, err)
+}
+
+func TestSnapshots(t *testing.T) {
+	for _, dir := range []string{"", t.TempDir()} {
+		net := NewNetwork("Snapshots")
+		in := net.AddLayer2D("Input", 1, 2, InputLayer)
+		out := net.AddLayer2D("Output", 1, 2, TargetLayer)
+		pt := net.ConnectLayers(in, out, paths.NewFull(), ForwardPath)
+		assert.NoError(t, net.Build())
+		assert.NoError(t, net.SnapshotWeights("off")) // Max = 0
+		assert.Equal(t, 0, net.NumSnapshots())
+
+		net.Snapshots.Max = 2
+		net.Snapshots.Dir = dir
+		var wts [][]float32
+		for i := range 3 {
+			net.SetRandSeed(int64(i + 1))
+			net.InitWeights()
+			wts = append(wts, slices.Clone(pt.Wts))
+			assert.NoError(t, net.SnapshotWeights(fmt.Sprintf("Epoch %d", i)))
+		}
+		assert.Equal(t, 2, net.NumSnapshots()) // oldest dropped
+
+		net.InitWeights()
+		label, err := net.RollbackWeights(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "Epoch 2", label)
+		assert.Equal(t, wts[2], pt.Wts)
+		assert.Equal(t, 2, net.NumSnapshots())
+
+		label, err = net.RollbackWeights(1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Epoch 1", label)
+		assert.Equal(t, wts[1], pt.Wts)
+		assert.Equal(t, 1, net.NumSnapshots()) // more recent discarded
+
+		_, err = net.RollbackWeights(1)
+		assert.Error(t, err)
+		assert.Equal(t, wts[1], pt.Wts)
+		if dir == "" {
+			continue
+		}
+
+		// a snapshot that cannot be written is not added,
+		// and does not replace the oldest one
+		assert.NoError(t, net.SnapshotWeights("Epoch 3"))
+		net.Snapshots.Dir = filepath.Join(dir, "missing")
+		assert.Error(t, net.SnapshotWeights("Epoch 4"))
+		assert.Equal(t, 2, net.NumSnapshots())
+		label, err = net.RollbackWeights(1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Epoch 1", label)
+		assert.Equal(t, wts[1], pt.Wts)
+	}
+}
+
+func TestInferTable(t *testing.T) {
+	net := NewNetwork("Infer")
+	in := net.AddLayer2D("Input", 1, 2, InputLayer)
+	out := net.AddLayer2D("Output", 1, 1, TargetLayer)
+	net.ConnectLayers(in, out, paths.NewFull(), ForwardPath)
+	net.SetRandSeed(1)
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+
+	ins := [][]float32{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+	inputs := table.New()
+	inputs.AddStringColumn("Name")
+	inputs.AddFloat32Column("Input", 2)
+	inputs.SetNumRows(len(ins))
+	for ri, pat := range ins {
+		inputs.Column("Name").SetStringRow(fmt.Sprint(pat), ri, 0)
+		for ci, v := range pat {
+			inputs.Column("Input").SetFloatRow(float64(v), ri, ci)
+		}
+	}
+	bi := emer.NewBatchInfer("Output")
+	_, err := net.InferTable(inputs, bi)
+	assert.Error(t, err) // ApplyInputs and RunTrial not set
+
+	ntrials := 0
+	bi.ApplyInputs = func(inputs *table.Table, row, di int) {
+		assert.Equal(t, 0, di)
+		net.InitSeq()
+		net.ApplyExt("Input", tensor.NewFloat32FromValues(ins[row]...))
+	}
+	bi.RunTrial = func(ndata int) {
+		assert.Equal(t, 1, ndata)
+		ntrials++
+		net.Forward(ctx)
+	}
+	bi.NData = 4 // limited to MaxParallelData
+	dt, err := net.InferTable(inputs, bi)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, ntrials)
+	assert.Equal(t, 4, dt.NumRows())
+	assert.Equal(t, []int{4, 1, 1}, dt.Column("Output").ShapeSizes())
+	for ri, pat := range ins {
+		assert.Equal(t, fmt.Sprint(pat), dt.Column("Name").StringRow(ri, 0))
+		net.InitSeq()
+		net.ApplyExt("Input", tensor.NewFloat32FromValues(pat...))
+		net.Forward(ctx)
+		assert.Equal(t, float64(out.Act[0]), dt.Column("Output").FloatRow(ri, 0))
+	}
+
+	bi.Outputs = []string{"Missing"}
+	_, err = net.InferTable(inputs, bi)
+	assert.Error(t, err)
+	bi.Outputs = []string{"Output"}
+	bi.Var = "Missing"
+	_, err = net.InferTable(inputs, bi)
+	assert.Error(t, err)
+}
+
+func TestWeightsRemapGrow(t *testing.T) {
+	newNet := func(nhid int) *Network {
+		net := NewNetwork("Remap")
+		in := net.AddLayer2D("Input", 1, 2, InputLayer)
+		hid := net.AddLayer2D("Hidden", 1, nhid, HiddenLayer)
+		net.ConnectLayers(in, hid, paths.NewFull(), ForwardPath)
+		net.SetRandSeed(1)
+		assert.NoError(t, net.Build())
+		return net
+	}
+	small := newNet(4)
+	for i := range small.Layers[1].Bias {
+		small.Layers[1].Bias[i] = 0.3
+	}
+	var b bytes.Buffer
+	assert.NoError(t, small.WriteWeightsJSON(&b))
+	nw, err := weights.NetReadJSON(&b)
+	assert.NoError(t, err)
+
+	big := newNet(8)
+	hid := big.Layers[1]
+	for i := range hid.Bias {
+		hid.Bias[i] = -0.1
+	}
+	rm := &weights.Remap{Method: weights.Truncate, Shapes: map[string][]int{"Hidden": {1, 4}}}
+	_, err = big.SetWeightsRemap(nw, rm)
+	assert.NoError(t, err)
+	// new units keep their initial values
+	assert.Equal(t, []float32{0.3, 0.3, 0.3, 0.3, -0.1, -0.1, -0.1, -0.1}, hid.Bias)
+
+	big.InitSeq()
+	big.ApplyExt("Input", tensor.NewFloat32FromValues(1, 0))
+	big.Forward(big.NewContext())
+	for i, act := range hid.Act {
+		assert.False(t, math.IsNaN(float64(act)), "unit %d", i)
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package bp is a reference implementation of standard feedforward error
+backpropagation, and of simple recurrent networks trained with
+backpropagation through time (BPTT), on the emer infrastructure,
+so that direct comparisons with other algorithms (e.g., leabra) on
+identical tasks are possible within one framework, using the same
+NetView, logging, and looper code. It is registered as the "bp" algorithm.
+*/
+package bp
+
+//go:generate core generate -add-types
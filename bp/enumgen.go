@@ -0,0 +1,93 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package bp
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _LayerTypesValues = []LayerTypes{0, 1, 2}
+
+// LayerTypesN is the highest valid value for type LayerTypes, plus one.
+const LayerTypesN LayerTypes = 3
+
+var _LayerTypesValueMap = map[string]LayerTypes{`InputLayer`: 0, `HiddenLayer`: 1, `TargetLayer`: 2}
+
+var _LayerTypesDescMap = map[LayerTypes]string{0: `InputLayer has its activity clamped to the external input applied with ApplyExt.`, 1: `HiddenLayer computes its activity from its receiving pathways, and learns from the error backpropagated from the layers it sends to.`, 2: `TargetLayer computes its activity from its receiving pathways, and learns from the difference between its activity and the target applied with ApplyExt, in addition to any backpropagated error.`}
+
+var _LayerTypesMap = map[LayerTypes]string{0: `InputLayer`, 1: `HiddenLayer`, 2: `TargetLayer`}
+
+// String returns the string representation of this LayerTypes value.
+func (i LayerTypes) String() string { return enums.String(i, _LayerTypesMap) }
+
+// SetString sets the LayerTypes value from its string representation,
+// and returns an error if the string is invalid.
+func (i *LayerTypes) SetString(s string) error {
+	return enums.SetString(i, s, _LayerTypesValueMap, "LayerTypes")
+}
+
+// Int64 returns the LayerTypes value as an int64.
+func (i LayerTypes) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the LayerTypes value from an int64.
+func (i *LayerTypes) SetInt64(in int64) { *i = LayerTypes(in) }
+
+// Desc returns the description of the LayerTypes value.
+func (i LayerTypes) Desc() string { return enums.Desc(i, _LayerTypesDescMap) }
+
+// LayerTypesValues returns all possible values for the type LayerTypes.
+func LayerTypesValues() []LayerTypes { return _LayerTypesValues }
+
+// Values returns all possible values for the type LayerTypes.
+func (i LayerTypes) Values() []enums.Enum { return enums.Values(_LayerTypesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i LayerTypes) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *LayerTypes) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "LayerTypes")
+}
+
+var _PathTypesValues = []PathTypes{0, 1}
+
+// PathTypesN is the highest valid value for type PathTypes, plus one.
+const PathTypesN PathTypes = 2
+
+var _PathTypesValueMap = map[string]PathTypes{`ForwardPath`: 0, `RecurrentPath`: 1}
+
+var _PathTypesDescMap = map[PathTypes]string{0: `ForwardPath sends the activity of the sending layer on the same time step, and must go from an earlier to a later layer.`, 1: `RecurrentPath sends the activity of the sending layer on the previous time step, as in a simple recurrent network, and its error is backpropagated through time.`}
+
+var _PathTypesMap = map[PathTypes]string{0: `ForwardPath`, 1: `RecurrentPath`}
+
+// String returns the string representation of this PathTypes value.
+func (i PathTypes) String() string { return enums.String(i, _PathTypesMap) }
+
+// SetString sets the PathTypes value from its string representation,
+// and returns an error if the string is invalid.
+func (i *PathTypes) SetString(s string) error {
+	return enums.SetString(i, s, _PathTypesValueMap, "PathTypes")
+}
+
+// Int64 returns the PathTypes value as an int64.
+func (i PathTypes) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the PathTypes value from an int64.
+func (i *PathTypes) SetInt64(in int64) { *i = PathTypes(in) }
+
+// Desc returns the description of the PathTypes value.
+func (i PathTypes) Desc() string { return enums.Desc(i, _PathTypesDescMap) }
+
+// PathTypesValues returns all possible values for the type PathTypes.
+func PathTypesValues() []PathTypes { return _PathTypesValues }
+
+// Values returns all possible values for the type PathTypes.
+func (i PathTypes) Values() []enums.Enum { return enums.Values(_PathTypesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i PathTypes) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *PathTypes) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "PathTypes")
+}
@@ -0,0 +1,468 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bp
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/weights"
+)
+
+// LayerTypes are the types of layers.
+type LayerTypes int32 //enums:enum
+
+const (
+	// InputLayer has its activity clamped to the external input
+	// applied with ApplyExt.
+	InputLayer LayerTypes = iota
+
+	// HiddenLayer computes its activity from its receiving pathways,
+	// and learns from the error backpropagated from the layers it sends to.
+	HiddenLayer
+
+	// TargetLayer computes its activity from its receiving pathways,
+	// and learns from the difference between its activity and the target
+	// applied with ApplyExt, in addition to any backpropagated error.
+	TargetLayer
+)
+
+// Params are the learning parameters of a layer, which apply to its
+// bias weights and the weights of its receiving pathways.
+type Params struct {
+
+	// Lrate is the learning rate.
+	Lrate float32 `default:"0.1"`
+
+	// Momentum is the proportion of the previous weight change
+	// that is added to the current one.
+	Momentum float32 `default:"0.9"`
+}
+
+func (lp *Params) Defaults() {
+	lp.Lrate = 0.1
+	lp.Momentum = 0.9
+}
+
+// step is the state of a layer on one time step of a sequence,
+// retained for backpropagation through time.
+type step struct {
+	act, ext []float32
+}
+
+// Layer is a layer of units with logistic sigmoid activation.
+type Layer struct {
+	emer.LayerBase
+
+	// Type is the type of layer.
+	Type LayerTypes
+
+	// Params are the learning parameters.
+	Params Params
+
+	// Network is the network this layer belongs to.
+	Network *Network `display:"-"`
+
+	// RecvPaths are the receiving pathways into this layer.
+	RecvPaths []*Path `display:"-"`
+
+	// SendPaths are the sending pathways from this layer.
+	SendPaths []*Path `display:"-"`
+
+	// Act is the activity of each unit on the current time step.
+	Act []float32 `display:"-"`
+
+	// Net is the net input of each unit on the current time step.
+	Net []float32 `display:"-"`
+
+	// Ext is the external input (input layers) or target (target layers)
+	// of each unit.
+	Ext []float32 `display:"-"`
+
+	// Err is the error derivative (delta) of each unit,
+	// from the last call to Backward, on the last time step.
+	Err []float32 `display:"-"`
+
+	// Bias is the bias weight of each unit.
+	Bias []float32 `display:"-"`
+
+	// DBias is the accumulated bias weight change, from Backward.
+	DBias []float32 `display:"-"`
+
+	// prevDBias is the previous bias weight change, for momentum.
+	prevDBias []float32
+
+	// hist has the state on each time step since the last Learn or InitSeq.
+	// The steps beyond its length are kept for reuse, so that Forward
+	// does not allocate in steady state.
+	hist []step
+
+	// ctxt is the activity prior to the first time step in hist,
+	// which is the input to recurrent pathways on the first step.
+	ctxt []float32
+
+	// errs are the deltas for the current and next time steps in Backward.
+	errs, nextErrs []float32
+}
+
+// UnitVars are the unit variables.
+var UnitVars = []string{"Act", "Net", "Ext", "Err", "Bias"}
+
+// UnitVarProps are the properties of the UnitVars.
+var UnitVarProps = map[string]string{
+	"Act":  `min:"0" max:"1" desc:"logistic sigmoid activity"`,
+	"Net":  `range:"5" desc:"net input: bias plus weighted sum of sending activity"`,
+	"Ext":  `min:"0" max:"1" desc:"external input or target"`,
+	"Err":  `zeroctr:"+" range:"0.25" desc:"error derivative (delta) backpropagated to the unit"`,
+	"Bias": `range:"2" desc:"bias weight"`,
+}
+
+func (ly *Layer) Defaults() {
+	ly.Params.Defaults()
+}
+
+func (ly *Layer) TypeName() string { return ly.Type.String() }
+func (ly *Layer) TypeNumber() int  { return int(ly.Type) }
+
+func (ly *Layer) NumRecvPaths() int          { return len(ly.RecvPaths) }
+func (ly *Layer) RecvPath(idx int) emer.Path { return ly.RecvPaths[idx] }
+func (ly *Layer) NumSendPaths() int          { return len(ly.SendPaths) }
+func (ly *Layer) SendPath(idx int) emer.Path { return ly.SendPaths[idx] }
+
+// build allocates the unit state, from the given arena if non-nil.
+func (ly *Layer) build(f32 *emer.Arena[float32]) {
+	nu := ly.NumUnits()
+	ly.Act = f32.Alloc(nu)
+	ly.Net = f32.Alloc(nu)
+	ly.Ext = f32.Alloc(nu)
+	ly.Err = f32.Alloc(nu)
+	ly.Bias = f32.Alloc(nu)
+	ly.DBias = f32.Alloc(nu)
+	ly.prevDBias = f32.Alloc(nu)
+	ly.ctxt = f32.Alloc(nu)
+	ly.errs = f32.Alloc(nu)
+	ly.nextErrs = f32.Alloc(nu)
+}
+
+// InitWeights initializes the bias weights and weight changes to 0.
+func (ly *Layer) InitWeights() {
+	clear(ly.Bias)
+	clear(ly.DBias)
+	clear(ly.prevDBias)
+}
+
+// InitSeq initializes the activity state and the time step history,
+// for the start of a new sequence.
+func (ly *Layer) InitSeq() {
+	clear(ly.Act)
+	clear(ly.Net)
+	clear(ly.Err)
+	clear(ly.ctxt)
+	ly.hist = ly.hist[:0]
+}
+
+// InitExt initializes the external input to 0.
+func (ly *Layer) InitExt() {
+	clear(ly.Ext)
+}
+
+// ApplyExt applies the values of given tensor as the external input,
+// or target, in 1D order, which must have the same number of values as units.
+func (ly *Layer) ApplyExt(ext tensor.Tensor) error {
+	if err := ly.checkExt(ext.Len()); err != nil {
+		return err
+	}
+	for i := range ly.Ext {
+		ly.Ext[i] = float32(ext.Float1D(i))
+	}
+	return nil
+}
+
+// ApplyValues applies given values as the external input or target,
+// in 1D order, which must have the same number of values as units.
+func (ly *Layer) ApplyValues(vals []float32) error {
+	if err := ly.checkExt(len(vals)); err != nil {
+		return err
+	}
+	copy(ly.Ext, vals)
+	return nil
+}
+
+// checkExt returns an error if given number of external
+// input values is not the number of units.
+func (ly *Layer) checkExt(n int) error {
+	if n != len(ly.Ext) {
+		return fmt.Errorf("bp.ApplyExt: layer %s has %d units but input has %d values", ly.Name, len(ly.Ext), n)
+	}
+	return nil
+}
+
+// prevAct returns the activity on the time step before given one,
+// which is the context activity for the first time step in hist.
+func (ly *Layer) prevAct(t int) []float32 {
+	if t == 0 {
+		return ly.ctxt
+	}
+	return ly.hist[t-1].act
+}
+
+// Forward computes the activity of the layer for a new time step:
+// the external input for input layers, and the logistic sigmoid of
+// the net input otherwise, and records it in the time step history.
+func (ly *Layer) Forward() {
+	t := len(ly.hist)
+	if ly.Type == InputLayer {
+		copy(ly.Act, ly.Ext)
+	} else {
+		copy(ly.Net, ly.Bias)
+		for _, pt := range ly.RecvPaths {
+			if pt.isOff() {
+				continue
+			}
+			sact := pt.Send.Act
+			if pt.Type == RecurrentPath {
+				sact = pt.Send.prevAct(t)
+			}
+			pt.sendNet(sact)
+		}
+		for i, net := range ly.Net {
+			ly.Act[i] = 1 / (1 + float32(math.Exp(float64(-net))))
+		}
+	}
+	if t < cap(ly.hist) {
+		ly.hist = ly.hist[:t+1]
+	} else {
+		ly.hist = append(ly.hist, step{})
+	}
+	st := &ly.hist[t]
+	st.act = append(st.act[:0], ly.Act...)
+	st.ext = append(st.ext[:0], ly.Ext...)
+}
+
+// backward computes the deltas for given time step, from the target
+// and the deltas of the layers this layer sends to, on this time step
+// (forward pathways) and the next time step (recurrent pathways),
+// with the deltas of the next time step in nextErrs.
+func (ly *Layer) backward(t int) {
+	if ly.Type == InputLayer {
+		return
+	}
+	st := &ly.hist[t]
+	clear(ly.errs)
+	if ly.Type == TargetLayer {
+		for i, a := range st.act {
+			ly.errs[i] = st.ext[i] - a
+		}
+	}
+	for _, pt := range ly.SendPaths {
+		if pt.isOff() || pt.Recv.Type == InputLayer {
+			continue
+		}
+		rerrs := pt.Recv.errs
+		if pt.Type == RecurrentPath {
+			if t == len(ly.hist)-1 {
+				continue
+			}
+			rerrs = pt.Recv.nextErrs
+		}
+		pt.sendErr(rerrs, ly.errs)
+	}
+	for i, a := range st.act {
+		ly.errs[i] *= a * (1 - a)
+	}
+}
+
+// accumDWt accumulates the weight changes of the bias and receiving
+// pathways from the deltas on given time step.
+func (ly *Layer) accumDWt(t int) {
+	if ly.Type == InputLayer {
+		return
+	}
+	for i, e := range ly.errs {
+		ly.DBias[i] += e
+	}
+	for _, pt := range ly.RecvPaths {
+		if pt.isOff() {
+			continue
+		}
+		sact := pt.Send.hist[t].act
+		if pt.Type == RecurrentPath {
+			sact = pt.Send.prevAct(t)
+		}
+		pt.accumDWt(ly.errs, sact)
+	}
+}
+
+// UpdateWeights updates the bias weights and the weights of the receiving
+// pathways from the accumulated weight changes, with momentum,
+// and resets the accumulated weight changes.
+func (ly *Layer) UpdateWeights() {
+	if ly.Type == InputLayer {
+		return
+	}
+	lr, mom := ly.Params.Lrate, ly.Params.Momentum
+	for i, db := range ly.DBias {
+		dw := lr*db + mom*ly.prevDBias[i]
+		ly.Bias[i] += dw
+		ly.prevDBias[i] = dw
+	}
+	clear(ly.DBias)
+	for _, pt := range ly.RecvPaths {
+		if pt.isOff() {
+			continue
+		}
+		pt.updateWeights(lr, mom)
+	}
+}
+
+// SSE returns the sum squared error of the current activity relative to
+// the target, over units with an error greater than given tolerance
+// (e.g., 0.5 to count only errors on the wrong side of 0.5).
+func (ly *Layer) SSE(tol float32) float64 {
+	sse := 0.0
+	for i, a := range ly.Act {
+		d := ly.Ext[i] - a
+		if d < 0 {
+			d = -d
+		}
+		if d > tol {
+			sse += float64(d * d)
+		}
+	}
+	return sse
+}
+
+func (ly *Layer) UnitVarIndex(varNm string) (int, error) {
+	if i := slices.Index(UnitVars, varNm); i >= 0 {
+		return i, nil
+	}
+	return -1, fmt.Errorf("bp: unit variable named %q not found", varNm)
+}
+
+func (ly *Layer) UnitValue1D(varIndex int, idx, di int) float32 {
+	if idx < 0 || idx >= len(ly.Act) {
+		return float32(math.NaN())
+	}
+	switch varIndex {
+	case 0:
+		return ly.Act[idx]
+	case 1:
+		return ly.Net[idx]
+	case 2:
+		return ly.Ext[idx]
+	case 3:
+		return ly.Err[idx]
+	case 4:
+		return ly.Bias[idx]
+	}
+	return float32(math.NaN())
+}
+
+func (ly *Layer) VarRange(varNm string) (min, max float32, err error) {
+	vi, err := ly.UnitVarIndex(varNm)
+	if err != nil {
+		return
+	}
+	for ni := range ly.Act {
+		v := ly.UnitValue1D(vi, ni, 0)
+		if ni == 0 || v < min {
+			min = v
+		}
+		if ni == 0 || v > max {
+			max = v
+		}
+	}
+	return
+}
+
+// pathValues fills in given vals with the value of given synapse variable
+// for each unit in this layer, for the pathway of given type connected with
+// given other layer, and unit index in that layer.
+func (ly *Layer) pathValues(vals *[]float32, varNm string, other emer.Layer, oidx int, pathType string, recv bool) error {
+	nu := ly.NumUnits()
+	if cap(*vals) < nu {
+		*vals = make([]float32, nu)
+	} else {
+		*vals = (*vals)[:nu]
+	}
+	nan := float32(math.NaN())
+	for i := range *vals {
+		(*vals)[i] = nan
+	}
+	pts := ly.SendPaths
+	if recv {
+		pts = ly.RecvPaths
+	}
+	for _, pt := range pts {
+		ol := pt.Recv
+		if recv {
+			ol = pt.Send
+		}
+		if emer.Layer(ol) != other || (pathType != "" && pt.TypeName() != pathType) {
+			continue
+		}
+		vi, err := pt.SynVarIndex(varNm)
+		if err != nil {
+			return err
+		}
+		for ni := range nu {
+			si, ri := oidx, ni
+			if !recv {
+				si, ri = ni, oidx
+			}
+			if syi := pt.SynIndex(si, ri); syi >= 0 {
+				(*vals)[ni] = pt.SynValue1D(vi, syi)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("bp: pathway between layers %s and %s not found", ly.Name, other.Label())
+}
+
+func (ly *Layer) RecvPathValues(vals *[]float32, varNm string, sendLay emer.Layer, sendIndex1D int, pathType string) error {
+	return ly.pathValues(vals, varNm, sendLay, sendIndex1D, pathType, true)
+}
+
+func (ly *Layer) SendPathValues(vals *[]float32, varNm string, recvLay emer.Layer, recvIndex1D int, pathType string) error {
+	return ly.pathValues(vals, varNm, recvLay, recvIndex1D, pathType, false)
+}
+
+func (ly *Layer) NonDefaultParams() string { return "" }
+
+func (ly *Layer) AllParams() string {
+	if ly.Type == InputLayer {
+		return fmt.Sprintf("Layer: %s\tType: %s\n", ly.Name, ly.Type)
+	}
+	return fmt.Sprintf("Layer: %s\tType: %s\tParams: %+v\n", ly.Name, ly.Type, ly.Params)
+}
+
+func (ly *Layer) WriteWeightsJSON(w io.Writer, depth int) {
+	if ly.Type == InputLayer {
+		ly.WriteWeightsJSONBase(w, depth)
+		return
+	}
+	ly.WriteWeightsJSONBase(w, depth, "Bias")
+}
+
+func (ly *Layer) SetWeights(lw *weights.Layer) error {
+	if b, ok := lw.Units["Bias"]; ok {
+		copy(ly.Bias, b)
+	}
+	for pi := range lw.Paths {
+		pw := &lw.Paths[pi]
+		pt, err := ly.RecvPathBySendName(pw.From)
+		if err != nil {
+			return err
+		}
+		if err := pt.SetWeights(pw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
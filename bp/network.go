@@ -0,0 +1,426 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bp
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/hybrid"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/emer/emergent/v2/weights"
+)
+
+func init() {
+	emer.RegisterAlgorithm(&emer.Algorithm{
+		Name: "bp",
+		Doc:  "error backpropagation, with backpropagation through time for simple recurrent networks",
+		NewNetwork: func(name string) emer.Network {
+			return NewNetwork(name)
+		},
+		AddLayer: func(net emer.Network, name string, shape []int, typ string) (emer.Layer, error) {
+			var lt LayerTypes
+			if err := lt.SetString(typ); err != nil {
+				return nil, fmt.Errorf("bp.AddLayer: layer %s: %w", name, err)
+			}
+			return net.(*Network).AddLayer(name, shape, lt), nil
+		},
+		ConnectLayers: func(net emer.Network, send, recv emer.Layer, pat paths.Pattern, typ string) (emer.Path, error) {
+			var pt PathTypes
+			if err := pt.SetString(typ); err != nil {
+				return nil, fmt.Errorf("bp.ConnectLayers: %s to %s: %w", send.Label(), recv.Label(), err)
+			}
+			return net.(*Network).ConnectLayers(send.(*Layer), recv.(*Layer), pat, pt), nil
+		},
+		Build: func(net emer.Network) error {
+			return net.(*Network).Build()
+		},
+	})
+}
+
+// Network is a backpropagation network of layers with logistic sigmoid
+// activation, which are computed in order on each time step, so that
+// ForwardPath pathways must go from earlier to later layers.
+// RecurrentPath pathways send the activity of the previous time step,
+// and their error is backpropagated through all of the time steps since
+// the last Learn (or InitSeq), so that calling Learn at the end of each
+// sequence of Forward steps does backpropagation through time (BPTT),
+// and calling it after every step is a simple recurrent network (SRN)
+// with a copied context. For feedforward networks, call InitSeq, ApplyExt
+// for the input and target layers, Forward, and then Learn, on each trial.
+type Network struct {
+	emer.NetworkBase
+
+	// Layers are the layers, in order of computation.
+	Layers []*Layer
+
+	// Paths are all of the pathways.
+	Paths []*Path `display:"-"`
+}
+
+// NewNetwork returns a new network with given name.
+func NewNetwork(name string) *Network {
+	nt := &Network{}
+	emer.InitNetwork(nt, name)
+	return nt
+}
+
+// AddLayer adds a new layer with given name, shape and type.
+func (nt *Network) AddLayer(name string, shape []int, typ LayerTypes) *Layer {
+	ly := &Layer{Type: typ, Network: nt}
+	emer.InitLayer(ly, name)
+	ly.SetShape(shape...)
+	ly.Index = len(nt.Layers)
+	ly.Defaults()
+	nt.Layers = append(nt.Layers, ly)
+	nt.UpdateLayerNameMap()
+	return ly
+}
+
+// AddLayer2D adds a new 2D layer with given name, shape and type.
+func (nt *Network) AddLayer2D(name string, nY, nX int, typ LayerTypes) *Layer {
+	return nt.AddLayer(name, []int{nY, nX}, typ)
+}
+
+// ConnectLayers adds a new pathway from the send to the recv layer,
+// with given pattern of connectivity and type.
+func (nt *Network) ConnectLayers(send, recv *Layer, pat paths.Pattern, typ PathTypes) *Path {
+	pt := &Path{Send: send, Recv: recv, Type: typ}
+	emer.InitPath(pt)
+	pt.Pattern = pat
+	pt.Name = send.Name + "To" + recv.Name
+	pt.Defaults()
+	send.SendPaths = append(send.SendPaths, pt)
+	recv.RecvPaths = append(recv.RecvPaths, pt)
+	nt.Paths = append(nt.Paths, pt)
+	return pt
+}
+
+// validate returns an error for forward pathways that do not go from an
+// earlier to a later layer, pathways into input layers, and Shared
+// Recip PoolTile pathways.
+func (nt *Network) validate() error {
+	for _, pt := range nt.Paths {
+		if pt.Recv.Type == InputLayer {
+			return fmt.Errorf("bp.Build: pathway %s: input layer %s cannot receive pathways", pt.Name, pt.Recv.Name)
+		}
+		if pt.Type == ForwardPath && pt.Send.Index >= pt.Recv.Index {
+			return fmt.Errorf("bp.Build: forward pathway %s must go from an earlier to a later layer: use a RecurrentPath", pt.Name)
+		}
+		if tile, ok := pt.Pattern.(*paths.PoolTile); ok && tile.Shared && tile.Recip {
+			return fmt.Errorf("bp.Build: pathway %s: %w", pt.Name, paths.ErrRecipShared)
+		}
+	}
+	return nil
+}
+
+// Build allocates the units and synapses, and initializes the weights.
+// It returns an error for forward pathways that do not go from an
+// earlier to a later layer, and pathways into input layers.
+func (nt *Network) Build() error {
+	if err := nt.validate(); err != nil {
+		return err
+	}
+	cons := make([]*tensor.Bool, len(nt.Paths))
+	nsyn := make([]int, len(nt.Paths))
+	for i, pt := range nt.Paths {
+		cons[i], nsyn[i] = pt.connect()
+	}
+	var f32 *emer.Arena[float32]
+	var i32 *emer.Arena[int32]
+	if nt.UseArenas {
+		nf, ni := 0, 0
+		for _, ly := range nt.Layers {
+			nf += 10 * ly.NumUnits() // Act, Net, Ext, Err, Bias, DBias and internal state
+		}
+		for i, pt := range nt.Paths {
+			nf += 3 * nsyn[i]                    // Wts, DWts, prevDWts
+			ni += 2*pt.Recv.NumUnits() + nsyn[i] // RecvConN, RecvConStart, RecvConIndex
+		}
+		f32, i32 = emer.NewArena[float32](nf), emer.NewArena[int32](ni)
+	}
+	for _, ly := range nt.Layers {
+		ly.build(f32)
+	}
+	for i, pt := range nt.Paths {
+		pt.build(cons[i], nsyn[i], f32, i32)
+	}
+	nt.InitWeights()
+	return nil
+}
+
+// BuildNew allocates the units and synapses of the layers and pathways
+// added since the last Build (or BuildNew), with initial random weights,
+// keeping the weights of the existing ones, e.g., to add to a trained
+// network in developmental growth models or interactive architecture
+// editing, and starts a new sequence with InitSeq.
+// The new state is not allocated in arenas.
+func (nt *Network) BuildNew() error {
+	if err := nt.validate(); err != nil {
+		return err
+	}
+	for _, ly := range nt.Layers {
+		if ly.Act == nil {
+			ly.build(nil)
+			ly.InitWeights()
+		}
+	}
+	for _, pt := range nt.Paths {
+		if pt.RecvConN == nil {
+			cons, nsyn := pt.connect()
+			pt.build(cons, nsyn, nil, nil)
+			pt.InitWeights()
+		}
+	}
+	nt.InitSeq()
+	return nil
+}
+
+// ResizeLayer changes the shape of the layer of given name in a built
+// network, e.g., to grow it in developmental growth models, keeping the
+// bias weights and weights of the units at the same topographic
+// coordinates in the old and new shapes (as in [weights.Truncate]),
+// with initial random weights for the synapses of new units, and starts
+// a new sequence with InitSeq. The unit groups of the layer are remapped
+// with [emer.LayerBase.RemapUnitGroups]. Only the layer and its pathways
+// are reallocated, and not in arenas.
+func (nt *Network) ResizeLayer(name string, shape ...int) error {
+	ly, err := nt.LayerByName(name)
+	if err != nil {
+		return err
+	}
+	umap := weights.IndexMap(slices.Clone(ly.Shape.Sizes), shape)
+	ly.RemapUnitGroups(umap)
+	bias := ly.Bias
+	ly.SetShape(shape...)
+	ly.build(nil)
+	ly.InitWeights()
+	for i, oi := range umap {
+		if oi >= 0 {
+			ly.Bias[i] = bias[oi]
+		}
+	}
+	unitMap := func(l *Layer) []int {
+		if l == ly {
+			return umap
+		}
+		return nil
+	}
+	for _, pt := range nt.Paths {
+		if pt.Send != ly && pt.Recv != ly {
+			continue
+		}
+		old := *pt
+		cons, nsyn := pt.connect()
+		pt.build(cons, nsyn, nil, nil)
+		pt.InitWeights()
+		pt.keepWeights(&old, unitMap(pt.Send), unitMap(pt.Recv))
+	}
+	nt.InitSeq()
+	return nil
+}
+
+// InitWeights initializes the weights of all pathways, after resetting
+// the random seed, and the bias weights and activity of all layers.
+func (nt *Network) InitWeights() {
+	nt.ResetRandSeed()
+	for _, pt := range nt.Paths {
+		pt.InitWeights()
+	}
+	for _, ly := range nt.Layers {
+		ly.InitWeights()
+	}
+	nt.InitSeq()
+}
+
+// InitSeq initializes the activity of all layers and the time step
+// history, for the start of a new sequence (or trial for feedforward
+// networks), so that recurrent pathways start from zero activity.
+func (nt *Network) InitSeq() {
+	defer nt.Allocs.Stop("InitSeq", nt.Allocs.Start())
+	for _, ly := range nt.Layers {
+		ly.InitSeq()
+	}
+}
+
+// InitExt initializes the external input of all layers.
+func (nt *Network) InitExt() {
+	for _, ly := range nt.Layers {
+		ly.InitExt()
+	}
+}
+
+// ApplyExt applies given tensor as the external input (input layers)
+// or target (target layers) to the layer of given name.
+func (nt *Network) ApplyExt(layer string, ext tensor.Tensor) error {
+	defer nt.Allocs.Stop("ApplyExt", nt.Allocs.Start())
+	ly, err := nt.LayerByName(layer)
+	if err != nil {
+		return err
+	}
+	return ly.ApplyExt(ext)
+}
+
+// ApplyInput applies given values as the external input or target
+// of the layer of given name, as a [hybrid.Component].
+func (nt *Network) ApplyInput(layer string, vals []float32) error {
+	defer nt.Allocs.Stop("ApplyInput", nt.Allocs.Start())
+	ly, err := nt.LayerByName(layer)
+	if err != nil {
+		return err
+	}
+	return ly.ApplyValues(vals)
+}
+
+// LayerByName returns the layer of given name.
+func (nt *Network) LayerByName(name string) (*Layer, error) {
+	ly, err := nt.EmerLayerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return ly.(*Layer), nil
+}
+
+// Forward computes the activity of all layers, in order, for a new
+// time step, which is added to the history used by Backward,
+// and increments the cycle counters of the context.
+// The history grows until Learn or InitSeq is called.
+func (nt *Network) Forward(ctx *emer.Context) {
+	defer nt.Allocs.Stop("Forward", nt.Allocs.Start())
+	for _, ly := range nt.Layers {
+		if !ly.Off {
+			ly.Forward()
+		}
+	}
+	ctx.CycleInc()
+}
+
+// Backward backpropagates the error on all of the time steps since the
+// last Learn or InitSeq, in reverse order, and accumulates the weight
+// changes, which are the negative gradient of the sum squared error.
+func (nt *Network) Backward(ctx *emer.Context) {
+	defer nt.Allocs.Stop("Backward", nt.Allocs.Start())
+	nsteps := 0
+	for _, ly := range nt.Layers {
+		if !ly.Off {
+			nsteps = len(ly.hist)
+			break
+		}
+	}
+	for t := nsteps - 1; t >= 0; t-- {
+		for li := len(nt.Layers) - 1; li >= 0; li-- {
+			ly := nt.Layers[li]
+			if ly.Off {
+				continue
+			}
+			ly.backward(t)
+			ly.accumDWt(t)
+			if t == nsteps-1 {
+				copy(ly.Err, ly.errs)
+			}
+		}
+		for _, ly := range nt.Layers {
+			if !ly.Off {
+				ly.errs, ly.nextErrs = ly.nextErrs, ly.errs
+			}
+		}
+	}
+}
+
+// UpdateWeights updates the weights from the weight changes
+// accumulated by Backward.
+func (nt *Network) UpdateWeights(ctx *emer.Context) {
+	defer nt.Allocs.Stop("UpdateWeights", nt.Allocs.Start())
+	for _, ly := range nt.Layers {
+		if !ly.Off {
+			ly.UpdateWeights()
+		}
+	}
+}
+
+// NewTrial calls InitSeq, as a [hybrid.Component],
+// so that each trial is a separate sequence.
+func (nt *Network) NewTrial(ctx *emer.Context) { nt.InitSeq() }
+
+// RunPhase runs the Forward pass in the minus phase,
+// as a [hybrid.Component], and does nothing in the plus phase,
+// as the error is computed from the targets by Learn.
+func (nt *Network) RunPhase(ctx *emer.Context) {
+	if !ctx.PlusPhase {
+		nt.Forward(ctx)
+	}
+}
+
+// Learn calls Backward and UpdateWeights, unless ctx.Testing is set,
+// and then starts a new history of time steps, with the current activity
+// as the context for recurrent pathways on the next time step.
+func (nt *Network) Learn(ctx *emer.Context) {
+	defer nt.Allocs.Stop("Learn", nt.Allocs.Start())
+	if !ctx.Testing {
+		nt.Backward(ctx)
+		nt.UpdateWeights(ctx)
+	}
+	for _, ly := range nt.Layers {
+		copy(ly.ctxt, ly.Act)
+		ly.hist = ly.hist[:0]
+	}
+}
+
+// SSE returns the sum squared error over all target layers,
+// for units with an error greater than given tolerance.
+func (nt *Network) SSE(tol float32) float64 {
+	sse := 0.0
+	for _, ly := range nt.Layers {
+		if ly.Type == TargetLayer && !ly.Off {
+			sse += ly.SSE(tol)
+		}
+	}
+	return sse
+}
+
+var _ hybrid.Component = (*Network)(nil)
+
+func (nt *Network) NumLayers() int               { return len(nt.Layers) }
+func (nt *Network) EmerLayer(idx int) emer.Layer { return nt.Layers[idx] }
+func (nt *Network) MaxParallelData() int         { return 1 }
+func (nt *Network) NParallelData() int           { return 1 }
+
+func (nt *Network) Defaults() {
+	for _, ly := range nt.Layers {
+		ly.Defaults()
+	}
+	for _, pt := range nt.Paths {
+		pt.Defaults()
+	}
+}
+
+func (nt *Network) UpdateParams() {}
+
+func (nt *Network) KeyLayerParams() string {
+	var b strings.Builder
+	for _, ly := range nt.Layers {
+		if ly.Type != InputLayer {
+			fmt.Fprintf(&b, "%15s\t Lrate: %g\t Momentum: %g\n", ly.Name, ly.Params.Lrate, ly.Params.Momentum)
+		}
+	}
+	return b.String()
+}
+
+func (nt *Network) KeyPathParams() string {
+	var b strings.Builder
+	for _, pt := range nt.Paths {
+		fmt.Fprintf(&b, "%15s\t Type: %s\t WtInit: Mean: %g\t Var: %g\n", pt.Name, pt.Type, pt.WtInit.Mean, pt.WtInit.Var)
+	}
+	return b.String()
+}
+
+func (nt *Network) UnitVarNames() []string            { return UnitVars }
+func (nt *Network) UnitVarProps() map[string]string   { return UnitVarProps }
+func (nt *Network) VarCategories() []emer.VarCategory { return nil }
+func (nt *Network) SynVarNames() []string             { return SynVars }
+func (nt *Network) SynVarProps() map[string]string    { return SynVarProps }
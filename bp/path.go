@@ -0,0 +1,360 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bp
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+
+	"cogentcore.org/core/base/indent"
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/emer/emergent/v2/weights"
+)
+
+// PathTypes are the types of pathways.
+type PathTypes int32 //enums:enum
+
+const (
+	// ForwardPath sends the activity of the sending layer on the same
+	// time step, and must go from an earlier to a later layer.
+	ForwardPath PathTypes = iota
+
+	// RecurrentPath sends the activity of the sending layer on the
+	// previous time step, as in a simple recurrent network, and its
+	// error is backpropagated through time.
+	RecurrentPath
+)
+
+// Path is a pathway of weights between two layers,
+// stored in receiver-based order.
+type Path struct {
+	emer.PathBase
+
+	// Type is the type of pathway.
+	Type PathTypes
+
+	// Send is the sending layer.
+	Send *Layer `display:"-"`
+
+	// Recv is the receiving layer.
+	Recv *Layer `display:"-"`
+
+	// WtInit are the parameters for the initial random weights.
+	WtInit randx.RandParams
+
+	// RecvConN is the number of connections for each receiving unit.
+	RecvConN []int32 `display:"-"`
+
+	// RecvConStart is the starting synapse index for each receiving unit.
+	RecvConStart []int32 `display:"-"`
+
+	// RecvConIndex is the sending unit index for each synapse.
+	RecvConIndex []int32 `display:"-"`
+
+	// Wts are the weights for each synapse.
+	Wts []float32 `display:"-"`
+
+	// DWts are the accumulated weight changes for each synapse, from Backward.
+	DWts []float32 `display:"-"`
+
+	// prevDWts are the previous weight changes, for momentum.
+	prevDWts []float32
+
+	// shared ties the weights of a Shared PoolTile pathway.
+	shared *paths.SharedSyns
+}
+
+// SynVars are the synapse variables.
+var SynVars = []string{"Wt", "DWt"}
+
+// SynVarProps are the properties of the SynVars.
+var SynVarProps = map[string]string{
+	"Wt":  `range:"2" desc:"synaptic weight"`,
+	"DWt": `auto-scale:"+" desc:"accumulated weight change"`,
+}
+
+func (pt *Path) Defaults() {
+	pt.WtInit.Dist = randx.Uniform
+	pt.WtInit.Mean = 0
+	pt.WtInit.Var = 0.5
+}
+
+func (pt *Path) TypeName() string      { return pt.Type.String() }
+func (pt *Path) TypeNumber() int       { return int(pt.Type) }
+func (pt *Path) SendLayer() emer.Layer { return pt.Send }
+func (pt *Path) RecvLayer() emer.Layer { return pt.Recv }
+func (pt *Path) NumSyns() int          { return len(pt.Wts) }
+func (pt *Path) SynVarNames() []string { return SynVars }
+func (pt *Path) SynVarNum() int        { return len(SynVars) }
+func (pt *Path) AllParams() string     { return fmt.Sprintf("Path: %s\tWtInit: %+v\n", pt.Name, pt.WtInit) }
+
+// isOff returns true if the pathway or either of its layers is Off.
+func (pt *Path) isOff() bool {
+	return pt.Off || pt.Send.Off || pt.Recv.Off
+}
+
+// synRange returns the range of synapse indexes for given receiving unit.
+func (pt *Path) synRange(ri int) (st, ed int) {
+	st = int(pt.RecvConStart[ri])
+	return st, st + int(pt.RecvConN[ri])
+}
+
+// connect returns the connectivity according to the Pattern,
+// and the total number of synapses.
+func (pt *Path) connect() (cons *tensor.Bool, nsyn int) {
+	_, recvn, cons := pt.Pattern.Connect(&pt.Send.Shape, &pt.Recv.Shape, pt.Send == pt.Recv)
+	for i := range recvn.Len() {
+		nsyn += int(recvn.Value1D(i))
+	}
+	return cons, nsyn
+}
+
+// build creates the synapses according to the given connectivity from
+// connect, allocating them from the given arenas if non-nil.
+func (pt *Path) build(cons *tensor.Bool, nsyn int, f32 *emer.Arena[float32], i32 *emer.Arena[int32]) {
+	ns, nr := pt.Send.NumUnits(), pt.Recv.NumUnits()
+	pt.RecvConN = i32.Alloc(nr)
+	pt.RecvConStart = i32.Alloc(nr)
+	pt.RecvConIndex = i32.Alloc(nsyn)
+	n := 0
+	for ri := range nr {
+		pt.RecvConStart[ri] = int32(n)
+		for si := range ns {
+			if cons.Value1D(ri*ns + si) {
+				pt.RecvConIndex[n] = int32(si)
+				n++
+			}
+		}
+		pt.RecvConN[ri] = int32(n) - pt.RecvConStart[ri]
+	}
+	pt.Wts = f32.Alloc(nsyn)
+	pt.DWts = f32.Alloc(nsyn)
+	pt.prevDWts = f32.Alloc(nsyn)
+	pt.shared = paths.NewSharedSyns(pt.Pattern, &pt.Send.Shape, &pt.Recv.Shape, cons)
+}
+
+// InitWeights initializes the weights according to WtInit,
+// using the random number generator of the network,
+// with the same weights at each position of a Shared PoolTile.
+func (pt *Path) InitWeights() {
+	rnd := &pt.Recv.Network.Rand
+	for i := range pt.Wts {
+		pt.Wts[i] = float32(pt.WtInit.Gen(rnd))
+	}
+	if pt.shared != nil {
+		pt.shared.Tie(pt.Wts)
+	}
+	clear(pt.DWts)
+	clear(pt.prevDWts)
+}
+
+// keepWeights copies the weights of given old state of this pathway
+// to the synapses between the same units, given the index of the old unit
+// for each sending and receiving unit (-1 for new units, nil if unchanged).
+func (pt *Path) keepWeights(old *Path, smap, rmap []int) {
+	for ri := range pt.RecvConN {
+		ori := ri
+		if rmap != nil {
+			ori = rmap[ri]
+		}
+		if ori < 0 {
+			continue
+		}
+		st, ed := pt.synRange(ri)
+		for syi := st; syi < ed; syi++ {
+			osi := int(pt.RecvConIndex[syi])
+			if smap != nil {
+				osi = smap[osi]
+			}
+			if osi < 0 {
+				continue
+			}
+			if osyi := old.SynIndex(osi, ori); osyi >= 0 {
+				pt.Wts[syi] = old.Wts[osyi]
+			}
+		}
+	}
+	if pt.shared != nil {
+		pt.shared.Tie(pt.Wts)
+	}
+}
+
+// sendNet adds the weighted sum of given sending activity
+// to the net input of the receiving layer.
+func (pt *Path) sendNet(sact []float32) {
+	net := pt.Recv.Net
+	for ri := range net {
+		st, ed := pt.synRange(ri)
+		sum := float32(0)
+		for syi := st; syi < ed; syi++ {
+			sum += sact[pt.RecvConIndex[syi]] * pt.Wts[syi]
+		}
+		net[ri] += sum
+	}
+}
+
+// sendErr adds the weighted sum of given receiving deltas
+// to given sending errors.
+func (pt *Path) sendErr(rerrs, serrs []float32) {
+	for ri, e := range rerrs {
+		if e == 0 {
+			continue
+		}
+		st, ed := pt.synRange(ri)
+		for syi := st; syi < ed; syi++ {
+			serrs[pt.RecvConIndex[syi]] += e * pt.Wts[syi]
+		}
+	}
+}
+
+// accumDWt accumulates the weight changes from given
+// receiving deltas and sending activity.
+func (pt *Path) accumDWt(rerrs, sact []float32) {
+	for ri, e := range rerrs {
+		if e == 0 {
+			continue
+		}
+		st, ed := pt.synRange(ri)
+		for syi := st; syi < ed; syi++ {
+			pt.DWts[syi] += e * sact[pt.RecvConIndex[syi]]
+		}
+	}
+}
+
+// updateWeights updates the weights from the accumulated weight changes,
+// with momentum, and resets the accumulated weight changes.
+// The weight changes of a Shared PoolTile are summed across positions.
+func (pt *Path) updateWeights(lrate, momentum float32) {
+	if pt.shared != nil {
+		pt.shared.Sum(pt.DWts)
+	}
+	for syi, d := range pt.DWts {
+		dw := lrate*d + momentum*pt.prevDWts[syi]
+		pt.Wts[syi] += dw
+		pt.prevDWts[syi] = dw
+	}
+	clear(pt.DWts)
+}
+
+func (pt *Path) SynIndex(sidx, ridx int) int {
+	if ridx < 0 || ridx >= len(pt.RecvConN) {
+		return -1
+	}
+	st, ed := pt.synRange(ridx)
+	for syi := st; syi < ed; syi++ {
+		if int(pt.RecvConIndex[syi]) == sidx {
+			return syi
+		}
+	}
+	return -1
+}
+
+// SynSendRecv returns the sending and receiving unit indexes of
+// given synapse, implementing [emer.SynSendRecver].
+func (pt *Path) SynSendRecv(synIndex int) (sidx, ridx int) {
+	ridx = sort.Search(len(pt.RecvConN), func(ri int) bool {
+		return int(pt.RecvConStart[ri]+pt.RecvConN[ri]) > synIndex
+	})
+	return int(pt.RecvConIndex[synIndex]), ridx
+}
+
+func (pt *Path) SynVarIndex(varNm string) (int, error) {
+	if i := slices.Index(SynVars, varNm); i >= 0 {
+		return i, nil
+	}
+	return -1, fmt.Errorf("bp: synapse variable named %q not found", varNm)
+}
+
+func (pt *Path) SynValues(vals *[]float32, varNm string) error {
+	vi, err := pt.SynVarIndex(varNm)
+	if err != nil {
+		return err
+	}
+	if vi == 0 {
+		*vals = append((*vals)[:0], pt.Wts...)
+	} else {
+		*vals = append((*vals)[:0], pt.DWts...)
+	}
+	return nil
+}
+
+func (pt *Path) SynValue1D(varIndex int, synIndex int) float32 {
+	if synIndex < 0 || synIndex >= len(pt.Wts) {
+		return float32(math.NaN())
+	}
+	switch varIndex {
+	case 0:
+		return pt.Wts[synIndex]
+	case 1:
+		return pt.DWts[synIndex]
+	}
+	return float32(math.NaN())
+}
+
+func (pt *Path) WriteWeightsJSON(w io.Writer, depth int) {
+	nr := len(pt.RecvConN)
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte("{\n"))
+	depth++
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte(fmt.Sprintf("\"From\": %q,\n", pt.Send.Name)))
+	pt.WriteMetaDataJSON(w, depth)
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte("\"Rs\": [\n"))
+	depth++
+	for ri := range nr {
+		st, ed := pt.synRange(ri)
+		w.Write(indent.TabBytes(depth))
+		w.Write([]byte("{\n"))
+		depth++
+		w.Write(indent.TabBytes(depth))
+		w.Write([]byte(fmt.Sprintf("\"Ri\": %d,\n", ri)))
+		w.Write(indent.TabBytes(depth))
+		w.Write([]byte(fmt.Sprintf("\"N\": %d,\n", ed-st)))
+		w.Write(indent.TabBytes(depth))
+		w.Write([]byte("\"Si\": [ "))
+		for syi := st; syi < ed; syi++ {
+			w.Write([]byte(fmt.Sprintf("%d", pt.RecvConIndex[syi])))
+			if syi < ed-1 {
+				w.Write([]byte(", "))
+			}
+		}
+		w.Write([]byte(" ],\n"))
+		weights.WriteWtJSON(w, depth, pt.Wts[st:ed])
+		depth--
+		w.Write(indent.TabBytes(depth))
+		if ri == nr-1 {
+			w.Write([]byte("}\n"))
+		} else {
+			w.Write([]byte("},\n"))
+		}
+	}
+	depth--
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte("]\n"))
+	depth--
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte("}")) // note: leave unterminated as outer loop needs to add , or just \n depending
+}
+
+func (pt *Path) SetWeights(pw *weights.Path) error {
+	for i := range pw.Rs {
+		rw := &pw.Rs[i]
+		for si, s := range rw.Si {
+			syi := pt.SynIndex(s, rw.Ri)
+			if syi < 0 {
+				return fmt.Errorf("bp.SetWeights: pathway %s has no synapse from sending unit %d to receiving unit %d", pt.Name, s, rw.Ri)
+			}
+			pt.Wts[syi] = rw.Wt[si]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package bp
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/bp.LayerTypes", IDName: "layer-types", Doc: "LayerTypes are the types of layers.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/bp.Params", IDName: "params", Doc: "Params are the learning parameters of a layer, which apply to its\nbias weights and the weights of its receiving pathways.", Fields: []types.Field{{Name: "Lrate", Doc: "Lrate is the learning rate."}, {Name: "Momentum", Doc: "Momentum is the proportion of the previous weight change\nthat is added to the current one."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/bp.Layer", IDName: "layer", Doc: "Layer is a layer of units with logistic sigmoid activation.", Embeds: []types.Field{{Name: "LayerBase"}}, Fields: []types.Field{{Name: "Type", Doc: "Type is the type of layer."}, {Name: "Params", Doc: "Params are the learning parameters."}, {Name: "Network", Doc: "Network is the network this layer belongs to."}, {Name: "RecvPaths", Doc: "RecvPaths are the receiving pathways into this layer."}, {Name: "SendPaths", Doc: "SendPaths are the sending pathways from this layer."}, {Name: "Act", Doc: "Act is the activity of each unit on the current time step."}, {Name: "Net", Doc: "Net is the net input of each unit on the current time step."}, {Name: "Ext", Doc: "Ext is the external input (input layers) or target (target layers)\nof each unit."}, {Name: "Err", Doc: "Err is the error derivative (delta) of each unit,\nfrom the last call to Backward, on the last time step."}, {Name: "Bias", Doc: "Bias is the bias weight of each unit."}, {Name: "DBias", Doc: "DBias is the accumulated bias weight change, from Backward."}, {Name: "prevDBias", Doc: "prevDBias is the previous bias weight change, for momentum."}, {Name: "hist", Doc: "hist has the state on each time step since the last Learn or InitSeq.\nThe steps beyond its length are kept for reuse, so that Forward\ndoes not allocate in steady state."}, {Name: "ctxt", Doc: "ctxt is the activity prior to the first time step in hist,\nwhich is the input to recurrent pathways on the first step."}, {Name: "errs", Doc: "errs are the deltas for the current and next time steps in Backward."}, {Name: "nextErrs", Doc: "errs are the deltas for the current and next time steps in Backward."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/bp.Network", IDName: "network", Doc: "Network is a backpropagation network of layers with logistic sigmoid\nactivation, which are computed in order on each time step, so that\nForwardPath pathways must go from earlier to later layers.\nRecurrentPath pathways send the activity of the previous time step,\nand their error is backpropagated through all of the time steps since\nthe last Learn (or InitSeq), so that calling Learn at the end of each\nsequence of Forward steps does backpropagation through time (BPTT),\nand calling it after every step is a simple recurrent network (SRN)\nwith a copied context. For feedforward networks, call InitSeq, ApplyExt\nfor the input and target layers, Forward, and then Learn, on each trial.", Embeds: []types.Field{{Name: "NetworkBase"}}, Fields: []types.Field{{Name: "Layers", Doc: "Layers are the layers, in order of computation."}, {Name: "Paths", Doc: "Paths are all of the pathways."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/bp.PathTypes", IDName: "path-types", Doc: "PathTypes are the types of pathways."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/bp.Path", IDName: "path", Doc: "Path is a pathway of weights between two layers,\nstored in receiver-based order.", Embeds: []types.Field{{Name: "PathBase"}}, Fields: []types.Field{{Name: "Type", Doc: "Type is the type of pathway."}, {Name: "Send", Doc: "Send is the sending layer."}, {Name: "Recv", Doc: "Recv is the receiving layer."}, {Name: "WtInit", Doc: "WtInit are the parameters for the initial random weights."}, {Name: "RecvConN", Doc: "RecvConN is the number of connections for each receiving unit."}, {Name: "RecvConStart", Doc: "RecvConStart is the starting synapse index for each receiving unit."}, {Name: "RecvConIndex", Doc: "RecvConIndex is the sending unit index for each synapse."}, {Name: "Wts", Doc: "Wts are the weights for each synapse."}, {Name: "DWts", Doc: "DWts are the accumulated weight changes for each synapse, from Backward."}, {Name: "prevDWts", Doc: "prevDWts are the previous weight changes, for momentum."}, {Name: "shared", Doc: "shared ties the weights of a Shared PoolTile pathway."}}})
@@ -0,0 +1,270 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package capacity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"slices"
+	"sync"
+
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/plot"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+// RunFunc is a function that builds a new model, initializing all random
+// number generation from given seed, trains it on the given patterns
+// (with columns Name, Input and Output), and tests its recall of them,
+// returning the recall accuracy: the proportion of patterns recalled
+// correctly (e.g., using estats.ClosestPattern). It is called concurrently
+// from multiple goroutines, so it must use its own network.
+type RunFunc func(pats *table.Table, seed int64) (float64, error)
+
+// Bench benchmarks the capacity of an associative memory configuration,
+// by running it on each of the Loads (numbers of patterns), for NRuns
+// different random seeds, each with its own random patterns, where the
+// patterns for smaller loads are the first rows of those for larger
+// loads.
+type Bench struct {
+
+	// Config is the name of the configuration being benchmarked,
+	// which is recorded in the tables.
+	Config string
+
+	// Loads are the numbers of patterns, in increasing order.
+	Loads []int
+
+	// NRuns is the number of runs for each load, each with a different seed.
+	NRuns int `default:"3"`
+
+	// NParallel is the maximum number of runs to execute in parallel,
+	// where 0 = the number of CPUs.
+	NParallel int
+
+	// Seeds are the random seeds for each run, which are initialized
+	// to 1..NRuns if not already set for NRuns.
+	Seeds randx.Seeds
+
+	// Shape is the shape of the patterns.
+	Shape []int
+
+	// PctAct is the proportion of active units in each pattern.
+	PctAct float32 `default:"0.2"`
+
+	// Auto makes the Output patterns the same as the Input patterns, for
+	// auto-associative memory, instead of separate random patterns for
+	// hetero-associative memory.
+	Auto bool
+
+	// Criterion is the minimum mean recall accuracy for a load
+	// to be within the Capacity.
+	Criterion float64 `default:"0.9"`
+
+	// Results has the accuracy of each run from the last Run,
+	// with columns Config, Load, Run, Seed and Accuracy.
+	Results *table.Table `display:"-"`
+
+	// Curve has the capacity curve from the last Run, with one row per
+	// load, with columns Config, Load, Accuracy (mean across runs),
+	// SEM, Min and Max.
+	Curve *table.Table `display:"-"`
+
+	// Capacity is the largest load for which the mean accuracy of
+	// it and all smaller loads is at least Criterion, from the last
+	// Run, which is 0 if none.
+	Capacity int
+}
+
+// NewBench returns a new [Bench] with given configuration name, pattern
+// shape, and loads, with default parameters.
+func NewBench(config string, shape []int, loads ...int) *Bench {
+	return &Bench{Config: config, Shape: shape, Loads: loads, NRuns: 3, PctAct: 0.2, Criterion: 0.9}
+}
+
+// Patterns returns the random patterns for given load and seed, with
+// columns Name, Input and Output. The patterns for a smaller load are the
+// first rows of those for a larger load with the same seed.
+func (bn *Bench) Patterns(load int, seed int64) *table.Table {
+	rnd := randx.NewSysRand(seed)
+	dt := table.New()
+	dt.AddStringColumn("Name")
+	in := dt.AddFloat32Column("Input", bn.Shape...)
+	out := dt.AddFloat32Column("Output", bn.Shape...)
+	dt.SetNumRows(load)
+	n := 1
+	for _, s := range bn.Shape {
+		n *= s
+	}
+	non := int(math.Round(float64(bn.PctAct) * float64(n)))
+	set := func(col *tensor.Float32, row int) {
+		for i, pi := range rnd.Perm(n) {
+			if i < non {
+				col.SetFloat1D(1, row*n+pi)
+			}
+		}
+	}
+	for row := range load {
+		dt.Column("Name").SetString1D(fmt.Sprintf("P%d", row), row)
+		set(in, row)
+		set(out, row)
+		if bn.Auto {
+			for i := range n {
+				out.SetFloat1D(in.Float1D(row*n+i), row*n+i)
+			}
+		}
+	}
+	return dt
+}
+
+// Run runs the benchmark with given function, for each of the Loads and
+// runs, in parallel, and returns the Curve, also computing the Results
+// and Capacity.
+func (bn *Bench) Run(fun RunFunc) (*table.Table, error) {
+	if bn.NRuns <= 0 || len(bn.Loads) == 0 {
+		return nil, fmt.Errorf("capacity.Bench: NRuns (%d) and the number of Loads (%d) must be > 0", bn.NRuns, len(bn.Loads))
+	}
+	if len(bn.Seeds) != bn.NRuns {
+		bn.Seeds.Init(bn.NRuns)
+	}
+	npar := bn.NParallel
+	if npar <= 0 {
+		npar = runtime.NumCPU()
+	}
+	nl := len(bn.Loads)
+	accs := make([]float64, nl*bn.NRuns)
+	errs := make([]error, nl*bn.NRuns)
+	sem := make(chan struct{}, npar)
+	var wg sync.WaitGroup
+	for li, load := range bn.Loads {
+		for run := range bn.NRuns {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer func() { <-sem; wg.Done() }()
+				idx := li*bn.NRuns + run
+				acc, err := fun(bn.Patterns(load, bn.Seeds[run]), bn.Seeds[run])
+				if err != nil {
+					errs[idx] = fmt.Errorf("capacity.Bench: load %d run %d: %w", load, run, err)
+				}
+				accs[idx] = acc
+			}()
+		}
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	bn.results(accs)
+	bn.curve(accs)
+	return bn.Curve, nil
+}
+
+// results records the Results table.
+func (bn *Bench) results(accs []float64) {
+	dt := table.New()
+	dt.AddStringColumn("Config")
+	dt.AddIntColumn("Load")
+	dt.AddIntColumn("Run")
+	dt.AddIntColumn("Seed")
+	dt.AddFloat64Column("Accuracy")
+	dt.SetNumRows(len(accs))
+	for li, load := range bn.Loads {
+		for run := range bn.NRuns {
+			row := li*bn.NRuns + run
+			dt.Column("Config").SetString1D(bn.Config, row)
+			dt.Column("Load").SetFloat1D(float64(load), row)
+			dt.Column("Run").SetFloat1D(float64(run), row)
+			dt.Column("Seed").SetFloat1D(float64(bn.Seeds[run]), row)
+			dt.Column("Accuracy").SetFloat1D(accs[row], row)
+		}
+	}
+	bn.Results = dt
+}
+
+// curve records the Curve table and the Capacity.
+func (bn *Bench) curve(accs []float64) {
+	dt := table.New()
+	dt.AddStringColumn("Config")
+	dt.AddIntColumn("Load")
+	dt.AddFloat64Column("Accuracy")
+	dt.AddFloat64Column("SEM")
+	dt.AddFloat64Column("Min")
+	dt.AddFloat64Column("Max")
+	dt.SetNumRows(len(bn.Loads))
+	bn.Capacity = 0
+	within := true
+	for li, load := range bn.Loads {
+		ra := accs[li*bn.NRuns : (li+1)*bn.NRuns]
+		n := float64(len(ra))
+		var mean, ss float64
+		for _, a := range ra {
+			mean += a
+		}
+		mean /= n
+		for _, a := range ra {
+			ss += (a - mean) * (a - mean)
+		}
+		var sem float64
+		if n > 1 {
+			sem = math.Sqrt(ss/(n-1)) / math.Sqrt(n)
+		}
+		dt.Column("Config").SetString1D(bn.Config, li)
+		dt.Column("Load").SetFloat1D(float64(load), li)
+		dt.Column("Accuracy").SetFloat1D(mean, li)
+		dt.Column("SEM").SetFloat1D(sem, li)
+		dt.Column("Min").SetFloat1D(slices.Min(ra), li)
+		dt.Column("Max").SetFloat1D(slices.Max(ra), li)
+		if within && mean >= bn.Criterion {
+			bn.Capacity = load
+		} else {
+			within = false
+		}
+	}
+	bn.Curve = dt
+}
+
+// Curves returns a table of the capacity curves of given benchmarks
+// from their last Run, for comparing configurations, with a Load column
+// and a column with the mean accuracy for each Config (NaN for loads
+// that it was not run on), styled for plotting accuracy vs. load.
+func Curves(bns ...*Bench) *table.Table {
+	var loads []int
+	for _, bn := range bns {
+		for _, l := range bn.Loads {
+			if !slices.Contains(loads, l) {
+				loads = append(loads, l)
+			}
+		}
+	}
+	slices.Sort(loads)
+	dt := table.New()
+	lc := dt.AddIntColumn("Load")
+	plot.SetStyle(lc, func(s *plot.Style) { s.Role = plot.X })
+	for _, bn := range bns {
+		bc := dt.AddFloat64Column(bn.Config)
+		plot.SetStyle(bc, func(s *plot.Style) {
+			s.On = true
+			s.Role = plot.Y
+			s.Point.On = plot.On
+			s.Range.SetMin(0).SetMax(1)
+		})
+	}
+	dt.SetNumRows(len(loads))
+	for row, l := range loads {
+		lc.SetFloat1D(float64(l), row)
+		for _, bn := range bns {
+			acc := math.NaN()
+			if li := slices.Index(bn.Loads, l); li >= 0 && bn.Curve != nil {
+				acc = bn.Curve.Column("Accuracy").Float1D(li)
+			}
+			dt.Column(bn.Config).SetFloat1D(acc, row)
+		}
+	}
+	return dt
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package capacity
+
+import (
+	"math"
+	"testing"
+
+	"cogentcore.org/lab/table"
+	"github.com/stretchr/testify/assert"
+)
+
+// slotMemory returns a RunFunc for a memory that stores
+// up to nslots patterns, and recalls only those.
+func slotMemory(nslots int) RunFunc {
+	return func(pats *table.Table, seed int64) (float64, error) {
+		n := pats.NumRows()
+		return float64(min(n, nslots)) / float64(n), nil
+	}
+}
+
+// values returns the values of given column.
+func values(dt *table.Table, column string) []float64 {
+	col := dt.Column(column)
+	vals := make([]float64, col.Len())
+	for i := range vals {
+		vals[i] = col.Float1D(i)
+	}
+	return vals
+}
+
+func TestPatterns(t *testing.T) {
+	bn := NewBench("Test", []int{5, 5}, 10)
+	dt := bn.Patterns(10, 1)
+	assert.Equal(t, 10, dt.NumRows())
+	in := dt.Column("Input")
+	for row := range 10 {
+		non := 0
+		for i := range 25 {
+			non += int(in.Float1D(row*25 + i))
+		}
+		assert.Equal(t, 5, non)
+	}
+	assert.NotEqual(t, values(dt, "Input"), values(dt, "Output"))
+
+	small := bn.Patterns(4, 1)
+	for i := range 4 * 25 {
+		assert.Equal(t, in.Float1D(i), small.Column("Input").Float1D(i))
+	}
+	other := bn.Patterns(4, 2)
+	assert.NotEqual(t, values(small, "Input"), values(other, "Input"))
+
+	bn.Auto = true
+	dt = bn.Patterns(3, 1)
+	assert.Equal(t, values(dt, "Input"), values(dt, "Output"))
+}
+
+func TestBench(t *testing.T) {
+	small := NewBench("Small", []int{4, 4}, 5, 10, 20, 40)
+	curve, err := small.Run(slotMemory(10))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, curve.NumRows())
+	assert.Equal(t, 1.0, curve.Column("Accuracy").Float1D(1))
+	assert.Equal(t, 0.5, curve.Column("Accuracy").Float1D(2))
+	assert.Equal(t, 0.0, curve.Column("SEM").Float1D(2))
+	assert.Equal(t, 10, small.Capacity)
+	assert.Equal(t, 12, small.Results.NumRows())
+	assert.Equal(t, "Small", small.Results.Column("Config").String1D(0))
+
+	large := NewBench("Large", []int{4, 4}, 10, 20, 80)
+	large.NRuns = 2
+	_, err = large.Run(slotMemory(20))
+	assert.NoError(t, err)
+	assert.Equal(t, 20, large.Capacity)
+
+	ct := Curves(small, large)
+	assert.Equal(t, 5, ct.NumRows())
+	assert.Equal(t, 80.0, ct.Column("Load").Float1D(4))
+	assert.True(t, math.IsNaN(ct.Column("Large").Float1D(0)))
+	assert.Equal(t, 1.0, ct.Column("Large").Float1D(2))
+	assert.Equal(t, 0.25, ct.Column("Large").Float1D(4))
+	assert.Equal(t, 0.25, ct.Column("Small").Float1D(3))
+
+	_, err = NewBench("None", []int{4}).Run(slotMemory(1))
+	assert.Error(t, err)
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package capacity provides a benchmarking harness for the capacity of
+associative memory configurations: it trains increasing numbers of random
+patterns into a given architecture, measures the recall accuracy vs. the
+load, and produces capacity curves, for comparing parameter settings and
+architectures.
+*/
+package capacity
+
+//go:generate core generate -add-types
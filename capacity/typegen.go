@@ -0,0 +1,11 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package capacity
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/capacity.RunFunc", IDName: "run-func", Doc: "RunFunc is a function that builds a new model, initializing all random\nnumber generation from given seed, trains it on the given patterns\n(with columns Name, Input and Output), and tests its recall of them,\nreturning the recall accuracy: the proportion of patterns recalled\ncorrectly (e.g., using estats.ClosestPattern). It is called concurrently\nfrom multiple goroutines, so it must use its own network.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/capacity.Bench", IDName: "bench", Doc: "Bench benchmarks the capacity of an associative memory configuration,\nby running it on each of the Loads (numbers of patterns), for NRuns\ndifferent random seeds, each with its own random patterns, where the\npatterns for smaller loads are the first rows of those for larger\nloads.", Fields: []types.Field{{Name: "Config", Doc: "Config is the name of the configuration being benchmarked,\nwhich is recorded in the tables."}, {Name: "Loads", Doc: "Loads are the numbers of patterns, in increasing order."}, {Name: "NRuns", Doc: "NRuns is the number of runs for each load, each with a different seed."}, {Name: "NParallel", Doc: "NParallel is the maximum number of runs to execute in parallel,\nwhere 0 = the number of CPUs."}, {Name: "Seeds", Doc: "Seeds are the random seeds for each run, which are initialized\nto 1..NRuns if not already set for NRuns."}, {Name: "Shape", Doc: "Shape is the shape of the patterns."}, {Name: "PctAct", Doc: "PctAct is the proportion of active units in each pattern."}, {Name: "Auto", Doc: "Auto makes the Output patterns the same as the Input patterns, for\nauto-associative memory, instead of separate random patterns for\nhetero-associative memory."}, {Name: "Criterion", Doc: "Criterion is the minimum mean recall accuracy for a load\nto be within the Capacity."}, {Name: "Results", Doc: "Results has the accuracy of each run from the last Run,\nwith columns Config, Load, Run, Seed and Accuracy."}, {Name: "Curve", Doc: "Curve has the capacity curve from the last Run, with one row per\nload, with columns Config, Load, Accuracy (mean across runs),\nSEM, Min and Max."}, {Name: "Capacity", Doc: "Capacity is the largest load for which the mean accuracy of\nit and all smaller loads is at least Criterion, from the last\nRun, which is 0 if none."}}})
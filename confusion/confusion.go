@@ -6,7 +6,15 @@ package confusion
 
 //go:generate core generate -add-types
 
-/*
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"cogentcore.org/core/base/fsx"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
 
 // Matrix computes the confusion matrix, with rows representing
 // the ground truth correct class, and columns representing the
@@ -22,8 +30,8 @@ type Matrix struct { //git:add
 	// counts per ground truth (rows)
 	N tensor.Float64 `display:"no-inline"`
 
-	// visualization using SimMat
-	Vis simat.SimMat `display:"no-inline"`
+	// class labels, for IncrLabel and the labeled grid view
+	Labels []string
 
 	// true pos/neg, false pos/neg for each class, generated from the confusion matrix
 	TFPN tensor.Float64 `display:"no-inline"`
@@ -38,13 +46,12 @@ type Matrix struct { //git:add
 // Init initializes the Matrix for given number of classes,
 // and resets the data to zero.
 func (cm *Matrix) Init(n int) {
-	cm.Prob.SetShape([]int{n, n}, "N", "N")
-	cm.Sum.SetShape([]int{n, n}, "N", "N")
-	cm.N.SetShape([]int{n}, "N")
-	cm.TFPN.SetShape([]int{n, 4}, "TP", "FP", "FN", "TN")
-	cm.ClassScores.SetShape([]int{n, 3}, "Precision", "Recall", "F1")
-	cm.MatrixScores.SetShape([]int{3}, "Precision", "Recall", "F1")
-	cm.Vis.Mat = &cm.Prob
+	cm.Prob.SetShapeSizes(n, n)
+	cm.Sum.SetShapeSizes(n, n)
+	cm.N.SetShapeSizes(n)
+	cm.TFPN.SetShapeSizes(n, 4)
+	cm.ClassScores.SetShapeSizes(n, 3)
+	cm.MatrixScores.SetShapeSizes(3)
 	cm.Reset()
 }
 
@@ -58,22 +65,21 @@ func (cm *Matrix) Reset() {
 	cm.MatrixScores.SetZeros()
 }
 
-// SetLabels sets the class labels, for visualization in Vis
+// SetLabels sets the class labels, for IncrLabel and visualization
 func (cm *Matrix) SetLabels(lbls []string) {
-	cm.Vis.Rows = lbls
-	cm.Vis.Columns = lbls
+	cm.Labels = lbls
 }
 
 // InitFromLabels does initialization based on given labels.
 // Calls Init on len(lbls) and SetLabels.
-// Default fontSize = 12 if 0 or -1 passed
-func (cm *Matrix) InitFromLabels(lbls []string, fontSize int) {
+func (cm *Matrix) InitFromLabels(lbls []string) {
 	cm.Init(len(lbls))
 	cm.SetLabels(lbls)
-	if fontSize <= 0 {
-		fontSize = 12
-	}
-	cm.Prob.SetMetaData("font-size", fmt.Sprintf("%d", fontSize))
+}
+
+// NClasses returns the number of classes.
+func (cm *Matrix) NClasses() int {
+	return cm.N.Len()
 }
 
 // Incr increments the data for given class ground truth and response.
@@ -81,74 +87,87 @@ func (cm *Matrix) Incr(class, resp int) {
 	if class < 0 || resp < 0 {
 		return
 	}
-	ncat := cm.Sum.DimSize(0)
+	ncat := cm.NClasses()
 	if class >= ncat || resp >= ncat {
 		return
 	}
-	ix := []int{class, resp}
-	sum := cm.Sum.Value(ix)
-	sum++
-	cm.Sum.Set(ix, sum)
-	n := cm.N.Value1D(class)
-	n++
-	cm.N.Set1D(class, n)
+	cm.Sum.SetAdd(1, class, resp)
+	cm.N.SetAdd(1, class)
+}
+
+// IncrLabel increments the data for given class ground truth
+// and response labels, e.g., the expected and closest pattern names.
+// Labels that are not in Labels are ignored.
+func (cm *Matrix) IncrLabel(class, resp string) {
+	cm.Incr(slices.Index(cm.Labels, class), slices.Index(cm.Labels, resp))
 }
 
 // Probs computes the probabilities based on accumulated data
 func (cm *Matrix) Probs() {
-	n := cm.N.Len()
+	n := cm.NClasses()
 	for cl := 0; cl < n; cl++ {
 		cn := cm.N.Value1D(cl)
 		if cn == 0 {
 			continue
 		}
 		for ri := 0; ri < n; ri++ {
-			ix := []int{cl, ri}
-			sum := cm.Sum.Value(ix)
-			cm.Prob.Set(ix, sum/cn)
+			sum := cm.Sum.Value(cl, ri)
+			cm.Prob.Set(sum/cn, cl, ri)
 		}
 	}
 }
 
+// PctCorrect returns the overall proportion of correct responses,
+// along the diagonal, based on the accumulated data.
+func (cm *Matrix) PctCorrect() float64 {
+	var cor, tot float64
+	n := cm.NClasses()
+	for cl := 0; cl < n; cl++ {
+		cor += cm.Sum.Value(cl, cl)
+		tot += cm.N.Value1D(cl)
+	}
+	if tot == 0 {
+		return 0
+	}
+	return cor / tot
+}
+
 func (cm *Matrix) SumTFPN(class int) {
 	fn := 0.0 // false negative
 	fp := 0.0 // false positive
 	tn := 0.0 // true negative
 
-	n := cm.N.Len()
+	n := cm.NClasses()
 	for c := 0; c < n; c++ {
 		for r := 0; r < n; r++ {
 			if r == class && c == class { //        True Positive
-				v := cm.Sum.FloatRowCell(r, c)
-				cm.TFPN.SetFloatRowCell(class, 0, v)
+				v := cm.Sum.FloatRow(r, c)
+				cm.TFPN.SetFloatRow(v, class, 0)
 			} else if r == class && c != class { // False Positive
-				fn += cm.Sum.FloatRowCell(r, c)
-				cm.TFPN.SetFloatRowCell(class, 1, fp)
+				fn += cm.Sum.FloatRow(r, c)
 			} else if r != class && c == class { // False Negative
-				fp += cm.Sum.FloatRowCell(r, c)
-				cm.TFPN.SetFloatRowCell(class, 2, fn)
+				fp += cm.Sum.FloatRow(r, c)
 			} else { //                             True Negative
-				tn += cm.Sum.FloatRowCell(r, c)
-				cm.TFPN.SetFloatRowCell(class, 3, tn)
+				tn += cm.Sum.FloatRow(r, c)
 			}
 		}
 	}
-	cm.TFPN.SetFloatRowCell(class, 1, fp)
-	cm.TFPN.SetFloatRowCell(class, 2, fn)
-	cm.TFPN.SetFloatRowCell(class, 3, tn)
+	cm.TFPN.SetFloatRow(fp, class, 1)
+	cm.TFPN.SetFloatRow(fn, class, 2)
+	cm.TFPN.SetFloatRow(tn, class, 3)
 }
 
 func (cm *Matrix) ScoreClass(class int) {
-	tp := cm.TFPN.FloatRowCell(class, 0)
-	fp := cm.TFPN.FloatRowCell(class, 1)
-	fn := cm.TFPN.FloatRowCell(class, 2)
+	tp := cm.TFPN.FloatRow(class, 0)
+	fp := cm.TFPN.FloatRow(class, 1)
+	fn := cm.TFPN.FloatRow(class, 2)
 
 	precision := tp / (tp + fp)
-	cm.ClassScores.SetFloatRowCell(class, 0, precision)
+	cm.ClassScores.SetFloatRow(precision, class, 0)
 	recall := tp / (tp + fn) // also called true positive rate and has other names
-	cm.ClassScores.SetFloatRowCell(class, 1, recall)
+	cm.ClassScores.SetFloatRow(recall, class, 1)
 	f1 := 2 * tp / ((2 * tp) + fp + fn) // 2 x (Precision x Recall) / (Precision + Recall)
-	cm.ClassScores.SetFloatRowCell(class, 2, f1)
+	cm.ClassScores.SetFloatRow(f1, class, 2)
 }
 
 func (cm *Matrix) ScoreMatrix() {
@@ -156,49 +175,89 @@ func (cm *Matrix) ScoreMatrix() {
 	fp := 0.0
 	fn := 0.0
 
-	n := cm.N.Len()
+	n := cm.NClasses()
 	for i := 0; i < n; i++ {
-		tp += cm.TFPN.FloatRowCell(i, 0)
-		fp += cm.TFPN.FloatRowCell(i, 1)
-		fn += cm.TFPN.FloatRowCell(i, 2)
+		tp += cm.TFPN.FloatRow(i, 0)
+		fp += cm.TFPN.FloatRow(i, 1)
+		fn += cm.TFPN.FloatRow(i, 2)
 	}
 
 	// micro F1 - ignores class
 	f1 := 2 * tp / ((2 * tp) + fp + fn) // 2 x (Precision x Recall) / (Precision + Recall)
-	cm.MatrixScores.SetFloat1D(0, f1)
+	cm.MatrixScores.SetFloat1D(f1, 0)
 
 	// macro F1 - unweighted average of class F1 scores
 	// some classes might not have any instances so check NaN
 	f1 = 0.0
 	for i := 0; i < n; i++ {
-		classf1 := cm.ClassScores.FloatRowCell(i, 2)
+		classf1 := cm.ClassScores.FloatRow(i, 2)
 		if math.IsNaN(classf1) == false {
 			f1 += classf1
 		}
 	}
-	cm.MatrixScores.SetFloat1D(1, f1/float64(n))
+	cm.MatrixScores.SetFloat1D(f1/float64(n), 1)
 
 	// weighted F1 - weighted average of class F1 scores
 	// some classes might not have any instances so check NaN
 	f1 = 0.0
 	totalN := 0.0
 	for i := 0; i < n; i++ {
-		classf1 := cm.ClassScores.FloatRowCell(i, 2) * cm.N.Float1D(i)
+		classf1 := cm.ClassScores.FloatRow(i, 2) * cm.N.Float1D(i)
 		if math.IsNaN(classf1) == false {
 			f1 += classf1
 		}
 		totalN += cm.N.Float1D(i)
 	}
-	cm.MatrixScores.SetFloat1D(2, f1/totalN)
+	cm.MatrixScores.SetFloat1D(f1/totalN, 2)
+}
+
+// SetTableRow sets the Prob matrix into given row of the column with
+// given name in given table (e.g., an epoch log), adding the column
+// as a Float64 column with NxN cells if it does not yet exist.
+func (cm *Matrix) SetTableRow(dt *table.Table, column string, row int) {
+	n := cm.NClasses()
+	if dt.Column(column) == nil {
+		dt.AddFloat64Column(column, n, n)
+	}
+	col := dt.Column(column)
+	if row >= dt.NumRows() {
+		dt.SetNumRows(row + 1)
+	}
+	for i := range n * n {
+		col.SetFloatRow(cm.Prob.Float1D(i), row, i)
+	}
+}
+
+// String returns the Prob matrix as text, with labels if set.
+func (cm *Matrix) String() string {
+	n := cm.NClasses()
+	label := func(i int) string {
+		if i < len(cm.Labels) {
+			return cm.Labels[i]
+		}
+		return fmt.Sprintf("%d", i)
+	}
+	s := "\t"
+	for c := range n {
+		s += label(c) + "\t"
+	}
+	s += "\n"
+	for r := range n {
+		s += label(r) + "\t"
+		for c := range n {
+			s += fmt.Sprintf("%.3g\t", cm.Prob.Value(r, c))
+		}
+		s += "\n"
+	}
+	return s
 }
 
 // SaveCSV saves Prob result to a CSV file, comma separated
-func (cm *Matrix) SaveCSV(fname core.Filename) {
-	tensor.SaveCSV(&cm.Prob, fname, ',')
+func (cm *Matrix) SaveCSV(fname fsx.Filename) error {
+	return tensor.SaveCSV(&cm.Prob, fname, tensor.Comma)
 }
 
 // OpenCSV opens Prob result from a CSV file, comma separated
-func (cm *Matrix) OpenCSV(fname core.Filename) {
-	tensor.OpenCSV(&cm.Prob, fname, ',')
+func (cm *Matrix) OpenCSV(fname fsx.Filename) error {
+	return tensor.OpenCSV(&cm.Prob, fname, tensor.Comma)
 }
-*/
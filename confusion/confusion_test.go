@@ -0,0 +1,41 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package confusion
+
+import (
+	"testing"
+
+	"cogentcore.org/lab/table"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatrix(t *testing.T) {
+	cm := &Matrix{}
+	cm.InitFromLabels([]string{"A", "B", "C"})
+	cm.IncrLabel("A", "A")
+	cm.IncrLabel("A", "B")
+	cm.IncrLabel("B", "B")
+	cm.IncrLabel("C", "C")
+	cm.IncrLabel("C", "D") // ignored
+	cm.Probs()
+	assert.Equal(t, 0.5, cm.Prob.Value(0, 0))
+	assert.Equal(t, 0.5, cm.Prob.Value(0, 1))
+	assert.Equal(t, 1.0, cm.Prob.Value(1, 1))
+	assert.Equal(t, 0.75, cm.PctCorrect())
+
+	for c := range 3 {
+		cm.SumTFPN(c)
+		cm.ScoreClass(c)
+	}
+	cm.ScoreMatrix()
+	assert.Equal(t, []float64{1, 0, 1, 2}, cm.TFPN.Values[0:4]) // A: TP, FP, FN, TN
+	assert.Equal(t, 0.5, cm.ClassScores.FloatRow(1, 0))         // B precision
+	assert.Equal(t, 0.75, cm.MatrixScores.Float1D(0))           // micro F1
+
+	dt := table.New()
+	cm.SetTableRow(dt, "Confusion", 1)
+	assert.Equal(t, 2, dt.NumRows())
+	assert.Equal(t, 0.5, dt.Column("Confusion").FloatRow(1, 1))
+}
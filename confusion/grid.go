@@ -0,0 +1,83 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package confusion
+
+import (
+	"fmt"
+
+	"cogentcore.org/core/colors"
+	"cogentcore.org/core/colors/colormap"
+	"cogentcore.org/core/core"
+	"cogentcore.org/core/styles"
+	"cogentcore.org/core/styles/units"
+	"cogentcore.org/core/tree"
+)
+
+// MakeGrid makes a labeled grid view of the Prob matrix in given parent,
+// with the Labels for the ground truth classes along the rows,
+// and for the responses along the columns, and each cell showing the
+// probability, colored from white (0) to red (1).
+// Call Update on the returned Frame to update the display after
+// computing new probabilities.
+func (cm *Matrix) MakeGrid(parent core.Widget) *core.Frame {
+	fr := core.NewFrame(parent)
+	fr.Styler(func(s *styles.Style) {
+		s.Display = styles.Grid
+		s.Columns = cm.NClasses() + 1
+		s.Gap.Set(units.Dp(2))
+	})
+	cmap := colormap.AvailableMaps["BlueWhiteRed"]
+	label := func(i int) string {
+		if i < len(cm.Labels) {
+			return cm.Labels[i]
+		}
+		return fmt.Sprintf("%d", i)
+	}
+	fr.Maker(func(p *tree.Plan) {
+		n := cm.NClasses()
+		tree.AddAt(p, "corner", func(w *core.Text) {
+			w.SetText("Truth \\ Resp")
+		})
+		for c := range n {
+			tree.AddAt(p, fmt.Sprintf("col-%d", c), func(w *core.Text) {
+				w.Updater(func() {
+					w.SetText(label(c))
+				})
+			})
+		}
+		for r := range n {
+			tree.AddAt(p, fmt.Sprintf("row-%d", r), func(w *core.Text) {
+				w.Updater(func() {
+					w.SetText(label(r))
+				})
+			})
+			for c := range n {
+				tree.AddAt(p, fmt.Sprintf("cell-%d-%d", r, c), func(w *core.Text) {
+					w.Styler(func(s *styles.Style) {
+						pr := cm.Prob.Value(r, c)
+						s.Background = colors.Uniform(cmap.Map(float32(0.5 + 0.5*pr)))
+						s.Color = colors.Uniform(colors.Black)
+						s.Min.X.Em(3)
+						s.Padding.Set(units.Dp(4))
+						s.Text.Align = styles.Center
+					})
+					w.Updater(func() {
+						w.SetText(fmt.Sprintf("%.2f", cm.Prob.Value(r, c)))
+					})
+				})
+			}
+		}
+	})
+	return fr
+}
+
+// View opens a window with a labeled grid view of the Prob matrix,
+// and the overall proportion correct.
+func (cm *Matrix) View(title string) {
+	b := core.NewBody("confusion").SetTitle(title)
+	core.NewText(b).SetText(fmt.Sprintf("Proportion correct: %.3g", cm.PctCorrect()))
+	cm.MakeGrid(b)
+	b.RunWindow()
+}
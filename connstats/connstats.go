@@ -0,0 +1,278 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package connstats
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/core/math32"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// Stats are the per-unit connectivity statistics
+// available as layer-shaped tensors.
+type Stats int32 //enums:enum
+
+const (
+	// FanIn is the number of receiving synapses (afferents) of each unit.
+	FanIn Stats = iota
+
+	// FanOut is the number of sending synapses (efferents) of each unit.
+	FanOut
+
+	// WtIn is the total weight of the receiving synapses of each unit.
+	WtIn
+
+	// WtOut is the total weight of the sending synapses of each unit.
+	WtOut
+)
+
+// Afferent is a sending unit that connects to a receiving unit.
+type Afferent struct {
+
+	// Layer is the name of the sending layer.
+	Layer string
+
+	// Unit is the 1D index of the sending unit in its layer.
+	Unit int
+
+	// Wt is the weight of the synapse.
+	Wt float32
+}
+
+// Unit has the connectivity statistics for one unit.
+type Unit struct {
+
+	// FanIn is the number of receiving synapses (afferents).
+	FanIn int
+
+	// FanOut is the number of sending synapses (efferents).
+	FanOut int
+
+	// WtIn is the total weight of the receiving synapses.
+	WtIn float32
+
+	// WtOut is the total weight of the sending synapses.
+	WtOut float32
+
+	// Strongest are the up to K strongest afferents,
+	// in descending order of weight.
+	Strongest []Afferent
+}
+
+// Layer has the connectivity statistics for all of the units in a layer.
+type Layer struct {
+
+	// Name of the layer.
+	Name string
+
+	// Shape of the layer.
+	Shape tensor.Shape
+
+	// WtVar is the synaptic variable used for the weights, e.g., Wt.
+	WtVar string
+
+	// K is the number of strongest afferents recorded per unit.
+	K int
+
+	// Units has the stats for each unit, in 1D index order.
+	Units []Unit
+}
+
+// Compute returns the connectivity statistics for all of the units in
+// given layer, using given synaptic variable for the weights (e.g., Wt),
+// and recording the k strongest afferents per unit. Pathways that are Off
+// are not included, and only synapses that actually exist are counted,
+// so this reflects any pruning or growth. This searches for synapses
+// between all pairs of sending and receiving units, so it can be slow
+// for large layers.
+func Compute(ly emer.Layer, wtVar string, k int) (*Layer, error) {
+	lb := ly.AsEmer()
+	cl := &Layer{Name: lb.Name, WtVar: wtVar, K: k}
+	cl.Shape.CopyFrom(&lb.Shape)
+	nu := lb.NumUnits()
+	cl.Units = make([]Unit, nu)
+	for pi := range ly.NumRecvPaths() {
+		pt := ly.RecvPath(pi)
+		if pt.AsEmer().Off {
+			continue
+		}
+		vi, err := pt.SynVarIndex(wtVar)
+		if err != nil {
+			return nil, fmt.Errorf("connstats.Compute: layer %q: %w", lb.Name, err)
+		}
+		sl := pt.SendLayer()
+		snm := sl.Label()
+		ns := sl.AsEmer().NumUnits()
+		for ri := range nu {
+			un := &cl.Units[ri]
+			for si := range ns {
+				syi := pt.SynIndex(si, ri)
+				if syi < 0 {
+					continue
+				}
+				wt := pt.SynValue1D(vi, syi)
+				if math32.IsNaN(wt) {
+					continue
+				}
+				un.FanIn++
+				un.WtIn += wt
+				un.addStrongest(Afferent{Layer: snm, Unit: si, Wt: wt}, k)
+			}
+		}
+	}
+	for pi := range ly.NumSendPaths() {
+		pt := ly.SendPath(pi)
+		if pt.AsEmer().Off {
+			continue
+		}
+		vi, err := pt.SynVarIndex(wtVar)
+		if err != nil {
+			return nil, fmt.Errorf("connstats.Compute: layer %q: %w", lb.Name, err)
+		}
+		nr := pt.RecvLayer().AsEmer().NumUnits()
+		for si := range nu {
+			un := &cl.Units[si]
+			for ri := range nr {
+				syi := pt.SynIndex(si, ri)
+				if syi < 0 {
+					continue
+				}
+				wt := pt.SynValue1D(vi, syi)
+				if math32.IsNaN(wt) {
+					continue
+				}
+				un.FanOut++
+				un.WtOut += wt
+			}
+		}
+	}
+	return cl, nil
+}
+
+// ComputeNetwork returns the connectivity statistics for all of the
+// layers in given network. See [Compute].
+func ComputeNetwork(net emer.Network, wtVar string, k int) ([]*Layer, error) {
+	nlay := net.NumLayers()
+	cls := make([]*Layer, 0, nlay)
+	for li := range nlay {
+		cl, err := Compute(net.EmerLayer(li), wtVar, k)
+		if err != nil {
+			return nil, err
+		}
+		cls = append(cls, cl)
+	}
+	return cls, nil
+}
+
+// addStrongest adds given afferent to the list of strongest afferents,
+// if it is among the k strongest.
+func (un *Unit) addStrongest(af Afferent, k int) {
+	if k <= 0 {
+		return
+	}
+	n := len(un.Strongest)
+	if n == k && af.Wt <= un.Strongest[n-1].Wt {
+		return
+	}
+	i, _ := slices.BinarySearchFunc(un.Strongest, af.Wt, func(a Afferent, wt float32) int {
+		switch {
+		case a.Wt > wt:
+			return -1
+		case a.Wt < wt:
+			return 1
+		}
+		return 0
+	})
+	un.Strongest = slices.Insert(un.Strongest, i, af)
+	if len(un.Strongest) > k {
+		un.Strongest = un.Strongest[:k]
+	}
+}
+
+// Value returns the value of given stat for given unit.
+func (un *Unit) Value(stat Stats) float32 {
+	switch stat {
+	case FanIn:
+		return float32(un.FanIn)
+	case FanOut:
+		return float32(un.FanOut)
+	case WtIn:
+		return un.WtIn
+	case WtOut:
+		return un.WtOut
+	}
+	return 0
+}
+
+// Tensor returns a tensor with the same shape as the layer,
+// with the values of given stat for each unit.
+func (cl *Layer) Tensor(stat Stats) *tensor.Float32 {
+	tsr := tensor.NewFloat32(cl.Shape.Sizes...)
+	for ui := range cl.Units {
+		tsr.SetFloat1D(float64(cl.Units[ui].Value(stat)), ui)
+	}
+	return tsr
+}
+
+// Table returns a table with one row per unit, with a Unit column
+// with the 1D unit index, a column for each of the [Stats], and
+// StrongLayer, StrongUnit, and StrongWt tensor columns with K cells
+// for the strongest afferents of each unit, in descending order.
+// Cells for units with fewer than K afferents have an empty layer
+// name, a unit index of -1, and a weight of NaN.
+func (cl *Layer) Table() *table.Table {
+	dt := table.New()
+	metadata.SetName(dt, "ConnStats: "+cl.Name)
+	tensor.SetPrecision(dt, 4)
+	nu := len(cl.Units)
+	dt.AddIntColumn("Unit")
+	for _, st := range StatsValues() {
+		dt.AddFloat32Column(st.String())
+	}
+	k := max(cl.K, 1)
+	sly := dt.AddStringColumn("StrongLayer", k)
+	sun := dt.AddIntColumn("StrongUnit", k)
+	swt := dt.AddFloat32Column("StrongWt", k)
+	dt.SetNumRows(nu)
+	for ui := range nu {
+		un := &cl.Units[ui]
+		dt.Column("Unit").SetIntRow(ui, ui, 0)
+		for _, st := range StatsValues() {
+			dt.Column(st.String()).SetFloatRow(float64(un.Value(st)), ui, 0)
+		}
+		for ki := range k {
+			if ki < len(un.Strongest) {
+				af := un.Strongest[ki]
+				sly.SetStringRow(af.Layer, ui, ki)
+				sun.SetIntRow(af.Unit, ui, ki)
+				swt.SetFloatRow(float64(af.Wt), ui, ki)
+			} else {
+				sun.SetIntRow(-1, ui, ki)
+				swt.SetFloatRow(math.NaN(), ui, ki)
+			}
+		}
+	}
+	return dt
+}
+
+// Overlayer is an interface for setting per-unit overlay values
+// for a layer, e.g., as implemented by the netview.NetView.
+type Overlayer interface {
+	SetOverlay(name, layer string, vals tensor.Tensor)
+}
+
+// SetOverlays sets overlays for all of the [Stats] for this layer,
+// e.g., for display in the netview.NetView.
+func (cl *Layer) SetOverlays(ov Overlayer) {
+	for _, st := range StatsValues() {
+		ov.SetOverlay(st.String(), cl.Name, cl.Tensor(st))
+	}
+}
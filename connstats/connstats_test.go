@@ -0,0 +1,114 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package connstats
+
+import (
+	"math"
+	"testing"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/hebb"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+type testOverlayer map[string]tensor.Tensor
+
+func (to testOverlayer) SetOverlay(name, layer string, vals tensor.Tensor) {
+	to[layer+":"+name] = vals
+}
+
+func TestLayer(t *testing.T) {
+	cl := &Layer{Name: "Hidden", WtVar: "Wt", K: 2}
+	cl.Shape.SetShapeSizes(1, 2)
+	cl.Units = make([]Unit, 2)
+	un := &cl.Units[0]
+	for i, wt := range []float32{0.2, 0.8, 0.5, 0.9} {
+		un.FanIn++
+		un.WtIn += wt
+		un.addStrongest(Afferent{Layer: "Input", Unit: i, Wt: wt}, cl.K)
+	}
+	un.FanOut = 3
+	un.WtOut = 1.5
+	cl.Units[1].addStrongest(Afferent{Layer: "Input", Unit: 2, Wt: 0.3}, cl.K)
+	cl.Units[1].FanIn = 1
+
+	assert.Equal(t, []Afferent{{"Input", 3, 0.9}, {"Input", 1, 0.8}}, un.Strongest)
+	assert.InDelta(t, 2.4, un.Value(WtIn), 1e-6)
+
+	tsr := cl.Tensor(FanIn)
+	assert.Equal(t, []int{1, 2}, tsr.Shape().Sizes)
+	assert.Equal(t, 4.0, tsr.Float1D(0))
+	assert.Equal(t, 1.0, tsr.Float1D(1))
+
+	dt := cl.Table()
+	assert.Equal(t, 2, dt.NumRows())
+	assert.Equal(t, 3.0, dt.Column("FanOut").FloatRow(0, 0))
+	assert.Equal(t, "Input", dt.Column("StrongLayer").StringRow(0, 1))
+	assert.Equal(t, 1.0, dt.Column("StrongUnit").FloatRow(0, 1))
+	assert.Equal(t, -1.0, dt.Column("StrongUnit").FloatRow(1, 1))
+	assert.True(t, math.IsNaN(dt.Column("StrongWt").FloatRow(1, 1)))
+
+	to := testOverlayer{}
+	cl.SetOverlays(to)
+	assert.Equal(t, len(StatsValues()), len(to))
+	assert.Equal(t, 1.5, to["Hidden:WtOut"].Float1D(0))
+}
+
+func TestTrace(t *testing.T) {
+	net := hebb.NewNetwork("Trace")
+	in := net.AddLayer2D("Input", 1, 2, hebb.InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 3, hebb.HiddenLayer)
+	out := net.AddLayer2D("Output", 1, 2, hebb.HiddenLayer)
+	ih := net.ConnectLayers(in, hid, paths.NewFull())
+	ho := net.ConnectLayers(hid, out, paths.NewFull())
+	io := net.ConnectLayers(in, out, paths.NewFull())
+	assert.NoError(t, net.Build())
+	setWts := func(pt *hebb.Path, wts [][]float32) {
+		for ri, rw := range wts {
+			for si, wt := range rw {
+				pt.Wts[pt.SynIndex(si, ri)] = wt
+			}
+		}
+	}
+	setWts(ih, [][]float32{{0.9, 0.1}, {0.5, 0.2}, {0.1, 0.8}})
+	setWts(ho, [][]float32{{0.8, 0.1, 0.3}, {0.1, 0.9, 0.7}})
+	setWts(io, [][]float32{{0.3, 0.1}, {0.1, 0.2}})
+
+	tr := NewTracer()
+	tr.K = 2
+	routes, err := tr.Trace(net, "Input", 0, "Output", -1)
+	assert.NoError(t, err)
+	// Input[0] efferents to Hidden: 0 (0.9), 1 (0.5), plus Output directly
+	assert.Equal(t, "Input[0] -0.9-> Hidden[0] -0.8-> Output[0]", routes[0].String())
+	assert.InDelta(t, 0.72, routes[0].Strength, 1e-6)
+	assert.Equal(t, "Input[0] -0.5-> Hidden[1] -0.9-> Output[1]", routes[1].String())
+	assert.Equal(t, "Input[0] -0.3-> Output[0]", routes[2].String())
+	assert.Equal(t, "Input[0] -0.5-> Hidden[1] -0.1-> Output[0]", routes[5].String())
+	assert.Len(t, routes, 6)
+
+	routes, err = tr.Trace(net, "Input", -1, "Output", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Input[1] -0.8-> Hidden[2] -0.7-> Output[1]", routes[0].String())
+	for _, rt := range routes {
+		assert.Equal(t, 1, rt.Steps[len(rt.Steps)-1].Unit)
+	}
+
+	tr.MaxPaths = 1
+	routes, err = tr.Trace(net, "Input", -1, "Output", -1)
+	assert.NoError(t, err)
+	assert.Len(t, routes, 4)
+
+	dt := RoutesTable(routes)
+	assert.Equal(t, 4, dt.NumRows())
+	assert.Equal(t, "Input[0]", dt.Column("Source").StringRow(0, 0))
+	assert.Equal(t, 1.0, dt.Column("NPaths").FloatRow(0, 0))
+
+	_, err = tr.Trace(net, "Missing", 0, "Output", -1)
+	assert.Error(t, err)
+	tr.WtVar = "Missing"
+	_, err = tr.Trace(net, "Input", 0, "Output", -1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,18 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package connstats provides per-unit structural connectivity statistics,
+including fan-in and fan-out synapse counts, total incoming and outgoing
+weight, and the strongest k afferents of each unit, as table columns and
+layer-shaped tensors that can be displayed as overlays in the NetView,
+so that connectivity after pruning, growth, or random wiring can be
+inspected quantitatively. It also traces the strongest
+multi-pathway routes between layers or units, by chaining the strongest
+efferent weights, to help interpret how information flows through
+trained networks.
+*/
+package connstats
+
+//go:generate core generate -add-types
@@ -0,0 +1,46 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package connstats
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _StatsValues = []Stats{0, 1, 2, 3}
+
+// StatsN is the highest valid value for type Stats, plus one.
+const StatsN Stats = 4
+
+var _StatsValueMap = map[string]Stats{`FanIn`: 0, `FanOut`: 1, `WtIn`: 2, `WtOut`: 3}
+
+var _StatsDescMap = map[Stats]string{0: `FanIn is the number of receiving synapses (afferents) of each unit.`, 1: `FanOut is the number of sending synapses (efferents) of each unit.`, 2: `WtIn is the total weight of the receiving synapses of each unit.`, 3: `WtOut is the total weight of the sending synapses of each unit.`}
+
+var _StatsMap = map[Stats]string{0: `FanIn`, 1: `FanOut`, 2: `WtIn`, 3: `WtOut`}
+
+// String returns the string representation of this Stats value.
+func (i Stats) String() string { return enums.String(i, _StatsMap) }
+
+// SetString sets the Stats value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Stats) SetString(s string) error { return enums.SetString(i, s, _StatsValueMap, "Stats") }
+
+// Int64 returns the Stats value as an int64.
+func (i Stats) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Stats value from an int64.
+func (i *Stats) SetInt64(in int64) { *i = Stats(in) }
+
+// Desc returns the description of the Stats value.
+func (i Stats) Desc() string { return enums.Desc(i, _StatsDescMap) }
+
+// StatsValues returns all possible values for the type Stats.
+func StatsValues() []Stats { return _StatsValues }
+
+// Values returns all possible values for the type Stats.
+func (i Stats) Values() []enums.Enum { return enums.Values(_StatsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Stats) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Stats) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Stats") }
@@ -0,0 +1,251 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package connstats
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/core/math32"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// Step is one unit along a [Route].
+type Step struct {
+
+	// Layer is the name of the layer of the unit.
+	Layer string
+
+	// Unit is the 1D index of the unit in its layer.
+	Unit int
+
+	// Wt is the weight of the synapse from the unit of the previous step,
+	// which is 0 for the first step.
+	Wt float32
+}
+
+// Route is a multi-pathway route of synapses from a source unit
+// to a target unit, found by a [Tracer].
+type Route struct {
+
+	// Steps are the units along the route, from the source to the target.
+	Steps []Step
+
+	// Strength is the product of the weights along the route.
+	Strength float32
+}
+
+// String returns the route as a string of the form:
+// Input[3] -0.9-> Hidden[2] -0.8-> Output[0]
+func (rt *Route) String() string {
+	var b strings.Builder
+	for i, st := range rt.Steps {
+		if i > 0 {
+			fmt.Fprintf(&b, " -%.4g-> ", st.Wt)
+		}
+		fmt.Fprintf(&b, "%s[%d]", st.Layer, st.Unit)
+	}
+	return b.String()
+}
+
+// Tracer finds the strongest multi-pathway routes from a source layer or
+// unit to a target layer or unit, by chaining the K strongest efferent
+// synapses of each unit along the way, starting from the source, to help
+// interpret how information flows through a trained network. The strength
+// of a route is the product of its weights. Pathways that are Off are not
+// included, and units are not revisited within a route.
+type Tracer struct {
+
+	// WtVar is the synaptic variable used for the weights, e.g., Wt.
+	WtVar string
+
+	// K is the number of strongest efferents followed from each unit.
+	K int `default:"3"`
+
+	// MaxPaths is the maximum number of pathways in a route.
+	MaxPaths int `default:"4"`
+
+	// NRoutes is the maximum number of routes returned.
+	NRoutes int `default:"10"`
+
+	// Abs uses the absolute value of the weights, for algorithms
+	// with negative weights, e.g., backpropagation.
+	Abs bool
+
+	// efferents are the K strongest efferents of each sending unit,
+	// for each pathway, computed as needed.
+	efferents map[emer.Path][][]Step
+
+	// routes are the routes found so far.
+	routes []Route
+}
+
+// NewTracer returns a new [Tracer] for the Wt variable,
+// with default parameters.
+func NewTracer() *Tracer {
+	return &Tracer{WtVar: "Wt", K: 3, MaxPaths: 4, NRoutes: 10}
+}
+
+// Trace returns the strongest routes from given unit of the source layer
+// to given unit of the target layer, in descending order of strength.
+// A unit index of -1 for the source starts from all of its units,
+// and for the target accepts any of its units. Returns an error for
+// an invalid layer name or weight variable.
+func (tr *Tracer) Trace(net emer.Network, src string, srcUnit int, trg string, trgUnit int) ([]Route, error) {
+	nb := net.AsEmer()
+	sl, err := nb.EmerLayerByName(src)
+	if err != nil {
+		return nil, fmt.Errorf("connstats.Trace: %w", err)
+	}
+	if _, err := nb.EmerLayerByName(trg); err != nil {
+		return nil, fmt.Errorf("connstats.Trace: %w", err)
+	}
+	tr.efferents = make(map[emer.Path][][]Step)
+	tr.routes = nil
+	units := []int{srcUnit}
+	if srcUnit < 0 {
+		units = make([]int, sl.AsEmer().NumUnits())
+		for i := range units {
+			units[i] = i
+		}
+	}
+	for _, ui := range units {
+		steps := []Step{{Layer: src, Unit: ui}}
+		if err := tr.trace(sl, steps, 1, trg, trgUnit); err != nil {
+			return nil, err
+		}
+	}
+	slices.SortStableFunc(tr.routes, func(a, b Route) int {
+		switch {
+		case a.Strength > b.Strength:
+			return -1
+		case a.Strength < b.Strength:
+			return 1
+		}
+		return 0
+	})
+	routes := tr.routes
+	if len(routes) > tr.NRoutes {
+		routes = routes[:tr.NRoutes]
+	}
+	tr.efferents = nil
+	tr.routes = nil
+	return routes, nil
+}
+
+// trace extends the route of given steps, ending in a unit of given layer,
+// with given strength so far, recording it if it reaches the target.
+func (tr *Tracer) trace(ly emer.Layer, steps []Step, strength float32, trg string, trgUnit int) error {
+	last := steps[len(steps)-1]
+	if len(steps) > 1 && last.Layer == trg {
+		if trgUnit < 0 || last.Unit == trgUnit {
+			tr.routes = append(tr.routes, Route{Steps: slices.Clone(steps), Strength: strength})
+		}
+		return nil
+	}
+	if len(steps) > tr.MaxPaths {
+		return nil
+	}
+	for pi := range ly.NumSendPaths() {
+		pt := ly.SendPath(pi)
+		if pt.AsEmer().Off {
+			continue
+		}
+		effs, err := tr.pathEfferents(pt)
+		if err != nil {
+			return err
+		}
+		rl := pt.RecvLayer()
+		for _, ef := range effs[last.Unit] {
+			if slices.ContainsFunc(steps, func(st Step) bool { return st.Layer == ef.Layer && st.Unit == ef.Unit }) {
+				continue
+			}
+			if err := tr.trace(rl, append(steps, ef), strength*tr.weight(ef.Wt), trg, trgUnit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// weight returns the weight used for selecting efferents and for the strength.
+func (tr *Tracer) weight(wt float32) float32 {
+	if tr.Abs {
+		return math32.Abs(wt)
+	}
+	return wt
+}
+
+// pathEfferents returns the K strongest efferents of each sending unit
+// of given pathway, computing them if needed.
+func (tr *Tracer) pathEfferents(pt emer.Path) ([][]Step, error) {
+	if effs, ok := tr.efferents[pt]; ok {
+		return effs, nil
+	}
+	rnm := pt.RecvLayer().Label()
+	effs := make([][]Step, pt.SendLayer().AsEmer().NumUnits())
+	k := max(tr.K, 1)
+	err := pt.AsEmer().RangeSyns(tr.WtVar, func(sidx, ridx int, wt float32) bool {
+		if math32.IsNaN(wt) {
+			return true
+		}
+		es := effs[sidx]
+		w := tr.weight(wt)
+		if len(es) == k && w <= tr.weight(es[k-1].Wt) {
+			return true
+		}
+		i, _ := slices.BinarySearchFunc(es, w, func(st Step, w float32) int {
+			sw := tr.weight(st.Wt)
+			switch {
+			case sw > w:
+				return -1
+			case sw < w:
+				return 1
+			}
+			return 0
+		})
+		es = slices.Insert(es, i, Step{Layer: rnm, Unit: ridx, Wt: wt})
+		if len(es) > k {
+			es = es[:k]
+		}
+		effs[sidx] = es
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connstats.Trace: pathway %q: %w", pt.Label(), err)
+	}
+	tr.efferents[pt] = effs
+	return effs, nil
+}
+
+// RoutesTable returns a table with one row per route, with columns:
+// Strength, NPaths (number of pathways), Source and Target units
+// (as Layer[Unit]), and the Route as a string with the intermediate
+// units and weights.
+func RoutesTable(routes []Route) *table.Table {
+	dt := table.New()
+	metadata.SetName(dt, "Routes")
+	tensor.SetPrecision(dt, 4)
+	dt.AddFloat32Column("Strength")
+	dt.AddIntColumn("NPaths")
+	dt.AddStringColumn("Source")
+	dt.AddStringColumn("Target")
+	dt.AddStringColumn("Route")
+	dt.SetNumRows(len(routes))
+	for i := range routes {
+		rt := &routes[i]
+		src, trg := rt.Steps[0], rt.Steps[len(rt.Steps)-1]
+		dt.Column("Strength").SetFloatRow(float64(rt.Strength), i, 0)
+		dt.Column("NPaths").SetIntRow(len(rt.Steps)-1, i, 0)
+		dt.Column("Source").SetStringRow(fmt.Sprintf("%s[%d]", src.Layer, src.Unit), i, 0)
+		dt.Column("Target").SetStringRow(fmt.Sprintf("%s[%d]", trg.Layer, trg.Unit), i, 0)
+		dt.Column("Route").SetStringRow(rt.String(), i, 0)
+	}
+	return dt
+}
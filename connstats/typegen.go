@@ -0,0 +1,23 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package connstats
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/connstats.Stats", IDName: "stats", Doc: "Stats are the per-unit connectivity statistics\navailable as layer-shaped tensors.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/connstats.Afferent", IDName: "afferent", Doc: "Afferent is a sending unit that connects to a receiving unit.", Fields: []types.Field{{Name: "Layer", Doc: "Layer is the name of the sending layer."}, {Name: "Unit", Doc: "Unit is the 1D index of the sending unit in its layer."}, {Name: "Wt", Doc: "Wt is the weight of the synapse."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/connstats.Unit", IDName: "unit", Doc: "Unit has the connectivity statistics for one unit.", Fields: []types.Field{{Name: "FanIn", Doc: "FanIn is the number of receiving synapses (afferents)."}, {Name: "FanOut", Doc: "FanOut is the number of sending synapses (efferents)."}, {Name: "WtIn", Doc: "WtIn is the total weight of the receiving synapses."}, {Name: "WtOut", Doc: "WtOut is the total weight of the sending synapses."}, {Name: "Strongest", Doc: "Strongest are the up to K strongest afferents,\nin descending order of weight."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/connstats.Layer", IDName: "layer", Doc: "Layer has the connectivity statistics for all of the units in a layer.", Fields: []types.Field{{Name: "Name", Doc: "Name of the layer."}, {Name: "Shape", Doc: "Shape of the layer."}, {Name: "WtVar", Doc: "WtVar is the synaptic variable used for the weights, e.g., Wt."}, {Name: "K", Doc: "K is the number of strongest afferents recorded per unit."}, {Name: "Units", Doc: "Units has the stats for each unit, in 1D index order."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/connstats.Overlayer", IDName: "overlayer", Doc: "Overlayer is an interface for setting per-unit overlay values\nfor a layer, e.g., as implemented by the netview.NetView.", Methods: []types.Method{{Name: "SetOverlay", Args: []string{"name", "layer", "vals"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/connstats.Step", IDName: "step", Doc: "Step is one unit along a [Route].", Fields: []types.Field{{Name: "Layer", Doc: "Layer is the name of the layer of the unit."}, {Name: "Unit", Doc: "Unit is the 1D index of the unit in its layer."}, {Name: "Wt", Doc: "Wt is the weight of the synapse from the unit of the previous step,\nwhich is 0 for the first step."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/connstats.Route", IDName: "route", Doc: "Route is a multi-pathway route of synapses from a source unit\nto a target unit, found by a [Tracer].", Fields: []types.Field{{Name: "Steps", Doc: "Steps are the units along the route, from the source to the target."}, {Name: "Strength", Doc: "Strength is the product of the weights along the route."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/connstats.Tracer", IDName: "tracer", Doc: "Tracer finds the strongest multi-pathway routes from a source layer or\nunit to a target layer or unit, by chaining the K strongest efferent\nsynapses of each unit along the way, starting from the source, to help\ninterpret how information flows through a trained network. The strength\nof a route is the product of its weights. Pathways that are Off are not\nincluded, and units are not revisited within a route.", Fields: []types.Field{{Name: "WtVar", Doc: "WtVar is the synaptic variable used for the weights, e.g., Wt."}, {Name: "K", Doc: "K is the number of strongest efferents followed from each unit."}, {Name: "MaxPaths", Doc: "MaxPaths is the maximum number of pathways in a route."}, {Name: "NRoutes", Doc: "NRoutes is the maximum number of routes returned."}, {Name: "Abs", Doc: "Abs uses the absolute value of the weights, for algorithms\nwith negative weights, e.g., backpropagation."}, {Name: "efferents", Doc: "efferents are the K strongest efferents of each sending unit,\nfor each pathway, computed as needed."}, {Name: "routes", Doc: "routes are the routes found so far."}}})
@@ -0,0 +1,74 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package coupling
+
+import (
+	"fmt"
+
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/env"
+)
+
+// Coupler drives the external inputs of layers of a target network with
+// the State elements of a source env, which is typically a [NetEnv] for
+// the output layers of a source network in the same process, or a
+// remoteenv.Env for a NetEnv served by another process. The Links bind each
+// source element (e.g., a layer name of the source network) to the target
+// layer that it drives, with an optional Transform, and Target links are
+// applied as targets, only in the plus phase. Call Apply each trial or
+// cycle, after the source network has been updated and the target inputs
+// have been initialized (e.g., InitExt).
+type Coupler struct {
+
+	// Source is the env providing the values, e.g., a [NetEnv].
+	Source env.Env `display:"-"`
+
+	// Target is the network whose layers are driven.
+	Target emer.Network `display:"-"`
+
+	// Links bind each source element to the target layer that it drives.
+	Links []env.Binding
+
+	// ApplyFunc applies the values to a target layer,
+	// which is specific to the algorithm of the target network.
+	ApplyFunc env.ApplyFunc `display:"-"`
+
+	// Di is the data parallel index of the target network.
+	Di int
+}
+
+// NewCoupler returns a new [Coupler] from given source env to given target
+// network, using given function to apply values to its layers.
+func NewCoupler(source env.Env, target emer.Network, apply env.ApplyFunc) *Coupler {
+	return &Coupler{Source: source, Target: target, ApplyFunc: apply}
+}
+
+// Link adds a link from given source element (e.g., a source layer name)
+// to given target layer. Returns the coupler so calls can be chained.
+func (cp *Coupler) Link(source, target string) *Coupler {
+	cp.Links = append(cp.Links, env.Binding{Element: source, Layer: target})
+	return cp
+}
+
+// Validate checks that each linked source element exists, and matches the
+// shape of its target layer, returning an error describing all mismatches
+// (see [env.ValidateBindings]), which should be called after building the
+// networks.
+func (cp *Coupler) Validate() error {
+	if err := env.ValidateBindings(cp.Source, cp.Target, cp.Links...); err != nil {
+		return fmt.Errorf("coupling.Validate: %w", err)
+	}
+	return nil
+}
+
+// Apply applies the current values of the linked source elements to their
+// target layers, where Target links are only applied if plus is true,
+// for the plus phase (see [env.ApplyInputs]).
+func (cp *Coupler) Apply(plus bool) error {
+	if err := env.ApplyInputs(cp.Source, cp.Target, cp.Di, plus, cp.ApplyFunc, cp.Links...); err != nil {
+		return fmt.Errorf("coupling.Apply: %w", err)
+	}
+	return nil
+}
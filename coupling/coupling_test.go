@@ -0,0 +1,98 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package coupling
+
+import (
+	"testing"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/env"
+	"github.com/emer/emergent/v2/hebb"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/emer/emergent/v2/remoteenv"
+	"github.com/stretchr/testify/assert"
+)
+
+// nets returns a source network with an Output layer driven by its
+// Input, and a target network with an Input and a Target layer.
+func nets(t *testing.T) (*hebb.Network, *hebb.Network) {
+	src := hebb.NewNetwork("Source")
+	in := src.AddLayer2D("Input", 2, 2, hebb.InputLayer)
+	out := src.AddLayer2D("Output", 1, 4, hebb.HiddenLayer)
+	src.ConnectLayers(in, out, paths.NewOneToOne())
+	assert.NoError(t, src.Build())
+	trg := hebb.NewNetwork("Target")
+	trg.AddLayer2D("Input", 1, 4, hebb.InputLayer)
+	trg.AddLayer2D("Target", 2, 2, hebb.InputLayer)
+	assert.NoError(t, trg.Build())
+	return src, trg
+}
+
+func applyHebb(ly emer.Layer, di int, ext tensor.Values) error {
+	return ly.(*hebb.Layer).ApplyExt(ext)
+}
+
+// check runs the source network on a pattern, applies the coupler,
+// and checks that the target Input activity matches the source Output.
+func check(t *testing.T, src, trg *hebb.Network, cp *Coupler, step func()) {
+	ctx := src.NewContext()
+	for pi := range 3 {
+		pat := []float32{0, 0, 0, 0}
+		pat[pi] = 1
+		src.InitExt()
+		assert.NoError(t, src.ApplyInput("Input", pat))
+		src.Cycle(ctx)
+		step()
+		trg.InitExt()
+		assert.NoError(t, cp.Apply(false))
+		trg.Cycle(ctx)
+		sout, _ := src.LayerByName("Output")
+		tin, _ := trg.LayerByName("Input")
+		assert.Greater(t, sout.Act[pi], float32(0))
+		assert.Equal(t, sout.Act, tin.Act)
+		ttrg, _ := trg.LayerByName("Target")
+		assert.Equal(t, []float32{0, 0, 0, 0}, ttrg.Ext)
+		assert.NoError(t, cp.Apply(true))
+		assert.Equal(t, pat, ttrg.Ext)
+	}
+}
+
+func TestLocal(t *testing.T) {
+	src, trg := nets(t)
+	ev := NewNetEnv(src)
+	ev.Init(0)
+	cp := NewCoupler(ev, trg, applyHebb).Link("Output", "Input")
+	cp.Links = append(cp.Links, env.Binding{Element: "Input:Ext", Layer: "Target", Target: true})
+	assert.NoError(t, cp.Validate())
+	check(t, src, trg, cp, func() { ev.Step() })
+	assert.Equal(t, 2, ev.Trial.Cur)
+
+	bad := NewCoupler(ev, trg, applyHebb).Link("Input", "Input").Link("Hidden", "Input").Link("Output:Bad", "Input")
+	err := bad.Validate()
+	assert.ErrorContains(t, err, `state element "Input" has shape [2 2], which does not match the shape [1 4] of layer "Input"`)
+	assert.ErrorContains(t, err, `state element "Hidden" not found`)
+	assert.ErrorContains(t, err, `state element "Output:Bad" not found`)
+	assert.Error(t, bad.Apply(false))
+}
+
+func TestRemote(t *testing.T) {
+	src, trg := nets(t)
+	sv := remoteenv.NewServer("tcp", "127.0.0.1:0", NewNetEnv(src))
+	assert.NoError(t, sv.Start())
+	defer sv.Close()
+	ev, err := remoteenv.Dial("tcp", sv.Addr())
+	assert.NoError(t, err)
+	defer ev.Close()
+	assert.Equal(t, "Source", ev.Label())
+	ev.Init(0)
+	cp := NewCoupler(ev, trg, applyHebb).Link("Output", "Input")
+	cp.Links = append(cp.Links, env.Binding{Element: "Input:Ext", Layer: "Target", Target: true})
+	assert.NoError(t, cp.Validate())
+	check(t, src, trg, cp, func() { ev.Step() })
+	ct, _ := ev.Counter("Trial")
+	assert.Equal(t, 2, ct.Cur)
+	assert.NoError(t, ev.Err)
+}
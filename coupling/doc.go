@@ -0,0 +1,17 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package coupling couples separately constructed networks, so that
+designated output layers of a source network drive the external inputs
+of layers of a target network, each trial or cycle, enabling modular
+brain-system models whose parts are developed and trained independently.
+The source network is exposed as an [env.Env] by [NetEnv], with its layer
+values as State elements, which the [Coupler] applies to the target
+network, locally, or through the [remoteenv] socket protocol for a
+source network running in another process.
+*/
+package coupling
+
+//go:generate core generate -add-types
@@ -0,0 +1,120 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package coupling
+
+import (
+	"fmt"
+	"strings"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/env"
+)
+
+// NetEnv is an [env.Env] that exposes the current unit values of the layers
+// of a network as its State elements, named by the layer name for the
+// default Var, or as Layer:Var for other variables (e.g., Output:ActM),
+// with the shape of the layer, so that the network can drive the inputs of
+// another network as a source of a [Coupler], or be served to other
+// processes with a remoteenv.Server. Step only increments the Trial counter,
+// as the network itself is run by its own sim, which should call Step
+// each time its outputs are updated, to synchronize with the consumers.
+type NetEnv struct {
+
+	// Name is the name of the env, which is the network name if empty.
+	Name string
+
+	// Net is the network whose layer values are exposed.
+	Net emer.Network `display:"-"`
+
+	// Var is the default unit variable for the layer values.
+	Var string
+
+	// Di is the data parallel index of the values.
+	Di int
+
+	// Trial counts the Steps since Init.
+	Trial env.Counter `display:"inline"`
+}
+
+// NewNetEnv returns a new [NetEnv] for given network,
+// using the Act unit variable.
+func NewNetEnv(net emer.Network) *NetEnv {
+	return &NetEnv{Net: net, Var: "Act"}
+}
+
+func (ev *NetEnv) Label() string {
+	if ev.Name == "" {
+		return ev.Net.Label()
+	}
+	return ev.Name
+}
+
+func (ev *NetEnv) String() string {
+	return fmt.Sprintf("%s:%d", ev.Label(), ev.Trial.Cur)
+}
+
+func (ev *NetEnv) Init(run int) {
+	ev.Trial.Init()
+	ev.Trial.Cur = -1
+}
+
+func (ev *NetEnv) Step() bool {
+	ev.Trial.Incr()
+	return true
+}
+
+// layerVar returns the layer and variable for given element,
+// or nil if the layer is not found.
+func (ev *NetEnv) layerVar(element string) (emer.Layer, string) {
+	lnm, vnm, ok := strings.Cut(element, ":")
+	if !ok {
+		vnm = ev.Var
+	}
+	ly, err := ev.Net.AsEmer().EmerLayerByName(lnm)
+	if err != nil {
+		return nil, ""
+	}
+	return ly, vnm
+}
+
+// State returns a new tensor with the current values of given element:
+// the layer name for the default Var, or Layer:Var, with the shape of the
+// layer, or nil if the layer or variable is not found.
+func (ev *NetEnv) State(element string) tensor.Values {
+	ly, vnm := ev.layerVar(element)
+	if ly == nil {
+		return nil
+	}
+	tsr := tensor.NewFloat32()
+	if err := ly.AsEmer().UnitValuesTensor(tsr, vnm, ev.Di); err != nil {
+		return nil
+	}
+	return tsr
+}
+
+// Action is not used, as the network is run by its own sim.
+func (ev *NetEnv) Action(element string, input tensor.Values) {}
+
+// StateShape returns the shape of the layer of given element,
+// or nil if not found, implementing [env.Shaper].
+func (ev *NetEnv) StateShape(element string) []int {
+	ly, vnm := ev.layerVar(element)
+	if ly == nil {
+		return nil
+	}
+	if _, err := emer.UnitVarIndex(ly, vnm); err != nil {
+		return nil
+	}
+	return ly.AsEmer().Shape.Sizes
+}
+
+// ActionShape returns nil, as there are no Action elements.
+func (ev *NetEnv) ActionShape(element string) []int {
+	return nil
+}
+
+// Compile-time check that implements Env interface
+var _ env.Env = (*NetEnv)(nil)
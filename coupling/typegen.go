@@ -0,0 +1,11 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package coupling
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/coupling.Coupler", IDName: "coupler", Doc: "Coupler drives the external inputs of layers of a target network with\nthe State elements of a source env, which is typically a [NetEnv] for\nthe output layers of a source network in the same process, or a\nremoteenv.Env for a NetEnv served by another process. The Links bind each\nsource element (e.g., a layer name of the source network) to the target\nlayer that it drives, with an optional Transform, and Target links are\napplied as targets, only in the plus phase. Call Apply each trial or\ncycle, after the source network has been updated and the target inputs\nhave been initialized (e.g., InitExt).", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Fields: []types.Field{{Name: "Source", Doc: "Source is the env providing the values, e.g., a [NetEnv]."}, {Name: "Target", Doc: "Target is the network whose layers are driven."}, {Name: "Links", Doc: "Links bind each source element to the target layer that it drives."}, {Name: "ApplyFunc", Doc: "ApplyFunc applies the values to a target layer,\nwhich is specific to the algorithm of the target network."}, {Name: "Di", Doc: "Di is the data parallel index of the target network."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/coupling.NetEnv", IDName: "net-env", Doc: "NetEnv is an [env.Env] that exposes the current unit values of the layers\nof a network as its State elements, named by the layer name for the\ndefault Var, or as Layer:Var for other variables (e.g., Output:ActM),\nwith the shape of the layer, so that the network can drive the inputs of\nanother network as a source of a [Coupler], or be served to other\nprocesses with a remoteenv.Server. Step only increments the Trial counter,\nas the network itself is run by its own sim, which should call Step\neach time its outputs are updated, to synchronize with the consumers.", Fields: []types.Field{{Name: "Name", Doc: "Name is the name of the env, which is the network name if empty."}, {Name: "Net", Doc: "Net is the network whose layer values are exposed."}, {Name: "Var", Doc: "Var is the default unit variable for the layer values."}, {Name: "Di", Doc: "Di is the data parallel index of the values."}, {Name: "Trial", Doc: "Trial counts the Steps since Init."}}})
@@ -0,0 +1,76 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ddm
+
+import (
+	"math"
+	"testing"
+
+	"cogentcore.org/lab/table"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEZ(t *testing.T) {
+	// example from Wagenmakers et al. (2007)
+	pr, err := EZ(0.802, 0.112, 0.723, 0, 0.1)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.0999, pr.Drift, 0.0005)
+	assert.InDelta(t, 0.1400, pr.Bound, 0.0005)
+	assert.InDelta(t, 0.3, pr.NonDecision, 0.0005)
+	assert.InDelta(t, 0.723, pr.NonDecision+pr.MeanDecision, 1e-10)
+
+	neg, err := EZ(1-0.802, 0.112, 0.723, 0, 0.1)
+	assert.NoError(t, err)
+	assert.InDelta(t, -pr.Drift, neg.Drift, 1e-10)
+	assert.InDelta(t, pr.Bound, neg.Bound, 1e-10)
+
+	_, err = EZ(1, 0.112, 0.723, 0, 0.1)
+	assert.Error(t, err)
+	pr, err = EZ(1, 0.112, 0.723, 50, 0.1) // edge correction
+	assert.NoError(t, err)
+	assert.Greater(t, pr.Drift, 0.0)
+	_, err = EZ(0.8, 0, 0.723, 50, 0.1)
+	assert.Error(t, err)
+}
+
+func TestFitTable(t *testing.T) {
+	dt := table.New()
+	dt.AddStringColumn("Cond")
+	dt.AddFloat64Column("Correct")
+	dt.AddFloat64Column("RT")
+	type trl struct {
+		cond    string
+		correct float64
+		rt      float64
+	}
+	trls := []trl{{"Easy", 1, 10}, {"Hard", 1, 30}, {"Easy", 1, 12}, {"Hard", 0, 40},
+		{"Easy", 1, 14}, {"Hard", 1, 34}, {"Easy", 0, 20}, {"Hard", 1, 38}, {"Easy", 1, -1}}
+	dt.SetNumRows(len(trls))
+	for i, tr := range trls {
+		dt.Column("Cond").SetStringRow(tr.cond, i, 0)
+		dt.Column("Correct").SetFloatRow(tr.correct, i, 0)
+		dt.Column("RT").SetFloatRow(tr.rt, i, 0)
+	}
+	dds, err := Conditions(dt, "Cond", "Correct", "RT")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(dds))
+	assert.Equal(t, "Easy", dds[0].Condition)
+	assert.Equal(t, 4, dds[0].N)
+	assert.Equal(t, 0.75, dds[0].PCorrect)
+	assert.Equal(t, 12.0, dds[0].MeanRT)
+	assert.Equal(t, 4.0, dds[0].VarRT)
+
+	ft, err := FitTable(dt, "Cond", "Correct", "RT", 0.1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ft.NumRows())
+	assert.Equal(t, "Hard", ft.Column("Condition").StringRow(1, 0))
+	ed := ft.Column("Drift").FloatRow(0, 0)
+	hd := ft.Column("Drift").FloatRow(1, 0)
+	assert.False(t, math.IsNaN(ed))
+	assert.Greater(t, ed, hd) // less RT variance at same accuracy = higher drift
+
+	_, err = FitTable(dt, "Cond", "Correct", "Missing", 0.1)
+	assert.Error(t, err)
+}
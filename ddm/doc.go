@@ -0,0 +1,18 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package ddm provides drift-diffusion model (DDM) analysis of choice and
+reaction time (RT) data, e.g., as recorded from a decision-making model,
+so that the model's behavior can be characterized with the same
+drift rate, boundary separation, and non-decision time parameters that
+are used to characterize human and animal behavior.
+
+The EZ-diffusion model (Wagenmakers, van der Maas, & Grasman, 2007)
+provides closed-form estimates of these parameters from the accuracy,
+and the mean and variance of the correct RTs, in each condition.
+*/
+package ddm
+
+//go:generate core generate -add-types
@@ -0,0 +1,200 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ddm
+
+import (
+	"fmt"
+	"math"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+// Params are the drift-diffusion model parameters for a condition,
+// as estimated by [EZ].
+type Params struct {
+
+	// Drift is the drift rate (v), which reflects the quality of the
+	// evidence, or the ability of the decision maker.
+	Drift float64
+
+	// Bound is the boundary separation (a), which reflects response
+	// caution, i.e., the speed-accuracy tradeoff.
+	Bound float64
+
+	// NonDecision is the non-decision time (Ter), for encoding and
+	// response execution, in the same units as the RTs.
+	NonDecision float64
+
+	// MeanDecision is the mean decision time, MRT - NonDecision.
+	MeanDecision float64
+}
+
+// String returns a summary of the parameters.
+func (pr *Params) String() string {
+	return fmt.Sprintf("Drift: %.4g, Bound: %.4g, NonDecision: %.4g", pr.Drift, pr.Bound, pr.NonDecision)
+}
+
+// EZ returns the EZ-diffusion (Wagenmakers et al., 2007) estimates of the
+// drift-diffusion parameters, from given proportion correct (pc), and the
+// variance (vrt) and mean (mrt) of the correct RTs, with given number of
+// trials (n), which is used for the edge correction when pc is 0.5 or 1
+// (set to 0 to skip it), and given scaling parameter s (the standard
+// deviation of the drift noise, conventionally 0.1). Accuracy below
+// chance results in a negative drift rate.
+func EZ(pc, vrt, mrt float64, n int, s float64) (Params, error) {
+	if vrt <= 0 {
+		return Params{}, fmt.Errorf("ddm.EZ: RT variance must be > 0: %g", vrt)
+	}
+	if n > 0 {
+		switch {
+		case pc >= 1:
+			pc = 1 - 1/(2*float64(n))
+		case pc <= 0:
+			pc = 1 / (2 * float64(n))
+		case pc == 0.5:
+			pc = 0.5 + 1/(2*float64(n))
+		}
+	}
+	if pc <= 0 || pc >= 1 || pc == 0.5 {
+		return Params{}, fmt.Errorf("ddm.EZ: proportion correct must be in (0, 1) and not 0.5: %g", pc)
+	}
+	s2 := s * s
+	l := math.Log(pc / (1 - pc))
+	x := l * (l*pc*pc - l*pc + pc - 0.5) / vrt
+	v := math.Copysign(s*math.Pow(x, 0.25), pc-0.5)
+	a := s2 * l / v
+	y := -v * a / s2
+	mdt := (a / (2 * v)) * (1 - math.Exp(y)) / (1 + math.Exp(y))
+	return Params{Drift: v, Bound: a, NonDecision: mrt - mdt, MeanDecision: mdt}, nil
+}
+
+// Data has the choice and RT data for one condition, for [EZ].
+type Data struct {
+
+	// Condition is the name of the condition.
+	Condition string
+
+	// N is the number of trials.
+	N int
+
+	// PCorrect is the proportion of correct trials.
+	PCorrect float64
+
+	// MeanRT is the mean of the correct RTs.
+	MeanRT float64
+
+	// VarRT is the (n-1) sample variance of the correct RTs.
+	VarRT float64
+}
+
+// Fit returns the EZ-diffusion parameters for the data,
+// with given scaling parameter s (conventionally 0.1).
+func (dd *Data) Fit(s float64) (Params, error) {
+	return EZ(dd.PCorrect, dd.VarRT, dd.MeanRT, dd.N, s)
+}
+
+// Conditions returns the [Data] for each condition from given table of
+// trials, with given columns for the condition (use "" for a single
+// condition), whether the response was correct (> 0), and the RT,
+// in the order the conditions are first encountered. Trials with a
+// negative RT (e.g., no response, as in estats.RTStat) are excluded.
+func Conditions(dt *table.Table, condCol, correctCol, rtCol string) ([]*Data, error) {
+	var cc *tensor.Rows
+	if condCol != "" {
+		if cc = dt.Column(condCol); cc == nil {
+			return nil, fmt.Errorf("ddm.Conditions: condition column %q not found", condCol)
+		}
+	}
+	ok := dt.Column(correctCol)
+	if ok == nil {
+		return nil, fmt.Errorf("ddm.Conditions: correct column %q not found", correctCol)
+	}
+	rc := dt.Column(rtCol)
+	if rc == nil {
+		return nil, fmt.Errorf("ddm.Conditions: RT column %q not found", rtCol)
+	}
+	var dds []*Data
+	idx := make(map[string]int)
+	rts := make(map[string][]float64)
+	for ri := range dt.NumRows() {
+		rt := rc.FloatRow(ri, 0)
+		if rt < 0 || math.IsNaN(rt) {
+			continue
+		}
+		cond := ""
+		if cc != nil {
+			cond = cc.StringRow(ri, 0)
+		}
+		di, has := idx[cond]
+		if !has {
+			di = len(dds)
+			idx[cond] = di
+			dds = append(dds, &Data{Condition: cond})
+		}
+		dd := dds[di]
+		dd.N++
+		if ok.FloatRow(ri, 0) > 0 {
+			dd.PCorrect++
+			rts[cond] = append(rts[cond], rt)
+		}
+	}
+	for _, dd := range dds {
+		dd.PCorrect /= float64(dd.N)
+		crt := rts[dd.Condition]
+		nc := float64(len(crt))
+		if nc == 0 {
+			continue
+		}
+		for _, rt := range crt {
+			dd.MeanRT += rt
+		}
+		dd.MeanRT /= nc
+		if nc < 2 {
+			continue
+		}
+		for _, rt := range crt {
+			dd.VarRT += (rt - dd.MeanRT) * (rt - dd.MeanRT)
+		}
+		dd.VarRT /= nc - 1
+	}
+	return dds, nil
+}
+
+// FitTable fits the EZ-diffusion model to the trials in given table, per
+// condition, as in [Conditions], with given scaling parameter s
+// (conventionally 0.1), returning a table with columns Condition, N,
+// PCorrect, MeanRT, VarRT, Drift, Bound, and NonDecision. Conditions that
+// cannot be fit (e.g., fewer than 2 correct trials) have NaN parameters.
+func FitTable(dt *table.Table, condCol, correctCol, rtCol string, s float64) (*table.Table, error) {
+	dds, err := Conditions(dt, condCol, correctCol, rtCol)
+	if err != nil {
+		return nil, err
+	}
+	rt := table.New()
+	metadata.SetName(rt, "EZ-Diffusion")
+	tensor.SetPrecision(rt, 4)
+	rt.AddStringColumn("Condition")
+	rt.AddIntColumn("N")
+	fcols := []string{"PCorrect", "MeanRT", "VarRT", "Drift", "Bound", "NonDecision"}
+	for _, nm := range fcols {
+		rt.AddFloat64Column(nm)
+	}
+	rt.SetNumRows(len(dds))
+	for ri, dd := range dds {
+		pr, err := dd.Fit(s)
+		if err != nil {
+			nan := math.NaN()
+			pr = Params{Drift: nan, Bound: nan, NonDecision: nan}
+		}
+		rt.Column("Condition").SetStringRow(dd.Condition, ri, 0)
+		rt.Column("N").SetIntRow(dd.N, ri, 0)
+		for ci, v := range []float64{dd.PCorrect, dd.MeanRT, dd.VarRT, pr.Drift, pr.Bound, pr.NonDecision} {
+			rt.Column(fcols[ci]).SetFloatRow(v, ri, 0)
+		}
+	}
+	return rt, nil
+}
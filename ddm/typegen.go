@@ -0,0 +1,11 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package ddm
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/ddm.Params", IDName: "params", Doc: "Params are the drift-diffusion model parameters for a condition,\nas estimated by [EZ].", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Fields: []types.Field{{Name: "Drift", Doc: "Drift is the drift rate (v), which reflects the quality of the\nevidence, or the ability of the decision maker."}, {Name: "Bound", Doc: "Bound is the boundary separation (a), which reflects response\ncaution, i.e., the speed-accuracy tradeoff."}, {Name: "NonDecision", Doc: "NonDecision is the non-decision time (Ter), for encoding and\nresponse execution, in the same units as the RTs."}, {Name: "MeanDecision", Doc: "MeanDecision is the mean decision time, MRT - NonDecision."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/ddm.Data", IDName: "data", Doc: "Data has the choice and RT data for one condition, for [EZ].", Fields: []types.Field{{Name: "Condition", Doc: "Condition is the name of the condition."}, {Name: "N", Doc: "N is the number of trials."}, {Name: "PCorrect", Doc: "PCorrect is the proportion of correct trials."}, {Name: "MeanRT", Doc: "MeanRT is the mean of the correct RTs."}, {Name: "VarRT", Doc: "VarRT is the (n-1) sample variance of the correct RTs."}}})
@@ -0,0 +1,148 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package deep
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/emer/emergent/v2/relpos"
+	"github.com/stretchr/testify/assert"
+)
+
+// testNet is a bp network, built using the registered "deeptest"
+// algorithm, whose layers and pathways have the deep type names
+// given when they are added, as bp does not have these types.
+type testNet struct {
+	*bp.Network
+	lays []*testLayer
+}
+
+type testLayer struct {
+	*bp.Layer
+	typ  string
+	recv []*testPath
+}
+
+type testPath struct {
+	*bp.Path
+	typ string
+}
+
+func (nt *testNet) NumLayers() int               { return len(nt.lays) }
+func (nt *testNet) EmerLayer(idx int) emer.Layer { return nt.lays[idx] }
+func (ly *testLayer) TypeName() string           { return ly.typ }
+func (ly *testLayer) NumRecvPaths() int          { return len(ly.recv) }
+func (ly *testLayer) RecvPath(idx int) emer.Path { return ly.recv[idx] }
+func (pt *testPath) TypeName() string            { return pt.typ }
+
+func init() {
+	emer.RegisterAlgorithm(&emer.Algorithm{
+		Name: "deeptest",
+		NewNetwork: func(name string) emer.Network {
+			return &testNet{Network: bp.NewNetwork(name)}
+		},
+		AddLayer: func(net emer.Network, name string, shape []int, typ string) (emer.Layer, error) {
+			nt := net.(*testNet)
+			ly := &testLayer{Layer: nt.AddLayer(name, shape, bp.HiddenLayer), typ: typ}
+			nt.lays = append(nt.lays, ly)
+			return ly, nil
+		},
+		ConnectLayers: func(net emer.Network, send, recv emer.Layer, pat paths.Pattern, typ string) (emer.Path, error) {
+			sl, rl := send.(*testLayer), recv.(*testLayer)
+			pt := &testPath{Path: net.(*testNet).ConnectLayers(sl.Layer, rl.Layer, pat, bp.RecurrentPath), typ: typ}
+			rl.recv = append(rl.recv, pt)
+			return pt, nil
+		},
+	})
+	// the bp target is the plus phase activity
+	emer.AddVarAliases("bp", map[string]string{"ActM": "Act", "ActP": "Ext"}, nil)
+}
+
+func TestAddDeep(t *testing.T) {
+	net, err := emer.NewNetwork("deeptest", "test")
+	assert.NoError(t, err)
+	alg, _ := emer.AlgorithmByName("deeptest")
+	hid, _ := alg.AddLayer(net, "Hidden", []int{2, 3, 4, 4}, "SuperLayer")
+
+	wr := NewWiring("deeptest")
+	bursts := map[string]int{"Hidden": 0x8}
+	wr.BurstQtr = func(ly emer.Layer) int { return bursts[ly.Label()] }
+	dp, trc, err := wr.AddDeep(net, hid)
+	assert.NoError(t, err)
+	assert.Equal(t, "HiddenD", dp.Label())
+	assert.Equal(t, "DeepLayer", dp.TypeName())
+	assert.Equal(t, "HiddenTRC", trc.Label())
+	assert.Equal(t, []int{2, 3, 4, 4}, trc.AsEmer().Shape.Sizes)
+	assert.Equal(t, relpos.Behind, trc.AsEmer().Pos.Rel)
+	assert.Equal(t, "HiddenD", trc.AsEmer().Pos.Other)
+	assert.Equal(t, "BurstCtxt", dp.RecvPath(0).TypeName())
+	assert.Equal(t, "BurstTRC", trc.RecvPath(0).TypeName())
+	assert.Equal(t, "HiddenDToHidden", hid.RecvPath(0).AsEmer().Name)
+	assert.NoError(t, wr.Validate(net))
+
+	_, _, err = wr.AddDeep(net, hid)
+	assert.ErrorContains(t, err, `layer "HiddenD" already exists`)
+	_, _, err = NewWiring("foo").AddDeep(net, hid)
+	assert.Error(t, err)
+	// bp has no deep layer types
+	bnet, err := emer.NewNetwork("bp", "bp")
+	assert.NoError(t, err)
+	balg, _ := emer.AlgorithmByName("bp")
+	bhid, err := balg.AddLayer(bnet, "Hidden", []int{2, 2}, "HiddenLayer")
+	assert.NoError(t, err)
+	_, _, err = NewWiring("bp").AddDeep(bnet, bhid)
+	assert.ErrorContains(t, err, "DeepLayer")
+
+	// misconfigurations
+	bursts["Hidden"] = 0
+	in, _ := alg.AddLayer(net, "Input", []int{5, 5}, "SuperLayer")
+	alg.AddLayer(net, "InputD", []int{5, 5}, "DeepLayer")
+	itrc, _ := alg.AddLayer(net, "InputTRC", []int{4, 4}, "TRCLayer")
+	alg.ConnectLayers(net, in, itrc, paths.NewOneToOne(), "BurstTRC")
+	err = wr.Validate(net)
+	assert.ErrorContains(t, err, "BurstTRC pathway InputToInputTRC is one-to-one, but sending layer Input has 25 units and receiving layer InputTRC has 16")
+	assert.ErrorContains(t, err, "DeepLayer layer InputD does not receive a BurstCtxt pathway")
+	assert.ErrorContains(t, err, "Super layer Hidden sends deep pathways but BurstQtr is not set")
+	assert.ErrorContains(t, err, "Super layer Input sends deep pathways")
+
+	alg.AddLayer(net, "OutTRC", []int{5, 5}, "TRCLayer")
+	assert.ErrorContains(t, wr.Validate(net), "TRCLayer layer OutTRC does not receive a BurstTRC driver pathway")
+}
+
+func TestVars(t *testing.T) {
+	props := map[string]string{"DeepAttn": `min:"0" max:"2"`}
+	nms := AddUnitVars([]string{"Act", "AttnGe"}, props)
+	assert.Equal(t, []string{"Act", "AttnGe", "DeepAttn", "DeepLrn", "PredErr"}, nms)
+	assert.Equal(t, `min:"0" max:"2"`, props["DeepAttn"])
+	assert.Contains(t, props["PredErr"], `zeroctr:"+"`)
+	assert.Len(t, AddUnitVars(nil, nil), len(UnitVars))
+	for _, vn := range UnitVars {
+		assert.Contains(t, UnitVarProps[vn], `cat:"Deep"`)
+	}
+
+	net, _ := emer.NewNetwork("deeptest", "test")
+	alg, _ := emer.AlgorithmByName("deeptest")
+	hid, _ := alg.AddLayer(net, "Hidden", []int{2, 2}, "SuperLayer")
+	_, trc, err := NewWiring("deeptest").AddDeep(net, hid)
+	assert.NoError(t, err)
+	off, _ := alg.AddLayer(net, "OffTRC", []int{2, 2}, "TRCLayer")
+	off.AsEmer().Off = true
+	assert.Equal(t, []string{"HiddenTRC"}, PredErrLayers(net, "TRCLayer"))
+
+	assert.NoError(t, net.(*testNet).Build())
+	copy(trc.(*testLayer).Act, []float32{0, 0.5, 1, 0})
+	copy(trc.(*testLayer).Ext, []float32{1, 0.5, 0, float32(math.NaN())})
+	pe, err := PredErr(trc, 0)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.0/3.0, pe, 1.0e-6)
+	stats := map[string]float64{}
+	assert.NoError(t, SetPredErrStats(net, "TRCLayer", 0, "Trl", func(name string, val float64) { stats[name] = val }))
+	assert.InDelta(t, 2.0/3.0, stats["TrlHiddenTRC_PredErr"], 1.0e-6)
+	assert.Len(t, stats, 1)
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package deep provides helpers for configuring the DeepLeabra architecture
+of cortical Super, Deep, and TRC (thalamic relay cell, Pulvinar) layers,
+with their standard BurstCtxt, BurstTRC, and DeepAttn pathways, using the
+constructors of a registered algorithm (see [emer.RegisterAlgorithm]),
+so that the wiring is consistent and common misconfigurations are
+caught with clear errors.
+*/
+package deep
+
+//go:generate core generate -add-types
@@ -0,0 +1,112 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package deep
+
+import (
+	"math"
+	"slices"
+
+	"github.com/emer/emergent/v2/emer"
+)
+
+// UnitVars are the standard deep unit variables for attentional
+// modulation and prediction error, which algorithms implementing
+// deep layers add to their UnitVarNames using [AddUnitVars],
+// so that they can be viewed and logged without custom plumbing:
+//   - DeepAttn: attentional modulation from DeepAttn pathways (0-1).
+//   - DeepLrn: attentional modulation of learning (0-1).
+//   - AttnGe: excitatory conductance from DeepAttn pathways.
+//   - PredErr: prediction error of TRC units, plus - minus phase activity.
+var UnitVars = []string{"DeepAttn", "DeepLrn", "AttnGe", "PredErr"}
+
+// UnitVarProps are the NetView display properties of the [UnitVars],
+// with default color ranges, all in the [VarCategory].
+var UnitVarProps = map[string]string{
+	"DeepAttn": `cat:"Deep" min:"0" max:"1" desc:"attentional modulation from DeepAttn pathways, which multiplies activity"`,
+	"DeepLrn":  `cat:"Deep" min:"0" max:"1" desc:"attentional modulation of learning from DeepAttn pathways"`,
+	"AttnGe":   `cat:"Deep" auto-scale:"+" desc:"excitatory conductance from DeepAttn pathways"`,
+	"PredErr":  `cat:"Deep" zeroctr:"+" range:"1" desc:"prediction error of TRC units: plus - minus phase activity"`,
+}
+
+// VarCategory is the NetView variable category of the [UnitVars].
+var VarCategory = emer.VarCategory{Cat: "Deep", Doc: "deep attentional modulation and TRC prediction error variables"}
+
+// AddUnitVars returns given unit variable names with the [UnitVars]
+// appended, skipping any already present, and adds the [UnitVarProps]
+// to given props map, if not nil, for any variables without props.
+// This is used by algorithms in their UnitVarNames and UnitVarProps,
+// along with [VarCategory] in their VarCategories.
+func AddUnitVars(names []string, props map[string]string) []string {
+	for _, vn := range UnitVars {
+		if !slices.Contains(names, vn) {
+			names = append(names, vn)
+		}
+		if _, has := props[vn]; props != nil && !has {
+			props[vn] = UnitVarProps[vn]
+		}
+	}
+	return names
+}
+
+// PredErr returns the prediction error of given TRC layer for given data
+// parallel index, as the mean absolute difference between the plus and
+// minus phase activity (canonical ActP and ActM variables) over units,
+// skipping NaN values.
+func PredErr(ly emer.Layer, di int) (float64, error) {
+	lb := ly.AsEmer()
+	var m, p []float32
+	if err := lb.UnitValues(&m, "ActM", di); err != nil {
+		return math.NaN(), err
+	}
+	if err := lb.UnitValues(&p, "ActP", di); err != nil {
+		return math.NaN(), err
+	}
+	sum := 0.0
+	n := 0
+	for i := range m {
+		d := float64(p[i] - m[i])
+		if math.IsNaN(d) {
+			continue
+		}
+		sum += math.Abs(d)
+		n++
+	}
+	if n == 0 {
+		return math.NaN(), nil
+	}
+	return sum / float64(n), nil
+}
+
+// PredErrLayers returns the names of the TRC layers in given network,
+// which are the layers of the given TRC layer type name that are not Off.
+func PredErrLayers(net emer.Network, trcType string) []string {
+	var lays []string
+	for li := range net.NumLayers() {
+		ly := net.EmerLayer(li)
+		if ly.TypeName() == trcType && !ly.AsEmer().Off {
+			lays = append(lays, ly.Label())
+		}
+	}
+	return lays
+}
+
+// SetPredErrStats calls the given set function with the [PredErr] of each
+// TRC layer in given network (of given TRC layer type name), for given
+// data parallel index, named prefix + layer name + "_PredErr",
+// e.g., for recording in the stats for logging every trial.
+func SetPredErrStats(net emer.Network, trcType string, di int, prefix string, set func(name string, val float64)) error {
+	for _, lnm := range PredErrLayers(net, trcType) {
+		ly, err := net.AsEmer().EmerLayerByName(lnm)
+		if err != nil {
+			return err
+		}
+		pe, err := PredErr(ly, di)
+		if err != nil {
+			return err
+		}
+		set(prefix+lnm+"_PredErr", pe)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package deep
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/paths"
+)
+
+// Wiring creates the Deep and TRC layers for Super layers, with the
+// standard pathways among them, using the constructors of a registered
+// algorithm, and validates the resulting configuration. For a Super layer
+// named Name, the Deep layer is named Name + DeepSuffix, and is placed
+// behind the Super layer, and the TRC layer is named Name + TRCSuffix,
+// and is placed behind the Deep layer. The Super layer sends a one-to-one
+// BurstCtxt pathway to the Deep layer, and a one-to-one BurstTRC pathway
+// to the TRC layer, as its driver, and the Deep layer sends a one-to-one
+// DeepAttn pathway back to the Super layer. The type names default to
+// those of DeepLeabra, and can be changed for other algorithms.
+type Wiring struct {
+
+	// Algorithm is the name of the registered algorithm
+	// used to create the layers and pathways.
+	Algorithm string
+
+	// DeepType is the layer type name for Deep layers.
+	DeepType string
+
+	// TRCType is the layer type name for TRC (Pulvinar) layers.
+	TRCType string
+
+	// BurstCtxtType is the pathway type name for the
+	// Super to Deep context pathway.
+	BurstCtxtType string
+
+	// BurstTRCType is the pathway type name for the
+	// Super to TRC driver pathway.
+	BurstTRCType string
+
+	// DeepAttnType is the pathway type name for the
+	// Deep to Super attentional modulation pathway.
+	DeepAttnType string
+
+	// DeepSuffix is added to the name of the Super layer
+	// for the name of the Deep layer.
+	DeepSuffix string
+
+	// TRCSuffix is added to the name of the Super layer
+	// for the name of the TRC layer.
+	TRCSuffix string
+
+	// Space is the spacing between the Super, Deep and TRC layers.
+	Space float32
+
+	// BurstQtr, if set, returns the quarters in which given Super layer
+	// bursts, as bit flags, which is checked by Validate to be non-zero
+	// for all Super layers that send BurstCtxt or BurstTRC pathways.
+	BurstQtr func(super emer.Layer) int `display:"-"`
+}
+
+// NewWiring returns a new [Wiring] for given registered algorithm,
+// with the DeepLeabra type names and default naming conventions.
+func NewWiring(algo string) *Wiring {
+	return &Wiring{Algorithm: algo, DeepType: "DeepLayer", TRCType: "TRCLayer", BurstCtxtType: "BurstCtxt", BurstTRCType: "BurstTRC", DeepAttnType: "DeepAttn", DeepSuffix: "D", TRCSuffix: "TRC", Space: 2}
+}
+
+// AddDeep adds the Deep and TRC layers for given Super layer in given
+// network, with the same shape as the Super layer, and the standard
+// one-to-one BurstCtxt, BurstTRC and DeepAttn pathways.
+// Returns an error if the algorithm does not accept one of the type names
+// (e.g., DeepLayer for an algorithm without deep layers), in which case
+// the layers and pathways already added are left in the network.
+func (wr *Wiring) AddDeep(net emer.Network, super emer.Layer) (deep, trc emer.Layer, err error) {
+	alg, err := emer.AlgorithmByName(wr.Algorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+	if alg.AddLayer == nil || alg.ConnectLayers == nil {
+		return nil, nil, fmt.Errorf("deep.AddDeep: algorithm %q does not define AddLayer and ConnectLayers", wr.Algorithm)
+	}
+	sb := super.AsEmer()
+	for _, nm := range []string{sb.Name + wr.DeepSuffix, sb.Name + wr.TRCSuffix} {
+		if _, err := net.AsEmer().EmerLayerByName(nm); err == nil {
+			return nil, nil, fmt.Errorf("deep.AddDeep: layer %q already exists in network", nm)
+		}
+	}
+	shp := sb.Shape.Sizes
+	if deep, err = alg.AddLayer(net, sb.Name+wr.DeepSuffix, shp, wr.DeepType); err != nil {
+		return nil, nil, fmt.Errorf("deep.AddDeep: %w", err)
+	}
+	deep.AsEmer().PlaceBehind(super, wr.Space)
+	if trc, err = alg.AddLayer(net, sb.Name+wr.TRCSuffix, shp, wr.TRCType); err != nil {
+		return nil, nil, fmt.Errorf("deep.AddDeep: %w", err)
+	}
+	trc.AsEmer().PlaceBehind(deep, wr.Space)
+	pts := []struct {
+		send, recv emer.Layer
+		typ        string
+	}{{super, deep, wr.BurstCtxtType}, {super, trc, wr.BurstTRCType}, {deep, super, wr.DeepAttnType}}
+	for _, pt := range pts {
+		if _, err := alg.ConnectLayers(net, pt.send, pt.recv, paths.NewOneToOne(), pt.typ); err != nil {
+			return nil, nil, fmt.Errorf("deep.AddDeep: %w", err)
+		}
+	}
+	net.AsEmer().UpdateLayerNameMap()
+	return deep, trc, nil
+}
+
+// Validate checks the deep configuration of given network for common
+// misconfigurations, returning an error describing all of the problems
+// found: Deep layers without a BurstCtxt pathway, TRC layers without a
+// BurstTRC driver pathway, one-to-one BurstCtxt, BurstTRC and DeepAttn
+// pathways between layers with different numbers of units, and Super
+// layers that do not burst in any quarter (if BurstQtr is set).
+func (wr *Wiring) Validate(net emer.Network) error {
+	var errs []error
+	supers := make(map[string]emer.Layer)
+	for li := range net.NumLayers() {
+		ly := net.EmerLayer(li)
+		lnm := ly.Label()
+		var hasCtxt, hasDriver bool
+		for pi := range ly.NumRecvPaths() {
+			pt := ly.RecvPath(pi)
+			pb := pt.AsEmer()
+			if pb.Off {
+				continue
+			}
+			typ := pt.TypeName()
+			if typ != wr.BurstCtxtType && typ != wr.BurstTRCType && typ != wr.DeepAttnType {
+				continue
+			}
+			sl := pt.SendLayer()
+			switch typ {
+			case wr.BurstCtxtType:
+				hasCtxt = true
+				supers[sl.Label()] = sl
+			case wr.BurstTRCType:
+				hasDriver = true
+				supers[sl.Label()] = sl
+			}
+			if _, ok := pb.Pattern.(*paths.OneToOne); !ok {
+				continue
+			}
+			sn, rn := sl.AsEmer().NumUnits(), ly.AsEmer().NumUnits()
+			if sn != rn {
+				errs = append(errs, fmt.Errorf("deep: %s pathway %s is one-to-one, but sending layer %s has %d units and receiving layer %s has %d: the shapes must match", typ, pb.Name, sl.Label(), sn, lnm, rn))
+			}
+		}
+		switch ly.TypeName() {
+		case wr.DeepType:
+			if !hasCtxt {
+				errs = append(errs, fmt.Errorf("deep: %s layer %s does not receive a %s pathway from its Super layer", wr.DeepType, lnm, wr.BurstCtxtType))
+			}
+		case wr.TRCType:
+			if !hasDriver {
+				errs = append(errs, fmt.Errorf("deep: %s layer %s does not receive a %s driver pathway from a Super layer", wr.TRCType, lnm, wr.BurstTRCType))
+			}
+		}
+	}
+	if wr.BurstQtr != nil {
+		for li := range net.NumLayers() { // in layer order
+			ly := net.EmerLayer(li)
+			if _, ok := supers[ly.Label()]; ok && wr.BurstQtr(ly) == 0 {
+				errs = append(errs, fmt.Errorf("deep: Super layer %s sends deep pathways but BurstQtr is not set: it must burst in at least one quarter", ly.Label()))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
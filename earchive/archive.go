@@ -0,0 +1,325 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package earchive
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/emer/emergent/v2/emer"
+)
+
+const (
+	// ManifestFile is the name of the manifest in the archive.
+	ManifestFile = "manifest.json"
+
+	// IndexFile is the name of the checksum index in the archive, in the
+	// format of the sha256sum tool, so that extracted files can also be
+	// checked with sha256sum -c. It has all of the other files.
+	IndexFile = "SHA256SUMS"
+)
+
+// Entry is a file in an [Archive].
+type Entry struct {
+
+	// Name is the path of the file in the archive, using forward slashes.
+	Name string
+
+	// Source is the file on disk that is archived, if Data is nil.
+	Source string
+
+	// Data is the content of the file, if it is not from a Source file.
+	Data []byte
+}
+
+// Archive is a bundle of the results of a run, which is written as a
+// gzip compressed tar file by [Archive.Write], with the Manifest as
+// manifest.json first, then the entries, and the SHA256SUMS index last.
+// The standard entries are added with AddConfig (config.json), AddParams
+// (params.txt), AddWeights (weights.wts.gz) and AddLogs (logs/), and any
+// other files with AddFile, AddDir, AddData and AddJSON.
+type Archive struct {
+
+	// Manifest has the information about the run.
+	Manifest *Manifest
+
+	// Entries are the files in the archive, in order.
+	Entries []Entry
+}
+
+// New returns a new [Archive] for a run with given name,
+// with a [NewManifest].
+func New(name string) *Archive {
+	return &Archive{Manifest: NewManifest(name)}
+}
+
+// AddFile adds the given source file on disk, with given name in the archive.
+func (ar *Archive) AddFile(name, source string) {
+	ar.Entries = append(ar.Entries, Entry{Name: name, Source: source})
+}
+
+// AddData adds the given data, with given name in the archive.
+func (ar *Archive) AddData(name string, data []byte) {
+	ar.Entries = append(ar.Entries, Entry{Name: name, Data: data})
+}
+
+// AddJSON adds the given value in indented JSON format,
+// with given name in the archive.
+func (ar *Archive) AddJSON(name string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	ar.AddData(name, append(b, '\n'))
+	return nil
+}
+
+// AddDir adds all of the files in given directory on disk, recursively,
+// under given name in the archive, in lexical order.
+func (ar *Archive) AddDir(name, dir string) error {
+	return filepath.WalkDir(dir, func(fp string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, fp)
+		if err != nil {
+			return err
+		}
+		ar.AddFile(path.Join(name, filepath.ToSlash(rel)), fp)
+		return nil
+	})
+}
+
+// AddConfig adds the given config of the sim as config.json.
+func (ar *Archive) AddConfig(cfg any) error {
+	return ar.AddJSON("config.json", cfg)
+}
+
+// AddParams adds a listing of all of the parameters of the network
+// as params.txt.
+func (ar *Archive) AddParams(net emer.Network) {
+	ar.AddData("params.txt", []byte(net.AsEmer().AllParams()))
+}
+
+// AddWeights adds the current weights of the network as weights.wts.gz,
+// which can be opened with OpenWeightsJSON.
+func (ar *Archive) AddWeights(net emer.Network) error {
+	var b bytes.Buffer
+	gzw := gzip.NewWriter(&b)
+	if err := net.AsEmer().WriteWeightsJSON(gzw); err != nil {
+		return err
+	}
+	if err := gzw.Close(); err != nil {
+		return err
+	}
+	ar.AddData("weights.wts.gz", b.Bytes())
+	return nil
+}
+
+// AddLogs adds all of the files in given log directory under logs/.
+func (ar *Archive) AddLogs(dir string) error {
+	return ar.AddDir("logs", dir)
+}
+
+// Write writes the archive to given file, as a gzip compressed tar file
+// (typically ending in .tar.gz), with the manifest, entries and index.
+func (ar *Archive) Write(filename string) error {
+	names := map[string]bool{ManifestFile: true, IndexFile: true}
+	for _, e := range ar.Entries {
+		if !fs.ValidPath(e.Name) || e.Name == "." {
+			return fmt.Errorf("earchive.Write: invalid entry name %q", e.Name)
+		}
+		if names[e.Name] {
+			return fmt.Errorf("earchive.Write: duplicate entry name %q", e.Name)
+		}
+		names[e.Name] = true
+	}
+	mf, err := json.MarshalIndent(ar.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	fp, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	gzw := gzip.NewWriter(fp)
+	tw := tar.NewWriter(gzw)
+	var index bytes.Buffer
+	add := func(name string, data []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: ar.Manifest.Created}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+		if name != IndexFile {
+			sum := sha256.Sum256(data)
+			fmt.Fprintf(&index, "%s  %s\n", hex.EncodeToString(sum[:]), name)
+		}
+		return nil
+	}
+	if err := add(ManifestFile, append(mf, '\n')); err != nil {
+		return err
+	}
+	for _, e := range ar.Entries {
+		data := e.Data
+		if data == nil && e.Source != "" {
+			data, err = os.ReadFile(e.Source)
+			if err != nil {
+				return err
+			}
+		}
+		if err := add(e.Name, data); err != nil {
+			return err
+		}
+	}
+	if err := add(IndexFile, index.Bytes()); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gzw.Close(); err != nil {
+		return err
+	}
+	return fp.Close()
+}
+
+// readArchive calls fun for each regular file in the archive,
+// in order, with its name and content.
+func readArchive(filename string, fun func(name string, data []byte) error) error {
+	fp, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	gzr, err := gzip.NewReader(bufio.NewReader(fp))
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if err := fun(hdr.Name, data); err != nil {
+			return err
+		}
+	}
+}
+
+// Verify verifies the integrity of given archive file, checking that the
+// files in the archive are exactly those in its index, with the same
+// checksums, and returns its manifest. The error lists all of the files
+// that do not match.
+func Verify(filename string) (*Manifest, error) {
+	sums := make(map[string]string)
+	var index []byte
+	var mf *Manifest
+	err := readArchive(filename, func(name string, data []byte) error {
+		switch name {
+		case IndexFile:
+			index = data
+			return nil
+		case ManifestFile:
+			mf = &Manifest{}
+			if err := json.Unmarshal(data, mf); err != nil {
+				return fmt.Errorf("earchive.Verify: %s: %w", ManifestFile, err)
+			}
+		}
+		sum := sha256.Sum256(data)
+		sums[name] = hex.EncodeToString(sum[:])
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if index == nil {
+		return mf, fmt.Errorf("earchive.Verify: %s has no %s index", filename, IndexFile)
+	}
+	var errs []error
+	indexed := make(map[string]bool)
+	for _, ln := range strings.Split(strings.TrimSpace(string(index)), "\n") {
+		sum, name, ok := strings.Cut(ln, "  ")
+		if !ok {
+			errs = append(errs, fmt.Errorf("earchive.Verify: invalid index line %q", ln))
+			continue
+		}
+		indexed[name] = true
+		got, has := sums[name]
+		switch {
+		case !has:
+			errs = append(errs, fmt.Errorf("earchive.Verify: %s is missing", name))
+		case got != sum:
+			errs = append(errs, fmt.Errorf("earchive.Verify: %s checksum does not match", name))
+		}
+	}
+	var extra []string
+	for name := range sums {
+		if !indexed[name] {
+			extra = append(extra, name)
+		}
+	}
+	slices.Sort(extra)
+	for _, name := range extra {
+		errs = append(errs, fmt.Errorf("earchive.Verify: %s is not in the index", name))
+	}
+	if mf == nil {
+		errs = append(errs, fmt.Errorf("earchive.Verify: %s has no %s", filename, ManifestFile))
+	}
+	return mf, errors.Join(errs...)
+}
+
+// Restore verifies the given archive file, and if it is valid, extracts
+// all of its files into given directory, which is created if needed,
+// and returns its manifest.
+func Restore(filename, dir string) (*Manifest, error) {
+	mf, err := Verify(filename)
+	if err != nil {
+		return mf, err
+	}
+	err = readArchive(filename, func(name string, data []byte) error {
+		if !fs.ValidPath(name) {
+			return fmt.Errorf("earchive.Restore: invalid file name %q", name)
+		}
+		fp := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(fp, data, 0644)
+	})
+	return mf, err
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/emer/emergent/v2/earchive"
+)
+
+// Create creates the archive with the given files of a run.
+// The manifest records the build information of earchive itself,
+// so sims should call earchive.New directly when the build of the sim
+// needs to be recorded.
+func Create(c *Config) error { //types:add
+	if c.Archive == "" {
+		return errors.New("earchive: the archive file must be given")
+	}
+	name := c.Name
+	if name == "" {
+		name = strings.TrimSuffix(strings.TrimSuffix(filepath.Base(c.Archive), ".gz"), ".tar")
+	}
+	ar := earchive.New(name)
+	ar.Manifest.Description = c.Description
+	if c.Config != "" {
+		ar.AddFile(filepath.Base(c.Config), c.Config)
+	}
+	if c.Params != "" {
+		ar.AddFile("params.txt", c.Params)
+	}
+	if c.Weights != "" {
+		ar.AddFile(filepath.Base(c.Weights), c.Weights)
+	}
+	if c.Logs != "" {
+		if err := ar.AddLogs(c.Logs); err != nil {
+			return err
+		}
+	}
+	for _, f := range c.Files {
+		st, err := os.Stat(f)
+		if err != nil {
+			return err
+		}
+		if st.IsDir() {
+			if err := ar.AddDir(filepath.Base(f), f); err != nil {
+				return err
+			}
+		} else {
+			ar.AddFile(filepath.Base(f), f)
+		}
+	}
+	if err := ar.Write(c.Archive); err != nil {
+		return err
+	}
+	fmt.Printf("%s: %d files\n", c.Archive, len(ar.Entries)+2)
+	return nil
+}
+
+// Verify verifies the checksums of all of the files in the archive.
+func Verify(c *Config) error { //types:add
+	mf, err := earchive.Verify(c.Archive)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s: OK (%s, created %s)\n", c.Archive, mf.Name, mf.Created.Format("2006-01-02 15:04:05"))
+	return nil
+}
+
+// Restore verifies the archive and extracts its files into Dir.
+func Restore(c *Config) error { //types:add
+	mf, err := earchive.Restore(c.Archive, c.Dir)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s: restored %s to %s\n", c.Archive, mf.Name, c.Dir)
+	return nil
+}
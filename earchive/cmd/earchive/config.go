@@ -0,0 +1,41 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+type Config struct { //types:add
+
+	// Archive is the archive file (typically ending in .tar.gz).
+	Archive string `posarg:"0"`
+
+	// Name is the name of the run, recorded in the manifest by the
+	// create command. It defaults to the archive file name.
+	Name string
+
+	// Description is a description of the run, recorded in the manifest
+	// by the create command.
+	Description string `flag:"d,description"`
+
+	// Config is the config file of the run, which is added as is
+	// (e.g., config.toml) by the create command.
+	Config string
+
+	// Params is the params file of the run (e.g., from SaveAllParams),
+	// which is added as params.txt by the create command.
+	Params string
+
+	// Weights is the final weights file of the run, which is added
+	// as is (e.g., trained.wts.gz) by the create command.
+	Weights string
+
+	// Logs is the directory with the log files of the run, which are
+	// added under logs/ by the create command.
+	Logs string
+
+	// Files are any other files or directories added by the create command.
+	Files []string `flag:"f,files"`
+
+	// Dir is the directory that the restore command extracts the files to.
+	Dir string `default:"."`
+}
@@ -0,0 +1,18 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command earchive creates, verifies, and restores reproducible archives
+// of simulation runs, bundling the manifest, config, params, weights,
+// and logs with a checksum index, for publication data deposits.
+package main
+
+import "cogentcore.org/core/cli"
+
+//go:generate core generate
+
+func main() {
+	opts := cli.DefaultOptions("earchive", "earchive creates, verifies, and restores reproducible archives of simulation runs.")
+	opts.PrintSuccess = false // commands print their own results
+	cli.Run(opts, &Config{}, Create, Verify, Restore)
+}
@@ -0,0 +1,15 @@
+// Code generated by "core generate"; DO NOT EDIT.
+
+package main
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "main.Config", IDName: "config", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Fields: []types.Field{{Name: "Archive", Doc: "Archive is the archive file (typically ending in .tar.gz)."}, {Name: "Name", Doc: "Name is the name of the run, recorded in the manifest by the\ncreate command. It defaults to the archive file name."}, {Name: "Description", Doc: "Description is a description of the run, recorded in the manifest\nby the create command."}, {Name: "Config", Doc: "Config is the config file of the run, which is added as is\n(e.g., config.toml) by the create command."}, {Name: "Params", Doc: "Params is the params file of the run (e.g., from SaveAllParams),\nwhich is added as params.txt by the create command."}, {Name: "Weights", Doc: "Weights is the final weights file of the run, which is added\nas is (e.g., trained.wts.gz) by the create command."}, {Name: "Logs", Doc: "Logs is the directory with the log files of the run, which are\nadded under logs/ by the create command."}, {Name: "Files", Doc: "Files are any other files or directories added by the create command."}, {Name: "Dir", Doc: "Dir is the directory that the restore command extracts the files to."}}})
+
+var _ = types.AddFunc(&types.Func{Name: "main.Create", Doc: "Create creates the archive with the given files of a run.\nThe manifest records the build information of earchive itself,\nso sims should call earchive.New directly when the build of the sim\nneeds to be recorded.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"c"}, Returns: []string{"error"}})
+
+var _ = types.AddFunc(&types.Func{Name: "main.Verify", Doc: "Verify verifies the checksums of all of the files in the archive.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"c"}, Returns: []string{"error"}})
+
+var _ = types.AddFunc(&types.Func{Name: "main.Restore", Doc: "Restore verifies the archive and extracts its files into Dir.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"c"}, Returns: []string{"error"}})
@@ -0,0 +1,14 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package earchive bundles the results of a simulation run into a single
+reproducible archive for publication data deposits: a manifest of the run
+(build, version control and host information), the config, params, final
+weights, and logs, in a gzip compressed tar file with a SHA256SUMS checksum
+index, which can be verified and restored.
+*/
+package earchive
+
+//go:generate core generate -add-types
@@ -0,0 +1,109 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package earchive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cogentcore.org/core/core"
+	"github.com/emer/emergent/v2/hebb"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+func testArchive(t *testing.T) (*Archive, *hebb.Network) {
+	net := hebb.NewNetwork("Test")
+	in := net.AddLayer2D("Input", 2, 2, hebb.InputLayer)
+	hid := net.AddLayer2D("Hidden", 2, 2, hebb.HiddenLayer)
+	net.ConnectLayers(in, hid, paths.NewFull())
+	assert.NoError(t, net.Build())
+	net.InitWeights()
+
+	logs := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(logs, "run0"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(logs, "epoch.tsv"), []byte("%Epoch\t#PctErr\n0\t1\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(logs, "run0", "trial.tsv"), []byte("%Trial\n0\n"), 0644))
+
+	ar := New("TestRun")
+	assert.Equal(t, "TestRun", ar.Manifest.Name)
+	assert.NoError(t, ar.AddConfig(map[string]any{"Epochs": 10}))
+	ar.AddParams(net)
+	assert.NoError(t, ar.AddWeights(net))
+	assert.NoError(t, ar.AddLogs(logs))
+	return ar, net
+}
+
+func TestArchive(t *testing.T) {
+	ar, net := testArchive(t)
+	fn := filepath.Join(t.TempDir(), "run.tar.gz")
+	assert.NoError(t, ar.Write(fn))
+
+	mf, err := Verify(fn)
+	assert.NoError(t, err)
+	assert.Equal(t, "TestRun", mf.Name)
+	assert.Equal(t, ar.Manifest.GoVersion, mf.GoVersion)
+
+	dir := t.TempDir()
+	_, err = Restore(fn, dir)
+	assert.NoError(t, err)
+	for _, f := range []string{ManifestFile, IndexFile, "config.json", "params.txt", "weights.wts.gz", "logs/epoch.tsv", "logs/run0/trial.tsv"} {
+		_, err := os.Stat(filepath.Join(dir, f))
+		assert.NoError(t, err, f)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "logs", "epoch.tsv"))
+	assert.NoError(t, err)
+	assert.Equal(t, "%Epoch\t#PctErr\n0\t1\n", string(b))
+	assert.NoError(t, net.AsEmer().OpenWeightsJSON(core.Filename(filepath.Join(dir, "weights.wts.gz"))))
+
+	ar.AddData("params.txt", nil)
+	assert.Error(t, ar.Write(fn))
+}
+
+func TestVerifyCorrupt(t *testing.T) {
+	ar, _ := testArchive(t)
+	fn := filepath.Join(t.TempDir(), "run.tar.gz")
+	assert.NoError(t, ar.Write(fn))
+
+	// rewrite the archive with a modified log file and an extra file
+	var names []string
+	var datas [][]byte
+	err := readArchive(fn, func(name string, data []byte) error {
+		if name == "logs/epoch.tsv" {
+			data = []byte("tampered")
+		}
+		names = append(names, name)
+		datas = append(datas, data)
+		return nil
+	})
+	assert.NoError(t, err)
+	names = append(names, "extra.txt")
+	datas = append(datas, []byte("extra"))
+	bad := filepath.Join(t.TempDir(), "bad.tar.gz")
+	fp, err := os.Create(bad)
+	assert.NoError(t, err)
+	gzw := gzip.NewWriter(fp)
+	tw := tar.NewWriter(gzw)
+	for i, name := range names {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(datas[i]))}))
+		_, err := tw.Write(datas[i])
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gzw.Close())
+	assert.NoError(t, fp.Close())
+
+	_, err = Verify(bad)
+	assert.ErrorContains(t, err, "logs/epoch.tsv checksum does not match")
+	assert.ErrorContains(t, err, "extra.txt is not in the index")
+	dir := t.TempDir()
+	_, err = Restore(bad, dir)
+	assert.Error(t, err)
+	_, err = os.Stat(filepath.Join(dir, ManifestFile))
+	assert.True(t, os.IsNotExist(err))
+}
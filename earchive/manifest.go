@@ -0,0 +1,93 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package earchive
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// Manifest records the information needed to reproduce a run,
+// which is saved as manifest.json in the archive.
+type Manifest struct {
+
+	// Name is the name of the run, e.g., the sim name and run tag.
+	Name string
+
+	// Description is a description of the run.
+	Description string
+
+	// Created is the time the manifest was created.
+	Created time.Time
+
+	// Args are the command line arguments of the run.
+	Args []string
+
+	// Host is the name of the host the run was on.
+	Host string
+
+	// GoVersion is the version of Go the sim was built with.
+	GoVersion string
+
+	// OS is the operating system of the run.
+	OS string
+
+	// Arch is the architecture of the run.
+	Arch string
+
+	// Module is the path of the main module of the sim.
+	Module string
+
+	// Version is the version of the main module, if known.
+	Version string
+
+	// Revision is the version control revision the sim was built from.
+	Revision string
+
+	// RevisionTime is the time of the Revision.
+	RevisionTime string
+
+	// Modified is true if the source had uncommitted changes
+	// relative to the Revision when the sim was built.
+	Modified bool
+
+	// Deps are the module dependencies, as path@version.
+	Deps []string
+}
+
+// NewManifest returns a new [Manifest] with given name, filled in with
+// the build information of the running binary, its args and host.
+func NewManifest(name string) *Manifest {
+	mf := &Manifest{Name: name, Created: time.Now(), GoVersion: runtime.Version(), OS: runtime.GOOS, Arch: runtime.GOARCH}
+	if len(os.Args) > 1 {
+		mf.Args = os.Args[1:]
+	}
+	mf.Host, _ = os.Hostname()
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return mf
+	}
+	mf.Module = bi.Main.Path
+	mf.Version = bi.Main.Version
+	for _, st := range bi.Settings {
+		switch st.Key {
+		case "vcs.revision":
+			mf.Revision = st.Value
+		case "vcs.time":
+			mf.RevisionTime = st.Value
+		case "vcs.modified":
+			mf.Modified = st.Value == "true"
+		}
+	}
+	for _, dp := range bi.Deps {
+		if dp.Replace != nil {
+			dp = dp.Replace
+		}
+		mf.Deps = append(mf.Deps, dp.Path+"@"+dp.Version)
+	}
+	return mf
+}
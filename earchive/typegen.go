@@ -0,0 +1,13 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package earchive
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/earchive.Manifest", IDName: "manifest", Doc: "Manifest records the information needed to reproduce a run,\nwhich is saved as manifest.json in the archive.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Fields: []types.Field{{Name: "Name", Doc: "Name is the name of the run, e.g., the sim name and run tag."}, {Name: "Description", Doc: "Description is a description of the run."}, {Name: "Created", Doc: "Created is the time the manifest was created."}, {Name: "Args", Doc: "Args are the command line arguments of the run."}, {Name: "Host", Doc: "Host is the name of the host the run was on."}, {Name: "GoVersion", Doc: "GoVersion is the version of Go the sim was built with."}, {Name: "OS", Doc: "OS is the operating system of the run."}, {Name: "Arch", Doc: "Arch is the architecture of the run."}, {Name: "Module", Doc: "Module is the path of the main module of the sim."}, {Name: "Version", Doc: "Version is the version of the main module, if known."}, {Name: "Revision", Doc: "Revision is the version control revision the sim was built from."}, {Name: "RevisionTime", Doc: "RevisionTime is the time of the Revision."}, {Name: "Modified", Doc: "Modified is true if the source had uncommitted changes\nrelative to the Revision when the sim was built."}, {Name: "Deps", Doc: "Deps are the module dependencies, as path@version."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/earchive.Entry", IDName: "entry", Doc: "Entry is a file in an [Archive].", Fields: []types.Field{{Name: "Name", Doc: "Name is the path of the file in the archive, using forward slashes."}, {Name: "Source", Doc: "Source is the file on disk that is archived, if Data is nil."}, {Name: "Data", Doc: "Data is the content of the file, if it is not from a Source file."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/earchive.Archive", IDName: "archive", Doc: "Archive is a bundle of the results of a run, which is written as a\ngzip compressed tar file by [Archive.Write], with the Manifest as\nmanifest.json first, then the entries, and the SHA256SUMS index last.\nThe standard entries are added with AddConfig (config.json), AddParams\n(params.txt), AddWeights (weights.wts.gz) and AddLogs (logs/), and any\nother files with AddFile, AddDir, AddData and AddJSON.", Fields: []types.Field{{Name: "Manifest", Doc: "Manifest has the information about the run."}, {Name: "Entries", Doc: "Entries are the files in the archive, in order."}}})
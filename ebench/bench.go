@@ -0,0 +1,233 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/hebb"
+	"github.com/emer/emergent/v2/hybrid"
+	"github.com/emer/emergent/v2/paths"
+)
+
+// Report is the performance report.
+type Report struct {
+
+	// Time is the time the benchmark was run.
+	Time time.Time
+
+	// Version is the version of the emergent module.
+	Version string
+
+	// GoVersion is the version of Go that ebench was built with.
+	GoVersion string
+
+	// OS is the operating system.
+	OS string
+
+	// Arch is the architecture.
+	Arch string
+
+	// NumCPU is the number of CPUs.
+	NumCPU int
+
+	// Results are the results of each benchmark.
+	Results []Result
+}
+
+// Result is the result of one benchmark:
+// one algorithm, size, learning mode and thread count.
+type Result struct {
+
+	// Algo is the algorithm of the reference network.
+	Algo string
+
+	// Size is the number of units along each side of the layers.
+	Size int
+
+	// Units is the total number of units in each network.
+	Units int
+
+	// Synapses is the total number of synapses in each network.
+	Synapses int
+
+	// Learn is whether the trials include learning.
+	Learn bool
+
+	// Threads is the number of threads, and networks run in parallel.
+	Threads int
+
+	// Trials is the number of trials run on each network.
+	Trials int
+
+	// Seconds is the elapsed time.
+	Seconds float64
+
+	// TrialsPerSec is the total number of trials per second
+	// over all of the networks.
+	TrialsPerSec float64
+
+	// SynapsesPerSec is the total number of synapses processed
+	// per second: TrialsPerSec times Synapses.
+	SynapsesPerSec float64
+}
+
+// Bench runs the benchmark and writes the report.
+//
+//cli:cmd -root
+func Bench(c *Config) error { //types:add
+	algos, sizes, threads := c.Algos, c.Sizes, c.Threads
+	if len(algos) == 0 {
+		algos = []string{"bp", "hebb"}
+	}
+	if len(sizes) == 0 {
+		sizes = []int{10, 20, 40}
+	}
+	if len(threads) == 0 {
+		threads = []int{1}
+		if runtime.NumCPU() > 1 {
+			threads = append(threads, runtime.NumCPU())
+		}
+	}
+	rp := &Report{Time: time.Now(), GoVersion: runtime.Version(), OS: runtime.GOOS, Arch: runtime.GOARCH, NumCPU: runtime.NumCPU()}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		rp.Version = bi.Main.Version
+		for _, dp := range bi.Deps {
+			if dp.Path == "github.com/emer/emergent/v2" {
+				rp.Version = dp.Version
+			}
+		}
+	}
+	procs := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(procs)
+	for _, algo := range algos {
+		for _, size := range sizes {
+			for _, learn := range []bool{false, true} {
+				for _, nthr := range threads {
+					res, err := run(algo, size, learn, nthr, c.Trials)
+					if err != nil {
+						return err
+					}
+					rp.Results = append(rp.Results, *res)
+				}
+			}
+		}
+	}
+	b, err := json.MarshalIndent(rp, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	if c.Output == "" {
+		_, err = os.Stdout.Write(b)
+		return err
+	}
+	for _, r := range rp.Results {
+		fmt.Printf("%-5s size: %3d  learn: %-5v  threads: %2d  trials/sec: %10.1f  synapses/sec: %.3g\n", r.Algo, r.Size, r.Learn, r.Threads, r.TrialsPerSec, r.SynapsesPerSec)
+	}
+	return os.WriteFile(c.Output, b, 0666)
+}
+
+// newNetwork returns a new reference network for given algorithm and size,
+// with the layers to apply inputs to.
+func newNetwork(algo string, size int) (hybrid.Component, []string, error) {
+	switch algo {
+	case "bp":
+		net := bp.NewNetwork("Bench")
+		in := net.AddLayer2D("Input", size, size, bp.InputLayer)
+		hid := net.AddLayer2D("Hidden", size, size, bp.HiddenLayer)
+		out := net.AddLayer2D("Output", size, size, bp.TargetLayer)
+		net.ConnectLayers(in, hid, paths.NewFull(), bp.ForwardPath)
+		net.ConnectLayers(hid, out, paths.NewFull(), bp.ForwardPath)
+		return net, []string{"Input", "Output"}, net.Build()
+	case "hebb":
+		net := hebb.NewNetwork("Bench")
+		in := net.AddLayer2D("Input", size, size, hebb.InputLayer)
+		hid := net.AddLayer2D("Hidden", size, size, hebb.HiddenLayer)
+		net.ConnectLayers(in, hid, paths.NewFull())
+		return net, []string{"Input"}, net.Build()
+	}
+	return nil, nil, fmt.Errorf("ebench: unknown algorithm %q: must be bp or hebb", algo)
+}
+
+// run runs one benchmark.
+func run(algo string, size int, learn bool, nthr, trials int) (*Result, error) {
+	nets := make([]hybrid.Component, nthr)
+	var inputs []string
+	for i := range nets {
+		net, ins, err := newNetwork(algo, size)
+		if err != nil {
+			return nil, err
+		}
+		net.AsEmer().SetRandSeed(int64(i + 1))
+		net.InitWeights()
+		nets[i], inputs = net, ins
+	}
+	res := &Result{Algo: algo, Size: size, Learn: learn, Threads: nthr, Trials: trials}
+	for li := range nets[0].NumLayers() {
+		ly := nets[0].EmerLayer(li)
+		res.Units += ly.AsEmer().NumUnits()
+		for pi := range ly.NumRecvPaths() {
+			res.Synapses += ly.RecvPath(pi).NumSyns()
+		}
+	}
+	// random patterns, generated before timing
+	rnd := rand.New(rand.NewSource(1))
+	pats := make([][]float32, 10)
+	for i := range pats {
+		pats[i] = make([]float32, size*size)
+		for j := range pats[i] {
+			if rnd.Float32() < 0.2 {
+				pats[i][j] = 1
+			}
+		}
+	}
+	runtime.GOMAXPROCS(nthr)
+	runtime.GC()
+	errs := make([]error, nthr)
+	st := time.Now()
+	var wg sync.WaitGroup
+	for i, net := range nets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := net.AsEmer().NewContext()
+			for trl := range trials {
+				ctx.NewTrial()
+				net.NewTrial(ctx)
+				for ii, lnm := range inputs {
+					if err := net.ApplyInput(lnm, pats[(trl+ii)%len(pats)]); err != nil {
+						errs[i] = err
+						return
+					}
+				}
+				net.RunPhase(ctx)
+				if learn {
+					ctx.SetPhase(int(hybrid.PlusPhase), true)
+					net.RunPhase(ctx)
+					net.Learn(ctx)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	res.Seconds = time.Since(st).Seconds()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	res.TrialsPerSec = float64(nthr*trials) / res.Seconds
+	res.SynapsesPerSec = res.TrialsPerSec * float64(res.Synapses)
+	return res, nil
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+type Config struct { //types:add
+
+	// Algos are the algorithms of the reference networks: bp (an
+	// Input -> Hidden -> Output backprop network) and hebb (an Input ->
+	// Hidden CPCA network). The default is both.
+	Algos []string `flag:"a,algos"`
+
+	// Sizes are the sizes of the layers of the reference networks, as the
+	// number of units along each side of the square layers, which are
+	// fully connected. The default is 10, 20 and 40.
+	Sizes []int `flag:"s,sizes"`
+
+	// Threads are the numbers of threads, for each of which as many
+	// networks are run in parallel, with GOMAXPROCS set to the number of
+	// threads, so that the trials per second measure the throughput of
+	// the hardware. The default is 1 and the number of CPUs.
+	Threads []int `flag:"t,threads"`
+
+	// Trials is the number of trials run on each network,
+	// for each size and thread count, with and without learning.
+	Trials int `default:"100"`
+
+	// Output is the file that the JSON report is written to, in which
+	// case a summary is printed to standard output. If empty, the report
+	// is written to standard output.
+	Output string `flag:"o,output"`
+}
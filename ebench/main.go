@@ -0,0 +1,19 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command ebench runs a standardized speed benchmark on reference
+// networks of several sizes, with and without learning, across thread
+// counts, and emits a machine-readable JSON performance report, for
+// tracking performance across releases and hardware.
+package main
+
+import "cogentcore.org/core/cli"
+
+//go:generate core generate
+
+func main() {
+	opts := cli.DefaultOptions("ebench", "ebench runs a standardized speed benchmark on reference networks, and emits a JSON performance report.")
+	opts.PrintSuccess = false // output goes to stdout by default
+	cli.Run(opts, &Config{}, Bench)
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package egui
+
+import (
+	"reflect"
+
+	"cogentcore.org/core/base/errors"
+	"cogentcore.org/core/base/reflectx"
+	"cogentcore.org/core/core"
+	"cogentcore.org/core/events"
+	"cogentcore.org/core/icons"
+	"cogentcore.org/core/styles"
+	"cogentcore.org/core/tree"
+	"cogentcore.org/core/types"
+	"cogentcore.org/lab/lab"
+	"github.com/emer/emergent/v2/params"
+)
+
+// ConfigPanel is a structured settings panel for a sim Config struct,
+// generated by reflection, so that all of the config options are
+// available in the GUI without hand-built forms. There is a tab for each
+// struct-valued field (e.g., Params, Run, Log), and a General tab for
+// the other fields, all of which respect the display tags and the
+// ShouldDisplay conditional visibility methods. Edits are made on
+// a copy of the Config, which is only copied to the Config by Apply,
+// and can be discarded by Reset.
+type ConfigPanel struct {
+
+	// Config is a pointer to the config struct used by the sim.
+	Config any
+
+	// Edit is the working copy of the Config that is edited in the panel.
+	Edit any
+
+	// OnApply is called after Apply copies the edits to the Config,
+	// e.g., to reconfigure the parts of the sim that depend on them.
+	OnApply func()
+
+	// Frame contains the toolbar and the tabs.
+	Frame *core.Frame
+
+	// Tabs has a tab for each struct-valued field, and a General tab.
+	Tabs *core.Tabs
+}
+
+// AddConfigPanel adds a [ConfigPanel] for given Config struct pointer
+// in a tab with given name, calling the given onApply function (can be nil)
+// after the edits are applied to the Config.
+func (gui *GUI) AddConfigPanel(tabName string, cfg any, onApply func()) *ConfigPanel {
+	cp := &ConfigPanel{Config: cfg, OnApply: onApply}
+	lab.NewTab(gui.Tabs, tabName, func(tab *core.Frame) *core.Frame {
+		cp.Make(tab)
+		return cp.Frame
+	})
+	return cp
+}
+
+// NewConfigPanel returns a new [ConfigPanel] for given Config struct pointer,
+// made in given parent widget. See [GUI.AddConfigPanel] to add it in a tab.
+func NewConfigPanel(parent core.Widget, cfg any, onApply func()) *ConfigPanel {
+	cp := &ConfigPanel{Config: cfg, OnApply: onApply}
+	cp.Make(parent)
+	return cp
+}
+
+// Make makes the panel widgets in given parent.
+func (cp *ConfigPanel) Make(parent core.Widget) {
+	cp.Edit = reflect.New(reflectx.NonPointerType(reflect.TypeOf(cp.Config))).Interface()
+	copyConfig(cp.Edit, cp.Config)
+
+	cp.Frame = core.NewFrame(parent)
+	cp.Frame.Styler(func(s *styles.Style) {
+		s.Direction = styles.Column
+		s.Grow.Set(1, 1)
+	})
+	tb := core.NewToolbar(cp.Frame)
+	tb.Maker(func(p *tree.Plan) {
+		tree.Add(p, func(w *core.Button) {
+			w.SetText("Apply").SetIcon(icons.Check).
+				SetTooltip("apply the edited settings to the sim config").
+				OnClick(func(e events.Event) {
+					cp.Apply()
+				})
+		})
+		tree.Add(p, func(w *core.Button) {
+			w.SetText("Reset").SetIcon(icons.Undo).
+				SetTooltip("discard the edits, resetting to the current sim config").
+				OnClick(func(e events.Event) {
+					cp.Reset()
+				})
+		})
+		tree.Add(p, func(w *core.Button) {
+			w.SetText("Defaults").SetIcon(icons.Reset).
+				SetTooltip("set the edited settings to their default values (Apply to use them)").
+				OnClick(func(e events.Event) {
+					cp.Defaults()
+				})
+		})
+	})
+	cp.Tabs = core.NewTabs(cp.Frame)
+	cp.makeTabs()
+}
+
+// makeTabs makes the tabs for the Edit struct.
+func (cp *ConfigPanel) makeTabs() {
+	val := reflect.ValueOf(cp.Edit).Elem()
+	typ := val.Type()
+	var gen *core.Frame
+	for i := range typ.NumField() {
+		fld := typ.Field(i)
+		if !fld.IsExported() || fld.Tag.Get("display") == "-" {
+			continue
+		}
+		fv := val.Field(i)
+		if fld.Type.Kind() == reflect.Struct {
+			tab, _ := cp.Tabs.NewTab(fld.Name)
+			fm := core.NewForm(tab).SetStruct(fv.Addr().Interface())
+			fm.Styler(func(s *styles.Style) {
+				s.Grow.Set(1, 1)
+			})
+			continue
+		}
+		if gen == nil {
+			gen, _ = cp.Tabs.NewTab("General")
+			gen.Styler(func(s *styles.Style) {
+				s.Display = styles.Grid
+				s.Columns = 2
+			})
+		}
+		cp.addField(gen, val, fld, fv)
+	}
+}
+
+// addField adds a label and value widget for given top-level field
+// of the Edit struct to given General tab frame.
+func (cp *ConfigPanel) addField(gen *core.Frame, val reflect.Value, fld reflect.StructField, fv reflect.Value) {
+	lbl := core.NewText(gen).SetText(fld.Name)
+	vw := core.NewValue(fv.Addr().Interface(), fld.Tag, gen)
+	if doc, ok := types.GetDoc(fv, val, fld, fld.Name); ok && doc != "" {
+		lbl.SetTooltip(doc)
+		vw.AsWidget().SetTooltip(doc)
+	}
+	if _, ok := cp.Edit.(params.ShouldDisplayer); ok {
+		show := func(s *styles.Style) {
+			if ok, _ := params.ShouldDisplay(cp.Edit, fld.Name); !ok {
+				s.Display = styles.DisplayNone
+			}
+		}
+		lbl.Styler(show)
+		vw.AsWidget().Styler(show)
+		vw.AsWidget().OnChange(func(e events.Event) {
+			gen.Restyle()
+		})
+	}
+}
+
+// Apply copies the edits to the Config, and calls OnApply if set.
+func (cp *ConfigPanel) Apply() {
+	copyConfig(cp.Config, cp.Edit)
+	if cp.OnApply != nil {
+		cp.OnApply()
+	}
+}
+
+// Reset discards the edits, resetting to the current Config values.
+func (cp *ConfigPanel) Reset() {
+	copyConfig(cp.Edit, cp.Config)
+	cp.Frame.Update()
+}
+
+// Defaults sets the edited values to their defaults from the
+// default struct tags, which must then be applied to the Config.
+func (cp *ConfigPanel) Defaults() {
+	errors.Log(reflectx.SetFromDefaultTags(cp.Edit))
+	if df, ok := cp.Edit.(interface{ Defaults() }); ok {
+		df.Defaults()
+	}
+	cp.Frame.Update()
+}
+
+// copyConfig copies the src config struct into the dst config struct,
+// where both are pointers to the same type, making copies of slices
+// and maps so that the two do not share them.
+func copyConfig(dst, src any) {
+	dv := reflect.ValueOf(dst).Elem()
+	dv.Set(reflect.ValueOf(src).Elem())
+	cloneRefs(dv)
+}
+
+// cloneRefs replaces the slices and maps in the exported fields of given
+// (settable) value with copies, recursively through structs.
+func cloneRefs(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				cloneRefs(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(cp, v)
+		for i := range cp.Len() {
+			cloneRefs(cp.Index(i))
+		}
+		v.Set(cp)
+	case reflect.Map:
+		if v.IsNil() {
+			return
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), iter.Value())
+		}
+		v.Set(cp)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package egui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRunConfig struct {
+	NEpochs int
+	Seeds   []int
+}
+
+type testConfig struct {
+	Name   string
+	Run    testRunConfig
+	Params map[string]string
+	Tags   []string
+}
+
+func TestCopyConfig(t *testing.T) {
+	src := &testConfig{Name: "Sim", Run: testRunConfig{NEpochs: 10, Seeds: []int{1, 2}},
+		Params: map[string]string{"Lrate": "0.1"}, Tags: []string{"a"}}
+	dst := &testConfig{}
+	copyConfig(dst, src)
+	assert.Equal(t, src, dst)
+
+	// the slices and maps are not shared
+	dst.Run.Seeds[0] = 5
+	dst.Params["Lrate"] = "0.2"
+	dst.Tags[0] = "b"
+	assert.Equal(t, []int{1, 2}, src.Run.Seeds)
+	assert.Equal(t, "0.1", src.Params["Lrate"])
+	assert.Equal(t, []string{"a"}, src.Tags)
+
+	copyConfig(dst, &testConfig{})
+	assert.Nil(t, dst.Tags)
+	assert.Nil(t, dst.Params)
+}
+
+func TestConfigPanelApply(t *testing.T) {
+	cfg := &testConfig{Name: "Sim", Run: testRunConfig{NEpochs: 10}}
+	applied := 0
+	cp := &ConfigPanel{Config: cfg, Edit: &testConfig{}, OnApply: func() { applied++ }}
+	copyConfig(cp.Edit, cp.Config)
+	cp.Edit.(*testConfig).Run.NEpochs = 20
+	assert.Equal(t, 10, cfg.Run.NEpochs) // only edits until Apply
+	cp.Apply()
+	assert.Equal(t, 20, cfg.Run.NEpochs)
+	assert.Equal(t, 1, applied)
+}
@@ -44,6 +44,9 @@ type GUI struct {
 	//	OnStop is called when running stopped through the GUI.
 	// Should update the network view.
 	OnStop func(mode, level enums.Enum)
+
+	// loopControls are the LoopControls by prefix.
+	loopControls map[string]*LoopControls
 }
 
 // UpdateWindow triggers an update on window body,
@@ -0,0 +1,128 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package egui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"cogentcore.org/core/base/errors"
+	"cogentcore.org/core/core"
+	"cogentcore.org/core/styles"
+	"cogentcore.org/lab/lab"
+	"cogentcore.org/lab/plotcore"
+	"github.com/emer/emergent/v2/tablestream"
+)
+
+// LogTail displays a live-updating plot of a log file that is being
+// written by a separate headless run, along with the current values
+// of its counters, so that a GUI instance can monitor the run without
+// the compute process having any GUI. The log file is polled at the
+// given Interval, and can be replaced by a new run at any point.
+type LogTail struct {
+
+	// Tail follows the log file.
+	Tail *tablestream.Tail
+
+	// Counters are the names of the columns shown as the counters
+	// above the plot, with their values from the last row.
+	Counters []string
+
+	// Interval is the interval between polls of the log file.
+	Interval time.Duration
+
+	// Plot is the plot editor showing the log table.
+	Plot *plotcore.Editor
+
+	// Text shows the counters.
+	Text *core.Text
+
+	// stop stops the polling when closed.
+	stop chan struct{}
+}
+
+// AddLogTail adds a tab with given label that displays a live-updating
+// plot of given log file (TSV / CSV with typed table headers, as written
+// by a headless run), polling it at given interval, with the values of
+// given counter columns (e.g., Run, Epoch) from the last row shown above
+// the plot. Polling starts immediately, and can be stopped with Stop.
+func (gui *GUI) AddLogTail(label, filename string, interval time.Duration, counters ...string) *LogTail {
+	lt := &LogTail{Tail: tablestream.NewTail(filename), Counters: counters, Interval: interval}
+	lab.NewTab(gui.Tabs, label, func(tab *core.Frame) *plotcore.Editor {
+		tab.Styler(func(s *styles.Style) {
+			s.Direction = styles.Column
+			s.Grow.Set(1, 1)
+		})
+		lt.Text = core.NewText(tab).SetText("waiting for " + filename)
+		tb := core.NewToolbar(tab)
+		lt.Plot = plotcore.NewEditor(tab)
+		tb.Maker(lt.Plot.MakeToolbar)
+		lt.Plot.SetTable(lt.Tail.Table)
+		return lt.Plot
+	})
+	gui.Tabs.Update()
+	lt.Start()
+	return lt
+}
+
+// Start starts polling the log file in a separate goroutine,
+// if not already polling.
+func (lt *LogTail) Start() {
+	if lt.stop != nil {
+		return
+	}
+	lt.stop = make(chan struct{})
+	go lt.poll(lt.stop)
+}
+
+// Stop stops polling the log file.
+func (lt *LogTail) Stop() {
+	if lt.stop == nil {
+		return
+	}
+	close(lt.stop)
+	lt.stop = nil
+}
+
+// poll polls the log file until stop is closed.
+func (lt *LogTail) poll(stop chan struct{}) {
+	tick := time.NewTicker(lt.Interval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-tick.C:
+		}
+		lt.Plot.AsyncLock()
+		n, err := lt.Tail.Poll()
+		if errors.Log(err) == nil && n > 0 {
+			lt.Text.SetText(lt.CountersString()).Update()
+			lt.Plot.UpdatePlot()
+		}
+		lt.Plot.AsyncUnlock()
+	}
+}
+
+// CountersString returns the values of the Counters in the last row
+// of the log table, along with the number of rows.
+func (lt *LogTail) CountersString() string {
+	dt := lt.Tail.Table
+	nr := dt.NumRows()
+	var b strings.Builder
+	fmt.Fprintf(&b, "Rows: %d", nr)
+	if nr == 0 {
+		return b.String()
+	}
+	for _, cn := range lt.Counters {
+		cl, err := dt.ColumnTry(cn)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "  %s: %s", cn, cl.StringRow(nr-1, 0))
+	}
+	return b.String()
+}
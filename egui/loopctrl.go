@@ -5,10 +5,9 @@
 package egui
 
 import (
-	"cmp"
-	"slices"
 	"strings"
 
+	"cogentcore.org/core/base/errors"
 	"cogentcore.org/core/core"
 	"cogentcore.org/core/enums"
 	"cogentcore.org/core/events"
@@ -16,55 +15,167 @@ import (
 	"cogentcore.org/core/styles"
 	"cogentcore.org/core/styles/abilities"
 	"cogentcore.org/core/tree"
-	"github.com/emer/emergent/v2/etime"
 	"github.com/emer/emergent/v2/looper"
 )
 
-// AddLooperCtrl adds toolbar control for looper.Stacks with Init, Run, Step controls,
-// with selector for which stack is being controlled.
-// A prefix can optionally be provided if multiple loops are used.
-func (gui *GUI) AddLooperCtrl(p *tree.Plan, loops *looper.Stacks, prefix ...string) {
-	pfx := ""
-	lblpfx := ""
-	if len(prefix) == 1 {
-		pfx = strings.ToLower(prefix[0]) + "-"
-		lblpfx = prefix[0] + " "
+// LoopControls is a reusable toolbar component for running [looper.Stacks],
+// with a mode selector segmented button, and mode-sensitive Init, Stop,
+// Run, and Step controls, where the step levels are those of the Stack for
+// the selected mode. It is driven generically from the Stacks and the modes,
+// which can be any [enums.Enum] mode set (not just Train and Test).
+type LoopControls struct {
+
+	// GUI is the GUI that the controls are in.
+	GUI *GUI
+
+	// Loops are the looper stacks being controlled.
+	Loops *looper.Stacks
+
+	// Modes are the modes that can be selected, in order. Only modes that have
+	// a Stack in the Loops are included, and if empty, all of the modes
+	// in the Loops are used, in enum value order.
+	Modes []enums.Enum
+
+	// Mode is the currently selected mode, which the controls apply to.
+	Mode enums.Enum
+
+	// Prefix is an optional prefix for the labels and names of the controls,
+	// to distinguish multiple sets of controls in the same toolbar.
+	Prefix string
+
+	// OnModeChange is called when a new mode is selected, if set.
+	OnModeChange func(mode enums.Enum)
+
+	// Until is a [looper.Condition] expression for the Until button,
+	// which runs the current mode until the expression is true at the end
+	// of an iteration of the step level, e.g., "PctErr < 0.05 || Epoch >= 50".
+	Until string
+
+	// StatValue looks up the values of named statistics for the Until
+	// expression, e.g., [estats.Stats.Value]. The loop level counters
+	// (e.g., Epoch) are always available.
+	StatValue func(name string) (float64, bool)
+
+	stepChoose *core.Chooser
+	stepNSpin  *core.Spinner
+}
+
+// AddLooperCtrl adds a [LoopControls] toolbar component for looper.Stacks,
+// with Init, Run, Step controls, and a selector for which mode stack is being
+// controlled. A prefix can optionally be provided if multiple loops are used.
+// This is called in the toolbar Maker, and returns the same LoopControls for
+// each call with the same prefix. Use [GUI.AddLoopControls] to specify the
+// order and subset of modes that can be selected.
+func (gui *GUI) AddLooperCtrl(p *tree.Plan, loops *looper.Stacks, prefix ...string) *LoopControls {
+	return gui.AddLoopControls(p, loops, strings.Join(prefix, ""))
+}
+
+// AddLoopControls adds a [LoopControls] toolbar component for given
+// looper.Stacks, with given optional prefix, and set of modes that can be
+// selected (all modes in the loops if none). This is called in the toolbar
+// Maker, and returns the same LoopControls for each call with the same prefix.
+func (gui *GUI) AddLoopControls(p *tree.Plan, loops *looper.Stacks, prefix string, modes ...enums.Enum) *LoopControls {
+	if gui.loopControls == nil {
+		gui.loopControls = make(map[string]*LoopControls)
 	}
-	modes := make([]enums.Enum, len(loops.Stacks))
-	var stepChoose *core.Chooser
-	var stepNSpin *core.Spinner
-	i := 0
-	for m := range loops.Stacks {
-		modes[i] = m
-		i++
+	lc, ok := gui.loopControls[prefix]
+	if !ok || lc.Loops != loops {
+		lc = NewLoopControls(gui, loops, modes...)
+		lc.Prefix = prefix
+		gui.loopControls[prefix] = lc
 	}
-	slices.SortFunc(modes, func(a, b enums.Enum) int {
-		return cmp.Compare(a.Int64(), b.Int64())
-	})
-	curMode := modes[0]
-	curStep := loops.Stacks[curMode].StepLevel
-
-	updateSteps := func() {
-		st := loops.Stacks[curMode]
-		stepStrs := make([]string, len(st.Order))
-		cur := ""
-		for i, s := range st.Order {
-			sv := s.String()
-			stepStrs[i] = sv
-			if s.Int64() == curStep.Int64() {
-				cur = sv
-			}
+	lc.MakeToolbar(p)
+	return lc
+}
+
+// NewLoopControls returns a new [LoopControls] for given GUI, looper stacks,
+// and optional set of modes that can be selected.
+func NewLoopControls(gui *GUI, loops *looper.Stacks, modes ...enums.Enum) *LoopControls {
+	lc := &LoopControls{GUI: gui, Loops: loops}
+	if len(modes) == 0 {
+		modes = loops.Modes()
+	}
+	for _, m := range modes {
+		if loops.Stacks[m] != nil {
+			lc.Modes = append(lc.Modes, m)
+		}
+	}
+	if len(lc.Modes) > 0 {
+		lc.Mode = lc.Modes[0]
+	}
+	return lc
+}
+
+// Stack returns the Stack for the current Mode.
+func (lc *LoopControls) Stack() *looper.Stack {
+	return lc.Loops.Stacks[lc.Mode]
+}
+
+// SetMode sets the current Mode, updating the step controls,
+// and calling OnModeChange if set.
+func (lc *LoopControls) SetMode(mode enums.Enum) {
+	st := lc.Loops.Stacks[mode]
+	if st == nil {
+		return
+	}
+	lc.Mode = mode
+	if lc.stepChoose != nil {
+		lc.updateSteps()
+		lc.stepChoose.Update()
+	}
+	if lc.stepNSpin != nil {
+		lc.stepNSpin.SetValue(float32(lc.stepCount(st)))
+		lc.stepNSpin.Update()
+	}
+	if lc.OnModeChange != nil {
+		lc.OnModeChange(mode)
+	}
+}
+
+// stepCount returns the step count for the step level of given stack.
+func (lc *LoopControls) stepCount(st *looper.Stack) int {
+	if lp := st.Loops[st.StepLevel]; lp != nil && lp.StepCount > 0 {
+		return lp.StepCount
+	}
+	return 1
+}
+
+// updateSteps updates the step level chooser for the current mode.
+func (lc *LoopControls) updateSteps() {
+	st := lc.Stack()
+	stepStrs := make([]string, len(st.Order))
+	cur := ""
+	for i, s := range st.Order {
+		sv := s.String()
+		stepStrs[i] = sv
+		if s.Int64() == st.StepLevel.Int64() {
+			cur = sv
 		}
-		stepChoose.SetStrings(stepStrs...)
-		stepChoose.SetCurrentValue(cur)
+	}
+	lc.stepChoose.SetStrings(stepStrs...)
+	lc.stepChoose.SetCurrentValue(cur)
+}
+
+// MakeToolbar makes the controls in given toolbar plan.
+func (lc *LoopControls) MakeToolbar(p *tree.Plan) {
+	if len(lc.Modes) == 0 {
+		return
+	}
+	gui := lc.GUI
+	loops := lc.Loops
+	pfx := ""
+	lblpfx := ""
+	if lc.Prefix != "" {
+		pfx = strings.ToLower(lc.Prefix) + "-"
+		lblpfx = lc.Prefix + " "
 	}
 
-	if len(modes) > 1 {
+	if len(lc.Modes) > 1 {
 		tree.AddAt(p, pfx+"loop-mode", func(w *core.Switches) {
 			w.SetType(core.SwitchSegmentedButton)
 			w.Mutex = true
-			w.SetEnums(modes...)
-			w.SelectValue(curMode)
+			w.SetEnums(lc.Modes...)
+			w.SelectValue(lc.Mode)
 			w.FinalStyler(func(s *styles.Style) {
 				s.Grow.Set(0, 0)
 			})
@@ -73,16 +184,7 @@ func (gui *GUI) AddLooperCtrl(p *tree.Plan, loops *looper.Stacks, prefix ...stri
 				if sel == nil || sel.Value == nil {
 					return
 				}
-				curMode = sel.Value.(enums.Enum)
-				st := loops.Stacks[curMode]
-				if st != nil {
-					curStep = st.StepLevel
-				}
-				updateSteps()
-				stepChoose.Update()
-				stepN := st.Loops[curStep].StepCount
-				stepNSpin.SetValue(float32(stepN))
-				stepNSpin.Update()
+				lc.SetMode(sel.Value.(enums.Enum))
 			})
 		})
 	}
@@ -92,7 +194,7 @@ func (gui *GUI) AddLooperCtrl(p *tree.Plan, loops *looper.Stacks, prefix ...stri
 		Tooltip: "Initializes running and state for current mode.",
 		Active:  ActiveStopped,
 		Func: func() {
-			loops.InitMode(curMode)
+			loops.InitMode(lc.Mode)
 		},
 	})
 
@@ -101,8 +203,9 @@ func (gui *GUI) AddLooperCtrl(p *tree.Plan, loops *looper.Stacks, prefix ...stri
 		Tooltip: "Interrupts current running. Will pick back up where it left off.",
 		Active:  ActiveRunning,
 		Func: func() {
-			loops.Stop(etime.Cycle)
-			// fmt.Println("Stop time!")
+			if st := loops.ModeStack(); st != nil && len(st.Order) > 0 {
+				loops.Stop(st.Order[len(st.Order)-1]) // innermost level
+			}
 			gui.StopNow = true
 		},
 	})
@@ -116,14 +219,48 @@ func (gui *GUI) AddLooperCtrl(p *tree.Plan, loops *looper.Stacks, prefix ...stri
 			if !gui.IsRunning {
 				gui.IsRunning = true
 				tb.Restyle()
+				mode := lc.Mode
 				go func() {
-					stop := loops.Run(curMode)
-					gui.Stopped(curMode, stop)
+					stop := loops.Run(mode)
+					gui.Stopped(mode, stop)
 				}()
 			}
 		})
 	})
 
+	tree.AddAt(p, pfx+"loop-until", func(w *core.Button) {
+		tb := gui.Toolbar
+		w.SetText("Until").SetIcon(icons.FastForward).
+			SetTooltip("Run the current mode until the following condition over statistics and counters is true at the end of the step level, e.g., PctErr < 0.05 || Epoch >= 50")
+		w.FirstStyler(func(s *styles.Style) { s.SetEnabled(!gui.IsRunning && lc.Until != "") })
+		w.OnClick(func(e events.Event) {
+			if !gui.IsRunning {
+				if _, err := looper.ParseCondition(lc.Until); err != nil {
+					core.ErrorSnackbar(w, err)
+					return
+				}
+				gui.IsRunning = true
+				tb.Restyle()
+				mode := lc.Mode
+				level := lc.Stack().StepLevel
+				go func() {
+					stop, err := loops.RunUntilExpr(mode, level, lc.Until, lc.StatValue)
+					errors.Log(err)
+					gui.Stopped(mode, stop)
+				}()
+			}
+		})
+	})
+
+	tree.AddAt(p, pfx+"until-expr", func(w *core.TextField) {
+		core.Bind(&lc.Until, w)
+		w.SetPlaceholder("condition")
+		w.SetTooltip("condition for the Until button, as comparisons of statistics and counters combined with && and ||, e.g., PctErr < 0.05 || Epoch >= 50")
+		w.OnChange(func(e events.Event) {
+			gui.Toolbar.Restyle()
+		})
+	})
+
 	tree.AddAt(p, pfx+"loop-step", func(w *core.Button) {
 		tb := gui.Toolbar
 		w.SetText("Step").SetIcon(icons.SkipNext).
@@ -136,22 +273,22 @@ func (gui *GUI) AddLooperCtrl(p *tree.Plan, loops *looper.Stacks, prefix ...stri
 			if !gui.IsRunning {
 				gui.IsRunning = true
 				tb.Restyle()
+				mode := lc.Mode
+				st := lc.Stack()
+				nst := int(lc.stepNSpin.Value)
 				go func() {
-					st := loops.Stacks[curMode]
-					nst := int(stepNSpin.Value)
-					stop := loops.Step(curMode, nst, st.StepLevel)
-					gui.Stopped(curMode, stop)
+					stop := loops.Step(mode, nst, st.StepLevel)
+					gui.Stopped(mode, stop)
 				}()
 			}
 		})
 	})
 
 	tree.AddAt(p, pfx+"step-level", func(w *core.Chooser) {
-		stepChoose = w
-		updateSteps()
-		w.SetCurrentValue(curStep.String())
+		lc.stepChoose = w
+		lc.updateSteps()
 		w.OnChange(func(e events.Event) {
-			st := loops.Stacks[curMode]
+			st := lc.Stack()
 			if w.CurrentItem.Value == nil {
 				return
 			}
@@ -159,8 +296,8 @@ func (gui *GUI) AddLooperCtrl(p *tree.Plan, loops *looper.Stacks, prefix ...stri
 			for _, l := range st.Order {
 				if l.String() == cs {
 					st.StepLevel = l
-					stepNSpin.Value = float32(st.Loops[l].StepCount)
-					stepNSpin.Update()
+					lc.stepNSpin.SetValue(float32(lc.stepCount(st)))
+					lc.stepNSpin.Update()
 					break
 				}
 			}
@@ -168,16 +305,15 @@ func (gui *GUI) AddLooperCtrl(p *tree.Plan, loops *looper.Stacks, prefix ...stri
 	})
 
 	tree.AddAt(p, pfx+"step-n", func(w *core.Spinner) {
-		stepNSpin = w
-		w.SetStep(1).SetMin(1).SetValue(1)
+		lc.stepNSpin = w
+		w.SetStep(1).SetMin(1).SetValue(float32(lc.stepCount(lc.Stack())))
 		w.SetTooltip("number of iterations per step")
 		w.OnChange(func(e events.Event) {
-			st := loops.Stacks[curMode]
+			st := lc.Stack()
 			if st != nil {
 				st.StepCount = int(w.Value)
 				st.Loops[st.StepLevel].StepCount = st.StepCount
 			}
 		})
 	})
-
 }
@@ -0,0 +1,54 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package egui
+
+import (
+	"testing"
+
+	"cogentcore.org/core/enums"
+	"github.com/emer/emergent/v2/looper"
+	"github.com/emer/emergent/v2/looper/levels"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoopControlsModes(t *testing.T) {
+	loops := looper.NewStacks()
+	loops.AddStack(levels.Train, levels.Trial).AddLevel(levels.Epoch, 3).AddLevel(levels.Trial, 2)
+	loops.AddStack(levels.Test, levels.Epoch).AddLevel(levels.Epoch, 1).AddLevel(levels.Trial, 2)
+
+	lc := NewLoopControls(nil, loops)
+	assert.Equal(t, []enums.Enum{levels.Train, levels.Test}, lc.Modes)
+	assert.Equal(t, levels.Train, lc.Mode)
+	assert.Equal(t, loops.Stacks[levels.Train], lc.Stack())
+
+	lc = NewLoopControls(nil, loops, levels.Test, levels.Train)
+	assert.Equal(t, []enums.Enum{levels.Test, levels.Train}, lc.Modes)
+	assert.Equal(t, levels.Test, lc.Mode)
+
+	var changed enums.Enum
+	lc.OnModeChange = func(mode enums.Enum) { changed = mode }
+	lc.SetMode(levels.Train)
+	assert.Equal(t, levels.Train, lc.Mode)
+	assert.Equal(t, levels.Train, changed)
+
+	trOnly := looper.NewStacks()
+	trOnly.AddStack(levels.Train, levels.Trial).AddLevel(levels.Trial, 2)
+	lc = NewLoopControls(nil, trOnly, levels.Test, levels.Train)
+	assert.Equal(t, []enums.Enum{levels.Train}, lc.Modes) // no Test stack
+	lc.SetMode(levels.Test)
+	assert.Equal(t, levels.Train, lc.Mode)
+}
+
+func TestLoopControlsStepCount(t *testing.T) {
+	loops := looper.NewStacks()
+	loops.AddStack(levels.Train, levels.Trial).AddLevel(levels.Epoch, 3).AddLevel(levels.Trial, 2)
+	lc := NewLoopControls(nil, loops)
+	st := lc.Stack()
+	assert.Equal(t, 1, lc.stepCount(st))
+	st.Loops[levels.Trial].StepCount = 3
+	assert.Equal(t, 3, lc.stepCount(st))
+	st.StepLevel = levels.Epoch
+	assert.Equal(t, 1, lc.stepCount(st))
+}
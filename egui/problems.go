@@ -0,0 +1,51 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package egui
+
+import (
+	"fmt"
+
+	"cogentcore.org/core/core"
+	"cogentcore.org/core/styles"
+	"cogentcore.org/lab/lab"
+	"cogentcore.org/lab/tensorcore"
+	"github.com/emer/emergent/v2/problems"
+)
+
+// AddProblemsTab adds a tab with given label that displays a consolidated
+// table of all of the warnings and errors in given problems collector
+// (typically [problems.Default]), with a count of each level above it.
+// The table is updated whenever a problem is added, by setting the
+// OnAdd function of the collector.
+func (gui *GUI) AddProblemsTab(label string, pc *problems.Collector) *tensorcore.Table {
+	var txt *core.Text
+	tv := lab.NewTab(gui.Tabs, label, func(tab *core.Frame) *tensorcore.Table {
+		tab.Styler(func(s *styles.Style) {
+			s.Direction = styles.Column
+			s.Grow.Set(1, 1)
+		})
+		txt = core.NewText(tab).SetText(problemsCounts(pc))
+		tv := tensorcore.NewTable(tab)
+		tv.SetReadOnly(true)
+		tv.SetTable(pc.Table())
+		return tv
+	})
+	gui.Tabs.Update()
+	pc.OnAdd = func(pr *problems.Problem) {
+		go func() { // problems can be added while the gui is locked
+			tv.AsyncLock()
+			txt.SetText(problemsCounts(pc)).Update()
+			tv.SetTable(pc.Table())
+			tv.Update()
+			tv.AsyncUnlock()
+		}()
+	}
+	return tv
+}
+
+// problemsCounts returns the numbers of errors and warnings in given collector.
+func problemsCounts(pc *problems.Collector) string {
+	return fmt.Sprintf("Errors: %d  Warnings: %d", len(pc.Level(problems.Error)), len(pc.Level(problems.Warning)))
+}
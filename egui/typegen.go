@@ -6,8 +6,14 @@ import (
 	"cogentcore.org/core/types"
 )
 
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/egui.ConfigPanel", IDName: "config-panel", Doc: "ConfigPanel is a structured settings panel for a sim Config struct,\ngenerated by reflection, so that all of the config options are\navailable in the GUI without hand-built forms. There is a tab for each\nstruct-valued field (e.g., Params, Run, Log), and a General tab for\nthe other fields, all of which respect the display tags and the\nShouldDisplay conditional visibility methods. Edits are made on\na copy of the Config, which is only copied to the Config by Apply,\nand can be discarded by Reset.", Fields: []types.Field{{Name: "Config", Doc: "Config is a pointer to the config struct used by the sim."}, {Name: "Edit", Doc: "Edit is the working copy of the Config that is edited in the panel."}, {Name: "OnApply", Doc: "OnApply is called after Apply copies the edits to the Config,\ne.g., to reconfigure the parts of the sim that depend on them."}, {Name: "Frame", Doc: "Frame contains the toolbar and the tabs."}, {Name: "Tabs", Doc: "Tabs has a tab for each struct-valued field, and a General tab."}}})
+
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/egui.GUI", IDName: "gui", Doc: "GUI manages all standard elements of a simulation Graphical User Interface", Fields: []types.Field{{Name: "CycleUpdateInterval", Doc: "how many cycles between updates of cycle-level plots"}, {Name: "Active", Doc: "true if the GUI is configured and running"}, {Name: "IsRunning", Doc: "true if sim is running"}, {Name: "StopNow", Doc: "flag to stop running"}, {Name: "Plots", Doc: "plots by scope"}, {Name: "TableViews", Doc: "plots by scope"}, {Name: "Grids", Doc: "tensor grid views by name -- used e.g., for Rasters or ActRFs -- use Grid(name) to access"}, {Name: "ViewUpdate", Doc: "the view update for managing updates of netview"}, {Name: "NetData", Doc: "net data for recording in nogui mode, if !nil"}, {Name: "SimForm", Doc: "displays Sim fields on left"}, {Name: "Tabs", Doc: "tabs for different view elements: plots, rasters"}, {Name: "Body", Doc: "Body is the content of the sim window"}, {Name: "Toolbar", Doc: "\tToolbar is the overall sim toolbar"}}})
 
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/egui.LogTail", IDName: "log-tail", Doc: "LogTail displays a live-updating plot of a log file that is being\nwritten by a separate headless run, along with the current values\nof its counters, so that a GUI instance can monitor the run without\nthe compute process having any GUI. The log file is polled at the\ngiven Interval, and can be replaced by a new run at any point.", Fields: []types.Field{{Name: "Tail", Doc: "Tail follows the log file."}, {Name: "Counters", Doc: "Counters are the names of the columns shown as the counters\nabove the plot, with their values from the last row."}, {Name: "Interval", Doc: "Interval is the interval between polls of the log file."}, {Name: "Plot", Doc: "Plot is the plot editor showing the log table."}, {Name: "Text", Doc: "Text shows the counters."}, {Name: "stop", Doc: "stop stops the polling when closed."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/egui.LoopControls", IDName: "loop-controls", Doc: "LoopControls is a reusable toolbar component for running [looper.Stacks],\nwith a mode selector segmented button, and mode-sensitive Init, Stop,\nRun, and Step controls, where the step levels are those of the Stack for\nthe selected mode. It is driven generically from the Stacks and the modes,\nwhich can be any [enums.Enum] mode set (not just Train and Test).", Fields: []types.Field{{Name: "GUI", Doc: "GUI is the GUI that the controls are in."}, {Name: "Loops", Doc: "Loops are the looper stacks being controlled."}, {Name: "Modes", Doc: "Modes are the modes that can be selected, in order. Only modes that have\na Stack in the Loops are included, and if empty, all of the modes\nin the Loops are used, in enum value order."}, {Name: "Mode", Doc: "Mode is the currently selected mode, which the controls apply to."}, {Name: "Prefix", Doc: "Prefix is an optional prefix for the labels and names of the controls,\nto distinguish multiple sets of controls in the same toolbar."}, {Name: "OnModeChange", Doc: "OnModeChange is called when a new mode is selected, if set."}, {Name: "Until", Doc: "Until is a [looper.Condition] expression for the Until button,\nwhich runs the current mode until the expression is true at the end\nof an iteration of the step level, e.g., \"PctErr < 0.05 || Epoch >= 50\"."}, {Name: "StatValue", Doc: "StatValue looks up the values of named statistics for the Until\nexpression, e.g., [estats.Stats.Value]. The loop level counters\n(e.g., Epoch) are always available."}, {Name: "stepChoose"}, {Name: "stepNSpin"}}})
+
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/egui.ToolbarItem", IDName: "toolbar-item", Doc: "ToolbarItem holds the configuration values for a toolbar item", Fields: []types.Field{{Name: "Label"}, {Name: "Icon"}, {Name: "Tooltip"}, {Name: "Active"}, {Name: "Func"}}})
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/egui.ToolGhosting", IDName: "tool-ghosting", Doc: "ToolGhosting the mode enum"})
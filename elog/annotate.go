@@ -0,0 +1,135 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elog
+
+import (
+	"fmt"
+	"slices"
+
+	"cogentcore.org/core/base/errors"
+	"cogentcore.org/lab/plot"
+	"cogentcore.org/lab/plot/plots"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"cogentcore.org/lab/tensorfs"
+)
+
+// Annotations records discrete named events, such as a lesion being
+// applied, the learning rate being dropped, or the environment being
+// switched, with the values of the time counters (e.g., Epoch, Trial)
+// at which they occurred, in a dedicated log table. The events can be
+// drawn as labeled vertical markers on time-series plots, using
+// [Annotations.Plotters] or [Annotations.AddToPlot].
+type Annotations struct {
+
+	// Counters are the names of the time counters recorded for each
+	// event, e.g., Epoch, Trial.
+	Counters []string
+
+	// Schema is the schema for the Table, with a Name string column,
+	// an int column for each of the Counters, and a Doc string column.
+	Schema *Schema
+
+	// Table has one row per event.
+	Table *table.Table
+}
+
+// NewAnnotations returns a new Annotations recording the given counters
+// for each event, e.g., "Epoch", "Trial".
+func NewAnnotations(counters ...string) *Annotations {
+	an := &Annotations{Counters: counters}
+	an.Schema = NewSchema().String("Name")
+	for _, c := range counters {
+		an.Schema.Int(c)
+	}
+	an.Schema.String("Doc")
+	an.Table = an.Schema.NewTable()
+	return an
+}
+
+// Config sets the columns of the Table as values in the given
+// [tensorfs] directory, e.g., Logs/Annotations, so that events
+// are saved and viewed along with the other logs.
+func (an *Annotations) Config(dir *tensorfs.Node) {
+	tensorfs.DirFromTable(dir, an.Table)
+}
+
+// Add records an event with given name and optional doc string
+// describing it, at given counter values, which must be in the
+// same order as the Counters.
+func (an *Annotations) Add(name, doc string, counters ...int) error {
+	if len(counters) != len(an.Counters) {
+		return errors.Log(fmt.Errorf("elog.Annotations: event %q has %d counter values, expected %d (%v)", name, len(counters), len(an.Counters), an.Counters))
+	}
+	row := an.Table.NumRows()
+	an.Table.SetNumRows(row + 1)
+	an.Schema.SetRow(an.Table, "Name", row, tensor.NewStringScalar(name))
+	for i, c := range an.Counters {
+		an.Schema.SetRow(an.Table, c, row, tensor.NewIntScalar(counters[i]))
+	}
+	an.Schema.SetRow(an.Table, "Doc", row, tensor.NewStringScalar(doc))
+	return nil
+}
+
+// NumEvents returns the number of events recorded.
+func (an *Annotations) NumEvents() int {
+	return an.Table.NumRows()
+}
+
+// Events returns the values of the given counter and the names
+// for all of the events.
+func (an *Annotations) Events(counter string) ([]float64, []string, error) {
+	if !slices.Contains(an.Counters, counter) {
+		return nil, nil, errors.Log(fmt.Errorf("elog.Annotations: counter %q not recorded (%v)", counter, an.Counters))
+	}
+	n := an.NumEvents()
+	xs := make([]float64, n)
+	names := make([]string, n)
+	cc := an.Table.Column(counter)
+	nc := an.Table.Column("Name")
+	for i := range n {
+		xs[i] = cc.FloatRow(i, 0)
+		names[i] = nc.StringRow(i, 0)
+	}
+	return xs, names, nil
+}
+
+// Plotters returns plotters that draw a dashed vertical line for each
+// event, from ymin to ymax at the X axis position given by the value of
+// the given counter, which should be the X axis of the plot, with the
+// name of the event as a label at the top. Returns nil if there are
+// no events.
+func (an *Annotations) Plotters(counter string, ymin, ymax float64) ([]plot.Plotter, error) {
+	xs, names, err := an.Events(counter)
+	if err != nil || len(xs) == 0 {
+		return nil, err
+	}
+	n := len(xs)
+	mid := make(plot.Values, n)
+	half := make(plot.Values, n)
+	top := make(plot.Values, n)
+	for i := range n {
+		mid[i] = 0.5 * (ymin + ymax)
+		half[i] = 0.5 * (ymax - ymin)
+		top[i] = ymax
+	}
+	lines := plots.NewYErrorBars(plot.Data{plot.X: plot.Values(xs), plot.Y: mid, plot.Low: half, plot.High: half})
+	lines.Styler(func(s *plot.Style) {
+		s.Line.Dashes = []float32{4, 4}
+		s.Width.Cap.Dp(0)
+	})
+	labels := plots.NewLabels(plot.Data{plot.X: plot.Values(xs), plot.Y: top, plot.Label: plot.Labels(names)})
+	return []plot.Plotter{lines, labels}, nil
+}
+
+// AddToPlot adds the [Annotations.Plotters] for the events to the given plot.
+func (an *Annotations) AddToPlot(plt *plot.Plot, counter string, ymin, ymax float64) error {
+	pts, err := an.Plotters(counter, ymin, ymax)
+	if err != nil {
+		return err
+	}
+	plt.Add(pts...)
+	return nil
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package elog provides support for logging data into [tensorfs] directories
+and [table.Table] tables, including a [Schema] declaration of the expected
+log columns with their types and tensor cell shapes, which is used to
+validate every write, and the rotation and downsampling of log files
+for very long runs.
+*/
+package elog
+
+//go:generate core generate -add-types
@@ -0,0 +1,131 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cogentcore.org/core/base/fsx"
+	"cogentcore.org/lab/plot"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"cogentcore.org/lab/tensorfs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema(t *testing.T) {
+	sc := NewSchema().String("TrialName").Int("Epoch").Float64("Err").Float32("Hidden", 2, 3)
+	assert.Equal(t, 4, len(sc.Columns))
+	sc.Float64("Err") // duplicate: logged, not added
+	assert.Equal(t, 4, len(sc.Columns))
+
+	dir, _ := tensorfs.NewDir("Root")
+	assert.NoError(t, sc.Config(dir))
+	assert.NoError(t, sc.AppendString(dir, "TrialName", "a"))
+	assert.NoError(t, sc.AppendFloat(dir, "Epoch", 1))
+	assert.NoError(t, sc.AppendFloat(dir, "Err", 0.5))
+	assert.NoError(t, sc.AppendRow(dir, "Hidden", tensor.NewFloat32(2, 3)))
+	assert.NoError(t, sc.ValidateDir(dir))
+	assert.Equal(t, 1, dir.Value("Hidden").DimSize(0))
+
+	assert.Error(t, sc.AppendFloat(dir, "SSE", 0.5))                      // undeclared
+	assert.Error(t, sc.AppendString(dir, "Err", "bad"))                   // type
+	assert.Error(t, sc.AppendRow(dir, "Hidden", tensor.NewFloat32(3, 3))) // shape
+	assert.NoError(t, sc.AppendFloat(dir, "Err", 0.2))
+	assert.Error(t, sc.ValidateDir(dir)) // rows inconsistent
+
+	dir2, _ := tensorfs.NewDir("Root2")
+	tensorfs.Value[float64](dir2, "Hidden", 0, 4)
+	assert.Error(t, sc.Config(dir2)) // existing value has wrong type and shape
+
+	dt := sc.NewTable()
+	assert.NoError(t, sc.ValidateTable(dt))
+	dt.SetNumRows(1)
+	assert.NoError(t, sc.SetRow(dt, "Hidden", 0, tensor.NewFloat32(2, 3)))
+	assert.Error(t, sc.SetRow(dt, "Hidden", 0, tensor.NewFloat32(6)))
+	dt.AddFloat64Column("Extra")
+	assert.Error(t, sc.ValidateTable(dt))
+}
+
+func TestAnnotations(t *testing.T) {
+	an := NewAnnotations("Epoch", "Trial")
+	assert.NoError(t, an.Add("Lesion", "lesioned Hidden layer", 10, 0))
+	assert.NoError(t, an.Add("LRate", "lrate * 0.5", 25, 3))
+	assert.Error(t, an.Add("Bad", "", 30))
+	assert.Equal(t, 2, an.NumEvents())
+	assert.NoError(t, an.Schema.ValidateTable(an.Table))
+
+	xs, names, err := an.Events("Epoch")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{10, 25}, xs)
+	assert.Equal(t, []string{"Lesion", "LRate"}, names)
+	_, _, err = an.Events("Run")
+	assert.Error(t, err)
+
+	pts, err := an.Plotters("Epoch", 0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(pts))
+	data, _, _ := pts[0].Data()
+	assert.Equal(t, 0.5, data[plot.High].Float1D(1))
+
+	dir, _ := tensorfs.NewDir("Root")
+	an.Config(dir)
+	assert.Equal(t, 2, dir.Value("Trial").DimSize(0))
+	assert.NoError(t, an.Add("EnvSwitch", "", 40, 0))
+	assert.Equal(t, 3, dir.Value("Trial").DimSize(0))
+}
+
+func TestRotate(t *testing.T) {
+	dir := t.TempDir()
+	dt := table.New()
+	dt.AddIntColumn("Epoch")
+	dt.AddFloat64Column("Err")
+	dt.SetNumRows(1)
+
+	rf := NewRotatingFile(filepath.Join(dir, "trial"), 10)
+	rf.Keep = 2
+	ds := NewDownsample(filepath.Join(dir, "summary.tsv"), 5)
+	for ep := range 35 {
+		dt.Column("Epoch").SetFloat1D(float64(ep), 0)
+		dt.Column("Err").SetFloat1D(1/float64(ep+1), 0)
+		assert.NoError(t, rf.WriteRow(dt, 0, ep))
+		assert.NoError(t, ds.WriteRow(dt, 0, ep))
+	}
+	assert.NoError(t, rf.Close())
+	assert.NoError(t, ds.Close())
+	assert.Equal(t, []string{filepath.Join(dir, "trial_000020.tsv"), filepath.Join(dir, "trial_000030.tsv")}, rf.Files)
+	_, err := os.Stat(filepath.Join(dir, "trial_000010.tsv"))
+	assert.True(t, os.IsNotExist(err))
+
+	rt := table.New()
+	assert.NoError(t, rt.OpenCSV(fsx.Filename(rf.Files[0]), tensor.Tab))
+	assert.Equal(t, 10, rt.NumRows())
+	assert.Equal(t, 20.0, rt.Column("Epoch").Float1D(0))
+
+	st := table.New()
+	assert.NoError(t, st.OpenCSV(fsx.Filename(ds.File), tensor.Tab))
+	assert.Equal(t, 7, st.NumRows())
+	assert.Equal(t, 30.0, st.Column("Epoch").Float1D(6))
+
+	// resuming appends to the existing files
+	rf2 := NewRotatingFile(rf.Base, 10)
+	rf2.Append = true
+	ds2 := NewDownsample(ds.File, 5)
+	ds2.Append = true
+	for ep := 35; ep < 41; ep++ {
+		dt.Column("Epoch").SetFloat1D(float64(ep), 0)
+		assert.NoError(t, rf2.WriteRow(dt, 0, ep))
+		assert.NoError(t, ds2.WriteRow(dt, 0, ep))
+	}
+	assert.NoError(t, rf2.Close())
+	assert.NoError(t, ds2.Close())
+	assert.NoError(t, rt.OpenCSV(fsx.Filename(rf.Files[1]), tensor.Tab))
+	assert.Equal(t, 10, rt.NumRows())
+	assert.NoError(t, st.OpenCSV(fsx.Filename(ds.File), tensor.Tab))
+	assert.Equal(t, 9, st.NumRows())
+	assert.Equal(t, 40.0, st.Column("Epoch").Float1D(8))
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elog
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+// RotatingFile writes the rows of a log table (e.g., the trial log) to a
+// sequence of files, starting a new file every Epochs epochs, so that very
+// long runs do not produce unmanageably large single files. The files are
+// named Base_<epoch>.tsv (or .csv), where epoch is the first epoch of the
+// file, which is a multiple of Epochs, and each file has the table headers.
+type RotatingFile struct {
+
+	// Base is the base of the file names, including any directory.
+	Base string
+
+	// Epochs is the number of epochs per file.
+	Epochs int `default:"100"`
+
+	// Keep is the maximum number of files kept, where the oldest files
+	// are removed when a new file is started, or 0 to keep all files.
+	Keep int
+
+	// Delim is the delimiter, where Comma gives .csv files,
+	// and otherwise they are .tsv files.
+	Delim tensor.Delims
+
+	// Append appends to an existing file for the current epochs,
+	// e.g., when resuming a run, instead of overwriting it.
+	Append bool
+
+	// Files are the names of the files that have been written,
+	// and not removed, in order.
+	Files []string
+
+	// file is the current file.
+	file *os.File
+
+	// start is the first epoch of the current file.
+	start int
+}
+
+// NewRotatingFile returns a new [RotatingFile] with given file name base,
+// starting a new file every given number of epochs.
+func NewRotatingFile(base string, epochs int) *RotatingFile {
+	return &RotatingFile{Base: base, Epochs: epochs}
+}
+
+// FileName returns the name of the file for given epoch.
+func (rf *RotatingFile) FileName(epoch int) string {
+	return fmt.Sprintf("%s_%06d%s", rf.Base, rf.fileStart(epoch), delimExt(rf.Delim))
+}
+
+// fileStart returns the first epoch of the file for given epoch.
+func (rf *RotatingFile) fileStart(epoch int) int {
+	n := max(rf.Epochs, 1)
+	return (epoch / n) * n
+}
+
+// WriteRow writes given row of the table, for given epoch, starting a new
+// file if the epoch is beyond the epochs of the current file.
+func (rf *RotatingFile) WriteRow(dt *table.Table, row, epoch int) error {
+	start := rf.fileStart(epoch)
+	if rf.file == nil || start != rf.start {
+		if err := rf.rotate(dt, start); err != nil {
+			return err
+		}
+	}
+	return dt.WriteCSVRow(rf.file, row, rf.Delim)
+}
+
+// rotate closes the current file, opens the file starting at given
+// epoch, and removes the oldest files beyond Keep.
+func (rf *RotatingFile) rotate(dt *table.Table, start int) error {
+	if err := rf.Close(); err != nil {
+		return err
+	}
+	fn := rf.FileName(start)
+	f, err := openLogFile(fn, dt, rf.Delim, rf.Append)
+	if err != nil {
+		return err
+	}
+	rf.file = f
+	rf.start = start
+	rf.Files = append(rf.Files, fn)
+	var errs []error
+	for rf.Keep > 0 && len(rf.Files) > rf.Keep {
+		if err := os.Remove(rf.Files[0]); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+		rf.Files = rf.Files[1:]
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the current file, if open.
+func (rf *RotatingFile) Close() error {
+	if rf.file == nil {
+		return nil
+	}
+	err := rf.file.Close()
+	rf.file = nil
+	return err
+}
+
+// Downsample writes every Every-th epoch of the rows of a log table
+// (e.g., the epoch log) to a single continuous summary file, which stays
+// small enough to load and plot for the whole of a very long run, along
+// with the full logs in [RotatingFile]s.
+type Downsample struct {
+
+	// File is the name of the summary file, where a .csv extension
+	// gives Comma delimiters, and otherwise Tab delimiters are used.
+	File string
+
+	// Every is the interval in epochs between the rows written.
+	Every int `default:"10"`
+
+	// Append appends to an existing file, e.g., when resuming a run,
+	// instead of overwriting it.
+	Append bool
+
+	// file is the summary file.
+	file *os.File
+}
+
+// NewDownsample returns a new [Downsample] that writes every given
+// number of epochs to given file.
+func NewDownsample(file string, every int) *Downsample {
+	return &Downsample{File: file, Every: every}
+}
+
+// WriteRow writes given row of the table, if given epoch is
+// a multiple of Every.
+func (ds *Downsample) WriteRow(dt *table.Table, row, epoch int) error {
+	if epoch%max(ds.Every, 1) != 0 {
+		return nil
+	}
+	delim := fileDelim(ds.File)
+	if ds.file == nil {
+		f, err := openLogFile(ds.File, dt, delim, ds.Append)
+		if err != nil {
+			return err
+		}
+		ds.file = f
+	}
+	return dt.WriteCSVRow(ds.file, row, delim)
+}
+
+// Close closes the file, if open.
+func (ds *Downsample) Close() error {
+	if ds.file == nil {
+		return nil
+	}
+	err := ds.file.Close()
+	ds.file = nil
+	return err
+}
+
+// openLogFile opens the given log file, writing the table headers
+// unless appending to an existing non-empty file.
+func openLogFile(fn string, dt *table.Table, delim tensor.Delims, appendTo bool) (*os.File, error) {
+	if appendTo {
+		if st, err := os.Stat(fn); err == nil && st.Size() > 0 {
+			return os.OpenFile(fn, os.O_APPEND|os.O_WRONLY, 0644)
+		}
+	}
+	f, err := os.Create(fn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dt.WriteCSVHeaders(f, delim); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// delimExt returns the file extension for given delimiter.
+func delimExt(delim tensor.Delims) string {
+	if delim == tensor.Comma {
+		return ".csv"
+	}
+	return ".tsv"
+}
+
+// fileDelim returns the delimiter for given file name.
+func fileDelim(fn string) tensor.Delims {
+	if strings.HasSuffix(fn, ".csv") {
+		return tensor.Comma
+	}
+	return tensor.Tab
+}
@@ -0,0 +1,282 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elog
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"cogentcore.org/core/base/errors"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"cogentcore.org/lab/tensorfs"
+)
+
+// Column is the declaration of one log column.
+type Column struct {
+
+	// Name of the column.
+	Name string
+
+	// Type is the data type of the column values.
+	Type reflect.Kind
+
+	// CellShape is the shape of each cell for tensor-valued columns,
+	// and is empty for scalar columns.
+	CellShape []int
+}
+
+// CellSize returns the number of values in each cell.
+func (cl *Column) CellSize() int {
+	n := 1
+	for _, s := range cl.CellShape {
+		n *= s
+	}
+	return n
+}
+
+// Check returns an error if the given tensor, which has the values for
+// one row of this column (one cell), does not match the column type
+// (string vs. numeric) and cell shape. The tensor can also have an outer
+// row dimension of size 1.
+func (cl *Column) Check(val tensor.Tensor) error {
+	if val.IsString() != (cl.Type == reflect.String) {
+		return fmt.Errorf("elog: column %q of type %s: value is of type %s", cl.Name, cl.Type, val.DataType())
+	}
+	sz := val.ShapeSizes()
+	if len(sz) > 0 && sz[0] == 1 && len(sz) == len(cl.CellShape)+1 {
+		sz = sz[1:] // single row
+	}
+	if !slices.Equal(sz, cl.CellShape) && !(len(cl.CellShape) == 0 && val.Len() == 1) {
+		return fmt.Errorf("elog: column %q with cell shape %v: value has shape %v", cl.Name, cl.CellShape, val.ShapeSizes())
+	}
+	return nil
+}
+
+// CheckTensor returns an error if the given tensor, which has the values
+// for all rows of this column, does not match the column type and cell shape.
+func (cl *Column) CheckTensor(tsr tensor.Tensor) error {
+	if tsr.DataType() != cl.Type {
+		return fmt.Errorf("elog: column %q of type %s: tensor is of type %s", cl.Name, cl.Type, tsr.DataType())
+	}
+	sz := tsr.ShapeSizes()
+	if len(sz) == 0 || !slices.Equal(sz[1:], cl.CellShape) {
+		return fmt.Errorf("elog: column %q with cell shape %v: tensor has shape %v", cl.Name, cl.CellShape, sz)
+	}
+	return nil
+}
+
+// Schema is a declaration of the expected log columns, with their types
+// and tensor cell shapes, which is declared once when configuring the
+// sim, and is then used to validate every write, returning (and logging)
+// an error on any mismatch, instead of silently creating inconsistent
+// data that breaks downstream plotting and analysis.
+type Schema struct {
+
+	// Columns are the column declarations, in order.
+	Columns []*Column
+
+	// index maps from name to column index.
+	index map[string]int
+}
+
+// NewSchema returns a new empty Schema.
+func NewSchema() *Schema {
+	return &Schema{index: make(map[string]int)}
+}
+
+// Add adds a column to the schema with given name, type, and cell shape,
+// returning the schema for chaining calls. Logs an error and does not
+// add the column if a column with the same name already exists.
+func (sc *Schema) Add(name string, typ reflect.Kind, cellShape ...int) *Schema {
+	if sc.index == nil {
+		sc.index = make(map[string]int)
+	}
+	if _, has := sc.index[name]; has {
+		errors.Log(fmt.Errorf("elog.Schema: column %q already declared", name))
+		return sc
+	}
+	sc.index[name] = len(sc.Columns)
+	sc.Columns = append(sc.Columns, &Column{Name: name, Type: typ, CellShape: cellShape})
+	return sc
+}
+
+// Float64 adds a float64 column with given name and cell shape.
+func (sc *Schema) Float64(name string, cellShape ...int) *Schema {
+	return sc.Add(name, reflect.Float64, cellShape...)
+}
+
+// Float32 adds a float32 column with given name and cell shape.
+func (sc *Schema) Float32(name string, cellShape ...int) *Schema {
+	return sc.Add(name, reflect.Float32, cellShape...)
+}
+
+// Int adds an int column with given name and cell shape.
+func (sc *Schema) Int(name string, cellShape ...int) *Schema {
+	return sc.Add(name, reflect.Int, cellShape...)
+}
+
+// String adds a string column with given name and cell shape.
+func (sc *Schema) String(name string, cellShape ...int) *Schema {
+	return sc.Add(name, reflect.String, cellShape...)
+}
+
+// Column returns the column with given name, or an error if not declared.
+func (sc *Schema) Column(name string) (*Column, error) {
+	ci, ok := sc.index[name]
+	if !ok {
+		return nil, fmt.Errorf("elog: column %q not declared in schema", name)
+	}
+	return sc.Columns[ci], nil
+}
+
+// NewTable returns a new table with all of the columns in the schema.
+func (sc *Schema) NewTable() *table.Table {
+	dt := table.New()
+	for _, cl := range sc.Columns {
+		dt.AddColumnOfType(cl.Name, cl.Type, cl.CellShape...)
+	}
+	return dt
+}
+
+// Config makes values for all of the columns in the schema in the given
+// [tensorfs] directory, with 0 rows, validating any existing values.
+func (sc *Schema) Config(dir *tensorfs.Node) error {
+	var errs []error
+	for _, cl := range sc.Columns {
+		if _, err := sc.value(dir, cl); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Log(errors.Join(errs...))
+}
+
+// value returns the value for given column in given directory,
+// creating it if it does not exist, and checking it otherwise.
+func (sc *Schema) value(dir *tensorfs.Node, cl *Column) (tensor.Values, error) {
+	if nd := dir.Node(cl.Name); nd != nil {
+		tsr, ok := nd.Tensor.(tensor.Values)
+		if !ok {
+			return nil, fmt.Errorf("elog: column %q is not a tensor value", cl.Name)
+		}
+		return tsr, cl.CheckTensor(tsr)
+	}
+	return tensorfs.ValueType(dir, cl.Name, cl.Type, append([]int{0}, cl.CellShape...)...), nil
+}
+
+// ValidateTable returns an error if the given table does not have exactly
+// the columns in the schema, in the same order, with the same types and
+// cell shapes.
+func (sc *Schema) ValidateTable(dt *table.Table) error {
+	var errs []error
+	if dt.NumColumns() != len(sc.Columns) {
+		errs = append(errs, fmt.Errorf("elog: table has %d columns, schema has %d", dt.NumColumns(), len(sc.Columns)))
+	}
+	for ci, cl := range sc.Columns {
+		if ci >= dt.NumColumns() || dt.Columns.Keys[ci] != cl.Name {
+			errs = append(errs, fmt.Errorf("elog: table column %d is not %q", ci, cl.Name))
+			continue
+		}
+		if err := cl.CheckTensor(dt.Columns.Values[ci]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Log(errors.Join(errs...))
+}
+
+// ValidateDir returns an error if the given [tensorfs] directory does not
+// have values for all the columns in the schema, with the same types and
+// cell shapes, and the same number of rows.
+func (sc *Schema) ValidateDir(dir *tensorfs.Node) error {
+	var errs []error
+	rows := -1
+	for _, cl := range sc.Columns {
+		nd := dir.Node(cl.Name)
+		if nd == nil {
+			errs = append(errs, fmt.Errorf("elog: column %q not found in %s", cl.Name, dir.Path()))
+			continue
+		}
+		if err := cl.CheckTensor(nd.Tensor); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		nr := nd.Tensor.DimSize(0)
+		if rows >= 0 && nr != rows {
+			errs = append(errs, fmt.Errorf("elog: column %q has %d rows, expected %d", cl.Name, nr, rows))
+		}
+		rows = nr
+	}
+	return errors.Log(errors.Join(errs...))
+}
+
+// AppendRow appends the given value as a new row of the given column in
+// the given [tensorfs] directory, returning (and logging) an error if the
+// column is not declared, or the value does not match the declared type
+// and cell shape.
+func (sc *Schema) AppendRow(dir *tensorfs.Node, name string, val tensor.Tensor) error {
+	cl, err := sc.Column(name)
+	if err != nil {
+		return errors.Log(err)
+	}
+	if err := cl.Check(val); err != nil {
+		return errors.Log(err)
+	}
+	tsr, err := sc.value(dir, cl)
+	if err != nil {
+		return errors.Log(err)
+	}
+	row := tsr.DimSize(0)
+	tsr.SetNumRows(row + 1)
+	st := row * cl.CellSize()
+	for i := range val.Len() {
+		if cl.Type == reflect.String {
+			tsr.SetString1D(val.String1D(i), st+i)
+		} else {
+			tsr.SetFloat1D(val.Float1D(i), st+i)
+		}
+	}
+	return nil
+}
+
+// AppendFloat appends the given value as a new row of the given scalar
+// numeric column, as in [Schema.AppendRow].
+func (sc *Schema) AppendFloat(dir *tensorfs.Node, name string, val float64) error {
+	return sc.AppendRow(dir, name, tensor.NewFloat64Scalar(val))
+}
+
+// AppendString appends the given value as a new row of the given scalar
+// string column, as in [Schema.AppendRow].
+func (sc *Schema) AppendString(dir *tensorfs.Node, name string, val string) error {
+	return sc.AppendRow(dir, name, tensor.NewStringScalar(val))
+}
+
+// SetRow sets the given row of the given column in the given table to
+// the given value, returning (and logging) an error if the column is not
+// declared, or the value does not match the declared type and cell shape.
+func (sc *Schema) SetRow(dt *table.Table, name string, row int, val tensor.Tensor) error {
+	cl, err := sc.Column(name)
+	if err != nil {
+		return errors.Log(err)
+	}
+	if err := cl.Check(val); err != nil {
+		return errors.Log(err)
+	}
+	col, err := dt.ColumnTry(name)
+	if err != nil {
+		return errors.Log(err)
+	}
+	if err := cl.CheckTensor(col.Tensor); err != nil {
+		return errors.Log(err)
+	}
+	for i := range val.Len() {
+		if cl.Type == reflect.String {
+			col.SetStringRow(val.String1D(i), row, i)
+		} else {
+			col.SetFloatRow(val.Float1D(i), row, i)
+		}
+	}
+	return nil
+}
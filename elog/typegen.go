@@ -0,0 +1,17 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package elog
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/elog.Annotations", IDName: "annotations", Doc: "Annotations records discrete named events, such as a lesion being\napplied, the learning rate being dropped, or the environment being\nswitched, with the values of the time counters (e.g., Epoch, Trial)\nat which they occurred, in a dedicated log table. The events can be\ndrawn as labeled vertical markers on time-series plots, using\n[Annotations.Plotters] or [Annotations.AddToPlot].", Fields: []types.Field{{Name: "Counters", Doc: "Counters are the names of the time counters recorded for each\nevent, e.g., Epoch, Trial."}, {Name: "Schema", Doc: "Schema is the schema for the Table, with a Name string column,\nan int column for each of the Counters, and a Doc string column."}, {Name: "Table", Doc: "Table has one row per event."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/elog.Column", IDName: "column", Doc: "Column is the declaration of one log column.", Fields: []types.Field{{Name: "Name", Doc: "Name of the column."}, {Name: "Type", Doc: "Type is the data type of the column values."}, {Name: "CellShape", Doc: "CellShape is the shape of each cell for tensor-valued columns,\nand is empty for scalar columns."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/elog.Schema", IDName: "schema", Doc: "Schema is a declaration of the expected log columns, with their types\nand tensor cell shapes, which is declared once when configuring the\nsim, and is then used to validate every write, returning (and logging)\nan error on any mismatch, instead of silently creating inconsistent\ndata that breaks downstream plotting and analysis.", Fields: []types.Field{{Name: "Columns", Doc: "Columns are the column declarations, in order."}, {Name: "index", Doc: "index maps from name to column index."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/elog.RotatingFile", IDName: "rotating-file", Doc: "RotatingFile writes the rows of a log table (e.g., the trial log) to a\nsequence of files, starting a new file every Epochs epochs, so that very\nlong runs do not produce unmanageably large single files. The files are\nnamed Base_<epoch>.tsv (or .csv), where epoch is the first epoch of the\nfile, which is a multiple of Epochs, and each file has the table headers.", Fields: []types.Field{{Name: "Base", Doc: "Base is the base of the file names, including any directory."}, {Name: "Epochs", Doc: "Epochs is the number of epochs per file."}, {Name: "Keep", Doc: "Keep is the maximum number of files kept, where the oldest files\nare removed when a new file is started, or 0 to keep all files."}, {Name: "Delim", Doc: "Delim is the delimiter, where Comma gives .csv files,\nand otherwise they are .tsv files."}, {Name: "Append", Doc: "Append appends to an existing file for the current epochs,\ne.g., when resuming a run, instead of overwriting it."}, {Name: "Files", Doc: "Files are the names of the files that have been written,\nand not removed, in order."}, {Name: "file", Doc: "file is the current file."}, {Name: "start", Doc: "start is the first epoch of the current file."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/elog.Downsample", IDName: "downsample", Doc: "Downsample writes every Every-th epoch of the rows of a log table\n(e.g., the epoch log) to a single continuous summary file, which stays\nsmall enough to load and plot for the whole of a very long run, along\nwith the full logs in [RotatingFile]s.", Fields: []types.Field{{Name: "File", Doc: "File is the name of the summary file, where a .csv extension\ngives Comma delimiters, and otherwise Tab delimiters are used."}, {Name: "Every", Doc: "Every is the interval in epochs between the rows written."}, {Name: "Append", Doc: "Append appends to an existing file, e.g., when resuming a run,\ninstead of overwriting it."}, {Name: "file", Doc: "file is the summary file."}}})
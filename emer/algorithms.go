@@ -0,0 +1,88 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/emer/emergent/v2/paths"
+)
+
+// Algorithm has the constructors for a given algorithm implementation
+// (e.g., leabra, axon), registered by the algorithm package using
+// [RegisterAlgorithm], typically in an init function. This allows generic
+// tools (network builders, GUI scaffolds, config-driven model loading) to
+// create networks by algorithm name, without importing the algorithm package
+// directly (other than for its init side effect).
+type Algorithm struct {
+
+	// Name of the algorithm, used to look it up, e.g., "leabra".
+	Name string
+
+	// Doc has documentation about the algorithm.
+	Doc string
+
+	// NewNetwork returns a new network with given name.
+	NewNetwork func(name string) Network
+
+	// AddLayer adds a new layer to given network, with given name, shape
+	// and algorithm type name (i.e., the layer TypeName() string).
+	// Returns an error if the type name is not valid for the algorithm.
+	AddLayer func(net Network, name string, shape []int, typ string) (Layer, error)
+
+	// ConnectLayers adds a new pathway between given layers in given network,
+	// with given pattern of connectivity and algorithm type name
+	// (i.e., the path TypeName() string).
+	// Returns an error if the type name is not valid for the algorithm.
+	ConnectLayers func(net Network, send, recv Layer, pat paths.Pattern, typ string) (Path, error)
+
+	// Build builds the network after all layers and pathways have been added,
+	// allocating and initializing all state.
+	Build func(net Network) error
+}
+
+// Algorithms is the registry of algorithm implementations, keyed by name.
+// Use [RegisterAlgorithm] to add to it.
+var Algorithms = map[string]*Algorithm{}
+
+// RegisterAlgorithm registers given algorithm under its Name,
+// replacing any existing one with the same name.
+func RegisterAlgorithm(alg *Algorithm) {
+	Algorithms[alg.Name] = alg
+}
+
+// AlgorithmByName returns the registered algorithm of given name,
+// or an error if not found.
+func AlgorithmByName(name string) (*Algorithm, error) {
+	alg, ok := Algorithms[name]
+	if !ok {
+		return nil, fmt.Errorf("emer.AlgorithmByName: algorithm %q not registered; available: %v", name, AlgorithmNames())
+	}
+	return alg, nil
+}
+
+// AlgorithmNames returns the sorted names of all registered algorithms.
+func AlgorithmNames() []string {
+	nms := make([]string, 0, len(Algorithms))
+	for nm := range Algorithms {
+		nms = append(nms, nm)
+	}
+	slices.Sort(nms)
+	return nms
+}
+
+// NewNetwork returns a new network with given name, for the registered
+// algorithm of given name.
+func NewNetwork(algo, name string) (Network, error) {
+	alg, err := AlgorithmByName(algo)
+	if err != nil {
+		return nil, err
+	}
+	if alg.NewNetwork == nil {
+		return nil, fmt.Errorf("emer.NewNetwork: algorithm %q does not define NewNetwork", algo)
+	}
+	return alg.NewNetwork(name), nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer_test
+
+import (
+	"testing"
+
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNetworkByName(t *testing.T) {
+	assert.Contains(t, emer.AlgorithmNames(), "bp")
+	net, err := emer.NewNetwork("bp", "ByName")
+	assert.NoError(t, err)
+	assert.IsType(t, &bp.Network{}, net)
+	assert.Equal(t, "ByName", net.AsEmer().Name)
+
+	alg, err := emer.AlgorithmByName("bp")
+	assert.NoError(t, err)
+	in, err := alg.AddLayer(net, "Input", []int{2, 2}, "InputLayer")
+	assert.NoError(t, err)
+	assert.Equal(t, "InputLayer", in.TypeName())
+	hid, err := alg.AddLayer(net, "Hidden", []int{1, 3}, "HiddenLayer")
+	assert.NoError(t, err)
+	pt, err := alg.ConnectLayers(net, in, hid, paths.NewFull(), "ForwardPath")
+	assert.NoError(t, err)
+	assert.Equal(t, "ForwardPath", pt.TypeName())
+	assert.NoError(t, alg.Build(net))
+	assert.Equal(t, 2, net.NumLayers())
+	assert.Equal(t, 12, pt.NumSyns())
+
+	_, err = emer.NewNetwork("nosuch", "ByName")
+	assert.ErrorContains(t, err, `algorithm "nosuch" not registered`)
+}
+
+func TestUnknownType(t *testing.T) {
+	net, err := emer.NewNetwork("bp", "Unknown")
+	assert.NoError(t, err)
+	alg, _ := emer.AlgorithmByName("bp")
+	ly, err := alg.AddLayer(net, "Input", []int{2, 2}, "Inptu")
+	assert.ErrorContains(t, err, "Inptu")
+	assert.Nil(t, ly)
+	assert.Equal(t, 0, net.NumLayers())
+	_, err = alg.AddLayer(net, "InputD", []int{2, 2}, "DeepLayer")
+	assert.Error(t, err)
+
+	in, _ := alg.AddLayer(net, "Input", []int{2, 2}, "InputLayer")
+	pt, err := alg.ConnectLayers(net, in, in, paths.NewFull(), "Recurent")
+	assert.ErrorContains(t, err, "Recurent")
+	assert.Nil(t, pt)
+}
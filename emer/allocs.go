@@ -0,0 +1,169 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// AllocCount is the number of heap allocations made by
+// a function during a trial, recorded by [AllocTracker].
+type AllocCount struct {
+
+	// Func is the name of the function.
+	Func string
+
+	// Calls is the number of calls to the function.
+	Calls int
+
+	// Objects is the number of heap objects allocated.
+	Objects uint64
+
+	// Bytes is the number of bytes allocated.
+	Bytes uint64
+}
+
+func (ac *AllocCount) String() string {
+	return fmt.Sprintf("%s: %d allocs, %d bytes in %d calls", ac.Func, ac.Objects, ac.Bytes, ac.Calls)
+}
+
+// AllocMark records the allocation counters at the start of a
+// function tracked by [AllocTracker].
+type AllocMark struct {
+
+	// objects is the number of heap objects allocated.
+	objects uint64
+
+	// bytes is the number of bytes allocated.
+	bytes uint64
+
+	// over is the tracker's own allocations.
+	over allocOverhead
+}
+
+// allocOverhead is the number of allocations made by [AllocTracker] itself,
+// which are excluded from the counts of enclosing functions.
+type allocOverhead struct {
+	objects uint64
+	bytes   uint64
+}
+
+// AllocTracker is a debug mode that records the heap allocations made
+// by each of the per-cycle and per-trial compute functions of a network,
+// which should make no allocations in steady state, to find the source of
+// garbage collection pauses that cause jitter in long recordings.
+// The compute functions call Start and Stop, which do nothing unless
+// On is set, e.g.:
+//
+//	defer nt.Allocs.Stop("Cycle", nt.Allocs.Start())
+//
+// and EndTrial is called at the end of each trial to report the functions
+// that allocated. Because it uses [runtime.ReadMemStats], which stops the
+// world and counts the allocations of all goroutines, it is slow and should
+// only be used for debugging, with the network running in one goroutine.
+type AllocTracker struct {
+
+	// On turns on recording of allocations.
+	On bool
+
+	// Print prints the functions that allocated in EndTrial.
+	Print bool
+
+	// Trial is the number of trials ended with EndTrial.
+	Trial int
+
+	// Counts are the allocations of each function in the current
+	// trial, in the order in which they were first called.
+	Counts []AllocCount
+
+	// over is the number of allocations made by the tracker itself.
+	over allocOverhead
+
+	// stats are the memory stats, which are only allocated
+	// when tracking is on, as they are large.
+	stats *runtime.MemStats
+}
+
+// Start returns the allocation counters for a call to Stop
+// at the end of a tracked function.
+func (at *AllocTracker) Start() AllocMark {
+	if !at.On {
+		return AllocMark{}
+	}
+	ms := at.readStats()
+	return AllocMark{objects: ms.Mallocs, bytes: ms.TotalAlloc, over: at.over}
+}
+
+// Stop adds the allocations since given Start to the counts of
+// given function.
+func (at *AllocTracker) Stop(fun string, st AllocMark) {
+	if !at.On {
+		return
+	}
+	ms := at.readStats()
+	objs, bytes := ms.Mallocs, ms.TotalAlloc
+	overObjs, overBytes := at.over.objects-st.over.objects, at.over.bytes-st.over.bytes
+	ci := -1
+	for i := range at.Counts {
+		if at.Counts[i].Func == fun {
+			ci = i
+			break
+		}
+	}
+	if ci < 0 {
+		at.Counts = append(at.Counts, AllocCount{Func: fun})
+		ci = len(at.Counts) - 1
+		runtime.ReadMemStats(ms)
+		at.over.objects += ms.Mallocs - objs
+		at.over.bytes += ms.TotalAlloc - bytes
+	}
+	ac := &at.Counts[ci]
+	ac.Calls++
+	ac.Objects += objs - st.objects - overObjs
+	ac.Bytes += bytes - st.bytes - overBytes
+}
+
+// readStats reads the memory stats, allocating them on first use,
+// which is before the counters are read by the first Start.
+func (at *AllocTracker) readStats() *runtime.MemStats {
+	if at.stats == nil {
+		at.stats = &runtime.MemStats{}
+	}
+	runtime.ReadMemStats(at.stats)
+	return at.stats
+}
+
+// EndTrial returns the counts of the functions that allocated in the
+// current trial, or nil if there were none, printing them if Print is set,
+// and resets the counts for the next trial.
+func (at *AllocTracker) EndTrial() []AllocCount {
+	var allocs []AllocCount
+	for _, ac := range at.Counts {
+		if ac.Objects > 0 {
+			allocs = append(allocs, ac)
+		}
+	}
+	if at.Print && len(allocs) > 0 {
+		fmt.Print(at.Report(allocs))
+	}
+	for i := range at.Counts {
+		ac := &at.Counts[i]
+		ac.Calls, ac.Objects, ac.Bytes = 0, 0, 0
+	}
+	at.Trial++
+	return allocs
+}
+
+// Report returns a report of given allocation counts for the current trial.
+func (at *AllocTracker) Report(allocs []AllocCount) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Trial %d allocations:\n", at.Trial)
+	for i := range allocs {
+		fmt.Fprintf(&b, "\t%s\n", allocs[i].String())
+	}
+	return b.String()
+}
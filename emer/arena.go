@@ -0,0 +1,54 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+// Arena is a single contiguous block of values of one type, from which
+// the neuron and synapse state slices of a network are allocated in
+// sequence when [NetworkBase.UseArenas] is set. Allocating all of the
+// state for a very large model in a few large arenas instead of many
+// small slices reduces garbage collection pressure and improves memory
+// locality, while the resulting slices are used exactly as before.
+type Arena[T any] struct {
+
+	// Values is the backing store for all of the allocated slices.
+	Values []T
+
+	// used is the number of values allocated so far.
+	used int
+}
+
+// NewArena returns a new arena with room for n values.
+func NewArena[T any](n int) *Arena[T] {
+	return &Arena[T]{Values: make([]T, n)}
+}
+
+// Alloc returns the next n values of the arena as a slice whose capacity
+// is limited to n, so that appending to it never overwrites neighboring
+// state. If the arena is nil or does not have n values left, a new slice
+// is made instead, so that a nil arena can be used to allocate normally.
+func (ar *Arena[T]) Alloc(n int) []T {
+	if ar == nil || ar.used+n > len(ar.Values) {
+		return make([]T, n)
+	}
+	st := ar.used
+	ar.used += n
+	return ar.Values[st:ar.used:ar.used]
+}
+
+// Len returns the total number of values in the arena.
+func (ar *Arena[T]) Len() int {
+	if ar == nil {
+		return 0
+	}
+	return len(ar.Values)
+}
+
+// Used returns the number of values allocated so far.
+func (ar *Arena[T]) Used() int {
+	if ar == nil {
+		return 0
+	}
+	return ar.used
+}
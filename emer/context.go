@@ -0,0 +1,88 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"cogentcore.org/core/enums"
+	"cogentcore.org/lab/base/randx"
+)
+
+// Context has the state of the current trial that is passed to the
+// compute methods of a network (e.g., Cycle, Learn), instead of being
+// kept in the network or in globals: the evaluation mode, the phase
+// within the trial, the cycle counters, whether learning is turned off,
+// the data-parallel index, and the random number generator,
+// so that every compute method sees the same phase information,
+// and the same network can be run on different data in parallel,
+// with a context for each.
+// The mode, phase and Testing flag are set by the sim, or by a hybrid
+// network for all of its modules, and the cycle counters are incremented
+// by the network methods that compute a cycle (or time step) of activity.
+// Use [NetworkBase.NewContext] to make a context for a network.
+type Context struct {
+
+	// Mode is the current evaluation mode (e.g., Train or Test),
+	// as defined by the sim.
+	Mode enums.Enum
+
+	// Testing turns off learning for the current trial, so that the
+	// Learn methods do nothing, e.g., when testing a trained network.
+	Testing bool
+
+	// Phase is the index of the current phase within the trial.
+	Phase int
+
+	// PlusPhase is true if this is the plus (outcome) phase,
+	// when the targets are present, and false in the minus
+	// (expectation) phase.
+	PlusPhase bool
+
+	// Cycle is the current cycle (or time step) within the trial,
+	// which is incremented with CycleInc by the network method that
+	// computes a cycle, and reset by NewTrial.
+	Cycle int
+
+	// CyclesTotal is the total number of cycles since the context was made.
+	CyclesTotal int
+
+	// Di is the data-parallel index of the input pattern being processed,
+	// for networks that can process multiple patterns in parallel.
+	Di int
+
+	// NData is the number of input patterns processed in parallel.
+	NData int
+
+	// Rand is the random number generator for any stochastic computation,
+	// which is the network's Rand by default.
+	Rand randx.Rand `display:"-"`
+}
+
+// NewContext returns a new [Context] for the network, using the
+// network's random number generator, and with NData set to the
+// number of input patterns processed in parallel.
+func (nt *NetworkBase) NewContext() *Context {
+	return &Context{NData: max(nt.EmerNetwork.NParallelData(), 1), Rand: &nt.Rand}
+}
+
+// NewTrial resets the cycle and phase counters at the start of a trial.
+func (ctx *Context) NewTrial() {
+	ctx.Cycle = 0
+	ctx.Phase = 0
+	ctx.PlusPhase = false
+}
+
+// SetPhase sets the current phase within the trial.
+func (ctx *Context) SetPhase(phase int, plus bool) {
+	ctx.Phase = phase
+	ctx.PlusPhase = plus
+}
+
+// CycleInc increments the cycle counters, at the end of a cycle.
+// It is called by the network method that computes a cycle,
+// e.g., Cycle in hebb and Forward in bp.
+func (ctx *Context) CycleInc() {
+	ctx.Cycle++
+	ctx.CyclesTotal++
+}
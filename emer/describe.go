@@ -0,0 +1,241 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"cogentcore.org/core/core"
+	"github.com/emer/emergent/v2/params"
+	"github.com/emer/emergent/v2/relpos"
+)
+
+// Description is a machine-readable description of the structure of a
+// network, returned by [NetworkBase.Describe], for the GUI, documentation
+// generators and external tools, which can be saved as JSON.
+type Description struct {
+
+	// Name is the name of the network.
+	Name string
+
+	// NumUnits is the total number of units in all layers.
+	NumUnits int
+
+	// NumSyns is the total number of synapses in all pathways.
+	NumSyns int
+
+	// ParamSheets are the names of the parameter sheets that have been
+	// applied to the network, in order (see [ApplyParamSheets]).
+	ParamSheets []string `json:",omitempty"`
+
+	// MetaData is the metadata of the network.
+	MetaData map[string]string `json:",omitempty"`
+
+	// Layers are the descriptions of the layers, in order.
+	Layers []LayerDescription
+
+	// Paths are the descriptions of the pathways, in order of the
+	// receiving layer, and then of its receiving pathways.
+	Paths []PathDescription
+}
+
+// LayerDescription is a machine-readable description of a layer,
+// in a network [Description].
+type LayerDescription struct {
+
+	// Name is the name of the layer.
+	Name string
+
+	// Type is the algorithm-specific type of the layer.
+	Type string
+
+	// Class has the parameter style classes of the layer.
+	Class string `json:",omitempty"`
+
+	// Doc is the documentation of the layer.
+	Doc string `json:",omitempty"`
+
+	// Shape is the shape of the layer.
+	Shape []int
+
+	// NumUnits is the number of units in the layer.
+	NumUnits int
+
+	// Pos is the position of the layer relative to other layers.
+	Pos relpos.Pos
+
+	// UnitGroups are the names of the unit groups of the layer, sorted.
+	UnitGroups []string `json:",omitempty"`
+
+	// Off is whether the layer is turned off.
+	Off bool `json:",omitempty"`
+
+	// Params is the listing of all of the parameters of the layer,
+	// from its AllParams method.
+	Params string `json:",omitempty"`
+
+	// NonDefaultParams is the listing of the parameters of the layer
+	// that are not at their default values, from its NonDefaultParams method.
+	NonDefaultParams string `json:",omitempty"`
+}
+
+// PathDescription is a machine-readable description of a pathway,
+// in a network [Description].
+type PathDescription struct {
+
+	// Name is the name of the pathway.
+	Name string
+
+	// Type is the algorithm-specific type of the pathway.
+	Type string
+
+	// Class has the parameter style classes of the pathway.
+	Class string `json:",omitempty"`
+
+	// Doc is the documentation of the pathway.
+	Doc string `json:",omitempty"`
+
+	// Send is the name of the sending layer.
+	Send string
+
+	// Recv is the name of the receiving layer.
+	Recv string
+
+	// Pattern is the name of the pattern of connectivity.
+	Pattern string
+
+	// NumSyns is the number of synapses in the pathway.
+	NumSyns int
+
+	// Off is whether the pathway is turned off.
+	Off bool `json:",omitempty"`
+
+	// Params is the listing of all of the parameters of the pathway,
+	// from its AllParams method.
+	Params string `json:",omitempty"`
+}
+
+// AddParamSheets records the names of given parameter sheets in
+// ParamSheets, as having been applied to the network, for [NetworkBase.Describe].
+// Names already recorded are moved to the end, as the last applied.
+// This is called by [ApplyParamSheets], and only needs to be called
+// directly for sheets that are applied in other ways.
+func (nt *NetworkBase) AddParamSheets(names ...string) {
+	for _, nm := range names {
+		nt.ParamSheets = slices.DeleteFunc(nt.ParamSheets, func(s string) bool { return s == nm })
+		nt.ParamSheets = append(nt.ParamSheets, nm)
+	}
+}
+
+// ApplyParamSheets applies the parameter sheets of given names from given
+// sheets, in order, to each of given objects of the network (e.g., its
+// layers, or their algorithm-specific params), and records the names of
+// the sheets in the network ParamSheets with AddParamSheets, so that the
+// [Description] has the parameters that were actually used. Selectors that
+// do not match any object are reported as warnings to [problems.Default].
+// Returns an error for any sheet that is not found, which is not recorded.
+func ApplyParamSheets[T params.Styler](nt *NetworkBase, sheets params.Sheets[T], objs []T, names ...string) error {
+	var errs []error
+	for _, nm := range names {
+		sh, err := sheets.SheetByName(nm)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sh.SelMatchReset()
+		for _, obj := range objs {
+			sh.Apply(obj)
+		}
+		sh.SelNoMatchWarn(nm, nt.Name)
+		nt.AddParamSheets(nm)
+	}
+	return errors.Join(errs...)
+}
+
+// Describe returns a machine-readable [Description] of the structure of
+// the network: its layers and pathways, with their shapes, types, classes,
+// patterns of connectivity and parameters, and the parameter sheets applied.
+func (nt *NetworkBase) Describe() *Description {
+	en := nt.EmerNetwork
+	d := &Description{Name: nt.Name, ParamSheets: slices.Clone(nt.ParamSheets), MetaData: maps.Clone(nt.MetaData)}
+	for li := range en.NumLayers() {
+		el := en.EmerLayer(li)
+		ly := el.AsEmer()
+		ld := LayerDescription{Name: ly.Name, Type: el.TypeName(), Class: ly.Class, Doc: ly.Doc, Shape: slices.Clone(ly.Shape.Sizes), NumUnits: ly.NumUnits(), Pos: ly.Pos, Off: ly.Off, Params: el.AllParams(), NonDefaultParams: el.NonDefaultParams()}
+		for nm := range ly.UnitGroups {
+			ld.UnitGroups = append(ld.UnitGroups, nm)
+		}
+		slices.Sort(ld.UnitGroups)
+		d.Layers = append(d.Layers, ld)
+		d.NumUnits += ld.NumUnits
+		for pi := range el.NumRecvPaths() {
+			ep := el.RecvPath(pi)
+			pt := ep.AsEmer()
+			pd := PathDescription{Name: pt.Name, Type: ep.TypeName(), Class: pt.Class, Doc: pt.Doc, Send: ep.SendLayer().Label(), Recv: ly.Name, NumSyns: ep.NumSyns(), Off: pt.Off, Params: ep.AllParams()}
+			if pt.Pattern != nil {
+				pd.Pattern = pt.Pattern.Name()
+			}
+			d.Paths = append(d.Paths, pd)
+			d.NumSyns += pd.NumSyns
+		}
+	}
+	return d
+}
+
+// WriteJSON writes the description to given writer in indented JSON format.
+func (d *Description) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(d)
+}
+
+// SaveJSON saves the description to given file in indented JSON format.
+func (d *Description) SaveJSON(filename core.Filename) error {
+	fp, err := os.Create(string(filename))
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	return d.WriteJSON(fp)
+}
+
+// String returns a human-readable summary of the description,
+// with one line for the network, and each layer and pathway.
+func (d *Description) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Network: %s: %d units, %d synapses", d.Name, d.NumUnits, d.NumSyns)
+	if len(d.ParamSheets) > 0 {
+		fmt.Fprintf(&b, ", params: %s", strings.Join(d.ParamSheets, ", "))
+	}
+	b.WriteString("\n")
+	for _, ld := range d.Layers {
+		fmt.Fprintf(&b, "Layer: %s (%s) %v: %d units", ld.Name, ld.Type, ld.Shape, ld.NumUnits)
+		describeClassOff(&b, ld.Class, ld.Off)
+	}
+	for _, pd := range d.Paths {
+		fmt.Fprintf(&b, "Path: %s (%s) %s -> %s, %s: %d synapses", pd.Name, pd.Type, pd.Send, pd.Recv, pd.Pattern, pd.NumSyns)
+		describeClassOff(&b, pd.Class, pd.Off)
+	}
+	return b.String()
+}
+
+// describeClassOff finishes a line of [Description.String]
+// with the class and off status.
+func describeClassOff(b *strings.Builder, class string, off bool) {
+	if class != "" {
+		fmt.Fprintf(b, " .%s", strings.Join(strings.Fields(class), " ."))
+	}
+	if off {
+		b.WriteString(" Off")
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import "fmt"
+
+// LayerNotFoundError is returned by [NetworkBase.EmerLayerByName]
+// when there is no layer with the given name in the network.
+type LayerNotFoundError struct {
+
+	// Name is the name of the layer that was looked for.
+	Name string
+
+	// Network is the name of the network.
+	Network string
+}
+
+func (e *LayerNotFoundError) Error() string {
+	return fmt.Sprintf("Layer named: %s not found in Network: %s", e.Name, e.Network)
+}
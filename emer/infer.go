@@ -0,0 +1,124 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"errors"
+	"fmt"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+)
+
+// BatchInfer has the configuration for running inference on every
+// row of a table of input patterns with [NetworkBase.InferTable],
+// for using a trained model in analysis scripts and applications.
+// Because applying inputs and running a trial are specific to each
+// algorithm, they are done by the ApplyInputs and RunTrial functions,
+// which must be set.
+type BatchInfer struct {
+
+	// Outputs are the names of the layers whose activity
+	// is recorded in the results.
+	Outputs []string
+
+	// Var is the unit variable that is recorded for the Outputs.
+	Var string
+
+	// NData is the number of rows processed in parallel on each trial,
+	// using data parallel indexes, which is limited to MaxParallelData
+	// of the network. If 0, NParallelData of the network is used.
+	NData int
+
+	// ApplyInputs applies the input patterns from given row of the inputs
+	// table to the network, for given data parallel index, e.g., using
+	// ApplyExt on the input layers with the corresponding columns.
+	ApplyInputs func(inputs *table.Table, row, di int) `display:"-"`
+
+	// RunTrial runs one trial of inference, without learning, on the given
+	// number of data parallel inputs, e.g., by running a Test mode looper
+	// Stack for one trial, or just the minus phase.
+	RunTrial func(ndata int) `display:"-"`
+}
+
+// NewBatchInfer returns a new [BatchInfer] recording the Act
+// variable for given output layers. ApplyInputs and RunTrial
+// must be set.
+func NewBatchInfer(outputs ...string) *BatchInfer {
+	return &BatchInfer{Outputs: outputs, Var: "Act"}
+}
+
+// InferTable runs inference on every row of given table of input patterns,
+// according to given [BatchInfer] configuration, processing NData rows in
+// parallel on each trial, and returns a results table with one row for
+// each input row, with the string columns of the inputs (e.g., Name),
+// and a column for each of the Outputs layers, with its shape, holding
+// the recorded Var values at the end of the trial.
+func (nt *NetworkBase) InferTable(inputs *table.Table, bi *BatchInfer) (*table.Table, error) {
+	if bi.ApplyInputs == nil || bi.RunTrial == nil {
+		return nil, errors.New("emer.InferTable: ApplyInputs and RunTrial must be set")
+	}
+	net := nt.EmerNetwork
+	lays := make([]Layer, len(bi.Outputs))
+	for i, lnm := range bi.Outputs {
+		ly, err := nt.EmerLayerByName(lnm)
+		if err != nil {
+			return nil, fmt.Errorf("emer.InferTable: %w", err)
+		}
+		if _, err := UnitVarIndex(ly, bi.Var); err != nil {
+			return nil, fmt.Errorf("emer.InferTable: layer %q: %w", lnm, err)
+		}
+		lays[i] = ly
+	}
+	nd := bi.NData
+	if nd <= 0 {
+		nd = net.NParallelData()
+	}
+	nd = max(min(nd, net.MaxParallelData()), 1)
+
+	nrows := inputs.NumRows()
+	dt := table.New()
+	metadata.SetName(dt, "Infer")
+	var strs []string
+	for ci, cl := range inputs.Columns.Values {
+		if cl.IsString() {
+			nm := inputs.Columns.Keys[ci]
+			strs = append(strs, nm)
+			dt.AddStringColumn(nm)
+		}
+	}
+	for _, ly := range lays {
+		lb := ly.AsEmer()
+		dt.AddFloat32Column(lb.Name, lb.Shape.Sizes...)
+	}
+	dt.SetNumRows(nrows)
+	for _, nm := range strs {
+		ic, oc := inputs.Column(nm), dt.Column(nm)
+		for row := range nrows {
+			oc.SetStringRow(ic.StringRow(row, 0), row, 0)
+		}
+	}
+
+	var vals []float32
+	for st := 0; st < nrows; st += nd {
+		n := min(nd, nrows-st)
+		for di := range n {
+			bi.ApplyInputs(inputs, st+di, di)
+		}
+		bi.RunTrial(n)
+		for di := range n {
+			row := st + di
+			for _, ly := range lays {
+				lb := ly.AsEmer()
+				lb.UnitValues(&vals, bi.Var, di)
+				cl := dt.Column(lb.Name)
+				for i, v := range vals {
+					cl.SetFloatRow(float64(v), row, i)
+				}
+			}
+		}
+	}
+	return dt, nil
+}
@@ -5,15 +5,17 @@
 package emer
 
 import (
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"math"
+	"strings"
 
 	"cogentcore.org/core/base/slicesx"
 	"cogentcore.org/core/math32"
 	"cogentcore.org/lab/tensor"
 	"github.com/emer/emergent/v2/params"
+	"github.com/emer/emergent/v2/problems"
 	"github.com/emer/emergent/v2/relpos"
 	"github.com/emer/emergent/v2/weights"
 )
@@ -192,6 +194,12 @@ type LayerBase struct {
 	// units in the central pools of a 4D layer.
 	SampleShape tensor.Shape `table:"-"`
 
+	// UnitGroups are named subsets of units within the layer, as sorted
+	// lists of 1D unit indexes, for functionally distinct populations
+	// that can be targeted by params, lesions, logging and the NetView.
+	// See AddUnitGroup.
+	UnitGroups map[string][]int `table:"-"`
+
 	// optional metadata that is saved in network weights files,
 	// e.g., can indicate number of epochs that were trained,
 	// or any other information about this network that would be useful to save.
@@ -209,6 +217,15 @@ func InitLayer(l Layer, name string) {
 func (ly *LayerBase) AsEmer() *LayerBase { return ly }
 func (ly *LayerBase) Label() string      { return ly.Name }
 
+// StyleClass implements the [params.Styler] interface for parameter setting,
+// with the type name of the layer and its Class.
+func (ly *LayerBase) StyleClass() string {
+	return strings.TrimSpace(ly.EmerLayer.TypeName() + " " + ly.Class)
+}
+
+// StyleName implements the [params.Styler] interface for parameter setting.
+func (ly *LayerBase) StyleName() string { return ly.Name }
+
 // AddClass adds a CSS-style class name(s) for this layer,
 // ensuring that it is not a duplicate, and properly space separated.
 // Returns Layer so it can be chained to set other properties too.
@@ -328,7 +345,7 @@ func (ly *LayerBase) NumPools() int {
 func (ly *LayerBase) UnitValues(vals *[]float32, varNm string, di int) error {
 	nn := ly.NumUnits()
 	*vals = slicesx.SetLength(*vals, nn)
-	vidx, err := ly.EmerLayer.UnitVarIndex(varNm)
+	vidx, err := UnitVarIndex(ly.EmerLayer, varNm)
 	if err != nil {
 		nan := math32.NaN()
 		for lni := range nn {
@@ -351,13 +368,11 @@ func (ly *LayerBase) UnitValues(vals *[]float32, varNm string, di int) error {
 // Returns error on invalid var name.
 func (ly *LayerBase) UnitValuesTensor(tsr tensor.Values, varNm string, di int) error {
 	if tsr == nil {
-		err := fmt.Errorf("emer.UnitValuesTensor: Tensor is nil")
-		log.Println(err)
-		return err
+		return problems.Err("emer", ly.Name, errors.New("emer.UnitValuesTensor: Tensor is nil"))
 	}
 	nn := ly.NumUnits()
 	tsr.SetShapeSizes(ly.Shape.Sizes...)
-	vidx, err := ly.EmerLayer.UnitVarIndex(varNm)
+	vidx, err := UnitVarIndex(ly.EmerLayer, varNm)
 	if err != nil {
 		nan := math.NaN()
 		for lni := 0; lni < nn; lni++ {
@@ -395,15 +410,13 @@ func (ly *LayerBase) UnitValuesSampleTensor(tsr tensor.Values, varNm string, di
 		return ly.UnitValuesTensor(tsr, varNm, di)
 	}
 	if tsr == nil {
-		err := fmt.Errorf("emer.UnitValuesSampleTensor: Tensor is nil")
-		log.Println(err)
-		return err
+		return problems.Err("emer", ly.Name, errors.New("emer.UnitValuesSampleTensor: Tensor is nil"))
 	}
 	if tsr.Len() != nu {
 		rs := ly.GetSampleShape()
 		tsr.SetShapeSizes(rs.Sizes...)
 	}
-	vidx, err := ly.EmerLayer.UnitVarIndex(varNm)
+	vidx, err := UnitVarIndex(ly.EmerLayer, varNm)
 	if err != nil {
 		nan := math.NaN()
 		for i, _ := range ly.SampleIndexes {
@@ -428,7 +441,7 @@ func (ly *LayerBase) UnitValuesSampleTensor(tsr tensor.Values, varNm string, di
 // di is a data parallel index di, for networks capable of
 // processing input patterns in parallel.
 func (ly *LayerBase) UnitValue(varNm string, idx []int, di int) float32 {
-	vidx, err := ly.EmerLayer.UnitVarIndex(varNm)
+	vidx, err := UnitVarIndex(ly.EmerLayer, varNm)
 	if err != nil {
 		return math32.NaN()
 	}
@@ -9,14 +9,13 @@ package emer
 import (
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"strings"
 
-	"cogentcore.org/core/base/errors"
 	"cogentcore.org/core/core"
 	"cogentcore.org/core/math32"
 	"cogentcore.org/lab/base/randx"
+	"github.com/emer/emergent/v2/problems"
 	"github.com/emer/emergent/v2/relpos"
 )
 
@@ -169,6 +168,11 @@ type NetworkBase struct {
 	// or any other information about this network that would be useful to save.
 	MetaData map[string]string
 
+	// ParamSheets are the names of the parameter sheets that have been
+	// applied to the network, in order, recorded with AddParamSheets
+	// for the network Description.
+	ParamSheets []string
+
 	// random number generator for the network.
 	// all random calls must use this.
 	// Set seed here for weight initialization values.
@@ -178,6 +182,22 @@ type NetworkBase struct {
 	// the network and initializing the weights.
 	// Set this to get a different set of weights.
 	RandSeed int64 `edit:"-"`
+
+	// UseArenas allocates all of the neuron and synapse state of the
+	// network in a few large contiguous [Arena]s (one per value type)
+	// in Build, instead of many small slices, which reduces garbage
+	// collection pressure and improves locality for very large models.
+	// Must be set before calling Build.
+	UseArenas bool
+
+	// Allocs is a debug mode that records the heap allocations made by
+	// the compute functions of the network on each trial, which should
+	// be zero in steady state.
+	Allocs AllocTracker `display:"-"`
+
+	// Snapshots keeps recent snapshots of the network weights,
+	// for reverting with RollbackWeights when training destabilizes.
+	Snapshots WeightSnapshots `display:"-"`
 }
 
 // InitNetwork initializes the network, setting the EmerNetwork interface
@@ -206,7 +226,7 @@ func (nt *NetworkBase) UpdateLayerNameMap() {
 }
 
 // EmerLayerByName returns a layer by looking it up by name.
-// returns error message if layer is not found.
+// returns a [*LayerNotFoundError] if layer is not found.
 func (nt *NetworkBase) EmerLayerByName(name string) (Layer, error) {
 	if nt.LayerNameMap == nil || len(nt.LayerNameMap) != nt.EmerNetwork.NumLayers() {
 		nt.UpdateLayerNameMap()
@@ -214,30 +234,30 @@ func (nt *NetworkBase) EmerLayerByName(name string) (Layer, error) {
 	if ly, ok := nt.LayerNameMap[name]; ok {
 		return ly, nil
 	}
-	err := fmt.Errorf("Layer named: %s not found in Network: %s", name, nt.Name)
-	return nil, err
+	return nil, &LayerNotFoundError{Name: name, Network: nt.Name}
 }
 
 // EmerPathByName returns a path by looking it up by name.
 // Paths are named SendToRecv = sending layer name "To" recv layer name.
-// returns error message if path is not found.
+// returns error message if path is not found, which is also reported
+// to [problems.Default].
 func (nt *NetworkBase) EmerPathByName(name string) (Path, error) {
 	ti := strings.Index(name, "To")
 	if ti < 0 {
-		return nil, errors.Log(fmt.Errorf("EmerPathByName: path name must contain 'To': %s", name))
+		return nil, problems.Err("emer", name, fmt.Errorf("EmerPathByName: path name must contain 'To': %s", name))
 	}
 	sendNm := name[:ti]
 	recvNm := name[ti+2:]
 	_, err := nt.EmerLayerByName(sendNm)
-	if errors.Log(err) != nil {
+	if problems.Err("emer", name, err) != nil {
 		return nil, err
 	}
 	recv, err := nt.EmerLayerByName(recvNm)
-	if errors.Log(err) != nil {
+	if problems.Err("emer", name, err) != nil {
 		return nil, err
 	}
 	path, err := recv.AsEmer().RecvPathBySendName(sendNm)
-	if errors.Log(err) != nil {
+	if problems.Err("emer", name, err) != nil {
 		return nil, err
 	}
 	return path, nil
@@ -263,7 +283,7 @@ func (nt *NetworkBase) LayoutLayers() {
 			} else {
 				if ly.Pos.Other != "" {
 					olyi, err := nt.EmerLayerByName(ly.Pos.Other)
-					if errors.Log(err) != nil {
+					if problems.Warn("emer", ly.Name, err) != nil {
 						continue
 					}
 					oly = olyi.AsEmer()
@@ -349,49 +369,37 @@ func (nt *NetworkBase) VarRange(varNm string) (min, max float32, err error) {
 
 // NonDefaultParams returns a listing of all parameters in the Network that
 // are not at their default values -- useful for setting param styles etc.
+// It is built from the layer NonDefaultParams in the [Description].
 func (nt *NetworkBase) NonDefaultParams() string {
-	nds := ""
-	en := nt.EmerNetwork
-	nlay := en.NumLayers()
-	for li := range nlay {
-		ly := en.EmerLayer(li)
-		nd := ly.NonDefaultParams()
-		nds += nd
+	var b strings.Builder
+	for _, ld := range nt.Describe().Layers {
+		b.WriteString(ld.NonDefaultParams)
 	}
-	return nds
+	return b.String()
 }
 
 // AllParams returns a listing of all parameters in the Network.
+// It is built from the layer Params in the [Description].
 func (nt *NetworkBase) AllParams() string {
-	nds := ""
-	en := nt.EmerNetwork
-	nlay := en.NumLayers()
-	for li := range nlay {
-		ly := en.EmerLayer(li)
-		nd := ly.AllParams()
-		nds += nd
+	var b strings.Builder
+	for _, ld := range nt.Describe().Layers {
+		b.WriteString(ld.Params)
 	}
-	return nds
+	return b.String()
 }
 
 // SaveAllParams saves list of all parameters in Network to given file.
 func (nt *NetworkBase) SaveAllParams(filename core.Filename) error {
 	str := nt.AllParams()
 	err := os.WriteFile(string(filename), []byte(str), 0666)
-	if err != nil {
-		log.Println(err)
-	}
-	return err
+	return problems.Err("emer", string(filename), err)
 }
 
 // SaveNonDefaultParams saves list of all non-default parameters in Network to given file.
 func (nt *NetworkBase) SaveNonDefaultParams(filename core.Filename) error {
 	str := nt.NonDefaultParams()
 	err := os.WriteFile(string(filename), []byte(str), 0666)
-	if err != nil {
-		log.Println(err)
-	}
-	return err
+	return problems.Err("emer", string(filename), err)
 }
 
 // SetRandSeed sets random seed and calls ResetRandSeed
@@ -133,6 +133,10 @@ type PathBase struct {
 
 	// Off inactivates this pathway, allowing for easy experimentation.
 	Off bool
+
+	// MetaData is optional metadata that is saved in network weights files,
+	// e.g., the [weights.Provenance] of the pathway (see SetProvenance).
+	MetaData map[string]string
 }
 
 // InitPath initializes the path, setting the EmerPath interface
@@ -145,6 +149,15 @@ func InitPath(pt Path) {
 func (pt *PathBase) AsEmer() *PathBase { return pt }
 func (pt *PathBase) Label() string     { return pt.Name }
 
+// StyleClass implements the [params.Styler] interface for parameter setting,
+// with the type name of the pathway and its Class.
+func (pt *PathBase) StyleClass() string {
+	return strings.TrimSpace(pt.EmerPath.TypeName() + " " + pt.Class)
+}
+
+// StyleName implements the [params.Styler] interface for parameter setting.
+func (pt *PathBase) StyleName() string { return pt.Name }
+
 // AddClass adds a CSS-style class name(s) for this path,
 // ensuring that it is not a duplicate, and properly space separated.
 // Returns Path so it can be chained to set other properties too.
@@ -174,7 +187,7 @@ func (pt *PathBase) IsTypeOrClass(types string) bool {
 // between given send, recv unit indexes (1D, flat indexes).
 // Returns math32.NaN() for access errors.
 func (pt *PathBase) SynValue(varNm string, sidx, ridx int) float32 {
-	vidx, err := pt.EmerPath.SynVarIndex(varNm)
+	vidx, err := SynVarIndex(pt.EmerPath, varNm)
 	if err != nil {
 		return math32.NaN()
 	}
@@ -0,0 +1,61 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/emer/emergent/v2/weights"
+)
+
+// SetProvenance records given training history of this pathway in its
+// MetaData, which is saved in the weights file, and restored on loading
+// it, setting the ParamHash from the current AllParams if it is empty.
+func (pt *PathBase) SetProvenance(pv *weights.Provenance) {
+	if pv.ParamHash == "" {
+		pv.ParamHash = weights.ParamHash(pt.EmerPath.AllParams())
+	}
+	if pt.MetaData == nil {
+		pt.MetaData = make(map[string]string)
+	}
+	pv.SetMetaData(pt.MetaData)
+}
+
+// Provenance returns the training history of this pathway recorded in
+// its MetaData, e.g., from a loaded weights file, or nil if there is none.
+func (pt *PathBase) Provenance() (*weights.Provenance, error) {
+	return weights.ProvenanceFromMetaData(pt.MetaData)
+}
+
+// ProvenanceReport returns a report of the training history of each
+// receiving pathway in the network that has one recorded, one per line,
+// e.g., after loading a composite model from separately trained weights.
+// Pathways whose parameters have changed since they were trained
+// (according to the ParamHash) are flagged as changed.
+func (nt *NetworkBase) ProvenanceReport() string {
+	var b strings.Builder
+	en := nt.EmerNetwork
+	for li := range en.NumLayers() {
+		ly := en.EmerLayer(li)
+		for pi := range ly.NumRecvPaths() {
+			pb := ly.RecvPath(pi).AsEmer()
+			pv, err := pb.Provenance()
+			if err != nil {
+				fmt.Fprintf(&b, "%s: %v\n", pb.Name, err)
+				continue
+			}
+			if pv == nil {
+				continue
+			}
+			fmt.Fprintf(&b, "%s: %s", pb.Name, pv.String())
+			if pv.ParamHash != weights.ParamHash(pb.EmerPath.AllParams()) {
+				b.WriteString(" (params changed)")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
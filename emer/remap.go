@@ -0,0 +1,176 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"cogentcore.org/core/base/errors"
+	"github.com/emer/emergent/v2/weights"
+)
+
+// OpenWeightsRemapJSON opens network weights from a JSON-formatted file,
+// remapping the weights of layers whose saved shapes differ from this
+// network, as in [NetworkBase.SetWeightsRemap], and returns a report of
+// the mapping. If filename has .gz extension, then file is gzip uncompressed.
+func (nt *NetworkBase) OpenWeightsRemapJSON(filename string, rm *weights.Remap) (string, error) {
+	fp, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer fp.Close()
+	var r io.Reader = bufio.NewReader(fp)
+	if filepath.Ext(filename) == ".gz" {
+		gzr, err := gzip.NewReader(fp)
+		if err != nil {
+			return "", err
+		}
+		defer gzr.Close()
+		r = gzr
+	}
+	nw, err := weights.NetReadJSON(r)
+	if err != nil || nw == nil {
+		return "", err
+	}
+	return nt.SetWeightsRemap(nw, rm)
+}
+
+// SetWeightsRemap sets the weights for this network from given decoded
+// weights, remapping the weights of layers whose saved shapes (given in
+// the Remap Shapes) differ from this network, using the Remap Method,
+// so that architectures can be scaled up without retraining from scratch.
+// The weights of new synapses without any corresponding saved synapses
+// keep their initial values, and unit-level values are remapped in the same
+// way, with units without any saved units keeping their current values
+// (a unit variable that cannot be read from the layer is reported as an
+// error, and not set). Returns a report of the
+// mapping of each remapped layer and pathway. Any algorithm-specific updates
+// after loading weights (as in ReadWeightsJSON) must be done after this.
+func (nt *NetworkBase) SetWeightsRemap(nw *weights.Network, rm *weights.Remap) (string, error) {
+	var b strings.Builder
+	var errs []error
+	shapes := func(ly Layer) (from, to []int) {
+		to = ly.AsEmer().Shape.Sizes
+		if sh, ok := rm.Shapes[ly.Label()]; ok {
+			return sh, to
+		}
+		return to, to
+	}
+	rnw := &weights.Network{Network: nw.Network, MetaData: nw.MetaData, Layers: make([]weights.Layer, len(nw.Layers))}
+	for li := range nw.Layers {
+		lw := &nw.Layers[li]
+		rlw := &rnw.Layers[li]
+		*rlw = *lw
+		ly, err := nt.EmerLayerByName(lw.Layer)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rfrom, rto := shapes(ly)
+		rmap := weights.UnitMap(rfrom, rto, rm.Method)
+		rchg := !slices.Equal(rfrom, rto)
+		if rchg {
+			fmt.Fprintf(&b, "%s: %v -> %v (%s)\n", lw.Layer, rfrom, rto, rm.Method)
+			rlw.Units = make(map[string][]float32, len(lw.Units))
+			var cur []float32
+			for vn, vals := range lw.Units {
+				rv := weights.RemapUnits(vals, rmap)
+				if slices.ContainsFunc(rv, isNaN) {
+					if err := ly.AsEmer().UnitValues(&cur, vn, 0); err != nil {
+						errs = append(errs, fmt.Errorf("emer.SetWeightsRemap: layer %s: %w", lw.Layer, err))
+						continue
+					}
+					for i, v := range rv {
+						if isNaN(v) {
+							rv[i] = cur[i]
+						}
+					}
+				}
+				rlw.Units[vn] = rv
+			}
+		}
+		rlw.Paths = make([]weights.Path, 0, len(lw.Paths))
+		nfrom := make(map[string]int)
+		for pi := range lw.Paths {
+			pw := &lw.Paths[pi]
+			pt := recvPathFrom(ly, pw.From, nfrom[pw.From])
+			nfrom[pw.From]++
+			if pt == nil {
+				errs = append(errs, fmt.Errorf("emer.SetWeightsRemap: layer %s has no pathway from %s", lw.Layer, pw.From))
+				continue
+			}
+			sfrom, sto := shapes(pt.SendLayer())
+			if !rchg && slices.Equal(sfrom, sto) {
+				rlw.Paths = append(rlw.Paths, *pw)
+				continue
+			}
+			smap := weights.UnitMap(sfrom, sto, rm.Method)
+			syns := make([][]int, ly.AsEmer().NumUnits())
+			nsyn := 0
+			err := pt.AsEmer().RangeSyns("Wt", func(sidx, ridx int, val float32) bool {
+				syns[ridx] = append(syns[ridx], sidx)
+				nsyn++
+				return true
+			})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("emer.SetWeightsRemap: pathway %s: %w", pt.Label(), err))
+				continue
+			}
+			for _, sis := range syns {
+				slices.Sort(sis)
+			}
+			rpw, nmiss, err := pw.RemapPath(smap, rmap, numUnits(sfrom), syns)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			rlw.Paths = append(rlw.Paths, *rpw)
+			fmt.Fprintf(&b, "%s: %d synapses remapped, %d without saved synapses\n", pt.Label(), nsyn-nmiss, nmiss)
+		}
+	}
+	if err := nt.SetWeights(rnw); err != nil {
+		errs = append(errs, err)
+	}
+	return b.String(), errors.Join(errs...)
+}
+
+// recvPathFrom returns the n-th receiving pathway of given layer from
+// the sending layer with given name, that is not Off, or nil if none.
+func recvPathFrom(ly Layer, from string, n int) Path {
+	for pi := range ly.NumRecvPaths() {
+		pt := ly.RecvPath(pi)
+		if pt.AsEmer().Off || pt.SendLayer().Label() != from {
+			continue
+		}
+		if n == 0 {
+			return pt
+		}
+		n--
+	}
+	return nil
+}
+
+// isNaN returns true if given value is NaN,
+// for units without saved units in [weights.RemapUnits].
+func isNaN(v float32) bool {
+	return math.IsNaN(float64(v))
+}
+
+// numUnits returns the number of units for given layer shape.
+func numUnits(shape []int) int {
+	n := 1
+	for _, s := range shape {
+		n *= s
+	}
+	return n
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"cogentcore.org/core/core"
+	"github.com/emer/emergent/v2/problems"
+	"github.com/emer/emergent/v2/ringidx"
+)
+
+// WeightSnapshots keeps the most recent snapshots of the network weights,
+// e.g., taken at the end of each epoch with [NetworkBase.SnapshotWeights],
+// in memory or on disk, so that the network can be reverted to a prior
+// state with [NetworkBase.RollbackWeights] when training destabilizes
+// (e.g., NaNs or collapse of activity), for example to then reduce the
+// learning rate and continue.
+type WeightSnapshots struct {
+
+	// Max is the maximum number of snapshots to keep,
+	// after which the oldest is dropped. Snapshots are off if 0.
+	Max int
+
+	// Dir is a directory for saving snapshots as compressed weights files,
+	// named by the network name and ring slot. If empty, the snapshots are
+	// kept in memory (compressed).
+	Dir string
+
+	// Labels are the labels for each snapshot (e.g., the epoch),
+	// indexed by ring slot.
+	Labels []string
+
+	// Ring is the ring index for the snapshots.
+	Ring ringidx.Index `display:"-"`
+
+	// data has the in-memory compressed snapshots, indexed by ring slot.
+	data [][]byte
+
+	// files has the snapshot file names, indexed by ring slot.
+	files []string
+}
+
+// NumSnapshots returns the number of weight snapshots currently stored.
+func (nt *NetworkBase) NumSnapshots() int {
+	return nt.Snapshots.Ring.Len
+}
+
+// SnapshotWeights saves a snapshot of the current network weights with
+// given label (e.g., "Epoch 10"), according to the Snapshots settings,
+// dropping the oldest snapshot if there are already Snapshots.Max.
+// If the snapshot cannot be written, the error is returned and the
+// existing snapshots are kept. Does nothing if Snapshots.Max is 0.
+func (nt *NetworkBase) SnapshotWeights(label string) error {
+	ws := &nt.Snapshots
+	if ws.Max <= 0 {
+		return nil
+	}
+	if ws.Ring.Max != ws.Max {
+		ws.Ring = ringidx.Index{Max: ws.Max}
+		ws.Labels = make([]string, ws.Max)
+		ws.data = make([][]byte, ws.Max)
+		ws.files = make([]string, ws.Max)
+	}
+	// the next slot, which is that of the oldest snapshot if full,
+	// is only added to the ring after the snapshot is written,
+	// so that a failed write does not lose the oldest snapshot
+	slot := ws.Ring.Index(ws.Ring.Len)
+	if ws.Dir != "" {
+		fnm := filepath.Join(ws.Dir, fmt.Sprintf("%s_snap%d.wts.gz", nt.Name, slot))
+		tmp := filepath.Join(ws.Dir, fmt.Sprintf("%s_snap%d_new.wts.gz", nt.Name, slot))
+		if err := nt.SaveWeightsJSON(core.Filename(tmp)); err != nil {
+			os.Remove(tmp)
+			return err
+		}
+		if err := os.Rename(tmp, fnm); err != nil {
+			os.Remove(tmp)
+			return problems.Err("emer", fnm, err)
+		}
+		ws.files[slot] = fnm
+	} else {
+		var b bytes.Buffer
+		gzw := gzip.NewWriter(&b)
+		err := nt.EmerNetwork.WriteWeightsJSON(gzw)
+		if cerr := gzw.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+		ws.data[slot] = b.Bytes()
+	}
+	ws.Ring.Add(1)
+	ws.Labels[slot] = label
+	return nil
+}
+
+// RollbackWeights restores the network weights from the snapshot taken
+// k snapshots before the most recent one (0 = most recent), and discards
+// all snapshots more recent than the one restored, so that subsequent
+// rollbacks go further back. Returns the label of the restored snapshot.
+func (nt *NetworkBase) RollbackWeights(k int) (string, error) {
+	ws := &nt.Snapshots
+	n := ws.Ring.Len
+	if k < 0 || k >= n {
+		return "", fmt.Errorf("emer.RollbackWeights: snapshot %d not available: %d stored", k, n)
+	}
+	ri := n - 1 - k
+	slot := ws.Ring.Index(ri)
+	var err error
+	if ws.Dir != "" {
+		err = nt.OpenWeightsJSON(core.Filename(ws.files[slot]))
+	} else {
+		var gzr *gzip.Reader
+		gzr, err = gzip.NewReader(bytes.NewReader(ws.data[slot]))
+		if err == nil {
+			err = nt.EmerNetwork.ReadWeightsJSON(gzr)
+			gzr.Close()
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	ws.Ring.Len = ri + 1
+	return ws.Labels[slot], nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+
+	"cogentcore.org/core/math32"
+	"cogentcore.org/lab/tensor"
+)
+
+// SynSendRecver is an optional interface for a [Path] that can
+// return the sending and receiving unit indexes of a synapse directly,
+// which makes [PathBase.RangeSyns] and [PathBase.SynVarByName] much
+// faster than searching for the synapse of each pair of units.
+type SynSendRecver interface {
+
+	// SynSendRecv returns the sending and receiving unit indexes
+	// (1D, flat indexes) of the synapse with given index.
+	SynSendRecv(synIndex int) (sidx, ridx int)
+}
+
+// RangeSyns calls given function for each synapse in the pathway with
+// the sending and receiving unit indexes (1D, flat indexes) and the value
+// of given synapse variable, which can be a canonical variable name
+// (see [SynVarIndex]), stopping if the function returns false.
+// The synapses are visited in their natural order if the path implements
+// [SynSendRecver], and otherwise in receiving then sending unit order,
+// searching for the synapse of each pair of units with SynIndex.
+// Returns an error on an invalid variable name.
+func (pt *PathBase) RangeSyns(varNm string, fun func(sidx, ridx int, val float32) bool) error {
+	ep := pt.EmerPath
+	vidx, err := SynVarIndex(ep, varNm)
+	if err != nil {
+		return err
+	}
+	if sr, ok := ep.(SynSendRecver); ok {
+		for syi := range ep.NumSyns() {
+			si, ri := sr.SynSendRecv(syi)
+			if !fun(si, ri, ep.SynValue1D(vidx, syi)) {
+				return nil
+			}
+		}
+		return nil
+	}
+	ns, nr := ep.SendLayer().AsEmer().NumUnits(), ep.RecvLayer().AsEmer().NumUnits()
+	for ri := range nr {
+		for si := range ns {
+			syi := ep.SynIndex(si, ri)
+			if syi < 0 {
+				continue
+			}
+			if !fun(si, ri, ep.SynValue1D(vidx, syi)) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// SynVarByName returns a tensor with the values of given synapse variable
+// for each synapse in the pathway, which can be a canonical variable name
+// (see [SynVarIndex]), with shape [recv units, send units] (1D, flat unit
+// indexes), and NaN for pairs of units without a synapse.
+// Returns an error on an invalid variable name.
+func (pt *PathBase) SynVarByName(varNm string) (*tensor.Float32, error) {
+	ep := pt.EmerPath
+	ns, nr := ep.SendLayer().AsEmer().NumUnits(), ep.RecvLayer().AsEmer().NumUnits()
+	tsr := tensor.NewFloat32(nr, ns)
+	nan := math32.NaN()
+	for i := range tsr.Values {
+		tsr.Values[i] = nan
+	}
+	err := pt.RangeSyns(varNm, func(sidx, ridx int, val float32) bool {
+		tsr.Values[ridx*ns+sidx] = val
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("emer.SynVarByName: path %s: %w", pt.Name, err)
+	}
+	return tsr, nil
+}
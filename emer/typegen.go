@@ -6,16 +6,43 @@ import (
 	"cogentcore.org/core/types"
 )
 
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.Algorithm", IDName: "algorithm", Doc: "Algorithm has the constructors for a given algorithm implementation\n(e.g., leabra, axon), registered by the algorithm package using\n[RegisterAlgorithm], typically in an init function. This allows generic\ntools (network builders, GUI scaffolds, config-driven model loading) to\ncreate networks by algorithm name, without importing the algorithm package\ndirectly (other than for its init side effect).", Fields: []types.Field{{Name: "Name", Doc: "Name of the algorithm, used to look it up, e.g., \"leabra\"."}, {Name: "Doc", Doc: "Doc has documentation about the algorithm."}, {Name: "NewNetwork", Doc: "NewNetwork returns a new network with given name."}, {Name: "AddLayer", Doc: "AddLayer adds a new layer to given network, with given name, shape\nand algorithm type name (i.e., the layer TypeName() string).\nReturns an error if the type name is not valid for the algorithm."}, {Name: "ConnectLayers", Doc: "ConnectLayers adds a new pathway between given layers in given network,\nwith given pattern of connectivity and algorithm type name\n(i.e., the path TypeName() string).\nReturns an error if the type name is not valid for the algorithm."}, {Name: "Build", Doc: "Build builds the network after all layers and pathways have been added,\nallocating and initializing all state."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.AllocCount", IDName: "alloc-count", Doc: "AllocCount is the number of heap allocations made by\na function during a trial, recorded by [AllocTracker].", Fields: []types.Field{{Name: "Func", Doc: "Func is the name of the function."}, {Name: "Calls", Doc: "Calls is the number of calls to the function."}, {Name: "Objects", Doc: "Objects is the number of heap objects allocated."}, {Name: "Bytes", Doc: "Bytes is the number of bytes allocated."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.AllocMark", IDName: "alloc-mark", Doc: "AllocMark records the allocation counters at the start of a\nfunction tracked by [AllocTracker].", Fields: []types.Field{{Name: "objects", Doc: "objects is the number of heap objects allocated."}, {Name: "bytes", Doc: "bytes is the number of bytes allocated."}, {Name: "over", Doc: "over is the tracker's own allocations."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.allocOverhead", IDName: "alloc-overhead", Doc: "allocOverhead is the number of allocations made by [AllocTracker] itself,\nwhich are excluded from the counts of enclosing functions.", Fields: []types.Field{{Name: "objects"}, {Name: "bytes"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.AllocTracker", IDName: "alloc-tracker", Doc: "AllocTracker is a debug mode that records the heap allocations made\nby each of the per-cycle and per-trial compute functions of a network,\nwhich should make no allocations in steady state, to find the source of\ngarbage collection pauses that cause jitter in long recordings.\nThe compute functions call Start and Stop, which do nothing unless\nOn is set, e.g.:\n\n\tdefer nt.Allocs.Stop(\"Cycle\", nt.Allocs.Start())\n\nand EndTrial is called at the end of each trial to report the functions\nthat allocated. Because it uses [runtime.ReadMemStats], which stops the\nworld and counts the allocations of all goroutines, it is slow and should\nonly be used for debugging, with the network running in one goroutine.", Fields: []types.Field{{Name: "On", Doc: "On turns on recording of allocations."}, {Name: "Print", Doc: "Print prints the functions that allocated in EndTrial."}, {Name: "Trial", Doc: "Trial is the number of trials ended with EndTrial."}, {Name: "Counts", Doc: "Counts are the allocations of each function in the current\ntrial, in the order in which they were first called."}, {Name: "over", Doc: "over is the number of allocations made by the tracker itself."}, {Name: "stats", Doc: "stats are the memory stats, which are only allocated\nwhen tracking is on, as they are large."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.Description", IDName: "description", Doc: "Description is a machine-readable description of the structure of a\nnetwork, returned by [NetworkBase.Describe], for the GUI, documentation\ngenerators and external tools, which can be saved as JSON.", Fields: []types.Field{{Name: "Name", Doc: "Name is the name of the network."}, {Name: "NumUnits", Doc: "NumUnits is the total number of units in all layers."}, {Name: "NumSyns", Doc: "NumSyns is the total number of synapses in all pathways."}, {Name: "ParamSheets", Doc: "ParamSheets are the names of the parameter sheets that have been\napplied to the network, in order (see [ApplyParamSheets])."}, {Name: "MetaData", Doc: "MetaData is the metadata of the network."}, {Name: "Layers", Doc: "Layers are the descriptions of the layers, in order."}, {Name: "Paths", Doc: "Paths are the descriptions of the pathways, in order of the\nreceiving layer, and then of its receiving pathways."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.LayerDescription", IDName: "layer-description", Doc: "LayerDescription is a machine-readable description of a layer,\nin a network [Description].", Fields: []types.Field{{Name: "Name", Doc: "Name is the name of the layer."}, {Name: "Type", Doc: "Type is the algorithm-specific type of the layer."}, {Name: "Class", Doc: "Class has the parameter style classes of the layer."}, {Name: "Doc", Doc: "Doc is the documentation of the layer."}, {Name: "Shape", Doc: "Shape is the shape of the layer."}, {Name: "NumUnits", Doc: "NumUnits is the number of units in the layer."}, {Name: "Pos", Doc: "Pos is the position of the layer relative to other layers."}, {Name: "UnitGroups", Doc: "UnitGroups are the names of the unit groups of the layer, sorted."}, {Name: "Off", Doc: "Off is whether the layer is turned off."}, {Name: "Params", Doc: "Params is the listing of all of the parameters of the layer,\nfrom its AllParams method."}, {Name: "NonDefaultParams", Doc: "NonDefaultParams is the listing of the parameters of the layer\nthat are not at their default values, from its NonDefaultParams method."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.PathDescription", IDName: "path-description", Doc: "PathDescription is a machine-readable description of a pathway,\nin a network [Description].", Fields: []types.Field{{Name: "Name", Doc: "Name is the name of the pathway."}, {Name: "Type", Doc: "Type is the algorithm-specific type of the pathway."}, {Name: "Class", Doc: "Class has the parameter style classes of the pathway."}, {Name: "Doc", Doc: "Doc is the documentation of the pathway."}, {Name: "Send", Doc: "Send is the name of the sending layer."}, {Name: "Recv", Doc: "Recv is the name of the receiving layer."}, {Name: "Pattern", Doc: "Pattern is the name of the pattern of connectivity."}, {Name: "NumSyns", Doc: "NumSyns is the number of synapses in the pathway."}, {Name: "Off", Doc: "Off is whether the pathway is turned off."}, {Name: "Params", Doc: "Params is the listing of all of the parameters of the pathway,\nfrom its AllParams method."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.BatchInfer", IDName: "batch-infer", Doc: "BatchInfer has the configuration for running inference on every\nrow of a table of input patterns with [NetworkBase.InferTable],\nfor using a trained model in analysis scripts and applications.\nBecause applying inputs and running a trial are specific to each\nalgorithm, they are done by the ApplyInputs and RunTrial functions,\nwhich must be set.", Fields: []types.Field{{Name: "Outputs", Doc: "Outputs are the names of the layers whose activity\nis recorded in the results."}, {Name: "Var", Doc: "Var is the unit variable that is recorded for the Outputs."}, {Name: "NData", Doc: "NData is the number of rows processed in parallel on each trial,\nusing data parallel indexes, which is limited to MaxParallelData\nof the network. If 0, NParallelData of the network is used."}, {Name: "ApplyInputs", Doc: "ApplyInputs applies the input patterns from given row of the inputs\ntable to the network, for given data parallel index, e.g., using\nApplyExt on the input layers with the corresponding columns."}, {Name: "RunTrial", Doc: "RunTrial runs one trial of inference, without learning, on the given\nnumber of data parallel inputs, e.g., by running a Test mode looper\nStack for one trial, or just the minus phase."}}})
+
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.Layer", IDName: "layer", Doc: "Layer defines the minimal interface for neural network layers,\nnecessary to support the visualization (NetView), I/O,\nand parameter setting functionality provided by emergent.\nMost of the standard expected functionality is defined in the\nLayerBase struct, and this interface only has methods that must be\nimplemented specifically for a given algorithmic implementation.", Methods: []types.Method{{Name: "AsEmer", Doc: "AsEmer returns the layer as an *emer.LayerBase,\nto access base functionality.", Returns: []string{"LayerBase"}}, {Name: "Label", Doc: "Label satisfies the core.Labeler interface for getting\nthe name of objects generically. Use to access Name via interface.", Returns: []string{"string"}}, {Name: "TypeName", Doc: "TypeName is the type or category of layer, defined\nby the algorithm (and usually set by an enum).", Returns: []string{"string"}}, {Name: "TypeNumber", Doc: "TypeNumber is the numerical value for the type or category\nof layer, defined by the algorithm (and usually set by an enum).", Returns: []string{"int"}}, {Name: "UnitVarIndex", Doc: "UnitVarIndex returns the index of given variable within\nthe Neuron, according to *this layer's* UnitVarNames() list\n(using a map to lookup index), or -1 and error message if\nnot found.", Args: []string{"varNm"}, Returns: []string{"int", "error"}}, {Name: "UnitValue1D", Doc: "UnitValue1D returns value of given variable index on given unit,\nusing 1-dimensional index, and a data parallel index di,\nfor networks capable of processing multiple input patterns\nin parallel. Returns NaN on invalid index.\nThis is the core unit var access method used by other methods,\nso it is the only one that needs to be updated for derived layer types.", Args: []string{"varIndex", "idx", "di"}, Returns: []string{"float32"}}, {Name: "VarRange", Doc: "VarRange returns the min / max values for given variable", Args: []string{"varNm"}, Returns: []string{"min", "max", "err"}}, {Name: "NumRecvPaths", Doc: "NumRecvPaths returns the number of receiving pathways.", Returns: []string{"int"}}, {Name: "RecvPath", Doc: "RecvPath returns a specific receiving pathway.", Args: []string{"idx"}, Returns: []string{"Path"}}, {Name: "NumSendPaths", Doc: "NumSendPaths returns the number of sending pathways.", Returns: []string{"int"}}, {Name: "SendPath", Doc: "SendPath returns a specific sending pathway.", Args: []string{"idx"}, Returns: []string{"Path"}}, {Name: "RecvPathValues", Doc: "RecvPathValues fills in values of given synapse variable name,\nfor pathway from given sending layer and neuron 1D index,\nfor all receiving neurons in this layer,\ninto given float32 slice (only resized if not big enough).\npathType is the string representation of the path type;\nused if non-empty, useful when there are multiple pathways\nbetween two layers.\nReturns error on invalid var name.\nIf the receiving neuron is not connected to the given sending\nlayer or neuron then the value is set to math32.NaN().\nReturns error on invalid var name or lack of recv path\n(vals always set to nan on path err).", Args: []string{"vals", "varNm", "sendLay", "sendIndex1D", "pathType"}, Returns: []string{"error"}}, {Name: "SendPathValues", Doc: "SendPathValues fills in values of given synapse variable name,\nfor pathway into given receiving layer and neuron 1D index,\nfor all sending neurons in this layer,\ninto given float32 slice (only resized if not big enough).\npathType is the string representation of the path type -- used if non-empty,\nuseful when there are multiple pathways between two layers.\nReturns error on invalid var name.\nIf the sending neuron is not connected to the given receiving layer or neuron\nthen the value is set to math32.NaN().\nReturns error on invalid var name or lack of recv path (vals always set to nan on path err).", Args: []string{"vals", "varNm", "recvLay", "recvIndex1D", "pathType"}, Returns: []string{"error"}}, {Name: "NonDefaultParams", Doc: "NonDefaultParams returns a listing of all parameters in the Layer that\nare not at their default values; useful for setting param styles etc.", Returns: []string{"string"}}, {Name: "AllParams", Doc: "AllParams returns a listing of all parameters in the Layer", Returns: []string{"string"}}, {Name: "WriteWeightsJSON", Doc: "WriteWeightsJSON writes the weights from this layer from the\nreceiver-side perspective in a JSON text format.", Args: []string{"w", "depth"}}, {Name: "SetWeights", Doc: "SetWeights sets the weights for this layer from weights.Layer\ndecoded values", Args: []string{"lw"}, Returns: []string{"error"}}}})
 
-var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.LayerBase", IDName: "layer-base", Doc: "LayerBase defines the basic shared data for neural network layers,\nused for managing the structural elements of a network,\nand for visualization, I/O, etc.\nNothing algorithm-specific is implemented here", Fields: []types.Field{{Name: "EmerLayer", Doc: "EmerLayer provides access to the emer.Layer interface\nmethods for functions defined in the LayerBase type.\nMust set this with a pointer to the actual instance\nwhen created, using InitLayer function."}, {Name: "Name", Doc: "Name of the layer, which must be unique within the network.\nLayers are typically accessed directly by name, via a map."}, {Name: "Class", Doc: "Class is for applying parameter styles across multiple layers\nthat all get the same parameters.  This can be space separated\nwith multple classes."}, {Name: "Doc", Doc: "Doc contains documentation about the layer.\nThis is displayed in a tooltip in the network view."}, {Name: "Off", Doc: "Off turns off the layer, removing from all computations.\nThis provides a convenient way to dynamically test for\nthe contributions of the layer, for example."}, {Name: "Shape", Doc: "Shape of the layer, either 2D or 4D.  Although spatial topology\nis not relevant to all algorithms, the 2D shape is important for\nefficiently visualizing large numbers of units / neurons.\n4D layers have 2D Pools of units embedded within a larger 2D\norganization of such pools.  This is used for max-pooling or\npooled inhibition at a finer-grained level, and biologically\ncorresopnds to hypercolumns in the cortex for example.\nOrder is outer-to-inner (row major), so Y then X for 2D;\n4D: Y-X unit pools then Y-X neurons within pools."}, {Name: "Pos", Doc: "Pos specifies the relative spatial relationship to another\nlayer, which determines positioning.  Every layer except one\n\"anchor\" layer should be positioned relative to another,\ne.g., RightOf, Above, etc.  This provides robust positioning\nin the face of layer size changes etc.\nLayers are arranged in X-Y planes, stacked vertically along the Z axis."}, {Name: "Index", Doc: "Index is a 0..n-1 index of the position of the layer within\nthe list of layers in the network."}, {Name: "SampleIndexes", Doc: "SampleIndexes are the current set of \"sample\" unit indexes,\nwhich are a smaller subset of units that represent the behavior\nof the layer, for computationally intensive statistics and displays\n(e.g., PCA, ActRF, NetView rasters), when the layer is large.\nIf none have been set, then all units are used.\nSee utility function CenterPoolIndexes that returns indexes of\nunits in the central pools of a 4D layer."}, {Name: "SampleShape", Doc: "SampleShape is the shape to use for the subset of sample\nunit indexes, in terms of an array of dimensions.\nSee Shape for more info.\nLayers that set SampleIndexes should also set this,\notherwise a 1D array of len SampleIndexes will be used.\nSee utility function CenterPoolShape that returns shape of\nunits in the central pools of a 4D layer."}, {Name: "MetaData", Doc: "optional metadata that is saved in network weights files,\ne.g., can indicate number of epochs that were trained,\nor any other information about this network that would be useful to save."}}})
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.LayerBase", IDName: "layer-base", Doc: "LayerBase defines the basic shared data for neural network layers,\nused for managing the structural elements of a network,\nand for visualization, I/O, etc.\nNothing algorithm-specific is implemented here", Fields: []types.Field{{Name: "EmerLayer", Doc: "EmerLayer provides access to the emer.Layer interface\nmethods for functions defined in the LayerBase type.\nMust set this with a pointer to the actual instance\nwhen created, using InitLayer function."}, {Name: "Name", Doc: "Name of the layer, which must be unique within the network.\nLayers are typically accessed directly by name, via a map."}, {Name: "Class", Doc: "Class is for applying parameter styles across multiple layers\nthat all get the same parameters.  This can be space separated\nwith multple classes."}, {Name: "Doc", Doc: "Doc contains documentation about the layer.\nThis is displayed in a tooltip in the network view."}, {Name: "Off", Doc: "Off turns off the layer, removing from all computations.\nThis provides a convenient way to dynamically test for\nthe contributions of the layer, for example."}, {Name: "Shape", Doc: "Shape of the layer, either 2D or 4D.  Although spatial topology\nis not relevant to all algorithms, the 2D shape is important for\nefficiently visualizing large numbers of units / neurons.\n4D layers have 2D Pools of units embedded within a larger 2D\norganization of such pools.  This is used for max-pooling or\npooled inhibition at a finer-grained level, and biologically\ncorresopnds to hypercolumns in the cortex for example.\nOrder is outer-to-inner (row major), so Y then X for 2D;\n4D: Y-X unit pools then Y-X neurons within pools."}, {Name: "Pos", Doc: "Pos specifies the relative spatial relationship to another\nlayer, which determines positioning.  Every layer except one\n\"anchor\" layer should be positioned relative to another,\ne.g., RightOf, Above, etc.  This provides robust positioning\nin the face of layer size changes etc.\nLayers are arranged in X-Y planes, stacked vertically along the Z axis."}, {Name: "Index", Doc: "Index is a 0..n-1 index of the position of the layer within\nthe list of layers in the network."}, {Name: "SampleIndexes", Doc: "SampleIndexes are the current set of \"sample\" unit indexes,\nwhich are a smaller subset of units that represent the behavior\nof the layer, for computationally intensive statistics and displays\n(e.g., PCA, ActRF, NetView rasters), when the layer is large.\nIf none have been set, then all units are used.\nSee utility function CenterPoolIndexes that returns indexes of\nunits in the central pools of a 4D layer."}, {Name: "SampleShape", Doc: "SampleShape is the shape to use for the subset of sample\nunit indexes, in terms of an array of dimensions.\nSee Shape for more info.\nLayers that set SampleIndexes should also set this,\notherwise a 1D array of len SampleIndexes will be used.\nSee utility function CenterPoolShape that returns shape of\nunits in the central pools of a 4D layer."}, {Name: "UnitGroups", Doc: "UnitGroups are named subsets of units within the layer, as sorted\nlists of 1D unit indexes, for functionally distinct populations\nthat can be targeted by params, lesions, logging and the NetView.\nSee AddUnitGroup."}, {Name: "MetaData", Doc: "optional metadata that is saved in network weights files,\ne.g., can indicate number of epochs that were trained,\nor any other information about this network that would be useful to save."}}})
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.VarCategory", IDName: "var-category", Doc: "VarCategory represents one category of unit, synapse variables.", Fields: []types.Field{{Name: "Cat", Doc: "Category name."}, {Name: "Doc", Doc: "Documentation of the category, used as a tooltip."}}})
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.Network", IDName: "network", Doc: "Network defines the minimal interface for a neural network,\nused for managing the structural elements of a network,\nand for visualization, I/O, etc.\nMost of the standard expected functionality is defined in the\nNetworkBase struct, and this interface only has methods that must be\nimplemented specifically for a given algorithmic implementation.", Methods: []types.Method{{Name: "AsEmer", Doc: "AsEmer returns the network as an *emer.NetworkBase,\nto access base functionality.", Returns: []string{"NetworkBase"}}, {Name: "Label", Doc: "Label satisfies the core.Labeler interface for getting\nthe name of objects generically.", Returns: []string{"string"}}, {Name: "NumLayers", Doc: "NumLayers returns the number of layers in the network.", Returns: []string{"int"}}, {Name: "EmerLayer", Doc: "EmerLayer returns layer as emer.Layer interface at given index.\nDoes not do extra bounds checking.", Args: []string{"idx"}, Returns: []string{"Layer"}}, {Name: "MaxParallelData", Doc: "MaxParallelData returns the maximum number of data inputs that can be\nprocessed in parallel by the network.\nThe NetView supports display of up to this many data elements.", Returns: []string{"int"}}, {Name: "NParallelData", Doc: "NParallelData returns the current number of data inputs currently being\nprocessed in parallel by the network.\nLogging supports recording each of these where appropriate.", Returns: []string{"int"}}, {Name: "Defaults", Doc: "Defaults sets default parameter values for everything in the Network."}, {Name: "UpdateParams", Doc: "UpdateParams() updates parameter values for all Network parameters,\nbased on any other params that might have changed."}, {Name: "KeyLayerParams", Doc: "KeyLayerParams returns a listing for all layers in the network,\nof the most important layer-level params (specific to each algorithm).", Returns: []string{"string"}}, {Name: "KeyPathParams", Doc: "KeyPathParams returns a listing for all Recv pathways in the network,\nof the most important pathway-level params (specific to each algorithm).", Returns: []string{"string"}}, {Name: "UnitVarNames", Doc: "UnitVarNames returns a list of variable names available on\nthe units in this network.\nThis list determines what is shown in the NetView\n(and the order of vars list).\nNot all layers need to support all variables,\nbut must safely return math32.NaN() for unsupported ones.\nThis is typically a global list so do not modify!", Returns: []string{"[]string"}}, {Name: "UnitVarProps", Doc: "UnitVarProps returns a map of unit variable properties,\nwith the key being the name of the variable,\nand the value gives a space-separated list of\ngo-tag-style properties for that variable.\nThe NetView recognizes the following properties:\n\t- range:\"##\" = +- range around 0 for default display scaling\n\t- min:\"##\" max:\"##\" = min, max display range\n\t- auto-scale:\"+\" or \"-\" = use automatic scaling instead of fixed range or not.\n\t- zeroctr:\"+\" or \"-\" = control whether zero-centering is used\n\t- desc:\"txt\" tooltip description of the variable\n\t- cat:\"cat\" variable category, for category tabs", Returns: []string{"map[string]string"}}, {Name: "VarCategories", Doc: "VarCategories is a list of unit & synapse variable categories,\nwhich organizes the variables into separate tabs in the network view.\nUsing categories results in a more compact display and makes it easier\nto find variables.\nSet the 'cat' property in the UnitVarProps, SynVarProps for each variable.\nIf no categories returned, the default is Unit, Wt.", Returns: []string{"VarCategory"}}, {Name: "SynVarNames", Doc: "SynVarNames returns the names of all the variables\non the synapses in this network.\nThis list determines what is shown in the NetView\n(and the order of vars list).\nNot all pathways need to support all variables,\nbut must safely return math32.NaN() for\nunsupported ones.\nThis is typically a global list so do not modify!", Returns: []string{"[]string"}}, {Name: "SynVarProps", Doc: "SynVarProps returns a map of synapse variable properties,\nwith the key being the name of the variable,\nand the value gives a space-separated list of\ngo-tag-style properties for that variable.\nThe NetView recognizes the following properties:\nrange:\"##\" = +- range around 0 for default display scaling\nmin:\"##\" max:\"##\" = min, max display range\nauto-scale:\"+\" or \"-\" = use automatic scaling instead of fixed range or not.\nzeroctr:\"+\" or \"-\" = control whether zero-centering is used\nNote: this is typically a global list so do not modify!", Returns: []string{"map[string]string"}}, {Name: "ReadWeightsJSON", Doc: "ReadWeightsJSON reads network weights from the receiver-side perspective\nin a JSON text format. Reads entire file into a temporary weights.Weights\nstructure that is then passed to Layers etc using SetWeights method.\nCall the NetworkBase version followed by any post-load updates.", Args: []string{"r"}, Returns: []string{"error"}}, {Name: "WriteWeightsJSON", Doc: "WriteWeightsJSON writes the weights from this network\nfrom the receiver-side perspective in a JSON text format.\nCall the NetworkBase version after pre-load updates.", Args: []string{"w"}, Returns: []string{"error"}}}})
 
-var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.NetworkBase", IDName: "network-base", Doc: "NetworkBase defines the basic data for a neural network,\nused for managing the structural elements of a network,\nand for visualization, I/O, etc.", Methods: []types.Method{{Name: "SaveWeightsJSON", Doc: "SaveWeightsJSON saves network weights (and any other state that adapts with learning)\nto a JSON-formatted file.  If filename has .gz extension, then file is gzip compressed.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"filename"}, Returns: []string{"error"}}, {Name: "OpenWeightsJSON", Doc: "OpenWeightsJSON opens network weights (and any other state that adapts with learning)\nfrom a JSON-formatted file.  If filename has .gz extension, then file is gzip uncompressed.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"filename"}, Returns: []string{"error"}}}, Fields: []types.Field{{Name: "EmerNetwork", Doc: "EmerNetwork provides access to the emer.Network interface\nmethods for functions defined in the NetworkBase type.\nMust set this with a pointer to the actual instance\nwhen created, using InitNetwork function."}, {Name: "Name", Doc: "overall name of network, which helps discriminate if there are multiple."}, {Name: "WeightsFile", Doc: "filename of last weights file loaded or saved."}, {Name: "LayerNameMap", Doc: "map of name to layers, for EmerLayerByName methods"}, {Name: "MinPos", Doc: "minimum display position in network"}, {Name: "MaxPos", Doc: "maximum display position in network"}, {Name: "MetaData", Doc: "optional metadata that is saved in network weights files,\ne.g., can indicate number of epochs that were trained,\nor any other information about this network that would be useful to save."}, {Name: "Rand", Doc: "random number generator for the network.\nall random calls must use this.\nSet seed here for weight initialization values."}, {Name: "RandSeed", Doc: "Random seed to be set at the start of configuring\nthe network and initializing the weights.\nSet this to get a different set of weights."}}})
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.NetworkBase", IDName: "network-base", Doc: "NetworkBase defines the basic data for a neural network,\nused for managing the structural elements of a network,\nand for visualization, I/O, etc.", Methods: []types.Method{{Name: "SaveWeightsJSON", Doc: "SaveWeightsJSON saves network weights (and any other state that adapts with learning)\nto a JSON-formatted file.  If filename has .gz extension, then file is gzip compressed.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"filename"}, Returns: []string{"error"}}, {Name: "OpenWeightsJSON", Doc: "OpenWeightsJSON opens network weights (and any other state that adapts with learning)\nfrom a JSON-formatted file.  If filename has .gz extension, then file is gzip uncompressed.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"filename"}, Returns: []string{"error"}}}, Fields: []types.Field{{Name: "EmerNetwork", Doc: "EmerNetwork provides access to the emer.Network interface\nmethods for functions defined in the NetworkBase type.\nMust set this with a pointer to the actual instance\nwhen created, using InitNetwork function."}, {Name: "Name", Doc: "overall name of network, which helps discriminate if there are multiple."}, {Name: "WeightsFile", Doc: "filename of last weights file loaded or saved."}, {Name: "LayerNameMap", Doc: "map of name to layers, for EmerLayerByName methods"}, {Name: "MinPos", Doc: "minimum display position in network"}, {Name: "MaxPos", Doc: "maximum display position in network"}, {Name: "MetaData", Doc: "optional metadata that is saved in network weights files,\ne.g., can indicate number of epochs that were trained,\nor any other information about this network that would be useful to save."}, {Name: "ParamSheets", Doc: "ParamSheets are the names of the parameter sheets that have been\napplied to the network, in order, recorded with AddParamSheets\nfor the network Description."}, {Name: "Rand", Doc: "random number generator for the network.\nall random calls must use this.\nSet seed here for weight initialization values."}, {Name: "RandSeed", Doc: "Random seed to be set at the start of configuring\nthe network and initializing the weights.\nSet this to get a different set of weights."}, {Name: "UseArenas", Doc: "UseArenas allocates all of the neuron and synapse state of the\nnetwork in a few large contiguous [Arena]s (one per value type)\nin Build, instead of many small slices, which reduces garbage\ncollection pressure and improves locality for very large models.\nMust be set before calling Build."}, {Name: "Allocs", Doc: "Allocs is a debug mode that records the heap allocations made by\nthe compute functions of the network on each trial, which should\nbe zero in steady state."}, {Name: "Snapshots", Doc: "Snapshots keeps recent snapshots of the network weights,\nfor reverting with RollbackWeights when training destabilizes."}}})
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.Path", IDName: "path", Doc: "Path defines the minimal interface for a pathway\nwhich connects two layers, using a specific Pattern\nof connectivity, and with its own set of parameters.\nThis supports visualization (NetView), I/O,\nand parameter setting functionality provided by emergent.\nMost of the standard expected functionality is defined in the\nPathBase struct, and this interface only has methods that must be\nimplemented specifically for a given algorithmic implementation,", Methods: []types.Method{{Name: "AsEmer", Doc: "AsEmer returns the path as an *emer.PathBase,\nto access base functionality.", Returns: []string{"PathBase"}}, {Name: "Label", Doc: "Label satisfies the core.Labeler interface for getting\nthe name of objects generically. Use to access Name via interface.", Returns: []string{"string"}}, {Name: "TypeName", Doc: "TypeName is the type or category of path, defined\nby the algorithm (and usually set by an enum).", Returns: []string{"string"}}, {Name: "TypeNumber", Doc: "TypeNumber is the numerical value for the type or category\nof path, defined by the algorithm (and usually set by an enum).", Returns: []string{"int"}}, {Name: "SendLayer", Doc: "SendLayer returns the sending layer for this pathway,\nas an emer.Layer interface.  The actual Path implmenetation\ncan use a Send field with the actual Layer struct type.", Returns: []string{"Layer"}}, {Name: "RecvLayer", Doc: "RecvLayer returns the receiving layer for this pathway,\nas an emer.Layer interface.  The actual Path implmenetation\ncan use a Recv field with the actual Layer struct type.", Returns: []string{"Layer"}}, {Name: "NumSyns", Doc: "NumSyns returns the number of synapses for this path.\nThis is the max idx for SynValue1D and the number\nof vals set by SynValues.", Returns: []string{"int"}}, {Name: "SynIndex", Doc: "SynIndex returns the index of the synapse between given send, recv unit indexes\n(1D, flat indexes). Returns -1 if synapse not found between these two neurons.\nThis requires searching within connections for receiving unit (a bit slow).", Args: []string{"sidx", "ridx"}, Returns: []string{"int"}}, {Name: "SynVarNames", Doc: "SynVarNames returns the names of all the variables on the synapse\nThis is typically a global list so do not modify!", Returns: []string{"[]string"}}, {Name: "SynVarNum", Doc: "SynVarNum returns the number of synapse-level variables\nfor this paths.  This is needed for extending indexes in derived types.", Returns: []string{"int"}}, {Name: "SynVarIndex", Doc: "SynVarIndex returns the index of given variable within the synapse,\naccording to *this path's* SynVarNames() list (using a map to lookup index),\nor -1 and error message if not found.", Args: []string{"varNm"}, Returns: []string{"int", "error"}}, {Name: "SynValues", Doc: "SynValues sets values of given variable name for each synapse,\nusing the natural ordering of the synapses (sender based for Axon),\ninto given float32 slice (only resized if not big enough).\nReturns error on invalid var name.", Args: []string{"vals", "varNm"}, Returns: []string{"error"}}, {Name: "SynValue1D", Doc: "SynValue1D returns value of given variable index\n(from SynVarIndex) on given SynIndex.\nReturns NaN on invalid index.\nThis is the core synapse var access method used by other methods,\nso it is the only one that needs to be updated for derived types.", Args: []string{"varIndex", "synIndex"}, Returns: []string{"float32"}}, {Name: "AllParams", Doc: "AllParams returns a listing of all parameters in the Pathway.", Returns: []string{"string"}}, {Name: "WriteWeightsJSON", Doc: "WriteWeightsJSON writes the weights from this pathway\nfrom the receiver-side perspective in a JSON text format.", Args: []string{"w", "depth"}}, {Name: "SetWeights", Doc: "SetWeights sets the weights for this pathway from weights.Path\ndecoded values", Args: []string{"pw"}, Returns: []string{"error"}}}})
 
-var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.PathBase", IDName: "path-base", Doc: "PathBase defines the basic shared data for a pathway\nwhich connects two layers, using a specific Pattern\nof connectivity, and with its own set of parameters.\nThe same struct token is added to the Recv and Send\nlayer path lists,", Fields: []types.Field{{Name: "EmerPath", Doc: "EmerPath provides access to the emer.Path interface\nmethods for functions defined in the PathBase type.\nMust set this with a pointer to the actual instance\nwhen created, using InitPath function."}, {Name: "Name", Doc: "Name of the path, which can be automatically set to\nSendLayer().Name + \"To\" + RecvLayer().Name via\nSetStandardName method."}, {Name: "Class", Doc: "Class is for applying parameter styles across multiple paths\nthat all get the same parameters. This can be space separated\nwith multple classes."}, {Name: "Doc", Doc: "Doc contains documentation about the pathway.\nThis is displayed in a tooltip in the network view."}, {Name: "Notes", Doc: "can record notes about this pathway here."}, {Name: "Pattern", Doc: "Pattern specifies the pattern of connectivity\nfor interconnecting the sending and receiving layers."}, {Name: "Off", Doc: "Off inactivates this pathway, allowing for easy experimentation."}}})
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.PathBase", IDName: "path-base", Doc: "PathBase defines the basic shared data for a pathway\nwhich connects two layers, using a specific Pattern\nof connectivity, and with its own set of parameters.\nThe same struct token is added to the Recv and Send\nlayer path lists,", Fields: []types.Field{{Name: "EmerPath", Doc: "EmerPath provides access to the emer.Path interface\nmethods for functions defined in the PathBase type.\nMust set this with a pointer to the actual instance\nwhen created, using InitPath function."}, {Name: "Name", Doc: "Name of the path, which can be automatically set to\nSendLayer().Name + \"To\" + RecvLayer().Name via\nSetStandardName method."}, {Name: "Class", Doc: "Class is for applying parameter styles across multiple paths\nthat all get the same parameters. This can be space separated\nwith multple classes."}, {Name: "Doc", Doc: "Doc contains documentation about the pathway.\nThis is displayed in a tooltip in the network view."}, {Name: "Notes", Doc: "can record notes about this pathway here."}, {Name: "Pattern", Doc: "Pattern specifies the pattern of connectivity\nfor interconnecting the sending and receiving layers."}, {Name: "Off", Doc: "Off inactivates this pathway, allowing for easy experimentation."}, {Name: "MetaData", Doc: "MetaData is optional metadata that is saved in network weights files,\ne.g., the [weights.Provenance] of the pathway (see SetProvenance)."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.VarAliases", IDName: "var-aliases", Doc: "VarAliases maps canonical variable names to the corresponding\nvariable names used by a given algorithm.", Fields: []types.Field{{Name: "Unit", Doc: "Unit maps canonical unit variable names to algorithm names."}, {Name: "Syn", Doc: "Syn maps canonical synapse variable names to algorithm names."}}})
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.WeightSnapshots", IDName: "weight-snapshots", Doc: "WeightSnapshots keeps the most recent snapshots of the network weights,\ne.g., taken at the end of each epoch with [NetworkBase.SnapshotWeights],\nin memory or on disk, so that the network can be reverted to a prior\nstate with [NetworkBase.RollbackWeights] when training destabilizes\n(e.g., NaNs or collapse of activity), for example to then reduce the\nlearning rate and continue.", Fields: []types.Field{{Name: "Max", Doc: "Max is the maximum number of snapshots to keep,\nafter which the oldest is dropped. Snapshots are off if 0."}, {Name: "Dir", Doc: "Dir is a directory for saving snapshots as compressed weights files,\nnamed by the network name and ring slot. If empty, the snapshots are\nkept in memory (compressed)."}, {Name: "Labels", Doc: "Labels are the labels for each snapshot (e.g., the epoch),\nindexed by ring slot."}, {Name: "Ring", Doc: "Ring is the ring index for the snapshots."}, {Name: "data", Doc: "data has the in-memory compressed snapshots, indexed by ring slot."}, {Name: "files", Doc: "files has the snapshot file names, indexed by ring slot."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.SynSendRecver", IDName: "syn-send-recver", Doc: "SynSendRecver is an optional interface for a [Path] that can\nreturn the sending and receiving unit indexes of a synapse directly,\nwhich makes [PathBase.RangeSyns] and [PathBase.SynVarByName] much\nfaster than searching for the synapse of each pair of units.", Methods: []types.Method{{Name: "SynSendRecv", Doc: "SynSendRecv returns the sending and receiving unit indexes\n(1D, flat indexes) of the synapse with given index.", Args: []string{"synIndex"}, Returns: []string{"sidx", "ridx"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.LayerNotFoundError", IDName: "layer-not-found-error", Doc: "LayerNotFoundError is returned by [NetworkBase.EmerLayerByName]\nwhen there is no layer with the given name in the network.", Fields: []types.Field{{Name: "Name", Doc: "Name is the name of the layer that was looked for."}, {Name: "Network", Doc: "Network is the name of the network."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/emer.Context", IDName: "context", Doc: "Context has the state of the current trial that is passed to the\ncompute methods of a network (e.g., Cycle, Learn), instead of being\nkept in the network or in globals: the evaluation mode, the phase\nwithin the trial, the cycle counters, whether learning is turned off,\nthe data-parallel index, and the random number generator,\nso that every compute method sees the same phase information,\nand the same network can be run on different data in parallel,\nwith a context for each.\nThe mode, phase and Testing flag are set by the sim, or by a hybrid\nnetwork for all of its modules, and the cycle counters are incremented\nby the network methods that compute a cycle (or time step) of activity.\nUse [NetworkBase.NewContext] to make a context for a network.", Fields: []types.Field{{Name: "Mode", Doc: "Mode is the current evaluation mode (e.g., Train or Test),\nas defined by the sim."}, {Name: "Testing", Doc: "Testing turns off learning for the current trial, so that the\nLearn methods do nothing, e.g., when testing a trained network."}, {Name: "Phase", Doc: "Phase is the index of the current phase within the trial."}, {Name: "PlusPhase", Doc: "PlusPhase is true if this is the plus (outcome) phase,\nwhen the targets are present, and false in the minus\n(expectation) phase."}, {Name: "Cycle", Doc: "Cycle is the current cycle (or time step) within the trial,\nwhich is incremented with CycleInc by the network method that\ncomputes a cycle, and reset by NewTrial."}, {Name: "CyclesTotal", Doc: "CyclesTotal is the total number of cycles since the context was made."}, {Name: "Di", Doc: "Di is the data-parallel index of the input pattern being processed,\nfor networks that can process multiple patterns in parallel."}, {Name: "NData", Doc: "NData is the number of input patterns processed in parallel."}, {Name: "Rand", Doc: "Rand is the random number generator for any stochastic computation,\nwhich is the network's Rand by default."}}})
@@ -0,0 +1,169 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"cogentcore.org/lab/tensor"
+)
+
+// AddUnitGroup adds a named group of units within the layer, with given
+// 1D unit indexes, for a functionally distinct population of units that
+// can be targeted by params, lesions (see RangeUnitGroup), logging
+// (see UnitGroupAvg), and NetView highlighting (see UnitGroupMask).
+// Replaces any existing group with the same name.
+// Returns an error if any index is out of range.
+func (ly *LayerBase) AddUnitGroup(name string, idxs ...int) error {
+	nu := ly.NumUnits()
+	for _, i := range idxs {
+		if i < 0 || i >= nu {
+			return fmt.Errorf("emer.AddUnitGroup: layer %s group %s: unit index %d is out of range for %d units", ly.Name, name, i, nu)
+		}
+	}
+	gi := slices.Clone(idxs)
+	slices.Sort(gi)
+	gi = slices.Compact(gi)
+	if ly.UnitGroups == nil {
+		ly.UnitGroups = make(map[string][]int)
+	}
+	ly.UnitGroups[name] = gi
+	return nil
+}
+
+// AddUnitGroupMask adds a named group of units within the layer
+// (see AddUnitGroup), for the units with non-zero values in given mask,
+// which must have the same number of values as the layer has units
+// (e.g., the same shape as the layer).
+func (ly *LayerBase) AddUnitGroupMask(name string, mask tensor.Tensor) error {
+	nu := ly.NumUnits()
+	if mask.Len() != nu {
+		return fmt.Errorf("emer.AddUnitGroupMask: layer %s group %s: mask has %d values but layer has %d units", ly.Name, name, mask.Len(), nu)
+	}
+	var idxs []int
+	for i := range nu {
+		if mask.Float1D(i) != 0 {
+			idxs = append(idxs, i)
+		}
+	}
+	return ly.AddUnitGroup(name, idxs...)
+}
+
+// RemapUnitGroups remaps the unit indexes of all of the unit groups
+// after the layer is resized, according to given map with the old unit
+// index for each new unit, or -1 for new units (see [weights.IndexMap]).
+// New units are not added to any group, and units that no longer
+// exist are dropped from their groups.
+func (ly *LayerBase) RemapUnitGroups(umap []int) {
+	for nm, idxs := range ly.UnitGroups {
+		var gi []int
+		for i, oi := range umap {
+			if oi >= 0 && slices.Contains(idxs, oi) {
+				gi = append(gi, i)
+			}
+		}
+		ly.UnitGroups[nm] = gi
+	}
+}
+
+// UnitGroup returns the sorted 1D unit indexes of the unit group
+// with given name, or an error if not found.
+func (ly *LayerBase) UnitGroup(name string) ([]int, error) {
+	idxs, ok := ly.UnitGroups[name]
+	if !ok {
+		return nil, fmt.Errorf("emer.UnitGroup: unit group %s not found in layer %s", name, ly.Name)
+	}
+	return idxs, nil
+}
+
+// UnitGroupNames returns the sorted names of the unit groups.
+func (ly *LayerBase) UnitGroupNames() []string {
+	nms := make([]string, 0, len(ly.UnitGroups))
+	for nm := range ly.UnitGroups {
+		nms = append(nms, nm)
+	}
+	slices.Sort(nms)
+	return nms
+}
+
+// RangeUnitGroup calls given function for the 1D index of each unit in
+// the unit group with given name, stopping if the function returns false.
+// This is used to target the units with algorithm-specific params or
+// lesions, e.g., setting a per-unit gain or an off flag on the neurons.
+// Returns an error if the group is not found.
+func (ly *LayerBase) RangeUnitGroup(name string, fun func(idx int) bool) error {
+	idxs, err := ly.UnitGroup(name)
+	if err != nil {
+		return err
+	}
+	for _, i := range idxs {
+		if !fun(i) {
+			break
+		}
+	}
+	return nil
+}
+
+// UnitGroupMask returns a mask with the shape of the layer, with 1 for
+// the units in the unit group with given name and 0 otherwise,
+// e.g., for highlighting the group as a NetView overlay.
+// Returns an error if the group is not found.
+func (ly *LayerBase) UnitGroupMask(name string) (*tensor.Float32, error) {
+	idxs, err := ly.UnitGroup(name)
+	if err != nil {
+		return nil, err
+	}
+	mask := tensor.NewFloat32(ly.Shape.Sizes...)
+	for _, i := range idxs {
+		mask.Values[i] = 1
+	}
+	return mask, nil
+}
+
+// UnitGroupValues fills in the values of given variable name for each
+// unit in the unit group with given name, in order of the group indexes,
+// into given float32 slice (only resized if not big enough), for given
+// data parallel index. Returns an error on an invalid group or var name.
+func (ly *LayerBase) UnitGroupValues(vals *[]float32, group, varNm string, di int) error {
+	idxs, err := ly.UnitGroup(group)
+	if err != nil {
+		return err
+	}
+	vidx, err := UnitVarIndex(ly.EmerLayer, varNm)
+	if err != nil {
+		return err
+	}
+	*vals = slices.Grow((*vals)[:0], len(idxs))[:len(idxs)]
+	for i, ui := range idxs {
+		(*vals)[i] = ly.EmerLayer.UnitValue1D(vidx, ui, di)
+	}
+	return nil
+}
+
+// UnitGroupAvg returns the average of the values of given variable name
+// over the units in the unit group with given name, ignoring NaN values,
+// for given data parallel index, e.g., for logging the average activity
+// of the group. Returns an error on an invalid group or var name.
+func (ly *LayerBase) UnitGroupAvg(group, varNm string, di int) (float32, error) {
+	var vals []float32
+	if err := ly.UnitGroupValues(&vals, group, varNm, di); err != nil {
+		return 0, err
+	}
+	var sum float64
+	n := 0
+	for _, v := range vals {
+		if math.IsNaN(float64(v)) {
+			continue
+		}
+		sum += float64(v)
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	return float32(sum / float64(n)), nil
+}
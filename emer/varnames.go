@@ -0,0 +1,124 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emer
+
+import (
+	"maps"
+	"path"
+	"reflect"
+	"sync"
+)
+
+// CanonicalUnitVars are the canonical names of unit variables that
+// analysis and logging code can use across algorithms. Algorithm
+// packages register aliases for any of these that they name differently,
+// using [AddVarAliases].
+var CanonicalUnitVars = []string{"Act", "Spike", "Ge", "Gi", "Vm", "ActM", "ActP", "ActAvg", "Bias", "Ext", "Target"}
+
+// CanonicalSynVars are the canonical names of synapse variables,
+// analogous to [CanonicalUnitVars].
+var CanonicalSynVars = []string{"Wt", "LWt", "DWt"}
+
+// VarAliases maps canonical variable names to the corresponding
+// variable names used by a given algorithm.
+type VarAliases struct {
+
+	// Unit maps canonical unit variable names to algorithm names.
+	Unit map[string]string
+
+	// Syn maps canonical synapse variable names to algorithm names.
+	Syn map[string]string
+}
+
+// AlgoVarAliases is the registry of per-algorithm variable aliases,
+// keyed by algorithm name, which is the name of the algorithm package
+// (e.g., "leabra", "axon"), as returned by [AlgorithmOf].
+// Use [AddVarAliases] to add to it, typically in an init function
+// of the algorithm package.
+var AlgoVarAliases = map[string]*VarAliases{}
+
+// AddVarAliases registers unit and synapse variable aliases for given
+// algorithm package name, mapping canonical names to the names used by
+// the algorithm. Either map can be nil, and multiple calls for the same
+// algorithm add to any existing aliases.
+func AddVarAliases(algo string, unit, syn map[string]string) {
+	va, ok := AlgoVarAliases[algo]
+	if !ok {
+		va = &VarAliases{Unit: map[string]string{}, Syn: map[string]string{}}
+		AlgoVarAliases[algo] = va
+	}
+	maps.Copy(va.Unit, unit)
+	maps.Copy(va.Syn, syn)
+}
+
+// algoNames caches the algorithm names of the layer and pathway types.
+var algoNames sync.Map // reflect.Type -> string
+
+// AlgorithmOf returns the name of the algorithm of given layer or pathway,
+// which is the name of the package that defines its type (e.g., "axon"),
+// and is used to look up its variable aliases in [AlgoVarAliases].
+func AlgorithmOf(obj any) string {
+	typ := reflect.TypeOf(obj)
+	if nm, ok := algoNames.Load(typ); ok {
+		return nm.(string)
+	}
+	t := typ
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	nm := ""
+	if t != nil {
+		nm = path.Base(t.PkgPath())
+	}
+	algoNames.Store(typ, nm)
+	return nm
+}
+
+// VarAlias returns the name used by given algorithm for given canonical
+// variable name, for unit (syn = false) or synapse variables,
+// or "" if the algorithm has no alias for it.
+func VarAlias(algo, varNm string, syn bool) string {
+	va, ok := AlgoVarAliases[algo]
+	if !ok {
+		return ""
+	}
+	if syn {
+		return va.Syn[varNm]
+	}
+	return va.Unit[varNm]
+}
+
+// UnitVarIndex returns the index of given unit variable name on given
+// layer, using the layer's own name if it exists, and otherwise
+// the alias of the name as a canonical variable registered for
+// the algorithm of the layer (see [AddVarAliases]).
+// Returns the original error if no alias is found.
+func UnitVarIndex(ly Layer, varNm string) (int, error) {
+	vidx, err := ly.UnitVarIndex(varNm)
+	if err == nil {
+		return vidx, nil
+	}
+	if nm := VarAlias(AlgorithmOf(ly), varNm, false); nm != "" && nm != varNm {
+		if vi, aerr := ly.UnitVarIndex(nm); aerr == nil {
+			return vi, nil
+		}
+	}
+	return vidx, err
+}
+
+// SynVarIndex returns the index of given synapse variable name on given
+// pathway, using the same alias logic as [UnitVarIndex].
+func SynVarIndex(pt Path, varNm string) (int, error) {
+	vidx, err := pt.SynVarIndex(varNm)
+	if err == nil {
+		return vidx, nil
+	}
+	if nm := VarAlias(AlgorithmOf(pt), varNm, true); nm != "" && nm != varNm {
+		if vi, aerr := pt.SynVarIndex(nm); aerr == nil {
+			return vi, nil
+		}
+	}
+	return vidx, err
+}
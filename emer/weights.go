@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
-	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -18,6 +17,7 @@ import (
 	"cogentcore.org/core/base/errors"
 	"cogentcore.org/core/base/indent"
 	"cogentcore.org/core/core"
+	"github.com/emer/emergent/v2/problems"
 	"github.com/emer/emergent/v2/weights"
 	"golang.org/x/exp/maps"
 )
@@ -28,8 +28,7 @@ func (nt *NetworkBase) SaveWeightsJSON(filename core.Filename) error { //types:a
 	fp, err := os.Create(string(filename))
 	defer fp.Close()
 	if err != nil {
-		log.Println(err)
-		return err
+		return problems.Err("emer", string(filename), err)
 	}
 	ext := filepath.Ext(string(filename))
 	if ext == ".gz" {
@@ -50,16 +49,14 @@ func (nt *NetworkBase) OpenWeightsJSON(filename core.Filename) error { //types:a
 	fp, err := os.Open(string(filename))
 	defer fp.Close()
 	if err != nil {
-		log.Println(err)
-		return err
+		return problems.Err("emer", string(filename), err)
 	}
 	ext := filepath.Ext(string(filename))
 	if ext == ".gz" {
 		gzr, err := gzip.NewReader(fp)
 		defer gzr.Close()
 		if err != nil {
-			log.Println(err)
-			return err
+			return problems.Err("emer", string(filename), err)
 		}
 		return nt.EmerNetwork.ReadWeightsJSON(gzr)
 	} else {
@@ -74,16 +71,14 @@ func (nt *NetworkBase) OpenWeightsFS(fsys fs.FS, filename string) error {
 	fp, err := fsys.Open(filename)
 	defer fp.Close()
 	if err != nil {
-		log.Println(err)
-		return err
+		return problems.Err("emer", string(filename), err)
 	}
 	ext := filepath.Ext(filename)
 	if ext == ".gz" {
 		gzr, err := gzip.NewReader(fp)
 		defer gzr.Close()
 		if err != nil {
-			log.Println(err)
-			return err
+			return problems.Err("emer", string(filename), err)
 		}
 		return nt.EmerNetwork.ReadWeightsJSON(gzr)
 	} else {
@@ -140,16 +135,16 @@ func (nt *NetworkBase) WriteWeightsJSON(w io.Writer) error {
 // ReadWeightsJSON reads network weights from the receiver-side perspective
 // in a JSON text format.  Reads entire file into a temporary weights.Weights
 // structure that is then passed to Layers etc using SetWeights method.
+// If the file cannot be decoded, none of the weights are set.
 func (nt *NetworkBase) ReadWeightsJSON(r io.Reader) error {
 	nw, err := weights.NetReadJSON(r)
 	if err != nil {
-		return err // note: already logged
+		return problems.Err("emer", nt.Name, err)
 	}
-	err = nt.SetWeights(nw)
-	if err != nil {
-		log.Println(err)
+	if nw == nil { // empty input
+		return nil
 	}
-	return err
+	return problems.Err("emer", nt.Name, nt.SetWeights(nw))
 }
 
 // SetWeights sets the weights for this network from weights.Network decoded values
@@ -175,10 +170,31 @@ func (nt *NetworkBase) SetWeights(nw *weights.Network) error {
 			continue
 		}
 		ly.SetWeights(lw)
+		setPathMetaData(ly, lw)
 	}
 	return errors.Join(errs...)
 }
 
+// setPathMetaData sets the MetaData of the receiving pathways of given layer
+// from the MetaData of given decoded weights, matching multiple pathways
+// from the same sending layer in order.
+func setPathMetaData(ly Layer, lw *weights.Layer) {
+	nfrom := make(map[string]int)
+	for pi := range lw.Paths {
+		pw := &lw.Paths[pi]
+		pt := recvPathFrom(ly, pw.From, nfrom[pw.From])
+		nfrom[pw.From]++
+		if pt == nil || len(pw.MetaData) == 0 {
+			continue
+		}
+		pb := pt.AsEmer()
+		if pb.MetaData == nil {
+			pb.MetaData = make(map[string]string)
+		}
+		maps.Copy(pb.MetaData, pw.MetaData)
+	}
+}
+
 // WriteWeightsJSONBase writes the weights from this layer
 // in a JSON text format.  Any values in the layer MetaData
 // will be written first, and unit-level variables in unitVars
@@ -190,31 +206,14 @@ func (ly *LayerBase) WriteWeightsJSONBase(w io.Writer, depth int, unitVars ...st
 	depth++
 	w.Write(indent.TabBytes(depth))
 	w.Write([]byte(fmt.Sprintf("\"Layer\": %q,\n", ly.Name)))
-	if len(ly.MetaData) > 0 {
-		w.Write(indent.TabBytes(depth))
-		w.Write([]byte(fmt.Sprintf("\"MetaData\": {\n")))
-		depth++
-		kys := maps.Keys(ly.MetaData)
-		sort.StringSlice(kys).Sort()
-		for i, k := range kys {
-			w.Write(indent.TabBytes(depth))
-			comma := ","
-			if i == len(kys)-1 { // note: last one has no comma
-				comma = ""
-			}
-			w.Write([]byte(fmt.Sprintf("%q: %q%s\n", k, ly.MetaData[k], comma)))
-		}
-		depth--
-		w.Write(indent.TabBytes(depth))
-		w.Write([]byte("},\n"))
-	}
+	writeMetaDataJSON(w, depth, ly.MetaData)
 	if len(unitVars) > 0 {
 		w.Write(indent.TabBytes(depth))
 		w.Write([]byte(fmt.Sprintf("\"Units\": {\n")))
 		depth++
 		for i, vname := range unitVars {
 			vidx, err := el.UnitVarIndex(vname)
-			if errors.Log(err) != nil {
+			if problems.Err("emer", ly.Name, err) != nil {
 				continue
 			}
 			w.Write(indent.TabBytes(depth))
@@ -269,6 +268,37 @@ func (ly *LayerBase) WriteWeightsJSONBase(w io.Writer, depth int, unitVars ...st
 	w.Write([]byte("}")) // note: leave unterminated as outer loop needs to add , or just \n depending
 }
 
+// writeMetaDataJSON writes given metadata, if any, as a MetaData
+// JSON object in sorted key order, followed by a comma.
+func writeMetaDataJSON(w io.Writer, depth int, md map[string]string) {
+	if len(md) == 0 {
+		return
+	}
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte(fmt.Sprintf("\"MetaData\": {\n")))
+	depth++
+	kys := maps.Keys(md)
+	sort.StringSlice(kys).Sort()
+	for i, k := range kys {
+		w.Write(indent.TabBytes(depth))
+		comma := ","
+		if i == len(kys)-1 { // note: last one has no comma
+			comma = ""
+		}
+		w.Write([]byte(fmt.Sprintf("%q: %q%s\n", k, md[k], comma)))
+	}
+	depth--
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte("},\n"))
+}
+
+// WriteMetaDataJSON writes the MetaData of this pathway, if any,
+// in a JSON text format, which should be called by the WriteWeightsJSON
+// method of the algorithm, after writing the From field.
+func (pt *PathBase) WriteMetaDataJSON(w io.Writer, depth int) {
+	writeMetaDataJSON(w, depth, pt.MetaData)
+}
+
 // ReadWeightsJSON reads the weights from this layer from the
 // receiver-side perspective in a JSON text format.
 // This is for a set of weights that were saved *for one layer only*
@@ -277,7 +307,10 @@ func (ly *LayerBase) WriteWeightsJSONBase(w io.Writer, depth int, unitVars ...st
 func (ly *LayerBase) ReadWeightsJSON(r io.Reader) error {
 	lw, err := weights.LayReadJSON(r)
 	if err != nil {
-		return err // note: already logged
+		return problems.Err("emer", ly.Name, err)
+	}
+	if lw == nil { // empty input
+		return nil
 	}
 	return ly.EmerLayer.SetWeights(lw)
 }
@@ -290,7 +323,10 @@ func (ly *LayerBase) ReadWeightsJSON(r io.Reader) error {
 func (pt *PathBase) ReadWeightsJSON(r io.Reader) error {
 	pw, err := weights.PathReadJSON(r)
 	if err != nil {
-		return err // note: already logged
+		return problems.Err("emer", pt.Name, err)
+	}
+	if pw == nil { // empty input
+		return nil
 	}
 	return pt.EmerPath.SetWeights(pw)
 }
@@ -0,0 +1,14 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package ensemble provides a Runner that runs the same model configuration
+with N different random seeds in parallel (e.g., different random initial
+weights), and reports the mean and variability of the final metrics
+across runs, flagging metrics with high variance, as a one-call ensemble
+evaluation during parameter exploration.
+*/
+package ensemble
+
+//go:generate core generate -add-types
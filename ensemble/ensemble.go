@@ -0,0 +1,264 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ensemble
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+// RunFunc is a function that builds, trains and tests one model, for
+// given run index, initializing all random number generation from given
+// seed, and returns the final metrics by name (e.g., "FirstZero",
+// "PctErr"). It is called concurrently from multiple goroutines, so it
+// must use its own network and environment, and must not depend on the
+// global random source, which is shared.
+type RunFunc func(run int, seed int64) (map[string]float64, error)
+
+// Runner runs the same model configuration with NRuns different
+// random seeds, in parallel, and summarizes the metrics across runs.
+type Runner struct {
+
+	// Config is the name of the configuration being run,
+	// which is recorded in the Summary table.
+	Config string
+
+	// NRuns is the number of runs, each with a different seed.
+	NRuns int `default:"10"`
+
+	// NParallel is the maximum number of runs to execute in parallel,
+	// where 0 = the number of CPUs.
+	NParallel int
+
+	// Seeds are the random seeds for each run, which are initialized
+	// to 1..NRuns if not already set for NRuns.
+	Seeds randx.Seeds
+
+	// Metrics are the names of the metrics to summarize, in order.
+	// If empty, all of the metrics returned by the runs are used,
+	// in sorted order.
+	Metrics []string
+
+	// CVThr is the threshold on the coefficient of variation
+	// (SD / |Mean|) of a metric across runs, above which it is
+	// flagged as having high variance.
+	CVThr float64 `default:"0.2"`
+
+	// Results has the metrics for each run from the last Run,
+	// with columns Run, Seed, and one for each metric.
+	Results *table.Table `display:"-"`
+
+	// Summary has the summary of each metric across runs from the last Run,
+	// with columns Config, Metric, N, Mean, SD, SEM, Min, Max, CV,
+	// and HighVar (1 if CV > CVThr).
+	Summary *table.Table `display:"-"`
+}
+
+// NewRunner returns a new [Runner] for given configuration name
+// and number of runs, with default parameters.
+func NewRunner(config string, nruns int) *Runner {
+	return &Runner{Config: config, NRuns: nruns, CVThr: 0.2}
+}
+
+// Run runs NRuns runs of given function in parallel, each with its own
+// seed, and returns the Summary table. Runs that return an error are
+// excluded from the summary, and the errors are returned together.
+func (rn *Runner) Run(fun RunFunc) (*table.Table, error) {
+	if rn.NRuns <= 0 {
+		return nil, fmt.Errorf("ensemble.Runner: NRuns must be > 0: %d", rn.NRuns)
+	}
+	if len(rn.Seeds) != rn.NRuns {
+		rn.Seeds.Init(rn.NRuns)
+	}
+	npar := rn.NParallel
+	if npar <= 0 {
+		npar = runtime.NumCPU()
+	}
+	mets := make([]map[string]float64, rn.NRuns)
+	errs := make([]error, rn.NRuns)
+	sem := make(chan struct{}, npar)
+	var wg sync.WaitGroup
+	for run := range rn.NRuns {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			m, err := fun(run, rn.Seeds[run])
+			if err != nil {
+				errs[run] = fmt.Errorf("ensemble.Runner: run %d: %w", run, err)
+				return
+			}
+			mets[run] = m
+		}()
+	}
+	wg.Wait()
+	rn.results(mets)
+	rn.summary()
+	return rn.Summary, errors.Join(errs...)
+}
+
+// metricNames returns the Metrics, or the sorted names
+// of all metrics in given run results if not set.
+func (rn *Runner) metricNames(mets []map[string]float64) []string {
+	if len(rn.Metrics) > 0 {
+		return rn.Metrics
+	}
+	var nms []string
+	for _, m := range mets {
+		for nm := range m {
+			if !slices.Contains(nms, nm) {
+				nms = append(nms, nm)
+			}
+		}
+	}
+	sort.Strings(nms)
+	return nms
+}
+
+// results records the metrics for the successful runs in the Results table.
+func (rn *Runner) results(mets []map[string]float64) {
+	dt := table.New()
+	metadata.SetName(dt, rn.Config+" Runs")
+	tensor.SetPrecision(dt, 4)
+	dt.AddIntColumn("Run")
+	dt.AddIntColumn("Seed")
+	nms := rn.metricNames(mets)
+	for _, nm := range nms {
+		dt.AddFloat64Column(nm)
+	}
+	for run, m := range mets {
+		if m == nil {
+			continue
+		}
+		row := dt.NumRows()
+		dt.SetNumRows(row + 1)
+		dt.Column("Run").SetIntRow(run, row, 0)
+		dt.Column("Seed").SetIntRow(int(rn.Seeds[run]), row, 0)
+		for _, nm := range nms {
+			v, ok := m[nm]
+			if !ok {
+				v = math.NaN()
+			}
+			dt.Column(nm).SetFloatRow(v, row, 0)
+		}
+	}
+	rn.Results = dt
+}
+
+// summary computes the Summary table from the Results table,
+// excluding missing (NaN) values.
+func (rn *Runner) summary() {
+	dt := table.New()
+	metadata.SetName(dt, rn.Config+" Summary")
+	tensor.SetPrecision(dt, 4)
+	dt.AddStringColumn("Config")
+	dt.AddStringColumn("Metric")
+	dt.AddIntColumn("N")
+	scols := []string{"Mean", "SD", "SEM", "Min", "Max", "CV"}
+	for _, nm := range scols {
+		dt.AddFloat64Column(nm)
+	}
+	dt.AddIntColumn("HighVar")
+	nms := rn.Results.Columns.Keys[2:]
+	dt.SetNumRows(len(nms))
+	for ri, nm := range nms {
+		col := rn.Results.Column(nm)
+		var vals []float64
+		for i := range rn.Results.NumRows() {
+			if v := col.FloatRow(i, 0); !math.IsNaN(v) {
+				vals = append(vals, v)
+			}
+		}
+		mean, sd, mn, mx := math.NaN(), math.NaN(), math.NaN(), math.NaN()
+		n := len(vals)
+		if n > 0 {
+			mean, sd = meanSD(vals)
+			mn, mx = slices.Min(vals), slices.Max(vals)
+		}
+		sem := sd / math.Sqrt(float64(n))
+		cv := 0.0
+		if sd > 0 {
+			cv = sd / math.Abs(mean)
+		}
+		hv := 0
+		if cv > rn.CVThr {
+			hv = 1
+		}
+		dt.Column("Config").SetStringRow(rn.Config, ri, 0)
+		dt.Column("Metric").SetStringRow(nm, ri, 0)
+		dt.Column("N").SetIntRow(n, ri, 0)
+		for ci, v := range []float64{mean, sd, sem, mn, mx, cv} {
+			dt.Column(scols[ci]).SetFloatRow(v, ri, 0)
+		}
+		dt.Column("HighVar").SetIntRow(hv, ri, 0)
+	}
+	rn.Summary = dt
+}
+
+// HighVariance returns the names of the metrics flagged as having
+// high variance across runs in the Summary from the last Run.
+func (rn *Runner) HighVariance() []string {
+	if rn.Summary == nil {
+		return nil
+	}
+	var nms []string
+	for ri := range rn.Summary.NumRows() {
+		if rn.Summary.Column("HighVar").IntRow(ri, 0) > 0 {
+			nms = append(nms, rn.Summary.Column("Metric").StringRow(ri, 0))
+		}
+	}
+	return nms
+}
+
+// meanSD returns the mean and the (n-1) sample standard deviation
+// of given values.
+func meanSD(vals []float64) (mean, sd float64) {
+	n := float64(len(vals))
+	for _, v := range vals {
+		mean += v
+	}
+	mean /= n
+	if n < 2 {
+		return mean, 0
+	}
+	for _, v := range vals {
+		sd += (v - mean) * (v - mean)
+	}
+	sd = math.Sqrt(sd / (n - 1))
+	return
+}
+
+// Summaries returns a table with the Summary rows of all of given
+// runners (e.g., one per parameter configuration being explored),
+// for comparing configurations and finding those with high variance.
+func Summaries(rns ...*Runner) *table.Table {
+	var dt *table.Table
+	for _, rn := range rns {
+		if rn.Summary == nil {
+			continue
+		}
+		if dt == nil {
+			dt = table.New()
+			metadata.SetName(dt, "Summaries")
+			tensor.SetPrecision(dt, 4)
+			for i, nm := range rn.Summary.Columns.Keys {
+				dt.AddColumn(nm, rn.Summary.Columns.Values[i].Clone())
+			}
+			continue
+		}
+		dt.AppendRows(rn.Summary)
+	}
+	return dt
+}
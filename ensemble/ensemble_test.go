@@ -0,0 +1,51 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ensemble
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunner(t *testing.T) {
+	rn := NewRunner("Base", 8)
+	rn.NParallel = 3
+	_, err := rn.Run(func(run int, seed int64) (map[string]float64, error) {
+		rnd := rand.New(rand.NewSource(seed))
+		return map[string]float64{"PctErr": 0.1 + 0.001*rnd.Float64(), "Epochs": float64(10 * seed)}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 8, rn.Results.NumRows())
+	assert.Equal(t, []string{"Run", "Seed", "Epochs", "PctErr"}, rn.Results.Columns.Keys)
+	assert.Equal(t, 5, rn.Results.Column("Seed").IntRow(4, 0))
+	sm := rn.Summary
+	assert.Equal(t, 2, sm.NumRows())
+	assert.Equal(t, "Epochs", sm.Column("Metric").StringRow(0, 0))
+	assert.Equal(t, 45.0, sm.Column("Mean").FloatRow(0, 0))
+	assert.Equal(t, 10.0, sm.Column("Min").FloatRow(0, 0))
+	assert.Equal(t, 80.0, sm.Column("Max").FloatRow(0, 0))
+	assert.Equal(t, []string{"Epochs"}, rn.HighVariance())
+
+	rn2 := NewRunner("Bad", 4)
+	rn2.Metrics = []string{"PctErr"}
+	_, err = rn2.Run(func(run int, seed int64) (map[string]float64, error) {
+		if run == 2 {
+			return nil, errors.New("diverged")
+		}
+		return map[string]float64{"PctErr": float64(run), "Other": 1}, nil
+	})
+	assert.ErrorContains(t, err, "run 2: diverged")
+	assert.Equal(t, 3, rn2.Results.NumRows())
+	assert.Equal(t, 3, rn2.Summary.Column("N").IntRow(0, 0))
+	assert.Equal(t, []string{"PctErr"}, rn2.HighVariance())
+
+	all := Summaries(rn, rn2)
+	assert.Equal(t, 3, all.NumRows())
+	assert.Equal(t, "Bad", all.Column("Config").StringRow(2, 0))
+	assert.Equal(t, 2, rn.Summary.NumRows())
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"errors"
+	"fmt"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// ApplyFunc applies given values as the external input (or target)
+// of given layer, for given data parallel index, which is specific
+// to each algorithm, e.g., calling ApplyExt on the layer.
+type ApplyFunc func(ly emer.Layer, di int, ext tensor.Values) error
+
+// ApplyInputs applies the State elements of given env to the layers of
+// given network that they are bound to, for given data parallel index,
+// using given algorithm-specific apply function, after applying the
+// Transform of each binding, if set. Action bindings are skipped, and
+// Target bindings are only applied if plus is true, for the plus phase.
+// Any external inputs should be initialized first (e.g., InitExt).
+// For envs that implement [Shaper], the existence of each element is
+// checked before getting its State, which can otherwise panic.
+// Returns an error describing all of the elements that were not found,
+// or that do not match the shape of their layer (see [ValidateBindings]),
+// which are not applied.
+func ApplyInputs(ev Env, net emer.Network, di int, plus bool, apply ApplyFunc, binds ...Binding) error {
+	var errs []error
+	shp, hasShaper := ev.(Shaper)
+	for _, bd := range binds {
+		if bd.Action || (bd.Target && !plus) {
+			continue
+		}
+		ly, err := net.AsEmer().EmerLayerByName(bd.Layer)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("env %q: state element %q: %w", ev.Label(), bd.Element, err))
+			continue
+		}
+		var st tensor.Values
+		if !hasShaper || shp.StateShape(bd.Element) != nil {
+			st = ev.State(bd.Element)
+		}
+		if st == nil {
+			errs = append(errs, fmt.Errorf("env %q: state element %q not found", ev.Label(), bd.Element))
+			continue
+		}
+		if bd.Transform != nil {
+			st = bd.Transform(st)
+		}
+		es, ls := st.ShapeSizes(), ly.AsEmer().Shape.Sizes
+		if !shapesMatch(es, ls) {
+			errs = append(errs, fmt.Errorf("env %q: state element %q has shape %v, which does not match the shape %v of layer %q", ev.Label(), bd.Element, es, ls, bd.Layer))
+			continue
+		}
+		if err := apply(ly, di, st); err != nil {
+			errs = append(errs, fmt.Errorf("env %q: state element %q: %w", ev.Label(), bd.Element, err))
+		}
+	}
+	return errors.Join(errs...)
+}
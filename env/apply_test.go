@@ -0,0 +1,83 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"errors"
+	"testing"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyInputs(t *testing.T) {
+	net := newTestNet(t)
+	sv := &SeqEnv{Name: "Seq"}
+	sv.Config(func() []string { return []string{"A", "C"} }, "A", "B", "C")
+	sv.Step()
+	var applied []string
+	apply := func(ly emer.Layer, di int, ext tensor.Values) error {
+		assert.Equal(t, 0, di)
+		applied = append(applied, ly.Label())
+		return ly.(*bp.Layer).ApplyExt(ext)
+	}
+	half := func(st tensor.Values) tensor.Values {
+		tr := tensor.NewFloat32(st.ShapeSizes()...)
+		for i := range st.Len() {
+			tr.SetFloat1D(0.5*st.Float1D(i), i)
+		}
+		return tr
+	}
+	binds := []Binding{
+		{Element: "Input", Layer: "Input", Transform: half},
+		{Element: "Target", Layer: "Output", Target: true},
+		{Element: "Response", Layer: "Response", Action: true},
+	}
+	in, _ := net.LayerByName("Input")
+	out, _ := net.LayerByName("Output")
+
+	// minus phase: no targets
+	net.InitExt()
+	assert.NoError(t, ApplyInputs(sv, net, 0, false, apply, binds...))
+	assert.Equal(t, []string{"Input"}, applied)
+	assert.Equal(t, []float32{0.5, 0, 0}, in.Ext)
+	assert.Equal(t, []float32{1, 0, 0}, sv.Input.Values) // env state not modified
+	assert.Equal(t, []float32{0, 0, 0}, out.Ext)
+
+	applied = nil
+	assert.NoError(t, ApplyInputs(sv, net, 0, true, apply, binds...))
+	assert.Equal(t, []string{"Input", "Output"}, applied)
+	assert.Equal(t, []float32{0, 0, 1}, out.Ext)
+
+	// errors for all bad bindings, which are not applied
+	applied = nil
+	errApply := errors.New("apply failed")
+	err := ApplyInputs(sv, net, 0, true, func(ly emer.Layer, di int, ext tensor.Values) error {
+		if ly.Label() == "Output" {
+			return errApply
+		}
+		return apply(ly, di, ext)
+	},
+		Binding{Element: "Input", Layer: "Hidden"},
+		Binding{Element: "Name", Layer: "Input"},
+		Binding{Element: "Input", Layer: "Response"},
+		Binding{Element: "Target", Layer: "Output"},
+		Binding{Element: "Input", Layer: "Input"})
+	assert.ErrorIs(t, err, errApply)
+	assert.ErrorContains(t, err, `env "Seq": state element "Name" not found`)
+	assert.ErrorContains(t, err, `env "Seq": state element "Input" has shape [3], which does not match the shape [1 2] of layer "Response"`)
+	assert.ErrorContains(t, err, `env "Seq": state element "Target": apply failed`)
+	assert.Equal(t, []string{"Input"}, applied)
+
+	// without Shaper, missing elements are reported from State
+	se := &stateEnv{name: "State", states: map[string]tensor.Values{"Input": tensor.NewFloat32FromValues(0, 1, 0)}}
+	applied = nil
+	err = ApplyInputs(se, net, 0, true, apply, binds...)
+	assert.ErrorContains(t, err, `env "State": state element "Target" not found`)
+	assert.Equal(t, []string{"Input"}, applied)
+	assert.Equal(t, []float32{0, 0.5, 0}, in.Ext)
+}
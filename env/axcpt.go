@@ -0,0 +1,284 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"fmt"
+	"math/rand"
+
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/labelcode"
+)
+
+// AXCPT is an Env for the AX continuous performance task (AX-CPT), a
+// context-maintenance task where each trial has a cue (A or B) followed
+// by a probe (X or Y), and a target response is required only for an X
+// probe following an A cue (AX trials), with non-target responses for
+// AY, BX, and BY trials. AX trials are frequent, creating a prepotent
+// target response to A cues (errors on AY) and X probes (errors on BX),
+// which must be controlled using the maintained cue context.
+// Each trial has two steps, for the cue and the probe, tracked by the
+// Tick counter. The Input state is a one-hot pattern for the current
+// stimulus, and the Target state has two units: [0] = target, [1] =
+// non-target, which is the target for all cues. The model response to
+// the probe is recorded with [AXCPT.Respond] or an Action on the
+// "Response" element with the same two units, and is scored in Score,
+// and TypeScores per TrialType.
+type AXCPT struct {
+
+	// Name of this environment, usually Train vs. Test.
+	Name string
+
+	// AXProb is the probability of AX trials.
+	AXProb float32 `default:"0.7"`
+
+	// AYProb is the probability of AY trials.
+	AYProb float32 `default:"0.1"`
+
+	// BXProb is the probability of BX trials.
+	BXProb float32 `default:"0.1"`
+
+	// BYProb is the probability of BY trials.
+	BYProb float32 `default:"0.1"`
+
+	// BCues are the non-A cue stimuli, chosen at random on B trials.
+	BCues []string
+
+	// YProbes are the non-X probe stimuli, chosen at random on Y trials.
+	YProbes []string
+
+	// Vocab has the stimuli: A, BCues, X, YProbes.
+	Vocab labelcode.Vocab `display:"-"`
+
+	// Trial is the trial counter. Set Max to the number of trials per block.
+	Trial Counter `display:"inline"`
+
+	// Tick is the step within the trial: 0 = cue, 1 = probe.
+	Tick Counter `display:"inline"`
+
+	// TrialType is the type of the current trial: AX, AY, BX, or BY.
+	TrialType CurPrevString
+
+	// Cue is the cue stimulus for the current trial.
+	Cue string
+
+	// Probe is the probe stimulus for the current trial.
+	Probe string
+
+	// Stimulus is the current stimulus (Cue or Probe).
+	Stimulus CurPrevString
+
+	// Score has the scores for all trials since the last ResetScores.
+	Score Score `display:"inline"`
+
+	// TypeScores has the scores for each TrialType.
+	TypeScores map[string]*Score `display:"-"`
+
+	// Rand is the random number source (nil = global).
+	Rand randx.Rand `display:"-"`
+
+	// Input is the one-hot pattern for the current stimulus.
+	Input tensor.Float32
+
+	// Target is the target response pattern.
+	Target tensor.Float32
+
+	// responded is whether a response has been recorded on this trial.
+	responded bool
+}
+
+func (ax *AXCPT) Defaults() {
+	ax.AXProb = 0.7
+	ax.AYProb = 0.1
+	ax.BXProb = 0.1
+	ax.BYProb = 0.1
+	ax.BCues = []string{"B"}
+	ax.YProbes = []string{"Y"}
+}
+
+func (ax *AXCPT) Validate() error {
+	if len(ax.BCues) == 0 || len(ax.YProbes) == 0 {
+		return fmt.Errorf("env.AXCPT: %v needs BCues and YProbes", ax.Name)
+	}
+	return nil
+}
+
+func (ax *AXCPT) Label() string { return ax.Name }
+
+func (ax *AXCPT) String() string {
+	return ax.TrialType.Cur + "_" + ax.Stimulus.Cur
+}
+
+// Config configures the env with default parameters and given number
+// of trials per block, and calls Init(0).
+func (ax *AXCPT) Config(trials int) {
+	ax.Defaults()
+	ax.Trial.Max = trials
+	ax.Init(0)
+}
+
+func (ax *AXCPT) Init(run int) {
+	ax.Vocab = *labelcode.NewVocab("A")
+	for _, st := range ax.BCues {
+		ax.Vocab.Add(st)
+	}
+	ax.Vocab.Add("X")
+	for _, st := range ax.YProbes {
+		ax.Vocab.Add(st)
+	}
+	ax.Vocab.Frozen = true
+	mx := ax.Trial.Max
+	ax.Trial.Init()
+	ax.Trial.Max = mx
+	ax.Trial.Cur = -1
+	ax.Tick.Init()
+	ax.Tick.Max = 2
+	ax.Tick.Cur = 1 // first Step starts a new trial
+	ax.TrialType = CurPrevString{}
+	ax.Stimulus = CurPrevString{}
+	ax.Input.SetShapeSizes(ax.Vocab.Len())
+	ax.Target.SetShapeSizes(2)
+	ax.ResetScores()
+}
+
+// ResetScores resets the Score and TypeScores, e.g., at the start of an epoch.
+func (ax *AXCPT) ResetScores() {
+	ax.Score.Reset()
+	ax.TypeScores = map[string]*Score{"AX": {}, "AY": {}, "BX": {}, "BY": {}}
+}
+
+// randIntn returns a random int in [0, n).
+func (ax *AXCPT) randIntn(n int) int {
+	if ax.Rand != nil {
+		return ax.Rand.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
+// NewTrial chooses a new trial type according to the probabilities,
+// and the cue and probe stimuli.
+func (ax *AXCPT) NewTrial() {
+	probs := []float32{ax.AXProb, ax.AYProb, ax.BXProb, ax.BYProb}
+	sum := float32(0)
+	for _, p := range probs {
+		sum += p
+	}
+	var r float32
+	if ax.Rand != nil {
+		r = ax.Rand.Float32() * sum
+	} else {
+		r = rand.Float32() * sum
+	}
+	ti := len(probs) - 1
+	for i, p := range probs {
+		r -= p
+		if r < 0 {
+			ti = i
+			break
+		}
+	}
+	typ := []string{"AX", "AY", "BX", "BY"}[ti]
+	ax.TrialType.Set(typ)
+	ax.Cue = "A"
+	if typ[0] == 'B' {
+		ax.Cue = ax.BCues[ax.randIntn(len(ax.BCues))]
+	}
+	ax.Probe = "X"
+	if typ[1] == 'Y' {
+		ax.Probe = ax.YProbes[ax.randIntn(len(ax.YProbes))]
+	}
+	ax.responded = false
+}
+
+func (ax *AXCPT) Step() bool {
+	if ax.Tick.Incr() { // if true, hit max, reset to 0
+		ax.Trial.Incr()
+		ax.NewTrial()
+	}
+	stim := ax.Cue
+	if ax.IsProbe() {
+		stim = ax.Probe
+	}
+	ax.Stimulus.Set(stim)
+	ax.Vocab.Encode(stim, &ax.Input)
+	ax.Target.SetZeros()
+	if ax.IsTarget() {
+		ax.Target.Values[0] = 1
+	} else {
+		ax.Target.Values[1] = 1
+	}
+	return true
+}
+
+// IsProbe returns true if the current step is the probe.
+func (ax *AXCPT) IsProbe() bool {
+	return ax.Tick.Cur == 1
+}
+
+// IsTarget returns true if the current step is the probe of an AX trial.
+func (ax *AXCPT) IsTarget() bool {
+	return ax.IsProbe() && ax.TrialType.Cur == "AX"
+}
+
+// Respond records the model response to the probe, for whether it
+// made a target response, in the Score and TypeScores, if this is
+// the probe step and a response was not already recorded on this trial.
+// Responses to the cue are not scored.
+func (ax *AXCPT) Respond(target bool) {
+	if ax.responded || !ax.IsProbe() || ax.TrialType.Cur == "" {
+		return
+	}
+	ax.responded = true
+	ax.Score.Record(ax.IsTarget(), target)
+	ax.TypeScores[ax.TrialType.Cur].Record(ax.IsTarget(), target)
+}
+
+// ContextDPrime returns the d'-context measure of context maintenance,
+// from the AX hit rate and the BX false alarm rate.
+func (ax *AXCPT) ContextDPrime() float64 {
+	axs, bxs := ax.TypeScores["AX"], ax.TypeScores["BX"]
+	sc := Score{Hits: axs.Hits, Misses: axs.Misses, FalseAlarms: bxs.FalseAlarms, CorrectRejects: bxs.CorrectRejects}
+	return sc.DPrime()
+}
+
+func (ax *AXCPT) State(element string) tensor.Values {
+	switch element {
+	case "Input":
+		return &ax.Input
+	case "Target":
+		return &ax.Target
+	}
+	return nil
+}
+
+// StateShape returns the shape of given State element,
+// implementing [Shaper]. The Input shape is set by Init.
+func (ax *AXCPT) StateShape(element string) []int {
+	if st := ax.State(element); st != nil {
+		return st.ShapeSizes()
+	}
+	return nil
+}
+
+// ActionShape returns the shape of the "Response" element,
+// implementing [Shaper].
+func (ax *AXCPT) ActionShape(element string) []int {
+	if element == "Response" {
+		return []int{2}
+	}
+	return nil
+}
+
+// Action records the model response for the "Response" element,
+// as a target response if unit [0] is more active than unit [1].
+func (ax *AXCPT) Action(element string, input tensor.Values) {
+	if element == "Response" {
+		ax.Respond(responseTarget(input))
+	}
+}
+
+// Compile-time check that implements Env interface
+var _ Env = (*AXCPT)(nil)
@@ -0,0 +1,50 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package env
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _SchedulesValues = []Schedules{0, 1, 2}
+
+// SchedulesN is the highest valid value for type Schedules, plus one.
+const SchedulesN Schedules = 3
+
+var _SchedulesValueMap = map[string]Schedules{`Blocked`: 0, `Interleaved`: 1, `Spaced`: 2}
+
+var _SchedulesDescMap = map[Schedules]string{0: `Blocked presents one task per block, cycling through the tasks in order across blocks.`, 1: `Interleaved presents all tasks within each block, in random order, with the number of trials for each task in proportion to its Ratio.`, 2: `Spaced presents all tasks within each block, in proportion to their Ratio, in a fixed order that spaces the trials of each task as evenly as possible.`}
+
+var _SchedulesMap = map[Schedules]string{0: `Blocked`, 1: `Interleaved`, 2: `Spaced`}
+
+// String returns the string representation of this Schedules value.
+func (i Schedules) String() string { return enums.String(i, _SchedulesMap) }
+
+// SetString sets the Schedules value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Schedules) SetString(s string) error {
+	return enums.SetString(i, s, _SchedulesValueMap, "Schedules")
+}
+
+// Int64 returns the Schedules value as an int64.
+func (i Schedules) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Schedules value from an int64.
+func (i *Schedules) SetInt64(in int64) { *i = Schedules(in) }
+
+// Desc returns the description of the Schedules value.
+func (i Schedules) Desc() string { return enums.Desc(i, _SchedulesDescMap) }
+
+// SchedulesValues returns all possible values for the type Schedules.
+func SchedulesValues() []Schedules { return _SchedulesValues }
+
+// Values returns all possible values for the type Schedules.
+func (i Schedules) Values() []enums.Enum { return enums.Values(_SchedulesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Schedules) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Schedules) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "Schedules")
+}
@@ -181,6 +181,18 @@ func (ft *FreqTable) State(element string) tensor.Values {
 	return et
 }
 
+// StateShape returns the cell shape of the table column for given element,
+// implementing [Shaper].
+func (ft *FreqTable) StateShape(element string) []int {
+	if cl := ft.Table.Column(element); cl != nil {
+		return cl.ShapeSizes()[1:]
+	}
+	return nil
+}
+
+// ActionShape returns nil, as there are no Action elements.
+func (ft *FreqTable) ActionShape(element string) []int { return nil }
+
 func (ft *FreqTable) Action(element string, input tensor.Values) {
 	// nop
 }
@@ -0,0 +1,139 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"math/rand"
+
+	"cogentcore.org/lab/base/randx"
+)
+
+// Transition is a transition in a finite-state [Grammar],
+// which outputs a Symbol and goes to the Next state.
+type Transition struct {
+
+	// Symbol is the output symbol.
+	Symbol string
+
+	// Next is the next state, where a state with no transitions
+	// ends the sequence.
+	Next string
+
+	// Prob is the relative probability of this transition among
+	// those from the same state.
+	Prob float32
+}
+
+// Grammar is a finite-state grammar that generates sequences of symbols,
+// starting from the Start state, and choosing a Transition from each state
+// at random according to their probabilities, until reaching a state
+// with no transitions. See [ReberGrammar] for the standard example.
+type Grammar struct {
+
+	// Name of the grammar.
+	Name string
+
+	// Start is the starting state.
+	Start string
+
+	// States are the transitions from each state.
+	States map[string][]Transition
+
+	// MaxLen is the maximum sequence length, to stop sequences from
+	// recursive states from growing too long, if > 0.
+	MaxLen int
+
+	// Rand is the random number source (nil = global).
+	Rand randx.Rand `display:"-"`
+}
+
+// NewGrammar returns a new [Grammar] with given name and start state.
+func NewGrammar(name, start string) *Grammar {
+	return &Grammar{Name: name, Start: start, States: make(map[string][]Transition)}
+}
+
+// Add adds a transition from given state to the next state,
+// outputting given symbol, with given relative probability.
+func (gr *Grammar) Add(state, symbol, next string, prob float32) *Grammar {
+	gr.States[state] = append(gr.States[state], Transition{Symbol: symbol, Next: next, Prob: prob})
+	return gr
+}
+
+// Symbols returns all of the symbols output by the grammar,
+// in the order of a breadth-first traversal from the Start state.
+func (gr *Grammar) Symbols() []string {
+	var syms []string
+	has := make(map[string]bool)
+	visited := map[string]bool{gr.Start: true}
+	queue := []string{gr.Start}
+	for len(queue) > 0 {
+		st := queue[0]
+		queue = queue[1:]
+		for _, tr := range gr.States[st] {
+			if !has[tr.Symbol] {
+				has[tr.Symbol] = true
+				syms = append(syms, tr.Symbol)
+			}
+			if !visited[tr.Next] {
+				visited[tr.Next] = true
+				queue = append(queue, tr.Next)
+			}
+		}
+	}
+	return syms
+}
+
+// choose returns a random transition from given list,
+// according to their probabilities.
+func (gr *Grammar) choose(trs []Transition) Transition {
+	sum := float32(0)
+	for _, tr := range trs {
+		sum += tr.Prob
+	}
+	var r float32
+	if gr.Rand != nil {
+		r = gr.Rand.Float32() * sum
+	} else {
+		r = rand.Float32() * sum
+	}
+	for _, tr := range trs {
+		r -= tr.Prob
+		if r < 0 {
+			return tr
+		}
+	}
+	return trs[len(trs)-1]
+}
+
+// Generate generates a new sequence of symbols from the grammar.
+func (gr *Grammar) Generate() []string {
+	var seq []string
+	st := gr.Start
+	for {
+		trs := gr.States[st]
+		if len(trs) == 0 || (gr.MaxLen > 0 && len(seq) >= gr.MaxLen) {
+			return seq
+		}
+		tr := gr.choose(trs)
+		seq = append(seq, tr.Symbol)
+		st = tr.Next
+	}
+}
+
+// ReberGrammar returns the standard Reber (1967) finite-state grammar,
+// which generates sequences starting with B and ending with E,
+// with equal probability transitions, as used in the Cleeremans et al.
+// (1989) simple recurrent network (SRN) prediction model.
+func ReberGrammar() *Grammar {
+	gr := NewGrammar("Reber", "0")
+	gr.Add("0", "B", "1", 1)
+	gr.Add("1", "T", "2", 1).Add("1", "P", "3", 1)
+	gr.Add("2", "S", "2", 1).Add("2", "X", "4", 1)
+	gr.Add("3", "T", "3", 1).Add("3", "V", "5", 1)
+	gr.Add("4", "X", "3", 1).Add("4", "S", "6", 1)
+	gr.Add("5", "P", "4", 1).Add("5", "V", "6", 1)
+	gr.Add("6", "E", "end", 1)
+	return gr
+}
@@ -155,6 +155,18 @@ func (ft *MPIFixedTable) State(element string) tensor.Values {
 	return et
 }
 
+// StateShape returns the cell shape of the table column for given element,
+// implementing [Shaper].
+func (ft *MPIFixedTable) StateShape(element string) []int {
+	if cl := ft.Table.Column(element); cl != nil {
+		return cl.ShapeSizes()[1:]
+	}
+	return nil
+}
+
+// ActionShape returns nil, as there are no Action elements.
+func (ft *MPIFixedTable) ActionShape(element string) []int { return nil }
+
 func (ft *MPIFixedTable) Action(element string, input tensor.Values) {
 	// nop
 }
@@ -0,0 +1,269 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/labelcode"
+)
+
+// NBack is an Env for the n-back working memory task, where a sequence
+// of stimuli is presented, and a target response is required when the
+// current stimulus matches the one presented N trials back.
+// Lures are stimuli that match one presented N-1 or N+1 trials back
+// (but not N), which are diagnostic of familiarity-based responding.
+// The Input state is a one-hot pattern for the current stimulus, and
+// the Target state has two units: [0] = target (match), [1] = non-target.
+// The model response is recorded with [NBack.Respond] or an Action on
+// the "Response" element with the same two units, and is scored in
+// Score, and TypeScores per TrialType (Target, Lure, NonTarget, and
+// Filler for the first N trials, which are not scored).
+type NBack struct {
+
+	// Name of this environment, usually Train vs. Test.
+	Name string
+
+	// N is the number of trials back to match.
+	N int `default:"2" min:"1"`
+
+	// TargetProb is the probability of a target (match) trial.
+	TargetProb float32 `default:"0.3"`
+
+	// LureProb is the probability of a lure trial.
+	LureProb float32 `default:"0.1"`
+
+	// Vocab has the stimuli.
+	Vocab labelcode.Vocab
+
+	// Trial is the trial counter. Set Max to the number of trials
+	// per block, after which the stimulus history starts over.
+	Trial Counter `display:"inline"`
+
+	// Stimulus is the current stimulus.
+	Stimulus CurPrevString
+
+	// TrialType is the type of the current trial:
+	// Target, Lure, NonTarget, or Filler.
+	TrialType CurPrevString
+
+	// History has the stimuli in the current block.
+	History []string `display:"-"`
+
+	// Score has the scores for all (non-filler) trials
+	// since the last ResetScores.
+	Score Score `display:"inline"`
+
+	// TypeScores has the scores for each TrialType.
+	TypeScores map[string]*Score `display:"-"`
+
+	// Rand is the random number source (nil = global).
+	Rand randx.Rand `display:"-"`
+
+	// Input is the one-hot pattern for the current stimulus.
+	Input tensor.Float32
+
+	// Target is the target response pattern.
+	Target tensor.Float32
+
+	// responded is whether a response has been recorded on this trial.
+	responded bool
+}
+
+func (nb *NBack) Defaults() {
+	nb.N = 2
+	nb.TargetProb = 0.3
+	nb.LureProb = 0.1
+}
+
+func (nb *NBack) Validate() error {
+	if nb.Vocab.Len() < 2 {
+		return fmt.Errorf("env.NBack: %v needs at least 2 stimuli", nb.Name)
+	}
+	if nb.N < 1 {
+		return fmt.Errorf("env.NBack: %v N must be >= 1", nb.Name)
+	}
+	return nil
+}
+
+func (nb *NBack) Label() string { return nb.Name }
+
+func (nb *NBack) String() string {
+	return nb.TrialType.Cur + "_" + nb.Stimulus.Cur
+}
+
+// Config configures the env for given N, trials per block,
+// and stimuli, with default probabilities, and calls Init(0).
+func (nb *NBack) Config(n, trials int, stimuli ...string) {
+	nb.Defaults()
+	nb.N = n
+	nb.Trial.Max = trials
+	nb.Vocab = *labelcode.NewVocab(stimuli...)
+	nb.Vocab.Frozen = true
+	nb.Init(0)
+}
+
+func (nb *NBack) Init(run int) {
+	mx := nb.Trial.Max
+	nb.Trial.Init()
+	nb.Trial.Max = mx
+	nb.Trial.Cur = -1
+	nb.History = nb.History[:0]
+	nb.Stimulus = CurPrevString{}
+	nb.TrialType = CurPrevString{}
+	nb.Input.SetShapeSizes(nb.Vocab.Len())
+	nb.Target.SetShapeSizes(2)
+	nb.ResetScores()
+}
+
+// ResetScores resets the Score and TypeScores, e.g., at the start of an epoch.
+func (nb *NBack) ResetScores() {
+	nb.Score.Reset()
+	nb.TypeScores = map[string]*Score{"Target": {}, "Lure": {}, "NonTarget": {}}
+}
+
+// randIntn returns a random int in [0, n).
+func (nb *NBack) randIntn(n int) int {
+	if nb.Rand != nil {
+		return nb.Rand.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
+// randFloat32 returns a random float32 in [0, 1).
+func (nb *NBack) randFloat32() float32 {
+	if nb.Rand != nil {
+		return nb.Rand.Float32()
+	}
+	return rand.Float32()
+}
+
+// randOther returns a random stimulus that is not any of given stimuli.
+func (nb *NBack) randOther(not ...string) string {
+	var cands []string
+	for _, st := range nb.Vocab.Labels {
+		if !slices.Contains(not, st) {
+			cands = append(cands, st)
+		}
+	}
+	if len(cands) == 0 {
+		return nb.Vocab.Labels[nb.randIntn(nb.Vocab.Len())]
+	}
+	return cands[nb.randIntn(len(cands))]
+}
+
+// next generates the next stimulus and trial type,
+// given the current History.
+func (nb *NBack) next() (stim, typ string) {
+	t := len(nb.History)
+	if t < nb.N {
+		return nb.randOther(), "Filler"
+	}
+	back := nb.History[t-nb.N]
+	r := nb.randFloat32()
+	if r < nb.TargetProb {
+		return back, "Target"
+	}
+	var lures []string
+	for _, off := range []int{nb.N - 1, nb.N + 1} {
+		if off >= 1 && t-off >= 0 && nb.History[t-off] != back {
+			lures = append(lures, nb.History[t-off])
+		}
+	}
+	if r < nb.TargetProb+nb.LureProb && len(lures) > 0 {
+		return lures[nb.randIntn(len(lures))], "Lure"
+	}
+	stim = nb.randOther(append(lures, back)...)
+	switch { // only if there are no other stimuli
+	case stim == back:
+		typ = "Target"
+	case slices.Contains(lures, stim):
+		typ = "Lure"
+	default:
+		typ = "NonTarget"
+	}
+	return
+}
+
+func (nb *NBack) Step() bool {
+	if nb.Trial.Incr() { // if true, hit max, reset to 0
+		nb.History = nb.History[:0]
+	}
+	stim, typ := nb.next()
+	nb.History = append(nb.History, stim)
+	nb.Stimulus.Set(stim)
+	nb.TrialType.Set(typ)
+	nb.Vocab.Encode(stim, &nb.Input)
+	nb.Target.SetZeros()
+	if nb.IsTarget() {
+		nb.Target.Values[0] = 1
+	} else {
+		nb.Target.Values[1] = 1
+	}
+	nb.responded = false
+	return true
+}
+
+// IsTarget returns true if the current trial is a target (match) trial.
+func (nb *NBack) IsTarget() bool {
+	return nb.TrialType.Cur == "Target"
+}
+
+// Respond records the model response on the current trial, for whether
+// it made a target (match) response, in the Score and TypeScores,
+// if not already recorded on this trial, and not a Filler trial.
+func (nb *NBack) Respond(target bool) {
+	if nb.responded || nb.TrialType.Cur == "Filler" || nb.TrialType.Cur == "" {
+		return
+	}
+	nb.responded = true
+	nb.Score.Record(nb.IsTarget(), target)
+	if sc := nb.TypeScores[nb.TrialType.Cur]; sc != nil {
+		sc.Record(nb.IsTarget(), target)
+	}
+}
+
+func (nb *NBack) State(element string) tensor.Values {
+	switch element {
+	case "Input":
+		return &nb.Input
+	case "Target":
+		return &nb.Target
+	}
+	return nil
+}
+
+// StateShape returns the shape of given State element,
+// implementing [Shaper]. The Input shape is set by Init.
+func (nb *NBack) StateShape(element string) []int {
+	if st := nb.State(element); st != nil {
+		return st.ShapeSizes()
+	}
+	return nil
+}
+
+// ActionShape returns the shape of the "Response" element,
+// implementing [Shaper].
+func (nb *NBack) ActionShape(element string) []int {
+	if element == "Response" {
+		return []int{2}
+	}
+	return nil
+}
+
+// Action records the model response for the "Response" element,
+// as a target response if unit [0] is more active than unit [1].
+func (nb *NBack) Action(element string, input tensor.Values) {
+	if element == "Response" {
+		nb.Respond(responseTarget(input))
+	}
+}
+
+// Compile-time check that implements Env interface
+var _ Env = (*NBack)(nil)
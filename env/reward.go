@@ -0,0 +1,224 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/problems"
+)
+
+// RewardBuffer buffers the recent actions of a model, and delivers
+// rewards for them after a configurable Delay in steps, together with
+// eligibility traces that assign credit for each reward to the recent
+// actions, decaying by Lambda for each step back in time, for RL
+// paradigms where reward is not immediate. It is typically a field of
+// an env that forwards its Init, Step, Action and State calls to it:
+// Record the action values on each step, call Reward when the outcome
+// of an action determines a reward, and Step at the start of each step,
+// which delivers the rewards that are due. The delivered reward and the
+// traces are available as State elements, to apply to the network as
+// modulator or target signals (e.g., with [ApplyInputs]):
+//   - "Reward" is the reward delivered on the current step (0 if none).
+//   - "Trace:" + action element is the eligibility trace of the action:
+//     the sum of its buffered values weighted by Lambda^age.
+//   - "Credit:" + action element is the Reward times its Trace.
+type RewardBuffer struct {
+
+	// Delay is the number of steps after the Reward call
+	// at which the reward is delivered, where 0 is immediately.
+	Delay int
+
+	// Lambda is the decay of the eligibility traces per step back in time,
+	// where 0 only gives credit to the actions of the current step.
+	Lambda float32 `default:"0.9"`
+
+	// Len is the maximum number of steps of actions buffered,
+	// which must be greater than Delay so that the actions are still
+	// buffered when their reward is delivered.
+	Len int `default:"10"`
+
+	// Time counts the steps since Init.
+	Time Counter `display:"inline"`
+
+	// Rew is the reward delivered on the current step, which is 0 if none.
+	Rew float32
+
+	// HasRew is true if a reward was delivered on the current step.
+	HasRew bool
+
+	// steps are the buffered actions of the recent steps,
+	// from the oldest to the current one.
+	steps []rewardStep
+
+	// pending are the rewards that have not been delivered yet.
+	pending []pendingReward
+}
+
+// rewardStep records the action values for one step.
+type rewardStep struct {
+	time    int
+	actions map[string]*tensor.Float32
+}
+
+// pendingReward is a reward due at a given time.
+type pendingReward struct {
+	due int
+	rew float32
+}
+
+// NewRewardBuffer returns a new [RewardBuffer] with given delay,
+// and default parameters.
+func NewRewardBuffer(delay int) *RewardBuffer {
+	return &RewardBuffer{Delay: delay, Lambda: 0.9, Len: 10}
+}
+
+// Validate returns an error if Len is not greater than Delay,
+// in which case the actions would be dropped from the buffer before
+// their reward is delivered, so they would get no credit for it.
+func (rb *RewardBuffer) Validate() error {
+	if rb.Delay >= max(rb.Len, 1) {
+		return fmt.Errorf("env.RewardBuffer: Len %d must be greater than Delay %d", rb.Len, rb.Delay)
+	}
+	return nil
+}
+
+// Init clears the buffer, with the Time counter at -1 so that the first
+// Step goes to 0. It reports an error to [problems.Default] if the
+// buffer is not valid (see Validate).
+func (rb *RewardBuffer) Init() {
+	problems.Err("env", "RewardBuffer", rb.Validate())
+	rb.Time.Init()
+	rb.Time.Cur = -1
+	rb.Rew = 0
+	rb.HasRew = false
+	rb.steps = nil
+	rb.pending = nil
+}
+
+// Step advances to the next step, delivering the sum of the rewards
+// that are due, and dropping the actions that are older than Len steps.
+func (rb *RewardBuffer) Step() {
+	rb.Time.Incr()
+	rb.Rew = 0
+	rb.HasRew = false
+	rb.deliver()
+	for len(rb.steps) > 0 && rb.Time.Cur-rb.steps[0].time >= max(rb.Len, 1) {
+		rb.steps = rb.steps[1:]
+	}
+}
+
+// deliver adds the pending rewards that are due to Rew.
+func (rb *RewardBuffer) deliver() {
+	keep := rb.pending[:0]
+	for _, pr := range rb.pending {
+		if pr.due <= rb.Time.Cur {
+			rb.Rew += pr.rew
+			rb.HasRew = true
+		} else {
+			keep = append(keep, pr)
+		}
+	}
+	rb.pending = keep
+}
+
+// Record records a copy of the values of given action element
+// for the current step.
+func (rb *RewardBuffer) Record(element string, vals tensor.Values) {
+	n := len(rb.steps)
+	if n == 0 || rb.steps[n-1].time != rb.Time.Cur {
+		rb.steps = append(rb.steps, rewardStep{time: rb.Time.Cur, actions: make(map[string]*tensor.Float32)})
+		n++
+	}
+	cp := tensor.NewFloat32(vals.ShapeSizes()...)
+	for i := range vals.Len() {
+		cp.Values[i] = float32(vals.Float1D(i))
+	}
+	rb.steps[n-1].actions[element] = cp
+}
+
+// Action records the values of given action element, as in Record,
+// so that the buffer can be used as the Action of an env.
+func (rb *RewardBuffer) Action(element string, input tensor.Values) {
+	rb.Record(element, input)
+}
+
+// Reward schedules given reward for delivery after Delay steps,
+// or delivers it on the current step if Delay is 0.
+func (rb *RewardBuffer) Reward(rew float32) {
+	rb.pending = append(rb.pending, pendingReward{due: rb.Time.Cur + rb.Delay, rew: rew})
+	if rb.Delay <= 0 {
+		rb.deliver()
+	}
+}
+
+// Pending returns the number of rewards that have not been delivered yet.
+func (rb *RewardBuffer) Pending() int {
+	return len(rb.pending)
+}
+
+// Eligibility returns the eligibility of the actions of the step
+// with given age in steps before the current one: Lambda^age.
+func (rb *RewardBuffer) Eligibility(age int) float32 {
+	return float32(math.Pow(float64(rb.Lambda), float64(age)))
+}
+
+// Trace returns the eligibility trace of given action element:
+// the sum over the buffered steps of its values weighted by the
+// Eligibility of their age, or nil if it has no buffered values.
+func (rb *RewardBuffer) Trace(element string) *tensor.Float32 {
+	var tr *tensor.Float32
+	for _, st := range rb.steps {
+		vals, ok := st.actions[element]
+		if !ok {
+			continue
+		}
+		if tr == nil {
+			tr = tensor.NewFloat32(vals.ShapeSizes()...)
+		}
+		el := rb.Eligibility(rb.Time.Cur - st.time)
+		for i, v := range vals.Values {
+			if i < len(tr.Values) {
+				tr.Values[i] += el * v
+			}
+		}
+	}
+	return tr
+}
+
+// Credit returns the credit for the delivered reward of given action
+// element: the Rew times its Trace, or nil if it has no buffered values.
+func (rb *RewardBuffer) Credit(element string) *tensor.Float32 {
+	tr := rb.Trace(element)
+	if tr == nil {
+		return nil
+	}
+	for i := range tr.Values {
+		tr.Values[i] *= rb.Rew
+	}
+	return tr
+}
+
+// State returns the given element: "Reward", or "Trace:" or "Credit:"
+// followed by the name of an action element, or nil if not found.
+func (rb *RewardBuffer) State(element string) tensor.Values {
+	if element == "Reward" {
+		return tensor.NewFloat32FromValues(rb.Rew)
+	}
+	if el, ok := strings.CutPrefix(element, "Trace:"); ok {
+		if tr := rb.Trace(el); tr != nil {
+			return tr
+		}
+	}
+	if el, ok := strings.CutPrefix(element, "Credit:"); ok {
+		if cr := rb.Credit(el); cr != nil {
+			return cr
+		}
+	}
+	return nil
+}
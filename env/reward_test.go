@@ -0,0 +1,109 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"testing"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/problems"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewardBuffer(t *testing.T) {
+	tests := []struct {
+		name   string
+		delay  int
+		bufLen int
+		steps  int     // steps run after the rewarded action
+		rew    float32 // expected Rew on the last step
+		trace  float32 // expected Trace:Act of the rewarded action on the last step
+		credit float32
+	}{
+		{"immediate", 0, 10, 0, 1, 1, 1},
+		{"delay", 2, 10, 2, 1, 0.25, 0.25},
+		{"before delay", 2, 10, 1, 0, 0.5, 0},
+		{"delay at len", 2, 3, 2, 1, 0.25, 0.25},
+		{"evicted", 1, 3, 3, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rb := &RewardBuffer{Delay: tt.delay, Lambda: 0.5, Len: tt.bufLen}
+			rb.Init()
+			rb.Step()
+			rb.Action("Act", tensor.NewFloat32FromValues(1))
+			rb.Reward(1)
+			for range tt.steps {
+				rb.Step()
+			}
+			assert.Equal(t, tt.rew, rb.Rew)
+			assert.Equal(t, tt.rew != 0, rb.HasRew)
+			assert.Equal(t, tt.rew, float32(rb.State("Reward").Float1D(0)))
+			if tt.trace == 0 {
+				assert.Nil(t, rb.State("Trace:Act"))
+				return
+			}
+			assert.Equal(t, tt.trace, float32(rb.State("Trace:Act").Float1D(0)))
+			assert.Equal(t, tt.credit, float32(rb.State("Credit:Act").Float1D(0)))
+		})
+	}
+}
+
+func TestRewardBufferTrace(t *testing.T) {
+	rb := &RewardBuffer{Delay: 1, Lambda: 0.5, Len: 2}
+	rb.Init()
+	for i := range 4 {
+		rb.Step()
+		rb.Action("Act", tensor.NewFloat32FromValues(float32(i+1), 1))
+	}
+	// only the last Len steps are buffered: 4 + 0.5*3
+	tr := rb.Trace("Act")
+	assert.Equal(t, []float32{5.5, 1.5}, tr.Values)
+	assert.Nil(t, rb.Trace("Other"))
+	assert.Nil(t, rb.State("Other"))
+
+	// rewards are summed when due on the same step
+	rb.Reward(1)
+	rb.Step()
+	rb.Reward(2)
+	assert.Equal(t, 1, rb.Pending())
+	rb.Step()
+	assert.Equal(t, float32(2), rb.Rew)
+	assert.Equal(t, 0, rb.Pending())
+}
+
+func TestRewardBufferReset(t *testing.T) {
+	rb := NewRewardBuffer(2)
+	rb.Init()
+	rb.Step()
+	rb.Action("Act", tensor.NewFloat32FromValues(1))
+	rb.Reward(1)
+	assert.Equal(t, 1, rb.Pending())
+
+	rb.Init()
+	assert.Equal(t, -1, rb.Time.Cur)
+	assert.Equal(t, 0, rb.Pending())
+	assert.Nil(t, rb.Trace("Act"))
+	for range 3 {
+		rb.Step()
+		assert.False(t, rb.HasRew)
+	}
+}
+
+func TestRewardBufferValidate(t *testing.T) {
+	assert.NoError(t, NewRewardBuffer(9).Validate())
+	assert.Error(t, NewRewardBuffer(10).Validate())
+	assert.Error(t, (&RewardBuffer{Delay: 1}).Validate())
+
+	problems.Default.Reset()
+	rb := NewRewardBuffer(10)
+	rb.Init()
+	prs := problems.Default.Level(problems.Error)
+	if assert.Equal(t, 1, len(prs)) {
+		assert.Equal(t, "env", prs[0].Source)
+		assert.Equal(t, "RewardBuffer", prs[0].Object)
+	}
+	problems.Default.Reset()
+}
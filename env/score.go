@@ -0,0 +1,111 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"math"
+
+	"cogentcore.org/lab/tensor"
+)
+
+// Score records signal detection scores for tasks where a response is
+// made on target trials and withheld on non-target trials, e.g., the
+// [NBack] and [AXCPT] envs.
+type Score struct {
+
+	// Hits is the number of target trials with a response.
+	Hits int
+
+	// Misses is the number of target trials without a response.
+	Misses int
+
+	// FalseAlarms is the number of non-target trials with a response.
+	FalseAlarms int
+
+	// CorrectRejects is the number of non-target trials without a response.
+	CorrectRejects int
+}
+
+// Reset resets the counts to 0.
+func (sc *Score) Reset() {
+	*sc = Score{}
+}
+
+// Record records a trial, for whether it is a target trial,
+// and whether there was a (target) response.
+func (sc *Score) Record(target, respond bool) {
+	switch {
+	case target && respond:
+		sc.Hits++
+	case target:
+		sc.Misses++
+	case respond:
+		sc.FalseAlarms++
+	default:
+		sc.CorrectRejects++
+	}
+}
+
+// N returns the total number of trials.
+func (sc *Score) N() int {
+	return sc.Hits + sc.Misses + sc.FalseAlarms + sc.CorrectRejects
+}
+
+// HitRate returns the proportion of target trials with a response.
+func (sc *Score) HitRate() float64 {
+	return ratio(sc.Hits, sc.Hits+sc.Misses)
+}
+
+// FARate returns the proportion of non-target trials with a response.
+func (sc *Score) FARate() float64 {
+	return ratio(sc.FalseAlarms, sc.FalseAlarms+sc.CorrectRejects)
+}
+
+// PctCorrect returns the proportion of trials with a correct response.
+func (sc *Score) PctCorrect() float64 {
+	return ratio(sc.Hits+sc.CorrectRejects, sc.N())
+}
+
+// DPrime returns the d' sensitivity measure, z(hit rate) - z(FA rate),
+// using the log-linear correction (adding 0.5 to each count and 1
+// to each number of trials), so that it is finite for rates of 0 or 1.
+func (sc *Score) DPrime() float64 {
+	hr := (float64(sc.Hits) + 0.5) / float64(sc.Hits+sc.Misses+1)
+	fr := (float64(sc.FalseAlarms) + 0.5) / float64(sc.FalseAlarms+sc.CorrectRejects+1)
+	return zScore(hr) - zScore(fr)
+}
+
+// SetStats calls given function (e.g., estats.Stats SetFloat) with the
+// HitRate, FARate, PctCorrect, and DPrime, with names prefixed by given
+// prefix (e.g., "Epc"), for logging.
+func (sc *Score) SetStats(prefix string, set func(name string, val float64)) {
+	set(prefix+"HitRate", sc.HitRate())
+	set(prefix+"FARate", sc.FARate())
+	set(prefix+"PctCorrect", sc.PctCorrect())
+	set(prefix+"DPrime", sc.DPrime())
+}
+
+// ratio returns n / d, or 0 if d is 0.
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// zScore returns the z score (inverse standard normal cumulative
+// distribution) for given probability.
+func zScore(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// responseTarget returns true if the response pattern has
+// greater activity on the first (target) unit than the second.
+func responseTarget(rsp tensor.Values) bool {
+	if rsp == nil || rsp.Len() < 2 {
+		return false
+	}
+	return rsp.Float1D(0) > rsp.Float1D(1)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"testing"
+
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/tensor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore(t *testing.T) {
+	var sc Score
+	assert.Equal(t, 0.0, sc.HitRate())
+	assert.Equal(t, 0.0, sc.DPrime())
+	sc.Record(true, true)
+	sc.Record(true, false)
+	sc.Record(false, true)
+	sc.Record(false, false)
+	sc.Record(false, false)
+	assert.Equal(t, Score{Hits: 1, Misses: 1, FalseAlarms: 1, CorrectRejects: 2}, sc)
+	assert.Equal(t, 5, sc.N())
+	assert.Equal(t, 0.5, sc.HitRate())
+	assert.InDelta(t, 1.0/3, sc.FARate(), 1.0e-9)
+	assert.Equal(t, 0.6, sc.PctCorrect())
+
+	sc = Score{Hits: 1, CorrectRejects: 1}
+	assert.InDelta(t, 1.3490, sc.DPrime(), 1.0e-4) // z(.75) - z(.25)
+	stats := map[string]float64{}
+	sc.SetStats("Epc", func(name string, val float64) { stats[name] = val })
+	assert.Equal(t, map[string]float64{"EpcHitRate": 1, "EpcFARate": 0, "EpcPctCorrect": 1, "EpcDPrime": sc.DPrime()}, stats)
+	sc.Reset()
+	assert.Equal(t, 0, sc.N())
+}
+
+func TestNBack(t *testing.T) {
+	nb := &NBack{Name: "Test"}
+	nb.Rand = randx.NewSysRand(1)
+	nb.Config(2, 50, "A", "B", "C", "D")
+	assert.NoError(t, nb.Validate())
+	assert.Equal(t, []int{4}, nb.StateShape("Input"))
+	assert.Equal(t, []int{2}, nb.ActionShape("Response"))
+	ntarg := 0
+	for ti := range 50 {
+		nb.Step()
+		assert.Equal(t, ti, nb.Trial.Cur)
+		stim := nb.Stimulus.Cur
+		assert.Equal(t, 1.0, nb.Input.Float1D(nb.Vocab.IndexOf(stim)))
+		typ := "Filler"
+		if ti >= 2 {
+			back := nb.History[ti-2]
+			switch {
+			case stim == back:
+				typ = "Target"
+			case stim == nb.History[ti-1] || (ti >= 3 && stim == nb.History[ti-3]):
+				typ = "Lure"
+			default:
+				typ = "NonTarget"
+			}
+		}
+		assert.Equal(t, typ, nb.TrialType.Cur, "trial %d: %v", ti, nb.History)
+		if nb.IsTarget() {
+			ntarg++
+			assert.Equal(t, []float32{1, 0}, nb.Target.Values)
+		} else {
+			assert.Equal(t, []float32{0, 1}, nb.Target.Values)
+		}
+		nb.Action("Response", &nb.Target) // correct response
+		nb.Respond(!nb.IsTarget())        // ignored: already responded
+	}
+	assert.Greater(t, ntarg, 0)
+	assert.Equal(t, 48, nb.Score.N()) // fillers not scored
+	assert.Equal(t, ntarg, nb.Score.Hits)
+	assert.Equal(t, 1.0, nb.Score.PctCorrect())
+	assert.Equal(t, ntarg, nb.TypeScores["Target"].N())
+
+	// new block starts with fillers
+	nb.Step()
+	assert.Equal(t, 0, nb.Trial.Cur)
+	assert.Equal(t, "Filler", nb.TrialType.Cur)
+	assert.Len(t, nb.History, 1)
+
+	nb.TargetProb = 1
+	nb.Step()
+	nb.Step()
+	assert.True(t, nb.IsTarget())
+	assert.Equal(t, nb.History[0], nb.Stimulus.Cur)
+
+	nb.ResetScores()
+	assert.Equal(t, 0, nb.Score.N())
+	assert.Error(t, (&NBack{Name: "Test", N: 2}).Validate())
+}
+
+func TestAXCPT(t *testing.T) {
+	ax := &AXCPT{Name: "Test"}
+	ax.Rand = randx.NewSysRand(1)
+	ax.Config(100)
+	ax.BCues = []string{"B", "C"}
+	ax.Init(0) // updates the Vocab
+	assert.NoError(t, ax.Validate())
+	assert.Equal(t, []int{5}, ax.StateShape("Input"))
+	types := map[string]int{}
+	rsp := tensor.NewFloat32(2)
+	for ti := range 100 {
+		ax.Step()
+		assert.Equal(t, ti, ax.Trial.Cur)
+		assert.False(t, ax.IsProbe())
+		assert.Equal(t, ax.Cue, ax.Stimulus.Cur)
+		assert.Equal(t, ax.TrialType.Cur[0] == 'A', ax.Cue == "A")
+		assert.Equal(t, []float32{0, 1}, ax.Target.Values)
+		ax.Respond(true) // cue responses not scored
+
+		ax.Step()
+		assert.Equal(t, ti, ax.Trial.Cur)
+		assert.True(t, ax.IsProbe())
+		assert.Equal(t, ax.Probe, ax.Stimulus.Cur)
+		assert.Equal(t, ax.TrialType.Cur[1] == 'X', ax.Probe == "X")
+		assert.Equal(t, 1.0, ax.Input.Float1D(ax.Vocab.IndexOf(ax.Probe)))
+		assert.Equal(t, ax.TrialType.Cur == "AX", ax.IsTarget())
+		types[ax.TrialType.Cur]++
+		// always respond target to an X probe: errors on BX only
+		rsp.Values[0], rsp.Values[1] = 0, 1
+		if ax.Probe == "X" {
+			rsp.Values[0], rsp.Values[1] = 1, 0
+		}
+		ax.Action("Response", rsp)
+	}
+	assert.Greater(t, types["AX"], types["AY"]+types["BX"]+types["BY"])
+	assert.Equal(t, 100, ax.Score.N())
+	assert.Equal(t, types["AX"], ax.Score.Hits)
+	assert.Equal(t, types["BX"], ax.Score.FalseAlarms)
+	assert.Equal(t, 1.0, ax.TypeScores["AX"].HitRate())
+	assert.Equal(t, 1.0, ax.TypeScores["BX"].FARate())
+	assert.Equal(t, 0.0, ax.TypeScores["AY"].FARate())
+	assert.Less(t, ax.ContextDPrime(), ax.Score.DPrime())
+
+	ax.ResetScores()
+	assert.Equal(t, 0, ax.Score.N())
+	ax.YProbes = nil
+	assert.Error(t, ax.Validate())
+}
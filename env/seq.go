@@ -0,0 +1,172 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"fmt"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/labelcode"
+	"github.com/emer/emergent/v2/problems"
+)
+
+// SeqEnv is an Env that presents sequences of symbols for prediction
+// learning, as in simple recurrent network (SRN) and deep predictive
+// learning models, where the Input state is the current symbol,
+// and the Target state is the next symbol in the sequence, both as
+// one-hot (localist) patterns over the Symbols. The sequences are
+// generated by the Generator function, e.g., [Grammar.Generate] for a
+// finite-state grammar such as [ReberGrammar], or the Gen method of
+// esg.Rules for stochastic sentence generator rules. Each Step advances
+// to the next symbol in the current sequence, and a new sequence is
+// generated when the end of the current one is reached, incrementing
+// the Seq counter. Each sequence of N symbols provides N-1 steps.
+type SeqEnv struct {
+
+	// Name of this environment, usually Train vs. Test.
+	Name string
+
+	// Generator generates a new sequence of symbols.
+	Generator func() []string
+
+	// Vocab has the symbols that can appear in sequences,
+	// which determines the size and order of the one-hot patterns.
+	Vocab labelcode.Vocab
+
+	// Seq is the sequence counter, incremented for each new sequence.
+	// Set Max to the number of sequences per epoch.
+	Seq Counter `display:"inline"`
+
+	// Tick is the position of the current symbol in the sequence.
+	Tick Counter `display:"inline"`
+
+	// Sequence is the current sequence of symbols.
+	Sequence []string
+
+	// Symbol is the current symbol.
+	Symbol CurPrevString
+
+	// Next is the next symbol, which is the prediction target.
+	Next string
+
+	// Input is the one-hot pattern for the current symbol.
+	Input tensor.Float32
+
+	// Target is the one-hot pattern for the next symbol.
+	Target tensor.Float32
+}
+
+func (sv *SeqEnv) Validate() error {
+	if sv.Generator == nil {
+		return fmt.Errorf("env.SeqEnv: %v has no Generator", sv.Name)
+	}
+	if sv.Vocab.Len() == 0 {
+		return fmt.Errorf("env.SeqEnv: %v has no Vocab symbols", sv.Name)
+	}
+	return nil
+}
+
+func (sv *SeqEnv) Label() string { return sv.Name }
+
+func (sv *SeqEnv) String() string {
+	return sv.Symbol.Cur
+}
+
+// Config configures the generator and symbols, and calls Init(0).
+// The Vocab is frozen so that unknown symbols are reported as errors.
+func (sv *SeqEnv) Config(gen func() []string, symbols ...string) {
+	sv.Generator = gen
+	sv.Vocab = *labelcode.NewVocab(symbols...)
+	sv.Vocab.Frozen = true
+	sv.Init(0)
+}
+
+// ConfigGrammar configures the env to generate sequences from
+// given grammar, using all of its symbols, and calls Init(0).
+func (sv *SeqEnv) ConfigGrammar(gr *Grammar) {
+	sv.Config(gr.Generate, gr.Symbols()...)
+}
+
+func (sv *SeqEnv) Init(run int) {
+	sv.Seq.Init()
+	sv.Seq.Cur = -1
+	sv.Tick.Init()
+	sv.Sequence = nil
+	sv.Symbol = CurPrevString{}
+	sv.Next = ""
+	sv.Input.SetShapeSizes(sv.Vocab.Len())
+	sv.Target.SetShapeSizes(sv.Vocab.Len())
+}
+
+// NewSequence generates a new sequence, which must
+// have at least 2 symbols, and increments the Seq counter.
+func (sv *SeqEnv) NewSequence() {
+	for range 100 {
+		sv.Sequence = sv.Generator()
+		if len(sv.Sequence) >= 2 {
+			break
+		}
+	}
+	sv.Seq.Incr()
+	sv.Tick.Init()
+}
+
+func (sv *SeqEnv) Step() bool {
+	sv.Seq.Same()
+	if sv.Tick.Cur+1 >= len(sv.Sequence)-1 {
+		sv.NewSequence()
+	} else {
+		sv.Tick.Incr()
+	}
+	if len(sv.Sequence) < 2 {
+		return false
+	}
+	sv.Symbol.Set(sv.Sequence[sv.Tick.Cur])
+	sv.Next = sv.Sequence[sv.Tick.Cur+1]
+	sv.encode(sv.Symbol.Cur, &sv.Input)
+	sv.encode(sv.Next, &sv.Target)
+	return true
+}
+
+// encode sets the one-hot pattern for given symbol,
+// reporting unknown symbols to [problems.Default].
+func (sv *SeqEnv) encode(sym string, pat *tensor.Float32) {
+	_, err := sv.Vocab.Encode(sym, pat)
+	problems.Err("env", sv.Name, err)
+}
+
+// IsEnd returns true if the current step is the last one in the sequence.
+func (sv *SeqEnv) IsEnd() bool {
+	return sv.Tick.Cur+2 >= len(sv.Sequence)
+}
+
+func (sv *SeqEnv) State(element string) tensor.Values {
+	switch element {
+	case "Input":
+		return &sv.Input
+	case "Target":
+		return &sv.Target
+	}
+	return nil
+}
+
+// StateShape returns the shape of given State element,
+// implementing [Shaper]. The shapes are set by Init.
+func (sv *SeqEnv) StateShape(element string) []int {
+	if st := sv.State(element); st != nil {
+		return st.ShapeSizes()
+	}
+	return nil
+}
+
+// ActionShape returns nil, as there are no Action elements.
+func (sv *SeqEnv) ActionShape(element string) []int { return nil }
+
+func (sv *SeqEnv) Action(element string, input tensor.Values) {
+	// nop
+}
+
+// Compile-time check that implements Env interface
+var _ Env = (*SeqEnv)(nil)
@@ -0,0 +1,117 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"testing"
+
+	"cogentcore.org/lab/base/randx"
+	"github.com/emer/emergent/v2/problems"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReberGrammar(t *testing.T) {
+	gr := ReberGrammar()
+	assert.Equal(t, []string{"B", "T", "P", "S", "X", "V", "E"}, gr.Symbols())
+	gr.Rand = randx.NewSysRand(1)
+	for range 50 {
+		seq := gr.Generate()
+		assert.Equal(t, "B", seq[0])
+		assert.Equal(t, "E", seq[len(seq)-1])
+		// every sequence must be a path through the grammar
+		st := gr.Start
+		for _, sym := range seq {
+			next := ""
+			for _, tr := range gr.States[st] {
+				if tr.Symbol == sym {
+					next = tr.Next
+				}
+			}
+			if !assert.NotEmpty(t, next, "no %s transition from state %s in %v", sym, st, seq) {
+				break
+			}
+			st = next
+		}
+		assert.Empty(t, gr.States[st])
+	}
+	gr.MaxLen = 3
+	assert.Len(t, gr.Generate(), 3)
+
+	sv := &SeqEnv{Name: "Reber"}
+	sv.ConfigGrammar(gr)
+	assert.Equal(t, 7, sv.Vocab.Len())
+	assert.True(t, sv.Step())
+	assert.Equal(t, "B", sv.Symbol.Cur)
+	assert.Equal(t, []float32{1, 0, 0, 0, 0, 0, 0}, sv.Input.Values)
+}
+
+func TestGrammarProb(t *testing.T) {
+	gr := NewGrammar("Test", "0")
+	gr.Add("0", "A", "end", 1).Add("0", "B", "end", 0)
+	gr.Rand = randx.NewSysRand(1)
+	for range 20 {
+		assert.Equal(t, []string{"A"}, gr.Generate())
+	}
+}
+
+func TestSeqEnv(t *testing.T) {
+	seqs := [][]string{{"A", "B", "C"}, {"C"}, {"C", "A"}}
+	gen := 0
+	sv := &SeqEnv{Name: "Test"}
+	sv.Config(func() []string {
+		seq := seqs[gen%len(seqs)]
+		gen++
+		return seq
+	}, "A", "B", "C")
+	assert.NoError(t, sv.Validate())
+	assert.Equal(t, []int{3}, sv.StateShape("Input"))
+	assert.Equal(t, []int{3}, sv.StateShape("Target"))
+
+	assert.True(t, sv.Step())
+	assert.Equal(t, 0, sv.Seq.Cur)
+	assert.True(t, sv.Seq.Changed)
+	assert.Equal(t, 0, sv.Tick.Cur)
+	assert.Equal(t, "A", sv.String())
+	assert.Equal(t, "B", sv.Next)
+	assert.Equal(t, []float32{1, 0, 0}, sv.Input.Values)
+	assert.Equal(t, []float32{0, 1, 0}, sv.Target.Values)
+	assert.False(t, sv.IsEnd())
+
+	assert.True(t, sv.Step())
+	assert.False(t, sv.Seq.Changed)
+	assert.Equal(t, 1, sv.Tick.Cur)
+	assert.Equal(t, "B", sv.Symbol.Cur)
+	assert.Equal(t, "A", sv.Symbol.Prev)
+	assert.Equal(t, "C", sv.Next)
+	assert.Equal(t, []float32{0, 0, 1}, sv.Target.Values)
+	assert.True(t, sv.IsEnd())
+
+	// the sequence of 1 symbol is skipped
+	assert.True(t, sv.Step())
+	assert.Equal(t, 1, sv.Seq.Cur)
+	assert.Equal(t, 0, sv.Tick.Cur)
+	assert.Equal(t, []string{"C", "A"}, sv.Sequence)
+	assert.Equal(t, "C", sv.Symbol.Cur)
+	assert.Equal(t, "A", sv.Next)
+	assert.True(t, sv.IsEnd())
+
+	sv.Init(0)
+	assert.Nil(t, sv.Sequence)
+	assert.Equal(t, -1, sv.Seq.Cur)
+}
+
+func TestSeqEnvUnknown(t *testing.T) {
+	problems.Default.Reset()
+	defer problems.Default.Reset()
+	sv := &SeqEnv{Name: "Test"}
+	sv.Config(func() []string { return []string{"A", "Z"} }, "A", "B")
+	sv.Step()
+	assert.Len(t, problems.Default.Level(problems.Error), 1)
+	assert.Equal(t, []float32{0, 0}, sv.Target.Values)
+
+	assert.Error(t, (&SeqEnv{Name: "Test"}).Validate())
+	sv.Generator = nil
+	assert.Error(t, sv.Validate())
+}
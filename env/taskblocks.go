@@ -0,0 +1,268 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"fmt"
+	"math"
+
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/tensor"
+)
+
+// Schedules are the ways of interleaving tasks within and across blocks
+// of trials, for the [TaskBlocks] env.
+type Schedules int32 //enums:enum
+
+const (
+	// Blocked presents one task per block, cycling through the tasks
+	// in order across blocks.
+	Blocked Schedules = iota
+
+	// Interleaved presents all tasks within each block, in random order,
+	// with the number of trials for each task in proportion to its Ratio.
+	Interleaved
+
+	// Spaced presents all tasks within each block, in proportion to
+	// their Ratio, in a fixed order that spaces the trials of each task
+	// as evenly as possible.
+	Spaced
+)
+
+// TaskBlocks is an Env that composes multiple task Envs (e.g., [FixedTable]
+// envs for different pattern tables) into blocks of trials, with controlled
+// interleaving of the tasks according to the Schedule.  This standardizes
+// interference and consolidation paradigms (blocked vs. interleaved training).
+// Each Step advances the Trial within the Block, and Steps the current task
+// env, which provides the State.  Use the Block counter to drive the outer
+// (e.g., Epoch) level of the looper, with BlockTrials as the Max of the
+// Trial level, and log the TaskName and Block to tag results with the
+// block and task identity.
+type TaskBlocks struct {
+
+	// Name of this environment, usually Train vs. Test.
+	Name string
+
+	// Tasks are the task environments.
+	Tasks []Env
+
+	// TaskNames are the names of each task, used for TaskName.
+	// If empty, the Label of each task env is used.
+	TaskNames []string
+
+	// Ratios are the relative frequencies of each task within a block,
+	// for the Interleaved and Spaced schedules. If empty, all are equal.
+	Ratios []float32
+
+	// Schedule determines how tasks are interleaved.
+	Schedule Schedules
+
+	// BlockTrials is the number of trials per block.
+	BlockTrials int
+
+	// Block is the block counter, incremented after each BlockTrials trials.
+	Block Counter `display:"inline"`
+
+	// Trial is the trial counter within the current block.
+	Trial Counter `display:"inline"`
+
+	// TaskIndex is the index of the current task, in Tasks.
+	TaskIndex CurPrev[int] `display:"-"`
+
+	// TaskName is the name of the current task.
+	TaskName CurPrevString
+
+	// Order is the order of task indexes for trials in the current block.
+	Order []int
+}
+
+func (tb *TaskBlocks) Validate() error {
+	if len(tb.Tasks) == 0 {
+		return fmt.Errorf("env.TaskBlocks: %v has no Tasks", tb.Name)
+	}
+	if len(tb.Ratios) > 0 && len(tb.Ratios) != len(tb.Tasks) {
+		return fmt.Errorf("env.TaskBlocks: %v has %d Ratios for %d Tasks", tb.Name, len(tb.Ratios), len(tb.Tasks))
+	}
+	if tb.BlockTrials <= 0 {
+		return fmt.Errorf("env.TaskBlocks: %v BlockTrials must be > 0", tb.Name)
+	}
+	return nil
+}
+
+func (tb *TaskBlocks) Label() string { return tb.Name }
+
+func (tb *TaskBlocks) String() string {
+	ev := tb.CurTask()
+	if ev == nil {
+		return tb.TaskName.Cur
+	}
+	return tb.TaskName.Cur + "_" + ev.String()
+}
+
+// Config configures the tasks and schedule, and calls Init(0).
+func (tb *TaskBlocks) Config(sched Schedules, blockTrials int, tasks ...Env) {
+	tb.Schedule = sched
+	tb.BlockTrials = blockTrials
+	tb.Tasks = tasks
+	tb.Init(0)
+}
+
+func (tb *TaskBlocks) Init(run int) {
+	for _, ev := range tb.Tasks {
+		ev.Init(run)
+	}
+	tb.Block.Init()
+	tb.Trial.Init()
+	tb.Trial.Max = tb.BlockTrials
+	tb.NewOrder()
+	tb.TaskIndex = CurPrev[int]{Cur: -1, Prev: -1}
+	tb.TaskName = CurPrevString{}
+	tb.Trial.Cur = -1 // init state -- key so that first Step() = 0
+}
+
+// TaskLabel returns the name of given task index.
+func (tb *TaskBlocks) TaskLabel(ti int) string {
+	if ti < len(tb.TaskNames) {
+		return tb.TaskNames[ti]
+	}
+	return tb.Tasks[ti].Label()
+}
+
+// CurTask returns the current task env, or nil if none.
+func (tb *TaskBlocks) CurTask() Env {
+	ti := tb.TaskIndex.Cur
+	if ti < 0 || ti >= len(tb.Tasks) {
+		return nil
+	}
+	return tb.Tasks[ti]
+}
+
+// TaskCounts returns the number of trials for each task in a block,
+// in proportion to the Ratios, summing exactly to BlockTrials.
+func (tb *TaskBlocks) TaskCounts() []int {
+	nt := len(tb.Tasks)
+	cnts := make([]int, nt)
+	sum := float32(0)
+	for ti := range nt {
+		sum += tb.ratio(ti)
+	}
+	if sum <= 0 {
+		return cnts
+	}
+	cum := float32(0)
+	prev := 0
+	for ti := range nt {
+		cum += tb.ratio(ti)
+		n := int(math.Round(float64(cum / sum * float32(tb.BlockTrials))))
+		cnts[ti] = n - prev
+		prev = n
+	}
+	return cnts
+}
+
+func (tb *TaskBlocks) ratio(ti int) float32 {
+	if len(tb.Ratios) == 0 {
+		return 1
+	}
+	return tb.Ratios[ti]
+}
+
+// NewOrder computes the Order of tasks for the current block,
+// according to the Schedule.
+func (tb *TaskBlocks) NewOrder() {
+	nt := len(tb.Tasks)
+	tb.Order = tb.Order[:0]
+	if nt == 0 {
+		return
+	}
+	switch tb.Schedule {
+	case Blocked:
+		ti := tb.Block.Cur % nt
+		for range tb.BlockTrials {
+			tb.Order = append(tb.Order, ti)
+		}
+	case Interleaved:
+		for ti, n := range tb.TaskCounts() {
+			for range n {
+				tb.Order = append(tb.Order, ti)
+			}
+		}
+		randx.PermuteInts(tb.Order)
+	case Spaced:
+		// smooth weighted round-robin: each trial goes to the task
+		// that is furthest behind its target count.
+		cnts := tb.TaskCounts()
+		cur := make([]int, nt)
+		for range tb.BlockTrials {
+			bi := 0
+			for ti := range nt {
+				cur[ti] += cnts[ti]
+				if cur[ti] > cur[bi] {
+					bi = ti
+				}
+			}
+			cur[bi] -= tb.BlockTrials
+			tb.Order = append(tb.Order, bi)
+		}
+	}
+}
+
+func (tb *TaskBlocks) Step() bool {
+	tb.Block.Same()
+	if tb.Trial.Incr() { // if true, hit max, reset to 0
+		tb.Block.Incr()
+		tb.NewOrder()
+	}
+	if len(tb.Order) == 0 {
+		return false
+	}
+	ti := tb.Order[tb.Trial.Cur]
+	tb.TaskIndex.Set(ti)
+	tb.TaskName.Set(tb.TaskLabel(ti))
+	return tb.Tasks[ti].Step()
+}
+
+func (tb *TaskBlocks) State(element string) tensor.Values {
+	ev := tb.CurTask()
+	if ev == nil {
+		return nil
+	}
+	return ev.State(element)
+}
+
+// StateShape returns the shape of given State element of the first task
+// that has it, implementing [Shaper], so all tasks should have the same
+// shapes. Tasks that do not implement [Shaper] are skipped.
+func (tb *TaskBlocks) StateShape(element string) []int {
+	return tb.taskShape(func(sh Shaper) []int { return sh.StateShape(element) })
+}
+
+// ActionShape returns the shape of given Action element of the first task
+// that has it, implementing [Shaper].
+func (tb *TaskBlocks) ActionShape(element string) []int {
+	return tb.taskShape(func(sh Shaper) []int { return sh.ActionShape(element) })
+}
+
+// taskShape returns the first non-nil shape from given function
+// over the tasks that implement [Shaper].
+func (tb *TaskBlocks) taskShape(fun func(sh Shaper) []int) []int {
+	for _, ev := range tb.Tasks {
+		if sh, ok := ev.(Shaper); ok {
+			if s := fun(sh); s != nil {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+func (tb *TaskBlocks) Action(element string, input tensor.Values) {
+	if ev := tb.CurTask(); ev != nil {
+		ev.Action(element, input)
+	}
+}
+
+// Compile-time check that implements Env interface
+var _ Env = (*TaskBlocks)(nil)
@@ -0,0 +1,95 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"testing"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTaskBlocks(sched Schedules, blockTrials int) *TaskBlocks {
+	ta := &stateEnv{name: "A", states: map[string]tensor.Values{"Input": tensor.NewFloat32FromValues(1, 0)}}
+	tb := &stateEnv{name: "B", states: map[string]tensor.Values{"Input": tensor.NewFloat32FromValues(0, 1)}}
+	bl := &TaskBlocks{Name: "Train"}
+	bl.Config(sched, blockTrials, ta, tb)
+	return bl
+}
+
+func TestTaskBlocksValidate(t *testing.T) {
+	bl := newTaskBlocks(Blocked, 4)
+	assert.NoError(t, bl.Validate())
+	bl.Ratios = []float32{1}
+	assert.ErrorContains(t, bl.Validate(), "1 Ratios for 2 Tasks")
+	bl.Ratios = nil
+	bl.BlockTrials = 0
+	assert.ErrorContains(t, bl.Validate(), "BlockTrials")
+	assert.Error(t, (&TaskBlocks{BlockTrials: 1}).Validate())
+}
+
+func TestTaskBlocksCounts(t *testing.T) {
+	bl := newTaskBlocks(Interleaved, 10)
+	assert.Equal(t, []int{5, 5}, bl.TaskCounts())
+	bl.Ratios = []float32{3, 1}
+	assert.Equal(t, []int{8, 2}, bl.TaskCounts())
+	bl.BlockTrials = 3
+	bl.Ratios = []float32{2, 1}
+	assert.Equal(t, []int{2, 1}, bl.TaskCounts())
+	bl.Ratios = []float32{0, 0}
+	assert.Equal(t, []int{0, 0}, bl.TaskCounts())
+}
+
+func TestTaskBlocksBlocked(t *testing.T) {
+	bl := newTaskBlocks(Blocked, 3)
+	assert.Nil(t, bl.CurTask())
+	assert.Nil(t, bl.State("Input"))
+	for blk := range 4 {
+		for trl := range 3 {
+			assert.True(t, bl.Step())
+			assert.Equal(t, blk, bl.Block.Cur)
+			assert.Equal(t, trl, bl.Trial.Cur)
+			assert.Equal(t, blk%2, bl.TaskIndex.Cur)
+			assert.Equal(t, bl.TaskLabel(blk%2), bl.TaskName.Cur)
+		}
+	}
+	assert.Equal(t, "B", bl.TaskName.Cur)
+	assert.Equal(t, []float32{0, 1}, bl.State("Input").(*tensor.Float32).Values)
+	assert.Equal(t, "B_B", bl.String())
+
+	bl.TaskNames = []string{"Task1", "Task2"}
+	bl.Init(0)
+	assert.Equal(t, -1, bl.Trial.Cur)
+	assert.Equal(t, 0, bl.Block.Cur)
+	assert.True(t, bl.Step())
+	assert.Equal(t, "Task1", bl.TaskName.Cur)
+}
+
+func TestTaskBlocksInterleaved(t *testing.T) {
+	bl := newTaskBlocks(Interleaved, 12)
+	bl.Ratios = []float32{2, 1}
+	bl.Init(0)
+	for blk := range 3 {
+		cnts := make([]int, 2)
+		for range 12 {
+			assert.True(t, bl.Step())
+			assert.Equal(t, blk, bl.Block.Cur)
+			cnts[bl.TaskIndex.Cur]++
+		}
+		assert.Equal(t, []int{8, 4}, cnts)
+	}
+}
+
+func TestTaskBlocksSpaced(t *testing.T) {
+	bl := newTaskBlocks(Spaced, 3)
+	bl.Ratios = []float32{2, 1}
+	bl.Init(0)
+	assert.Equal(t, []int{0, 1, 0}, bl.Order)
+
+	bl.Ratios = nil
+	bl.BlockTrials = 6
+	bl.Init(0)
+	assert.Equal(t, []int{0, 1, 0, 1, 0, 1}, bl.Order)
+}
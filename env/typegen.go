@@ -6,6 +6,8 @@ import (
 	"cogentcore.org/core/types"
 )
 
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.AXCPT", IDName: "axcpt", Doc: "AXCPT is an Env for the AX continuous performance task (AX-CPT), a\ncontext-maintenance task where each trial has a cue (A or B) followed\nby a probe (X or Y), and a target response is required only for an X\nprobe following an A cue (AX trials), with non-target responses for\nAY, BX, and BY trials. AX trials are frequent, creating a prepotent\ntarget response to A cues (errors on AY) and X probes (errors on BX),\nwhich must be controlled using the maintained cue context.\nEach trial has two steps, for the cue and the probe, tracked by the\nTick counter. The Input state is a one-hot pattern for the current\nstimulus, and the Target state has two units: [0] = target, [1] =\nnon-target, which is the target for all cues. The model response to\nthe probe is recorded with [AXCPT.Respond] or an Action on the\n\"Response\" element with the same two units, and is scored in Score,\nand TypeScores per TrialType.", Fields: []types.Field{{Name: "Name", Doc: "Name of this environment, usually Train vs. Test."}, {Name: "AXProb", Doc: "AXProb is the probability of AX trials."}, {Name: "AYProb", Doc: "AYProb is the probability of AY trials."}, {Name: "BXProb", Doc: "BXProb is the probability of BX trials."}, {Name: "BYProb", Doc: "BYProb is the probability of BY trials."}, {Name: "BCues", Doc: "BCues are the non-A cue stimuli, chosen at random on B trials."}, {Name: "YProbes", Doc: "YProbes are the non-X probe stimuli, chosen at random on Y trials."}, {Name: "Vocab", Doc: "Vocab has the stimuli: A, BCues, X, YProbes."}, {Name: "Trial", Doc: "Trial is the trial counter. Set Max to the number of trials per block."}, {Name: "Tick", Doc: "Tick is the step within the trial: 0 = cue, 1 = probe."}, {Name: "TrialType", Doc: "TrialType is the type of the current trial: AX, AY, BX, or BY."}, {Name: "Cue", Doc: "Cue is the cue stimulus for the current trial."}, {Name: "Probe", Doc: "Probe is the probe stimulus for the current trial."}, {Name: "Stimulus", Doc: "Stimulus is the current stimulus (Cue or Probe)."}, {Name: "Score", Doc: "Score has the scores for all trials since the last ResetScores."}, {Name: "TypeScores", Doc: "TypeScores has the scores for each TrialType."}, {Name: "Rand", Doc: "Rand is the random number source (nil = global)."}, {Name: "Input", Doc: "Input is the one-hot pattern for the current stimulus."}, {Name: "Target", Doc: "Target is the target response pattern."}, {Name: "responded", Doc: "responded is whether a response has been recorded on this trial."}}})
+
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.Counter", IDName: "counter", Doc: "Counter is a counter that counts increments at a given time scale.\nIt keeps track of when it has been incremented or not, and\nretains the previous value.", Fields: []types.Field{{Name: "Cur", Doc: "current counter value"}, {Name: "Prv", Doc: "previous counter value, prior to last Incr() call (init to -1)"}, {Name: "Chg", Doc: "did this change on the last Step() call or not?"}, {Name: "Max", Doc: "where relevant, this is a fixed maximum counter value, above which the counter will reset back to 0 -- only used if > 0"}, {Name: "Scale", Doc: "the unit of time scale represented by this counter (just FYI)"}}})
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.Counters", IDName: "counters", Doc: "Counters contains an ordered slice of timescales,\nand a lookup map of counters by timescale\nused to manage counters in the Env.", Fields: []types.Field{{Name: "Order", Doc: "ordered list of the counter timescales, from outer-most (highest) to inner-most (lowest)"}, {Name: "Counters", Doc: "map of the counters by timescale"}}})
@@ -28,4 +30,24 @@ var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.FixedTa
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.FreqTable", IDName: "freq-table", Doc: "FreqTable is an Env that manages patterns from an table.Table with frequency\ninformation so that items are presented according to their associated frequencies\nwhich are effectively probabilities of presenting any given input -- must have\na Freq column with these numbers in the table (actual col name in FreqCol).\nEither sequential or permuted random ordering is supported, with std Trial / Epoch\nTimeScale counters to record progress and iterations through the table.\nIt also records the outer loop of Run as provided by the model.\nIt uses an IndexView indexed view of the Table, so a single shared table\ncan be used across different environments, with each having its own unique view.", Fields: []types.Field{{Name: "Name", Doc: "name of this environment"}, {Name: "Table", Doc: "this is an indexed view of the table with the set of patterns to output -- the indexes are used for the *sequential* view so you can easily sort / split / filter the patterns to be presented using this view -- we then add the random permuted Order on top of those if !sequential"}, {Name: "NSamples", Doc: "number of samples to use in constructing the list of items to present according to frequency -- number per epoch ~ NSamples * Freq -- see RandSamp option"}, {Name: "RandSamp", Doc: "if true, use random sampling of items NSamples times according to given Freq probability value -- otherwise just directly add NSamples * Freq items to the list"}, {Name: "Sequential", Doc: "present items from the table in sequential order (i.e., according to the indexed view on the Table)?  otherwise permuted random order.  All repetitions of given item will be sequential if Sequential"}, {Name: "Order", Doc: "list of items to present, with repetitions -- updated every time through the list"}, {Name: "Trial", Doc: "current ordinal item in Table -- if Sequential then = row number in table, otherwise is index in Order list that then gives row number in Table"}, {Name: "TrialName", Doc: "if Table has a Name column, this is the contents of that"}, {Name: "GroupName", Doc: "if Table has a Group column, this is contents of that"}, {Name: "NameCol", Doc: "name of the Name column -- defaults to 'Name'"}, {Name: "GroupCol", Doc: "name of the Group column -- defaults to 'Group'"}, {Name: "FreqCol", Doc: "name of the Freq column -- defaults to 'Freq'"}}})
 
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.Transition", IDName: "transition", Doc: "Transition is a transition in a finite-state [Grammar],\nwhich outputs a Symbol and goes to the Next state.", Fields: []types.Field{{Name: "Symbol", Doc: "Symbol is the output symbol."}, {Name: "Next", Doc: "Next is the next state, where a state with no transitions\nends the sequence."}, {Name: "Prob", Doc: "Prob is the relative probability of this transition among\nthose from the same state."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.Grammar", IDName: "grammar", Doc: "Grammar is a finite-state grammar that generates sequences of symbols,\nstarting from the Start state, and choosing a Transition from each state\nat random according to their probabilities, until reaching a state\nwith no transitions. See [ReberGrammar] for the standard example.", Fields: []types.Field{{Name: "Name", Doc: "Name of the grammar."}, {Name: "Start", Doc: "Start is the starting state."}, {Name: "States", Doc: "States are the transitions from each state."}, {Name: "MaxLen", Doc: "MaxLen is the maximum sequence length, to stop sequences from\nrecursive states from growing too long, if > 0."}, {Name: "Rand", Doc: "Rand is the random number source (nil = global)."}}})
+
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.MPIFixedTable", IDName: "mpi-fixed-table", Doc: "MPIFixedTable is an MPI-enabled version of the FixedTable, which is\na basic Env that manages patterns from an table.Table, with\neither sequential or permuted random ordering, and uses standard Trial\nTime counter to record iterations through the table.\nIt uses an IndexView indexed view of the Table, so a single shared table\ncan be used across different environments, with each having its own unique view.\nThe MPI version distributes trials across MPI procs, in the Order list.\nIt is ESSENTIAL that the number of trials (rows) in Table is\nevenly divisible by number of MPI procs!\nIf all nodes start with the same seed, it should remain synchronized.", Fields: []types.Field{{Name: "Name", Doc: "name of this environment"}, {Name: "Table", Doc: "this is an indexed view of the table with the set of patterns to output -- the indexes are used for the *sequential* view so you can easily sort / split / filter the patterns to be presented using this view -- we then add the random permuted Order on top of those if !sequential"}, {Name: "Sequential", Doc: "present items from the table in sequential order (i.e., according to the indexed view on the Table)?  otherwise permuted random order"}, {Name: "Order", Doc: "permuted order of items to present if not sequential -- updated every time through the list"}, {Name: "Trial", Doc: "current ordinal item in Table -- if Sequential then = row number in table, otherwise is index in Order list that then gives row number in Table"}, {Name: "TrialName", Doc: "if Table has a Name column, this is the contents of that"}, {Name: "GroupName", Doc: "if Table has a Group column, this is contents of that"}, {Name: "NameCol", Doc: "name of the Name column -- defaults to 'Name'"}, {Name: "GroupCol", Doc: "name of the Group column -- defaults to 'Group'"}, {Name: "TrialSt", Doc: "for MPI, trial we start each epoch on, as index into Order"}, {Name: "TrialEd", Doc: "for MPI, trial number we end each epoch before (i.e., when ctr gets to Ed, restarts)"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.NBack", IDName: "n-back", Doc: "NBack is an Env for the n-back working memory task, where a sequence\nof stimuli is presented, and a target response is required when the\ncurrent stimulus matches the one presented N trials back.\nLures are stimuli that match one presented N-1 or N+1 trials back\n(but not N), which are diagnostic of familiarity-based responding.\nThe Input state is a one-hot pattern for the current stimulus, and\nthe Target state has two units: [0] = target (match), [1] = non-target.\nThe model response is recorded with [NBack.Respond] or an Action on\nthe \"Response\" element with the same two units, and is scored in\nScore, and TypeScores per TrialType (Target, Lure, NonTarget, and\nFiller for the first N trials, which are not scored).", Fields: []types.Field{{Name: "Name", Doc: "Name of this environment, usually Train vs. Test."}, {Name: "N", Doc: "N is the number of trials back to match."}, {Name: "TargetProb", Doc: "TargetProb is the probability of a target (match) trial."}, {Name: "LureProb", Doc: "LureProb is the probability of a lure trial."}, {Name: "Vocab", Doc: "Vocab has the stimuli."}, {Name: "Trial", Doc: "Trial is the trial counter. Set Max to the number of trials\nper block, after which the stimulus history starts over."}, {Name: "Stimulus", Doc: "Stimulus is the current stimulus."}, {Name: "TrialType", Doc: "TrialType is the type of the current trial:\nTarget, Lure, NonTarget, or Filler."}, {Name: "History", Doc: "History has the stimuli in the current block."}, {Name: "Score", Doc: "Score has the scores for all (non-filler) trials\nsince the last ResetScores."}, {Name: "TypeScores", Doc: "TypeScores has the scores for each TrialType."}, {Name: "Rand", Doc: "Rand is the random number source (nil = global)."}, {Name: "Input", Doc: "Input is the one-hot pattern for the current stimulus."}, {Name: "Target", Doc: "Target is the target response pattern."}, {Name: "responded", Doc: "responded is whether a response has been recorded on this trial."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.Score", IDName: "score", Doc: "Score records signal detection scores for tasks where a response is\nmade on target trials and withheld on non-target trials, e.g., the\n[NBack] and [AXCPT] envs.", Fields: []types.Field{{Name: "Hits", Doc: "Hits is the number of target trials with a response."}, {Name: "Misses", Doc: "Misses is the number of target trials without a response."}, {Name: "FalseAlarms", Doc: "FalseAlarms is the number of non-target trials with a response."}, {Name: "CorrectRejects", Doc: "CorrectRejects is the number of non-target trials without a response."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.SeqEnv", IDName: "seq-env", Doc: "SeqEnv is an Env that presents sequences of symbols for prediction\nlearning, as in simple recurrent network (SRN) and deep predictive\nlearning models, where the Input state is the current symbol,\nand the Target state is the next symbol in the sequence, both as\none-hot (localist) patterns over the Symbols. The sequences are\ngenerated by the Generator function, e.g., [Grammar.Generate] for a\nfinite-state grammar such as [ReberGrammar], or the Gen method of\nesg.Rules for stochastic sentence generator rules. Each Step advances\nto the next symbol in the current sequence, and a new sequence is\ngenerated when the end of the current one is reached, incrementing\nthe Seq counter. Each sequence of N symbols provides N-1 steps.", Fields: []types.Field{{Name: "Name", Doc: "Name of this environment, usually Train vs. Test."}, {Name: "Generator", Doc: "Generator generates a new sequence of symbols."}, {Name: "Vocab", Doc: "Vocab has the symbols that can appear in sequences,\nwhich determines the size and order of the one-hot patterns."}, {Name: "Seq", Doc: "Seq is the sequence counter, incremented for each new sequence.\nSet Max to the number of sequences per epoch."}, {Name: "Tick", Doc: "Tick is the position of the current symbol in the sequence."}, {Name: "Sequence", Doc: "Sequence is the current sequence of symbols."}, {Name: "Symbol", Doc: "Symbol is the current symbol."}, {Name: "Next", Doc: "Next is the next symbol, which is the prediction target."}, {Name: "Input", Doc: "Input is the one-hot pattern for the current symbol."}, {Name: "Target", Doc: "Target is the one-hot pattern for the next symbol."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.Schedules", IDName: "schedules", Doc: "Schedules are the ways of interleaving tasks within and across blocks\nof trials, for the [TaskBlocks] env."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.TaskBlocks", IDName: "task-blocks", Doc: "TaskBlocks is an Env that composes multiple task Envs (e.g., [FixedTable]\nenvs for different pattern tables) into blocks of trials, with controlled\ninterleaving of the tasks according to the Schedule.  This standardizes\ninterference and consolidation paradigms (blocked vs. interleaved training).\nEach Step advances the Trial within the Block, and Steps the current task\nenv, which provides the State.  Use the Block counter to drive the outer\n(e.g., Epoch) level of the looper, with BlockTrials as the Max of the\nTrial level, and log the TaskName and Block to tag results with the\nblock and task identity.", Fields: []types.Field{{Name: "Name", Doc: "Name of this environment, usually Train vs. Test."}, {Name: "Tasks", Doc: "Tasks are the task environments."}, {Name: "TaskNames", Doc: "TaskNames are the names of each task, used for TaskName.\nIf empty, the Label of each task env is used."}, {Name: "Ratios", Doc: "Ratios are the relative frequencies of each task within a block,\nfor the Interleaved and Spaced schedules. If empty, all are equal."}, {Name: "Schedule", Doc: "Schedule determines how tasks are interleaved."}, {Name: "BlockTrials", Doc: "BlockTrials is the number of trials per block."}, {Name: "Block", Doc: "Block is the block counter, incremented after each BlockTrials trials."}, {Name: "Trial", Doc: "Trial is the trial counter within the current block."}, {Name: "TaskIndex", Doc: "TaskIndex is the index of the current task, in Tasks."}, {Name: "TaskName", Doc: "TaskName is the name of the current task."}, {Name: "Order", Doc: "Order is the order of task indexes for trials in the current block."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.Shaper", IDName: "shaper", Doc: "Shaper is an optional interface for an [Env] that returns the shapes\nof its State and Action elements, independent of the current state,\nso that they can be validated at Init, before the first Step.\n[ValidateBindings] uses the shape of the current State for envs that\ndo not implement it.", Methods: []types.Method{{Name: "StateShape", Doc: "StateShape returns the shape sizes of given State element,\nor nil if there is no such element.", Args: []string{"element"}, Returns: []string{"[]int"}}, {Name: "ActionShape", Doc: "ActionShape returns the shape sizes of given Action element,\nor nil if there is no such element.", Args: []string{"element"}, Returns: []string{"[]int"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.Binding", IDName: "binding", Doc: "Binding binds an element of the State or Action of an [Env]\nto the layer of a network that it is applied to, or read from.", Fields: []types.Field{{Name: "Element", Doc: "Element is the name of the env element."}, {Name: "Layer", Doc: "Layer is the name of the layer."}, {Name: "Action", Doc: "Action indicates an Action element, whose values come from the\nlayer, instead of a State element that is applied to the layer."}, {Name: "Target", Doc: "Target indicates a State element with target values, which\n[ApplyInputs] only applies in the plus phase."}, {Name: "Transform", Doc: "Transform is an optional function that transforms the State\nelement values before they are applied to the layer by\n[ApplyInputs], e.g., to scale or pad them. It must not\nmodify the given values, which belong to the env."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/env.RewardBuffer", IDName: "reward-buffer", Doc: "RewardBuffer buffers the recent actions of a model, and delivers\nrewards for them after a configurable Delay in steps, together with\neligibility traces that assign credit for each reward to the recent\nactions, decaying by Lambda for each step back in time, for RL\nparadigms where reward is not immediate. It is typically a field of\nan env that forwards its Init, Step, Action and State calls to it:\nRecord the action values on each step, call Reward when the outcome\nof an action determines a reward, and Step at the start of each step,\nwhich delivers the rewards that are due. The delivered reward and the\ntraces are available as State elements, to apply to the network as\nmodulator or target signals (e.g., with [ApplyInputs]):\n  - \"Reward\" is the reward delivered on the current step (0 if none).\n  - \"Trace:\" + action element is the eligibility trace of the action:\n    the sum of its buffered values weighted by Lambda^age.\n  - \"Credit:\" + action element is the Reward times its Trace.", Fields: []types.Field{{Name: "Delay", Doc: "Delay is the number of steps after the Reward call\nat which the reward is delivered, where 0 is immediately."}, {Name: "Lambda", Doc: "Lambda is the decay of the eligibility traces per step back in time,\nwhere 0 only gives credit to the actions of the current step."}, {Name: "Len", Doc: "Len is the maximum number of steps of actions buffered,\nwhich must be greater than Delay so that the actions are still\nbuffered when their reward is delivered."}, {Name: "Time", Doc: "Time counts the steps since Init."}, {Name: "Rew", Doc: "Rew is the reward delivered on the current step, which is 0 if none."}, {Name: "HasRew", Doc: "HasRew is true if a reward was delivered on the current step."}, {Name: "steps", Doc: "steps are the buffered actions of the recent steps,\nfrom the oldest to the current one."}, {Name: "pending", Doc: "pending are the rewards that have not been delivered yet."}}})
@@ -0,0 +1,136 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// Shaper is an optional interface for an [Env] that returns the shapes
+// of its State and Action elements, independent of the current state,
+// so that they can be validated at Init, before the first Step.
+// [ValidateBindings] uses the shape of the current State for envs that
+// do not implement it.
+type Shaper interface {
+
+	// StateShape returns the shape sizes of given State element,
+	// or nil if there is no such element.
+	StateShape(element string) []int
+
+	// ActionShape returns the shape sizes of given Action element,
+	// or nil if there is no such element.
+	ActionShape(element string) []int
+}
+
+// Binding binds an element of the State or Action of an [Env]
+// to the layer of a network that it is applied to, or read from.
+type Binding struct {
+
+	// Element is the name of the env element.
+	Element string
+
+	// Layer is the name of the layer.
+	Layer string
+
+	// Action indicates an Action element, whose values come from the
+	// layer, instead of a State element that is applied to the layer.
+	Action bool
+
+	// Target indicates a State element with target values, which
+	// [ApplyInputs] only applies in the plus phase.
+	Target bool
+
+	// Transform is an optional function that transforms the State
+	// element values before they are applied to the layer by
+	// [ApplyInputs], e.g., to scale or pad them. It must not
+	// modify the given values, which belong to the env.
+	Transform func(st tensor.Values) tensor.Values `display:"-"`
+}
+
+// ValidateBindings checks that each of the given bound elements of the env
+// exists, and has the same number of values as its layer in given network,
+// with the same shape if they have the same number of dimensions, returning
+// an error with a clear message describing all mismatches. This should be
+// called at Init (e.g., after configuring the envs and building the network),
+// instead of getting index-out-of-range panics deep inside ApplyExt.
+// Envs that do not implement [Shaper] must return a valid State after Init,
+// and their Action elements are only checked for the existence of the layer.
+func ValidateBindings(ev Env, net emer.Network, binds ...Binding) error {
+	var errs []error
+	shp, hasShaper := ev.(Shaper)
+	for _, bd := range binds {
+		kind := "state"
+		if bd.Action {
+			kind = "action"
+		}
+		ly, err := net.AsEmer().EmerLayerByName(bd.Layer)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("env %q: %s element %q: %w", ev.Label(), kind, bd.Element, err))
+			continue
+		}
+		var es []int
+		switch {
+		case hasShaper && bd.Action:
+			es = shp.ActionShape(bd.Element)
+		case hasShaper:
+			es = shp.StateShape(bd.Element)
+		case bd.Action:
+			continue
+		default:
+			if st := ev.State(bd.Element); st != nil {
+				es = st.ShapeSizes()
+			}
+		}
+		if es == nil {
+			errs = append(errs, fmt.Errorf("env %q: %s element %q not found", ev.Label(), kind, bd.Element))
+			continue
+		}
+		ls := ly.AsEmer().Shape.Sizes
+		if !shapesMatch(es, ls) {
+			errs = append(errs, fmt.Errorf("env %q: %s element %q has shape %v, which does not match the shape %v of layer %q", ev.Label(), kind, bd.Element, es, ls, bd.Layer))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// shapesMatch returns true if the given shapes have the same number of
+// values, and the same sizes if they have the same number of dimensions.
+func shapesMatch(a, b []int) bool {
+	if len(a) == len(b) {
+		return slices.Equal(a, b)
+	}
+	return numValues(a) == numValues(b)
+}
+
+// numValues returns the number of values in a tensor of given shape sizes.
+func numValues(sizes []int) int {
+	n := 1
+	for _, s := range sizes {
+		n *= s
+	}
+	return n
+}
+
+// Validate calls [ValidateBindings] for each of the envs,
+// in sorted order of their names, returning all of the errors.
+func (es *Envs) Validate(net emer.Network, binds ...Binding) error {
+	nms := make([]string, 0, len(*es))
+	for nm := range *es {
+		nms = append(nms, nm)
+	}
+	slices.Sort(nms)
+	var errs []error
+	for _, nm := range nms {
+		if err := ValidateBindings((*es)[nm], net, binds...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
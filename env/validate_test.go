@@ -0,0 +1,99 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package env
+
+import (
+	"strings"
+	"testing"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+// stateEnv is an [Env] with fixed State elements,
+// which does not implement [Shaper].
+type stateEnv struct {
+	name   string
+	states map[string]tensor.Values
+}
+
+func (se *stateEnv) Label() string                              { return se.name }
+func (se *stateEnv) String() string                             { return se.name }
+func (se *stateEnv) Init(run int)                               {}
+func (se *stateEnv) Step() bool                                 { return true }
+func (se *stateEnv) State(element string) tensor.Values         { return se.states[element] }
+func (se *stateEnv) Action(element string, input tensor.Values) {}
+
+func newTestNet(t *testing.T) *bp.Network {
+	net := bp.NewNetwork("Env")
+	in := net.AddLayer2D("Input", 1, 3, bp.InputLayer)
+	out := net.AddLayer2D("Output", 1, 3, bp.TargetLayer)
+	rsp := net.AddLayer2D("Response", 1, 2, bp.TargetLayer)
+	net.ConnectLayers(in, out, paths.NewFull(), bp.ForwardPath)
+	net.ConnectLayers(in, rsp, paths.NewFull(), bp.ForwardPath)
+	assert.NoError(t, net.Build())
+	return net
+}
+
+func TestValidateBindings(t *testing.T) {
+	net := newTestNet(t)
+	sv := &SeqEnv{Name: "Seq"}
+	sv.Config(func() []string { return []string{"A", "B"} }, "A", "B", "C")
+	binds := []Binding{{Element: "Input", Layer: "Input"}, {Element: "Target", Layer: "Output", Target: true}}
+	assert.NoError(t, ValidateBindings(sv, net, binds...)) // [3] matches [1 3]
+
+	nb := &NBack{Name: "NBack"}
+	nb.Config(2, 10, "A", "B", "C")
+	assert.NoError(t, ValidateBindings(nb, net,
+		Binding{Element: "Input", Layer: "Input"},
+		Binding{Element: "Target", Layer: "Response", Target: true},
+		Binding{Element: "Response", Layer: "Response", Action: true}))
+
+	err := ValidateBindings(sv, net,
+		Binding{Element: "Input", Layer: "Hidden"},
+		Binding{Element: "Input", Layer: "Response"},
+		Binding{Element: "Response", Layer: "Response", Action: true},
+		Binding{Element: "Name", Layer: "Input"})
+	assert.Error(t, err)
+	msg := err.Error()
+	assert.Equal(t, 4, len(strings.Split(msg, "\n")), msg)
+	assert.Contains(t, msg, `env "Seq": state element "Input": `)
+	assert.Contains(t, msg, `env "Seq": state element "Input" has shape [3], which does not match the shape [1 2] of layer "Response"`)
+	assert.Contains(t, msg, `env "Seq": action element "Response" not found`)
+	assert.Contains(t, msg, `env "Seq": state element "Name" not found`)
+
+	// same number of dimensions must have the same shape
+	se := &stateEnv{name: "State", states: map[string]tensor.Values{"Input": tensor.NewFloat32(3, 1)}}
+	err = ValidateBindings(se, net, Binding{Element: "Input", Layer: "Input"})
+	assert.ErrorContains(t, err, "has shape [3 1], which does not match the shape [1 3]")
+	// without Shaper, actions are only checked for the layer
+	assert.NoError(t, ValidateBindings(se, net, Binding{Element: "Response", Layer: "Response", Action: true}))
+	assert.Error(t, ValidateBindings(se, net, Binding{Element: "Response", Layer: "Hidden", Action: true}))
+	assert.ErrorContains(t, ValidateBindings(se, net, Binding{Element: "Target", Layer: "Output"}), `env "State": state element "Target" not found`)
+}
+
+func TestEnvsValidate(t *testing.T) {
+	net := newTestNet(t)
+	sv := &SeqEnv{Name: "Train"}
+	sv.Config(func() []string { return []string{"A", "B"} }, "A", "B", "C", "D")
+	ts := &SeqEnv{Name: "Test"}
+	ts.Config(func() []string { return []string{"A", "B"} }, "A", "B")
+	ok := &SeqEnv{Name: "Valid"}
+	ok.Config(func() []string { return []string{"A", "B"} }, "A", "B", "C")
+	es := Envs{}
+	es.Add(sv, ts, ok)
+	err := es.Validate(net, Binding{Element: "Input", Layer: "Input"})
+	assert.Error(t, err)
+	msgs := strings.Split(err.Error(), "\n")
+	assert.Len(t, msgs, 2)
+	assert.True(t, strings.HasPrefix(msgs[0], `env "Test"`), msgs[0])
+	assert.True(t, strings.HasPrefix(msgs[1], `env "Train"`), msgs[1])
+
+	es = Envs{}
+	es.Add(ok)
+	assert.NoError(t, es.Validate(net, Binding{Element: "Input", Layer: "Input"}))
+}
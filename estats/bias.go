@@ -0,0 +1,83 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"math"
+
+	"github.com/emer/emergent/v2/emer"
+)
+
+// BiasStat is a [LayerStat] that summarizes the bias weights of the units
+// in a layer at the end of each epoch, for monitoring bias drift without
+// code-level inspection. The epoch stats are EpcBiasMean, EpcBiasSD,
+// EpcBiasMin, EpcBiasMax, and EpcBiasDrift, which is the mean absolute
+// change in the bias weights since the previous epoch.
+// The bias weights are read on the trial with data parallel index 0,
+// as they are shared across data parallel inputs.
+type BiasStat struct {
+
+	// Layer is the name of the layer.
+	Layer string
+
+	// Var is the unit variable for the bias weights.
+	Var string
+
+	vals []float32
+	prev []float32
+}
+
+// NewBiasStat returns a new [BiasStat] for given layer,
+// using the canonical Bias variable (see [emer.UnitVarIndex]).
+func NewBiasStat(layer string) *BiasStat {
+	return &BiasStat{Layer: layer, Var: "Bias"}
+}
+
+func (bs *BiasStat) Init(st *Stats) {
+}
+
+func (bs *BiasStat) TrialStats(st *Stats, net emer.Network, di int) {
+	if di != 0 {
+		return
+	}
+	layerValues(net, bs.Layer, bs.Var, di, &bs.vals)
+}
+
+// SetValues sets the current bias weight values directly,
+// instead of reading them from the network in TrialStats.
+func (bs *BiasStat) SetValues(vals []float32) {
+	bs.vals = append(bs.vals[:0], vals...)
+}
+
+func (bs *BiasStat) EpochStats(st *Stats) {
+	n := len(bs.vals)
+	if n == 0 {
+		return
+	}
+	var sum, ssq float64
+	mn, mx := math.Inf(1), math.Inf(-1)
+	for _, v := range bs.vals {
+		fv := float64(v)
+		sum += fv
+		ssq += fv * fv
+		mn = min(mn, fv)
+		mx = max(mx, fv)
+	}
+	mean := sum / float64(n)
+	vr := max(ssq/float64(n)-mean*mean, 0)
+	drift := 0.0
+	if len(bs.prev) == n {
+		for i, v := range bs.vals {
+			drift += math.Abs(float64(v - bs.prev[i]))
+		}
+		drift /= float64(n)
+	}
+	bs.prev = append(bs.prev[:0], bs.vals...)
+	st.SetFloat(bs.Layer+"_EpcBiasMean", mean)
+	st.SetFloat(bs.Layer+"_EpcBiasSD", math.Sqrt(vr))
+	st.SetFloat(bs.Layer+"_EpcBiasMin", mn)
+	st.SetFloat(bs.Layer+"_EpcBiasMax", mx)
+	st.SetFloat(bs.Layer+"_EpcBiasDrift", drift)
+}
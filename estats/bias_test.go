@@ -0,0 +1,30 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBiasStat(t *testing.T) {
+	st := &Stats{}
+	st.Init()
+	bs := NewBiasStat("Hidden")
+	bs.Init(st)
+	bs.EpochStats(st) // no values yet
+	assert.NotContains(t, st.Floats, "Hidden_EpcBiasMean")
+	bs.SetValues([]float32{-1, 1, 0, 0})
+	bs.EpochStats(st)
+	assert.Equal(t, 0.0, st.Floats["Hidden_EpcBiasMean"])
+	assert.InDelta(t, 0.7071, st.Floats["Hidden_EpcBiasSD"], 1.0e-4)
+	assert.Equal(t, -1.0, st.Floats["Hidden_EpcBiasMin"])
+	assert.Equal(t, 1.0, st.Floats["Hidden_EpcBiasMax"])
+	assert.Equal(t, 0.0, st.Floats["Hidden_EpcBiasDrift"])
+	bs.SetValues([]float32{-0.5, 1, 0, 0.5})
+	bs.EpochStats(st)
+	assert.Equal(t, 0.25, st.Floats["Hidden_EpcBiasDrift"])
+}
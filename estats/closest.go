@@ -0,0 +1,182 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"math"
+
+	"cogentcore.org/lab/table"
+	"github.com/emer/emergent/v2/confusion"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// ClosestMatch is the result of finding the closest pattern to a given
+// pattern (e.g., output layer activations) in a bank of patterns,
+// as computed by [ClosestPattern]. This is the standard "name error"
+// evaluation from C++ emergent.
+type ClosestMatch struct {
+
+	// Row is the row of the closest pattern, -1 if there are no patterns.
+	Row int
+
+	// Name is the name of the closest pattern, if a name column is given.
+	Name string
+
+	// Similarity is the similarity (correlation or cosine)
+	// of the closest pattern.
+	Similarity float64
+
+	// Second is the similarity of the second-closest pattern,
+	// -Inf if there is only one pattern.
+	Second float64
+
+	// Margin is the difference in similarity between the closest and
+	// second-closest patterns, which indicates how unambiguous the
+	// match is, and is 0 if there is only one pattern.
+	Margin float64
+}
+
+// IsCorrect returns true if the closest pattern has given name.
+func (cm *ClosestMatch) IsCorrect(name string) bool {
+	return cm.Row >= 0 && cm.Name == name
+}
+
+// ClosestPattern returns the [ClosestMatch] for given values among the
+// rows of given column of given table of patterns, with names from given
+// name column (if non-empty), using correlation as the similarity measure,
+// or cosine if cosine is true. The pattern cells are compared with the
+// values up to the smaller of their sizes.
+func ClosestPattern(vals []float32, pats *table.Table, column, nameColumn string, cosine bool) ClosestMatch {
+	cm := ClosestMatch{Row: -1, Similarity: math.Inf(-1), Second: math.Inf(-1)}
+	nr := pats.NumRows()
+	if nr == 0 {
+		return cm
+	}
+	col := pats.Column(column)
+	csz := col.Len() / nr
+	n := min(csz, len(vals))
+	pat := make([]float32, n)
+	for ri := range nr {
+		for ci := range n {
+			pat[ci] = float32(col.Float1D(ri*csz + ci))
+		}
+		var sim float64
+		if cosine {
+			sim = Cosine(vals[:n], pat)
+		} else {
+			sim = Correlation(vals[:n], pat)
+		}
+		switch {
+		case sim > cm.Similarity:
+			cm.Second = cm.Similarity
+			cm.Row, cm.Similarity = ri, sim
+		case sim > cm.Second:
+			cm.Second = sim
+		}
+	}
+	if nr > 1 {
+		cm.Margin = cm.Similarity - cm.Second
+	}
+	if nameColumn != "" {
+		cm.Name = pats.Column(nameColumn).String1D(cm.Row)
+	}
+	return cm
+}
+
+// ClosestPatStat is a [LayerStat] that finds the closest pattern to the
+// layer activations among the rows of a column of a table of patterns,
+// using [ClosestPattern], with trial stats TrlClosest (the name of the
+// closest pattern, in Strings), TrlSim (its similarity), TrlMargin (the
+// margin to the second-closest), and TrlClosestErr (1 if the name is not
+// the Expected one), and epoch stats EpcSim, EpcMargin and EpcClosestPctErr.
+type ClosestPatStat struct {
+
+	// Layer is the name of the layer.
+	Layer string
+
+	// Var is the unit variable, e.g., ActM.
+	Var string
+
+	// Patterns is the table of patterns.
+	Patterns *table.Table
+
+	// Column is the name of the column with the patterns.
+	Column string
+
+	// NameColumn is the name of the column with the pattern names.
+	NameColumn string
+
+	// Cosine uses cosine instead of correlation as the similarity measure.
+	Cosine bool
+
+	// Expected returns the name of the expected (correct) pattern
+	// for given data parallel index, e.g., from the environment.
+	// If nil, TrlClosestErr is not computed.
+	Expected func(di int) string
+
+	// Confusion, if set, accumulates the confusion matrix of the expected
+	// vs. closest pattern names over each epoch, e.g., the Stats Confusion,
+	// initialized with InitFromLabels from the pattern names.
+	Confusion *confusion.Matrix
+
+	acts   []float32
+	sim    epochAvg
+	margin epochAvg
+	err    epochAvg
+}
+
+// NewClosestPatStat returns a new [ClosestPatStat] for given layer,
+// patterns table, and columns, using the minus phase activations (ActM).
+func NewClosestPatStat(layer string, pats *table.Table, column, nameColumn string, expected func(di int) string) *ClosestPatStat {
+	return &ClosestPatStat{Layer: layer, Var: "ActM", Patterns: pats, Column: column, NameColumn: nameColumn, Expected: expected}
+}
+
+func (cp *ClosestPatStat) Init(st *Stats) {
+	cp.sim.reset()
+	cp.margin.reset()
+	cp.err.reset()
+	if cp.Confusion != nil {
+		cp.Confusion.Reset()
+	}
+}
+
+func (cp *ClosestPatStat) TrialStats(st *Stats, net emer.Network, di int) {
+	if !layerValues(net, cp.Layer, cp.Var, di, &cp.acts) {
+		return
+	}
+	cm := ClosestPattern(cp.acts, cp.Patterns, cp.Column, cp.NameColumn, cp.Cosine)
+	if cm.Row < 0 {
+		return
+	}
+	st.SetString(cp.Layer+"_TrlClosest", cm.Name)
+	st.SetStringDi(cp.Layer+"_TrlClosest", di, cm.Name)
+	st.setTrialFloat(cp.Layer+"_TrlSim", di, cm.Similarity)
+	st.setTrialFloat(cp.Layer+"_TrlMargin", di, cm.Margin)
+	cp.sim.add(cm.Similarity)
+	cp.margin.add(cm.Margin)
+	if cp.Expected == nil {
+		return
+	}
+	err := 1.0
+	if cm.IsCorrect(cp.Expected(di)) {
+		err = 0
+	}
+	st.setTrialFloat(cp.Layer+"_TrlClosestErr", di, err)
+	cp.err.add(err)
+	if cp.Confusion != nil {
+		cp.Confusion.IncrLabel(cp.Expected(di), cm.Name)
+	}
+}
+
+func (cp *ClosestPatStat) EpochStats(st *Stats) {
+	st.SetFloat(cp.Layer+"_EpcSim", cp.sim.avg())
+	st.SetFloat(cp.Layer+"_EpcMargin", cp.margin.avg())
+	if cp.Expected != nil {
+		st.SetFloat(cp.Layer+"_EpcClosestPctErr", cp.err.avg())
+	}
+	if cp.Confusion != nil {
+		cp.Confusion.Probs()
+	}
+}
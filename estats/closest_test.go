@@ -0,0 +1,116 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"math"
+	"testing"
+
+	"cogentcore.org/lab/table"
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/emer/emergent/v2/problems"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPatterns() *table.Table {
+	dt := table.New()
+	dt.AddStringColumn("Name")
+	dt.AddFloat32Column("Output", 4)
+	dt.SetNumRows(3)
+	pats := [][]float32{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 1}}
+	for ri, pat := range pats {
+		dt.Column("Name").SetStringRow(string(rune('A'+ri)), ri, 0)
+		for ci, v := range pat {
+			dt.Column("Output").SetFloatRow(float64(v), ri, ci)
+		}
+	}
+	return dt
+}
+
+func TestClosestPattern(t *testing.T) {
+	dt := testPatterns()
+	cm := ClosestPattern([]float32{0.9, 0.2, 0, 0}, dt, "Output", "Name", false)
+	assert.Equal(t, 0, cm.Row)
+	assert.Equal(t, "A", cm.Name)
+	assert.True(t, cm.IsCorrect("A"))
+	assert.False(t, cm.IsCorrect("B"))
+	assert.Greater(t, cm.Similarity, cm.Second)
+	assert.InDelta(t, cm.Similarity-cm.Second, cm.Margin, 1.0e-9)
+
+	cm = ClosestPattern([]float32{0, 0, 1, 0}, dt, "Output", "Name", true)
+	assert.Equal(t, "C", cm.Name)
+	assert.InDelta(t, 1/math.Sqrt(2), cm.Similarity, 1.0e-6)
+	assert.Equal(t, 0.0, cm.Second)
+
+	dt.SetNumRows(0)
+	cm = ClosestPattern([]float32{0, 0, 1, 0}, dt, "Output", "Name", true)
+	assert.Equal(t, -1, cm.Row)
+	assert.False(t, cm.IsCorrect(""))
+}
+
+func TestLayerStats(t *testing.T) {
+	net := bp.NewNetwork("LayerStats")
+	in := net.AddLayer2D("Input", 1, 4, bp.InputLayer)
+	out := net.AddLayer2D("Output", 1, 4, bp.TargetLayer)
+	net.ConnectLayers(in, out, paths.NewFull(), bp.ForwardPath)
+	assert.NoError(t, net.Build())
+
+	st := &Stats{}
+	st.Init()
+	sse := NewSSEStat("Output")
+	pe := NewPctErrStat("Output")
+	cd := NewCosDiffStat("Output")
+	cp := NewClosestPatStat("Output", testPatterns(), "Output", "Name", func(di int) string { return "A" })
+	cp.Cosine = true
+	sse.ActVar, sse.TargVar = "Act", "Ext"
+	pe.ActVar, pe.TargVar = "Act", "Ext"
+	cd.MinusVar, cd.PlusVar = "Act", "Ext"
+	cp.Var = "Act"
+	st.AddLayerStat(sse, pe, cd, cp)
+	st.InitLayerStats()
+
+	// correct within tolerance
+	copy(out.Ext, []float32{1, 0, 0, 0})
+	copy(out.Act, []float32{0.9, 0.1, 0, 0})
+	st.TrialLayerStats(net, 0)
+	assert.Equal(t, 0.0, st.Floats["Output_TrlSSE"])
+	assert.Equal(t, 0.0, st.FloatDi("Output_TrlErr", 0))
+	assert.InDelta(t, 0.9/math.Sqrt(0.82), st.Floats["Output_TrlCosDiff"], 1.0e-6)
+	assert.Equal(t, "A", st.Strings["Output_TrlClosest"])
+	assert.Equal(t, 0.0, st.Floats["Output_TrlClosestErr"])
+
+	// wrong unit active
+	copy(out.Act, []float32{0, 0.9, 0, 0})
+	st.TrialLayerStats(net, 1)
+	assert.InDelta(t, 1.81, st.FloatDi("Output_TrlSSE", 1), 1.0e-6)
+	assert.InDelta(t, 1.81/4, st.Floats["Output_TrlAvgSSE"], 1.0e-6)
+	assert.Equal(t, 1.0, st.Floats["Output_TrlErr"])
+	assert.Equal(t, 0.0, st.Floats["Output_TrlCosDiff"])
+	assert.Equal(t, "B", st.StringDi("Output_TrlClosest", 1))
+	assert.Equal(t, 1.0, st.Floats["Output_TrlClosestErr"])
+	assert.Equal(t, 0.0, st.FloatDi("Output_TrlSSE", 0)) // di 0 unchanged
+
+	st.EpochLayerStats()
+	assert.InDelta(t, 0.905, st.Floats["Output_EpcSSE"], 1.0e-6)
+	assert.Equal(t, 0.5, st.Floats["Output_EpcPctErr"])
+	assert.Equal(t, 0.5, st.Floats["Output_EpcPctCor"])
+	assert.InDelta(t, 0.9/math.Sqrt(0.82)/2, st.Floats["Output_EpcCosDiff"], 1.0e-6)
+	assert.Equal(t, 0.5, st.Floats["Output_EpcClosestPctErr"])
+	assert.InDelta(t, (0.9/math.Sqrt(0.82)+1)/2, st.Floats["Output_EpcSim"], 1.0e-6)
+
+	// a new epoch starts the accumulators over
+	st.InitLayerStats()
+	st.EpochLayerStats()
+	assert.Equal(t, 0.0, st.Floats["Output_EpcSSE"])
+
+	// a missing layer is reported, and sets no stats
+	problems.Default.Reset()
+	defer problems.Default.Reset()
+	ms := NewSSEStat("Missing")
+	ms.TrialStats(st, net, 0)
+	assert.Len(t, problems.Default.Level(problems.Error), 1)
+	assert.NotContains(t, st.Floats, "Missing_TrlSSE")
+}
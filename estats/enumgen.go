@@ -0,0 +1,50 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package estats
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _OverlapsValues = []Overlaps{0, 1, 2}
+
+// OverlapsN is the highest valid value for type Overlaps, plus one.
+const OverlapsN Overlaps = 3
+
+var _OverlapsValueMap = map[string]Overlaps{`OverlapDot`: 0, `OverlapCosine`: 1, `OverlapJaccard`: 2}
+
+var _OverlapsDescMap = map[Overlaps]string{0: `OverlapDot is the dot product of the activities.`, 1: `OverlapCosine is the cosine (normalized dot product) of the activities.`, 2: `OverlapJaccard is the number of units active in both patterns, divided by the number of units active in either pattern, where active units have an activity above the threshold. It is 0 if neither pattern has active units.`}
+
+var _OverlapsMap = map[Overlaps]string{0: `OverlapDot`, 1: `OverlapCosine`, 2: `OverlapJaccard`}
+
+// String returns the string representation of this Overlaps value.
+func (i Overlaps) String() string { return enums.String(i, _OverlapsMap) }
+
+// SetString sets the Overlaps value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Overlaps) SetString(s string) error {
+	return enums.SetString(i, s, _OverlapsValueMap, "Overlaps")
+}
+
+// Int64 returns the Overlaps value as an int64.
+func (i Overlaps) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Overlaps value from an int64.
+func (i *Overlaps) SetInt64(in int64) { *i = Overlaps(in) }
+
+// Desc returns the description of the Overlaps value.
+func (i Overlaps) Desc() string { return enums.Desc(i, _OverlapsDescMap) }
+
+// OverlapsValues returns all possible values for the type Overlaps.
+func OverlapsValues() []Overlaps { return _OverlapsValues }
+
+// Values returns all possible values for the type Overlaps.
+func (i Overlaps) Values() []enums.Enum { return enums.Values(_OverlapsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Overlaps) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Overlaps) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "Overlaps")
+}
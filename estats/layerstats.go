@@ -0,0 +1,306 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"math"
+
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/problems"
+)
+
+// LayerStat is a statistic computed on a layer (typically an output layer)
+// at the end of each trial, and aggregated over the trials of an epoch,
+// which can be registered with [Stats.AddLayerStat], so that simple models
+// get the standard statistics without copying the TrialStats boilerplate,
+// and custom models can plug in their own.
+// Standard implementations are [SSEStat], [PctErrStat], [CosDiffStat],
+// and [ClosestPatStat]. The values are stored in the Stats Floats
+// (and Strings) maps, with names of the form Layer_TrlName for trial
+// stats, and Layer_EpcName for epoch stats, e.g., Output_TrlSSE.
+// Trial stats are also stored with the data parallel index
+// appended (see [DiName]).
+type LayerStat interface {
+
+	// Init initializes the epoch accumulators,
+	// at the start of each epoch.
+	Init(st *Stats)
+
+	// TrialStats computes the trial stats for given data parallel index,
+	// storing them in st, and accumulates them for the epoch.
+	TrialStats(st *Stats, net emer.Network, di int)
+
+	// EpochStats computes the epoch stats from the accumulated
+	// trial stats, storing them in st.
+	EpochStats(st *Stats)
+}
+
+// AddLayerStat adds given [LayerStat]s to the list of registered stats.
+func (st *Stats) AddLayerStat(ls ...LayerStat) {
+	st.LayerStats = append(st.LayerStats, ls...)
+}
+
+// InitLayerStats calls Init on all registered [LayerStat]s,
+// at the start of each epoch.
+func (st *Stats) InitLayerStats() {
+	for _, ls := range st.LayerStats {
+		ls.Init(st)
+	}
+}
+
+// TrialLayerStats calls TrialStats on all registered [LayerStat]s,
+// for given data parallel index, at the end of each trial.
+func (st *Stats) TrialLayerStats(net emer.Network, di int) {
+	for _, ls := range st.LayerStats {
+		ls.TrialStats(st, net, di)
+	}
+}
+
+// EpochLayerStats calls EpochStats on all registered [LayerStat]s,
+// at the end of each epoch.
+func (st *Stats) EpochLayerStats() {
+	for _, ls := range st.LayerStats {
+		ls.EpochStats(st)
+	}
+}
+
+// setTrialFloat sets a trial stat value, under the plain
+// name and the data parallel index name.
+func (st *Stats) setTrialFloat(name string, di int, value float64) {
+	st.SetFloat(name, value)
+	st.SetFloatDi(name, di, value)
+}
+
+// epochAvg accumulates the average of a trial stat over an epoch.
+type epochAvg struct {
+	sum float64
+	n   int
+}
+
+func (ea *epochAvg) reset() {
+	ea.sum = 0
+	ea.n = 0
+}
+
+func (ea *epochAvg) add(v float64) {
+	ea.sum += v
+	ea.n++
+}
+
+func (ea *epochAvg) avg() float64 {
+	if ea.n == 0 {
+		return 0
+	}
+	return ea.sum / float64(ea.n)
+}
+
+// layerValues fills given slice with the values of given unit variable
+// on given layer, returning false (with the error reported to
+// [problems.Default]) if not found.
+func layerValues(net emer.Network, layer, unitVar string, di int, vals *[]float32) bool {
+	ly, err := net.AsEmer().EmerLayerByName(layer)
+	if problems.Err("estats", layer, err) != nil {
+		return false
+	}
+	return problems.Err("estats", layer, ly.AsEmer().UnitValues(vals, unitVar, di)) == nil
+}
+
+// SSE returns the sum squared error between given actual and target
+// values, and its average per unit, where differences with an absolute
+// value less than tol are counted as 0, as in the leabra SSE.
+func SSE(acts, targs []float32, tol float32) (sse, avg float64) {
+	for i, a := range acts {
+		d := targs[i] - a
+		if math.Abs(float64(d)) < float64(tol) {
+			continue
+		}
+		sse += float64(d * d)
+	}
+	if len(acts) > 0 {
+		avg = sse / float64(len(acts))
+	}
+	return
+}
+
+// Cosine returns the cosine (normalized dot product) of given values.
+func Cosine(a, b []float32) float64 {
+	var ab, aa, bb float64
+	for i, av := range a {
+		bv := float64(b[i])
+		ab += float64(av) * bv
+		aa += float64(av) * float64(av)
+		bb += bv * bv
+	}
+	if aa == 0 || bb == 0 {
+		return 0
+	}
+	return ab / math.Sqrt(aa*bb)
+}
+
+// Correlation returns the correlation of given values.
+func Correlation(a, b []float32) float64 {
+	n := float64(len(a))
+	if n == 0 {
+		return 0
+	}
+	var ma, mb float64
+	for i, av := range a {
+		ma += float64(av)
+		mb += float64(b[i])
+	}
+	ma /= n
+	mb /= n
+	var ab, aa, bb float64
+	for i, av := range a {
+		da := float64(av) - ma
+		db := float64(b[i]) - mb
+		ab += da * db
+		aa += da * da
+		bb += db * db
+	}
+	if aa == 0 || bb == 0 {
+		return 0
+	}
+	return ab / math.Sqrt(aa*bb)
+}
+
+// SSEStat is a [LayerStat] for the sum squared error (SSE) between
+// the actual and target values on a layer, with trial stats TrlSSE and
+// TrlAvgSSE (average per unit), and epoch stats EpcSSE and EpcAvgSSE.
+type SSEStat struct {
+
+	// Layer is the name of the layer.
+	Layer string
+
+	// ActVar is the unit variable for the actual values, e.g., ActM.
+	ActVar string
+
+	// TargVar is the unit variable for the target values, e.g., Targ.
+	TargVar string
+
+	// Tol is the tolerance: differences less than this are counted as 0.
+	Tol float32
+
+	acts, targs []float32
+	sse, avg    epochAvg
+}
+
+// NewSSEStat returns a new [SSEStat] for given layer,
+// with leabra defaults: ActM, Targ, and Tol = 0.5.
+func NewSSEStat(layer string) *SSEStat {
+	return &SSEStat{Layer: layer, ActVar: "ActM", TargVar: "Targ", Tol: 0.5}
+}
+
+func (ss *SSEStat) Init(st *Stats) {
+	ss.sse.reset()
+	ss.avg.reset()
+}
+
+func (ss *SSEStat) TrialStats(st *Stats, net emer.Network, di int) {
+	if !layerValues(net, ss.Layer, ss.ActVar, di, &ss.acts) || !layerValues(net, ss.Layer, ss.TargVar, di, &ss.targs) {
+		return
+	}
+	sse, avg := SSE(ss.acts, ss.targs, ss.Tol)
+	st.setTrialFloat(ss.Layer+"_TrlSSE", di, sse)
+	st.setTrialFloat(ss.Layer+"_TrlAvgSSE", di, avg)
+	ss.sse.add(sse)
+	ss.avg.add(avg)
+}
+
+func (ss *SSEStat) EpochStats(st *Stats) {
+	st.SetFloat(ss.Layer+"_EpcSSE", ss.sse.avg())
+	st.SetFloat(ss.Layer+"_EpcAvgSSE", ss.avg.avg())
+}
+
+// PctErrStat is a [LayerStat] for the proportion of trials with an error,
+// where any unit differs from its target by more than Tol, with trial stat
+// TrlErr (1 for an error, else 0), and epoch stats EpcPctErr and EpcPctCor.
+type PctErrStat struct {
+
+	// Layer is the name of the layer.
+	Layer string
+
+	// ActVar is the unit variable for the actual values, e.g., ActM.
+	ActVar string
+
+	// TargVar is the unit variable for the target values, e.g., Targ.
+	TargVar string
+
+	// Tol is the tolerance: differences less than this are not errors.
+	Tol float32
+
+	acts, targs []float32
+	err         epochAvg
+}
+
+// NewPctErrStat returns a new [PctErrStat] for given layer,
+// with leabra defaults: ActM, Targ, and Tol = 0.5.
+func NewPctErrStat(layer string) *PctErrStat {
+	return &PctErrStat{Layer: layer, ActVar: "ActM", TargVar: "Targ", Tol: 0.5}
+}
+
+func (ps *PctErrStat) Init(st *Stats) {
+	ps.err.reset()
+}
+
+func (ps *PctErrStat) TrialStats(st *Stats, net emer.Network, di int) {
+	if !layerValues(net, ps.Layer, ps.ActVar, di, &ps.acts) || !layerValues(net, ps.Layer, ps.TargVar, di, &ps.targs) {
+		return
+	}
+	sse, _ := SSE(ps.acts, ps.targs, ps.Tol)
+	err := 0.0
+	if sse > 0 {
+		err = 1
+	}
+	st.setTrialFloat(ps.Layer+"_TrlErr", di, err)
+	ps.err.add(err)
+}
+
+func (ps *PctErrStat) EpochStats(st *Stats) {
+	pct := ps.err.avg()
+	st.SetFloat(ps.Layer+"_EpcPctErr", pct)
+	st.SetFloat(ps.Layer+"_EpcPctCor", 1-pct)
+}
+
+// CosDiffStat is a [LayerStat] for the cosine between the minus and plus
+// phase activations on a layer, which measures how well the layer predicts
+// its plus phase outcome, with trial stat TrlCosDiff and epoch stat EpcCosDiff.
+type CosDiffStat struct {
+
+	// Layer is the name of the layer.
+	Layer string
+
+	// MinusVar is the unit variable for the minus phase, e.g., ActM.
+	MinusVar string
+
+	// PlusVar is the unit variable for the plus phase, e.g., ActP.
+	PlusVar string
+
+	minus, plus []float32
+	cos         epochAvg
+}
+
+// NewCosDiffStat returns a new [CosDiffStat] for given layer,
+// with leabra defaults: ActM, ActP.
+func NewCosDiffStat(layer string) *CosDiffStat {
+	return &CosDiffStat{Layer: layer, MinusVar: "ActM", PlusVar: "ActP"}
+}
+
+func (cs *CosDiffStat) Init(st *Stats) {
+	cs.cos.reset()
+}
+
+func (cs *CosDiffStat) TrialStats(st *Stats, net emer.Network, di int) {
+	if !layerValues(net, cs.Layer, cs.MinusVar, di, &cs.minus) || !layerValues(net, cs.Layer, cs.PlusVar, di, &cs.plus) {
+		return
+	}
+	cos := Cosine(cs.minus, cs.plus)
+	st.setTrialFloat(cs.Layer+"_TrlCosDiff", di, cos)
+	cs.cos.add(cos)
+}
+
+func (cs *CosDiffStat) EpochStats(st *Stats) {
+	st.SetFloat(cs.Layer+"_EpcCosDiff", cs.cos.avg())
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+// Overlaps are the measures of the overlap between two activity patterns,
+// for evaluating pattern separation and completion.
+type Overlaps int32 //enums:enum
+
+const (
+	// OverlapDot is the dot product of the activities.
+	OverlapDot Overlaps = iota
+
+	// OverlapCosine is the cosine (normalized dot product) of the activities.
+	OverlapCosine
+
+	// OverlapJaccard is the number of units active in both patterns, divided by
+	// the number of units active in either pattern, where active units
+	// have an activity above the threshold. It is 0 if neither pattern
+	// has active units.
+	OverlapJaccard
+)
+
+// Sparsity returns the fraction of the units that are active, with
+// an activity above given threshold, which is the activity level of
+// the pattern: lower values are sparser.
+func Sparsity(acts []float32, thr float32) float64 {
+	if len(acts) == 0 {
+		return 0
+	}
+	n := 0
+	for _, a := range acts {
+		if a > thr {
+			n++
+		}
+	}
+	return float64(n) / float64(len(acts))
+}
+
+// Overlap returns the overlap between the given activity patterns,
+// using given measure, with given activity threshold for OverlapJaccard.
+func Overlap(a, b []float32, measure Overlaps, thr float32) float64 {
+	switch measure {
+	case OverlapCosine:
+		return Cosine(a, b)
+	case OverlapJaccard:
+		var both, either int
+		for i, av := range a {
+			aon, bon := av > thr, b[i] > thr
+			if aon && bon {
+				both++
+			}
+			if aon || bon {
+				either++
+			}
+		}
+		if either == 0 {
+			return 0
+		}
+		return float64(both) / float64(either)
+	}
+	var ab float64
+	for i, av := range a {
+		ab += float64(av) * float64(b[i])
+	}
+	return ab
+}
+
+// OverlapMatrix returns the matrix of the pairwise overlaps between the
+// given patterns, using given measure and threshold (see [Overlap]).
+func OverlapMatrix(pats [][]float32, measure Overlaps, thr float32) *tensor.Float64 {
+	n := len(pats)
+	om := tensor.NewFloat64(n, n)
+	for i := range n {
+		for j := i; j < n; j++ {
+			ov := Overlap(pats[i], pats[j], measure, thr)
+			om.Set(ov, i, j)
+			om.Set(ov, j, i)
+		}
+	}
+	return om
+}
+
+// OverlapSummary summarizes the sparsity and overlap of the
+// activity patterns of a layer across recorded trials, with the mean
+// overlap of pairs of patterns within and between categories (conditions),
+// as computed by [SummarizeOverlap].
+type OverlapSummary struct {
+
+	// Sparsity is the mean [Sparsity] of the patterns.
+	Sparsity float64
+
+	// Within is the mean overlap of the pairs of different
+	// patterns in the same category.
+	Within float64
+
+	// Between is the mean overlap of the pairs of patterns
+	// in different categories.
+	Between float64
+
+	// NWithin is the number of pairs within categories.
+	NWithin int
+
+	// NBetween is the number of pairs between categories.
+	NBetween int
+}
+
+// SummarizeOverlap returns the [OverlapSummary] for given patterns,
+// with given category of each pattern, using given overlap measure and
+// activity threshold (for the Sparsity and OverlapJaccard). Greater Within
+// than Between overlap indicates that the layer represents the categories.
+func SummarizeOverlap(pats [][]float32, cats []string, measure Overlaps, thr float32) OverlapSummary {
+	var sum OverlapSummary
+	if len(pats) == 0 {
+		return sum
+	}
+	for i, p := range pats {
+		sum.Sparsity += Sparsity(p, thr)
+		for j := i + 1; j < len(pats); j++ {
+			ov := Overlap(p, pats[j], measure, thr)
+			if cats[i] == cats[j] {
+				sum.Within += ov
+				sum.NWithin++
+			} else {
+				sum.Between += ov
+				sum.NBetween++
+			}
+		}
+	}
+	sum.Sparsity /= float64(len(pats))
+	if sum.NWithin > 0 {
+		sum.Within /= float64(sum.NWithin)
+	}
+	if sum.NBetween > 0 {
+		sum.Between /= float64(sum.NBetween)
+	}
+	return sum
+}
+
+// ColumnPatterns returns the patterns in the rows of given column
+// of given table, such as the layer activities recorded on each trial.
+func ColumnPatterns(dt *table.Table, column string) [][]float32 {
+	nr := dt.NumRows()
+	if nr == 0 {
+		return nil
+	}
+	col := dt.Column(column)
+	csz := col.Len() / nr
+	pats := make([][]float32, nr)
+	for ri := range nr {
+		pat := make([]float32, csz)
+		for ci := range csz {
+			pat[ci] = float32(col.Float1D(ri*csz + ci))
+		}
+		pats[ri] = pat
+	}
+	return pats
+}
+
+// OverlapTable returns a table summarizing the sparsity and overlap of
+// the activity patterns of the given layer columns of given table of
+// recorded trials, with the category of each trial in given category
+// column, using [SummarizeOverlap]. It has one row per layer, with
+// columns Layer, Sparsity, Within, Between, NWithin and NBetween.
+func OverlapTable(dt *table.Table, layers []string, catColumn string, measure Overlaps, thr float32) *table.Table {
+	cats := make([]string, dt.NumRows())
+	cc := dt.Column(catColumn)
+	for ri := range cats {
+		cats[ri] = cc.String1D(ri)
+	}
+	ot := table.New()
+	ot.AddStringColumn("Layer")
+	ot.AddFloat64Column("Sparsity")
+	ot.AddFloat64Column("Within")
+	ot.AddFloat64Column("Between")
+	ot.AddIntColumn("NWithin")
+	ot.AddIntColumn("NBetween")
+	ot.SetNumRows(len(layers))
+	for li, ly := range layers {
+		sum := SummarizeOverlap(ColumnPatterns(dt, ly), cats, measure, thr)
+		ot.Column("Layer").SetString1D(ly, li)
+		ot.Column("Sparsity").SetFloat1D(sum.Sparsity, li)
+		ot.Column("Within").SetFloat1D(sum.Within, li)
+		ot.Column("Between").SetFloat1D(sum.Between, li)
+		ot.Column("NWithin").SetFloat1D(float64(sum.NWithin), li)
+		ot.Column("NBetween").SetFloat1D(float64(sum.NBetween), li)
+	}
+	return ot
+}
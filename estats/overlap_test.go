@@ -0,0 +1,58 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"testing"
+
+	"cogentcore.org/lab/table"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlap(t *testing.T) {
+	a := []float32{1, 1, 0, 0}
+	b := []float32{1, 0, 1, 0}
+	assert.Equal(t, 0.5, Sparsity(a, 0.5))
+	assert.Equal(t, 0.0, Sparsity(nil, 0.5))
+	assert.Equal(t, 1.0, Overlap(a, b, OverlapDot, 0.5))
+	assert.InDelta(t, 0.5, Overlap(a, b, OverlapCosine, 0.5), 1.0e-9)
+	assert.InDelta(t, 1.0/3.0, Overlap(a, b, OverlapJaccard, 0.5), 1.0e-9)
+	assert.Equal(t, 0.0, Overlap(make([]float32, 4), make([]float32, 4), OverlapJaccard, 0.5))
+
+	om := OverlapMatrix([][]float32{a, b}, OverlapJaccard, 0.5)
+	assert.Equal(t, 1.0, om.Value(0, 0))
+	assert.InDelta(t, 1.0/3.0, om.Value(0, 1), 1.0e-9)
+	assert.Equal(t, om.Value(0, 1), om.Value(1, 0))
+}
+
+func TestOverlapTable(t *testing.T) {
+	dt := table.New()
+	dt.AddStringColumn("Cat")
+	dt.AddFloat32Column("Hidden", 4)
+	pats := [][]float32{{1, 1, 0, 0}, {1, 1, 1, 0}, {0, 0, 1, 1}, {0, 1, 1, 1}}
+	cats := []string{"A", "A", "B", "B"}
+	dt.SetNumRows(len(pats))
+	for ri, pat := range pats {
+		dt.Column("Cat").SetString1D(cats[ri], ri)
+		for ci, v := range pat {
+			dt.Column("Hidden").SetFloatRow(float64(v), ri, ci)
+		}
+	}
+	assert.Equal(t, pats, ColumnPatterns(dt, "Hidden"))
+
+	sum := SummarizeOverlap(pats, cats, OverlapJaccard, 0.5)
+	assert.Equal(t, 2, sum.NWithin)
+	assert.Equal(t, 4, sum.NBetween)
+	assert.InDelta(t, 0.625, sum.Sparsity, 1.0e-9)
+	assert.InDelta(t, 2.0/3.0, sum.Within, 1.0e-9)
+	// between: {0,2}=0, {0,3}=1/4, {1,2}=1/4, {1,3}=2/4
+	assert.InDelta(t, 0.25, sum.Between, 1.0e-9)
+
+	ot := OverlapTable(dt, []string{"Hidden"}, "Cat", OverlapJaccard, 0.5)
+	assert.Equal(t, 1, ot.NumRows())
+	assert.Equal(t, "Hidden", ot.Column("Layer").String1D(0))
+	assert.InDelta(t, sum.Within, ot.Column("Within").Float1D(0), 1.0e-9)
+	assert.Equal(t, 4.0, ot.Column("NBetween").Float1D(0))
+}
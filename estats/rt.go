@@ -0,0 +1,215 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// RTStat is a [LayerStat] that measures the reaction time (RT) on each
+// trial, as the number of settling cycles until the maximum activity
+// of a unit in a layer (typically the output layer) first exceeds a
+// threshold, for Stroop-style and decision-making models that report RTs.
+// Call Cycle every cycle, which returns true when the threshold is
+// reached, so that settling can optionally be stopped at that point.
+// The trial stats are TrlRT (-1 if the threshold was not reached) and
+// TrlRTUnit (the index of the unit that reached it), and the epoch stat
+// is EpcRT (mean over trials that reached threshold), along with the
+// mean per condition as EpcRT_Condition, if Condition is set. The
+// distributions of RTs per condition are available from RTs and Table.
+type RTStat struct {
+
+	// Layer is the name of the layer.
+	Layer string
+
+	// Var is the unit variable, e.g., Act.
+	Var string
+
+	// Thr is the threshold on the maximum unit activity.
+	Thr float32
+
+	// Condition returns the condition for the current trial for
+	// given data parallel index, e.g., from the environment,
+	// for recording separate RT distributions per condition.
+	Condition func(di int) string
+
+	// RTs are the RTs for the trials that reached threshold
+	// in the current epoch, per condition.
+	RTs map[string][]float64 `display:"-"`
+
+	// Conditions are the conditions in the order first encountered.
+	Conditions []string `display:"-"`
+
+	rt    []int
+	unit  []int
+	vals  []float32
+	nmiss int
+}
+
+// NewRTStat returns a new [RTStat] for given layer and
+// threshold, using the Act variable.
+func NewRTStat(layer string, thr float32) *RTStat {
+	return &RTStat{Layer: layer, Var: "Act", Thr: thr}
+}
+
+// resetTrial resets the RT for given data parallel index.
+func (rs *RTStat) resetTrial(di int) {
+	if di >= len(rs.rt) {
+		rs.rt = slices.Grow(rs.rt, di+1-len(rs.rt))[:di+1]
+		rs.unit = slices.Grow(rs.unit, di+1-len(rs.unit))[:di+1]
+	}
+	rs.rt[di] = -1
+	rs.unit[di] = -1
+}
+
+// CycleValues records the RT at given cycle for given data parallel index,
+// if the maximum of given values first exceeds Thr on this trial,
+// returning true if the threshold has been reached.
+func (rs *RTStat) CycleValues(vals []float32, cycle, di int) bool {
+	if di >= len(rs.rt) {
+		rs.resetTrial(di)
+	}
+	if rs.rt[di] >= 0 {
+		return true
+	}
+	mx, mxi := float32(math.Inf(-1)), -1
+	for i, v := range vals {
+		if v > mx {
+			mx, mxi = v, i
+		}
+	}
+	if mxi < 0 || mx <= rs.Thr {
+		return false
+	}
+	rs.rt[di] = cycle
+	rs.unit[di] = mxi
+	return true
+}
+
+// Cycle records the RT at given cycle for given data parallel index,
+// if the maximum layer activity first exceeds Thr on this trial,
+// returning true if the threshold has been reached.
+func (rs *RTStat) Cycle(net emer.Network, cycle, di int) bool {
+	if !layerValues(net, rs.Layer, rs.Var, di, &rs.vals) {
+		return false
+	}
+	return rs.CycleValues(rs.vals, cycle, di)
+}
+
+func (rs *RTStat) Init(st *Stats) {
+	rs.RTs = make(map[string][]float64)
+	rs.Conditions = nil
+	rs.nmiss = 0
+	for di := range rs.rt {
+		rs.resetTrial(di)
+	}
+}
+
+func (rs *RTStat) TrialStats(st *Stats, net emer.Network, di int) {
+	if rs.RTs == nil {
+		rs.Init(st)
+	}
+	if di >= len(rs.rt) {
+		rs.resetTrial(di)
+	}
+	rt := rs.rt[di]
+	st.setTrialFloat(rs.Layer+"_TrlRT", di, float64(rt))
+	st.setTrialFloat(rs.Layer+"_TrlRTUnit", di, float64(rs.unit[di]))
+	rs.resetTrial(di)
+	if rt < 0 {
+		rs.nmiss++
+		return
+	}
+	cond := ""
+	if rs.Condition != nil {
+		cond = rs.Condition(di)
+	}
+	if _, has := rs.RTs[cond]; !has {
+		rs.Conditions = append(rs.Conditions, cond)
+	}
+	rs.RTs[cond] = append(rs.RTs[cond], float64(rt))
+}
+
+func (rs *RTStat) EpochStats(st *Stats) {
+	var sum float64
+	n := 0
+	for _, cond := range rs.Conditions {
+		rts := rs.RTs[cond]
+		mean, _ := meanSD(rts)
+		if cond != "" {
+			st.SetFloat(rs.Layer+"_EpcRT_"+cond, mean)
+		}
+		for _, rt := range rts {
+			sum += rt
+		}
+		n += len(rts)
+	}
+	if n > 0 {
+		sum /= float64(n)
+	}
+	st.SetFloat(rs.Layer+"_EpcRT", sum)
+	st.SetInt(rs.Layer+"_EpcRTMiss", rs.nmiss)
+}
+
+// meanSD returns the mean and standard deviation of given values.
+func meanSD(vals []float64) (mean, sd float64) {
+	n := float64(len(vals))
+	if n == 0 {
+		return
+	}
+	for _, v := range vals {
+		mean += v
+	}
+	mean /= n
+	for _, v := range vals {
+		sd += (v - mean) * (v - mean)
+	}
+	if n > 1 {
+		sd = math.Sqrt(sd / (n - 1))
+	} else {
+		sd = 0
+	}
+	return
+}
+
+// Table returns a table summarizing the RT distribution per condition
+// in the current epoch, with columns Condition, N, Mean, SD, Min,
+// Median, and Max, for logging or plotting.
+func (rs *RTStat) Table() *table.Table {
+	dt := table.New()
+	metadata.SetName(dt, fmt.Sprintf("%s RT", rs.Layer))
+	tensor.SetPrecision(dt, 4)
+	dt.AddStringColumn("Condition")
+	dt.AddIntColumn("N")
+	for _, nm := range []string{"Mean", "SD", "Min", "Median", "Max"} {
+		dt.AddFloat64Column(nm)
+	}
+	dt.SetNumRows(len(rs.Conditions))
+	for ri, cond := range rs.Conditions {
+		rts := slices.Clone(rs.RTs[cond])
+		slices.Sort(rts)
+		mean, sd := meanSD(rts)
+		n := len(rts)
+		med := rts[n/2]
+		if n%2 == 0 {
+			med = 0.5 * (rts[n/2-1] + rts[n/2])
+		}
+		dt.Column("Condition").SetStringRow(cond, ri, 0)
+		dt.Column("N").SetIntRow(n, ri, 0)
+		dt.Column("Mean").SetFloatRow(mean, ri, 0)
+		dt.Column("SD").SetFloatRow(sd, ri, 0)
+		dt.Column("Min").SetFloatRow(rts[0], ri, 0)
+		dt.Column("Median").SetFloatRow(med, ri, 0)
+		dt.Column("Max").SetFloatRow(rts[n-1], ri, 0)
+	}
+	return dt
+}
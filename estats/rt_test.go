@@ -0,0 +1,46 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRTStat(t *testing.T) {
+	st := &Stats{}
+	st.Init()
+	conds := []string{"Congruent", "Incongruent", "Congruent", "Incongruent", "Congruent"}
+	rts := []int{10, 20, 14, 30, -1}
+	rs := NewRTStat("Output", 0.5)
+	trl := 0
+	rs.Condition = func(di int) string { return conds[trl] }
+	rs.Init(st)
+	for ; trl < len(conds); trl++ {
+		for cyc := range 50 {
+			v := float32(0)
+			if rts[trl] >= 0 && cyc >= rts[trl] {
+				v = 0.8
+			}
+			done := rs.CycleValues([]float32{0.1, v}, cyc, 0)
+			assert.Equal(t, rts[trl] >= 0 && cyc >= rts[trl], done)
+		}
+		rs.TrialStats(st, nil, 0)
+		assert.Equal(t, float64(rts[trl]), st.Floats["Output_TrlRT"])
+	}
+	assert.Equal(t, -1.0, st.Floats["Output_TrlRTUnit_00"]) // last trial missed
+	rs.EpochStats(st)
+	assert.Equal(t, 18.5, st.Floats["Output_EpcRT"])
+	assert.Equal(t, 12.0, st.Floats["Output_EpcRT_Congruent"])
+	assert.Equal(t, 25.0, st.Floats["Output_EpcRT_Incongruent"])
+	assert.Equal(t, 1, st.Ints["Output_EpcRTMiss"])
+
+	dt := rs.Table()
+	assert.Equal(t, 2, dt.NumRows())
+	assert.Equal(t, "Congruent", dt.Column("Condition").StringRow(0, 0))
+	assert.Equal(t, 12.0, dt.Column("Median").FloatRow(0, 0))
+	assert.Equal(t, 30.0, dt.Column("Max").FloatRow(1, 0))
+}
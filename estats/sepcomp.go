@@ -0,0 +1,302 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"cogentcore.org/lab/plot"
+	"cogentcore.org/lab/table"
+)
+
+// SepComp computes the standard pattern separation and completion
+// analyses for hippocampal models, so that models report comparable
+// metrics, from tables of recorded trials with a column of input patterns
+// (e.g., the EC input or cue) and columns of layer activities (e.g., DG,
+// CA3, CA1):
+//   - Separation: for each pair of stored patterns, the overlap of the layer
+//     activities (output overlap) vs. the overlap of the inputs (input
+//     overlap), which is below the identity line for pattern separation.
+//   - Completion: for each recall trial with a degraded cue of a stored
+//     pattern, the overlap of the layer activities with those for the
+//     stored pattern vs. the overlap of the cue with the stored input,
+//     which is above the identity line for pattern completion.
+type SepComp struct {
+
+	// Input is the column of the input patterns, which are the full
+	// patterns for the stored trials, and the cues for the recall trials.
+	Input string
+
+	// Layers are the columns of the layer activities.
+	Layers []string
+
+	// Name is an optional column with the name of the pattern on each
+	// trial, which is used to match each recall trial to its stored
+	// pattern. If empty, they are matched by row.
+	Name string
+
+	// Measure is the overlap measure, which should be normalized
+	// (Cosine or Jaccard) for the completion index.
+	Measure Overlaps `default:"OverlapCosine"`
+
+	// Thr is the activity threshold for the Jaccard overlap.
+	Thr float32 `default:"0.5"`
+
+	// Bins is the number of bins of input overlap for the curves.
+	Bins int `default:"10"`
+}
+
+// NewSepComp returns a new [SepComp] for given input column and
+// layer columns, with default parameters.
+func NewSepComp(input string, layers ...string) *SepComp {
+	return &SepComp{Input: input, Layers: layers, Measure: OverlapCosine, Thr: 0.5, Bins: 10}
+}
+
+// curvePoint is an input overlap with the corresponding
+// output overlap for each layer.
+type curvePoint struct {
+	in  float64
+	out []float64
+}
+
+// separationPoints returns the input and output overlaps
+// for all pairs of stored patterns.
+func (sc *SepComp) separationPoints(stored *table.Table) []curvePoint {
+	ins := ColumnPatterns(stored, sc.Input)
+	outs := sc.layerPatterns(stored)
+	var pts []curvePoint
+	for i := range ins {
+		for j := i + 1; j < len(ins); j++ {
+			pt := curvePoint{in: Overlap(ins[i], ins[j], sc.Measure, sc.Thr), out: make([]float64, len(outs))}
+			for li, lp := range outs {
+				pt.out[li] = Overlap(lp[i], lp[j], sc.Measure, sc.Thr)
+			}
+			pts = append(pts, pt)
+		}
+	}
+	return pts
+}
+
+// completionPoints returns the cue overlap and the output overlap with
+// the stored pattern for each recall trial.
+func (sc *SepComp) completionPoints(stored, recall *table.Table) ([]curvePoint, error) {
+	sins := ColumnPatterns(stored, sc.Input)
+	souts := sc.layerPatterns(stored)
+	rins := ColumnPatterns(recall, sc.Input)
+	routs := sc.layerPatterns(recall)
+	rows := make(map[string]int)
+	if sc.Name != "" {
+		nc := stored.Column(sc.Name)
+		for ri := range stored.NumRows() {
+			rows[nc.String1D(ri)] = ri
+		}
+	}
+	pts := make([]curvePoint, 0, len(rins))
+	for ri := range rins {
+		si := ri
+		if sc.Name != "" {
+			nm := recall.Column(sc.Name).String1D(ri)
+			var ok bool
+			if si, ok = rows[nm]; !ok {
+				return nil, fmt.Errorf("estats.SepComp: recall pattern %q is not in the stored patterns", nm)
+			}
+		} else if si >= len(sins) {
+			return nil, fmt.Errorf("estats.SepComp: recall row %d is beyond the %d stored patterns", ri, len(sins))
+		}
+		pt := curvePoint{in: Overlap(rins[ri], sins[si], sc.Measure, sc.Thr), out: make([]float64, len(sc.Layers))}
+		for li := range sc.Layers {
+			pt.out[li] = Overlap(routs[li][ri], souts[li][si], sc.Measure, sc.Thr)
+		}
+		pts = append(pts, pt)
+	}
+	return pts, nil
+}
+
+// layerPatterns returns the patterns of each layer column.
+func (sc *SepComp) layerPatterns(dt *table.Table) [][][]float32 {
+	lps := make([][][]float32, len(sc.Layers))
+	for li, ly := range sc.Layers {
+		lps[li] = ColumnPatterns(dt, ly)
+	}
+	return lps
+}
+
+// curveTable returns the table of the mean output overlap of each layer
+// in each bin of input overlap, with given name of the input overlap
+// column, omitting empty bins.
+func (sc *SepComp) curveTable(pts []curvePoint, inName string) *table.Table {
+	dt := table.New()
+	xc := dt.AddFloat64Column(inName)
+	plot.SetStyle(xc, func(s *plot.Style) { s.Role = plot.X })
+	nc := dt.AddIntColumn("N")
+	plot.SetStyle(nc, func(s *plot.Style) { s.On = false })
+	ic := dt.AddFloat64Column("Identity")
+	plot.SetStyle(ic, func(s *plot.Style) {
+		s.On = true
+		s.Role = plot.Y
+		s.Line.Dashes = []float32{4, 4}
+	})
+	for _, ly := range sc.Layers {
+		lc := dt.AddFloat64Column(ly)
+		plot.SetStyle(lc, func(s *plot.Style) {
+			s.On = true
+			s.Role = plot.Y
+			s.Point.On = plot.On
+		})
+	}
+	if len(pts) == 0 {
+		return dt
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, pt := range pts {
+		lo = min(lo, pt.in)
+		hi = max(hi, pt.in)
+	}
+	nb := max(sc.Bins, 1)
+	type bin struct {
+		n   int
+		in  float64
+		out []float64
+	}
+	bins := make([]bin, nb)
+	for _, pt := range pts {
+		bi := 0
+		if hi > lo {
+			bi = min(int(float64(nb)*(pt.in-lo)/(hi-lo)), nb-1)
+		}
+		b := &bins[bi]
+		if b.out == nil {
+			b.out = make([]float64, len(sc.Layers))
+		}
+		b.n++
+		b.in += pt.in
+		for li, o := range pt.out {
+			b.out[li] += o
+		}
+	}
+	for _, b := range bins {
+		if b.n == 0 {
+			continue
+		}
+		row := dt.NumRows()
+		dt.SetNumRows(row + 1)
+		n := float64(b.n)
+		xc.SetFloat1D(b.in/n, row)
+		nc.SetFloat1D(n, row)
+		ic.SetFloat1D(b.in/n, row)
+		for li, ly := range sc.Layers {
+			dt.Column(ly).SetFloat1D(b.out[li]/n, row)
+		}
+	}
+	return dt
+}
+
+// SeparationTable returns the pattern separation curve for given table
+// of stored patterns: the mean output overlap of each layer (in a column
+// named for the layer) for each bin of InputOverlap over all pairs of
+// stored patterns, with the number of pairs N, and the Identity line.
+// Use [SepComp.Plot] to plot it.
+func (sc *SepComp) SeparationTable(stored *table.Table) *table.Table {
+	return sc.curveTable(sc.separationPoints(stored), "InputOverlap")
+}
+
+// CompletionTable returns the pattern completion curve for given tables
+// of stored patterns and recall trials with degraded cues: the mean overlap
+// of each layer with its activity for the stored pattern (in a column named
+// for the layer) for each bin of CueOverlap of the cue with the stored
+// input, with the number of trials N, and the Identity line.
+// Use [SepComp.Plot] to plot it.
+func (sc *SepComp) CompletionTable(stored, recall *table.Table) (*table.Table, error) {
+	pts, err := sc.completionPoints(stored, recall)
+	if err != nil {
+		return nil, err
+	}
+	return sc.curveTable(pts, "CueOverlap"), nil
+}
+
+// IndexTable returns the separation and completion indexes for each
+// layer, with columns Layer, Separation and Completion, for given tables
+// of stored patterns and recall trials (nil for no completion index,
+// which is then NaN):
+//   - Separation is the mean over all pairs of stored patterns of the
+//     input overlap minus the output overlap, which is positive for
+//     pattern separation.
+//   - Completion is the mean over recall trials of the output overlap
+//     minus the cue overlap, as a proportion of the overlap missing from
+//     the cue (1 - cue overlap): 1 is complete recovery of the stored
+//     pattern, 0 is no completion, and negative is further degradation.
+//     Trials with complete cues are not included.
+func (sc *SepComp) IndexTable(stored, recall *table.Table) (*table.Table, error) {
+	dt := table.New()
+	dt.AddStringColumn("Layer")
+	dt.AddFloat64Column("Separation")
+	dt.AddFloat64Column("Completion")
+	dt.SetNumRows(len(sc.Layers))
+	seps := make([]float64, len(sc.Layers))
+	spts := sc.separationPoints(stored)
+	for _, pt := range spts {
+		for li, o := range pt.out {
+			seps[li] += pt.in - o
+		}
+	}
+	comps := make([]float64, len(sc.Layers))
+	ncomp := 0
+	if recall != nil {
+		cpts, err := sc.completionPoints(stored, recall)
+		if err != nil {
+			return nil, err
+		}
+		for _, pt := range cpts {
+			if pt.in >= 1 {
+				continue
+			}
+			ncomp++
+			for li, o := range pt.out {
+				comps[li] += (o - pt.in) / (1 - pt.in)
+			}
+		}
+	}
+	for li, ly := range sc.Layers {
+		dt.Column("Layer").SetString1D(ly, li)
+		sep, comp := math.NaN(), math.NaN()
+		if len(spts) > 0 {
+			sep = seps[li] / float64(len(spts))
+		}
+		if ncomp > 0 {
+			comp = comps[li] / float64(ncomp)
+		}
+		dt.Column("Separation").SetFloat1D(sep, li)
+		dt.Column("Completion").SetFloat1D(comp, li)
+	}
+	return dt, nil
+}
+
+// Plot returns a plot of given separation or completion curve table,
+// with given title, which can be shown in a plotcore.Plot widget.
+// Use [SepComp.SavePlot] to save it as an image.
+func (sc *SepComp) Plot(curve *table.Table, title string) (*plot.Plot, error) {
+	pl, err := plot.NewTablePlot(curve)
+	if err != nil {
+		return nil, err
+	}
+	pl.Title.Text = title
+	pl.X.Label.Text = curve.Columns.Keys[0]
+	pl.Y.Label.Text = "Output overlap"
+	return pl, nil
+}
+
+// SavePlot saves a plot of given separation or completion curve table,
+// with given title, as an image file with given name and size in pixels.
+func (sc *SepComp) SavePlot(curve *table.Table, title, filename string, size image.Point) error {
+	pl, err := sc.Plot(curve, title)
+	if err != nil {
+		return err
+	}
+	pl.Resize(size)
+	pl.Draw()
+	return pl.SaveImage(filename)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package estats
+
+import (
+	"image"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cogentcore.org/lab/table"
+	"github.com/stretchr/testify/assert"
+)
+
+// sepCompTable returns a table of trials with given names, inputs,
+// and DG and CA3 activities.
+func sepCompTable(names []string, inputs, dg, ca3 [][]float32) *table.Table {
+	dt := table.New()
+	dt.AddStringColumn("Name")
+	dt.AddFloat32Column("Input", 4)
+	dt.AddFloat32Column("DG", 4)
+	dt.AddFloat32Column("CA3", 4)
+	dt.SetNumRows(len(names))
+	for ri, nm := range names {
+		dt.Column("Name").SetString1D(nm, ri)
+		for ci := range 4 {
+			dt.Column("Input").SetFloatRow(float64(inputs[ri][ci]), ri, ci)
+			dt.Column("DG").SetFloatRow(float64(dg[ri][ci]), ri, ci)
+			dt.Column("CA3").SetFloatRow(float64(ca3[ri][ci]), ri, ci)
+		}
+	}
+	return dt
+}
+
+func TestSepComp(t *testing.T) {
+	// overlapping inputs, with orthogonal DG (separation),
+	// and CA3 the same as the input.
+	inputs := [][]float32{{1, 1, 0, 0}, {0, 1, 1, 0}, {0, 0, 1, 1}}
+	dg := [][]float32{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}}
+	stored := sepCompTable([]string{"A", "B", "C"}, inputs, dg, inputs)
+
+	// degraded cues, with DG as degraded as the cue (no completion), and
+	// CA3 recovering the stored pattern (complete completion).
+	cues := [][]float32{{0, 1, 0, 0}, {1, 0, 0, 0}}
+	rdg := [][]float32{{0, 1, 1, 0}, {1, 0, 0, 1}}
+	recall := sepCompTable([]string{"B", "A"}, cues, rdg, [][]float32{inputs[1], inputs[0]})
+
+	sc := NewSepComp("Input", "DG", "CA3")
+	sc.Name = "Name"
+	st := sc.SeparationTable(stored)
+	// pairs: AB and BC have input overlap 0.5, AC 0
+	assert.Equal(t, 2, st.NumRows())
+	assert.Equal(t, 0.0, st.Column("InputOverlap").Float1D(0))
+	assert.Equal(t, 1.0, st.Column("N").Float1D(0))
+	assert.InDelta(t, 0.5, st.Column("InputOverlap").Float1D(1), 1.0e-6)
+	assert.Equal(t, 2.0, st.Column("N").Float1D(1))
+	assert.Equal(t, 0.0, st.Column("DG").Float1D(1))
+	assert.InDelta(t, 0.5, st.Column("CA3").Float1D(1), 1.0e-6)
+	assert.Equal(t, st.Column("InputOverlap").Float1D(1), st.Column("Identity").Float1D(1))
+
+	ct, err := sc.CompletionTable(stored, recall)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ct.NumRows())
+	assert.InDelta(t, 1/math.Sqrt(2), ct.Column("CueOverlap").Float1D(0), 1.0e-6)
+	assert.InDelta(t, 1.0, ct.Column("CA3").Float1D(0), 1.0e-6)
+
+	it, err := sc.IndexTable(stored, recall)
+	assert.NoError(t, err)
+	assert.Equal(t, "DG", it.Column("Layer").String1D(0))
+	assert.InDelta(t, 1.0/3.0, it.Column("Separation").Float1D(0), 1.0e-6)
+	assert.InDelta(t, 0, it.Column("Separation").Float1D(1), 1.0e-6)
+	assert.InDelta(t, 0, it.Column("Completion").Float1D(0), 1.0e-6)
+	assert.InDelta(t, 1, it.Column("Completion").Float1D(1), 1.0e-6)
+
+	it, err = sc.IndexTable(stored, nil)
+	assert.NoError(t, err)
+	assert.True(t, math.IsNaN(it.Column("Completion").Float1D(0)))
+
+	bad := sepCompTable([]string{"D"}, cues[:1], cues[:1], cues[:1])
+	_, err = sc.CompletionTable(stored, bad)
+	assert.Error(t, err)
+
+	fn := filepath.Join(t.TempDir(), "sep.png")
+	assert.NoError(t, sc.SavePlot(st, "Separation", fn, image.Point{320, 240}))
+	_, err = os.Stat(fn)
+	assert.NoError(t, err)
+}
@@ -12,6 +12,7 @@ import (
 	"cogentcore.org/core/base/timer"
 	"cogentcore.org/lab/plotcore"
 	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/confusion"
 	"github.com/emer/emergent/v2/decoder"
 )
 
@@ -32,7 +33,7 @@ type Stats struct {
 	IntTensors map[string]*tensor.Int
 
 	// confusion matrix
-	// Confusion confusion.Matrix `display:"no-inline"`
+	Confusion confusion.Matrix `display:"no-inline"`
 
 	// similarity matrix for comparing pattern similarities
 	SimMats map[string]*tensor.Float64
@@ -54,6 +55,10 @@ type Stats struct {
 
 	// named timers available for timing how long different computations take (wall-clock time)
 	Timers map[string]*timer.Time
+
+	// layer stats registered with AddLayerStat, computed by
+	// TrialLayerStats and EpochLayerStats
+	LayerStats []LayerStat `display:"-"`
 }
 
 // Init must be called before use to create all the maps
@@ -160,6 +165,19 @@ func (st *Stats) Float(name string) float64 {
 	return 0
 }
 
+// Value returns the Floats or Ints stat value of given name as a float64,
+// and false if not found, for looking up stats by name,
+// e.g., in a looper.Condition expression.
+func (st *Stats) Value(name string) (float64, bool) {
+	if val, has := st.Floats[name]; has {
+		return val, true
+	}
+	if val, has := st.Ints[name]; has {
+		return float64(val), true
+	}
+	return 0, false
+}
+
 // Float32 returns Floats stat value converted to float32.
 // prints error message and returns 0 if not found
 func (st *Stats) Float32(name string) float32 {
@@ -6,4 +6,27 @@ import (
 	"cogentcore.org/core/types"
 )
 
-var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.Stats", IDName: "stats", Doc: "Stats provides maps for storing statistics as named scalar and tensor values.\nThese stats are available in the elog.Context for use during logging.", Fields: []types.Field{{Name: "Floats"}, {Name: "Strings"}, {Name: "Ints"}, {Name: "F32Tensors", Doc: "float32 tensors used for grabbing values from layers"}, {Name: "F64Tensors", Doc: "float64 tensors as needed for other computations"}, {Name: "IntTensors", Doc: "int tensors as needed for other computations"}, {Name: "SimMats", Doc: "similarity matrix for comparing pattern similarities"}, {Name: "Plots", Doc: "analysis plots -- created by analysis routines"}, {Name: "Rasters", Doc: "list of layer names configured for recording raster plots"}, {Name: "LinDecoders", Doc: "linear decoders"}, {Name: "SoftMaxDecoders", Doc: "softmax decoders"}, {Name: "Timers", Doc: "named timers available for timing how long different computations take (wall-clock time)"}}})
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.BiasStat", IDName: "bias-stat", Doc: "BiasStat is a [LayerStat] that summarizes the bias weights of the units\nin a layer at the end of each epoch, for monitoring bias drift without\ncode-level inspection. The epoch stats are EpcBiasMean, EpcBiasSD,\nEpcBiasMin, EpcBiasMax, and EpcBiasDrift, which is the mean absolute\nchange in the bias weights since the previous epoch.\nThe bias weights are read on the trial with data parallel index 0,\nas they are shared across data parallel inputs.", Fields: []types.Field{{Name: "Layer", Doc: "Layer is the name of the layer."}, {Name: "Var", Doc: "Var is the unit variable for the bias weights."}, {Name: "vals"}, {Name: "prev"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.ClosestMatch", IDName: "closest-match", Doc: "ClosestMatch is the result of finding the closest pattern to a given\npattern (e.g., output layer activations) in a bank of patterns,\nas computed by [ClosestPattern]. This is the standard \"name error\"\nevaluation from C++ emergent.", Fields: []types.Field{{Name: "Row", Doc: "Row is the row of the closest pattern, -1 if there are no patterns."}, {Name: "Name", Doc: "Name is the name of the closest pattern, if a name column is given."}, {Name: "Similarity", Doc: "Similarity is the similarity (correlation or cosine)\nof the closest pattern."}, {Name: "Second", Doc: "Second is the similarity of the second-closest pattern,\n-Inf if there is only one pattern."}, {Name: "Margin", Doc: "Margin is the difference in similarity between the closest and\nsecond-closest patterns, which indicates how unambiguous the\nmatch is, and is 0 if there is only one pattern."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.ClosestPatStat", IDName: "closest-pat-stat", Doc: "ClosestPatStat is a [LayerStat] that finds the closest pattern to the\nlayer activations among the rows of a column of a table of patterns,\nusing [ClosestPattern], with trial stats TrlClosest (the name of the\nclosest pattern, in Strings), TrlSim (its similarity), TrlMargin (the\nmargin to the second-closest), and TrlClosestErr (1 if the name is not\nthe Expected one), and epoch stats EpcSim, EpcMargin and EpcClosestPctErr.", Fields: []types.Field{{Name: "Layer", Doc: "Layer is the name of the layer."}, {Name: "Var", Doc: "Var is the unit variable, e.g., ActM."}, {Name: "Patterns", Doc: "Patterns is the table of patterns."}, {Name: "Column", Doc: "Column is the name of the column with the patterns."}, {Name: "NameColumn", Doc: "NameColumn is the name of the column with the pattern names."}, {Name: "Cosine", Doc: "Cosine uses cosine instead of correlation as the similarity measure."}, {Name: "Expected", Doc: "Expected returns the name of the expected (correct) pattern\nfor given data parallel index, e.g., from the environment.\nIf nil, TrlClosestErr is not computed."}, {Name: "Confusion", Doc: "Confusion, if set, accumulates the confusion matrix of the expected\nvs. closest pattern names over each epoch, e.g., the Stats Confusion,\ninitialized with InitFromLabels from the pattern names."}, {Name: "acts"}, {Name: "sim"}, {Name: "margin"}, {Name: "err"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.LayerStat", IDName: "layer-stat", Doc: "LayerStat is a statistic computed on a layer (typically an output layer)\nat the end of each trial, and aggregated over the trials of an epoch,\nwhich can be registered with [Stats.AddLayerStat], so that simple models\nget the standard statistics without copying the TrialStats boilerplate,\nand custom models can plug in their own.\nStandard implementations are [SSEStat], [PctErrStat], [CosDiffStat],\nand [ClosestPatStat]. The values are stored in the Stats Floats\n(and Strings) maps, with names of the form Layer_TrlName for trial\nstats, and Layer_EpcName for epoch stats, e.g., Output_TrlSSE.\nTrial stats are also stored with the data parallel index\nappended (see [DiName]).", Methods: []types.Method{{Name: "Init", Doc: "Init initializes the epoch accumulators,\nat the start of each epoch.", Args: []string{"st"}}, {Name: "TrialStats", Doc: "TrialStats computes the trial stats for given data parallel index,\nstoring them in st, and accumulates them for the epoch.", Args: []string{"st", "net", "di"}}, {Name: "EpochStats", Doc: "EpochStats computes the epoch stats from the accumulated\ntrial stats, storing them in st.", Args: []string{"st"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.epochAvg", IDName: "epoch-avg", Doc: "epochAvg accumulates the average of a trial stat over an epoch.", Fields: []types.Field{{Name: "sum"}, {Name: "n"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.SSEStat", IDName: "sse-stat", Doc: "SSEStat is a [LayerStat] for the sum squared error (SSE) between\nthe actual and target values on a layer, with trial stats TrlSSE and\nTrlAvgSSE (average per unit), and epoch stats EpcSSE and EpcAvgSSE.", Fields: []types.Field{{Name: "Layer", Doc: "Layer is the name of the layer."}, {Name: "ActVar", Doc: "ActVar is the unit variable for the actual values, e.g., ActM."}, {Name: "TargVar", Doc: "TargVar is the unit variable for the target values, e.g., Targ."}, {Name: "Tol", Doc: "Tol is the tolerance: differences less than this are counted as 0."}, {Name: "acts"}, {Name: ""}, {Name: "sse"}, {Name: ""}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.PctErrStat", IDName: "pct-err-stat", Doc: "PctErrStat is a [LayerStat] for the proportion of trials with an error,\nwhere any unit differs from its target by more than Tol, with trial stat\nTrlErr (1 for an error, else 0), and epoch stats EpcPctErr and EpcPctCor.", Fields: []types.Field{{Name: "Layer", Doc: "Layer is the name of the layer."}, {Name: "ActVar", Doc: "ActVar is the unit variable for the actual values, e.g., ActM."}, {Name: "TargVar", Doc: "TargVar is the unit variable for the target values, e.g., Targ."}, {Name: "Tol", Doc: "Tol is the tolerance: differences less than this are not errors."}, {Name: "acts"}, {Name: ""}, {Name: "err"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.CosDiffStat", IDName: "cos-diff-stat", Doc: "CosDiffStat is a [LayerStat] for the cosine between the minus and plus\nphase activations on a layer, which measures how well the layer predicts\nits plus phase outcome, with trial stat TrlCosDiff and epoch stat EpcCosDiff.", Fields: []types.Field{{Name: "Layer", Doc: "Layer is the name of the layer."}, {Name: "MinusVar", Doc: "MinusVar is the unit variable for the minus phase, e.g., ActM."}, {Name: "PlusVar", Doc: "PlusVar is the unit variable for the plus phase, e.g., ActP."}, {Name: "minus"}, {Name: ""}, {Name: "cos"}}})
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.RTStat", IDName: "rt-stat", Doc: "RTStat is a [LayerStat] that measures the reaction time (RT) on each\ntrial, as the number of settling cycles until the maximum activity\nof a unit in a layer (typically the output layer) first exceeds a\nthreshold, for Stroop-style and decision-making models that report RTs.\nCall Cycle every cycle, which returns true when the threshold is\nreached, so that settling can optionally be stopped at that point.\nThe trial stats are TrlRT (-1 if the threshold was not reached) and\nTrlRTUnit (the index of the unit that reached it), and the epoch stat\nis EpcRT (mean over trials that reached threshold), along with the\nmean per condition as EpcRT_Condition, if Condition is set. The\ndistributions of RTs per condition are available from RTs and Table.", Fields: []types.Field{{Name: "Layer", Doc: "Layer is the name of the layer."}, {Name: "Var", Doc: "Var is the unit variable, e.g., Act."}, {Name: "Thr", Doc: "Thr is the threshold on the maximum unit activity."}, {Name: "Condition", Doc: "Condition returns the condition for the current trial for\ngiven data parallel index, e.g., from the environment,\nfor recording separate RT distributions per condition."}, {Name: "RTs", Doc: "RTs are the RTs for the trials that reached threshold\nin the current epoch, per condition."}, {Name: "Conditions", Doc: "Conditions are the conditions in the order first encountered."}, {Name: "rt"}, {Name: "unit"}, {Name: "vals"}, {Name: "nmiss"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.Stats", IDName: "stats", Doc: "Stats provides maps for storing statistics as named scalar and tensor values.\nThese stats are available in the elog.Context for use during logging.", Fields: []types.Field{{Name: "Floats"}, {Name: "Strings"}, {Name: "Ints"}, {Name: "F32Tensors", Doc: "float32 tensors used for grabbing values from layers"}, {Name: "F64Tensors", Doc: "float64 tensors as needed for other computations"}, {Name: "IntTensors", Doc: "int tensors as needed for other computations"}, {Name: "Confusion", Doc: "confusion matrix"}, {Name: "SimMats", Doc: "similarity matrix for comparing pattern similarities"}, {Name: "Plots", Doc: "analysis plots -- created by analysis routines"}, {Name: "Rasters", Doc: "list of layer names configured for recording raster plots"}, {Name: "LinDecoders", Doc: "linear decoders"}, {Name: "SoftMaxDecoders", Doc: "softmax decoders"}, {Name: "Timers", Doc: "named timers available for timing how long different computations take (wall-clock time)"}, {Name: "LayerStats", Doc: "layer stats registered with AddLayerStat, computed by\nTrialLayerStats and EpochLayerStats"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.Overlaps", IDName: "overlaps", Doc: "Overlaps are the measures of the overlap between two activity patterns,\nfor evaluating pattern separation and completion."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.OverlapSummary", IDName: "overlap-summary", Doc: "OverlapSummary summarizes the sparsity and overlap of the\nactivity patterns of a layer across recorded trials, with the mean\noverlap of pairs of patterns within and between categories (conditions),\nas computed by [SummarizeOverlap].", Fields: []types.Field{{Name: "Sparsity", Doc: "Sparsity is the mean [Sparsity] of the patterns."}, {Name: "Within", Doc: "Within is the mean overlap of the pairs of different\npatterns in the same category."}, {Name: "Between", Doc: "Between is the mean overlap of the pairs of patterns\nin different categories."}, {Name: "NWithin", Doc: "NWithin is the number of pairs within categories."}, {Name: "NBetween", Doc: "NBetween is the number of pairs between categories."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/estats.SepComp", IDName: "sep-comp", Doc: "SepComp computes the standard pattern separation and completion\nanalyses for hippocampal models, so that models report comparable\nmetrics, from tables of recorded trials with a column of input patterns\n(e.g., the EC input or cue) and columns of layer activities (e.g., DG,\nCA3, CA1):\n  - Separation: for each pair of stored patterns, the overlap of the layer\n    activities (output overlap) vs. the overlap of the inputs (input\n    overlap), which is below the identity line for pattern separation.\n  - Completion: for each recall trial with a degraded cue of a stored\n    pattern, the overlap of the layer activities with those for the\n    stored pattern vs. the overlap of the cue with the stored input,\n    which is above the identity line for pattern completion.", Fields: []types.Field{{Name: "Input", Doc: "Input is the column of the input patterns, which are the full\npatterns for the stored trials, and the cues for the recall trials."}, {Name: "Layers", Doc: "Layers are the columns of the layer activities."}, {Name: "Name", Doc: "Name is an optional column with the name of the pattern on each\ntrial, which is used to match each recall trial to its stored\npattern. If empty, they are matched by row."}, {Name: "Measure", Doc: "Measure is the overlap measure, which should be normalized\n(Cosine or Jaccard) for the completion index."}, {Name: "Thr", Doc: "Thr is the activity threshold for the Jaccard overlap."}, {Name: "Bins", Doc: "Bins is the number of bins of input overlap for the curves."}}})
@@ -0,0 +1,359 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"cogentcore.org/core/base/fsx"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/tablejson"
+	"github.com/emer/emergent/v2/tablestream"
+)
+
+// Cat concatenates the input files into the output.
+// This is the default command.
+//
+//cli:cmd -root
+func Cat(c *Config) error { //types:add
+	dt, err := readFiles(c)
+	if err != nil {
+		return err
+	}
+	return writeTable(c, dt)
+}
+
+// Filter outputs the rows of the input files matching the Where expression.
+func Filter(c *Config) error { //types:add
+	dt, err := readFiles(c)
+	if err != nil {
+		return err
+	}
+	if err := filterTable(dt, c.Where); err != nil {
+		return err
+	}
+	return writeTable(c, dt)
+}
+
+// Group outputs the Stat aggregate of the Values columns for each unique
+// combination of values in the Columns.
+func Group(c *Config) error { //types:add
+	if c.Chunk > 0 {
+		return streamGroup(c)
+	}
+	dt, err := readFiles(c)
+	if err != nil {
+		return err
+	}
+	gt, err := groupTable(dt, c.Columns, c.Values, c)
+	if err != nil {
+		return err
+	}
+	return writeTable(c, gt)
+}
+
+// Pivot outputs a table with one row for each unique value of the
+// first of the Columns, and a column for each unique value of the second
+// of the Columns, containing the Stat aggregate of the first of the Values.
+func Pivot(c *Config) error { //types:add
+	dt, err := readFiles(c)
+	if err != nil {
+		return err
+	}
+	pt, err := pivotTable(dt, c)
+	if err != nil {
+		return err
+	}
+	return writeTable(c, pt)
+}
+
+// readFiles reads and concatenates the input files.
+func readFiles(c *Config) (*table.Table, error) {
+	if len(c.Files) == 0 {
+		return nil, errors.New("etcat: no input files specified")
+	}
+	var dt *table.Table
+	for _, fn := range c.Files {
+		ft, err := readFile(fn)
+		if err != nil {
+			return nil, fmt.Errorf("etcat: reading %q: %w", fn, err)
+		}
+		if dt == nil {
+			dt = ft
+			continue
+		}
+		dt.AppendRows(ft)
+	}
+	return dt, nil
+}
+
+// readFile reads one file, in typed JSON format if it ends in .json.
+func readFile(fn string) (*table.Table, error) {
+	if isJSON(fn) {
+		return tablejson.Open(fn)
+	}
+	dt := table.New(filepath.Base(fn))
+	err := dt.OpenCSV(fsx.Filename(fn), fileDelim(fn))
+	return dt, err
+}
+
+// fileDelim returns the delimiter for given file name:
+// comma if it ends in .csv, and tab otherwise.
+func fileDelim(fn string) tensor.Delims {
+	if strings.HasSuffix(strings.ToLower(fn), ".csv") {
+		return tensor.Comma
+	}
+	return tensor.Tab
+}
+
+func isJSON(fn string) bool {
+	return strings.HasSuffix(strings.ToLower(fn), ".json")
+}
+
+// writeTable writes the table to the Output, or standard output.
+func writeTable(c *Config, dt *table.Table) error {
+	if c.Output == "" {
+		return dt.WriteCSV(os.Stdout, tensor.Tab, table.Headers)
+	}
+	if isJSON(c.Output) {
+		return tablejson.Save(dt, c.Output)
+	}
+	return dt.SaveCSV(fsx.Filename(c.Output), fileDelim(c.Output), table.Headers)
+}
+
+var whereRegexp = regexp.MustCompile(`^\s*([^\s=!<>~]+)\s*(==|!=|<=|>=|<|>|~)\s*(.*?)\s*$`)
+
+// filterTable filters the table according to the where expression.
+func filterTable(dt *table.Table, where string) error {
+	m := whereRegexp.FindStringSubmatch(where)
+	if m == nil {
+		return fmt.Errorf("etcat: invalid where expression: %q", where)
+	}
+	cnm, op, val := m[1], m[2], m[3]
+	col, err := dt.ColumnTry(cnm)
+	if err != nil {
+		return err
+	}
+	if op == "~" || col.IsString() {
+		dt.Filter(func(dt *table.Table, row int) bool {
+			return compare(strings.Compare(col.StringRow(row, 0), val), op, col.StringRow(row, 0), val)
+		})
+		return nil
+	}
+	fv, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return fmt.Errorf("etcat: value %q for numeric column %q: %w", val, cnm, err)
+	}
+	dt.Filter(func(dt *table.Table, row int) bool {
+		v := col.FloatRow(row, 0)
+		cmp := 0
+		switch {
+		case v < fv:
+			cmp = -1
+		case v > fv:
+			cmp = 1
+		}
+		return compare(cmp, op, "", "")
+	})
+	return nil
+}
+
+// compare returns the result of given comparison operator,
+// given the comparison result, and strings for the contains operator.
+func compare(cmp int, op string, s, sub string) bool {
+	switch op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "~":
+		return strings.Contains(s, sub)
+	}
+	return false
+}
+
+// groups returns the row indexes for each unique combination of values
+// of the given columns, in order of first appearance, along with the
+// index of the first row of each group, and the group of each row.
+func groups(dt *table.Table, cols []*tensor.Rows) (idxs [][]int, first, rowGp []int) {
+	gmap := make(map[string]int)
+	for ri := range dt.NumRows() {
+		var key strings.Builder
+		for _, col := range cols {
+			key.WriteString(col.StringRow(ri, 0))
+			key.WriteByte(0)
+		}
+		k := key.String()
+		gi, ok := gmap[k]
+		if !ok {
+			gi = len(idxs)
+			gmap[k] = gi
+			idxs = append(idxs, nil)
+			first = append(first, ri)
+		}
+		idxs[gi] = append(idxs[gi], dt.RowIndex(ri))
+		rowGp = append(rowGp, gi)
+	}
+	return
+}
+
+// aggregate returns the Stat aggregate of given column over given row indexes.
+func aggregate(c *Config, col *tensor.Rows, idxs []int) float64 {
+	return c.Stat.Call(tensor.NewRows(col.Tensor, idxs...)).Float1D(0)
+}
+
+// groupTable returns a table with the Stat aggregate of the given value
+// columns for each unique combination of the given group columns.
+func groupTable(dt *table.Table, gcols, vcols []string, c *Config) (*table.Table, error) {
+	if len(gcols) == 0 {
+		return nil, errors.New("etcat: group requires Columns to group by")
+	}
+	if len(vcols) == 0 {
+		for i, nm := range dt.Columns.Keys {
+			if !dt.Columns.Values[i].IsString() && !slices.Contains(gcols, nm) {
+				vcols = append(vcols, nm)
+			}
+		}
+	}
+	gcs := make([]*tensor.Rows, len(gcols))
+	for i, nm := range gcols {
+		col, err := dt.ColumnTry(nm)
+		if err != nil {
+			return nil, err
+		}
+		gcs[i] = col
+	}
+	vcs := make([]*tensor.Rows, len(vcols))
+	for i, nm := range vcols {
+		col, err := dt.ColumnTry(nm)
+		if err != nil {
+			return nil, err
+		}
+		vcs[i] = col
+	}
+	idxs, first, _ := groups(dt, gcs)
+	gt := table.New()
+	for i, nm := range gcols {
+		if gcs[i].IsString() {
+			gt.AddStringColumn(nm)
+		} else {
+			gt.AddFloat64Column(nm)
+		}
+	}
+	for _, nm := range vcols {
+		gt.AddFloat64Column(nm)
+	}
+	gt.SetNumRows(len(idxs))
+	for gi, ix := range idxs {
+		for i, col := range gcs {
+			gc := gt.ColumnByIndex(i)
+			if col.IsString() {
+				gc.SetStringRow(col.StringRow(first[gi], 0), gi, 0)
+			} else {
+				gc.SetFloatRow(col.FloatRow(first[gi], 0), gi, 0)
+			}
+		}
+		for i, col := range vcs {
+			gt.ColumnByIndex(len(gcs)+i).SetFloatRow(aggregate(c, col, ix), gi, 0)
+		}
+	}
+	return gt, nil
+}
+
+// streamGroup does the Group command reading Chunk rows at a time,
+// for files that are too large to load into memory.
+func streamGroup(c *Config) error {
+	if len(c.Files) == 0 {
+		return errors.New("etcat: no input files specified")
+	}
+	if len(c.Columns) == 0 {
+		return errors.New("etcat: group requires Columns to group by")
+	}
+	ga, err := tablestream.NewGroupAgg(c.Columns, c.Values, c.Stat)
+	if err != nil {
+		return err
+	}
+	for _, fn := range c.Files {
+		rd, err := tablestream.Open(fn, c.Chunk)
+		if err != nil {
+			return fmt.Errorf("etcat: reading %q: %w", fn, err)
+		}
+		for {
+			dt, err := rd.Next()
+			if err == io.EOF {
+				break
+			}
+			if err == nil {
+				err = ga.Add(dt)
+			}
+			if err != nil {
+				rd.Close()
+				return fmt.Errorf("etcat: reading %q: %w", fn, err)
+			}
+		}
+		rd.Close()
+	}
+	return writeTable(c, ga.Table())
+}
+
+// pivotTable returns the pivot table for Columns[0] rows by Columns[1]
+// columns, with the Stat aggregate of Values[0].
+func pivotTable(dt *table.Table, c *Config) (*table.Table, error) {
+	if len(c.Columns) != 2 || len(c.Values) != 1 {
+		return nil, errors.New("etcat: pivot requires two Columns (row and column keys) and one Values column")
+	}
+	rcol, err := dt.ColumnTry(c.Columns[0])
+	if err != nil {
+		return nil, err
+	}
+	ccol, err := dt.ColumnTry(c.Columns[1])
+	if err != nil {
+		return nil, err
+	}
+	vcol, err := dt.ColumnTry(c.Values[0])
+	if err != nil {
+		return nil, err
+	}
+	ridxs, rfirst, rgp := groups(dt, []*tensor.Rows{rcol})
+	_, cfirst, cgp := groups(dt, []*tensor.Rows{ccol})
+	nc := len(cfirst)
+	cells := make([][]int, len(ridxs)*nc)
+	for ri := range dt.NumRows() {
+		ci := rgp[ri]*nc + cgp[ri]
+		cells[ci] = append(cells[ci], dt.RowIndex(ri))
+	}
+	pt := table.New()
+	pt.AddStringColumn(c.Columns[0])
+	for _, cr := range cfirst {
+		pt.AddFloat64Column(ccol.StringRow(cr, 0))
+	}
+	pt.SetNumRows(len(ridxs))
+	for ri := range ridxs {
+		pt.ColumnByIndex(0).SetStringRow(rcol.StringRow(rfirst[ri], 0), ri, 0)
+		for ci := range nc {
+			if ix := cells[ri*nc+ci]; len(ix) > 0 {
+				pt.ColumnByIndex(1+ci).SetFloatRow(aggregate(c, vcol, ix), ri, 0)
+			}
+		}
+	}
+	return pt, nil
+}
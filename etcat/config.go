@@ -0,0 +1,42 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import "cogentcore.org/lab/stats/stats"
+
+type Config struct { //types:add
+
+	// Files are the input TSV / CSV files (or typed JSON files ending
+	// in .json), which are concatenated in order. Columns present in
+	// the first file are retained.
+	Files []string `posarg:"all"`
+
+	// Output is the output file. The delimiter is a comma if it ends
+	// in .csv, and a tab otherwise, and typed JSON is written if it ends
+	// in .json. If empty, tab-separated values are written to standard output.
+	Output string `flag:"o,output"`
+
+	// Where is the filter expression for the filter command, of the form
+	// Column op Value, where op is one of: == != < <= > >= and ~ (contains).
+	// Numeric columns are compared as numbers, and others as strings.
+	Where string `flag:"w,where"`
+
+	// Columns are the columns to group by for the group command, or the
+	// row and column key columns for the pivot command, as a comma-separated list.
+	Columns []string `flag:"c,columns"`
+
+	// Values are the value columns to aggregate for the group and pivot
+	// commands. For group, if empty, all numeric columns not in Columns
+	// are used.
+	Values []string
+
+	// Stat is the aggregation statistic for the group and pivot commands.
+	Stat stats.Stats `default:"Mean"`
+
+	// Chunk, if > 0, is the number of rows to process at a time for the
+	// group command, for files that are too large to load into memory.
+	// Only TSV / CSV files are supported, and not all stats (e.g., Median).
+	Chunk int
+}
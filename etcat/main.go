@@ -0,0 +1,18 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command etcat concatenates, filters, groups, and pivots emergent
+// TSV / CSV log files, preserving the typed-header format, for quick
+// post-processing of data (e.g., on clusters) without Python.
+package main
+
+import "cogentcore.org/core/cli"
+
+//go:generate core generate
+
+func main() {
+	opts := cli.DefaultOptions("etcat", "etcat concatenates, filters, groups, and pivots emergent TSV / CSV log files, preserving the typed-header format.")
+	opts.PrintSuccess = false // output goes to stdout by default
+	cli.Run(opts, &Config{}, Cat, Filter, Group, Pivot)
+}
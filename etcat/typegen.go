@@ -0,0 +1,17 @@
+// Code generated by "core generate"; DO NOT EDIT.
+
+package main
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "main.Config", IDName: "config", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Fields: []types.Field{{Name: "Files", Doc: "Files are the input TSV / CSV files (or typed JSON files ending\nin .json), which are concatenated in order. Columns present in\nthe first file are retained."}, {Name: "Output", Doc: "Output is the output file. The delimiter is a comma if it ends\nin .csv, and a tab otherwise, and typed JSON is written if it ends\nin .json. If empty, tab-separated values are written to standard output."}, {Name: "Where", Doc: "Where is the filter expression for the filter command, of the form\nColumn op Value, where op is one of: == != < <= > >= and ~ (contains).\nNumeric columns are compared as numbers, and others as strings."}, {Name: "Columns", Doc: "Columns are the columns to group by for the group command, or the\nrow and column key columns for the pivot command, as a comma-separated list."}, {Name: "Values", Doc: "Values are the value columns to aggregate for the group and pivot\ncommands. For group, if empty, all numeric columns not in Columns\nare used."}, {Name: "Stat", Doc: "Stat is the aggregation statistic for the group and pivot commands."}, {Name: "Chunk", Doc: "Chunk, if > 0, is the number of rows to process at a time for the\ngroup command, for files that are too large to load into memory.\nOnly TSV / CSV files are supported, and not all stats (e.g., Median)."}}})
+
+var _ = types.AddFunc(&types.Func{Name: "main.Cat", Doc: "Cat concatenates the input files into the output.\nThis is the default command.", Directives: []types.Directive{{Tool: "cli", Directive: "cmd", Args: []string{"-root"}}, {Tool: "types", Directive: "add"}}, Args: []string{"c"}, Returns: []string{"error"}})
+
+var _ = types.AddFunc(&types.Func{Name: "main.Filter", Doc: "Filter outputs the rows of the input files matching the Where expression.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"c"}, Returns: []string{"error"}})
+
+var _ = types.AddFunc(&types.Func{Name: "main.Group", Doc: "Group outputs the Stat aggregate of the Values columns for each unique\ncombination of values in the Columns.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"c"}, Returns: []string{"error"}})
+
+var _ = types.AddFunc(&types.Func{Name: "main.Pivot", Doc: "Pivot outputs a table with one row for each unique value of the\nfirst of the Columns, and a column for each unique value of the second\nof the Columns, containing the Stat aggregate of the first of the Values.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"c"}, Returns: []string{"error"}})
@@ -0,0 +1,21 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package etensor provides tensor utility functions for input processing
+pipelines, such as padding, cropping, resampling, and tiling of images
+and other spatially organized input patterns, on 2D and 4D tensors.
+
+For 2D tensors, the dimensions are [Y][X]. For 4D tensors, the outer two
+dimensions are the spatial [Y][X] dimensions, and the inner two dimensions
+are features at each spatial location (e.g., [Y][X][Angle][Polarity] for
+V1 filter outputs, or [PoolY][PoolX][UnitY][UnitX] for a 4D layer),
+which are processed together as a unit.
+
+The functions write to an output tensor of any type, which is reshaped
+as needed, so it can be reused across calls.
+*/
+package etensor
+
+//go:generate core generate -add-types
@@ -0,0 +1,50 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package etensor
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _InterpsValues = []Interps{0, 1}
+
+// InterpsN is the highest valid value for type Interps, plus one.
+const InterpsN Interps = 2
+
+var _InterpsValueMap = map[string]Interps{`Nearest`: 0, `Bilinear`: 1}
+
+var _InterpsDescMap = map[Interps]string{0: `Nearest uses the value at the nearest input location.`, 1: `Bilinear linearly interpolates between the four nearest input locations.`}
+
+var _InterpsMap = map[Interps]string{0: `Nearest`, 1: `Bilinear`}
+
+// String returns the string representation of this Interps value.
+func (i Interps) String() string { return enums.String(i, _InterpsMap) }
+
+// SetString sets the Interps value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Interps) SetString(s string) error {
+	return enums.SetString(i, s, _InterpsValueMap, "Interps")
+}
+
+// Int64 returns the Interps value as an int64.
+func (i Interps) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Interps value from an int64.
+func (i *Interps) SetInt64(in int64) { *i = Interps(in) }
+
+// Desc returns the description of the Interps value.
+func (i Interps) Desc() string { return enums.Desc(i, _InterpsDescMap) }
+
+// InterpsValues returns all possible values for the type Interps.
+func InterpsValues() []Interps { return _InterpsValues }
+
+// Values returns all possible values for the type Interps.
+func (i Interps) Values() []enums.Enum { return enums.Values(_InterpsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Interps) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Interps) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "Interps")
+}
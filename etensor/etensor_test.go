@@ -0,0 +1,91 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etensor
+
+import (
+	"testing"
+
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/tensor"
+	"github.com/stretchr/testify/assert"
+)
+
+// ramp returns a 2D tensor with values y*10 + x.
+func ramp(ny, nx int) *tensor.Float32 {
+	tsr := tensor.NewFloat32(ny, nx)
+	for y := range ny {
+		for x := range nx {
+			tsr.Set(float32(y*10+x), y, x)
+		}
+	}
+	return tsr
+}
+
+func TestPadCrop(t *testing.T) {
+	in := ramp(2, 3)
+	out := tensor.NewFloat32()
+	assert.NoError(t, Pad(in, out, 4, 5, -1))
+	assert.Equal(t, []int{4, 5}, out.ShapeSizes())
+	assert.Equal(t, float32(-1), out.Value(0, 0))
+	assert.Equal(t, float32(0), out.Value(1, 1))
+	assert.Equal(t, float32(12), out.Value(2, 3))
+	assert.Equal(t, float32(-1), out.Value(3, 4))
+	assert.Error(t, Pad(in, out, 1, 5, 0))
+
+	cr := tensor.NewFloat64()
+	assert.NoError(t, CropCenter(out, cr, 2, 3))
+	assert.Equal(t, in.Values, tensor.AsFloat32(cr).Values)
+	assert.Error(t, Crop(in, cr, 1, 1, 2, 2))
+
+	big := ramp(6, 6)
+	y, x, err := CropRandom(big, cr, 3, 2, randx.NewSysRand(1))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 2}, cr.ShapeSizes())
+	assert.Equal(t, float64(y*10+x), cr.Value(0, 0))
+	assert.Equal(t, float64((y+2)*10+x+1), cr.Value(2, 1))
+
+	in4 := tensor.NewFloat32(2, 2, 1, 2)
+	for i := range in4.Len() {
+		in4.SetFloat1D(float64(i+1), i)
+	}
+	out4 := tensor.NewFloat32()
+	assert.NoError(t, Pad(in4, out4, 4, 4, 0))
+	assert.Equal(t, []int{4, 4, 1, 2}, out4.ShapeSizes())
+	assert.Equal(t, float32(1), out4.Value(1, 1, 0, 0))
+	assert.Equal(t, float32(8), out4.Value(2, 2, 0, 1))
+	assert.Error(t, Pad(tensor.NewFloat32(3), out, 4, 4, 0))
+}
+
+func TestResample(t *testing.T) {
+	in := ramp(2, 2)
+	out := tensor.NewFloat32()
+	assert.NoError(t, Resample(in, out, 4, 4, Nearest))
+	assert.Equal(t, float32(0), out.Value(0, 0))
+	assert.Equal(t, float32(11), out.Value(3, 3))
+
+	assert.NoError(t, Resample(in, out, 4, 4, Bilinear))
+	assert.Equal(t, float32(0), out.Value(0, 0))
+	assert.Equal(t, float32(0.25), out.Value(0, 1))
+	assert.Equal(t, float32(2.5), out.Value(1, 0))
+	assert.Equal(t, float32(11), out.Value(3, 3))
+
+	// downsampling averages neighboring pairs
+	assert.NoError(t, Resample(ramp(4, 4), out, 2, 2, Bilinear))
+	assert.Equal(t, float32(5.5), out.Value(0, 0))
+	assert.Equal(t, float32(27.5), out.Value(1, 1))
+}
+
+func TestTile(t *testing.T) {
+	out := tensor.NewFloat32()
+	assert.NoError(t, Tile(ramp(4, 6), out, 2, 2, 2, 2))
+	assert.Equal(t, []int{2, 3, 2, 2}, out.ShapeSizes())
+	assert.Equal(t, float32(24), out.Value(1, 2, 0, 0))
+	assert.Equal(t, float32(35), out.Value(1, 2, 1, 1))
+
+	assert.NoError(t, Tile(ramp(4, 6), out, 3, 3, 1, 3)) // overlapping in Y
+	assert.Equal(t, []int{2, 2, 3, 3}, out.ShapeSizes())
+	assert.Equal(t, float32(13), out.Value(1, 1, 0, 0))
+	assert.Error(t, Tile(ramp(2, 2), out, 3, 3, 1, 1))
+}
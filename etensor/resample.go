@@ -0,0 +1,75 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etensor
+
+import (
+	"math"
+
+	"cogentcore.org/lab/tensor"
+)
+
+// Interps are the interpolation methods for [Resample].
+type Interps int32 //enums:enum
+
+const (
+	// Nearest uses the value at the nearest input location.
+	Nearest Interps = iota
+
+	// Bilinear linearly interpolates between the four nearest
+	// input locations.
+	Bilinear
+)
+
+// Resample resamples the spatial dimensions of in to size ny, nx in out,
+// using given interpolation method, where the centers of the input
+// and output locations are aligned (i.e., not the corners).
+func Resample(in tensor.Tensor, out tensor.Values, ny, nx int, interp Interps) error {
+	iy, ix, inner, err := spatial("Resample", in)
+	if err != nil {
+		return err
+	}
+	setSpatialShape(out, ny, nx, inner)
+	cs := cellSize(inner)
+	scy := float64(iy) / float64(ny)
+	scx := float64(ix) / float64(nx)
+	for y := range ny {
+		fy := (float64(y)+0.5)*scy - 0.5
+		for x := range nx {
+			fx := (float64(x)+0.5)*scx - 0.5
+			oi := (y*nx + x) * cs
+			if interp == Nearest {
+				sy := min(int(math.Round(fy)), iy-1)
+				sx := min(int(math.Round(fx)), ix-1)
+				ii := (max(sy, 0)*ix + max(sx, 0)) * cs
+				for c := range cs {
+					out.SetFloat1D(in.Float1D(ii+c), oi+c)
+				}
+				continue
+			}
+			y0, y1, wy := interpIndexes(fy, iy)
+			x0, x1, wx := interpIndexes(fx, ix)
+			for c := range cs {
+				v00 := in.Float1D((y0*ix+x0)*cs + c)
+				v01 := in.Float1D((y0*ix+x1)*cs + c)
+				v10 := in.Float1D((y1*ix+x0)*cs + c)
+				v11 := in.Float1D((y1*ix+x1)*cs + c)
+				v0 := v00 + wx*(v01-v00)
+				v1 := v10 + wx*(v11-v10)
+				out.SetFloat1D(v0+wy*(v1-v0), oi+c)
+			}
+		}
+	}
+	return nil
+}
+
+// interpIndexes returns the two input indexes bracketing given fractional
+// position, clamped to the range of given size, and the weight of the second.
+func interpIndexes(f float64, n int) (i0, i1 int, w float64) {
+	f = min(max(f, 0), float64(n-1))
+	i0 = int(math.Floor(f))
+	i1 = min(i0+1, n-1)
+	w = f - float64(i0)
+	return
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etensor
+
+import (
+	"fmt"
+	"math/rand"
+
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/tensor"
+)
+
+// spatial returns the spatial Y, X sizes of given 2D or 4D tensor,
+// and the inner feature sizes (nil for 2D), with an error for other shapes.
+func spatial(fn string, tsr tensor.Tensor) (ny, nx int, inner []int, err error) {
+	sz := tsr.ShapeSizes()
+	switch len(sz) {
+	case 2:
+		return sz[0], sz[1], nil, nil
+	case 4:
+		return sz[0], sz[1], sz[2:], nil
+	}
+	return 0, 0, nil, fmt.Errorf("etensor.%s: tensor must be 2D or 4D, not %dD", fn, len(sz))
+}
+
+// cellSize returns the number of values per spatial location.
+func cellSize(inner []int) int {
+	n := 1
+	for _, s := range inner {
+		n *= s
+	}
+	return n
+}
+
+// setSpatialShape sets the shape of out to given spatial sizes
+// plus the inner feature sizes.
+func setSpatialShape(out tensor.Values, ny, nx int, inner []int) {
+	out.SetShapeSizes(append([]int{ny, nx}, inner...)...)
+}
+
+// Place copies in to out with the in [0,0] location at given Y, X offset
+// in out, which can be negative, setting all out locations that do not
+// correspond to an in location to the fill value. The out tensor must
+// already have the same inner feature sizes as in. This is the general
+// function used for padding and cropping.
+func Place(in tensor.Tensor, out tensor.Values, offY, offX int, fill float64) error {
+	iy, ix, inner, err := spatial("Place", in)
+	if err != nil {
+		return err
+	}
+	oy, ox, oinner, err := spatial("Place", out)
+	if err != nil {
+		return err
+	}
+	cs := cellSize(inner)
+	if cellSize(oinner) != cs {
+		return fmt.Errorf("etensor.Place: in and out inner sizes differ: %v != %v", inner, oinner)
+	}
+	for y := range oy {
+		sy := y - offY
+		for x := range ox {
+			sx := x - offX
+			oi := (y*ox + x) * cs
+			if sy < 0 || sy >= iy || sx < 0 || sx >= ix {
+				for c := range cs {
+					out.SetFloat1D(fill, oi+c)
+				}
+				continue
+			}
+			ii := (sy*ix + sx) * cs
+			for c := range cs {
+				out.SetFloat1D(in.Float1D(ii+c), oi+c)
+			}
+		}
+	}
+	return nil
+}
+
+// Pad pads in to the larger target spatial size ny, nx in out, with in
+// centered and the padding set to the fill value.
+func Pad(in tensor.Tensor, out tensor.Values, ny, nx int, fill float64) error {
+	iy, ix, inner, err := spatial("Pad", in)
+	if err != nil {
+		return err
+	}
+	if ny < iy || nx < ix {
+		return fmt.Errorf("etensor.Pad: target size %d x %d is smaller than input size %d x %d", ny, nx, iy, ix)
+	}
+	setSpatialShape(out, ny, nx, inner)
+	return Place(in, out, (ny-iy)/2, (nx-ix)/2, fill)
+}
+
+// Crop crops the region of in starting at given Y, X position, of
+// spatial size ny, nx, into out.
+func Crop(in tensor.Tensor, out tensor.Values, y, x, ny, nx int) error {
+	iy, ix, inner, err := spatial("Crop", in)
+	if err != nil {
+		return err
+	}
+	if y < 0 || x < 0 || y+ny > iy || x+nx > ix {
+		return fmt.Errorf("etensor.Crop: region %d x %d at %d, %d is out of range for input size %d x %d", ny, nx, y, x, iy, ix)
+	}
+	setSpatialShape(out, ny, nx, inner)
+	return Place(in, out, -y, -x, 0)
+}
+
+// CropCenter crops the central region of in of spatial size ny, nx, into out.
+func CropCenter(in tensor.Tensor, out tensor.Values, ny, nx int) error {
+	iy, ix, _, err := spatial("CropCenter", in)
+	if err != nil {
+		return err
+	}
+	return Crop(in, out, (iy-ny)/2, (ix-nx)/2, ny, nx)
+}
+
+// CropRandom crops a region of in of spatial size ny, nx, at a uniformly
+// random position, into out, using given random source (nil = global),
+// returning the Y, X position of the region, e.g., for data augmentation.
+func CropRandom(in tensor.Tensor, out tensor.Values, ny, nx int, rnd randx.Rand) (y, x int, err error) {
+	iy, ix, _, err := spatial("CropRandom", in)
+	if err != nil {
+		return
+	}
+	if ny > iy || nx > ix {
+		err = fmt.Errorf("etensor.CropRandom: crop size %d x %d is larger than input size %d x %d", ny, nx, iy, ix)
+		return
+	}
+	intn := rand.Intn
+	if rnd != nil {
+		intn = rnd.Intn
+	}
+	y = intn(iy - ny + 1)
+	x = intn(ix - nx + 1)
+	err = Crop(in, out, y, x, ny, nx)
+	return
+}
+
+// Tile splits the spatial dimensions of 2D in into tiles of size ty, tx,
+// starting every sy, sx locations (stride, where stride < size gives
+// overlapping tiles), into 4D out with shape [NY][NX][ty][tx], for
+// the NY, NX tiles that fit entirely within in, e.g., to present
+// an image to the pools of a 4D input layer.
+func Tile(in tensor.Tensor, out tensor.Values, ty, tx, sy, sx int) error {
+	iy, ix, inner, err := spatial("Tile", in)
+	if err != nil {
+		return err
+	}
+	if inner != nil {
+		return fmt.Errorf("etensor.Tile: input must be 2D")
+	}
+	if ty <= 0 || tx <= 0 || sy <= 0 || sx <= 0 || ty > iy || tx > ix {
+		return fmt.Errorf("etensor.Tile: invalid tile size %d x %d or stride %d x %d for input size %d x %d", ty, tx, sy, sx, iy, ix)
+	}
+	ny := (iy-ty)/sy + 1
+	nx := (ix-tx)/sx + 1
+	out.SetShapeSizes(ny, nx, ty, tx)
+	oi := 0
+	for py := range ny {
+		for px := range nx {
+			for y := range ty {
+				for x := range tx {
+					out.SetFloat1D(in.Float1D((py*sy+y)*ix+px*sx+x), oi)
+					oi++
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package hebb is a small reference implementation of two classic
+unsupervised learning algorithms on the emer infrastructure:
+the Kohonen self-organizing map (SOM), and pure Hebbian learning
+with the conditional principal components analysis (CPCA) rule
+and k-winners-take-all activation. It is intended both for teaching
+and as a control to compare against the learning of other algorithms
+(e.g., leabra) in the same sims, using the same NetView, logging,
+and looper infrastructure. It is registered as the "hebb" algorithm.
+*/
+package hebb
+
+//go:generate core generate -add-types
@@ -0,0 +1,89 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package hebb
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _LayerTypesValues = []LayerTypes{0, 1}
+
+// LayerTypesN is the highest valid value for type LayerTypes, plus one.
+const LayerTypesN LayerTypes = 2
+
+var _LayerTypesValueMap = map[string]LayerTypes{`InputLayer`: 0, `HiddenLayer`: 1}
+
+var _LayerTypesDescMap = map[LayerTypes]string{0: `InputLayer has its activity clamped to the external input applied with ApplyExt.`, 1: `HiddenLayer computes its activity from its receiving pathways and learns according to its learning Rule.`}
+
+var _LayerTypesMap = map[LayerTypes]string{0: `InputLayer`, 1: `HiddenLayer`}
+
+// String returns the string representation of this LayerTypes value.
+func (i LayerTypes) String() string { return enums.String(i, _LayerTypesMap) }
+
+// SetString sets the LayerTypes value from its string representation,
+// and returns an error if the string is invalid.
+func (i *LayerTypes) SetString(s string) error {
+	return enums.SetString(i, s, _LayerTypesValueMap, "LayerTypes")
+}
+
+// Int64 returns the LayerTypes value as an int64.
+func (i LayerTypes) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the LayerTypes value from an int64.
+func (i *LayerTypes) SetInt64(in int64) { *i = LayerTypes(in) }
+
+// Desc returns the description of the LayerTypes value.
+func (i LayerTypes) Desc() string { return enums.Desc(i, _LayerTypesDescMap) }
+
+// LayerTypesValues returns all possible values for the type LayerTypes.
+func LayerTypesValues() []LayerTypes { return _LayerTypesValues }
+
+// Values returns all possible values for the type LayerTypes.
+func (i LayerTypes) Values() []enums.Enum { return enums.Values(_LayerTypesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i LayerTypes) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *LayerTypes) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "LayerTypes")
+}
+
+var _RulesValues = []Rules{0, 1}
+
+// RulesN is the highest valid value for type Rules, plus one.
+const RulesN Rules = 2
+
+var _RulesValueMap = map[string]Rules{`CPCA`: 0, `SOM`: 1}
+
+var _RulesDescMap = map[Rules]string{0: `CPCA is the conditional principal components analysis Hebbian rule: the k units with the highest net input (Ge) are active, and dwt = Lrate * y * (x - w), so that each weight converges on the probability of the sending unit being active when the receiving unit is active.`, 1: `SOM is the Kohonen self-organizing map: the winner is the unit whose weights are closest to the input (minimum squared distance, in Ge), and activity is a Gaussian function of the distance to the winner over the 2D layer grid, with width Sigma, which multiplies the same dwt = Lrate * y * (x - w) learning rule, so that neighboring units come to represent similar inputs.`}
+
+var _RulesMap = map[Rules]string{0: `CPCA`, 1: `SOM`}
+
+// String returns the string representation of this Rules value.
+func (i Rules) String() string { return enums.String(i, _RulesMap) }
+
+// SetString sets the Rules value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Rules) SetString(s string) error { return enums.SetString(i, s, _RulesValueMap, "Rules") }
+
+// Int64 returns the Rules value as an int64.
+func (i Rules) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Rules value from an int64.
+func (i *Rules) SetInt64(in int64) { *i = Rules(in) }
+
+// Desc returns the description of the Rules value.
+func (i Rules) Desc() string { return enums.Desc(i, _RulesDescMap) }
+
+// RulesValues returns all possible values for the type Rules.
+func RulesValues() []Rules { return _RulesValues }
+
+// Values returns all possible values for the type Rules.
+func (i Rules) Values() []enums.Enum { return enums.Values(_RulesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Rules) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Rules) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Rules") }
@@ -0,0 +1,671 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hebb
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"slices"
+	"testing"
+	"unsafe"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/mechs"
+	"github.com/emer/emergent/v2/params"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/emer/emergent/v2/weights"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPCA(t *testing.T) {
+	net := NewNetwork("CPCA")
+	in := net.AddLayer2D("Input", 1, 4, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 1, HiddenLayer)
+	hid.Params.Lrate = 0.02
+	pt := net.ConnectLayers(in, hid, paths.NewFull())
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+
+	// unit 0 is always on, unit 1 half the time, unit 2 never, unit 3 1/4
+	pats := [][]float32{{1, 1, 0, 1}, {1, 0, 0, 0}, {1, 1, 0, 0}, {1, 0, 0, 0}}
+	for trl := range 2000 {
+		assert.NoError(t, net.ApplyExt("Input", tensor.NewFloat32FromValues(pats[trl%4]...)))
+		net.Cycle(ctx)
+		net.Learn(ctx)
+	}
+	assert.Equal(t, float32(1), hid.Act[0])
+	for i, p := range []float32{1, 0.5, 0, 0.25} {
+		assert.InDelta(t, p, pt.Wts[i], 0.05)
+	}
+
+	// no learning when testing
+	wts := slices.Clone(pt.Wts)
+	ctx.Testing = true
+	net.Cycle(ctx)
+	net.Learn(ctx)
+	assert.Equal(t, wts, pt.Wts)
+
+	// Cycle increments the cycle counters, and NewTrial resets Cycle
+	assert.Equal(t, 2001, ctx.Cycle)
+	ctx.NewTrial()
+	net.Cycle(ctx)
+	assert.Equal(t, 1, ctx.Cycle)
+	assert.Equal(t, 2002, ctx.CyclesTotal)
+
+	// the context uses the network's Rand, one data input at a time
+	assert.Equal(t, 1, ctx.NData)
+	assert.Equal(t, 0, ctx.Di)
+	assert.Same(t, &net.Rand, ctx.Rand)
+}
+
+func TestCPCAWinners(t *testing.T) {
+	net := NewNetwork("CPCA")
+	in := net.AddLayer2D("Input", 1, 4, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 2, HiddenLayer)
+	net.ConnectLayers(in, hid, paths.NewFull())
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+
+	pats := [][]float32{{1, 1, 0, 0}, {0, 0, 1, 1}}
+	win := func(pat []float32) int {
+		net.ApplyExt("Input", tensor.NewFloat32FromValues(pat...))
+		net.Cycle(ctx)
+		return hid.Winner
+	}
+	for trl := range 200 {
+		win(pats[trl%2])
+		net.Learn(ctx)
+	}
+	w0, w1 := win(pats[0]), win(pats[1])
+	assert.NotEqual(t, w0, w1)
+	assert.Equal(t, float32(1), hid.Act[w1])
+	assert.Equal(t, float32(0), hid.Act[w0])
+}
+
+func TestSynDelay(t *testing.T) {
+	net := NewNetwork("Delay")
+	in := net.AddLayer2D("Input", 1, 2, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 1, HiddenLayer)
+	pt := net.ConnectLayers(in, hid, paths.NewFull())
+	pt.Delay.On = true
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+	assert.NoError(t, net.ApplyInput("Input", []float32{1, 1}))
+
+	// the input arrives after Delay = 2 cycles
+	for range 2 {
+		net.Cycle(ctx)
+		assert.Equal(t, float32(0), hid.Ge[0])
+	}
+	net.Cycle(ctx)
+	assert.Equal(t, pt.Wts[0]+pt.Wts[1], hid.Ge[0])
+
+	// nothing is in transit after InitActs
+	net.InitActs()
+	net.Cycle(ctx)
+	assert.Equal(t, float32(0), hid.Ge[0])
+}
+
+func TestShortPlast(t *testing.T) {
+	net := NewNetwork("STP")
+	in := net.AddLayer2D("Input", 1, 2, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 1, HiddenLayer)
+	pt := net.ConnectLayers(in, hid, paths.NewFull())
+	pt.STP.On = true
+	pt.STP.Algo = mechs.STPTrialBinary
+	pt.STP.RecTau = 2
+	pt.STP.FacTau = 1
+	net.UpdateParams()
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+	ctx.Testing = true // short-term plasticity is still updated
+	assert.NoError(t, net.ApplyInput("Input", []float32{1, 0}))
+	net.Cycle(ctx)
+	sum := pt.Wts[0]
+	assert.Equal(t, sum, hid.Ge[0])
+	net.Learn(ctx)
+
+	// nr = 1 - P0, pr = P0 + Fac * (1 - P0), tr = pr * nr / P0
+	assert.InDelta(t, 1.44, pt.STPTr[0], 1.0e-6)
+	assert.Equal(t, float32(1), pt.STPTr[1])
+	net.Cycle(ctx)
+	assert.InDelta(t, 1.44*sum, hid.Ge[0], 1.0e-6)
+
+	// the same update every cycle for STPCycles
+	pt.STP.Algo = mechs.STPCycles
+	net.InitWeights()
+	net.Cycle(ctx)
+	assert.Equal(t, sum, hid.Ge[0])
+	assert.InDelta(t, 1.44, pt.STPTr[0], 1.0e-6)
+}
+
+func TestKNa(t *testing.T) {
+	net := NewNetwork("KNa")
+	in := net.AddLayer2D("Input", 1, 2, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 2, HiddenLayer)
+	pt := net.ConnectLayers(in, hid, paths.NewFull())
+	for _, ly := range net.Layers {
+		ly.KNa.On = true
+		ly.KNa.Fast.Rise = 0.5
+		ly.KNa.Fast.Max = 0.5
+		ly.KNa.Med.On = false
+		ly.KNa.Slow.On = false
+	}
+	assert.NoError(t, net.Build())
+	copy(pt.Wts, []float32{0.6, 0.6, 0.5, 0.5})
+	ctx := net.NewContext()
+	assert.NoError(t, net.ApplyInput("Input", []float32{1, 1}))
+
+	// unit 0 wins, and then adapts so that unit 1 wins
+	net.Cycle(ctx)
+	assert.Equal(t, []float32{1, 0}, hid.Act)
+	assert.InDelta(t, 0.25, hid.GknaFast[0], 1.0e-6)
+	net.Cycle(ctx)
+	assert.Equal(t, []float32{0, 1}, hid.Act)
+	assert.InDelta(t, 0.95, hid.Ge[0], 1.0e-6)
+
+	// input layers only adapt with Clamp
+	assert.Equal(t, []float32{1, 1}, in.Act)
+	in.KNa.Clamp = true
+	net.Cycle(ctx)
+	net.Cycle(ctx)
+	assert.InDelta(t, 0.75, in.Act[0], 1.0e-6)
+
+	// learning is not affected by adaptation with InvertNd
+	w := hid.Winner
+	assert.Equal(t, float32(1), hid.lrnAct(w))
+	hid.KNa.InvertNd = true
+	assert.InDelta(t, 1+hid.GknaFast[w], hid.lrnAct(w), 1.0e-6)
+
+	// adaptation persists across trials until InitWeights
+	net.InitActs()
+	assert.NotZero(t, hid.GknaFast[0])
+	net.InitWeights()
+	assert.Zero(t, hid.GknaFast[0])
+}
+
+func TestAdaptWtScale(t *testing.T) {
+	net := NewNetwork("WtScale")
+	in := net.AddLayer2D("Input", 1, 3, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 1, HiddenLayer)
+	hid.Params.Lrate = 0
+	pt := net.ConnectLayers(in, hid, paths.NewFull())
+	pt.WtScale.On = true
+	pt.WtScale.Tau = 2
+	net.UpdateParams()
+	assert.NoError(t, net.Build())
+	assert.Equal(t, []float32{1, 1, 1}, pt.Scale)
+	copy(pt.Wts, []float32{0.1, 0.5, 0.9})
+	ctx := net.NewContext()
+	assert.NoError(t, net.ApplyInput("Input", []float32{1, 1, 1}))
+	net.Cycle(ctx)
+	assert.InDelta(t, 1.5, hid.Ge[0], 1.0e-6)
+	net.Learn(ctx)
+
+	// halfway to LoScale and HiScale, and unchanged between the thresholds
+	assert.InDelta(t, 0.505, pt.SynValue("Scale", 0, 0), 1.0e-6)
+	assert.Equal(t, float32(1), pt.SynValue("Scale", 1, 0))
+	assert.InDelta(t, 1.5, pt.SynValue("Scale", 2, 0), 1.0e-6)
+	net.Cycle(ctx)
+	assert.InDelta(t, 0.505*0.1+0.5+1.5*0.9, hid.Ge[0], 1.0e-6)
+
+	net.InitWeights()
+	assert.Equal(t, []float32{1, 1, 1}, pt.Scale)
+}
+
+func TestDWtShare(t *testing.T) {
+	net := NewNetwork("DWtShare")
+	in := net.AddLayer2D("Input", 1, 4, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 1, HiddenLayer)
+	hid.Params.Lrate = 0.5
+	pt := net.ConnectLayers(in, hid, paths.NewFull())
+	pt.DWtShare.On = true
+	pt.DWtShare.Neigh = 1
+	pt.DWtShare.PShare = 1
+	assert.NoError(t, net.Build())
+	clear(pt.Wts)
+	ctx := net.NewContext()
+	assert.NoError(t, net.ApplyInput("Input", []float32{1, 0, 1, 0}))
+	net.Cycle(ctx)
+	net.Learn(ctx)
+
+	// the weight changes of 0.5, 0, 0.5, 0 are averaged with neighbors,
+	// which keeps their sum
+	assert.NotEqual(t, []float32{0.5, 0, 0.5, 0}, pt.Wts)
+	sum := float32(0)
+	for _, w := range pt.Wts {
+		sum += w
+	}
+	assert.InDelta(t, 1, sum, 1.0e-6)
+}
+
+func TestSOM(t *testing.T) {
+	net := NewNetwork("SOM")
+	in := net.AddLayer2D("Input", 1, 1, InputLayer)
+	hid := net.AddLayer2D("Map", 1, 10, HiddenLayer)
+	hid.Params.Rule = SOM
+	pt := net.ConnectLayers(in, hid, paths.NewFull())
+	net.SetRandSeed(1)
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+
+	ntrls := 2000
+	inp := tensor.NewFloat32(1)
+	for trl := range ntrls {
+		hid.Params.Sigma = 3 - 2.5*float32(trl)/float32(ntrls)
+		inp.SetFloat1D(float64(net.Rand.Float32()), 0)
+		net.ApplyExt("Input", inp)
+		net.Cycle(ctx)
+		net.Learn(ctx)
+	}
+	// weights are topographically ordered, in either direction
+	incr := pt.Wts[9] > pt.Wts[0]
+	for i := 1; i < 10; i++ {
+		assert.Equal(t, incr, pt.Wts[i] > pt.Wts[i-1])
+	}
+	assert.Equal(t, float32(1), hid.Act[hid.Winner])
+	assert.Less(t, hid.Ge[hid.Winner], float32(0.01))
+}
+
+func TestArenas(t *testing.T) {
+	train := func(arenas bool) *Network {
+		net := NewNetwork("CPCA")
+		net.UseArenas = arenas
+		in := net.AddLayer2D("Input", 2, 3, InputLayer)
+		hid := net.AddLayer2D("Hidden", 2, 2, HiddenLayer)
+		net.ConnectLayers(in, hid, paths.NewFull())
+		net.SetRandSeed(1)
+		assert.NoError(t, net.Build())
+		ctx := net.NewContext()
+		for trl := range 50 {
+			net.ApplyExt("Input", tensor.NewFloat32FromValues(float32(trl%2), 1, 0, float32(trl%3), 0, 1))
+			net.Cycle(ctx)
+			net.Learn(ctx)
+		}
+		return net
+	}
+	net, anet := train(false), train(true)
+	assert.Equal(t, net.Paths[0].Wts, anet.Paths[0].Wts)
+	assert.Equal(t, net.Paths[0].RecvConIndex, anet.Paths[0].RecvConIndex)
+	assert.Equal(t, net.Layers[1].Act, anet.Layers[1].Act)
+	// the unit state of all layers is allocated contiguously
+	in, hid := anet.Layers[0], anet.Layers[1]
+	addr := func(v *float32) uintptr { return uintptr(unsafe.Pointer(v)) }
+	assert.Equal(t, addr(&in.GknaSlow[0])+uintptr(4*len(in.GknaSlow)), addr(&hid.Act[0]))
+}
+
+func TestWeights(t *testing.T) {
+	en, err := emer.NewNetwork("hebb", "Weights")
+	assert.NoError(t, err)
+	net := en.(*Network)
+	alg, _ := emer.AlgorithmByName("hebb")
+	in, _ := alg.AddLayer(net, "Input", []int{3, 3}, "InputLayer")
+	hid, err := alg.AddLayer(net, "Hidden", []int{2, 2}, "HiddenLayer")
+	assert.NoError(t, err)
+	assert.Equal(t, "HiddenLayer", hid.TypeName())
+	_, err = alg.ConnectLayers(net, in, hid, paths.NewFull(), "ForwardPath")
+	assert.NoError(t, err)
+	_, err = alg.ConnectLayers(net, in, hid, paths.NewFull(), "RecurrentPath")
+	assert.ErrorContains(t, err, "not supported")
+	assert.NoError(t, alg.Build(net))
+	pt := net.Paths[0]
+	assert.Equal(t, 36, pt.NumSyns())
+	orig := append([]float32{}, pt.Wts...)
+
+	var b bytes.Buffer
+	assert.NoError(t, net.WriteWeightsJSON(&b))
+	net.SetRandSeed(10)
+	net.InitWeights()
+	assert.NotEqual(t, orig, pt.Wts)
+	assert.NoError(t, net.ReadWeightsJSON(&b))
+	assert.Equal(t, orig, pt.Wts)
+
+	var vals []float32
+	assert.NoError(t, hid.RecvPathValues(&vals, "Wt", in, 4, ""))
+	for ri := range 4 {
+		assert.Equal(t, orig[pt.SynIndex(4, ri)], vals[ri])
+	}
+}
+
+func TestProvenance(t *testing.T) {
+	newNet := func() *Network {
+		net := NewNetwork("Composite")
+		in := net.AddLayer2D("Input", 3, 3, InputLayer)
+		hid := net.AddLayer2D("Hidden", 2, 2, HiddenLayer)
+		net.ConnectLayers(in, hid, paths.NewFull())
+		net.ConnectLayers(in, hid, paths.NewOneToOne())
+		assert.NoError(t, net.Build())
+		return net
+	}
+	net := newNet()
+	pv := &weights.Provenance{Source: "vision", NTrials: 5000, LRate: 0.04, Frozen: true}
+	net.Paths[1].SetProvenance(pv)
+	assert.NotEmpty(t, pv.ParamHash)
+
+	var b bytes.Buffer
+	assert.NoError(t, net.WriteWeightsJSON(&b))
+	assert.Contains(t, b.String(), `"Provenance.NTrials": "5000"`)
+
+	ld := newNet()
+	assert.NoError(t, ld.ReadWeightsJSON(&b))
+	lpv, err := ld.Paths[0].Provenance()
+	assert.NoError(t, err)
+	assert.Nil(t, lpv)
+	lpv, err = ld.Paths[1].Provenance()
+	assert.NoError(t, err)
+	assert.Equal(t, pv, lpv)
+	rpt := ld.ProvenanceReport()
+	assert.Equal(t, ld.Paths[1].Name+": "+pv.String()+"\n", rpt)
+
+	ld.Paths[1].WtInit.Mean = 0.9
+	assert.Contains(t, ld.ProvenanceReport(), "(params changed)")
+}
+
+func TestWeightsRemap(t *testing.T) {
+	newNet := func(iy, ix int) *Network {
+		net := NewNetwork("Remap")
+		in := net.AddLayer2D("Input", iy, ix, InputLayer)
+		hid := net.AddLayer2D("Hidden", 1, 2, HiddenLayer)
+		net.ConnectLayers(in, hid, paths.NewFull())
+		assert.NoError(t, net.Build())
+		return net
+	}
+	small := newNet(2, 2)
+	for i := range small.Paths[0].Wts {
+		small.Paths[0].Wts[i] = float32(i) / 10
+	}
+	var b bytes.Buffer
+	assert.NoError(t, small.WriteWeightsJSON(&b))
+	nw, err := weights.NetReadJSON(&b)
+	assert.NoError(t, err)
+
+	big := newNet(4, 4)
+	rm := &weights.Remap{Method: weights.Nearest, Shapes: map[string][]int{"Input": {2, 2}}}
+	rpt, err := big.SetWeightsRemap(nw, rm)
+	assert.NoError(t, err)
+	assert.Equal(t, "Input: [2 2] -> [4 4] (Nearest)\nInputToHidden: 32 synapses remapped, 0 without saved synapses\n", rpt)
+	sp, bp := small.Paths[0], big.Paths[0]
+	for ri := range 2 {
+		for y := range 4 {
+			for x := range 4 {
+				ssi := sp.SynIndex((y/2)*2+x/2, ri)
+				assert.Equal(t, sp.Wts[ssi], bp.Wts[bp.SynIndex(y*4+x, ri)])
+			}
+		}
+	}
+
+	big = newNet(4, 4)
+	init := append([]float32{}, big.Paths[0].Wts...)
+	rm.Method = weights.Truncate
+	rpt, err = big.SetWeightsRemap(nw, rm)
+	assert.NoError(t, err)
+	assert.Equal(t, "Input: [2 2] -> [4 4] (Truncate)\nInputToHidden: 8 synapses remapped, 24 without saved synapses\n", rpt)
+	bp = big.Paths[0]
+	assert.Equal(t, sp.Wts[sp.SynIndex(3, 1)], bp.Wts[bp.SynIndex(5, 1)])
+	assert.Equal(t, init[bp.SynIndex(15, 1)], bp.Wts[bp.SynIndex(15, 1)])
+}
+
+func TestUnitGroups(t *testing.T) {
+	net := NewNetwork("Groups")
+	in := net.AddLayer2D("Input", 2, 3, InputLayer)
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+	assert.NoError(t, in.AddUnitGroup("Exc", 3, 0, 1, 1))
+	mask := tensor.NewFloat32(2, 3)
+	mask.Values[5] = 1
+	assert.NoError(t, in.AddUnitGroupMask("Inh", mask))
+	assert.Error(t, in.AddUnitGroup("Bad", 6))
+	assert.Error(t, in.AddUnitGroupMask("Bad", tensor.NewFloat32(2)))
+	assert.Equal(t, []string{"Exc", "Inh"}, in.UnitGroupNames())
+	idxs, err := in.UnitGroup("Exc")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 3}, idxs)
+	_, err = in.UnitGroup("Bad")
+	assert.Error(t, err)
+
+	assert.NoError(t, net.ApplyInput("Input", []float32{1, 0.5, 0, 0, 0, 1}))
+	net.Cycle(ctx)
+	var vals []float32
+	assert.NoError(t, in.UnitGroupValues(&vals, "Exc", "Act", 0))
+	assert.Equal(t, []float32{1, 0.5, 0}, vals)
+	avg, err := in.UnitGroupAvg("Exc", "Act", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(0.5), avg)
+	_, err = in.UnitGroupAvg("Exc", "Bad", 0)
+	assert.Error(t, err)
+
+	// lesion the group
+	assert.NoError(t, in.RangeUnitGroup("Exc", func(idx int) bool {
+		in.Act[idx] = 0
+		return true
+	}))
+	assert.Equal(t, []float32{0, 0, 0, 0, 0, 1}, in.Act)
+
+	gm, err := in.UnitGroupMask("Inh")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, gm.ShapeSizes())
+	assert.Equal(t, []float32{0, 0, 0, 0, 0, 1}, gm.Values)
+}
+
+// noSendRecv hides the SynSendRecv method of the path,
+// to test the generic synapse access.
+type noSendRecv struct {
+	*Path
+	SynSendRecv bool
+}
+
+func TestSynVars(t *testing.T) {
+	net := NewNetwork("SynVars")
+	in := net.AddLayer2D("Input", 1, 4, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 3, HiddenLayer)
+	pt := net.ConnectLayers(in, hid, paths.NewUniformRand())
+	assert.NoError(t, net.Build())
+	for _, ep := range []emer.Path{pt, &noSendRecv{Path: pt}} {
+		pt.EmerPath = ep
+		tsr, err := pt.SynVarByName("Wt")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{3, 4}, tsr.Shape().Sizes)
+		n := 0
+		for ri := range 3 {
+			for si := range 4 {
+				syi := pt.SynIndex(si, ri)
+				if syi < 0 {
+					assert.True(t, math.IsNaN(float64(tsr.Value(ri, si))))
+					continue
+				}
+				n++
+				assert.Equal(t, pt.Wts[syi], tsr.Value(ri, si))
+			}
+		}
+		assert.Equal(t, pt.NumSyns(), n)
+		assert.Less(t, n, 12)
+
+		var syns [][2]int
+		assert.NoError(t, pt.RangeSyns("Wt", func(sidx, ridx int, val float32) bool {
+			assert.Equal(t, pt.SynValue("Wt", sidx, ridx), val)
+			syns = append(syns, [2]int{sidx, ridx})
+			return len(syns) < 3
+		}))
+		assert.Len(t, syns, 3)
+		assert.Equal(t, 0, syns[0][1]) // first receiving unit
+
+		_, err = pt.SynVarByName("DWt")
+		assert.Error(t, err)
+	}
+}
+
+var allocSink []float32
+
+func TestZeroAllocs(t *testing.T) {
+	net := NewNetwork("Allocs")
+	in := net.AddLayer2D("Input", 2, 3, InputLayer)
+	hid := net.AddLayer2D("Hidden", 2, 2, HiddenLayer)
+	hid.Params.K = 2
+	som := net.AddLayer("Map", []int{2, 2, 2, 3}, HiddenLayer)
+	som.Params.Rule = SOM
+	net.ConnectLayers(in, hid, paths.NewFull())
+	net.ConnectLayers(in, som, paths.NewFull())
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+
+	// the grid positions of the units in the 4D pools
+	for ni := range som.NumUnits() {
+		idx := som.Shape.IndexFrom1D(ni)
+		y, x := som.gridPos(ni)
+		assert.Equal(t, idx[0]*2+idx[2], y)
+		assert.Equal(t, idx[1]*3+idx[3], x)
+	}
+
+	pat := []float32{1, 0, 1, 0, 1, 1}
+	trial := func() {
+		net.NewTrial(ctx)
+		net.ApplyInput("Input", pat)
+		net.Cycle(ctx)
+		net.Learn(ctx)
+	}
+	trial()
+	assert.Zero(t, testing.AllocsPerRun(10, trial))
+
+	// the large memory stats are only allocated when tracking is on
+	assert.Less(t, unsafe.Sizeof(net.Allocs), uintptr(256))
+	net.Allocs.On = true
+	trial()
+	assert.Nil(t, net.Allocs.EndTrial())
+	st := net.Allocs.Start()
+	allocSink = make([]float32, 10)
+	net.Allocs.Stop("Test", st)
+	allocs := net.Allocs.EndTrial()
+	if assert.Len(t, allocs, 1) {
+		assert.Equal(t, "Test", allocs[0].Func)
+		assert.Equal(t, uint64(1), allocs[0].Objects)
+	}
+	assert.Equal(t, 2, net.Allocs.Trial)
+}
+
+func TestResize(t *testing.T) {
+	net := NewNetwork("Grow")
+	in := net.AddLayer2D("Input", 2, 2, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 2, HiddenLayer)
+	pt := net.ConnectLayers(in, hid, paths.NewFull())
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+	pats := [][]float32{{1, 1, 0, 0}, {0, 0, 1, 1}}
+	for trl := range 20 {
+		net.ApplyInput("Input", pats[trl%2])
+		net.Cycle(ctx)
+		net.Learn(ctx)
+	}
+	wt := func(sy, sx, ri int) float32 { return pt.SynValue("Wt", sy*in.Shape.DimSize(1)+sx, ri) }
+	w0, w1 := wt(0, 1, 0), wt(1, 0, 1)
+
+	assert.NoError(t, net.ResizeLayer("Hidden", 1, 3))
+	assert.NoError(t, net.ResizeLayer("Input", 2, 3))
+	assert.Equal(t, 6, len(in.Act))
+	assert.Equal(t, 18, len(pt.Wts))
+	assert.Equal(t, w0, wt(0, 1, 0))
+	assert.Equal(t, w1, wt(1, 0, 1))
+	assert.NotZero(t, wt(1, 2, 2)) // new synapse with initial weight
+
+	out := net.AddLayer2D("Output", 1, 2, HiddenLayer)
+	opt := net.ConnectLayers(hid, out, paths.NewFull())
+	assert.NoError(t, net.BuildNew())
+	assert.Equal(t, w0, wt(0, 1, 0))
+	assert.Len(t, opt.Wts, 6)
+	assert.NoError(t, net.ApplyInput("Input", []float32{1, 1, 0, 0, 0, 0}))
+	net.Cycle(ctx)
+	assert.Equal(t, float32(1), out.Act[out.Winner])
+
+	assert.Error(t, net.ResizeLayer("Nope", 2))
+}
+
+func TestDescribe(t *testing.T) {
+	net := NewNetwork("Describe")
+	in := net.AddLayer2D("Input", 2, 2, InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 3, HiddenLayer)
+	hid.AddClass("Hid Map")
+	net.ConnectLayers(in, hid, paths.NewFull())
+	assert.NoError(t, net.Build())
+	sheets := params.Sheets[*Layer]{
+		"Base": {
+			{Sel: "HiddenLayer", Doc: "slower learning", Set: func(ly *Layer) { ly.Params.Lrate = 0.05 }},
+		},
+		"Fast": {
+			{Sel: ".Hid", Doc: "faster learning", Set: func(ly *Layer) { ly.Params.Lrate = 0.5 }},
+		},
+	}
+	assert.NoError(t, emer.ApplyParamSheets(net.AsEmer(), sheets, net.Layers, "Base", "Fast", "Base"))
+	assert.Error(t, emer.ApplyParamSheets(net.AsEmer(), sheets, net.Layers, "Missing"))
+	assert.Equal(t, float32(0.05), hid.Params.Lrate)
+
+	d := net.Describe()
+	assert.Equal(t, 7, d.NumUnits)
+	assert.Equal(t, 12, d.NumSyns)
+	assert.Equal(t, []string{"Fast", "Base"}, d.ParamSheets)
+	assert.Equal(t, emer.LayerDescription{Name: "Hidden", Type: "HiddenLayer", Class: "Hid Map", Shape: []int{1, 3}, NumUnits: 3, Pos: hid.Pos, Params: hid.AllParams()}, d.Layers[1])
+	assert.Contains(t, d.Layers[1].Params, "Lrate:0.05")
+	assert.Equal(t, emer.PathDescription{Name: "InputToHidden", Type: "ForwardPath", Send: "Input", Recv: "Hidden", Pattern: "Full", NumSyns: 12, Params: net.Paths[0].AllParams()}, d.Paths[0])
+	assert.Equal(t, d.Layers[0].Params+d.Layers[1].Params, net.AllParams())
+
+	var b bytes.Buffer
+	assert.NoError(t, d.WriteJSON(&b))
+	var rd emer.Description
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &rd))
+	assert.Equal(t, d, &rd)
+
+	assert.Equal(t, `Network: Describe: 7 units, 12 synapses, params: Fast, Base
+Layer: Input (InputLayer) [2 2]: 4 units
+Layer: Hidden (HiddenLayer) [1 3]: 3 units .Hid .Map
+Path: InputToHidden (ForwardPath) Input -> Hidden, Full: 12 synapses
+`, d.String())
+}
+
+func TestSharedWeights(t *testing.T) {
+	net := NewNetwork("Shared")
+	in := net.AddLayer("Input", []int{4, 4, 2, 2}, InputLayer)
+	hid := net.AddLayer("Hidden", []int{2, 2, 1, 2}, HiddenLayer)
+	tile := paths.NewPoolTile()
+	tile.Size.Set(2, 2)
+	tile.Skip.Set(2, 2)
+	tile.Start.Set(0, 0)
+	tile.Wrap = false
+	tile.Shared = true
+	pt := net.ConnectLayers(in, hid, tile)
+	assert.NoError(t, net.Build())
+	assert.NotNil(t, pt.shared)
+	assertTied := func() {
+		kw := make(map[int32]float32)
+		for syi, ki := range pt.shared.Index {
+			if w, ok := kw[ki]; ok {
+				assert.Equal(t, w, pt.Wts[syi])
+			} else {
+				kw[ki] = pt.Wts[syi]
+			}
+		}
+		assert.Less(t, len(kw), len(pt.Wts))
+	}
+	assertTied()
+
+	ctx := net.NewContext()
+	inp := tensor.NewFloat32(4, 4, 2, 2)
+	for i := range inp.Values {
+		inp.Values[i] = float32(i % 2)
+	}
+	wts := slices.Clone(pt.Wts)
+	for range 20 {
+		assert.NoError(t, net.ApplyExt("Input", inp))
+		net.Cycle(ctx)
+		net.Learn(ctx)
+	}
+	assert.NotEqual(t, wts, pt.Wts)
+	assertTied()
+	for _, w := range pt.Wts {
+		assert.True(t, w >= 0 && w <= 1)
+	}
+
+	tile.Recip = true
+	assert.ErrorIs(t, net.Build(), paths.ErrRecipShared)
+}
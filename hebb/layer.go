@@ -0,0 +1,478 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hebb
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/mechs"
+	"github.com/emer/emergent/v2/weights"
+)
+
+// LayerTypes are the types of layers.
+type LayerTypes int32 //enums:enum
+
+const (
+	// InputLayer has its activity clamped to the external input
+	// applied with ApplyExt.
+	InputLayer LayerTypes = iota
+
+	// HiddenLayer computes its activity from its receiving pathways
+	// and learns according to its learning Rule.
+	HiddenLayer
+)
+
+// Rules are the learning rules for hidden layers, which also
+// determine how their activity is computed.
+type Rules int32 //enums:enum
+
+const (
+	// CPCA is the conditional principal components analysis Hebbian rule:
+	// the k units with the highest net input (Ge) are active, and
+	// dwt = Lrate * y * (x - w), so that each weight converges on the
+	// probability of the sending unit being active when the receiving
+	// unit is active.
+	CPCA Rules = iota
+
+	// SOM is the Kohonen self-organizing map: the winner is the unit whose
+	// weights are closest to the input (minimum squared distance, in Ge),
+	// and activity is a Gaussian function of the distance to the winner
+	// over the 2D layer grid, with width Sigma, which multiplies the
+	// same dwt = Lrate * y * (x - w) learning rule, so that neighboring
+	// units come to represent similar inputs.
+	SOM
+)
+
+// Params are the activation and learning parameters of a hidden layer.
+type Params struct {
+
+	// Rule is the learning rule, which also determines how activity is computed.
+	Rule Rules
+
+	// Lrate is the learning rate.
+	Lrate float32 `default:"0.1"`
+
+	// K is the number of active (winning) units for the CPCA rule.
+	K int `default:"1"`
+
+	// Sigma is the width of the Gaussian neighborhood around the winner
+	// for the SOM rule, in units of the 2D layer grid.
+	// It is typically decreased over the course of learning.
+	Sigma float32 `default:"1"`
+}
+
+func (lp *Params) Defaults() {
+	lp.Lrate = 0.1
+	lp.K = 1
+	lp.Sigma = 1
+}
+
+// Layer is a layer of units with a single activation value each.
+type Layer struct {
+	emer.LayerBase
+
+	// Type is the type of layer.
+	Type LayerTypes
+
+	// Params are the activation and learning parameters (hidden layers only).
+	Params Params
+
+	// KNa is the sodium-gated potassium adaptation of the activity of
+	// hidden layers, and of input layers if Clamp is set, for modeling
+	// neural adaptation and habituation.
+	KNa mechs.KNaAdaptSpec `display:"inline"`
+
+	// Network is the network this layer belongs to.
+	Network *Network `display:"-"`
+
+	// RecvPaths are the receiving pathways into this layer.
+	RecvPaths []*Path `display:"-"`
+
+	// SendPaths are the sending pathways from this layer.
+	SendPaths []*Path `display:"-"`
+
+	// Winner is the index of the winning unit on the last Cycle,
+	// which is the unit with the highest net input for CPCA,
+	// and the closest unit to the input for SOM (-1 if none).
+	Winner int `edit:"-"`
+
+	// Act is the activity of each unit.
+	Act []float32 `display:"-"`
+
+	// Ext is the external input of each unit.
+	Ext []float32 `display:"-"`
+
+	// Ge is the net input to each unit: the weighted sum of sending
+	// activity for CPCA, and the squared distance between the sending
+	// activity and the weights for SOM, with the KNa adaptation.
+	Ge []float32 `display:"-"`
+
+	// GknaFast is the fast KNa adaptation conductance of each unit.
+	GknaFast []float32 `display:"-"`
+
+	// GknaMed is the medium KNa adaptation conductance of each unit.
+	GknaMed []float32 `display:"-"`
+
+	// GknaSlow is the slow KNa adaptation conductance of each unit.
+	GknaSlow []float32 `display:"-"`
+
+	// order is the unit indexes in order of decreasing net input,
+	// used by kWTA, allocated once so that Cycle does not allocate.
+	order []int
+}
+
+// UnitVars are the unit variables.
+var UnitVars = []string{"Act", "Ext", "Ge"}
+
+// UnitVarProps are the properties of the UnitVars.
+var UnitVarProps = map[string]string{
+	"Act": `min:"0" max:"1" desc:"unit activity: clamped input, k winners for CPCA, neighborhood of winner for SOM"`,
+	"Ext": `min:"0" max:"1" desc:"external input"`,
+	"Ge":  `auto-scale:"+" desc:"net input for CPCA, squared distance from the input for SOM"`,
+}
+
+func (ly *Layer) Defaults() {
+	ly.Params.Defaults()
+	ly.KNa.Defaults()
+}
+
+// UpdateParams updates the parameters computed from other parameters.
+func (ly *Layer) UpdateParams() {
+	ly.KNa.Update()
+}
+
+func (ly *Layer) TypeName() string { return ly.Type.String() }
+func (ly *Layer) TypeNumber() int  { return int(ly.Type) }
+
+func (ly *Layer) NumRecvPaths() int          { return len(ly.RecvPaths) }
+func (ly *Layer) RecvPath(idx int) emer.Path { return ly.RecvPaths[idx] }
+func (ly *Layer) NumSendPaths() int          { return len(ly.SendPaths) }
+func (ly *Layer) SendPath(idx int) emer.Path { return ly.SendPaths[idx] }
+
+// build allocates the unit state, from the given arena if non-nil.
+func (ly *Layer) build(f32 *emer.Arena[float32]) {
+	nu := ly.NumUnits()
+	ly.Act = f32.Alloc(nu)
+	ly.Ext = f32.Alloc(nu)
+	ly.Ge = f32.Alloc(nu)
+	ly.GknaFast = f32.Alloc(nu)
+	ly.GknaMed = f32.Alloc(nu)
+	ly.GknaSlow = f32.Alloc(nu)
+	ly.order = make([]int, nu)
+	ly.Winner = -1
+}
+
+// InitActs initializes the activity state to 0,
+// including the activations in transit on delayed pathways.
+func (ly *Layer) InitActs() {
+	clear(ly.Act)
+	clear(ly.Ge)
+	ly.Winner = -1
+	for _, pt := range ly.RecvPaths {
+		pt.delay.Reset()
+	}
+}
+
+// InitAdapt initializes the KNa adaptation conductances to 0,
+// which persist across trials, unlike the activity.
+func (ly *Layer) InitAdapt() {
+	clear(ly.GknaFast)
+	clear(ly.GknaMed)
+	clear(ly.GknaSlow)
+}
+
+// InitExt initializes the external input to 0.
+func (ly *Layer) InitExt() {
+	clear(ly.Ext)
+}
+
+// ApplyExt applies the values of given tensor as the external input,
+// in 1D order, which must have the same number of values as units.
+func (ly *Layer) ApplyExt(ext tensor.Tensor) error {
+	if err := ly.checkExt(ext.Len()); err != nil {
+		return err
+	}
+	for i := range ly.Ext {
+		ly.Ext[i] = float32(ext.Float1D(i))
+	}
+	return nil
+}
+
+// ApplyValues applies given values as the external input, in 1D order,
+// which must have the same number of values as units.
+func (ly *Layer) ApplyValues(vals []float32) error {
+	if err := ly.checkExt(len(vals)); err != nil {
+		return err
+	}
+	copy(ly.Ext, vals)
+	return nil
+}
+
+// checkExt returns an error if given number of external
+// input values is not the number of units.
+func (ly *Layer) checkExt(n int) error {
+	if n != len(ly.Ext) {
+		return fmt.Errorf("hebb.ApplyExt: layer %s has %d units but input has %d values", ly.Name, len(ly.Ext), n)
+	}
+	return nil
+}
+
+// Cycle computes the activity of the layer: the external input
+// for input layers, and according to the learning Rule for hidden layers,
+// with the KNa adaptation, which is then updated for the cycle.
+func (ly *Layer) Cycle() {
+	if ly.Type == InputLayer {
+		if !ly.KNa.On || !ly.KNa.Clamp {
+			copy(ly.Act, ly.Ext)
+			return
+		}
+		for ni, ext := range ly.Ext {
+			ly.Act[ni] = ly.KNa.ClampAct(ext, ly.gcKNa(ni))
+		}
+		ly.updateKNa()
+		return
+	}
+	clear(ly.Ge)
+	for _, pt := range ly.RecvPaths {
+		if pt.Off {
+			continue
+		}
+		pt.sendGe(ly.Params.Rule)
+	}
+	if ly.KNa.On {
+		// adaptation reduces the net input for CPCA,
+		// and increases the distance from the input for SOM
+		sign := float32(-1)
+		if ly.Params.Rule == SOM {
+			sign = 1
+		}
+		for ni := range ly.Ge {
+			ly.Ge[ni] += sign * ly.gcKNa(ni)
+		}
+	}
+	switch ly.Params.Rule {
+	case CPCA:
+		ly.kWTA()
+	case SOM:
+		ly.neighborhood()
+	}
+	ly.updateKNa()
+}
+
+// gcKNa returns the total KNa adaptation conductance of given unit.
+func (ly *Layer) gcKNa(ni int) float32 {
+	return ly.KNa.Gc(ly.GknaFast[ni], ly.GknaMed[ni], ly.GknaSlow[ni])
+}
+
+// updateKNa updates the KNa adaptation conductances
+// from the current activity, if KNa is On.
+func (ly *Layer) updateKNa() {
+	if !ly.KNa.On {
+		return
+	}
+	for ni, act := range ly.Act {
+		ly.KNa.GcFromRate(&ly.GknaFast[ni], &ly.GknaMed[ni], &ly.GknaSlow[ni], act)
+	}
+}
+
+// lrnAct returns the activity of given unit used for learning,
+// which inverts the effect of the KNa adaptation if KNa.InvertNd is set.
+func (ly *Layer) lrnAct(ni int) float32 {
+	return ly.KNa.NdAct(ly.Act[ni], ly.gcKNa(ni))
+}
+
+// kWTA activates the K units with the highest net input.
+func (ly *Layer) kWTA() {
+	idx := ly.order
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(a, b int) int { return cmp.Compare(ly.Ge[b], ly.Ge[a]) })
+	clear(ly.Act)
+	k := min(max(ly.Params.K, 1), len(idx))
+	for _, ni := range idx[:k] {
+		ly.Act[ni] = 1
+	}
+	ly.Winner = idx[0]
+}
+
+// neighborhood activates the units according to a Gaussian function
+// of their distance from the winner, which is closest to the input.
+func (ly *Layer) neighborhood() {
+	ly.Winner = 0
+	for ni, d := range ly.Ge {
+		if d < ly.Ge[ly.Winner] {
+			ly.Winner = ni
+		}
+	}
+	wy, wx := ly.gridPos(ly.Winner)
+	s2 := 2 * ly.Params.Sigma * ly.Params.Sigma
+	for ni := range ly.Act {
+		y, x := ly.gridPos(ni)
+		d2 := float32((y-wy)*(y-wy) + (x-wx)*(x-wx))
+		if s2 == 0 {
+			ly.Act[ni] = 0
+			if d2 == 0 {
+				ly.Act[ni] = 1
+			}
+			continue
+		}
+		ly.Act[ni] = float32(math.Exp(float64(-d2 / s2)))
+	}
+}
+
+// gridPos returns the position of given unit on the 2D layer grid,
+// with the unit pools of 4D layers laid out contiguously.
+func (ly *Layer) gridPos(ni int) (y, x int) {
+	sz := ly.Shape.Sizes
+	switch len(sz) {
+	case 2:
+		return ni / sz[1], ni % sz[1]
+	case 4:
+		pi, ui := ni/(sz[2]*sz[3]), ni%(sz[2]*sz[3])
+		return (pi/sz[1])*sz[2] + ui/sz[3], (pi%sz[1])*sz[3] + ui%sz[3]
+	}
+	return 0, ni
+}
+
+// Learn updates the weights of the receiving pathways
+// of hidden layers, according to the current activity,
+// and then adapts their weight scales.
+func (ly *Layer) Learn() {
+	if ly.Type == InputLayer {
+		return
+	}
+	for _, pt := range ly.RecvPaths {
+		if pt.Off {
+			continue
+		}
+		pt.learn(ly.Params.Lrate)
+		pt.WtScale.AdaptValues(pt.Scale, pt.Wts)
+	}
+}
+
+func (ly *Layer) UnitVarIndex(varNm string) (int, error) {
+	if i := slices.Index(UnitVars, varNm); i >= 0 {
+		return i, nil
+	}
+	return -1, fmt.Errorf("hebb: unit variable named %q not found", varNm)
+}
+
+func (ly *Layer) UnitValue1D(varIndex int, idx, di int) float32 {
+	if idx < 0 || idx >= len(ly.Act) {
+		return float32(math.NaN())
+	}
+	switch varIndex {
+	case 0:
+		return ly.Act[idx]
+	case 1:
+		return ly.Ext[idx]
+	case 2:
+		return ly.Ge[idx]
+	}
+	return float32(math.NaN())
+}
+
+func (ly *Layer) VarRange(varNm string) (min, max float32, err error) {
+	vi, err := ly.UnitVarIndex(varNm)
+	if err != nil {
+		return
+	}
+	for ni := range ly.Act {
+		v := ly.UnitValue1D(vi, ni, 0)
+		if ni == 0 || v < min {
+			min = v
+		}
+		if ni == 0 || v > max {
+			max = v
+		}
+	}
+	return
+}
+
+// pathValues fills in given vals with the value of given synapse variable
+// for each unit in this layer, for the pathway of given type connected with
+// given other layer, and unit index in that layer.
+func (ly *Layer) pathValues(vals *[]float32, varNm string, other emer.Layer, oidx int, pathType string, recv bool) error {
+	nu := ly.NumUnits()
+	if cap(*vals) < nu {
+		*vals = make([]float32, nu)
+	} else {
+		*vals = (*vals)[:nu]
+	}
+	nan := float32(math.NaN())
+	for i := range *vals {
+		(*vals)[i] = nan
+	}
+	pts := ly.SendPaths
+	if recv {
+		pts = ly.RecvPaths
+	}
+	for _, pt := range pts {
+		ol := pt.Recv
+		if recv {
+			ol = pt.Send
+		}
+		if emer.Layer(ol) != other || (pathType != "" && pt.TypeName() != pathType) {
+			continue
+		}
+		vi, err := pt.SynVarIndex(varNm)
+		if err != nil {
+			return err
+		}
+		for ni := range nu {
+			si, ri := oidx, ni
+			if !recv {
+				si, ri = ni, oidx
+			}
+			if syi := pt.SynIndex(si, ri); syi >= 0 {
+				(*vals)[ni] = pt.SynValue1D(vi, syi)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("hebb: pathway between layers %s and %s not found", ly.Name, other.Label())
+}
+
+func (ly *Layer) RecvPathValues(vals *[]float32, varNm string, sendLay emer.Layer, sendIndex1D int, pathType string) error {
+	return ly.pathValues(vals, varNm, sendLay, sendIndex1D, pathType, true)
+}
+
+func (ly *Layer) SendPathValues(vals *[]float32, varNm string, recvLay emer.Layer, recvIndex1D int, pathType string) error {
+	return ly.pathValues(vals, varNm, recvLay, recvIndex1D, pathType, false)
+}
+
+func (ly *Layer) NonDefaultParams() string { return "" }
+
+func (ly *Layer) AllParams() string {
+	if ly.Type == InputLayer {
+		return fmt.Sprintf("Layer: %s\tType: %s\tKNa: %+v\n", ly.Name, ly.Type, ly.KNa)
+	}
+	return fmt.Sprintf("Layer: %s\tType: %s\tParams: %+v\tKNa: %+v\n", ly.Name, ly.Type, ly.Params, ly.KNa)
+}
+
+func (ly *Layer) WriteWeightsJSON(w io.Writer, depth int) {
+	ly.WriteWeightsJSONBase(w, depth)
+}
+
+func (ly *Layer) SetWeights(lw *weights.Layer) error {
+	for pi := range lw.Paths {
+		pw := &lw.Paths[pi]
+		pt, err := ly.RecvPathBySendName(pw.From)
+		if err != nil {
+			return err
+		}
+		if err := pt.SetWeights(pw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
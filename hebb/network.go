@@ -0,0 +1,358 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hebb
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/hybrid"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/emer/emergent/v2/weights"
+)
+
+func init() {
+	emer.RegisterAlgorithm(&emer.Algorithm{
+		Name: "hebb",
+		Doc:  "Kohonen self-organizing map (SOM) and CPCA Hebbian learning reference algorithms",
+		NewNetwork: func(name string) emer.Network {
+			return NewNetwork(name)
+		},
+		AddLayer: func(net emer.Network, name string, shape []int, typ string) (emer.Layer, error) {
+			var lt LayerTypes
+			if err := lt.SetString(typ); err != nil {
+				return nil, fmt.Errorf("hebb.AddLayer: layer %s: %w", name, err)
+			}
+			return net.(*Network).AddLayer(name, shape, lt), nil
+		},
+		ConnectLayers: func(net emer.Network, send, recv emer.Layer, pat paths.Pattern, typ string) (emer.Path, error) {
+			if typ != "" && typ != "ForwardPath" { // the only type of pathway
+				return nil, fmt.Errorf("hebb.ConnectLayers: %s to %s: pathway type %q is not supported, only ForwardPath", send.Label(), recv.Label(), typ)
+			}
+			return net.(*Network).ConnectLayers(send.(*Layer), recv.(*Layer), pat), nil
+		},
+		Build: func(net emer.Network) error {
+			return net.(*Network).Build()
+		},
+	})
+}
+
+// Network is a network of layers using the SOM or CPCA learning rules,
+// which processes one input pattern at a time. For each input pattern,
+// call ApplyExt on the input layers, then Cycle to compute the activity
+// of all layers in order, and then Learn to update the weights.
+type Network struct {
+	emer.NetworkBase
+
+	// Layers are the layers, in order of computation.
+	Layers []*Layer
+
+	// Paths are all of the pathways.
+	Paths []*Path `display:"-"`
+}
+
+// NewNetwork returns a new network with given name.
+func NewNetwork(name string) *Network {
+	nt := &Network{}
+	emer.InitNetwork(nt, name)
+	return nt
+}
+
+// AddLayer adds a new layer with given name, shape and type.
+// Hidden layers use the CPCA rule by default.
+func (nt *Network) AddLayer(name string, shape []int, typ LayerTypes) *Layer {
+	ly := &Layer{Type: typ, Network: nt}
+	emer.InitLayer(ly, name)
+	ly.SetShape(shape...)
+	ly.Index = len(nt.Layers)
+	ly.Defaults()
+	nt.Layers = append(nt.Layers, ly)
+	nt.UpdateLayerNameMap()
+	return ly
+}
+
+// AddLayer2D adds a new 2D layer with given name, shape and type.
+func (nt *Network) AddLayer2D(name string, nY, nX int, typ LayerTypes) *Layer {
+	return nt.AddLayer(name, []int{nY, nX}, typ)
+}
+
+// ConnectLayers adds a new pathway from the send to the recv layer,
+// with given pattern of connectivity.
+func (nt *Network) ConnectLayers(send, recv *Layer, pat paths.Pattern) *Path {
+	pt := &Path{Send: send, Recv: recv}
+	emer.InitPath(pt)
+	pt.Pattern = pat
+	pt.Name = send.Name + "To" + recv.Name
+	pt.Defaults()
+	send.SendPaths = append(send.SendPaths, pt)
+	recv.RecvPaths = append(recv.RecvPaths, pt)
+	nt.Paths = append(nt.Paths, pt)
+	return pt
+}
+
+// validate returns an error if a hidden layer has no receiving pathways,
+// or for Shared Recip PoolTile pathways.
+func (nt *Network) validate() error {
+	for _, ly := range nt.Layers {
+		if ly.Type == HiddenLayer && len(ly.RecvPaths) == 0 {
+			return fmt.Errorf("hebb.Build: hidden layer %s has no receiving pathways", ly.Name)
+		}
+	}
+	for _, pt := range nt.Paths {
+		if tile, ok := pt.Pattern.(*paths.PoolTile); ok && tile.Shared && tile.Recip {
+			return fmt.Errorf("hebb.Build: pathway %s: %w", pt.Name, paths.ErrRecipShared)
+		}
+	}
+	return nil
+}
+
+// Build allocates the units and synapses, and initializes the weights.
+func (nt *Network) Build() error {
+	if err := nt.validate(); err != nil {
+		return err
+	}
+	cons := make([]*tensor.Bool, len(nt.Paths))
+	nsyn := make([]int, len(nt.Paths))
+	for i, pt := range nt.Paths {
+		cons[i], nsyn[i] = pt.connect()
+	}
+	var f32 *emer.Arena[float32]
+	var i32 *emer.Arena[int32]
+	if nt.UseArenas {
+		nf, ni := 0, 0
+		for _, ly := range nt.Layers {
+			nf += 6 * ly.NumUnits() // Act, Ext, Ge, GknaFast, GknaMed, GknaSlow
+		}
+		for i, pt := range nt.Paths {
+			nf += 2*nsyn[i] + 3*pt.Send.NumUnits() // Wts, Scale, STPTr, STPNr, STPPr
+			if tile, ok := pt.Pattern.(*paths.PoolTile); ok && tile.Shared {
+				nf += nsyn[i] // dWts
+			}
+			ni += 2*pt.Recv.NumUnits() + nsyn[i] // RecvConN, RecvConStart, RecvConIndex
+		}
+		f32, i32 = emer.NewArena[float32](nf), emer.NewArena[int32](ni)
+	}
+	for _, ly := range nt.Layers {
+		ly.build(f32)
+	}
+	for i, pt := range nt.Paths {
+		pt.build(cons[i], nsyn[i], f32, i32)
+	}
+	nt.InitWeights()
+	return nil
+}
+
+// BuildNew allocates the units and synapses of the layers and pathways
+// added since the last Build (or BuildNew), with initial random weights,
+// keeping the state and weights of the existing ones, e.g., to add to
+// a trained network in developmental growth models or interactive
+// architecture editing. The new state is not allocated in arenas.
+func (nt *Network) BuildNew() error {
+	if err := nt.validate(); err != nil {
+		return err
+	}
+	for _, ly := range nt.Layers {
+		if ly.Act == nil {
+			ly.build(nil)
+			ly.InitActs()
+		}
+	}
+	for _, pt := range nt.Paths {
+		if pt.RecvConN == nil {
+			cons, nsyn := pt.connect()
+			pt.build(cons, nsyn, nil, nil)
+			pt.InitWeights()
+		}
+	}
+	return nil
+}
+
+// ResizeLayer changes the shape of the layer of given name in a built
+// network, e.g., to grow it in developmental growth models, keeping the
+// weights of the units at the same topographic coordinates in the old
+// and new shapes (as in [weights.Truncate]), with initial random weights
+// for the synapses of new units. The unit groups of the layer are remapped
+// with [emer.LayerBase.RemapUnitGroups]. Only the layer and its pathways
+// are reallocated, and not in arenas.
+func (nt *Network) ResizeLayer(name string, shape ...int) error {
+	ly, err := nt.LayerByName(name)
+	if err != nil {
+		return err
+	}
+	umap := weights.IndexMap(slices.Clone(ly.Shape.Sizes), shape)
+	ly.RemapUnitGroups(umap)
+	ly.SetShape(shape...)
+	ly.build(nil)
+	ly.InitActs()
+	unitMap := func(l *Layer) []int {
+		if l == ly {
+			return umap
+		}
+		return nil
+	}
+	for _, pt := range nt.Paths {
+		if pt.Send != ly && pt.Recv != ly {
+			continue
+		}
+		old := *pt
+		cons, nsyn := pt.connect()
+		pt.build(cons, nsyn, nil, nil)
+		pt.InitWeights()
+		pt.keepWeights(&old, unitMap(pt.Send), unitMap(pt.Recv))
+	}
+	return nil
+}
+
+// InitWeights initializes the weights of all pathways, after resetting
+// the random seed, and the activity and adaptation of all layers.
+func (nt *Network) InitWeights() {
+	nt.ResetRandSeed()
+	for _, pt := range nt.Paths {
+		pt.InitWeights()
+	}
+	for _, ly := range nt.Layers {
+		ly.InitAdapt()
+	}
+	nt.InitActs()
+}
+
+// InitActs initializes the activity of all layers.
+func (nt *Network) InitActs() {
+	defer nt.Allocs.Stop("InitActs", nt.Allocs.Start())
+	for _, ly := range nt.Layers {
+		ly.InitActs()
+	}
+}
+
+// InitExt initializes the external input of all layers.
+func (nt *Network) InitExt() {
+	for _, ly := range nt.Layers {
+		ly.InitExt()
+	}
+}
+
+// ApplyExt applies given tensor as the external input to the layer of given name.
+func (nt *Network) ApplyExt(layer string, ext tensor.Tensor) error {
+	defer nt.Allocs.Stop("ApplyExt", nt.Allocs.Start())
+	ly, err := nt.LayerByName(layer)
+	if err != nil {
+		return err
+	}
+	return ly.ApplyExt(ext)
+}
+
+// ApplyInput applies given values as the external input to the layer
+// of given name, as a [hybrid.Component].
+func (nt *Network) ApplyInput(layer string, vals []float32) error {
+	defer nt.Allocs.Stop("ApplyInput", nt.Allocs.Start())
+	ly, err := nt.LayerByName(layer)
+	if err != nil {
+		return err
+	}
+	return ly.ApplyValues(vals)
+}
+
+// LayerByName returns the layer of given name.
+func (nt *Network) LayerByName(name string) (*Layer, error) {
+	ly, err := nt.EmerLayerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return ly.(*Layer), nil
+}
+
+// Cycle computes the activity of all layers, in order,
+// and increments the cycle counters of the context.
+func (nt *Network) Cycle(ctx *emer.Context) {
+	defer nt.Allocs.Stop("Cycle", nt.Allocs.Start())
+	for _, ly := range nt.Layers {
+		if !ly.Off {
+			ly.Cycle()
+		}
+	}
+	ctx.CycleInc()
+}
+
+// NewTrial calls InitActs, as a [hybrid.Component].
+func (nt *Network) NewTrial(ctx *emer.Context) { nt.InitActs() }
+
+// RunPhase runs Cycle in the minus phase, as a [hybrid.Component],
+// and does nothing in the plus phase, as learning is unsupervised.
+func (nt *Network) RunPhase(ctx *emer.Context) {
+	if !ctx.PlusPhase {
+		nt.Cycle(ctx)
+	}
+}
+
+// Learn updates the weights according to the current activity,
+// unless ctx.Testing is set, and then the short-term plasticity
+// of the pathways for the end of the trial.
+func (nt *Network) Learn(ctx *emer.Context) {
+	defer nt.Allocs.Stop("Learn", nt.Allocs.Start())
+	if !ctx.Testing {
+		for _, ly := range nt.Layers {
+			if !ly.Off {
+				ly.Learn()
+			}
+		}
+	}
+	for _, pt := range nt.Paths {
+		if !pt.Off {
+			pt.STP.UpdateTrialValues(pt.STPTr, pt.STPNr, pt.STPPr, pt.Send.Act)
+		}
+	}
+}
+
+var _ hybrid.Component = (*Network)(nil)
+
+func (nt *Network) NumLayers() int               { return len(nt.Layers) }
+func (nt *Network) EmerLayer(idx int) emer.Layer { return nt.Layers[idx] }
+func (nt *Network) MaxParallelData() int         { return 1 }
+func (nt *Network) NParallelData() int           { return 1 }
+
+func (nt *Network) Defaults() {
+	for _, ly := range nt.Layers {
+		ly.Defaults()
+	}
+	for _, pt := range nt.Paths {
+		pt.Defaults()
+	}
+}
+
+func (nt *Network) UpdateParams() {
+	for _, ly := range nt.Layers {
+		ly.UpdateParams()
+	}
+	for _, pt := range nt.Paths {
+		pt.UpdateParams()
+	}
+}
+
+func (nt *Network) KeyLayerParams() string {
+	var b strings.Builder
+	for _, ly := range nt.Layers {
+		if ly.Type == HiddenLayer {
+			fmt.Fprintf(&b, "%15s\t Rule: %s\t Lrate: %g\t K: %d\t Sigma: %g\n", ly.Name, ly.Params.Rule, ly.Params.Lrate, ly.Params.K, ly.Params.Sigma)
+		}
+	}
+	return b.String()
+}
+
+func (nt *Network) KeyPathParams() string {
+	var b strings.Builder
+	for _, pt := range nt.Paths {
+		fmt.Fprintf(&b, "%15s\t WtInit: Mean: %g\t Var: %g\n", pt.Name, pt.WtInit.Mean, pt.WtInit.Var)
+	}
+	return b.String()
+}
+
+func (nt *Network) UnitVarNames() []string            { return UnitVars }
+func (nt *Network) UnitVarProps() map[string]string   { return UnitVarProps }
+func (nt *Network) VarCategories() []emer.VarCategory { return nil }
+func (nt *Network) SynVarNames() []string             { return SynVars }
+func (nt *Network) SynVarProps() map[string]string    { return SynVarProps }
@@ -0,0 +1,431 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hebb
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+
+	"cogentcore.org/core/base/indent"
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/mechs"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/emer/emergent/v2/weights"
+)
+
+// Path is a pathway of weights between two layers,
+// stored in receiver-based order.
+type Path struct {
+	emer.PathBase
+
+	// Send is the sending layer.
+	Send *Layer `display:"-"`
+
+	// Recv is the receiving layer.
+	Recv *Layer `display:"-"`
+
+	// WtInit are the parameters for the initial random weights.
+	WtInit randx.RandParams
+
+	// Delay is the transmission delay of the sending activations, in
+	// cycles, e.g., for recurrent self-pathways in timing models.
+	Delay mechs.SynDelaySpec `display:"inline"`
+
+	// STP is the short-term plasticity (facilitation and depression)
+	// of the transmission of the sending activations.
+	STP mechs.ShortPlastSpec `display:"inline"`
+
+	// WtScale adapts the Scale of each synapse, which multiplies
+	// its weight in the CPCA net input, from the learned weight.
+	WtScale mechs.AdaptWtScaleSpec `display:"inline"`
+
+	// DWtShare shares the weight changes of each receiving unit among
+	// neighboring sending units, for topographic smoothing of the
+	// learned weights.
+	DWtShare mechs.DWtShareSpec `display:"inline"`
+
+	// RecvConN is the number of connections for each receiving unit.
+	RecvConN []int32 `display:"-"`
+
+	// RecvConStart is the starting synapse index for each receiving unit.
+	RecvConStart []int32 `display:"-"`
+
+	// RecvConIndex is the sending unit index for each synapse.
+	RecvConIndex []int32 `display:"-"`
+
+	// Wts are the weights for each synapse.
+	Wts []float32 `display:"-"`
+
+	// Scale is the adaptive scale of the weight of each synapse,
+	// from WtScale, so that the effective weight is Scale * Wt.
+	Scale []float32 `display:"-"`
+
+	// STPTr is the short-term plasticity transmission efficacy
+	// for each sending unit, which multiplies its activation.
+	STPTr []float32 `display:"-"`
+
+	// STPNr is the short-term plasticity fraction of available
+	// vesicles for each sending unit.
+	STPNr []float32 `display:"-"`
+
+	// STPPr is the short-term plasticity probability of release
+	// for each sending unit.
+	STPPr []float32 `display:"-"`
+
+	// shared ties the weights of a Shared PoolTile pathway.
+	shared *paths.SharedSyns
+
+	// dWts are the weight changes for each synapse, for Shared pathways.
+	dWts []float32
+
+	// delay has the sending activations in transit, for Delay.
+	delay mechs.SynDelayBuffer
+
+	// shareDWts are the weight changes of one receiving unit,
+	// for DWtShare, grown as needed.
+	shareDWts []float32
+}
+
+// SynVars are the synapse variables.
+var SynVars = []string{"Wt", "Scale"}
+
+// SynVarProps are the properties of the SynVars.
+var SynVarProps = map[string]string{
+	"Wt":    `min:"0" max:"1" desc:"synaptic weight"`,
+	"Scale": `min:"0" max:"2" desc:"adaptive weight scale, for an effective weight of Scale * Wt"`,
+}
+
+func (pt *Path) Defaults() {
+	pt.WtInit.Dist = randx.Uniform
+	pt.WtInit.Mean = 0.5
+	pt.WtInit.Var = 0.25
+	pt.Delay.Defaults()
+	pt.STP.Defaults()
+	pt.WtScale.Defaults()
+	pt.DWtShare.Defaults()
+}
+
+// UpdateParams updates the parameters computed from other parameters.
+func (pt *Path) UpdateParams() {
+	pt.Delay.Update()
+	pt.STP.Update()
+	pt.WtScale.Update()
+	pt.DWtShare.Update()
+}
+
+func (pt *Path) TypeName() string      { return "ForwardPath" }
+func (pt *Path) TypeNumber() int       { return 0 }
+func (pt *Path) SendLayer() emer.Layer { return pt.Send }
+func (pt *Path) RecvLayer() emer.Layer { return pt.Recv }
+func (pt *Path) NumSyns() int          { return len(pt.Wts) }
+func (pt *Path) SynVarNames() []string { return SynVars }
+func (pt *Path) SynVarNum() int        { return len(SynVars) }
+func (pt *Path) AllParams() string {
+	return fmt.Sprintf("Path: %s\tWtInit: %+v\tDelay: %+v\tSTP: %+v\tWtScale: %+v\tDWtShare: %+v\n", pt.Name, pt.WtInit, pt.Delay, pt.STP, pt.WtScale, pt.DWtShare)
+}
+
+// synRange returns the range of synapse indexes for given receiving unit.
+func (pt *Path) synRange(ri int) (st, ed int) {
+	st = int(pt.RecvConStart[ri])
+	return st, st + int(pt.RecvConN[ri])
+}
+
+// connect returns the connectivity according to the Pattern,
+// and the total number of synapses.
+func (pt *Path) connect() (cons *tensor.Bool, nsyn int) {
+	_, recvn, cons := pt.Pattern.Connect(&pt.Send.Shape, &pt.Recv.Shape, pt.Send == pt.Recv)
+	for i := range recvn.Len() {
+		nsyn += int(recvn.Value1D(i))
+	}
+	return cons, nsyn
+}
+
+// build creates the synapses according to the given connectivity from
+// connect, allocating them from the given arenas if non-nil.
+func (pt *Path) build(cons *tensor.Bool, nsyn int, f32 *emer.Arena[float32], i32 *emer.Arena[int32]) {
+	ns, nr := pt.Send.NumUnits(), pt.Recv.NumUnits()
+	pt.RecvConN = i32.Alloc(nr)
+	pt.RecvConStart = i32.Alloc(nr)
+	pt.RecvConIndex = i32.Alloc(nsyn)
+	n := 0
+	for ri := range nr {
+		pt.RecvConStart[ri] = int32(n)
+		for si := range ns {
+			if cons.Value1D(ri*ns + si) {
+				pt.RecvConIndex[n] = int32(si)
+				n++
+			}
+		}
+		pt.RecvConN[ri] = int32(n) - pt.RecvConStart[ri]
+	}
+	pt.Wts = f32.Alloc(nsyn)
+	pt.Scale = f32.Alloc(nsyn)
+	pt.STPTr = f32.Alloc(ns)
+	pt.STPNr = f32.Alloc(ns)
+	pt.STPPr = f32.Alloc(ns)
+	pt.shared = paths.NewSharedSyns(pt.Pattern, &pt.Send.Shape, &pt.Recv.Shape, cons)
+	pt.dWts = nil
+	if pt.shared != nil {
+		pt.dWts = f32.Alloc(nsyn)
+	}
+}
+
+// InitWeights initializes the weights according to WtInit,
+// using the random number generator of the network,
+// with the same weights at each position of a Shared PoolTile,
+// the weight scales to 1, and the short-term plasticity state to baseline.
+func (pt *Path) InitWeights() {
+	pt.STP.InitValues(pt.STPTr, pt.STPNr, pt.STPPr)
+	rnd := &pt.Recv.Network.Rand
+	for i := range pt.Wts {
+		pt.Wts[i] = float32(pt.WtInit.Gen(rnd))
+		pt.Scale[i] = 1
+	}
+	if pt.shared != nil {
+		pt.shared.Tie(pt.Wts)
+	}
+}
+
+// keepWeights copies the weights and scales of given old state of this pathway
+// to the synapses between the same units, given the index of the old unit
+// for each sending and receiving unit (-1 for new units, nil if unchanged).
+func (pt *Path) keepWeights(old *Path, smap, rmap []int) {
+	for ri := range pt.RecvConN {
+		ori := ri
+		if rmap != nil {
+			ori = rmap[ri]
+		}
+		if ori < 0 {
+			continue
+		}
+		st, ed := pt.synRange(ri)
+		for syi := st; syi < ed; syi++ {
+			osi := int(pt.RecvConIndex[syi])
+			if smap != nil {
+				osi = smap[osi]
+			}
+			if osi < 0 {
+				continue
+			}
+			if osyi := old.SynIndex(osi, ori); osyi >= 0 {
+				pt.Wts[syi] = old.Wts[osyi]
+				pt.Scale[syi] = old.Scale[osyi]
+			}
+		}
+	}
+	if pt.shared != nil {
+		pt.shared.Tie(pt.Wts)
+	}
+}
+
+// sendGe accumulates the net input to the receiving layer,
+// according to given rule, from the sending activations
+// of Delay cycles ago, multiplied by the STP transmission efficacy,
+// which is then updated for the cycle. The CPCA net input uses
+// the effective weights, Scale * Wt.
+func (pt *Path) sendGe(rule Rules) {
+	sact, ge := pt.Delay.Send(&pt.delay, pt.Send.Act), pt.Recv.Ge
+	for ri := range ge {
+		st, ed := pt.synRange(ri)
+		sum := float32(0)
+		for syi := st; syi < ed; syi++ {
+			si := pt.RecvConIndex[syi]
+			x := sact[si]
+			if pt.STP.On {
+				x *= pt.STPTr[si]
+			}
+			if rule == SOM {
+				d := x - pt.Wts[syi]
+				sum += d * d
+			} else {
+				sum += x * pt.Scale[syi] * pt.Wts[syi]
+			}
+		}
+		ge[ri] += sum
+	}
+	pt.STP.UpdateCycleValues(pt.STPTr, pt.STPNr, pt.STPPr, sact)
+}
+
+// learn updates the weights with dwt = lrate * y * (x - w),
+// using the learning activity of the layers (see Layer.lrnAct),
+// with the weight changes of each receiving unit shared
+// among neighbors according to DWtShare.
+func (pt *Path) learn(lrate float32) {
+	if pt.shared != nil {
+		pt.learnShared(lrate)
+		return
+	}
+	rnd := &pt.Recv.Network.Rand
+	for ri := range pt.Recv.Act {
+		y := pt.Recv.lrnAct(ri)
+		if y == 0 {
+			continue
+		}
+		st, ed := pt.synRange(ri)
+		if pt.DWtShare.On {
+			if cap(pt.shareDWts) < ed-st {
+				pt.shareDWts = make([]float32, ed-st)
+			}
+			dwts := pt.shareDWts[:ed-st]
+			for syi := st; syi < ed; syi++ {
+				x := pt.Send.lrnAct(int(pt.RecvConIndex[syi]))
+				dwts[syi-st] = lrate * y * (x - pt.Wts[syi])
+			}
+			pt.DWtShare.Share(dwts, rnd)
+			for i, dw := range dwts {
+				pt.Wts[st+i] += dw
+			}
+			continue
+		}
+		for syi := st; syi < ed; syi++ {
+			x := pt.Send.lrnAct(int(pt.RecvConIndex[syi]))
+			pt.Wts[syi] += lrate * y * (x - pt.Wts[syi])
+		}
+	}
+}
+
+// learnShared updates the weights of a Shared PoolTile pathway
+// with the mean of dwt = lrate * y * (x - w) across positions,
+// after sharing according to DWtShare.
+func (pt *Path) learnShared(lrate float32) {
+	rnd := &pt.Recv.Network.Rand
+	clear(pt.dWts)
+	for ri := range pt.Recv.Act {
+		y := pt.Recv.lrnAct(ri)
+		if y == 0 {
+			continue
+		}
+		st, ed := pt.synRange(ri)
+		for syi := st; syi < ed; syi++ {
+			x := pt.Send.lrnAct(int(pt.RecvConIndex[syi]))
+			pt.dWts[syi] = lrate * y * (x - pt.Wts[syi])
+		}
+		pt.DWtShare.Share(pt.dWts[st:ed], rnd)
+	}
+	pt.shared.Mean(pt.dWts)
+	for syi, dw := range pt.dWts {
+		pt.Wts[syi] += dw
+	}
+}
+
+func (pt *Path) SynIndex(sidx, ridx int) int {
+	if ridx < 0 || ridx >= len(pt.RecvConN) {
+		return -1
+	}
+	st, ed := pt.synRange(ridx)
+	for syi := st; syi < ed; syi++ {
+		if int(pt.RecvConIndex[syi]) == sidx {
+			return syi
+		}
+	}
+	return -1
+}
+
+// SynSendRecv returns the sending and receiving unit indexes of
+// given synapse, implementing [emer.SynSendRecver].
+func (pt *Path) SynSendRecv(synIndex int) (sidx, ridx int) {
+	ridx = sort.Search(len(pt.RecvConN), func(ri int) bool {
+		return int(pt.RecvConStart[ri]+pt.RecvConN[ri]) > synIndex
+	})
+	return int(pt.RecvConIndex[synIndex]), ridx
+}
+
+func (pt *Path) SynVarIndex(varNm string) (int, error) {
+	if i := slices.Index(SynVars, varNm); i >= 0 {
+		return i, nil
+	}
+	return -1, fmt.Errorf("hebb: synapse variable named %q not found", varNm)
+}
+
+func (pt *Path) SynValues(vals *[]float32, varNm string) error {
+	vi, err := pt.SynVarIndex(varNm)
+	if err != nil {
+		return err
+	}
+	src := pt.Wts
+	if vi == 1 {
+		src = pt.Scale
+	}
+	*vals = append((*vals)[:0], src...)
+	return nil
+}
+
+func (pt *Path) SynValue1D(varIndex int, synIndex int) float32 {
+	if synIndex < 0 || synIndex >= len(pt.Wts) {
+		return float32(math.NaN())
+	}
+	switch varIndex {
+	case 0:
+		return pt.Wts[synIndex]
+	case 1:
+		return pt.Scale[synIndex]
+	}
+	return float32(math.NaN())
+}
+
+func (pt *Path) WriteWeightsJSON(w io.Writer, depth int) {
+	nr := len(pt.RecvConN)
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte("{\n"))
+	depth++
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte(fmt.Sprintf("\"From\": %q,\n", pt.Send.Name)))
+	pt.WriteMetaDataJSON(w, depth)
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte("\"Rs\": [\n"))
+	depth++
+	for ri := range nr {
+		st, ed := pt.synRange(ri)
+		w.Write(indent.TabBytes(depth))
+		w.Write([]byte("{\n"))
+		depth++
+		w.Write(indent.TabBytes(depth))
+		w.Write([]byte(fmt.Sprintf("\"Ri\": %d,\n", ri)))
+		w.Write(indent.TabBytes(depth))
+		w.Write([]byte(fmt.Sprintf("\"N\": %d,\n", ed-st)))
+		w.Write(indent.TabBytes(depth))
+		w.Write([]byte("\"Si\": [ "))
+		for syi := st; syi < ed; syi++ {
+			w.Write([]byte(fmt.Sprintf("%d", pt.RecvConIndex[syi])))
+			if syi < ed-1 {
+				w.Write([]byte(", "))
+			}
+		}
+		w.Write([]byte(" ],\n"))
+		weights.WriteWtJSON(w, depth, pt.Wts[st:ed])
+		depth--
+		w.Write(indent.TabBytes(depth))
+		if ri == nr-1 {
+			w.Write([]byte("}\n"))
+		} else {
+			w.Write([]byte("},\n"))
+		}
+	}
+	depth--
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte("]\n"))
+	depth--
+	w.Write(indent.TabBytes(depth))
+	w.Write([]byte("}")) // note: leave unterminated as outer loop needs to add , or just \n depending
+}
+
+func (pt *Path) SetWeights(pw *weights.Path) error {
+	for i := range pw.Rs {
+		rw := &pw.Rs[i]
+		for si, s := range rw.Si {
+			syi := pt.SynIndex(s, rw.Ri)
+			if syi < 0 {
+				return fmt.Errorf("hebb.SetWeights: pathway %s has no synapse from sending unit %d to receiving unit %d", pt.Name, s, rw.Ri)
+			}
+			pt.Wts[syi] = rw.Wt[si]
+		}
+	}
+	return nil
+}
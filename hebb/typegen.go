@@ -0,0 +1,19 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package hebb
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/hebb.LayerTypes", IDName: "layer-types", Doc: "LayerTypes are the types of layers.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/hebb.Rules", IDName: "rules", Doc: "Rules are the learning rules for hidden layers, which also\ndetermine how their activity is computed."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/hebb.Params", IDName: "params", Doc: "Params are the activation and learning parameters of a hidden layer.", Fields: []types.Field{{Name: "Rule", Doc: "Rule is the learning rule, which also determines how activity is computed."}, {Name: "Lrate", Doc: "Lrate is the learning rate."}, {Name: "K", Doc: "K is the number of active (winning) units for the CPCA rule."}, {Name: "Sigma", Doc: "Sigma is the width of the Gaussian neighborhood around the winner\nfor the SOM rule, in units of the 2D layer grid.\nIt is typically decreased over the course of learning."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/hebb.Layer", IDName: "layer", Doc: "Layer is a layer of units with a single activation value each.", Embeds: []types.Field{{Name: "LayerBase"}}, Fields: []types.Field{{Name: "Type", Doc: "Type is the type of layer."}, {Name: "Params", Doc: "Params are the activation and learning parameters (hidden layers only)."}, {Name: "KNa", Doc: "KNa is the sodium-gated potassium adaptation of the activity of\nhidden layers, and of input layers if Clamp is set, for modeling\nneural adaptation and habituation."}, {Name: "Network", Doc: "Network is the network this layer belongs to."}, {Name: "RecvPaths", Doc: "RecvPaths are the receiving pathways into this layer."}, {Name: "SendPaths", Doc: "SendPaths are the sending pathways from this layer."}, {Name: "Winner", Doc: "Winner is the index of the winning unit on the last Cycle,\nwhich is the unit with the highest net input for CPCA,\nand the closest unit to the input for SOM (-1 if none)."}, {Name: "Act", Doc: "Act is the activity of each unit."}, {Name: "Ext", Doc: "Ext is the external input of each unit."}, {Name: "Ge", Doc: "Ge is the net input to each unit: the weighted sum of sending\nactivity for CPCA, and the squared distance between the sending\nactivity and the weights for SOM, with the KNa adaptation."}, {Name: "GknaFast", Doc: "GknaFast is the fast KNa adaptation conductance of each unit."}, {Name: "GknaMed", Doc: "GknaMed is the medium KNa adaptation conductance of each unit."}, {Name: "GknaSlow", Doc: "GknaSlow is the slow KNa adaptation conductance of each unit."}, {Name: "order", Doc: "order is the unit indexes in order of decreasing net input,\nused by kWTA, allocated once so that Cycle does not allocate."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/hebb.Network", IDName: "network", Doc: "Network is a network of layers using the SOM or CPCA learning rules,\nwhich processes one input pattern at a time. For each input pattern,\ncall ApplyExt on the input layers, then Cycle to compute the activity\nof all layers in order, and then Learn to update the weights.", Embeds: []types.Field{{Name: "NetworkBase"}}, Fields: []types.Field{{Name: "Layers", Doc: "Layers are the layers, in order of computation."}, {Name: "Paths", Doc: "Paths are all of the pathways."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/hebb.Path", IDName: "path", Doc: "Path is a pathway of weights between two layers,\nstored in receiver-based order.", Embeds: []types.Field{{Name: "PathBase"}}, Fields: []types.Field{{Name: "Send", Doc: "Send is the sending layer."}, {Name: "Recv", Doc: "Recv is the receiving layer."}, {Name: "WtInit", Doc: "WtInit are the parameters for the initial random weights."}, {Name: "Delay", Doc: "Delay is the transmission delay of the sending activations, in\ncycles, e.g., for recurrent self-pathways in timing models."}, {Name: "STP", Doc: "STP is the short-term plasticity (facilitation and depression)\nof the transmission of the sending activations."}, {Name: "WtScale", Doc: "WtScale adapts the Scale of each synapse, which multiplies\nits weight in the CPCA net input, from the learned weight."}, {Name: "DWtShare", Doc: "DWtShare shares the weight changes of each receiving unit among\nneighboring sending units, for topographic smoothing of the\nlearned weights."}, {Name: "RecvConN", Doc: "RecvConN is the number of connections for each receiving unit."}, {Name: "RecvConStart", Doc: "RecvConStart is the starting synapse index for each receiving unit."}, {Name: "RecvConIndex", Doc: "RecvConIndex is the sending unit index for each synapse."}, {Name: "Wts", Doc: "Wts are the weights for each synapse."}, {Name: "Scale", Doc: "Scale is the adaptive scale of the weight of each synapse,\nfrom WtScale, so that the effective weight is Scale * Wt."}, {Name: "STPTr", Doc: "STPTr is the short-term plasticity transmission efficacy\nfor each sending unit, which multiplies its activation."}, {Name: "STPNr", Doc: "STPNr is the short-term plasticity fraction of available\nvesicles for each sending unit."}, {Name: "STPPr", Doc: "STPPr is the short-term plasticity probability of release\nfor each sending unit."}, {Name: "shared", Doc: "shared ties the weights of a Shared PoolTile pathway."}, {Name: "dWts", Doc: "dWts are the weight changes for each synapse, for Shared pathways."}}})
@@ -0,0 +1,59 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hybrid
+
+import "github.com/emer/emergent/v2/emer"
+
+// Phases are the phases of a trial, which every [Component] runs in order,
+// with the components run in order within each phase.
+type Phases int32 //enums:enum
+
+const (
+	// MinusPhase is the expectation phase, in which each component computes
+	// its activity from its inputs, without any targets (e.g., the first
+	// three quarters of a leabra trial, or the forward pass of backprop).
+	MinusPhase Phases = iota
+
+	// PlusPhase is the outcome phase, in which each component computes its
+	// activity with its targets (e.g., the last quarter of a leabra trial).
+	// Components that learn from an explicit error, such as backprop,
+	// can do nothing in this phase.
+	PlusPhase
+)
+
+// Component is a network governed by one algorithm, which is part of a
+// hybrid [Network]. In addition to the emer.Network interface, it
+// implements the phase / scheduling contract of a trial:
+//   - NewTrial is called for all components at the start of a trial.
+//   - For each of the [Phases], the inputs of each component from
+//     [Bridge]s are applied with ApplyInput, and then RunPhase is called,
+//     for each component in order.
+//   - Learn is called for each component that is learning, in order,
+//     at the end of the trial, as a separate learning pass.
+//
+// All of the components share the same [emer.Context], with the
+// current phase set in its Phase and PlusPhase fields.
+type Component interface {
+	emer.Network
+
+	// InitWeights initializes the weights of the network.
+	InitWeights()
+
+	// NewTrial initializes the activity state at the start of a trial,
+	// as appropriate for the algorithm.
+	NewTrial(ctx *emer.Context)
+
+	// ApplyInput applies given values as the external input or target
+	// of the layer of given name, in 1D order, for the next phase.
+	ApplyInput(layer string, vals []float32) error
+
+	// RunPhase runs the current phase of the trial, given by the
+	// Phase and PlusPhase of the context.
+	RunPhase(ctx *emer.Context)
+
+	// Learn updates the weights at the end of the trial,
+	// according to the activity in the phases, unless ctx.Testing is set.
+	Learn(ctx *emer.Context)
+}
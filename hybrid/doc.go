@@ -0,0 +1,17 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package hybrid supports networks that contain layers governed by different
+algorithms, for example a backprop read-out layer on top of a leabra
+network, for hybrid modeling studies. Each algorithm runs its own
+[Component] network, which implements a common phase / scheduling
+contract and a separate learning pass, and a [Network] composes the
+components into a single emer.Network for visualization, logging and
+weights files, with [Bridge]s that send the activity of a layer in one
+component as the input to a layer in another.
+*/
+package hybrid
+
+//go:generate core generate -add-types
@@ -0,0 +1,48 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package hybrid
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _PhasesValues = []Phases{0, 1}
+
+// PhasesN is the highest valid value for type Phases, plus one.
+const PhasesN Phases = 2
+
+var _PhasesValueMap = map[string]Phases{`MinusPhase`: 0, `PlusPhase`: 1}
+
+var _PhasesDescMap = map[Phases]string{0: `MinusPhase is the expectation phase, in which each component computes its activity from its inputs, without any targets (e.g., the first three quarters of a leabra trial, or the forward pass of backprop).`, 1: `PlusPhase is the outcome phase, in which each component computes its activity with its targets (e.g., the last quarter of a leabra trial). Components that learn from an explicit error, such as backprop, can do nothing in this phase.`}
+
+var _PhasesMap = map[Phases]string{0: `MinusPhase`, 1: `PlusPhase`}
+
+// String returns the string representation of this Phases value.
+func (i Phases) String() string { return enums.String(i, _PhasesMap) }
+
+// SetString sets the Phases value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Phases) SetString(s string) error {
+	return enums.SetString(i, s, _PhasesValueMap, "Phases")
+}
+
+// Int64 returns the Phases value as an int64.
+func (i Phases) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Phases value from an int64.
+func (i *Phases) SetInt64(in int64) { *i = Phases(in) }
+
+// Desc returns the description of the Phases value.
+func (i Phases) Desc() string { return enums.Desc(i, _PhasesDescMap) }
+
+// PhasesValues returns all possible values for the type Phases.
+func PhasesValues() []Phases { return _PhasesValues }
+
+// Values returns all possible values for the type Phases.
+func (i Phases) Values() []enums.Enum { return enums.Values(_PhasesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Phases) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Phases) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Phases") }
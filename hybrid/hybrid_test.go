@@ -0,0 +1,146 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hybrid_test
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/hebb"
+	"github.com/emer/emergent/v2/hybrid"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+// newHybrid returns a hybrid network with a CPCA hebbian network,
+// and a backprop read-out of its hidden layer.
+func newHybrid(t *testing.T) (*hybrid.Network, *hebb.Network, *bp.Network) {
+	hn := hebb.NewNetwork("Hebb")
+	in := hn.AddLayer2D("Input", 1, 4, hebb.InputLayer)
+	hid := hn.AddLayer2D("Hidden", 1, 2, hebb.HiddenLayer)
+	hn.ConnectLayers(in, hid, paths.NewFull())
+	assert.NoError(t, hn.Build())
+
+	bn := bp.NewNetwork("ReadOut")
+	rin := bn.AddLayer2D("ReadIn", 1, 2, bp.InputLayer)
+	out := bn.AddLayer2D("Output", 1, 2, bp.TargetLayer)
+	bn.ConnectLayers(rin, out, paths.NewFull(), bp.ForwardPath)
+	bn.SetRandSeed(1)
+	assert.NoError(t, bn.Build())
+
+	net := hybrid.NewNetwork("Hybrid")
+	net.AddModule(hn)
+	ro := net.AddModule(bn)
+	ro.AddBridge(hid, "ReadIn")
+	assert.NoError(t, net.Validate())
+	return net, hn, bn
+}
+
+func TestReadOut(t *testing.T) {
+	net, hn, bn := newHybrid(t)
+	assert.Equal(t, 4, net.NumLayers())
+	assert.Equal(t, "ReadIn", net.EmerLayer(2).Label())
+	assert.Equal(t, []string{"Act", "Ext", "Ge", "Net", "Err", "Bias"}, net.UnitVarNames())
+	assert.Equal(t, []string{"Wt", "Scale", "DWt"}, net.SynVarNames())
+
+	ins := [][]float32{{1, 1, 0, 0}, {0, 0, 1, 1}}
+	outs := [][]float32{{1, 0}, {0, 1}}
+	ctx := net.NewContext()
+	trial := func(i int, learn bool) float64 {
+		assert.NoError(t, hn.ApplyInput("Input", ins[i]))
+		assert.NoError(t, bn.ApplyInput("Output", outs[i]))
+		ctx.Testing = !learn
+		assert.NoError(t, net.Trial(ctx))
+		return bn.SSE(0)
+	}
+	for range 500 {
+		for i := range ins {
+			trial(i, true)
+		}
+	}
+	for i := range ins {
+		assert.Less(t, trial(i, false), 0.01)
+	}
+
+	// weights of all modules are saved and loaded together
+	orig := slices.Clone(hn.Paths[0].Wts)
+	borig := slices.Clone(bn.Paths[0].Wts)
+	var b bytes.Buffer
+	assert.NoError(t, net.WriteWeightsJSON(&b))
+	net.InitWeights()
+	assert.NotEqual(t, borig, bn.Paths[0].Wts)
+	assert.NoError(t, net.ReadWeightsJSON(&b))
+	assert.Equal(t, orig, hn.Paths[0].Wts)
+	assert.Equal(t, borig, bn.Paths[0].Wts)
+}
+
+func TestNoLearn(t *testing.T) {
+	net, hn, bn := newHybrid(t)
+	net.Modules[0].NoLearn = true
+	orig := slices.Clone(hn.Paths[0].Wts)
+	borig := slices.Clone(bn.Paths[0].Wts)
+	hn.ApplyInput("Input", []float32{1, 0, 1, 0})
+	bn.ApplyInput("Output", []float32{1, 0})
+	ctx := net.NewContext()
+	ctx.Testing = true
+	assert.NoError(t, net.Trial(ctx))
+	assert.Equal(t, borig, bn.Paths[0].Wts)
+	ctx.Testing = false
+	assert.NoError(t, net.Trial(ctx))
+	assert.Equal(t, orig, hn.Paths[0].Wts)
+	assert.NotEqual(t, borig, bn.Paths[0].Wts)
+
+	// each module runs one cycle in the minus phase, at the same time
+	assert.Equal(t, 1, ctx.Cycle)
+	assert.Equal(t, 2, ctx.CyclesTotal)
+}
+
+func TestValidate(t *testing.T) {
+	net, hn, _ := newHybrid(t)
+	dup := hebb.NewNetwork("Dup")
+	dup.AddLayer2D("Input", 1, 3, hebb.InputLayer)
+	md := net.AddModule(dup)
+	md.AddBridge(hn.Layers[1], "Input")
+	md.AddBridge(hn.Layers[1], "Missing")
+	err := net.Validate()
+	assert.ErrorContains(t, err, `layer name "Input" is used in module 0 (Hebb) and module 2 (Dup)`)
+	assert.ErrorContains(t, err, "the number of units must match")
+	assert.ErrorContains(t, err, "Missing")
+}
+
+// recComp records the calls of the phase / scheduling contract.
+type recComp struct {
+	*hebb.Network
+	log *[]string
+}
+
+func (rc *recComp) NewTrial(ctx *emer.Context) {
+	*rc.log = append(*rc.log, rc.Name+" NewTrial")
+}
+
+func (rc *recComp) RunPhase(ctx *emer.Context) {
+	*rc.log = append(*rc.log, fmt.Sprintf("%s %s %v", rc.Name, hybrid.Phases(ctx.Phase), ctx.PlusPhase))
+}
+
+func (rc *recComp) Learn(ctx *emer.Context) {
+	*rc.log = append(*rc.log, rc.Name+" Learn")
+}
+
+func TestSchedule(t *testing.T) {
+	var log []string
+	net := hybrid.NewNetwork("Sched")
+	for _, nm := range []string{"A", "B"} {
+		hn := hebb.NewNetwork(nm)
+		hn.AddLayer2D(nm+"Input", 1, 2, hebb.InputLayer)
+		net.AddModule(&recComp{Network: hn, log: &log})
+	}
+	net.Modules[1].NoLearn = true
+	assert.NoError(t, net.Trial(net.NewContext()))
+	assert.Equal(t, []string{"A NewTrial", "B NewTrial", "A MinusPhase false", "B MinusPhase false", "A PlusPhase true", "B PlusPhase true", "A Learn"}, log)
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hybrid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/emer/emergent/v2/emer"
+)
+
+// Bridge sends the values of a unit variable of a layer in one [Component]
+// as the input to a layer in another, at the start of each phase, just
+// before the receiving component runs, so that it receives the activity
+// of the phase if the sending component comes earlier in the order,
+// or of the previous phase otherwise.
+type Bridge struct {
+
+	// Send is the sending layer.
+	Send emer.Layer
+
+	// Var is the unit variable of the sending layer that is sent.
+	Var string
+
+	// Recv is the name of the receiving layer in the Module.
+	Recv string
+
+	// vals are the sent values.
+	vals []float32
+}
+
+// Module is a [Component] in a hybrid [Network], with the [Bridge]s
+// that send its inputs.
+type Module struct {
+
+	// Component is the network of the module.
+	Component Component
+
+	// NoLearn turns off learning for the module, e.g., to train a read-out
+	// of a fixed, pretrained network.
+	NoLearn bool
+
+	// Bridges send the inputs to this module from other modules.
+	Bridges []*Bridge
+}
+
+// AddBridge adds a [Bridge] sending the activity (Act) of given layer
+// in another module to the layer of given name in this module.
+func (md *Module) AddBridge(send emer.Layer, recv string) *Bridge {
+	br := &Bridge{Send: send, Var: "Act", Recv: recv}
+	md.Bridges = append(md.Bridges, br)
+	return br
+}
+
+// applyBridges applies the inputs from the bridges to the component.
+func (md *Module) applyBridges() error {
+	var errs []error
+	for _, br := range md.Bridges {
+		if err := br.Send.AsEmer().UnitValues(&br.vals, br.Var, 0); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := md.Component.ApplyInput(br.Recv, br.vals); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Network is a hybrid network composed of [Module]s of different
+// algorithms, which presents the layers of all of them as a single
+// emer.Network, in module order, for visualization, logging and
+// weights files, and runs trials according to the [Component]
+// phase / scheduling contract. Layer names must be unique
+// across all modules.
+type Network struct {
+	emer.NetworkBase
+
+	// Modules are the modules, in the order that they are run.
+	Modules []*Module
+
+	// layers are all of the layers, in module order.
+	layers []emer.Layer
+}
+
+// NewNetwork returns a new hybrid network with given name.
+func NewNetwork(name string) *Network {
+	nt := &Network{}
+	emer.InitNetwork(nt, name)
+	return nt
+}
+
+// AddModule adds a new [Module] for given component, which is run after
+// the existing ones. The component must already have all of its layers.
+func (nt *Network) AddModule(comp Component) *Module {
+	md := &Module{Component: comp}
+	nt.Modules = append(nt.Modules, md)
+	nt.updateLayers()
+	return md
+}
+
+// updateLayers updates the list and name map of all layers.
+func (nt *Network) updateLayers() {
+	nt.layers = nt.layers[:0]
+	for _, md := range nt.Modules {
+		for li := range md.Component.NumLayers() {
+			nt.layers = append(nt.layers, md.Component.EmerLayer(li))
+		}
+	}
+	nt.UpdateLayerNameMap()
+}
+
+// Validate checks that the layer names are unique across modules,
+// and that each bridge connects layers with the same number of units,
+// returning an error describing all of the problems found.
+func (nt *Network) Validate() error {
+	nt.updateLayers()
+	var errs []error
+	owner := make(map[string]int)
+	for mi, md := range nt.Modules {
+		for li := range md.Component.NumLayers() {
+			lnm := md.Component.EmerLayer(li).Label()
+			if om, has := owner[lnm]; has {
+				errs = append(errs, fmt.Errorf("hybrid: layer name %q is used in module %d (%s) and module %d (%s)", lnm, om, nt.Modules[om].Component.Label(), mi, md.Component.Label()))
+				continue
+			}
+			owner[lnm] = mi
+		}
+	}
+	for _, md := range nt.Modules {
+		for _, br := range md.Bridges {
+			rl, err := md.Component.AsEmer().EmerLayerByName(br.Recv)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("hybrid: bridge from %s: %w", br.Send.Label(), err))
+				continue
+			}
+			sn, rn := br.Send.AsEmer().NumUnits(), rl.AsEmer().NumUnits()
+			if sn != rn {
+				errs = append(errs, fmt.Errorf("hybrid: bridge from %s (%d units) to %s (%d units): the number of units must match", br.Send.Label(), sn, br.Recv, rn))
+			}
+			if _, err := emer.UnitVarIndex(br.Send, br.Var); err != nil {
+				errs = append(errs, fmt.Errorf("hybrid: bridge from %s: %w", br.Send.Label(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// InitWeights initializes the weights of all modules.
+func (nt *Network) InitWeights() {
+	for _, md := range nt.Modules {
+		md.Component.InitWeights()
+	}
+}
+
+// Trial runs one trial with given context. It calls NewTrial for the
+// context and all modules, and then runs each of the [Phases] for each
+// module in order, applying the inputs from the bridges before running
+// each module. Finally, it runs the learning pass of each module in order,
+// except for modules with NoLearn set. If ctx.Testing is set, the learning
+// pass does not change the weights. Any external inputs and targets must
+// be applied to the modules before calling Trial.
+func (nt *Network) Trial(ctx *emer.Context) error {
+	ctx.NewTrial()
+	for _, md := range nt.Modules {
+		md.Component.NewTrial(ctx)
+	}
+	for _, ph := range PhasesValues() {
+		if err := nt.RunPhase(ctx, ph); err != nil {
+			return err
+		}
+	}
+	nt.Learn(ctx)
+	return nil
+}
+
+// RunPhase sets given phase in the context, and runs it for each module
+// in order, applying the inputs from the bridges before running each module.
+// The modules run over the same period of time, so each starts from the
+// same cycle counters of the context, which then advance by the largest
+// number of cycles run by any module.
+func (nt *Network) RunPhase(ctx *emer.Context, phase Phases) error {
+	ctx.SetPhase(int(phase), phase == PlusPhase)
+	cyc, tot := ctx.Cycle, ctx.CyclesTotal
+	ncyc := 0
+	for _, md := range nt.Modules {
+		if err := md.applyBridges(); err != nil {
+			return err
+		}
+		ctx.Cycle, ctx.CyclesTotal = cyc, tot
+		md.Component.RunPhase(ctx)
+		ncyc = max(ncyc, ctx.Cycle-cyc)
+	}
+	ctx.Cycle, ctx.CyclesTotal = cyc+ncyc, tot+ncyc
+	return nil
+}
+
+// Learn runs the learning pass of each module in order,
+// except those with NoLearn set.
+func (nt *Network) Learn(ctx *emer.Context) {
+	for _, md := range nt.Modules {
+		if !md.NoLearn {
+			md.Component.Learn(ctx)
+		}
+	}
+}
+
+func (nt *Network) NumLayers() int               { return len(nt.layers) }
+func (nt *Network) EmerLayer(idx int) emer.Layer { return nt.layers[idx] }
+
+// MaxParallelData returns the minimum over the modules.
+func (nt *Network) MaxParallelData() int {
+	return nt.minData(emer.Network.MaxParallelData)
+}
+
+// NParallelData returns the minimum over the modules.
+func (nt *Network) NParallelData() int {
+	return nt.minData(emer.Network.NParallelData)
+}
+
+func (nt *Network) minData(fun func(emer.Network) int) int {
+	if len(nt.Modules) == 0 {
+		return 1
+	}
+	n := fun(nt.Modules[0].Component)
+	for _, md := range nt.Modules[1:] {
+		n = min(n, fun(md.Component))
+	}
+	return n
+}
+
+func (nt *Network) Defaults() {
+	for _, md := range nt.Modules {
+		md.Component.Defaults()
+	}
+}
+
+func (nt *Network) UpdateParams() {
+	for _, md := range nt.Modules {
+		md.Component.UpdateParams()
+	}
+}
+
+func (nt *Network) KeyLayerParams() string {
+	return nt.modulesString(emer.Network.KeyLayerParams)
+}
+
+func (nt *Network) KeyPathParams() string {
+	return nt.modulesString(emer.Network.KeyPathParams)
+}
+
+// modulesString returns the concatenation of the given string
+// for each module, labeled by the module name.
+func (nt *Network) modulesString(fun func(emer.Network) string) string {
+	var b strings.Builder
+	for _, md := range nt.Modules {
+		fmt.Fprintf(&b, "Module: %s\n%s", md.Component.Label(), fun(md.Component))
+	}
+	return b.String()
+}
+
+// UnitVarNames returns the union of the unit variables of the modules,
+// in order. Layers return NaN for variables they do not have.
+func (nt *Network) UnitVarNames() []string {
+	return nt.varNames(emer.Network.UnitVarNames)
+}
+
+func (nt *Network) UnitVarProps() map[string]string {
+	return nt.varProps(emer.Network.UnitVarProps)
+}
+
+// SynVarNames returns the union of the synapse variables of the modules,
+// in order.
+func (nt *Network) SynVarNames() []string {
+	return nt.varNames(emer.Network.SynVarNames)
+}
+
+func (nt *Network) SynVarProps() map[string]string {
+	return nt.varProps(emer.Network.SynVarProps)
+}
+
+func (nt *Network) VarCategories() []emer.VarCategory {
+	var cats []emer.VarCategory
+	for _, md := range nt.Modules {
+		for _, vc := range md.Component.VarCategories() {
+			if !slices.ContainsFunc(cats, func(c emer.VarCategory) bool { return c.Cat == vc.Cat }) {
+				cats = append(cats, vc)
+			}
+		}
+	}
+	return cats
+}
+
+func (nt *Network) varNames(fun func(emer.Network) []string) []string {
+	var names []string
+	for _, md := range nt.Modules {
+		for _, vn := range fun(md.Component) {
+			if !slices.Contains(names, vn) {
+				names = append(names, vn)
+			}
+		}
+	}
+	return names
+}
+
+func (nt *Network) varProps(fun func(emer.Network) map[string]string) map[string]string {
+	props := make(map[string]string)
+	for _, md := range nt.Modules {
+		for vn, p := range fun(md.Component) {
+			if _, has := props[vn]; !has {
+				props[vn] = p
+			}
+		}
+	}
+	return props
+}
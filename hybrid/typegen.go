@@ -0,0 +1,17 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package hybrid
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/hybrid.Phases", IDName: "phases", Doc: "Phases are the phases of a trial, which every [Component] runs in order,\nwith the components run in order within each phase.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/hybrid.Component", IDName: "component", Doc: "Component is a network governed by one algorithm, which is part of a\nhybrid [Network]. In addition to the emer.Network interface, it\nimplements the phase / scheduling contract of a trial:\n  - NewTrial is called for all components at the start of a trial.\n  - For each of the [Phases], the inputs of each component from\n    [Bridge]s are applied with ApplyInput, and then RunPhase is called,\n    for each component in order.\n  - Learn is called for each component that is learning, in order,\n    at the end of the trial, as a separate learning pass.", Methods: []types.Method{{Name: "InitWeights", Doc: "InitWeights initializes the weights of the network."}, {Name: "NewTrial", Doc: "NewTrial initializes the activity state at the start of a trial,\nas appropriate for the algorithm."}, {Name: "ApplyInput", Doc: "ApplyInput applies given values as the external input or target\nof the layer of given name, in 1D order, for the next phase.", Args: []string{"layer", "vals"}, Returns: []string{"error"}}, {Name: "RunPhase", Doc: "RunPhase runs given phase of the trial.", Args: []string{"phase"}}, {Name: "Learn", Doc: "Learn updates the weights at the end of the trial,\naccording to the activity in the phases."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/hybrid.Bridge", IDName: "bridge", Doc: "Bridge sends the values of a unit variable of a layer in one [Component]\nas the input to a layer in another, at the start of each phase, just\nbefore the receiving component runs, so that it receives the activity\nof the phase if the sending component comes earlier in the order,\nor of the previous phase otherwise.", Fields: []types.Field{{Name: "Send", Doc: "Send is the sending layer."}, {Name: "Var", Doc: "Var is the unit variable of the sending layer that is sent."}, {Name: "Recv", Doc: "Recv is the name of the receiving layer in the Module."}, {Name: "vals", Doc: "vals are the sent values."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/hybrid.Module", IDName: "module", Doc: "Module is a [Component] in a hybrid [Network], with the [Bridge]s\nthat send its inputs.", Fields: []types.Field{{Name: "Component", Doc: "Component is the network of the module."}, {Name: "NoLearn", Doc: "NoLearn turns off learning for the module, e.g., to train a read-out\nof a fixed, pretrained network."}, {Name: "Bridges", Doc: "Bridges send the inputs to this module from other modules."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/hybrid.Network", IDName: "network", Doc: "Network is a hybrid network composed of [Module]s of different\nalgorithms, which presents the layers of all of them as a single\nemer.Network, in module order, for visualization, logging and\nweights files, and runs trials according to the [Component]\nphase / scheduling contract. Layer names must be unique\nacross all modules.", Embeds: []types.Field{{Name: "NetworkBase"}}, Fields: []types.Field{{Name: "Modules", Doc: "Modules are the modules, in the order that they are run."}, {Name: "layers", Doc: "layers are all of the layers, in module order."}}})
@@ -0,0 +1,25 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package labelcode provides encoding of string category labels
+(e.g., words, object names) into input patterns, for language and
+categorical-input models.
+
+Vocab maintains a mapping from labels to indexes, which can be saved
+and loaded as JSON so that the same codes are used across runs, and
+Encode produces a one-hot (localist) pattern, while Decode returns the
+label for the most active unit.
+
+NGrams produces a distributed pattern for any label, without a
+vocabulary, by hashing the character n-grams of the label onto units,
+so that labels with similar spelling have overlapping patterns.
+
+Embeddings loads pre-trained word vectors (GloVe or word2vec text
+formats) for looking up the vector for a token, with optional PCA
+projection down to a target layer shape.
+*/
+package labelcode
+
+//go:generate core generate -add-types
@@ -0,0 +1,218 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package labelcode
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"cogentcore.org/core/core"
+	"cogentcore.org/lab/matrix"
+	"cogentcore.org/lab/stats/metric"
+	"cogentcore.org/lab/tensor"
+)
+
+// Embeddings has pre-trained word vectors (embeddings), e.g., from GloVe
+// or word2vec, for looking up the vector for a token (word) to use as an
+// input pattern. Load the vectors with [Embeddings.OpenText], and
+// optionally reduce them to a layer shape with [Embeddings.PCA].
+type Embeddings struct {
+
+	// Vocab maps tokens to rows of the Vectors. Its Unknown label
+	// (e.g., "<unk>") is used for out-of-vocabulary tokens,
+	// if it is present in the vectors.
+	Vocab Vocab
+
+	// Vectors has the vector for each token in the Vocab, with
+	// the outer dimension as rows, and the inner dimensions as the
+	// shape of the vector (1D as loaded, or a layer shape after PCA).
+	Vectors *tensor.Float32
+
+	// Lower converts tokens to lower case when loading and
+	// looking up vectors, for vectors trained on lower case text.
+	Lower bool
+}
+
+// Len returns the number of tokens.
+func (em *Embeddings) Len() int {
+	return em.Vocab.Len()
+}
+
+// Dim returns the number of values in each vector.
+func (em *Embeddings) Dim() int {
+	if em.Vectors == nil || em.Len() == 0 {
+		return 0
+	}
+	return em.Vectors.Len() / em.Len()
+}
+
+// OpenText opens word vectors from given text file, in GloVe format
+// (one token per line followed by its vector values, separated by
+// spaces) or word2vec text format (the same, after a header line with
+// the number of tokens and vector size), which is gzip-decompressed
+// if it ends in .gz. If maxTokens > 0, only that many tokens are
+// loaded, which are typically the most frequent ones.
+func (em *Embeddings) OpenText(filename core.Filename, maxTokens int) error { //types:add
+	fp, err := os.Open(string(filename))
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+	if filepath.Ext(string(filename)) == ".gz" {
+		gzr, err := gzip.NewReader(fp)
+		if err != nil {
+			return err
+		}
+		defer gzr.Close()
+		return em.ReadText(gzr, maxTokens)
+	}
+	return em.ReadText(fp, maxTokens)
+}
+
+// ReadText reads word vectors from given reader, in GloVe or word2vec
+// text format. See [Embeddings.OpenText].
+func (em *Embeddings) ReadText(r io.Reader, maxTokens int) error {
+	em.Vocab = Vocab{Unknown: em.Vocab.Unknown}
+	var vals []float32
+	dim := 0
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	ln := 0
+	for sc.Scan() {
+		ln++
+		fs := strings.Fields(sc.Text())
+		if len(fs) == 0 {
+			continue
+		}
+		if ln == 1 && len(fs) == 2 {
+			_, err1 := strconv.Atoi(fs[0])
+			_, err2 := strconv.Atoi(fs[1])
+			if err1 == nil && err2 == nil { // word2vec header
+				continue
+			}
+		}
+		if dim == 0 {
+			dim = len(fs) - 1
+		}
+		if len(fs)-1 != dim {
+			return fmt.Errorf("labelcode.Embeddings: line %d has %d values instead of %d", ln, len(fs)-1, dim)
+		}
+		tok := fs[0]
+		if em.Lower {
+			tok = strings.ToLower(tok)
+		}
+		if em.hasToken(tok) {
+			continue // duplicate, e.g., from lower casing
+		}
+		for _, f := range fs[1:] {
+			v, err := strconv.ParseFloat(f, 32)
+			if err != nil {
+				return fmt.Errorf("labelcode.Embeddings: line %d: %w", ln, err)
+			}
+			vals = append(vals, float32(v))
+		}
+		em.Vocab.Add(tok)
+		if maxTokens > 0 && em.Len() >= maxTokens {
+			break
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	em.Vectors = tensor.NewFloat32FromValues(vals...)
+	em.Vectors.SetShapeSizes(em.Len(), dim)
+	return nil
+}
+
+// hasToken returns true if given token is actually in the Vocab,
+// as opposed to being mapped to the Unknown token.
+func (em *Embeddings) hasToken(tok string) bool {
+	idx := em.Vocab.IndexOf(tok)
+	return idx >= 0 && em.Vocab.Label(idx) == tok
+}
+
+// Lookup returns the row of the vector for given token, which is that
+// of the Vocab Unknown token if the token is not found and the Unknown
+// token is present, and otherwise -1.
+func (em *Embeddings) Lookup(token string) int {
+	if em.Lower {
+		token = strings.ToLower(token)
+	}
+	return em.Vocab.IndexOf(token)
+}
+
+// Encode sets out to the vector for given token, returning false if it
+// is not found, in which case out is set to the vector for the Unknown
+// token if present (see [Embeddings.Lookup]), and otherwise to 0.
+// If out has fewer values than the vectors (e.g., is empty), it is
+// reshaped to the shape of the vectors, and otherwise its shape is kept.
+func (em *Embeddings) Encode(token string, out tensor.Values) bool {
+	dim := em.Dim()
+	if out.Len() < dim {
+		out.SetShapeSizes(em.Vectors.ShapeSizes()[1:]...)
+	}
+	out.SetZeros()
+	row := em.Lookup(token)
+	if row < 0 {
+		return false
+	}
+	for i := range dim {
+		out.SetFloat1D(float64(em.Vectors.Values[row*dim+i]), i)
+	}
+	if em.Lower {
+		token = strings.ToLower(token)
+	}
+	return em.Vocab.Label(row) == token
+}
+
+// PCA projects the Vectors onto their top principal components (the
+// directions of greatest variance across tokens), with the number of
+// components given by the product of given sizes, which is the new shape
+// of each vector, e.g., the 2D shape of an input layer. This reduces the
+// dimensionality of the vectors while preserving as much of their
+// similarity structure as possible. The mean vector is subtracted
+// before projection, so the values are centered on 0.
+func (em *Embeddings) PCA(sizes ...int) error {
+	dim := em.Dim()
+	nc := 1
+	for _, s := range sizes {
+		nc *= s
+	}
+	if dim == 0 || nc <= 0 || nc > dim {
+		return fmt.Errorf("labelcode.Embeddings: PCA size %v must be between 1 and the vector size %d", sizes, dim)
+	}
+	n := em.Len()
+	em.Vectors.SetShapeSizes(n, dim)
+	cov := metric.CovarianceMatrix(metric.Covariance, em.Vectors)
+	vecs, _ := matrix.SVD(cov)
+	mean := make([]float64, dim)
+	for row := range n {
+		for i, v := range em.Vectors.Values[row*dim : (row+1)*dim] {
+			mean[i] += float64(v)
+		}
+	}
+	for i := range mean {
+		mean[i] /= float64(n)
+	}
+	proj := tensor.NewFloat32(append([]int{n}, sizes...)...)
+	for row := range n {
+		vec := em.Vectors.Values[row*dim : (row+1)*dim]
+		for c := range nc {
+			sum := 0.0
+			for i, v := range vec {
+				sum += (float64(v) - mean[i]) * vecs.Float(i, c)
+			}
+			proj.Values[row*nc+c] = float32(sum)
+		}
+	}
+	em.Vectors = proj
+	return nil
+}
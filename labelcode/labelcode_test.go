@@ -0,0 +1,128 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package labelcode
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cogentcore.org/core/core"
+	"cogentcore.org/lab/tensor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVocab(t *testing.T) {
+	vc := NewVocab("cat", "dog")
+	assert.Equal(t, 2, vc.Len())
+	out := tensor.NewFloat32()
+	idx, err := vc.Encode("bird", out)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, idx)
+	assert.Equal(t, []float32{0, 0, 1}, out.Values)
+	lb, di := vc.Decode(out)
+	assert.Equal(t, "bird", lb)
+	assert.Equal(t, 2, di)
+
+	lay := tensor.NewFloat32(2, 2) // layer shape is preserved
+	vc.Encode("dog", lay)
+	assert.Equal(t, []int{2, 2}, lay.ShapeSizes())
+	assert.Equal(t, []float32{0, 1, 0, 0}, lay.Values)
+
+	vc.Frozen = true
+	_, err = vc.Encode("fish", out)
+	assert.Error(t, err)
+	assert.Equal(t, -1, vc.IndexOf("fish"))
+	vc.Unknown = "<unk>"
+	idx, err = vc.Encode("fish", out)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, idx)
+	assert.Equal(t, 3, vc.Add("horse"))
+	assert.Equal(t, 4, vc.Len())
+
+	fnm := core.Filename(filepath.Join(t.TempDir(), "vocab.json"))
+	assert.NoError(t, vc.SaveJSON(fnm))
+	vc2 := &Vocab{}
+	assert.NoError(t, vc2.OpenJSON(fnm))
+	assert.Equal(t, vc.Labels, vc2.Labels)
+	assert.Equal(t, 1, vc2.IndexOf("dog"))
+	assert.Equal(t, 3, vc2.IndexOf("fish"))
+}
+
+func TestNGrams(t *testing.T) {
+	ng := NewNGrams(3, 200)
+	assert.Equal(t, []string{"#ca", "cat", "at#"}, ng.Grams("cat"))
+	assert.Equal(t, []string{"#a#"}, ng.Grams("a"))
+
+	overlap := func(a, b *tensor.Float32) int {
+		n := 0
+		for i, v := range a.Values {
+			if v > 0 && b.Values[i] > 0 {
+				n++
+			}
+		}
+		return n
+	}
+	cat, cats, dog := tensor.NewFloat32(), tensor.NewFloat32(), tensor.NewFloat32()
+	ng.Encode("cat", cat)
+	ng.Encode("cats", cats)
+	ng.Encode("dog", dog)
+	assert.Equal(t, 200, cat.Len())
+	assert.Greater(t, overlap(cat, cats), overlap(cat, dog))
+
+	again := tensor.NewFloat32()
+	ng.Encode("cat", again)
+	assert.Equal(t, cat.Values, again.Values)
+
+	ng.NHash = 2
+	ng.Encode("cat", again)
+	assert.Greater(t, overlap(again, again), overlap(cat, cat))
+}
+
+func TestEmbeddings(t *testing.T) {
+	txt := `4 3
+the 1 0 0.5
+Cat 0 1 0.5
+dog 0 0.9 0.4
+<unk> 0.1 0.1 0.1
+`
+	em := &Embeddings{Lower: true}
+	em.Vocab.Unknown = "<unk>"
+	assert.NoError(t, em.ReadText(strings.NewReader(txt), 0))
+	assert.Equal(t, 4, em.Len())
+	assert.Equal(t, 3, em.Dim())
+	assert.Equal(t, 1, em.Lookup("CAT"))
+	assert.Equal(t, 3, em.Lookup("fish"))
+
+	out := tensor.NewFloat32()
+	assert.True(t, em.Encode("dog", out))
+	assert.Equal(t, []float32{0, 0.9, 0.4}, out.Values)
+	assert.False(t, em.Encode("fish", out))
+	assert.Equal(t, []float32{0.1, 0.1, 0.1}, out.Values)
+	em.Vocab.Unknown = ""
+	assert.False(t, em.Encode("fish", out))
+	assert.Equal(t, []float32{0, 0, 0}, out.Values)
+
+	em2 := &Embeddings{}
+	assert.NoError(t, em2.ReadText(strings.NewReader(txt[4:]), 2)) // GloVe format, no header
+	assert.Equal(t, 2, em2.Len())
+	assert.Error(t, em2.ReadText(strings.NewReader("a 1 2\nb 1\n"), 0))
+
+	assert.NoError(t, em.PCA(1, 2))
+	assert.Equal(t, []int{4, 1, 2}, em.Vectors.ShapeSizes())
+	out = tensor.NewFloat32()
+	assert.True(t, em.Encode("cat", out))
+	assert.Equal(t, []int{1, 2}, out.ShapeSizes())
+	// cat and dog are close, and far from the
+	cat, dog, the := make([]float32, 2), make([]float32, 2), make([]float32, 2)
+	copy(cat, em.Vectors.Values[2:4])
+	copy(dog, em.Vectors.Values[4:6])
+	copy(the, em.Vectors.Values[0:2])
+	dist := func(a, b []float32) float32 {
+		return (a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1])
+	}
+	assert.Less(t, dist(cat, dog), dist(cat, the))
+	assert.Error(t, em.PCA(3))
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package labelcode
+
+import (
+	"hash/fnv"
+
+	"cogentcore.org/lab/tensor"
+)
+
+// NGrams produces distributed patterns for labels by hashing each
+// character n-gram of the label (including begin and end markers)
+// onto NHash units, so that any label can be encoded without a
+// vocabulary, and labels with similar spelling have overlapping
+// patterns. Different labels can collide on some units, which is
+// less likely with more units.
+type NGrams struct {
+
+	// N is the number of characters in each n-gram.
+	N int `default:"3" min:"1"`
+
+	// Size is the number of units in the pattern.
+	Size int `default:"100" min:"1"`
+
+	// NHash is the number of units that each n-gram activates.
+	NHash int `default:"1" min:"1"`
+
+	// Bound is the character used to mark the beginning and end
+	// of the label, so that n-grams at the edges are distinct.
+	Bound rune `default:"#"`
+}
+
+func (ng *NGrams) Defaults() {
+	ng.N = 3
+	ng.Size = 100
+	ng.NHash = 1
+	ng.Bound = '#'
+}
+
+// NewNGrams returns a new [NGrams] with given n-gram size and
+// number of units, with other parameters at defaults.
+func NewNGrams(n, size int) *NGrams {
+	ng := &NGrams{}
+	ng.Defaults()
+	ng.N = n
+	ng.Size = size
+	return ng
+}
+
+// Grams returns the character n-grams of given label, including
+// the Bound markers at the beginning and end. Labels shorter than N
+// produce a single n-gram of the whole marked label.
+func (ng *NGrams) Grams(label string) []string {
+	rs := []rune(string(ng.Bound) + label + string(ng.Bound))
+	if len(rs) <= ng.N {
+		return []string{string(rs)}
+	}
+	grams := make([]string, 0, len(rs)-ng.N+1)
+	for i := 0; i+ng.N <= len(rs); i++ {
+		grams = append(grams, string(rs[i:i+ng.N]))
+	}
+	return grams
+}
+
+// Units returns the unit indexes for given n-gram.
+func (ng *NGrams) Units(gram string) []int {
+	idxs := make([]int, ng.NHash)
+	for k := range ng.NHash {
+		h := fnv.New32a()
+		h.Write([]byte{byte(k)})
+		h.Write([]byte(gram))
+		idxs[k] = int(h.Sum32() % uint32(ng.Size))
+	}
+	return idxs
+}
+
+// Encode sets out to the binary distributed pattern for given label,
+// with the units for all of its n-grams set to 1, and all others to 0.
+// If out has fewer values than Size (e.g., is empty), it is reshaped to
+// 1D of size Size; otherwise its shape is preserved.
+func (ng *NGrams) Encode(label string, out tensor.Values) {
+	if out.Len() < ng.Size {
+		out.SetShapeSizes(ng.Size)
+	}
+	out.SetZeros()
+	for _, gram := range ng.Grams(label) {
+		for _, ui := range ng.Units(gram) {
+			out.SetFloat1D(1, ui)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package labelcode
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/labelcode.Embeddings", IDName: "embeddings", Doc: "Embeddings has pre-trained word vectors (embeddings), e.g., from GloVe\nor word2vec, for looking up the vector for a token (word) to use as an\ninput pattern. Load the vectors with [Embeddings.OpenText], and\noptionally reduce them to a layer shape with [Embeddings.PCA].", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Methods: []types.Method{{Name: "OpenText", Doc: "OpenText opens word vectors from given text file, in GloVe format\n(one token per line followed by its vector values, separated by\nspaces) or word2vec text format (the same, after a header line with\nthe number of tokens and vector size), which is gzip-decompressed\nif it ends in .gz. If maxTokens > 0, only that many tokens are\nloaded, which are typically the most frequent ones.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"filename", "maxTokens"}, Returns: []string{"error"}}}, Fields: []types.Field{{Name: "Vocab", Doc: "Vocab maps tokens to rows of the Vectors. Its Unknown label\n(e.g., \"<unk>\") is used for out-of-vocabulary tokens,\nif it is present in the vectors."}, {Name: "Vectors", Doc: "Vectors has the vector for each token in the Vocab, with\nthe outer dimension as rows, and the inner dimensions as the\nshape of the vector (1D as loaded, or a layer shape after PCA)."}, {Name: "Lower", Doc: "Lower converts tokens to lower case when loading and\nlooking up vectors, for vectors trained on lower case text."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/labelcode.NGrams", IDName: "n-grams", Doc: "NGrams produces distributed patterns for labels by hashing each\ncharacter n-gram of the label (including begin and end markers)\nonto NHash units, so that any label can be encoded without a\nvocabulary, and labels with similar spelling have overlapping\npatterns. Different labels can collide on some units, which is\nless likely with more units.", Fields: []types.Field{{Name: "N", Doc: "N is the number of characters in each n-gram."}, {Name: "Size", Doc: "Size is the number of units in the pattern."}, {Name: "NHash", Doc: "NHash is the number of units that each n-gram activates."}, {Name: "Bound", Doc: "Bound is the character used to mark the beginning and end\nof the label, so that n-grams at the edges are distinct."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/labelcode.Vocab", IDName: "vocab", Doc: "Vocab is a vocabulary of category labels, mapping each label to\na unique index, in the order added, for one-hot (localist) encoding.", Methods: []types.Method{{Name: "SaveJSON", Doc: "SaveJSON saves the vocabulary to given JSON file.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"filename"}, Returns: []string{"error"}}, {Name: "OpenJSON", Doc: "OpenJSON opens the vocabulary from given JSON file.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Args: []string{"filename"}, Returns: []string{"error"}}}, Fields: []types.Field{{Name: "Labels", Doc: "Labels are the labels, in index order."}, {Name: "Frozen", Doc: "Frozen prevents new labels from being added by [Vocab.Add],\ne.g., after training, so that unknown labels are mapped to\nthe Unknown label instead."}, {Name: "Unknown", Doc: "Unknown is the label to use for unknown (out-of-vocabulary)\nlabels when Frozen, e.g., \"<unk>\", which is added to the\nvocabulary if needed. If empty, unknown labels have index -1."}, {Name: "index", Doc: "index maps labels to indexes."}}})
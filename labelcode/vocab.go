@@ -0,0 +1,155 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package labelcode
+
+import (
+	"fmt"
+
+	"cogentcore.org/core/base/iox/jsonx"
+	"cogentcore.org/core/core"
+	"cogentcore.org/lab/tensor"
+)
+
+// Vocab is a vocabulary of category labels, mapping each label to
+// a unique index, in the order added, for one-hot (localist) encoding.
+type Vocab struct {
+
+	// Labels are the labels, in index order.
+	Labels []string
+
+	// Frozen prevents new labels from being added by [Vocab.Add],
+	// e.g., after training, so that unknown labels are mapped to
+	// the Unknown label instead.
+	Frozen bool
+
+	// Unknown is the label to use for unknown (out-of-vocabulary)
+	// labels when Frozen, e.g., "<unk>", which is added to the
+	// vocabulary if needed. If empty, unknown labels have index -1.
+	Unknown string
+
+	// index maps labels to indexes.
+	index map[string]int
+}
+
+// NewVocab returns a new [Vocab] with given labels.
+func NewVocab(labels ...string) *Vocab {
+	vc := &Vocab{}
+	for _, lb := range labels {
+		vc.Add(lb)
+	}
+	return vc
+}
+
+// Len returns the number of labels.
+func (vc *Vocab) Len() int {
+	return len(vc.Labels)
+}
+
+// reindex rebuilds the index map from the Labels.
+func (vc *Vocab) reindex() {
+	vc.index = make(map[string]int, len(vc.Labels))
+	for i, lb := range vc.Labels {
+		vc.index[lb] = i
+	}
+}
+
+// IndexOf returns the index of given label, or that of the
+// Unknown label if it is not in the vocabulary (-1 if none).
+func (vc *Vocab) IndexOf(label string) int {
+	if vc.index == nil {
+		vc.reindex()
+	}
+	if i, ok := vc.index[label]; ok {
+		return i
+	}
+	if vc.Unknown != "" {
+		if i, ok := vc.index[vc.Unknown]; ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add adds given label to the vocabulary if it is not already present
+// and the vocabulary is not Frozen, returning its index. If Frozen,
+// it returns the index of the Unknown label, which is added if needed,
+// or -1 if Unknown is not set.
+func (vc *Vocab) Add(label string) int {
+	if vc.index == nil {
+		vc.reindex()
+	}
+	if i, ok := vc.index[label]; ok {
+		return i
+	}
+	if vc.Frozen {
+		if vc.Unknown == "" {
+			return -1
+		}
+		label = vc.Unknown
+		if i, ok := vc.index[label]; ok {
+			return i
+		}
+	}
+	i := len(vc.Labels)
+	vc.Labels = append(vc.Labels, label)
+	vc.index[label] = i
+	return i
+}
+
+// Label returns the label for given index, or "" if out of range.
+func (vc *Vocab) Label(idx int) string {
+	if idx < 0 || idx >= len(vc.Labels) {
+		return ""
+	}
+	return vc.Labels[idx]
+}
+
+// Encode sets out to the one-hot pattern for given label, with the unit
+// at the label's index set to 1 and all others set to 0, adding the
+// label with [Vocab.Add] if it is new. If out has fewer values than
+// the vocabulary (e.g., is empty), it is reshaped to 1D of size Len;
+// otherwise its shape is preserved (e.g., a 2D layer shape).
+// It returns the label index, and an error if the label is unknown.
+func (vc *Vocab) Encode(label string, out tensor.Values) (int, error) {
+	idx := vc.Add(label)
+	if out.Len() < vc.Len() {
+		out.SetShapeSizes(vc.Len())
+	}
+	out.SetZeros()
+	if idx < 0 {
+		return idx, fmt.Errorf("labelcode.Vocab: label %q not in frozen vocabulary", label)
+	}
+	out.SetFloat1D(1, idx)
+	return idx, nil
+}
+
+// Decode returns the label and index of the most active unit in given
+// pattern, among the first Len units, or "", -1 if the pattern is empty.
+func (vc *Vocab) Decode(pat tensor.Tensor) (string, int) {
+	n := min(pat.Len(), vc.Len())
+	mi := -1
+	mx := 0.0
+	for i := range n {
+		v := pat.Float1D(i)
+		if mi < 0 || v > mx {
+			mi, mx = i, v
+		}
+	}
+	return vc.Label(mi), mi
+}
+
+// SaveJSON saves the vocabulary to given JSON file.
+func (vc *Vocab) SaveJSON(filename core.Filename) error { //types:add
+	return jsonx.Save(vc, string(filename))
+}
+
+// OpenJSON opens the vocabulary from given JSON file.
+func (vc *Vocab) OpenJSON(filename core.Filename) error { //types:add
+	if err := jsonx.Open(vc, string(filename)); err != nil {
+		return err
+	}
+	vc.reindex()
+	return nil
+}
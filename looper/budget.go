@@ -0,0 +1,144 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package looper
+
+import (
+	"fmt"
+	"time"
+
+	"cogentcore.org/core/enums"
+)
+
+// Budget limits the running of a [Stack] to a given wall-clock time
+// and/or number of iterations of its innermost level (e.g., Cycles).
+// When the budget is exceeded, the stack stops cleanly at the end of the
+// next iteration of the budget Level (e.g., Epoch), and the OnExceeded
+// functions are called, e.g., to do final logging and saving of weights.
+// This is what is needed to run within the time limits of cluster jobs.
+// Running again after the budget is exceeded continues from where it
+// stopped, with a fresh budget.
+type Budget struct {
+
+	// Duration is the wall-clock time budget, starting from the first
+	// run after the budget is set or reset. 0 = no time limit.
+	Duration time.Duration
+
+	// Cycles is the budget for the number of iterations of the innermost
+	// level of the stack (e.g., Cycle). 0 = no limit.
+	Cycles int
+
+	// Level is the level at the end of which the stack stops
+	// when the budget is exceeded, e.g., Epoch, which must be in the stack.
+	Level enums.Enum
+
+	// OnExceeded functions are called when the budget is exceeded,
+	// after the end of the Level iteration where the stack stops.
+	OnExceeded NamedFuncs
+
+	// Exceeded is set when the budget has been exceeded,
+	// and remains set until Reset.
+	Exceeded bool
+
+	// start is the start time of the budget.
+	start time.Time
+
+	// cycles is the number of innermost iterations run.
+	cycles int
+}
+
+// Reset resets the budget, so that time and cycles start
+// counting again from the next run.
+func (bg *Budget) Reset() {
+	bg.start = time.Time{}
+	bg.cycles = 0
+	bg.Exceeded = false
+}
+
+// Elapsed returns the wall-clock time elapsed since the budget started.
+func (bg *Budget) Elapsed() time.Duration {
+	if bg.start.IsZero() {
+		return 0
+	}
+	return time.Since(bg.start)
+}
+
+// CyclesRun returns the number of innermost iterations run
+// since the budget started.
+func (bg *Budget) CyclesRun() int {
+	return bg.cycles
+}
+
+// IsExceeded returns true if the time or cycle budget has been exceeded.
+func (bg *Budget) IsExceeded() bool {
+	if bg.Duration > 0 && bg.Elapsed() >= bg.Duration {
+		return true
+	}
+	if bg.Cycles > 0 && bg.cycles >= bg.Cycles {
+		return true
+	}
+	return false
+}
+
+// String returns a summary of the budget and its current usage.
+func (bg *Budget) String() string {
+	return fmt.Sprintf("Budget: stop at end of %s, elapsed: %v of %v, cycles: %d of %d, exceeded: %v", bg.Level, bg.Elapsed().Round(time.Second), bg.Duration, bg.cycles, bg.Cycles, bg.Exceeded)
+}
+
+// begin is called at the start of running, starting the clock if not
+// already started, and resetting the budget if it was exceeded, so that
+// running again continues with a fresh budget.
+func (bg *Budget) begin() {
+	if bg.Exceeded {
+		bg.Reset()
+	}
+	if bg.start.IsZero() {
+		bg.start = time.Now()
+	}
+}
+
+// SetBudget sets a [Budget] for running this Stack, with given wall-clock
+// duration and innermost level cycles (0 = no limit for either), stopping
+// at the end of given level when exceeded. Add OnExceeded functions
+// to the returned Budget for final logging and saving.
+func (st *Stack) SetBudget(level enums.Enum, duration time.Duration, cycles int) *Budget {
+	st.Budget = &Budget{Level: level, Duration: duration, Cycles: cycles}
+	return st.Budget
+}
+
+// SetBudget sets a [Budget] for running the Stack of given mode.
+// See [Stack.SetBudget].
+func (ls *Stacks) SetBudget(mode, level enums.Enum, duration time.Duration, cycles int) *Budget {
+	return ls.Stacks[mode].SetBudget(level, duration, cycles)
+}
+
+// BudgetExceeded returns true if the Stack for given mode has a [Budget]
+// that has been exceeded.
+func (ls *Stacks) BudgetExceeded(mode enums.Enum) bool {
+	st := ls.Stacks[mode]
+	return st != nil && st.Budget != nil && st.Budget.Exceeded
+}
+
+// checkBudget is called at the end of each iteration of given level in
+// runLevel, returning true if the budget has been exceeded, in which case
+// the stack stops.
+func (ls *Stacks) checkBudget(st *Stack, currentLevel int) bool {
+	bg := st.Budget
+	if bg == nil {
+		return false
+	}
+	if currentLevel == len(st.Order)-1 {
+		bg.cycles++
+	}
+	if bg.Exceeded || st.Order[currentLevel] != bg.Level || !bg.IsExceeded() {
+		return false
+	}
+	bg.Exceeded = true
+	if PrintControlFlow {
+		fmt.Printf("%s%s: %s\n", indent(currentLevel), bg.Level.String(), bg.String())
+	}
+	bg.OnExceeded.Run()
+	ls.internalStop = true
+	return true
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package looper
+
+import (
+	"fmt"
+	"strings"
+
+	"cogentcore.org/core/enums"
+)
+
+// StackDef is a declarative definition of a [Stack], as an ordered
+// list of levels with their counters and function lists, which can be
+// written as a single literal expression, instead of a sequence of
+// configuration calls. Use [Stacks.AddStackDef] to add the Stack, and
+// [Stack.Def] to get the definition of an existing Stack.
+type StackDef struct {
+
+	// Mode identifies the mode of processing of the stack, e.g., Train or Test.
+	Mode enums.Enum
+
+	// StepLevel is the default level for stepping.
+	StepLevel enums.Enum
+
+	// OnInit are functions to run when Init is called.
+	OnInit NamedFuncs
+
+	// Levels are the level definitions, ordered from top to bottom,
+	// so longer timescales like Run are at the start.
+	Levels []LevelDef
+}
+
+// LevelDef is a declarative definition of one level [Loop] in a [StackDef].
+type LevelDef struct {
+
+	// Level is the level enum value.
+	Level enums.Enum
+
+	// Max is the maximum counter value (number of iterations).
+	Max int
+
+	// Inc is the counter increment per iteration, which defaults to 1.
+	Inc int
+
+	// OnStart functions are called at the beginning of each iteration.
+	OnStart NamedFuncs
+
+	// OnEnd functions are called at the end of each iteration.
+	OnEnd NamedFuncs
+
+	// IsDone functions are called after each iteration,
+	// and the loop is terminated if any return true.
+	IsDone NamedFuncs
+
+	// Events are called when the counter is at their AtCounter value.
+	Events []*Event
+
+	// Phases, if set, make this a phase level, where Max is the number
+	// of phases. See [Stack.AddPhaseLevel].
+	Phases []*Phase
+}
+
+// Func returns a [NamedFunc] with given name for given function,
+// for use in the function lists of a [LevelDef].
+func Func(name string, fun func()) NamedFunc {
+	return NamedFunc{Name: name, Func: func() bool { fun(); return true }}
+}
+
+// BoolFunc returns a [NamedFunc] with given name for given function
+// with a bool return value, for the IsDone list of a [LevelDef].
+func BoolFunc(name string, fun func() bool) NamedFunc {
+	return NamedFunc{Name: name, Func: fun}
+}
+
+// NewStackFromDef returns a new [Stack] from given definition.
+// The function lists are copied, so the definition can be reused.
+func NewStackFromDef(def *StackDef) *Stack {
+	st := NewStack(def.Mode, def.StepLevel)
+	st.OnInit = append(NamedFuncs{}, def.OnInit...)
+	for _, ld := range def.Levels {
+		inc := ld.Inc
+		if inc == 0 {
+			inc = 1
+		}
+		mx := ld.Max
+		if len(ld.Phases) > 0 {
+			mx = len(ld.Phases)
+		}
+		st.AddLevelIncr(ld.Level, mx, inc)
+		lp := st.Loops[ld.Level]
+		lp.OnStart = append(NamedFuncs{}, ld.OnStart...)
+		lp.OnEnd = append(NamedFuncs{}, ld.OnEnd...)
+		lp.IsDone = append(NamedFuncs{}, ld.IsDone...)
+		for _, ev := range ld.Events {
+			lp.Events = append(lp.Events, &Event{Name: ev.Name, AtCounter: ev.AtCounter, OnEvent: append(NamedFuncs{}, ev.OnEvent...)})
+		}
+		for _, ph := range ld.Phases {
+			lp.Phases = append(lp.Phases, ph.clone())
+		}
+	}
+	return st
+}
+
+// AddStackDef adds a new [Stack] from given definition,
+// replacing any existing Stack for the same Mode.
+func (ls *Stacks) AddStackDef(def *StackDef) *Stack {
+	st := NewStackFromDef(def)
+	ls.Stacks[def.Mode] = st
+	return st
+}
+
+// Def returns the declarative definition of this Stack,
+// reflecting all of the functions added to it.
+func (st *Stack) Def() *StackDef {
+	def := &StackDef{Mode: st.Mode, StepLevel: st.StepLevel}
+	def.OnInit = append(NamedFuncs{}, st.OnInit...)
+	for _, lv := range st.Order {
+		lp := st.Loops[lv]
+		ld := LevelDef{Level: lv, Max: lp.Counter.Max, Inc: lp.Counter.Inc}
+		ld.OnStart = append(NamedFuncs{}, lp.OnStart...)
+		ld.OnEnd = append(NamedFuncs{}, lp.OnEnd...)
+		ld.IsDone = append(NamedFuncs{}, lp.IsDone...)
+		ld.Events = append([]*Event{}, lp.Events...)
+		if len(lp.Phases) > 0 {
+			ld.Phases = append([]*Phase{}, lp.Phases...)
+		}
+		def.Levels = append(def.Levels, ld)
+	}
+	return def
+}
+
+// PlanStep is one step in the execution plan of a [Stack],
+// as returned by [Stack.PlanSteps], which is a function
+// that runs at a given level, at a given point in the loop.
+type PlanStep struct {
+
+	// Level is the level where the function runs.
+	// It is nil for OnInit functions.
+	Level enums.Enum
+
+	// Depth is the depth of the Level in the Stack Order (0 = top),
+	// and is -1 for OnInit functions.
+	Depth int
+
+	// When is when the function runs: Init, Event, Start, End, or IsDone.
+	When string
+
+	// Phase is the name of the [Phase] for phase Start and End functions.
+	Phase string
+
+	// AtCounter is the counter value for Event functions.
+	AtCounter int
+
+	// Name is the name of the function.
+	Name string
+}
+
+// String returns a one-line description of the step.
+func (ps *PlanStep) String() string {
+	lv := "Init"
+	if ps.Level != nil {
+		lv = ps.Level.String()
+	}
+	if ps.When == "Event" {
+		return fmt.Sprintf("%s Event [at %d]: %s", lv, ps.AtCounter, ps.Name)
+	}
+	if ps.When == "Init" {
+		return "Init: " + ps.Name
+	}
+	if ps.Phase != "" {
+		return fmt.Sprintf("%s %s %s: %s", lv, ps.Phase, ps.When, ps.Name)
+	}
+	return fmt.Sprintf("%s %s: %s", lv, ps.When, ps.Name)
+}
+
+// PlanSteps returns the list of all the functions in this Stack,
+// in the order in which they are run within one iteration of each
+// level: Events, OnStart, Phase OnStart, the levels below, Phase OnEnd,
+// OnEnd, and IsDone. The OnInit functions are first.
+func (st *Stack) PlanSteps() []PlanStep {
+	var steps []PlanStep
+	for _, fn := range st.OnInit {
+		steps = append(steps, PlanStep{Depth: -1, When: "Init", Name: fn.Name})
+	}
+	var add func(depth int)
+	add = func(depth int) {
+		if depth >= len(st.Order) {
+			return
+		}
+		lv := st.Order[depth]
+		lp := st.Loops[lv]
+		for _, ev := range lp.Events {
+			for _, fn := range ev.OnEvent {
+				steps = append(steps, PlanStep{Level: lv, Depth: depth, When: "Event", AtCounter: ev.AtCounter, Name: fn.Name})
+			}
+		}
+		for _, fn := range lp.OnStart {
+			steps = append(steps, PlanStep{Level: lv, Depth: depth, When: "Start", Name: fn.Name})
+		}
+		for _, ph := range lp.Phases {
+			for _, fn := range ph.OnStart {
+				steps = append(steps, PlanStep{Level: lv, Depth: depth, When: "Start", Phase: ph.Name, Name: fn.Name})
+			}
+		}
+		add(depth + 1)
+		for _, ph := range lp.Phases {
+			for _, fn := range ph.OnEnd {
+				steps = append(steps, PlanStep{Level: lv, Depth: depth, When: "End", Phase: ph.Name, Name: fn.Name})
+			}
+		}
+		for _, fn := range lp.OnEnd {
+			steps = append(steps, PlanStep{Level: lv, Depth: depth, When: "End", Name: fn.Name})
+		}
+		for _, fn := range lp.IsDone {
+			steps = append(steps, PlanStep{Level: lv, Depth: depth, When: "IsDone", Name: fn.Name})
+		}
+	}
+	add(0)
+	return steps
+}
+
+// Plan returns a human-readable plan of the functions that run at each
+// level of this Stack, in order, as a "cheat sheet" of the control flow,
+// with each function on a separate numbered line, e.g.:
+//
+//	Train:
+//	   1. Init: InitRun
+//	   for Epoch = 0; Epoch < 3; Epoch++ {
+//	      2. Epoch Event [at 2]: EpochTwoEvent
+//	      3. Epoch Start: NewEpoch
+//	      for Trial = 0; Trial < 2; Trial++ {
+//	         4. Trial Start: ApplyInputs
+//	      }
+//	      5. Epoch End: LogEpoch
+//	   }
+func (st *Stack) Plan() string {
+	var sb strings.Builder
+	sb.WriteString(st.Mode.String() + ":\n")
+	n := 0
+	line := func(depth int, s string) {
+		sb.WriteString(indent(depth+1) + s + "\n")
+	}
+	steps := st.PlanSteps()
+	si := 0
+	for ; si < len(steps) && steps[si].Depth < 0; si++ {
+		n++
+		line(0, fmt.Sprintf("%d. %s", n, steps[si].String()))
+	}
+	var plan func(depth int)
+	plan = func(depth int) {
+		if depth >= len(st.Order) {
+			return
+		}
+		lv := st.Order[depth]
+		lp := st.Loops[lv]
+		nm := lv.String()
+		inc := nm + "++"
+		if lp.Counter.Inc > 1 {
+			inc = fmt.Sprintf("%s += %d", nm, lp.Counter.Inc)
+		}
+		if len(lp.Phases) > 0 {
+			line(depth, fmt.Sprintf("for %s in %s {", nm, lp.phasesString()))
+		} else {
+			mx := fmt.Sprintf("%d", lp.Counter.Max)
+			if depth > 0 && len(st.Level(depth-1).Phases) > 0 {
+				mx = st.Order[depth-1].String() + ".Duration"
+			}
+			line(depth, fmt.Sprintf("for %s = 0; %s < %s; %s {", nm, nm, mx, inc))
+		}
+		for ; si < len(steps) && steps[si].Depth == depth && (steps[si].When == "Event" || steps[si].When == "Start"); si++ {
+			n++
+			line(depth+1, fmt.Sprintf("%d. %s", n, steps[si].String()))
+		}
+		plan(depth + 1)
+		for ; si < len(steps) && steps[si].Depth == depth; si++ {
+			n++
+			line(depth+1, fmt.Sprintf("%d. %s", n, steps[si].String()))
+		}
+		line(depth, "}")
+	}
+	plan(0)
+	return sb.String()
+}
+
+// Plan returns a human-readable plan of the functions that run at each
+// level of each Stack, in Mode order. See [Stack.Plan].
+func (ls *Stacks) Plan() string {
+	var sb strings.Builder
+	for _, m := range ls.Modes() {
+		sb.WriteString(ls.Stacks[m].Plan())
+	}
+	return sb.String()
+}
@@ -18,4 +18,9 @@ const (
 	Trial
 	Epoch
 	Run
+
+	// Phase is added at the end so the values of the other levels do not
+	// change: the nesting of levels is determined by their order in
+	// a Stack, not by their values.
+	Phase
 )
@@ -20,7 +20,9 @@ import (
 //	for {
 //		Events[Counter == AtCounter] // run events at counter
 //		OnStart()
+//		    Phases[Counter].OnStart() // if a phase level
 //		    Run Sub-Loop to completion
+//		    Phases[Counter].OnEnd()
 //		OnEnd()
 //		Counter += Inc
 //		if Counter >= Max || IsDone() {
@@ -47,6 +49,11 @@ type Loop struct {
 
 	// StepCount is the default step count for this loop level.
 	StepCount int
+
+	// Phases, if set, make this a phase level, where each iteration
+	// runs the next [Phase], which sets the Max of the level below.
+	// See [Stack.AddPhaseLevel].
+	Phases []*Phase
 }
 
 // NewLoop returns a new loop with given Counter Max and increment.
@@ -106,6 +113,17 @@ func (lp *Loop) DocString(st *Stack, level int) string {
 		ctrs = fmt.Sprintf("[0 : %d]:\n", lp.Counter.Max)
 	}
 	sb.WriteString(indent(level+1) + st.Order[level].String() + ctrs)
+	if len(lp.Phases) > 0 {
+		sb.WriteString(indent(level+2) + "Phases: " + lp.phasesString() + "\n")
+		for _, ph := range lp.Phases {
+			if len(ph.OnStart) > 0 {
+				sb.WriteString(indent(level+3) + ph.Name + " Start:  " + ph.OnStart.String() + "\n")
+			}
+			if len(ph.OnEnd) > 0 {
+				sb.WriteString(indent(level+3) + ph.Name + " End:    " + ph.OnEnd.String() + "\n")
+			}
+		}
+	}
 	if len(lp.Events) > 0 {
 		sb.WriteString(indent(level+2) + "Events:\n")
 		for _, ev := range lp.Events {
@@ -0,0 +1,173 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package looper
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"cogentcore.org/core/enums"
+)
+
+// Phase is a named phase within a phase level [Loop], such as the
+// minus and plus phases of a trial, or the quarters of an alpha cycle,
+// which has its own OnStart and OnEnd functions, and a Duration that
+// determines the number of iterations of the level below it (e.g., Cycles).
+// Each iteration of a phase level loop runs the next phase, so that
+// algorithms and environments can hook phase boundaries through the same
+// mechanism as trials and epochs. See [Stack.AddPhaseLevel].
+type Phase struct {
+
+	// Name of the phase, e.g., Minus or Plus.
+	Name string
+
+	// Duration is the number of iterations of the level below in this phase,
+	// which is set as the Max of its Counter at the start of the phase.
+	Duration int
+
+	// OnStart functions are called at the start of the phase,
+	// after the OnStart functions of the phase level Loop.
+	OnStart NamedFuncs
+
+	// OnEnd functions are called at the end of the phase,
+	// before the OnEnd functions of the phase level Loop.
+	OnEnd NamedFuncs
+}
+
+// NewPhase returns a new Phase with given name and duration.
+func NewPhase(name string, duration int) *Phase {
+	return &Phase{Name: name, Duration: duration}
+}
+
+// clone returns a copy of the Phase with its own function lists,
+// so that adding functions to one does not affect the other.
+func (ph *Phase) clone() *Phase {
+	return &Phase{Name: ph.Name, Duration: ph.Duration, OnStart: slices.Clone(ph.OnStart), OnEnd: slices.Clone(ph.OnEnd)}
+}
+
+// String describes the Phase in human readable text.
+func (ph *Phase) String() string {
+	return fmt.Sprintf("%s[%d]", ph.Name, ph.Duration)
+}
+
+// AddPhaseLevel adds a new phase level to this Stack, with given phases,
+// where the counter iterates over the phases, each of which sets the Max
+// of the counter for the next level down (e.g., Cycle) to its Duration.
+// As with AddLevel, the order in which levels are added determines
+// their nesting, e.g., Trial, Phase, Cycle.
+func (st *Stack) AddPhaseLevel(level enums.Enum, phases ...*Phase) *Stack {
+	st.AddLevel(level, len(phases))
+	st.Loops[level].Phases = phases
+	return st
+}
+
+// Phase returns the current [Phase] for this loop,
+// based on the counter, or nil if it is not a phase level.
+func (lp *Loop) Phase() *Phase {
+	if len(lp.Phases) == 0 {
+		return nil
+	}
+	ci := lp.Counter.Cur
+	if ci < 0 || ci >= len(lp.Phases) {
+		return nil
+	}
+	return lp.Phases[ci]
+}
+
+// PhaseByName returns the [Phase] with given name, or nil if not found.
+func (lp *Loop) PhaseByName(name string) *Phase {
+	for _, ph := range lp.Phases {
+		if ph.Name == name {
+			return ph
+		}
+	}
+	return nil
+}
+
+// phasesString returns a summary of the phases in the loop.
+func (lp *Loop) phasesString() string {
+	ps := make([]string, len(lp.Phases))
+	for i, ph := range lp.Phases {
+		ps[i] = ph.String()
+	}
+	return strings.Join(ps, ", ")
+}
+
+// Phase returns the [Phase] with given name at given level in given mode,
+// or nil if not found.
+func (ls *Stacks) Phase(mode, level enums.Enum, name string) *Phase {
+	lp := ls.Loop(mode, level)
+	if lp == nil {
+		return nil
+	}
+	return lp.PhaseByName(name)
+}
+
+// AddOnPhaseStart adds given function taking mode arg to the OnStart
+// functions of the Phase with given name at given level, in all stacks.
+func (ls *Stacks) AddOnPhaseStart(level enums.Enum, phase, name string, fun func(mode enums.Enum)) {
+	for m, st := range ls.Stacks {
+		if lp := st.Loops[level]; lp != nil {
+			if ph := lp.PhaseByName(phase); ph != nil {
+				ph.OnStart.Add(name, func() { fun(m) })
+			}
+		}
+	}
+}
+
+// AddOnPhaseEnd adds given function taking mode arg to the OnEnd
+// functions of the Phase with given name at given level, in all stacks.
+func (ls *Stacks) AddOnPhaseEnd(level enums.Enum, phase, name string, fun func(mode enums.Enum)) {
+	for m, st := range ls.Stacks {
+		if lp := st.Loops[level]; lp != nil {
+			if ph := lp.PhaseByName(phase); ph != nil {
+				ph.OnEnd.Add(name, func() { fun(m) })
+			}
+		}
+	}
+}
+
+// AddPhaseOnlyStack adds a new Stack for given mode that is a copy of the
+// Stack for the from mode, except that the phase level only runs the phase
+// with given name, with given duration if > 0 (e.g., a reduced number of
+// cycles). For example, running only the Minus phase, without the Plus
+// phase and its learning functions, roughly halves the compute for fast
+// inference and testing sweeps, which can be interleaved with training by
+// running the new mode periodically. The function lists of the from Stack
+// are copied, so this is typically called right after configuring the
+// levels, before functions are added with AddOnStartToAll, AddOnPhaseEnd,
+// etc, so that they are added to the new Stack for its own mode.
+// Any learning functions at other levels must not be added for this mode.
+// Returns an error if the from Stack does not have a phase level with
+// the given phase.
+func (ls *Stacks) AddPhaseOnlyStack(mode, from, level enums.Enum, phase string, duration int) (*Stack, error) {
+	fst := ls.Stacks[from]
+	if fst == nil {
+		return nil, fmt.Errorf("looper.AddPhaseOnlyStack: stack %s not found", from)
+	}
+	lp := fst.Loops[level]
+	if lp == nil {
+		return nil, fmt.Errorf("looper.AddPhaseOnlyStack: level %s not found in stack %s", level, from)
+	}
+	ph := lp.PhaseByName(phase)
+	if ph == nil {
+		return nil, fmt.Errorf("looper.AddPhaseOnlyStack: phase %q not found at level %s in stack %s", phase, level, from)
+	}
+	def := fst.Def()
+	def.Mode = mode
+	for i := range def.Levels {
+		ld := &def.Levels[i]
+		if ld.Level != level {
+			continue
+		}
+		nph := ph.clone()
+		if duration > 0 {
+			nph.Duration = duration
+		}
+		ld.Phases = []*Phase{nph}
+	}
+	return ls.AddStackDef(def), nil
+}
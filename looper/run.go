@@ -29,12 +29,11 @@ func (ss *Stacks) runLevel(currentLevel int) (bool, enums.Enum) {
 	ctr := &loop.Counter
 
 	for ctr.Cur < ctr.Max || ctr.Max <= 0 { // Loop forever for non-maxes
-		stoplev := int64(-1)
+		stopAtLevelOrLarger := true
 		if st.StopLevel != nil {
-			stoplev = st.StopLevel.Int64()
 			stoppedLevel = st.StopLevel
+			stopAtLevelOrLarger = st.atOrAbove(level, st.StopLevel)
 		}
-		stopAtLevelOrLarger := st.Order[currentLevel].Int64() >= stoplev
 		if st.StopFlag && stopAtLevelOrLarger {
 			ss.internalStop = true
 		}
@@ -64,6 +63,12 @@ func (ss *Stacks) runLevel(currentLevel int) (bool, enums.Enum) {
 				}
 			}
 			loop.OnStart.Run()
+			if ph := loop.Phase(); ph != nil {
+				if currentLevel+1 < len(st.Order) && ph.Duration > 0 {
+					st.Level(currentLevel + 1).Counter.Max = ph.Duration
+				}
+				ph.OnStart.Run()
+			}
 		} else if PrintControlFlow {
 			fmt.Printf("%s%s: Skipping Start: %d\n", indent(currentLevel), level.String(), ctr.Cur)
 		}
@@ -77,6 +82,9 @@ func (ss *Stacks) runLevel(currentLevel int) (bool, enums.Enum) {
 			if PrintControlFlow {
 				fmt.Printf("%s%s: End: %d\n", indent(currentLevel), level.String(), ctr.Cur)
 			}
+			if ph := loop.Phase(); ph != nil {
+				ph.OnEnd.Run()
+			}
 			loop.OnEnd.Run()
 			ctr.Incr()
 			// Reset the counter at the next level.
@@ -94,6 +102,10 @@ func (ss *Stacks) runLevel(currentLevel int) (bool, enums.Enum) {
 					goto exitLoop // Exit IsDone and Counter for-loops without flag variable.
 				}
 			}
+
+			if ss.checkBudget(st, currentLevel) || ss.checkUntil(st, currentLevel) {
+				return false, level
+			}
 		}
 	}
 
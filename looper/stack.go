@@ -6,6 +6,7 @@ package looper
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"cogentcore.org/core/enums"
@@ -53,6 +54,14 @@ type Stack struct {
 	// StepCount is a saved copy of StopCount for stepping.
 	// This is what was set for last Step call (which sets StopCount) or by GUI.
 	StepCount int
+
+	// Budget is an optional wall-clock time and cycle budget for running
+	// this stack. See [Stack.SetBudget].
+	Budget *Budget
+
+	// untilLevel and untilFunc are the level and condition for RunUntil.
+	untilLevel enums.Enum
+	untilFunc  func() bool
 }
 
 // NewStack returns a new Stack for given mode and default step level.
@@ -139,6 +148,19 @@ func (st *Stack) LevelBelow(level enums.Enum) (enums.Enum, bool) {
 	return level, false
 }
 
+// atOrAbove returns true if given level is at or above the ref level,
+// according to their order in the stack, which determines the nesting
+// of levels independent of their enum values. If either level is not
+// in the stack, their enum values are compared, where higher levels
+// have larger values by convention.
+func (st *Stack) atOrAbove(level, ref enums.Enum) bool {
+	li, ri := slices.Index(st.Order, level), slices.Index(st.Order, ref)
+	if li < 0 || ri < 0 {
+		return level.Int64() >= ref.Int64()
+	}
+	return li <= ri
+}
+
 //////// Control
 
 // SetStep sets stepping to given level and number of iterations.
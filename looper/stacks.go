@@ -80,6 +80,9 @@ func (ls *Stacks) ResetAndRun(mode enums.Enum) enums.Enum {
 func (ls *Stacks) Cont() enums.Enum {
 	ls.isRunning = true
 	ls.internalStop = false
+	if st := ls.Stacks[ls.Mode]; st != nil && st.Budget != nil {
+		st.Budget.begin()
+	}
 	_, stop := ls.runLevel(0) // 0 Means the top level loop
 	ls.isRunning = false
 	return stop
@@ -187,10 +190,13 @@ func (ls *Stacks) IsRunning() bool {
 }
 
 // InitMode initializes [Stack] of given mode,
-// resetting counters and calling the OnInit functions.
+// resetting counters and any Budget, and calling the OnInit functions.
 func (ls *Stacks) InitMode(mode enums.Enum) {
 	ls.ResetCountersByMode(mode)
 	st := ls.Stacks[mode]
+	if st.Budget != nil {
+		st.Budget.Reset()
+	}
 	st.OnInit.Run()
 }
 
@@ -238,7 +244,7 @@ func (ls *Stacks) ResetCountersBelow(mode enums.Enum, level enums.Enum) {
 			continue
 		}
 		for lt, loop := range st.Loops {
-			if lt.Int64() > level.Int64() {
+			if !st.atOrAbove(level, lt) {
 				continue
 			}
 			loop.Counter.Cur = 0
@@ -7,8 +7,11 @@ package looper
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"cogentcore.org/core/enums"
 	"github.com/emer/emergent/v2/looper/levels"
+	"github.com/stretchr/testify/assert"
 )
 
 var printTest = false
@@ -232,3 +235,272 @@ func TestStepIncr(t *testing.T) {
 		}
 	}
 }
+
+func ExampleStack_Plan() {
+	stacks := NewStacks()
+	stacks.AddStackDef(&StackDef{Mode: levels.Train, StepLevel: levels.Trial,
+		OnInit: NamedFuncs{Func("InitRun", func() {})},
+		Levels: []LevelDef{
+			{Level: levels.Epoch, Max: 3,
+				OnStart: NamedFuncs{Func("NewEpoch", func() {})},
+				OnEnd:   NamedFuncs{Func("LogEpoch", func() {})},
+				Events:  []*Event{NewEvent("EpochTwoEvent", 2, func() {})},
+			},
+			{Level: levels.Trial, Max: 2,
+				OnStart: NamedFuncs{Func("ApplyInputs", func() {})},
+				IsDone:  NamedFuncs{BoolFunc("Stop", func() bool { return false })},
+			},
+		}})
+
+	fmt.Print(stacks.Plan())
+
+	// Output:
+	// Train:
+	//    1. Init: InitRun
+	//    for Epoch = 0; Epoch < 3; Epoch++ {
+	//       2. Epoch Event [at 2]: EpochTwoEvent
+	//       3. Epoch Start: NewEpoch
+	//       for Trial = 0; Trial < 2; Trial++ {
+	//          4. Trial Start: ApplyInputs
+	//          5. Trial IsDone: Stop
+	//       }
+	//       6. Epoch End: LogEpoch
+	//    }
+}
+
+func TestStackDef(t *testing.T) {
+	trials := 0
+	def := &StackDef{Mode: levels.Train, StepLevel: levels.Trial,
+		Levels: []LevelDef{
+			{Level: levels.Epoch, Max: 3},
+			{Level: levels.Trial, Max: 4, Inc: 2, OnStart: NamedFuncs{Func("Count", func() { trials++ })}},
+		}}
+	stacks := NewStacks()
+	st := stacks.AddStackDef(def)
+	assert.Equal(t, []enums.Enum{levels.Epoch, levels.Trial}, st.Order)
+	assert.Equal(t, 1, st.Loops[levels.Epoch].Counter.Inc)
+	stacks.Run(levels.Train)
+	assert.Equal(t, 6, trials)
+
+	// definitions round trip, and adding to the stack does not modify the def
+	st.Loops[levels.Trial].OnEnd.Add("End", func() {})
+	assert.Equal(t, 0, len(def.Levels[1].OnEnd))
+	sd := st.Def()
+	assert.Equal(t, 1, len(sd.Levels[1].OnEnd))
+	assert.Equal(t, 4, sd.Levels[1].Max)
+	steps := NewStackFromDef(sd).PlanSteps()
+	assert.Equal(t, 2, len(steps))
+	assert.Equal(t, "Trial End: End", steps[1].String())
+}
+
+func ExampleStack_AddPhaseLevel() {
+	stacks := NewStacks()
+	stacks.AddStack(levels.Train, levels.Trial).
+		AddLevel(levels.Trial, 2).
+		AddPhaseLevel(levels.Phase, NewPhase("Minus", 3), NewPhase("Plus", 1)).
+		AddLevel(levels.Cycle, 0)
+
+	cycles := 0
+	stacks.Loop(levels.Train, levels.Trial).OnStart.Add("Trial Start", func() { fmt.Println("Trial Start") })
+	stacks.Loop(levels.Train, levels.Cycle).OnEnd.Add("Cycle", func() { cycles++ })
+	stacks.AddOnPhaseStart(levels.Phase, "Minus", "MinusStart", func(mode enums.Enum) { fmt.Println("  Minus Start", mode) })
+	stacks.AddOnPhaseEnd(levels.Phase, "Minus", "MinusEnd", func(mode enums.Enum) { fmt.Println("  Minus End", cycles) })
+	stacks.AddOnPhaseEnd(levels.Phase, "Plus", "PlusEnd", func(mode enums.Enum) { fmt.Println("  Plus End", cycles) })
+
+	stacks.Run(levels.Train)
+
+	// Output:
+	// Trial Start
+	//   Minus Start Train
+	//   Minus End 3
+	//   Plus End 4
+	// Trial Start
+	//   Minus Start Train
+	//   Minus End 7
+	//   Plus End 8
+}
+
+func TestPhasePlan(t *testing.T) {
+	stacks := NewStacks()
+	stacks.AddStack(levels.Train, levels.Trial).
+		AddLevel(levels.Trial, 2).
+		AddPhaseLevel(levels.Phase, NewPhase("Minus", 3), NewPhase("Plus", 1)).
+		AddLevel(levels.Cycle, 0)
+	stacks.AddOnPhaseEnd(levels.Phase, "Plus", "DWt", func(mode enums.Enum) {})
+	st := stacks.Stacks[levels.Train]
+	assert.Equal(t, 2, st.Loops[levels.Phase].Counter.Max)
+	assert.Equal(t, "Plus", stacks.Phase(levels.Train, levels.Phase, "Plus").Name)
+	assert.Nil(t, stacks.Phase(levels.Train, levels.Trial, "Plus"))
+
+	plan := `Train:
+   for Trial = 0; Trial < 2; Trial++ {
+      for Phase in Minus[3], Plus[1] {
+         for Cycle = 0; Cycle < Phase.Duration; Cycle++ {
+         }
+         1. Phase Plus End: DWt
+      }
+   }
+`
+	assert.Equal(t, plan, st.Plan())
+
+	// the def round trip includes the phases
+	st2 := NewStackFromDef(st.Def())
+	assert.Equal(t, plan, st2.Plan())
+	assert.Equal(t, 2, st2.Loops[levels.Phase].Counter.Max)
+
+	// step by phase
+	cycles := 0
+	st.Loops[levels.Cycle].OnEnd.Add("Cycle", func() { cycles++ })
+	stacks.Step(levels.Train, 1, levels.Phase)
+	assert.Equal(t, 3, cycles)
+	stacks.Step(levels.Train, 1, levels.Phase)
+	assert.Equal(t, 4, cycles)
+}
+
+func TestPhaseLevelOrder(t *testing.T) {
+	// adding Phase does not renumber existing levels
+	assert.Equal(t, []levels.Levels{0, 1, 2, 3}, []levels.Levels{levels.Cycle, levels.Trial, levels.Epoch, levels.Run})
+	assert.Greater(t, levels.Phase, levels.Run)
+
+	stacks := NewStacks()
+	stacks.AddStack(levels.Train, levels.Trial).
+		AddLevel(levels.Epoch, 2).
+		AddLevel(levels.Trial, 2).
+		AddPhaseLevel(levels.Phase, NewPhase("Minus", 3), NewPhase("Plus", 1)).
+		AddLevel(levels.Cycle, 0)
+	st := stacks.Stacks[levels.Train]
+	cycles := 0
+	st.Loops[levels.Cycle].OnEnd.Add("Cycle", func() { cycles++ })
+
+	// stepping by Trial must not stop at every Phase, which has a larger value
+	stacks.Step(levels.Train, 1, levels.Trial)
+	assert.Equal(t, 4, cycles)
+	assert.Equal(t, 1, st.Loops[levels.Trial].Counter.Cur)
+	stacks.Step(levels.Train, 1, levels.Phase)
+	assert.Equal(t, 7, cycles)
+
+	// resetting below Trial resets Phase and Cycle but not Epoch
+	stacks.ResetCountersBelow(levels.Train, levels.Trial)
+	assert.Equal(t, 0, st.Loops[levels.Trial].Counter.Cur)
+	assert.Equal(t, 0, st.Loops[levels.Phase].Counter.Cur)
+	assert.Equal(t, 0, st.Loops[levels.Cycle].Counter.Cur)
+	st.Loops[levels.Epoch].Counter.Cur = 1
+	stacks.ResetCountersBelow(levels.Train, levels.Trial)
+	assert.Equal(t, 1, st.Loops[levels.Epoch].Counter.Cur)
+}
+
+func TestPhaseOnlyStack(t *testing.T) {
+	stacks := NewStacks()
+	stacks.AddStack(levels.Train, levels.Trial).
+		AddLevel(levels.Trial, 2).
+		AddPhaseLevel(levels.Phase, NewPhase("Minus", 3), NewPhase("Plus", 1)).
+		AddLevel(levels.Cycle, 0)
+	minusEnds := map[string]int{}
+	stacks.Phase(levels.Train, levels.Phase, "Minus").OnEnd.Add("MinusEnd", func() { minusEnds["Orig"]++ })
+	st, err := stacks.AddPhaseOnlyStack(levels.Test, levels.Train, levels.Phase, "Minus", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, levels.Test, st.Mode)
+	assert.Equal(t, 1, st.Loops[levels.Phase].Counter.Max)
+	assert.Equal(t, 2, st.Loops[levels.Phase].Phases[0].Duration)
+	assert.Equal(t, 3, stacks.Phase(levels.Train, levels.Phase, "Minus").Duration) // not changed
+
+	// function lists are copied, so changing one stack's phase does not
+	// change the other
+	stacks.Phase(levels.Test, levels.Phase, "Minus").OnEnd.Replace("MinusEnd", func() bool { minusEnds["Test"]++; return true })
+	stacks.Phase(levels.Test, levels.Phase, "Minus").OnEnd.Add("TestOnly", func() {})
+	assert.Equal(t, 1, len(stacks.Phase(levels.Train, levels.Phase, "Minus").OnEnd))
+
+	cycles := map[enums.Enum]int{}
+	dwts := 0
+	stacks.AddOnEndToLoop(levels.Cycle, "Cycle", func(mode enums.Enum) { cycles[mode]++ })
+	stacks.AddOnPhaseEnd(levels.Phase, "Plus", "DWt", func(mode enums.Enum) { dwts++ })
+	stacks.Run(levels.Test)
+	assert.Equal(t, 4, cycles[levels.Test])
+	assert.Equal(t, 0, dwts)
+	stacks.Run(levels.Train)
+	assert.Equal(t, 8, cycles[levels.Train])
+	assert.Equal(t, 2, dwts)
+	assert.Equal(t, map[string]int{"Orig": 2, "Test": 2}, minusEnds)
+
+	_, err = stacks.AddPhaseOnlyStack(levels.Test, levels.Train, levels.Phase, "Foo", 0)
+	assert.Error(t, err)
+	_, err = stacks.AddPhaseOnlyStack(levels.Test, levels.Train, levels.Epoch, "Minus", 0)
+	assert.Error(t, err)
+}
+
+func TestBudget(t *testing.T) {
+	stacks := NewStacks()
+	stacks.AddStack(levels.Train, levels.Trial).
+		AddLevel(levels.Epoch, 10).
+		AddLevel(levels.Trial, 4)
+	exceeded := 0
+	bg := stacks.SetBudget(levels.Train, levels.Epoch, 0, 10)
+	bg.OnExceeded.Add("Save", func() { exceeded++ })
+
+	stop := stacks.Run(levels.Train)
+	assert.Equal(t, levels.Epoch, stop)
+	assert.True(t, stacks.BudgetExceeded(levels.Train))
+	assert.Equal(t, 1, exceeded)
+	assert.Equal(t, 12, bg.CyclesRun())
+	epoch := stacks.Loop(levels.Train, levels.Epoch)
+	assert.Equal(t, 3, epoch.Counter.Cur)
+	assert.Equal(t, 0, stacks.Loop(levels.Train, levels.Trial).Counter.Cur)
+
+	// continues with a fresh budget
+	stacks.Run(levels.Train)
+	assert.Equal(t, 2, exceeded)
+	assert.Equal(t, 6, epoch.Counter.Cur)
+
+	// time budget
+	stacks.InitMode(levels.Train)
+	assert.False(t, stacks.BudgetExceeded(levels.Train))
+	bg.Cycles = 0
+	bg.Duration = time.Nanosecond
+	stacks.Run(levels.Train)
+	assert.Equal(t, 3, exceeded)
+	assert.Equal(t, 1, epoch.Counter.Cur)
+
+	// no budget: runs to completion
+	stacks.Stacks[levels.Train].Budget = nil
+	stacks.Run(levels.Train)
+	assert.Equal(t, 10, epoch.Counter.Cur)
+}
+
+func TestRunUntil(t *testing.T) {
+	stacks := NewStacks()
+	stacks.AddStack(levels.Train, levels.Trial).
+		AddLevel(levels.Epoch, 100).
+		AddLevel(levels.Trial, 4)
+	err := 1.0
+	stacks.Loop(levels.Train, levels.Epoch).OnEnd.Add("Stats", func() { err *= 0.5 })
+	stats := func(name string) (float64, bool) {
+		if name == "Err" {
+			return err, true
+		}
+		return 0, false
+	}
+	epoch := stacks.Loop(levels.Train, levels.Epoch)
+
+	stop, rerr := stacks.RunUntilExpr(levels.Train, levels.Epoch, "Err < 0.1", stats)
+	assert.NoError(t, rerr)
+	assert.Equal(t, levels.Epoch, stop)
+	assert.Equal(t, 4, epoch.Counter.Cur)
+
+	_, rerr = stacks.RunUntilExpr(levels.Train, levels.Epoch, "Err < 0.01 && Epoch >= 10 || Epoch == 6", stats)
+	assert.NoError(t, rerr)
+	assert.Equal(t, 6, epoch.Counter.Cur)
+
+	_, rerr = stacks.RunUntilExpr(levels.Train, levels.Epoch, "Err < 0.01 && Epoch >= 10", stats)
+	assert.NoError(t, rerr)
+	assert.Equal(t, 10, epoch.Counter.Cur)
+
+	_, rerr = stacks.RunUntilExpr(levels.Train, levels.Epoch, "Err", stats)
+	assert.Error(t, rerr)
+	_, rerr = stacks.RunUntilExpr(levels.Train, levels.Epoch, "Foo > 2", stats)
+	assert.Error(t, rerr)
+	assert.Equal(t, 11, epoch.Counter.Cur)
+
+	// step by N at a given level in one call
+	stacks.Step(levels.Train, 8, levels.Trial)
+	assert.Equal(t, 13, epoch.Counter.Cur)
+}
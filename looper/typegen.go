@@ -6,8 +6,16 @@ import (
 	"cogentcore.org/core/types"
 )
 
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.Budget", IDName: "budget", Doc: "Budget limits the running of a [Stack] to a given wall-clock time\nand/or number of iterations of its innermost level (e.g., Cycles).\nWhen the budget is exceeded, the stack stops cleanly at the end of the\nnext iteration of the budget Level (e.g., Epoch), and the OnExceeded\nfunctions are called, e.g., to do final logging and saving of weights.\nThis is what is needed to run within the time limits of cluster jobs.\nRunning again after the budget is exceeded continues from where it\nstopped, with a fresh budget.", Fields: []types.Field{{Name: "Duration", Doc: "Duration is the wall-clock time budget, starting from the first\nrun after the budget is set or reset. 0 = no time limit."}, {Name: "Cycles", Doc: "Cycles is the budget for the number of iterations of the innermost\nlevel of the stack (e.g., Cycle). 0 = no limit."}, {Name: "Level", Doc: "Level is the level at the end of which the stack stops\nwhen the budget is exceeded, e.g., Epoch, which must be in the stack."}, {Name: "OnExceeded", Doc: "OnExceeded functions are called when the budget is exceeded,\nafter the end of the Level iteration where the stack stops."}, {Name: "Exceeded", Doc: "Exceeded is set when the budget has been exceeded,\nand remains set until Reset."}, {Name: "start", Doc: "start is the start time of the budget."}, {Name: "cycles", Doc: "cycles is the number of innermost iterations run."}}})
+
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.Counter", IDName: "counter", Doc: "Counter combines an integer with a maximum value. It supports time tracking within looper.", Fields: []types.Field{{Name: "Cur", Doc: "current counter value"}, {Name: "Max", Doc: "maximum counter value -- only used if > 0"}, {Name: "Inc", Doc: "increment per iteration"}}})
 
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.StackDef", IDName: "stack-def", Doc: "StackDef is a declarative definition of a [Stack], as an ordered\nlist of levels with their counters and function lists, which can be\nwritten as a single literal expression, instead of a sequence of\nconfiguration calls. Use [Stacks.AddStackDef] to add the Stack, and\n[Stack.Def] to get the definition of an existing Stack.", Fields: []types.Field{{Name: "Mode", Doc: "Mode identifies the mode of processing of the stack, e.g., Train or Test."}, {Name: "StepLevel", Doc: "StepLevel is the default level for stepping."}, {Name: "OnInit", Doc: "OnInit are functions to run when Init is called."}, {Name: "Levels", Doc: "Levels are the level definitions, ordered from top to bottom,\nso longer timescales like Run are at the start."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.LevelDef", IDName: "level-def", Doc: "LevelDef is a declarative definition of one level [Loop] in a [StackDef].", Fields: []types.Field{{Name: "Level", Doc: "Level is the level enum value."}, {Name: "Max", Doc: "Max is the maximum counter value (number of iterations)."}, {Name: "Inc", Doc: "Inc is the counter increment per iteration, which defaults to 1."}, {Name: "OnStart", Doc: "OnStart functions are called at the beginning of each iteration."}, {Name: "OnEnd", Doc: "OnEnd functions are called at the end of each iteration."}, {Name: "IsDone", Doc: "IsDone functions are called after each iteration,\nand the loop is terminated if any return true."}, {Name: "Events", Doc: "Events are called when the counter is at their AtCounter value."}, {Name: "Phases", Doc: "Phases, if set, make this a phase level, where Max is the number\nof phases. See [Stack.AddPhaseLevel]."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.PlanStep", IDName: "plan-step", Doc: "PlanStep is one step in the execution plan of a [Stack],\nas returned by [Stack.PlanSteps], which is a function\nthat runs at a given level, at a given point in the loop.", Fields: []types.Field{{Name: "Level", Doc: "Level is the level where the function runs.\nIt is nil for OnInit functions."}, {Name: "Depth", Doc: "Depth is the depth of the Level in the Stack Order (0 = top),\nand is -1 for OnInit functions."}, {Name: "When", Doc: "When is when the function runs: Init, Event, Start, End, or IsDone."}, {Name: "Phase", Doc: "Phase is the name of the [Phase] for phase Start and End functions."}, {Name: "AtCounter", Doc: "AtCounter is the counter value for Event functions."}, {Name: "Name", Doc: "Name is the name of the function."}}})
+
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.Event", IDName: "event", Doc: "A Event has function(s) that can be called at a particular point\nin the loop, when the counter is AtCounter value.", Fields: []types.Field{{Name: "Name", Doc: "Might be 'plus' or 'minus' for example."}, {Name: "AtCounter", Doc: "The counter value upon which this Event occurs."}, {Name: "OnEvent", Doc: "Callback function for the Event."}}})
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.NamedFunc", IDName: "named-func", Doc: "NamedFunc lets you keep an ordered map of functions.", Fields: []types.Field{{Name: "Name"}, {Name: "Func"}}})
@@ -16,8 +24,14 @@ var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.Name
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.NamedFuncsBool", IDName: "named-funcs-bool", Doc: "NamedFuncsBool is like NamedFuncs, but for functions that return a bool."})
 
-var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.Loop", IDName: "loop", Doc: "Loop contains one level of a multi-level iteration scheme.\nIt wraps around an inner loop recorded in a Stack, or around Main functions.\nIt records how many times the loop should be repeated in the Counter.\nIt records what happens at the beginning and end of each loop.\nFor example, a loop with 1 start, 1 end, and a Counter with max=3 will do:\nStart, Inner, End, Start, Inner, End, Start, Inner, End\nWhere the Inner loop is specified by a Stack or by Main,\nand Start and End are functions on the loop.\nSee Stack for more details on how loops are combined.", Fields: []types.Field{{Name: "Counter", Doc: "Tracks time within the loop. Also tracks the maximum. OnStart, Main, and OnEnd will be called Counter.Max times, or until IsDone is satisfied, whichever ends first."}, {Name: "OnStart", Doc: "OnStart is called at the beginning of each loop."}, {Name: "Main", Doc: "OnStart is called in the middle of each loop. In general, only use Main for the last Loop in a Stack. For example, actual Net updates might occur here."}, {Name: "OnEnd", Doc: "OnStart is called at the end of each loop."}, {Name: "IsDone", Doc: "If true, end loop. Maintained as an unordered map because they should not have side effects."}, {Name: "Events", Doc: "Events occur when Counter.Cur gets to their AtCounter."}}})
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.Loop", IDName: "loop", Doc: "Loop contains one level of a multi-level iteration stack,\nwith functions that can be called at the start and end\nof each iteration of the loop, and a Counter that increments\nfor each iteration, terminating if >= Max, or IsDone returns true.\nWithin each iteration, any sub-loop at the next level down\nin its [Stack] runs its full set of iterations.\nThe control flow is:\n\n\tfor {\n\t\tEvents[Counter == AtCounter] // run events at counter\n\t\tOnStart()\n\t\t    Phases[Counter].OnStart() // if a phase level\n\t\t    Run Sub-Loop to completion\n\t\t    Phases[Counter].OnEnd()\n\t\tOnEnd()\n\t\tCounter += Inc\n\t\tif Counter >= Max || IsDone() {\n\t\t    break\n\t\t}\n\t}", Fields: []types.Field{{Name: "Counter", Doc: "Counter increments every iteration through the loop, up to [Counter.Max]."}, {Name: "Events", Doc: "Events occur when Counter.Cur is at their AtCounter."}, {Name: "OnStart", Doc: "OnStart functions are called at the beginning of each loop iteration."}, {Name: "OnEnd", Doc: "OnEnd functions are called at the end of each loop iteration."}, {Name: "IsDone", Doc: "IsDone functions are called after each loop iteration,\nand if any return true, then the loop iteration is terminated."}, {Name: "StepCount", Doc: "StepCount is the default step count for this loop level."}, {Name: "Phases", Doc: "Phases, if set, make this a phase level, where each iteration\nruns the next [Phase], which sets the Max of the level below.\nSee [Stack.AddPhaseLevel]."}}})
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.Manager", IDName: "manager", Doc: "Manager holds data relating to multiple stacks of loops,\nas well as the logic for stepping through it.\nIt also holds helper methods for constructing the data.\nIt's also a control object for stepping through Stacks of Loops.\nIt holds data about how the flow is going.", Fields: []types.Field{{Name: "Stacks", Doc: "map of stacks by Mode"}, {Name: "Mode", Doc: "The current evaluation mode."}, {Name: "isRunning", Doc: "Set to true while looping, false when done. Read only."}, {Name: "lastStartedCounter", Doc: "The Cur value of the Counter associated with the last started level, for each timescale."}, {Name: "internalStop"}}})
 
-var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.Stack", IDName: "stack", Doc: "Stack contains a list of Loops Ordered from top to bottom.\nFor example, a Stack might be created like this:\n\n\tmystack := manager.AddStack(etime.Train).AddTime(etime.Run, 2).AddTime(etime.Trial, 3)\n\tmyStack.Loops[etime.Run].OnStart.Add(\"NewRun\", initRunFunc)\n\tmyStack.Loops[etime.Trial].OnStart.Add(\"PresentTrial\", trialFunc)\n\nWhen run, myStack will behave like this:\ninitRunFunc, trialFunc, trialFunc, trialFunc, initRunFunc, trialFunc, trialFunc, trialFunc", Fields: []types.Field{{Name: "Mode", Doc: "evaluation mode for this stack"}, {Name: "Loops", Doc: "An ordered map of Loops, from the outer loop at the start to the inner loop at the end."}, {Name: "Order", Doc: "The list and order of time scales looped over by this stack of loops,  ordered from top to bottom, so longer timescales like Run should be at the beginning and shorter timescales like Trial should be and the end."}, {Name: "StopNext", Doc: "If true, stop model at the end of the current StopLevel."}, {Name: "StopFlag", Doc: "If true, stop model ASAP."}, {Name: "StopLevel", Doc: "Time level to stop at the end of."}, {Name: "StopCount", Doc: "How many iterations at StopLevel before actually stopping."}, {Name: "StepLevel", Doc: "Saved Time level for stepping -- what was set for last step or by gui."}, {Name: "StepCount", Doc: "Saved number of steps for stepping -- what was set for last step or by gui."}}})
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.Phase", IDName: "phase", Doc: "Phase is a named phase within a phase level [Loop], such as the\nminus and plus phases of a trial, or the quarters of an alpha cycle,\nwhich has its own OnStart and OnEnd functions, and a Duration that\ndetermines the number of iterations of the level below it (e.g., Cycles).\nEach iteration of a phase level loop runs the next phase, so that\nalgorithms and environments can hook phase boundaries through the same\nmechanism as trials and epochs. See [Stack.AddPhaseLevel].", Fields: []types.Field{{Name: "Name", Doc: "Name of the phase, e.g., Minus or Plus."}, {Name: "Duration", Doc: "Duration is the number of iterations of the level below in this phase,\nwhich is set as the Max of its Counter at the start of the phase."}, {Name: "OnStart", Doc: "OnStart functions are called at the start of the phase,\nafter the OnStart functions of the phase level Loop."}, {Name: "OnEnd", Doc: "OnEnd functions are called at the end of the phase,\nbefore the OnEnd functions of the phase level Loop."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.Stack", IDName: "stack", Doc: "Stack contains a list of Loops to run, for a given Mode of processing,\nwhich distinguishes this stack, and is its key in the map of Stacks.\nThe order of Loop stacks is determined by the Order list of loop levels.", Fields: []types.Field{{Name: "Mode", Doc: "Mode identifies the mode of processing this stack performs, e.g., Train or Test."}, {Name: "Loops", Doc: "Loops is the set of Loops for this Stack, keyed by the level enum value.\nOrder is determined by the Order list."}, {Name: "Order", Doc: "Order is the list and order of levels looped over by this stack of loops.\nThe order is from top to bottom, so longer timescales like Run should be at\nthe start and shorter level timescales like Trial should be at the end."}, {Name: "OnInit", Doc: "OnInit are functions to run when Init is called, to restart processing,\nwhich also resets the counters for this stack."}, {Name: "StopNext", Doc: "StopNext will stop running at the end of the current StopLevel if set."}, {Name: "StopFlag", Doc: "StopFlag will stop running ASAP if set."}, {Name: "StopLevel", Doc: "StopLevel sets the level to stop at the end of.\nThis is the current active Step level, which will be reset when done."}, {Name: "StopCount", Doc: "StopCount determines how many iterations at StopLevel before actually stopping.\nThis is the current active Step control value."}, {Name: "StepLevel", Doc: "StepLevel is a saved copy of StopLevel for stepping.\nThis is what was set for last Step call (which sets StopLevel) or by GUI."}, {Name: "StepCount", Doc: "StepCount is a saved copy of StopCount for stepping.\nThis is what was set for last Step call (which sets StopCount) or by GUI."}, {Name: "Budget", Doc: "Budget is an optional wall-clock time and cycle budget for running\nthis stack. See [Stack.SetBudget]."}, {Name: "untilLevel", Doc: "untilLevel and untilFunc are the level and condition for RunUntil."}, {Name: "untilFunc"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.Condition", IDName: "condition", Doc: "Condition is a parsed stopping condition expression, over named values\nsuch as statistics and loop counters, for use in [Stacks.RunUntil].\nThe expression is a set of comparisons of the form `name op value`,\nwhere op is one of < <= > >= == !=, and value is a number or another\nname, combined with && and || (&& binds tighter; no parentheses), e.g.:\n\n\tPctErr < 0.05 && Epoch >= 10 || Epoch >= 100", Fields: []types.Field{{Name: "Expr", Doc: "Expr is the original expression."}, {Name: "or", Doc: "or is the list of || terms, each of which is a list of && comparisons."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/looper.comparison", IDName: "comparison", Doc: "comparison is one comparison in a Condition.", Fields: []types.Field{{Name: "name"}, {Name: ""}}})
@@ -0,0 +1,198 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package looper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cogentcore.org/core/enums"
+)
+
+// Condition is a parsed stopping condition expression, over named values
+// such as statistics and loop counters, for use in [Stacks.RunUntil].
+// The expression is a set of comparisons of the form `name op value`,
+// where op is one of < <= > >= == !=, and value is a number or another
+// name, combined with && and || (&& binds tighter; no parentheses), e.g.:
+//
+//	PctErr < 0.05 && Epoch >= 10 || Epoch >= 100
+type Condition struct {
+
+	// Expr is the original expression.
+	Expr string
+
+	// or is the list of || terms, each of which is a list of && comparisons.
+	or [][]comparison
+}
+
+// comparison is one comparison in a Condition.
+type comparison struct {
+	name, op, value string
+}
+
+// compareOps are the comparison operators, with longer ones first for parsing.
+var compareOps = []string{"<=", ">=", "==", "!=", "<", ">"}
+
+// ParseCondition parses given expression into a [Condition],
+// returning an error if the syntax is not valid.
+func ParseCondition(expr string) (*Condition, error) {
+	cd := &Condition{Expr: expr}
+	for _, ors := range strings.Split(expr, "||") {
+		var ands []comparison
+		for _, cs := range strings.Split(ors, "&&") {
+			cs = strings.TrimSpace(cs)
+			cmp, err := parseComparison(cs)
+			if err != nil {
+				return nil, fmt.Errorf("looper.ParseCondition: %q: %w", expr, err)
+			}
+			ands = append(ands, cmp)
+		}
+		cd.or = append(cd.or, ands)
+	}
+	return cd, nil
+}
+
+// parseComparison parses one `name op value` comparison.
+func parseComparison(cs string) (comparison, error) {
+	for _, op := range compareOps {
+		i := strings.Index(cs, op)
+		if i < 0 {
+			continue
+		}
+		cmp := comparison{name: strings.TrimSpace(cs[:i]), op: op, value: strings.TrimSpace(cs[i+len(op):])}
+		if cmp.name == "" || cmp.value == "" {
+			return cmp, fmt.Errorf("comparison %q is missing a name or value", cs)
+		}
+		return cmp, nil
+	}
+	return comparison{}, fmt.Errorf("comparison %q does not have one of the operators: %v", cs, compareOps)
+}
+
+// Eval evaluates the condition using given function to look up the
+// values of names, returning an error if a name is not found.
+func (cd *Condition) Eval(lookup func(name string) (float64, bool)) (bool, error) {
+	value := func(s string) (float64, error) {
+		if v, err := strconv.ParseFloat(s, 64); err == nil {
+			return v, nil
+		}
+		v, ok := lookup(s)
+		if !ok {
+			return 0, fmt.Errorf("looper.Condition: %q: value named %q not found", cd.Expr, s)
+		}
+		return v, nil
+	}
+	for _, ands := range cd.or {
+		all := true
+		for _, cmp := range ands {
+			a, err := value(cmp.name)
+			if err != nil {
+				return false, err
+			}
+			b, err := value(cmp.value)
+			if err != nil {
+				return false, err
+			}
+			if !compare(a, cmp.op, b) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// compare returns the result of comparing a and b using given op.
+func compare(a float64, op string, b float64) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	}
+	return false
+}
+
+// RunUntil runs the stack of loops for given mode until given condition
+// function returns true at the end of an iteration of given level,
+// or the stack is done or stopped. The stack stops cleanly at the end of
+// the level iteration, and running can be continued from there.
+// Returns the level that was running when it stopped.
+func (ls *Stacks) RunUntil(mode, level enums.Enum, cond func() bool) enums.Enum {
+	st := ls.Stacks[mode]
+	st.untilLevel = level
+	st.untilFunc = cond
+	defer func() {
+		st.untilLevel = nil
+		st.untilFunc = nil
+	}()
+	return ls.Run(mode)
+}
+
+// RunUntilExpr runs the stack of loops for given mode until given
+// [Condition] expression is true at the end of an iteration of given level.
+// The names in the expression are looked up using given lookup function
+// (e.g., from registered statistics), which can be nil, and then as
+// the current counter values of the levels of the stack (e.g., Epoch).
+// Returns an error if the expression is not valid, or a name is not found,
+// in which case the stack stops at the end of the level iteration.
+func (ls *Stacks) RunUntilExpr(mode, level enums.Enum, expr string, lookup func(name string) (float64, bool)) (enums.Enum, error) {
+	cd, err := ParseCondition(expr)
+	if err != nil {
+		return level, err
+	}
+	st := ls.Stacks[mode]
+	look := func(name string) (float64, bool) {
+		if lookup != nil {
+			if v, ok := lookup(name); ok {
+				return v, true
+			}
+		}
+		for _, lv := range st.Order {
+			if lv.String() == name {
+				return float64(st.Loops[lv].Counter.Cur), true
+			}
+		}
+		return 0, false
+	}
+	var evalErr error
+	stop := ls.RunUntil(mode, level, func() bool {
+		done, err := cd.Eval(look)
+		if err != nil {
+			evalErr = err
+			return true
+		}
+		return done
+	})
+	return stop, evalErr
+}
+
+// checkUntil is called at the end of each iteration of given level in
+// runLevel, returning true if the RunUntil condition is met,
+// in which case the stack stops.
+func (ls *Stacks) checkUntil(st *Stack, currentLevel int) bool {
+	if st.untilFunc == nil || st.Order[currentLevel] != st.untilLevel {
+		return false
+	}
+	if !st.untilFunc() {
+		return false
+	}
+	if PrintControlFlow {
+		fmt.Printf("%s%s: RunUntil condition met\n", indent(currentLevel), st.untilLevel.String())
+	}
+	ls.internalStop = true
+	return true
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"cogentcore.org/core/math32"
+)
+
+// ActRegTargets are the unit parameters adapted by activity regularization.
+type ActRegTargets int32 //enums:enum
+
+const (
+	// RegBias adapts the bias (intrinsic excitability) of all units in
+	// the layer, which is decreased when activity is above the target.
+	RegBias ActRegTargets = iota
+
+	// RegThr adapts the firing threshold of all units in the layer,
+	// which is increased when activity is above the target.
+	RegThr
+)
+
+// ActRegSpec has parameters for activity regularization, which nudges
+// the average activity of a layer toward a target sparsity level, by
+// adapting a layer-wide offset to the bias or threshold of all units.
+// This complements the FFFB inhibition function for models that need
+// precise control of sparseness, and differs from [HomeostasisSpec] in
+// operating on the layer average instead of individual units.
+// The algorithm calls Adapt at the end of each trial with the layer
+// average activity, and adds [ActRegState.Offset] to the bias or
+// threshold (according to Target) of all units in the layer.
+type ActRegSpec struct {
+
+	// On enables activity regularization.
+	On bool
+
+	// Target is the unit parameter that is adapted.
+	Target ActRegTargets `default:"RegBias"`
+
+	// Sparsity is the target average activity of the layer
+	// (proportion of units active).
+	Sparsity float32 `default:"0.15" min:"0" max:"1"`
+
+	// Tol is the tolerance around Sparsity within which no adaptation
+	// occurs, as a proportion of Sparsity.
+	Tol float32 `default:"0.1" min:"0"`
+
+	// AvgTau is the time constant for integrating the running average
+	// activity of the layer, in terms of the number of Adapt calls
+	// (typically trials).
+	AvgTau float32 `default:"20" min:"1"`
+
+	// Rate is the rate of change in the offset per Adapt call,
+	// as a proportion of the difference between the running average
+	// activity and Sparsity.
+	Rate float32 `default:"0.01"`
+
+	// Max is the maximum magnitude of the offset.
+	Max float32 `default:"0.5"`
+
+	// AvgDt is the rate constant = 1 / AvgTau
+	AvgDt float32 `display:"-"`
+}
+
+func (ar *ActRegSpec) Defaults() {
+	ar.Sparsity = 0.15
+	ar.Tol = 0.1
+	ar.AvgTau = 20
+	ar.Rate = 0.01
+	ar.Max = 0.5
+	ar.Update()
+}
+
+func (ar *ActRegSpec) Update() {
+	ar.AvgDt = 1 / ar.AvgTau
+}
+
+func (ar *ActRegSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	default:
+		return ar.On
+	}
+}
+
+// ActRegState is the per-layer state for an [ActRegSpec].
+type ActRegState struct {
+
+	// Avg is the running average activity of the layer.
+	Avg float32
+
+	// Offset is the current offset added to the bias or
+	// threshold of all units in the layer.
+	Offset float32
+
+	// Penalty is the squared difference between the running average
+	// activity and the target sparsity, which can be logged.
+	Penalty float32
+}
+
+// Init initializes the state, with the running average at given
+// initial value (typically Sparsity).
+func (st *ActRegState) Init(avg float32) {
+	*st = ActRegState{Avg: avg}
+}
+
+// Adapt updates the running average activity from given current
+// layer average activity, and adapts the offset to move it toward
+// Sparsity, if On. Returns the offset.
+func (ar *ActRegSpec) Adapt(st *ActRegState, layAvg float32) float32 {
+	st.Avg += ar.AvgDt * (layAvg - st.Avg)
+	del := st.Avg - ar.Sparsity
+	st.Penalty = del * del
+	if !ar.On {
+		return st.Offset
+	}
+	if math32.Abs(del) <= ar.Tol*ar.Sparsity {
+		return st.Offset
+	}
+	dof := ar.Rate * del
+	if ar.Target == RegBias {
+		dof = -dof
+	}
+	st.Offset = math32.Clamp(st.Offset+dof, -ar.Max, ar.Max)
+	return st.Offset
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+// AdaptWtScaleSpec has parameters for an adaptive scale factor for each
+// synapse, as in the C++ emergent AdaptWtScaleSpec, which slowly drifts
+// toward LoScale when the normalized (0-1) weight is below LoThr, and
+// toward HiScale when it is above HiThr, so that synapses that are
+// consistently weak become weaker, and those that are consistently strong
+// become stronger, beyond the range of the learned weight itself.
+// The effective weight is Scale * Wt. The algorithm stores Scale as a
+// per-synapse variable (initialized to 1), named "Scale" so that it can be
+// viewed and logged like any other synapse variable, and calls Adapt
+// (or AdaptValues) after updating the weights.
+type AdaptWtScaleSpec struct {
+
+	// On enables adaptive weight scaling.
+	On bool
+
+	// Tau is the time constant for the scale to adapt,
+	// in terms of the number of weight updates.
+	Tau float32 `default:"5000" min:"1"`
+
+	// LoThr is the low threshold on the normalized weight,
+	// below which the scale adapts toward LoScale.
+	LoThr float32 `default:"0.25"`
+
+	// HiThr is the high threshold on the normalized weight,
+	// above which the scale adapts toward HiScale.
+	HiThr float32 `default:"0.75"`
+
+	// LoScale is the scale value for weights below LoThr.
+	LoScale float32 `default:"0.01"`
+
+	// HiScale is the scale value for weights above HiThr.
+	HiScale float32 `default:"2"`
+
+	// Dt is the rate constant = 1 / Tau
+	Dt float32 `display:"-"`
+}
+
+func (aw *AdaptWtScaleSpec) Defaults() {
+	aw.Tau = 5000
+	aw.LoThr = 0.25
+	aw.HiThr = 0.75
+	aw.LoScale = 0.01
+	aw.HiScale = 2
+	aw.Update()
+}
+
+func (aw *AdaptWtScaleSpec) Update() {
+	aw.Dt = 1 / aw.Tau
+}
+
+func (aw *AdaptWtScaleSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	default:
+		return aw.On
+	}
+}
+
+// Adapt updates the scale for given normalized weight, if On.
+func (aw *AdaptWtScaleSpec) Adapt(scale *float32, wt float32) {
+	if !aw.On {
+		return
+	}
+	switch {
+	case wt < aw.LoThr:
+		*scale += aw.Dt * (aw.LoScale - *scale)
+	case wt > aw.HiThr:
+		*scale += aw.Dt * (aw.HiScale - *scale)
+	}
+}
+
+// AdaptValues updates the scales for all synapses in given slices
+// from corresponding normalized weights, if On.
+func (aw *AdaptWtScaleSpec) AdaptValues(scales, wts []float32) {
+	if !aw.On {
+		return
+	}
+	for i, wt := range wts {
+		aw.Adapt(&scales[i], wt)
+	}
+}
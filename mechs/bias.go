@@ -0,0 +1,72 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"cogentcore.org/core/math32"
+)
+
+// BiasLearnSpec has per-layer parameters for learning of the bias weights
+// of units, which can be set via params (e.g., to turn off bias learning
+// or use a slower learning rate for specific layers), separately from the
+// learning rate of the synaptic weights. The algorithm exposes the bias
+// weights as a unit variable (canonically "Bias", see [emer.CanonicalUnitVars]),
+// so they can be viewed in the NetView and logged (e.g., using estats.BiasStat).
+// The algorithm computes the bias weight change as usual, and calls DWt
+// to apply it.
+type BiasLearnSpec struct {
+
+	// Learn enables learning of the bias weights.
+	Learn bool `default:"true"`
+
+	// Lrate is the learning rate for the bias weights, which multiplies
+	// the bias weight change computed by the algorithm (in addition to
+	// any learning rate already applied there).
+	Lrate float32 `default:"1" min:"0"`
+
+	// Max is the maximum magnitude of the bias weights (0 = no limit).
+	Max float32 `default:"0" min:"0"`
+}
+
+func (bs *BiasLearnSpec) Defaults() {
+	bs.Learn = true
+	bs.Lrate = 1
+	bs.Max = 0
+}
+
+func (bs *BiasLearnSpec) Update() {
+}
+
+func (bs *BiasLearnSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "Learn":
+		return true
+	default:
+		return bs.Learn
+	}
+}
+
+// DWt applies given bias weight change to given bias weight,
+// if Learn, multiplied by Lrate and limited to Max.
+func (bs *BiasLearnSpec) DWt(bias *float32, dwt float32) {
+	if !bs.Learn {
+		return
+	}
+	*bias += bs.Lrate * dwt
+	if bs.Max > 0 {
+		*bias = math32.Clamp(*bias, -bs.Max, bs.Max)
+	}
+}
+
+// DWtValues applies given bias weight changes to given bias weights,
+// for all units, if Learn.
+func (bs *BiasLearnSpec) DWtValues(biases, dwts []float32) {
+	if !bs.Learn {
+		return
+	}
+	for i, dw := range dwts {
+		bs.DWt(&biases[i], dw)
+	}
+}
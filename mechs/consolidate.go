@@ -0,0 +1,267 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"cogentcore.org/core/base/errors"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// ImportanceMeasures are the ways of estimating the importance of
+// each synapse for the tasks learned so far.
+type ImportanceMeasures int32 //enums:enum
+
+const (
+	// AbsDWt accumulates the absolute value of weight changes.
+	AbsDWt ImportanceMeasures = iota
+
+	// Fisher accumulates the squared weight changes, which is proportional
+	// to the diagonal of the empirical Fisher information when weight
+	// changes are proportional to the gradient, as in elastic weight
+	// consolidation (EWC; Kirkpatrick et al., 2017).
+	Fisher
+)
+
+// ConsolidateSpec has parameters for continual learning consolidation,
+// where the importance of each synapse is tracked across a task block,
+// and important synapses have reduced plasticity, and are pulled back
+// toward their prior values, in subsequent tasks, to reduce
+// catastrophic interference.
+type ConsolidateSpec struct {
+
+	// On enables consolidation.
+	On bool
+
+	// Measure is how synapse importance is estimated.
+	Measure ImportanceMeasures `default:"AbsDWt"`
+
+	// Strength is the strength of consolidation: weight changes are
+	// multiplied by 1 / (1 + Strength * importance), and the penalty
+	// pulling weights back to their prior values is
+	// Penalty * Strength * importance * (weight - prior).
+	Strength float32 `default:"1"`
+
+	// Penalty is the proportion of the EWC-style penalty that pulls
+	// weights back toward their values at the last task boundary.
+	// If 0, only plasticity is reduced.
+	Penalty float32 `default:"0.1"`
+
+	// Decay is the proportion of prior importance that is retained at
+	// each task boundary, when adding the importance from the new task.
+	Decay float32 `default:"1"`
+
+	// Norm normalizes the importance from each task by its maximum value
+	// in each pathway, so that Strength has a consistent meaning.
+	Norm bool `default:"true"`
+}
+
+func (cs *ConsolidateSpec) Defaults() {
+	cs.Strength = 1
+	cs.Penalty = 0.1
+	cs.Decay = 1
+	cs.Norm = true
+}
+
+func (cs *ConsolidateSpec) Update() {
+}
+
+func (cs *ConsolidateSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	default:
+		return cs.On
+	}
+}
+
+// Importance returns the importance increment for given weight change.
+func (cs *ConsolidateSpec) Importance(dwt float32) float32 {
+	if cs.Measure == Fisher {
+		return dwt * dwt
+	}
+	return max(dwt, -dwt)
+}
+
+// DWt returns the consolidated weight change, given the importance,
+// current weight, prior (anchor) weight, and raw weight change.
+func (cs *ConsolidateSpec) DWt(imp, wt, anchor, dwt float32) float32 {
+	if !cs.On || imp == 0 {
+		return dwt
+	}
+	si := cs.Strength * imp
+	return dwt/(1+si) - cs.Penalty*si*(wt-anchor)
+}
+
+// SynImportance holds the synapse importance tracking state for one pathway.
+type SynImportance struct {
+
+	// Acc is the importance accumulated over the current task.
+	Acc []float32
+
+	// Imp is the consolidated importance from prior tasks,
+	// which determines the reduction in plasticity.
+	Imp []float32
+
+	// Anchor are the weights at the last task boundary.
+	Anchor []float32
+}
+
+// Init initializes the state for given number of synapses, resetting all.
+func (si *SynImportance) Init(n int) {
+	si.Acc = make([]float32, n)
+	si.Imp = make([]float32, n)
+	si.Anchor = make([]float32, n)
+}
+
+// Accum accumulates importance from the given weight changes.
+func (si *SynImportance) Accum(cs *ConsolidateSpec, dwts []float32) {
+	if len(si.Acc) != len(dwts) {
+		si.Init(len(dwts))
+	}
+	for i, dw := range dwts {
+		si.Acc[i] += cs.Importance(dw)
+	}
+}
+
+// TaskBoundary marks the end of a task: the accumulated importance is
+// added into the consolidated importance, the current weights are saved
+// as the anchor, and the accumulation is reset for the next task.
+func (si *SynImportance) TaskBoundary(cs *ConsolidateSpec, wts []float32) {
+	if len(si.Acc) != len(wts) {
+		si.Init(len(wts))
+	}
+	norm := float32(1)
+	if cs.Norm {
+		mx := float32(0)
+		for _, a := range si.Acc {
+			mx = max(mx, a)
+		}
+		if mx > 0 {
+			norm = 1 / mx
+		}
+	}
+	for i, a := range si.Acc {
+		si.Imp[i] = cs.Decay*si.Imp[i] + norm*a
+		si.Acc[i] = 0
+	}
+	copy(si.Anchor, wts)
+}
+
+// ConsolidateDWts applies consolidation to the given weight changes,
+// in place, given the current weights.
+func (si *SynImportance) ConsolidateDWts(cs *ConsolidateSpec, wts, dwts []float32) {
+	if !cs.On || len(si.Imp) != len(dwts) {
+		return
+	}
+	for i, dw := range dwts {
+		dwts[i] = cs.DWt(si.Imp[i], wts[i], si.Anchor[i], dw)
+	}
+}
+
+// Consolidation manages synapse importance tracking for all pathways
+// in a network, using the generic synapse variable access methods
+// of the emer.Path interface.  Call Accum after each weight change
+// computation (i.e., when DWt values are available), and TaskBoundary
+// when a new task starts.  The algorithm then calls ConsolidateDWts
+// on the SynImportance for each pathway (see PathImportance) prior to
+// applying the weight changes.
+type Consolidation struct {
+
+	// Spec has the consolidation parameters.
+	Spec ConsolidateSpec
+
+	// WtVar is the name of the synapse weight variable.
+	WtVar string `default:"Wt"`
+
+	// DWtVar is the name of the synapse weight change variable.
+	DWtVar string `default:"DWt"`
+
+	// Task is the number of task boundaries so far.
+	Task int `edit:"-"`
+
+	// Paths has the synapse importance state for each pathway.
+	// Pathway names are not unique across layers, so the pathways
+	// themselves are the keys.
+	Paths map[emer.Path]*SynImportance `display:"-"`
+
+	// vals is a temporary buffer for synapse values.
+	vals []float32
+}
+
+func (cn *Consolidation) Defaults() {
+	cn.Spec.Defaults()
+	cn.WtVar = "Wt"
+	cn.DWtVar = "DWt"
+}
+
+// Init resets all of the importance tracking state.
+func (cn *Consolidation) Init() {
+	cn.Task = 0
+	cn.Paths = make(map[emer.Path]*SynImportance)
+}
+
+// PathImportance returns the SynImportance for given pathway,
+// creating it if it does not yet exist.
+func (cn *Consolidation) PathImportance(pt emer.Path) *SynImportance {
+	if cn.Paths == nil {
+		cn.Paths = make(map[emer.Path]*SynImportance)
+	}
+	si, ok := cn.Paths[pt]
+	if !ok {
+		si = &SynImportance{}
+		si.Init(pt.NumSyns())
+		cn.Paths[pt] = si
+	}
+	return si
+}
+
+// Accum accumulates importance for all pathways in the network,
+// from the current DWtVar values.
+func (cn *Consolidation) Accum(net emer.Network) error {
+	if !cn.Spec.On {
+		return nil
+	}
+	return cn.forPaths(net, func(pt emer.Path) error {
+		if err := pt.SynValues(&cn.vals, cn.DWtVar); err != nil {
+			return err
+		}
+		cn.PathImportance(pt).Accum(&cn.Spec, cn.vals)
+		return nil
+	})
+}
+
+// TaskBoundary marks a task boundary for all pathways in the network,
+// consolidating importance and saving the current WtVar values.
+func (cn *Consolidation) TaskBoundary(net emer.Network) error {
+	cn.Task++
+	return cn.forPaths(net, func(pt emer.Path) error {
+		if err := pt.SynValues(&cn.vals, cn.WtVar); err != nil {
+			return err
+		}
+		cn.PathImportance(pt).TaskBoundary(&cn.Spec, cn.vals)
+		return nil
+	})
+}
+
+// forPaths calls given function on all receiving pathways that are not Off.
+func (cn *Consolidation) forPaths(net emer.Network, fun func(pt emer.Path) error) error {
+	var errs []error
+	for li := range net.NumLayers() {
+		ly := net.EmerLayer(li)
+		if ly.AsEmer().Off {
+			continue
+		}
+		for pi := range ly.NumRecvPaths() {
+			pt := ly.RecvPath(pi)
+			if pt.AsEmer().Off {
+				continue
+			}
+			if err := fun(pt); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package mechs provides algorithm-independent parameters and
+computations for common neural mechanisms, which can be embedded
+in the layer and pathway parameters of any algorithm implementation
+(e.g., leabra, axon), and called from its compute functions.
+
+This follows the same approach as the popcode package: the mechanism
+is defined once here, completely independent of any specific algorithm,
+so that it can be shared, tested, and documented in one place.
+*/
+package mechs
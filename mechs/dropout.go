@@ -0,0 +1,122 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"slices"
+	"strings"
+
+	"cogentcore.org/lab/base/randx"
+)
+
+// DropoutSpec has parameters for stochastic silencing of units in a layer
+// (test-time dropout), where each unit has probability P of having its
+// activation zeroed on each trial, only during the evaluation modes listed
+// in Modes (e.g., Test), to measure the robustness and redundancy of
+// the representations. The silenced units are determined once per trial
+// from a seed computed from Seed and the trial number, which is recorded
+// in [DropoutState] for logging, so that results can be reproduced exactly.
+// The algorithm calls NewTrial at the start of each trial, and Apply
+// on the unit activations after they are computed each cycle.
+type DropoutSpec struct {
+
+	// On enables stochastic silencing.
+	On bool
+
+	// P is the probability of silencing each unit on each trial.
+	P float32 `default:"0.1" min:"0" max:"1"`
+
+	// Modes is a space-separated list of the evaluation mode names
+	// (e.g., Test, Validate) in which silencing is applied.
+	// It is never applied in modes not on this list.
+	Modes string `default:"Test"`
+
+	// Seed is the base random seed, combined with the trial number
+	// to determine the units silenced on each trial.
+	Seed int64
+}
+
+func (ds *DropoutSpec) Defaults() {
+	ds.P = 0.1
+	ds.Modes = "Test"
+}
+
+func (ds *DropoutSpec) Update() {
+}
+
+func (ds *DropoutSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	default:
+		return ds.On
+	}
+}
+
+// Active returns true if silencing applies in given evaluation mode.
+func (ds *DropoutSpec) Active(mode string) bool {
+	if !ds.On || ds.P <= 0 {
+		return false
+	}
+	return slices.Contains(strings.Fields(ds.Modes), mode)
+}
+
+// DropoutState is the per-layer state for a [DropoutSpec].
+type DropoutState struct {
+
+	// Active is true if silencing is active for the current trial.
+	Active bool
+
+	// TrialSeed is the random seed used for the current trial,
+	// which can be logged to reproduce the silenced units.
+	TrialSeed int64
+
+	// Silenced has a flag for each unit, true if it is silenced
+	// on the current trial.
+	Silenced []bool
+
+	// NSilenced is the number of silenced units on the current trial.
+	NSilenced int
+
+	// rand is the random number generator.
+	rand randx.SysRand
+}
+
+// NewTrial determines the silenced units for a new trial with given
+// trial number in given evaluation mode, for a layer with given number
+// of units. Returns true if silencing is active.
+func (ds *DropoutSpec) NewTrial(st *DropoutState, mode string, trial, nUnits int) bool {
+	st.Silenced = slices.Grow(st.Silenced[:0], nUnits)[:nUnits]
+	st.NSilenced = 0
+	st.Active = ds.Active(mode)
+	if !st.Active {
+		clear(st.Silenced)
+		st.TrialSeed = 0
+		return false
+	}
+	st.TrialSeed = ds.Seed + int64(trial)
+	st.rand.NewRand(st.TrialSeed)
+	for i := range st.Silenced {
+		sil := st.rand.Float32() < ds.P
+		st.Silenced[i] = sil
+		if sil {
+			st.NSilenced++
+		}
+	}
+	return true
+}
+
+// Apply zeros the activations of the silenced units for the current
+// trial, in given activations (one per unit), if active.
+func (ds *DropoutSpec) Apply(st *DropoutState, acts []float32) {
+	if !st.Active {
+		return
+	}
+	for i, sil := range st.Silenced {
+		if sil && i < len(acts) {
+			acts[i] = 0
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"math/rand"
+
+	"cogentcore.org/lab/base/randx"
+)
+
+// DWtShareSpec has parameters for sharing weight changes among neighboring
+// synapses, as in the C++ emergent dwt_share option, where each synapse
+// has a probability PShare of averaging its weight change with a random
+// neighbor within Neigh sending units. This produces topographic smoothing
+// of the learned weights, e.g., in self-organizing map models, where the
+// sending units are arranged topographically in index order.
+// The algorithm calls Share on the weight changes of each receiving unit,
+// ordered by sending unit index, before applying them.
+type DWtShareSpec struct {
+
+	// On enables sharing of weight changes.
+	On bool
+
+	// Neigh is the number of neighboring sending units on either side
+	// of each synapse that it can share weight changes with.
+	Neigh int `default:"8" min:"1"`
+
+	// PShare is the probability for each synapse to
+	// share its weight change with a neighbor.
+	PShare float32 `default:"0.05" min:"0" max:"1"`
+}
+
+func (ds *DWtShareSpec) Defaults() {
+	ds.Neigh = 8
+	ds.PShare = 0.05
+}
+
+func (ds *DWtShareSpec) Update() {
+}
+
+func (ds *DWtShareSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	default:
+		return ds.On
+	}
+}
+
+// Share shares the weight changes in given slice (ordered by sending
+// unit index) among neighbors, if On: with probability PShare, each
+// weight change is averaged with that of a random neighbor within Neigh,
+// with both set to the average. Uses given random number source
+// (nil = global source). Returns the number of shares.
+func (ds *DWtShareSpec) Share(dwts []float32, rnd randx.Rand) int {
+	n := len(dwts)
+	if !ds.On || n < 2 || ds.Neigh < 1 {
+		return 0
+	}
+	rf := rand.Float32
+	ri := rand.Intn
+	if rnd != nil {
+		rf = rnd.Float32
+		ri = rnd.Intn
+	}
+	nsh := 0
+	for i := range dwts {
+		if rf() >= ds.PShare {
+			continue
+		}
+		off := ri(ds.Neigh) + 1
+		if ri(2) == 0 {
+			off = -off
+		}
+		j := min(max(i+off, 0), n-1)
+		if j == i {
+			continue
+		}
+		avg := 0.5 * (dwts[i] + dwts[j])
+		dwts[i] = avg
+		dwts[j] = avg
+		nsh++
+	}
+	return nsh
+}
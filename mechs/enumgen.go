@@ -0,0 +1,304 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package mechs
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _SignsValues = []Signs{0, 1, 2}
+
+// SignsN is the highest valid value for type Signs, plus one.
+const SignsN Signs = 3
+
+var _SignsValueMap = map[string]Signs{`Unsigned`: 0, `Excitatory`: 1, `Inhibitory`: 2}
+
+var _SignsDescMap = map[Signs]string{0: `Unsigned places no constraint on the sign of the weights.`, 1: `Excitatory constrains weights to be positive (&gt;= 0).`, 2: `Inhibitory constrains weights to be negative (&lt;= 0).`}
+
+var _SignsMap = map[Signs]string{0: `Unsigned`, 1: `Excitatory`, 2: `Inhibitory`}
+
+// String returns the string representation of this Signs value.
+func (i Signs) String() string { return enums.String(i, _SignsMap) }
+
+// SetString sets the Signs value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Signs) SetString(s string) error { return enums.SetString(i, s, _SignsValueMap, "Signs") }
+
+// Int64 returns the Signs value as an int64.
+func (i Signs) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Signs value from an int64.
+func (i *Signs) SetInt64(in int64) { *i = Signs(in) }
+
+// Desc returns the description of the Signs value.
+func (i Signs) Desc() string { return enums.Desc(i, _SignsDescMap) }
+
+// SignsValues returns all possible values for the type Signs.
+func SignsValues() []Signs { return _SignsValues }
+
+// Values returns all possible values for the type Signs.
+func (i Signs) Values() []enums.Enum { return enums.Values(_SignsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Signs) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Signs) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Signs") }
+
+var _InhibRegimesValues = []InhibRegimes{0, 1}
+
+// InhibRegimesN is the highest valid value for type InhibRegimes, plus one.
+const InhibRegimesN InhibRegimes = 2
+
+var _InhibRegimesValueMap = map[string]InhibRegimes{`FFFB`: 0, `Interneurons`: 1}
+
+var _InhibRegimesDescMap = map[InhibRegimes]string{0: `FFFB uses the standard feedforward and feedback inhibition function, which approximates the effects of inhibitory interneurons.`, 1: `Interneurons uses an explicit paired layer of inhibitory interneurons, with excitatory to inhibitory (E-&gt;I) and inhibitory to excitatory (I-&gt;E) pathways.`}
+
+var _InhibRegimesMap = map[InhibRegimes]string{0: `FFFB`, 1: `Interneurons`}
+
+// String returns the string representation of this InhibRegimes value.
+func (i InhibRegimes) String() string { return enums.String(i, _InhibRegimesMap) }
+
+// SetString sets the InhibRegimes value from its string representation,
+// and returns an error if the string is invalid.
+func (i *InhibRegimes) SetString(s string) error {
+	return enums.SetString(i, s, _InhibRegimesValueMap, "InhibRegimes")
+}
+
+// Int64 returns the InhibRegimes value as an int64.
+func (i InhibRegimes) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the InhibRegimes value from an int64.
+func (i *InhibRegimes) SetInt64(in int64) { *i = InhibRegimes(in) }
+
+// Desc returns the description of the InhibRegimes value.
+func (i InhibRegimes) Desc() string { return enums.Desc(i, _InhibRegimesDescMap) }
+
+// InhibRegimesValues returns all possible values for the type InhibRegimes.
+func InhibRegimesValues() []InhibRegimes { return _InhibRegimesValues }
+
+// Values returns all possible values for the type InhibRegimes.
+func (i InhibRegimes) Values() []enums.Enum { return enums.Values(_InhibRegimesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i InhibRegimes) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *InhibRegimes) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "InhibRegimes")
+}
+
+var _NeuromodsValues = []Neuromods{0, 1, 2, 3}
+
+// NeuromodsN is the highest valid value for type Neuromods, plus one.
+const NeuromodsN Neuromods = 4
+
+var _NeuromodsValueMap = map[string]Neuromods{`DA`: 0, `ACh`: 1, `NE`: 2, `Ser`: 3}
+
+var _NeuromodsDescMap = map[Neuromods]string{0: `DA is dopamine, which signals reward prediction errors and modulates learning and gating.`, 1: `ACh is acetylcholine, which signals salience / novelty and modulates attention and learning rate.`, 2: `NE is norepinephrine, which signals arousal / uncertainty, and modulates the gain of neural responses.`, 3: `Ser is serotonin (5HT), which signals aversive outcomes and patience / temporal discounting.`}
+
+var _NeuromodsMap = map[Neuromods]string{0: `DA`, 1: `ACh`, 2: `NE`, 3: `Ser`}
+
+// String returns the string representation of this Neuromods value.
+func (i Neuromods) String() string { return enums.String(i, _NeuromodsMap) }
+
+// SetString sets the Neuromods value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Neuromods) SetString(s string) error {
+	return enums.SetString(i, s, _NeuromodsValueMap, "Neuromods")
+}
+
+// Int64 returns the Neuromods value as an int64.
+func (i Neuromods) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Neuromods value from an int64.
+func (i *Neuromods) SetInt64(in int64) { *i = Neuromods(in) }
+
+// Desc returns the description of the Neuromods value.
+func (i Neuromods) Desc() string { return enums.Desc(i, _NeuromodsDescMap) }
+
+// NeuromodsValues returns all possible values for the type Neuromods.
+func NeuromodsValues() []Neuromods { return _NeuromodsValues }
+
+// Values returns all possible values for the type Neuromods.
+func (i Neuromods) Values() []enums.Enum { return enums.Values(_NeuromodsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Neuromods) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Neuromods) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "Neuromods")
+}
+
+var _ImportanceMeasuresValues = []ImportanceMeasures{0, 1}
+
+// ImportanceMeasuresN is the highest valid value for type ImportanceMeasures, plus one.
+const ImportanceMeasuresN ImportanceMeasures = 2
+
+var _ImportanceMeasuresValueMap = map[string]ImportanceMeasures{`AbsDWt`: 0, `Fisher`: 1}
+
+var _ImportanceMeasuresDescMap = map[ImportanceMeasures]string{0: `AbsDWt accumulates the absolute value of weight changes.`, 1: `Fisher accumulates the squared weight changes, which is proportional to the diagonal of the empirical Fisher information when weight changes are proportional to the gradient, as in elastic weight consolidation (EWC; Kirkpatrick et al., 2017).`}
+
+var _ImportanceMeasuresMap = map[ImportanceMeasures]string{0: `AbsDWt`, 1: `Fisher`}
+
+// String returns the string representation of this ImportanceMeasures value.
+func (i ImportanceMeasures) String() string { return enums.String(i, _ImportanceMeasuresMap) }
+
+// SetString sets the ImportanceMeasures value from its string representation,
+// and returns an error if the string is invalid.
+func (i *ImportanceMeasures) SetString(s string) error {
+	return enums.SetString(i, s, _ImportanceMeasuresValueMap, "ImportanceMeasures")
+}
+
+// Int64 returns the ImportanceMeasures value as an int64.
+func (i ImportanceMeasures) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the ImportanceMeasures value from an int64.
+func (i *ImportanceMeasures) SetInt64(in int64) { *i = ImportanceMeasures(in) }
+
+// Desc returns the description of the ImportanceMeasures value.
+func (i ImportanceMeasures) Desc() string { return enums.Desc(i, _ImportanceMeasuresDescMap) }
+
+// ImportanceMeasuresValues returns all possible values for the type ImportanceMeasures.
+func ImportanceMeasuresValues() []ImportanceMeasures { return _ImportanceMeasuresValues }
+
+// Values returns all possible values for the type ImportanceMeasures.
+func (i ImportanceMeasures) Values() []enums.Enum { return enums.Values(_ImportanceMeasuresValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i ImportanceMeasures) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *ImportanceMeasures) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "ImportanceMeasures")
+}
+
+var _ShortPlastAlgosValues = []ShortPlastAlgos{0, 1}
+
+// ShortPlastAlgosN is the highest valid value for type ShortPlastAlgos, plus one.
+const ShortPlastAlgosN ShortPlastAlgos = 2
+
+var _ShortPlastAlgosValueMap = map[string]ShortPlastAlgos{`STPCycles`: 0, `STPTrialBinary`: 1}
+
+var _ShortPlastAlgosDescMap = map[ShortPlastAlgos]string{0: `STPCycles updates the synaptic state every cycle, using the sending spike (or rate-code activation as the expected spikes per cycle), as in the Hennig (2013) model.`, 1: `STPTrialBinary updates the synaptic state once per trial, based on whether the sending activation is above the TrialThr threshold, which is a simpler, more abstract model for rate-code networks.`}
+
+var _ShortPlastAlgosMap = map[ShortPlastAlgos]string{0: `STPCycles`, 1: `STPTrialBinary`}
+
+// String returns the string representation of this ShortPlastAlgos value.
+func (i ShortPlastAlgos) String() string { return enums.String(i, _ShortPlastAlgosMap) }
+
+// SetString sets the ShortPlastAlgos value from its string representation,
+// and returns an error if the string is invalid.
+func (i *ShortPlastAlgos) SetString(s string) error {
+	return enums.SetString(i, s, _ShortPlastAlgosValueMap, "ShortPlastAlgos")
+}
+
+// Int64 returns the ShortPlastAlgos value as an int64.
+func (i ShortPlastAlgos) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the ShortPlastAlgos value from an int64.
+func (i *ShortPlastAlgos) SetInt64(in int64) { *i = ShortPlastAlgos(in) }
+
+// Desc returns the description of the ShortPlastAlgos value.
+func (i ShortPlastAlgos) Desc() string { return enums.Desc(i, _ShortPlastAlgosDescMap) }
+
+// ShortPlastAlgosValues returns all possible values for the type ShortPlastAlgos.
+func ShortPlastAlgosValues() []ShortPlastAlgos { return _ShortPlastAlgosValues }
+
+// Values returns all possible values for the type ShortPlastAlgos.
+func (i ShortPlastAlgos) Values() []enums.Enum { return enums.Values(_ShortPlastAlgosValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i ShortPlastAlgos) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *ShortPlastAlgos) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "ShortPlastAlgos")
+}
+
+var _ClampSpikesValues = []ClampSpikes{0, 1, 2}
+
+// ClampSpikesN is the highest valid value for type ClampSpikes, plus one.
+const ClampSpikesN ClampSpikes = 3
+
+var _ClampSpikesValueMap = map[string]ClampSpikes{`Poisson`: 0, `Regular`: 1, `Clamped`: 2}
+
+var _ClampSpikesDescMap = map[ClampSpikes]string{0: `Poisson generates spikes with a probability per cycle of ClampMaxP times the clamped activation.`, 1: `Regular generates spikes at regular intervals, with an inter-spike interval of 1 / (ClampMaxP times the clamped activation).`, 2: `Clamped uses the clamped activation directly as the spike, spiking when it is &gt; 0.5, for externally generated spike trains.`}
+
+var _ClampSpikesMap = map[ClampSpikes]string{0: `Poisson`, 1: `Regular`, 2: `Clamped`}
+
+// String returns the string representation of this ClampSpikes value.
+func (i ClampSpikes) String() string { return enums.String(i, _ClampSpikesMap) }
+
+// SetString sets the ClampSpikes value from its string representation,
+// and returns an error if the string is invalid.
+func (i *ClampSpikes) SetString(s string) error {
+	return enums.SetString(i, s, _ClampSpikesValueMap, "ClampSpikes")
+}
+
+// Int64 returns the ClampSpikes value as an int64.
+func (i ClampSpikes) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the ClampSpikes value from an int64.
+func (i *ClampSpikes) SetInt64(in int64) { *i = ClampSpikes(in) }
+
+// Desc returns the description of the ClampSpikes value.
+func (i ClampSpikes) Desc() string { return enums.Desc(i, _ClampSpikesDescMap) }
+
+// ClampSpikesValues returns all possible values for the type ClampSpikes.
+func ClampSpikesValues() []ClampSpikes { return _ClampSpikesValues }
+
+// Values returns all possible values for the type ClampSpikes.
+func (i ClampSpikes) Values() []enums.Enum { return enums.Values(_ClampSpikesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i ClampSpikes) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *ClampSpikes) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "ClampSpikes")
+}
+
+var _ActRegTargetsValues = []ActRegTargets{0, 1}
+
+// ActRegTargetsN is the highest valid value for type ActRegTargets, plus one.
+const ActRegTargetsN ActRegTargets = 2
+
+var _ActRegTargetsValueMap = map[string]ActRegTargets{`RegBias`: 0, `RegThr`: 1}
+
+var _ActRegTargetsDescMap = map[ActRegTargets]string{0: `RegBias adapts the bias (intrinsic excitability) of all units in the layer, which is decreased when activity is above the target.`, 1: `RegThr adapts the firing threshold of all units in the layer, which is increased when activity is above the target.`}
+
+var _ActRegTargetsMap = map[ActRegTargets]string{0: `RegBias`, 1: `RegThr`}
+
+// String returns the string representation of this ActRegTargets value.
+func (i ActRegTargets) String() string { return enums.String(i, _ActRegTargetsMap) }
+
+// SetString sets the ActRegTargets value from its string representation,
+// and returns an error if the string is invalid.
+func (i *ActRegTargets) SetString(s string) error {
+	return enums.SetString(i, s, _ActRegTargetsValueMap, "ActRegTargets")
+}
+
+// Int64 returns the ActRegTargets value as an int64.
+func (i ActRegTargets) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the ActRegTargets value from an int64.
+func (i *ActRegTargets) SetInt64(in int64) { *i = ActRegTargets(in) }
+
+// Desc returns the description of the ActRegTargets value.
+func (i ActRegTargets) Desc() string { return enums.Desc(i, _ActRegTargetsDescMap) }
+
+// ActRegTargetsValues returns all possible values for the type ActRegTargets.
+func ActRegTargetsValues() []ActRegTargets { return _ActRegTargetsValues }
+
+// Values returns all possible values for the type ActRegTargets.
+func (i ActRegTargets) Values() []enums.Enum { return enums.Values(_ActRegTargetsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i ActRegTargets) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *ActRegTargets) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "ActRegTargets")
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"strings"
+
+	"cogentcore.org/core/math32"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/params"
+)
+
+// GainModSpec has parameters for the modulation of the activation function
+// gain of layers by a network-level scalar arousal level, simulating
+// the effects of norepinephrine (NE) from the locus coeruleus (LC),
+// as in LC-NE models of attention and arousal (Aston-Jones & Cohen, 2005).
+// The gain multiplier is 1 at the Base level, and increases linearly
+// with Sens as the level goes above Base, clipped to the Min..Max range.
+type GainModSpec struct {
+
+	// On enables gain modulation.
+	On bool
+
+	// Layers is a space-separated list of selectors for the layers that
+	// are modulated, using params selector syntax: .Class or #Name.
+	// An empty list applies to all layers.
+	Layers string
+
+	// Base is the baseline arousal level where the gain multiplier is 1.
+	Base float32 `default:"0.5"`
+
+	// Sens is the sensitivity of the gain multiplier to the difference
+	// of the arousal level from Base.
+	Sens float32 `default:"1"`
+
+	// Min is the minimum gain multiplier.
+	Min float32 `default:"0.5"`
+
+	// Max is the maximum gain multiplier.
+	Max float32 `default:"2"`
+}
+
+func (gm *GainModSpec) Defaults() {
+	gm.Base = 0.5
+	gm.Sens = 1
+	gm.Min = 0.5
+	gm.Max = 2
+}
+
+func (gm *GainModSpec) Update() {
+}
+
+func (gm *GainModSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	default:
+		return gm.On
+	}
+}
+
+// GainMult returns the gain multiplier for given arousal level,
+// which is 1 if not On.
+func (gm *GainModSpec) GainMult(level float32) float32 {
+	if !gm.On {
+		return 1
+	}
+	return math32.Clamp(1+gm.Sens*(level-gm.Base), gm.Min, gm.Max)
+}
+
+// Applies returns true if the gain modulation applies to given layer,
+// according to the Layers selectors.
+func (gm *GainModSpec) Applies(ly emer.Layer) bool {
+	if !gm.On {
+		return false
+	}
+	return LayerSelMatch(gm.Layers, ly)
+}
+
+// GainMod holds the current arousal level and resulting gain multiplier,
+// according to the GainModSpec.  Typically the Level is set per trial
+// from the environment or a model signal (e.g., NE on a NeuromodBus),
+// and the algorithm multiplies its activation function gain by Gain
+// for each layer that the spec Applies to.
+// The Level and Gain values can be logged as stats.
+type GainMod struct {
+	GainModSpec
+
+	// Level is the current arousal level.
+	Level float32 `edit:"-"`
+
+	// Gain is the current gain multiplier, computed from Level.
+	Gain float32 `edit:"-"`
+}
+
+func (gm *GainMod) Defaults() {
+	gm.GainModSpec.Defaults()
+	gm.Init()
+}
+
+// Init resets the Level to Base, with a Gain of 1.
+func (gm *GainMod) Init() {
+	gm.SetLevel(gm.Base)
+}
+
+// SetLevel sets the current arousal level and updates the gain multiplier.
+func (gm *GainMod) SetLevel(level float32) {
+	gm.Level = level
+	gm.Gain = gm.GainMult(level)
+}
+
+// SetFromBus sets the current arousal level from the NE
+// value on given NeuromodBus.
+func (gm *GainMod) SetFromBus(nb *NeuromodBus) {
+	gm.SetLevel(nb.Values[NE])
+}
+
+// LayerGain returns the gain multiplier for given layer, which is 1
+// if the gain modulation does not apply to the layer.
+func (gm *GainMod) LayerGain(ly emer.Layer) float32 {
+	if !gm.Applies(ly) {
+		return 1
+	}
+	return gm.Gain
+}
+
+// LayerSelMatch returns true if given space-separated list of selectors,
+// using params selector syntax (.Class or #Name, or a plain type name)
+// matches given layer. An empty list matches all layers.
+func LayerSelMatch(sels string, ly emer.Layer) bool {
+	sl := strings.Fields(sels)
+	if len(sl) == 0 {
+		return true
+	}
+	lb := ly.AsEmer()
+	for _, sel := range sl {
+		switch sel[0] {
+		case '.':
+			if params.ClassMatch(sel[1:], lb.Class) {
+				return true
+			}
+		case '#':
+			if lb.Name == sel[1:] {
+				return true
+			}
+		default:
+			if ly.TypeName() == sel {
+				return true
+			}
+		}
+	}
+	return false
+}
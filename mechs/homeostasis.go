@@ -0,0 +1,97 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"cogentcore.org/core/math32"
+)
+
+// HomeostasisSpec has parameters for a slow homeostatic adjustment of the
+// intrinsic excitability (bias) of each unit toward a target average
+// activation, which keeps long-running models (e.g., continual learning)
+// from drifting into silent or saturated regimes.  This operates on the
+// unit bias directly, in addition to any BCM-style long-term average
+// (AvgL) modulation of learning in the algorithm.
+// Typically called at the end of each trial: UpdateAvg updates the running
+// average activity of each unit, and Adapt adjusts the bias accordingly.
+type HomeostasisSpec struct {
+
+	// On enables homeostatic adaptation of unit excitability.
+	On bool
+
+	// Target is the target average activity level for each unit.
+	Target float32 `default:"0.15"`
+
+	// AvgTau is the time constant for integrating the running average
+	// activity of each unit, in terms of the number of UpdateAvg calls
+	// (typically trials).
+	AvgTau float32 `default:"200"`
+
+	// Rate is the rate of change in the bias per UpdateAvg call,
+	// as a proportion of the difference between Target and average activity.
+	Rate float32 `default:"0.001"`
+
+	// Min is the minimum bias value.
+	Min float32 `default:"-1"`
+
+	// Max is the maximum bias value.
+	Max float32 `default:"1"`
+
+	// AvgDt is the rate constant = 1 / AvgTau
+	AvgDt float32 `display:"-"`
+}
+
+func (hs *HomeostasisSpec) Defaults() {
+	hs.Target = 0.15
+	hs.AvgTau = 200
+	hs.Rate = 0.001
+	hs.Min = -1
+	hs.Max = 1
+	hs.Update()
+}
+
+func (hs *HomeostasisSpec) Update() {
+	hs.AvgDt = 1 / hs.AvgTau
+}
+
+func (hs *HomeostasisSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	default:
+		return hs.On
+	}
+}
+
+// UpdateAvg updates the running average activity from current activity.
+func (hs *HomeostasisSpec) UpdateAvg(avg *float32, act float32) {
+	*avg += hs.AvgDt * (act - *avg)
+}
+
+// BiasDelta returns the change in bias for given running average activity.
+func (hs *HomeostasisSpec) BiasDelta(avg float32) float32 {
+	return hs.Rate * (hs.Target - avg)
+}
+
+// Adapt adjusts the bias toward keeping the average activity at Target,
+// if On.
+func (hs *HomeostasisSpec) Adapt(bias *float32, avg float32) {
+	if !hs.On {
+		return
+	}
+	*bias = math32.Clamp(*bias+hs.BiasDelta(avg), hs.Min, hs.Max)
+}
+
+// AdaptValues updates the running averages from the current activities
+// and then adapts the biases, for all units in given slices, if On.
+func (hs *HomeostasisSpec) AdaptValues(biases, avgs, acts []float32) {
+	if !hs.On {
+		return
+	}
+	for i, act := range acts {
+		hs.UpdateAvg(&avgs[i], act)
+		hs.Adapt(&biases[i], avgs[i])
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"math"
+
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/paths"
+)
+
+// InhibRegimes are the ways of implementing inhibition within a layer.
+type InhibRegimes int32 //enums:enum
+
+const (
+	// FFFB uses the standard feedforward and feedback inhibition
+	// function, which approximates the effects of inhibitory interneurons.
+	FFFB InhibRegimes = iota
+
+	// Interneurons uses an explicit paired layer of inhibitory interneurons,
+	// with excitatory to inhibitory (E->I) and inhibitory to excitatory
+	// (I->E) pathways.
+	Interneurons
+)
+
+// Builder is the interface that an algorithm implements to support
+// generic network construction helpers, such as AddInterneurons.
+// Layer and pathway types are given using the algorithm's type names
+// (i.e., the TypeName() strings), which the implementation
+// is responsible for converting into its own types.
+type Builder interface {
+
+	// AddLayer adds a new layer with given name, shape and type name.
+	AddLayer(name string, shape []int, typ string) emer.Layer
+
+	// ConnectLayers adds a new pathway between given layers,
+	// with given pattern of connectivity and type name.
+	ConnectLayers(send, recv emer.Layer, pat paths.Pattern, typ string) emer.Path
+
+	// SetFFFB turns the FFFB inhibition function on or off for given layer.
+	SetFFFB(ly emer.Layer, on bool)
+}
+
+// InterneuronSpec has parameters for constructing a paired layer of
+// explicit inhibitory interneurons for a given excitatory layer.
+type InterneuronSpec struct {
+
+	// Prop is the number of inhibitory units as a proportion of the number
+	// of excitatory units. In the neocortex, roughly 20% of neurons
+	// are inhibitory. For 4D layers, this applies within each pool.
+	Prop float32 `default:"0.2"`
+
+	// Suffix is added to the name of the excitatory layer
+	// to name the inhibitory layer.
+	Suffix string `default:"Inhib"`
+
+	// LayerType is the algorithm type name for the inhibitory layer.
+	LayerType string
+
+	// EToIType is the algorithm type name for the E->I pathway.
+	EToIType string
+
+	// IToEType is the algorithm type name for the I->E pathway,
+	// which must be an inhibitory pathway type.
+	IToEType string
+
+	// EToIClass is the parameter class added to the E->I pathway.
+	EToIClass string `default:"EToI"`
+
+	// IToEClass is the parameter class added to the I->E pathway.
+	IToEClass string `default:"IToE"`
+}
+
+func (is *InterneuronSpec) Defaults() {
+	is.Prop = 0.2
+	is.Suffix = "Inhib"
+	is.EToIClass = "EToI"
+	is.IToEClass = "IToE"
+}
+
+func (is *InterneuronSpec) Update() {
+}
+
+// InhibShape returns the shape of the inhibitory layer for given
+// excitatory layer shape, as a square-ish 2D layer with Prop times
+// as many units, or the same for each pool in a 4D layer.
+func (is *InterneuronSpec) InhibShape(exc []int) []int {
+	nd := len(exc)
+	ny, nx := exc[nd-2], exc[nd-1]
+	n := max(int(math.Round(float64(is.Prop)*float64(ny*nx))), 1)
+	iy := max(int(math.Round(math.Sqrt(float64(n)*float64(ny)/float64(nx)))), 1)
+	ix := max((n+iy-1)/iy, 1)
+	if nd == 4 {
+		return []int{exc[0], exc[1], iy, ix}
+	}
+	return []int{iy, ix}
+}
+
+// InhibPair holds an excitatory layer and its paired
+// inhibitory interneuron layer and pathways.
+type InhibPair struct {
+
+	// Exc is the excitatory layer.
+	Exc emer.Layer
+
+	// Inh is the inhibitory interneuron layer.
+	Inh emer.Layer
+
+	// EToI is the pathway from excitatory to inhibitory layer.
+	EToI emer.Path
+
+	// IToE is the pathway from inhibitory to excitatory layer.
+	IToE emer.Path
+
+	// Regime is the current inhibition regime.
+	Regime InhibRegimes
+}
+
+// AddInterneurons adds a paired layer of inhibitory interneurons for
+// given excitatory layer, using the given Builder, with E->I and I->E
+// pathways that are Full for 2D layers, and PoolOneToOne for 4D layers,
+// so that inhibition remains local to each pool.  The resulting pair is
+// set to the Interneurons regime; use SetRegime to switch to FFFB for
+// comparison.
+func AddInterneurons(b Builder, exc emer.Layer, is *InterneuronSpec) *InhibPair {
+	eb := exc.AsEmer()
+	inh := b.AddLayer(eb.Name+is.Suffix, is.InhibShape(eb.Shape.Sizes), is.LayerType)
+	ib := inh.AsEmer()
+	if eb.Is4D() {
+		ib.PlaceRightOf(exc, 1)
+	} else {
+		ib.PlaceRightOf(exc, 2)
+	}
+	var pat paths.Pattern
+	if eb.Is4D() {
+		pat = paths.NewPoolOneToOne()
+	} else {
+		pat = paths.NewFull()
+	}
+	ip := &InhibPair{Exc: exc, Inh: inh}
+	ip.EToI = b.ConnectLayers(exc, inh, pat, is.EToIType)
+	ip.EToI.AsEmer().AddClass(is.EToIClass)
+	ip.IToE = b.ConnectLayers(inh, exc, pat, is.IToEType)
+	ip.IToE.AsEmer().AddClass(is.IToEClass)
+	ip.SetRegime(b, Interneurons)
+	return ip
+}
+
+// SetRegime switches between the FFFB and Interneurons inhibition regimes,
+// by turning the interneuron layer and pathways Off, and the FFFB
+// inhibition of the excitatory layer on, or vice-versa.
+func (ip *InhibPair) SetRegime(b Builder, regime InhibRegimes) {
+	ip.Regime = regime
+	intOff := regime != Interneurons
+	ip.Inh.AsEmer().Off = intOff
+	ip.EToI.AsEmer().Off = intOff
+	ip.IToE.AsEmer().Off = intOff
+	b.SetFFFB(ip.Exc, intOff)
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+// KNaChan has parameters for one sodium-gated potassium (KNa) channel,
+// which builds up a conductance with each spike (or in proportion to the
+// rate-code activation) and decays with time constant Tau, producing
+// neural adaptation at a corresponding time scale.
+type KNaChan struct {
+
+	// On enables this channel.
+	On bool
+
+	// Rise is the rate of increase in conductance with each spike,
+	// as a proportion of the remaining distance to Max.
+	Rise float32 `default:"0.05,0.02,0.001"`
+
+	// Max is the maximum conductance.
+	Max float32 `default:"0.1,0.2"`
+
+	// Tau is the time constant in cycles for the decay of conductance.
+	Tau float32 `default:"50,200,1000"`
+
+	// Dt is the rate constant = 1 / Tau
+	Dt float32 `display:"-"`
+}
+
+func (kc *KNaChan) Defaults() {
+	kc.On = true
+	kc.Rise = 0.05
+	kc.Max = 0.1
+	kc.Tau = 50
+	kc.Update()
+}
+
+func (kc *KNaChan) Update() {
+	kc.Dt = 1 / kc.Tau
+}
+
+func (kc *KNaChan) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	default:
+		return kc.On
+	}
+}
+
+// GcFromSpike updates the conductance for one cycle,
+// given whether the unit spiked.
+func (kc *KNaChan) GcFromSpike(gc *float32, spike bool) {
+	if !kc.On {
+		*gc = 0
+		return
+	}
+	if spike {
+		*gc += kc.Rise * (kc.Max - *gc)
+	} else {
+		*gc -= kc.Dt * *gc
+	}
+}
+
+// GcFromRate updates the conductance for one cycle,
+// given the rate-code activation, as the expected spikes per cycle.
+func (kc *KNaChan) GcFromRate(gc *float32, act float32) {
+	if !kc.On {
+		*gc = 0
+		return
+	}
+	*gc += kc.Rise*act*(kc.Max-*gc) - kc.Dt**gc
+}
+
+// KNaAdaptSpec has parameters for sodium-gated potassium (KNa) adaptation
+// channels at fast, medium and slow time scales, as in the C++ emergent
+// KNaAdaptSpec, for modeling neural adaptation and habituation.
+// The algorithm keeps the fast, medium and slow conductances for each unit,
+// updates them every cycle with GcFromSpike or GcFromRate, and adds the
+// total Gc to the potassium conductance of the unit. For clamped layers,
+// where the conductance does not affect the activation, ClampAct applies
+// the adaptation directly to the clamped activation. NdAct computes the
+// non-depressed activation (used for learning), optionally inverting
+// the effects of adaptation.
+type KNaAdaptSpec struct {
+
+	// On enables KNa adaptation.
+	On bool
+
+	// Fast is the fast time scale channel.
+	Fast KNaChan `display:"inline"`
+
+	// Med is the medium time scale channel.
+	Med KNaChan `display:"inline"`
+
+	// Slow is the slow time scale channel.
+	Slow KNaChan `display:"inline"`
+
+	// Clamp applies the adaptation to the activations of
+	// clamped (input) layers, via ClampAct.
+	Clamp bool
+
+	// InvertNd inverts the effect of adaptation on the non-depressed
+	// activation used for learning, via NdAct, driving it up by the
+	// proportion that adaptation drives activation down, so that
+	// learning is not affected by habituation.
+	InvertNd bool
+}
+
+func (ka *KNaAdaptSpec) Defaults() {
+	ka.Fast.Defaults()
+	ka.Med.Defaults()
+	ka.Med.Rise = 0.02
+	ka.Med.Max = 0.2
+	ka.Med.Tau = 200
+	ka.Slow.Defaults()
+	ka.Slow.Rise = 0.001
+	ka.Slow.Max = 0.2
+	ka.Slow.Tau = 1000
+	ka.Update()
+}
+
+func (ka *KNaAdaptSpec) Update() {
+	ka.Fast.Update()
+	ka.Med.Update()
+	ka.Slow.Update()
+}
+
+func (ka *KNaAdaptSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	default:
+		return ka.On
+	}
+}
+
+// GcFromSpike updates the fast, medium and slow conductances
+// for one cycle, given whether the unit spiked, if On.
+func (ka *KNaAdaptSpec) GcFromSpike(gcF, gcM, gcS *float32, spike bool) {
+	if !ka.On {
+		return
+	}
+	ka.Fast.GcFromSpike(gcF, spike)
+	ka.Med.GcFromSpike(gcM, spike)
+	ka.Slow.GcFromSpike(gcS, spike)
+}
+
+// GcFromRate updates the fast, medium and slow conductances
+// for one cycle, given the rate-code activation, if On.
+func (ka *KNaAdaptSpec) GcFromRate(gcF, gcM, gcS *float32, act float32) {
+	if !ka.On {
+		return
+	}
+	ka.Fast.GcFromRate(gcF, act)
+	ka.Med.GcFromRate(gcM, act)
+	ka.Slow.GcFromRate(gcS, act)
+}
+
+// Gc returns the total KNa conductance, to be added to
+// the potassium conductance, or 0 if not On.
+func (ka *KNaAdaptSpec) Gc(gcF, gcM, gcS float32) float32 {
+	if !ka.On {
+		return 0
+	}
+	return gcF + gcM + gcS
+}
+
+// ClampAct returns the clamped activation reduced by the proportion
+// of given total conductance, if On and Clamp.
+func (ka *KNaAdaptSpec) ClampAct(act, gc float32) float32 {
+	if !ka.On || !ka.Clamp {
+		return act
+	}
+	return act * (1 - min(gc, 1))
+}
+
+// NdAct returns the non-depressed activation used for learning,
+// increased by the proportion of given total conductance, if On and
+// InvertNd, and otherwise the activation itself.
+func (ka *KNaAdaptSpec) NdAct(act, gc float32) float32 {
+	if !ka.On || !ka.InvertNd {
+		return act
+	}
+	return act * (1 + gc)
+}
@@ -0,0 +1,423 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"slices"
+	"testing"
+
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/params"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignSpec(t *testing.T) {
+	ss := &SignSpec{}
+	ss.Defaults()
+	assert.Equal(t, float32(-0.5), ss.Constrain(-0.5))
+	assert.Equal(t, float32(-0.3), ss.ConstrainDWt(0.2, -0.3))
+
+	ss.Sign = Excitatory
+	assert.Equal(t, float32(0), ss.Constrain(-0.5))
+	assert.Equal(t, float32(0.5), ss.Constrain(0.5))
+	assert.Equal(t, float32(-0.2), ss.ConstrainDWt(0.2, -0.3))
+	assert.Equal(t, float32(0.1), ss.ConstrainDWt(0.2, 0.1))
+	assert.True(t, ss.IsValid(0))
+	assert.False(t, ss.IsValid(-0.1))
+
+	ss.Sign = Inhibitory
+	ss.MinAbs = 0.01
+	assert.Equal(t, float32(-0.01), ss.Constrain(0.5))
+	assert.InDelta(t, float32(0.19), ss.ConstrainDWt(-0.2, 0.3), 1.0e-6)
+
+	wts := []float32{-1, 0.5, 0, -0.3}
+	assert.Equal(t, 2, ss.ConstrainValues(wts))
+	assert.Equal(t, []float32{-1, -0.01, -0.01, -0.3}, wts)
+}
+
+func TestInhibShape(t *testing.T) {
+	is := &InterneuronSpec{}
+	is.Defaults()
+	assert.Equal(t, []int{2, 3}, is.InhibShape([]int{5, 5}))
+	assert.Equal(t, []int{4, 4, 1, 2}, is.InhibShape([]int{4, 4, 3, 3}))
+	assert.Equal(t, []int{1, 1}, is.InhibShape([]int{1, 2}))
+}
+
+func TestNeuromodBus(t *testing.T) {
+	nb := &NeuromodBus{}
+	da := float32(0.5)
+	nb.AddSource(DA, "VTA", func() float32 { return da })
+	nb.Subscribe("Striatum", DA, 2)
+	nb.Subscribe("Striatum", ACh, 1)
+	nb.Publish(ACh, 0.3)
+	nb.Update()
+	assert.Equal(t, float32(1), nb.Value("Striatum", DA))
+	assert.Equal(t, float32(0.3), nb.Value("Striatum", ACh))
+	assert.Equal(t, float32(0), nb.Value("Striatum", NE))
+	assert.Equal(t, float32(0), nb.Value("PFC", DA))
+	da = -0.25
+	nb.Update()
+	assert.Equal(t, float32(-0.5), nb.Value("Striatum", DA))
+	nb.Init()
+	assert.Equal(t, float32(0), nb.Value("Striatum", DA))
+}
+
+func TestGainMod(t *testing.T) {
+	gm := &GainMod{}
+	gm.Defaults()
+	assert.Equal(t, float32(1), gm.Gain)
+	gm.SetLevel(1)
+	assert.Equal(t, float32(1), gm.Gain) // not On
+	gm.On = true
+	gm.SetLevel(1)
+	assert.Equal(t, float32(1.5), gm.Gain)
+	gm.SetLevel(3)
+	assert.Equal(t, float32(2), gm.Gain)
+	nb := &NeuromodBus{}
+	nb.Publish(NE, 0.25)
+	gm.SetFromBus(nb)
+	assert.Equal(t, float32(0.75), gm.Gain)
+}
+
+func TestHomeostasis(t *testing.T) {
+	hs := &HomeostasisSpec{}
+	hs.Defaults()
+	hs.On = true
+	hs.Rate = 0.01
+	// silent unit increases its bias, saturated unit decreases it
+	biases := []float32{0, 0}
+	avgs := []float32{hs.Target, hs.Target}
+	acts := []float32{0, 1}
+	for range 1000 {
+		hs.AdaptValues(biases, avgs, acts)
+	}
+	assert.Greater(t, biases[0], float32(0))
+	assert.Less(t, biases[1], float32(0))
+	assert.Less(t, avgs[0], hs.Target)
+	hs.Rate = 1
+	hs.AdaptValues(biases, avgs, acts)
+	assert.Equal(t, hs.Max, biases[0])
+	assert.Equal(t, hs.Min, biases[1])
+}
+
+func TestConsolidate(t *testing.T) {
+	cs := &ConsolidateSpec{}
+	cs.Defaults()
+	si := &SynImportance{}
+	si.Accum(cs, []float32{0.1, -0.4, 0})
+	si.Accum(cs, []float32{0.1, 0, 0})
+	assert.InDelta(t, 0.2, si.Acc[0], 1.0e-6)
+	assert.InDelta(t, 0.4, si.Acc[1], 1.0e-6)
+	wts := []float32{0.5, 0.5, 0.5}
+	si.TaskBoundary(cs, wts)
+	assert.InDelta(t, 0.5, si.Imp[0], 1.0e-6)
+	assert.Equal(t, float32(1), si.Imp[1])
+	assert.Equal(t, float32(0), si.Acc[0])
+	assert.Equal(t, wts, si.Anchor)
+
+	dwts := []float32{0.1, 0.1, 0.1}
+	si.ConsolidateDWts(cs, wts, dwts)
+	assert.Equal(t, float32(0.1), dwts[0]) // not On
+	cs.On = true
+	cs.Penalty = 0
+	si.ConsolidateDWts(cs, wts, dwts)
+	assert.InDelta(t, 0.1/1.5, dwts[0], 1.0e-6)
+	assert.InDelta(t, 0.05, dwts[1], 1.0e-6)
+	assert.Equal(t, float32(0.1), dwts[2]) // unimportant
+	cs.Penalty = 1
+	assert.InDelta(t, -0.1, cs.DWt(1, 0.6, 0.5, 0), 1.0e-6)
+}
+
+func TestConsolidation(t *testing.T) {
+	net := bp.NewNetwork("Consolidation")
+	in := net.AddLayer2D("Input", 1, 2, bp.InputLayer)
+	h1 := net.AddLayer2D("Hidden1", 1, 2, bp.HiddenLayer)
+	h2 := net.AddLayer2D("Hidden2", 1, 3, bp.HiddenLayer)
+	out := net.AddLayer2D("Output", 1, 1, bp.TargetLayer)
+	p1 := net.ConnectLayers(in, h1, paths.NewFull(), bp.ForwardPath)
+	p2 := net.ConnectLayers(in, h2, paths.NewFull(), bp.ForwardPath)
+	p1.Name, p2.Name = "Fwd", "Fwd" // same name in different layers
+	net.ConnectLayers(h1, out, paths.NewFull(), bp.ForwardPath)
+	net.ConnectLayers(h2, out, paths.NewFull(), bp.ForwardPath)
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+
+	cn := &Consolidation{}
+	cn.Defaults()
+	cn.Spec.On = true
+	cn.Init()
+	net.ApplyExt("Input", tensor.NewFloat32FromValues(1, 1))
+	net.ApplyExt("Output", tensor.NewFloat32FromValues(1))
+	net.Forward(ctx)
+	net.Backward(ctx)
+	assert.NoError(t, cn.Accum(net))
+	assert.Len(t, cn.Paths, 4)
+	for _, pt := range []*bp.Path{p1, p2} {
+		si := cn.PathImportance(pt)
+		assert.Len(t, si.Acc, len(pt.DWts))
+		for i, dw := range pt.DWts {
+			assert.Equal(t, max(dw, -dw), si.Acc[i])
+		}
+	}
+	assert.NoError(t, cn.TaskBoundary(net))
+	assert.Equal(t, p1.Wts, cn.PathImportance(p1).Anchor)
+	assert.Equal(t, p2.Wts, cn.PathImportance(p2).Anchor)
+}
+
+func TestSynDelay(t *testing.T) {
+	sd := &SynDelaySpec{}
+	sd.Defaults()
+	buf := &SynDelayBuffer{}
+	acts := []float32{1, 2}
+	assert.Equal(t, acts, sd.Send(buf, acts)) // not On
+	sd.On = true
+	var got []float32
+	for cyc := range 5 {
+		acts := []float32{float32(cyc), float32(10 * cyc)}
+		got = append(got, sd.Send(buf, acts)[1])
+	}
+	assert.Equal(t, []float32{0, 0, 0, 10, 20}, got)
+	assert.Equal(t, float32(2), buf.DelayedValue(0))
+	buf.Reset()
+	assert.Equal(t, []float32{0, 0}, buf.Delayed())
+}
+
+func TestShortPlast(t *testing.T) {
+	sp := &ShortPlastSpec{}
+	sp.Defaults()
+	trs, nrs, prs := make([]float32, 2), make([]float32, 2), make([]float32, 2)
+	sp.InitValues(trs, nrs, prs)
+	assert.Equal(t, []float32{1, 1}, trs)
+	sp.UpdateCycleValues(trs, nrs, prs, []float32{1, 0})
+	assert.Equal(t, float32(1), trs[0]) // not On
+	sp.On = true
+	// strong facilitation and slow recovery: facilitation then depression
+	for range 3 {
+		sp.UpdateCycleValues(trs, nrs, prs, []float32{1, 0})
+	}
+	assert.Greater(t, prs[0], sp.P0)
+	assert.Less(t, nrs[0], float32(1))
+	assert.Equal(t, float32(1), trs[1])
+	for range 50 {
+		sp.UpdateCycleValues(trs, nrs, prs, []float32{1, 0})
+	}
+	assert.Less(t, trs[0], float32(0.5))
+
+	sp.Algo = STPTrialBinary
+	sp.RecTau = 2
+	sp.FacTau = 1
+	sp.Update()
+	sp.InitValues(trs, nrs, prs)
+	sp.UpdateTrialValues(trs, nrs, prs, []float32{0.8, 0.2})
+	assert.InDelta(t, 0.36*0.8/0.2, trs[0], 1.0e-6)
+	assert.Equal(t, float32(1), trs[1])
+	sp.UpdateTrialValues(trs, nrs, prs, []float32{0.2, 0.2})
+	assert.Greater(t, nrs[0], float32(0.8))
+}
+
+func TestKNaAdapt(t *testing.T) {
+	ka := &KNaAdaptSpec{}
+	ka.Defaults()
+	var gcF, gcM, gcS float32
+	ka.GcFromSpike(&gcF, &gcM, &gcS, true)
+	assert.Equal(t, float32(0), ka.Gc(gcF, gcM, gcS)) // not On
+	ka.On = true
+	for range 20 {
+		ka.GcFromSpike(&gcF, &gcM, &gcS, true)
+	}
+	assert.Greater(t, gcF/ka.Fast.Max, gcM/ka.Med.Max) // fast rises faster
+	assert.Greater(t, gcM, gcS)
+	assert.Less(t, gcF, ka.Fast.Max)
+	fast, slow := gcF, gcS
+	for range 100 {
+		ka.GcFromRate(&gcF, &gcM, &gcS, 0)
+	}
+	assert.Less(t, gcF/fast, gcS/slow) // fast decays faster
+	gc := ka.Gc(gcF, gcM, gcS)
+	assert.Equal(t, float32(0.5), ka.ClampAct(0.5, gc))
+	ka.Clamp = true
+	ka.InvertNd = true
+	assert.InDelta(t, 0.5*(1-gc), ka.ClampAct(0.5, gc), 1.0e-6)
+	assert.InDelta(t, 0.5*(1+gc), ka.NdAct(0.5, gc), 1.0e-6)
+}
+
+func TestSpikeMisc(t *testing.T) {
+	sm := &SpikeMiscSpec{}
+	sm.Defaults()
+	assert.Equal(t, float32(0), sm.ExpDrive(0.6))
+	sm.Exp = true
+	assert.InDelta(t, sm.ExpSlope, sm.ExpDrive(sm.Thr), 1.0e-3)
+	assert.Greater(t, sm.ExpDrive(0.6), sm.ExpDrive(0.5))
+
+	vm, isi := float32(1), -1
+	assert.False(t, sm.Spike(&vm, &isi)) // below ExpThr
+	assert.Equal(t, -1, isi)
+	vm = 1.3
+	assert.True(t, sm.Spike(&vm, &isi))
+	assert.Equal(t, sm.VmR, vm)
+	nspk := 0
+	for range 3 {
+		vm = 2
+		if sm.Spike(&vm, &isi) {
+			nspk++
+		}
+	}
+	assert.Equal(t, 0, nspk) // refractory
+	vm = 2
+	assert.True(t, sm.Spike(&vm, &isi))
+
+	sm.ClampType = Regular
+	sm.ClampMaxP = 0.25
+	nspk = 0
+	for cyc := range 100 {
+		if sm.ClampSpike(1, cyc, nil) {
+			nspk++
+		}
+	}
+	assert.Equal(t, 25, nspk)
+	sm.ClampType = Clamped
+	assert.True(t, sm.ClampSpike(1, 0, nil))
+	assert.False(t, sm.ClampSpike(0, 0, nil))
+}
+
+func TestAdaptWtScale(t *testing.T) {
+	aw := &AdaptWtScaleSpec{}
+	aw.Defaults()
+	scales := []float32{1, 1, 1}
+	wts := []float32{0.1, 0.5, 0.9}
+	aw.AdaptValues(scales, wts)
+	assert.Equal(t, []float32{1, 1, 1}, scales) // not On
+	aw.On = true
+	aw.Tau = 10
+	aw.Update()
+	for range 1000 {
+		aw.AdaptValues(scales, wts)
+	}
+	assert.InDelta(t, aw.LoScale, scales[0], 1.0e-4)
+	assert.Equal(t, float32(1), scales[1])
+	assert.InDelta(t, aw.HiScale, scales[2], 1.0e-4)
+}
+
+func TestDWtShare(t *testing.T) {
+	ds := &DWtShareSpec{}
+	ds.Defaults()
+	dwts := []float32{1, 0, 0, 0, 0, 0, 0, 0}
+	assert.Equal(t, 0, ds.Share(dwts, nil)) // not On
+	ds.On = true
+	ds.PShare = 1
+	ds.Neigh = 1
+	rnd := randx.NewSysRand(1)
+	nsh := ds.Share(dwts, rnd)
+	assert.Greater(t, nsh, 0)
+	sum := float32(0)
+	for _, dw := range dwts {
+		sum += dw
+	}
+	assert.InDelta(t, 1, sum, 1.0e-6) // total change is conserved
+	assert.Less(t, dwts[0], float32(1))
+}
+
+func TestUnlearnable(t *testing.T) {
+	us := &UnlearnableSpec{}
+	us.Defaults()
+	us.On = true
+	cs := &CosDiffStats{}
+	for i := range 200 {
+		us.Trial(cs, 0.9+0.02*float32(i%2))
+		assert.Equal(t, float32(1), us.LrateMod(cs))
+	}
+	assert.InDelta(t, 0.91, cs.Avg, 0.01)
+	assert.True(t, us.Trial(cs, 0.5))
+	assert.Less(t, cs.Z, -us.ZThr)
+	assert.Equal(t, float32(0), us.LrateMod(cs))
+	assert.False(t, us.Trial(cs, 0.91))
+	assert.Equal(t, 1, cs.ResetCount())
+	assert.Equal(t, 0, cs.NUnlearnable)
+}
+
+func TestDropout(t *testing.T) {
+	ds := &DropoutSpec{}
+	ds.Defaults()
+	st := &DropoutState{}
+	acts := []float32{1, 1, 1, 1}
+	assert.False(t, ds.NewTrial(st, "Test", 0, len(acts))) // not On
+	ds.On = true
+	ds.P = 0.5
+	ds.Seed = 10
+	assert.False(t, ds.NewTrial(st, "Train", 0, len(acts)))
+	ds.Apply(st, acts)
+	assert.Equal(t, []float32{1, 1, 1, 1}, acts)
+
+	n := 1000
+	assert.True(t, ds.NewTrial(st, "Test", 3, n))
+	assert.Equal(t, int64(13), st.TrialSeed)
+	assert.InDelta(t, 500, st.NSilenced, 60)
+	sil := slices.Clone(st.Silenced)
+	ds.NewTrial(st, "Test", 3, n)
+	assert.Equal(t, sil, st.Silenced) // reproducible from seed
+	acts = make([]float32, n)
+	for i := range acts {
+		acts[i] = 1
+	}
+	ds.Apply(st, acts)
+	for i, sl := range st.Silenced {
+		assert.Equal(t, sl, acts[i] == 0)
+	}
+}
+
+func TestActReg(t *testing.T) {
+	ar := &ActRegSpec{}
+	ar.Defaults()
+	st := &ActRegState{}
+	st.Init(ar.Sparsity)
+	assert.Equal(t, float32(0), ar.Adapt(st, 0.5)) // not On
+	ar.On = true
+	st.Init(ar.Sparsity)
+	assert.Equal(t, float32(0), ar.Adapt(st, 0.15)) // within tolerance
+	for range 100 {
+		ar.Adapt(st, 0.4)
+	}
+	assert.Less(t, st.Offset, float32(0)) // bias reduced when too active
+	assert.Greater(t, st.Penalty, float32(0))
+	ar.Target = RegThr
+	st.Init(ar.Sparsity)
+	for range 100 {
+		ar.Adapt(st, 0.4)
+	}
+	assert.Greater(t, st.Offset, float32(0)) // threshold raised
+	for range 10000 {
+		ar.Adapt(st, 1)
+	}
+	assert.Equal(t, ar.Max, st.Offset)
+}
+
+func TestBiasLearn(t *testing.T) {
+	bs := &BiasLearnSpec{}
+	bs.Defaults()
+	biases := []float32{0, 0.5}
+	bs.Lrate = 0.5
+	bs.Max = 0.6
+	bs.DWtValues(biases, []float32{0.2, 0.4})
+	assert.InDelta(t, 0.1, biases[0], 1.0e-6)
+	assert.InDelta(t, 0.6, biases[1], 1.0e-6)
+	bs.Learn = false
+	bs.DWtValues(biases, []float32{1, 1})
+	assert.InDelta(t, 0.1, biases[0], 1.0e-6)
+}
+
+func TestDefaults(t *testing.T) {
+	specs := []any{&ActRegSpec{}, &AdaptWtScaleSpec{}, &BiasLearnSpec{}, &ConsolidateSpec{}, &DropoutSpec{}, &DWtShareSpec{}, &GainModSpec{}, &HomeostasisSpec{}, &InterneuronSpec{}, &KNaAdaptSpec{}, &NeuromodBus{}, &ShortPlastSpec{}, &SignSpec{}, &SpikeMiscSpec{}, &SynDelaySpec{}, &UnlearnableSpec{}}
+	assert.NoError(t, params.CheckDefaults(specs...))
+	assert.NoError(t, params.CheckShouldDisplay(specs...))
+	// switches and seeds that have no meaningful default value
+	var miss []string
+	for _, sp := range specs {
+		miss = append(miss, params.MissingDefaults(sp)...)
+	}
+	assert.Equal(t, []string{"DropoutSpec.Seed", "KNaAdaptSpec.Clamp", "KNaAdaptSpec.InvertNd", "SpikeMiscSpec.Exp"}, miss)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"fmt"
+
+	"cogentcore.org/core/math32"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// Neuromods are the neuromodulatory signals broadcast on a NeuromodBus.
+type Neuromods int32 //enums:enum
+
+const (
+	// DA is dopamine, which signals reward prediction errors
+	// and modulates learning and gating.
+	DA Neuromods = iota
+
+	// ACh is acetylcholine, which signals salience / novelty
+	// and modulates attention and learning rate.
+	ACh
+
+	// NE is norepinephrine, which signals arousal / uncertainty,
+	// and modulates the gain of neural responses.
+	NE
+
+	// Ser is serotonin (5HT), which signals aversive outcomes
+	// and patience / temporal discounting.
+	Ser
+)
+
+// NeuromodSource is a source of a neuromodulatory signal,
+// which is updated by calling its Func.
+type NeuromodSource struct {
+
+	// Mod is the neuromodulator that this source publishes.
+	Mod Neuromods
+
+	// Name is the name of the source, typically the layer name.
+	Name string
+
+	// Func computes the current value of the signal.
+	Func func() float32 `display:"-"`
+}
+
+// NeuromodBus is a network-level broadcast mechanism for neuromodulatory
+// signals (DA, ACh, NE, Ser): designated sources publish scalar values,
+// and receiving layers subscribe with a per-layer gain on each signal.
+// Call Update at the appropriate time (e.g., each trial or cycle)
+// to update the values from the sources, and then receiving layers
+// get their gain-weighted value using Value.
+// Values can also be directly set using Publish.
+type NeuromodBus struct {
+
+	// Values are the current values of each neuromodulator.
+	Values [NeuromodsN]float32
+
+	// Sources are the sources of neuromodulatory signals.
+	Sources []*NeuromodSource
+
+	// Gains are the subscription gains for each receiving layer, by name,
+	// on each neuromodulator.
+	Gains map[string]*[NeuromodsN]float32
+}
+
+// Init resets the current values to 0, retaining the sources and gains.
+func (nb *NeuromodBus) Init() {
+	for i := range nb.Values {
+		nb.Values[i] = 0
+	}
+}
+
+// AddSource adds a source that publishes given neuromodulator,
+// with the value computed by given function.
+func (nb *NeuromodBus) AddSource(mod Neuromods, name string, fun func() float32) *NeuromodSource {
+	src := &NeuromodSource{Mod: mod, Name: name, Func: fun}
+	nb.Sources = append(nb.Sources, src)
+	return src
+}
+
+// AddLayerSource adds the given layer as a source that publishes given
+// neuromodulator, with the value being the average of given unit
+// variable across the units in the layer (for data parallel index 0).
+func (nb *NeuromodBus) AddLayerSource(mod Neuromods, ly emer.Layer, varNm string) *NeuromodSource {
+	var vals []float32
+	return nb.AddSource(mod, ly.Label(), func() float32 {
+		if ly.AsEmer().UnitValues(&vals, varNm, 0) != nil {
+			return 0
+		}
+		sum := float32(0)
+		n := 0
+		for _, v := range vals {
+			if !math32.IsNaN(v) {
+				sum += v
+				n++
+			}
+		}
+		if n == 0 {
+			return 0
+		}
+		return sum / float32(n)
+	})
+}
+
+// Subscribe subscribes given receiving layer to given neuromodulator
+// with given gain.
+func (nb *NeuromodBus) Subscribe(layer string, mod Neuromods, gain float32) {
+	if nb.Gains == nil {
+		nb.Gains = make(map[string]*[NeuromodsN]float32)
+	}
+	gs, ok := nb.Gains[layer]
+	if !ok {
+		gs = &[NeuromodsN]float32{}
+		nb.Gains[layer] = gs
+	}
+	gs[mod] = gain
+}
+
+// Publish directly sets the current value of given neuromodulator.
+func (nb *NeuromodBus) Publish(mod Neuromods, val float32) {
+	nb.Values[mod] = val
+}
+
+// Update updates the current values from all of the sources.
+// If multiple sources publish the same neuromodulator, the values are summed.
+func (nb *NeuromodBus) Update() {
+	if len(nb.Sources) == 0 {
+		return
+	}
+	var set [NeuromodsN]bool
+	for _, src := range nb.Sources {
+		v := src.Func()
+		if !set[src.Mod] {
+			nb.Values[src.Mod] = v
+			set[src.Mod] = true
+		} else {
+			nb.Values[src.Mod] += v
+		}
+	}
+}
+
+// Value returns the gain-weighted value of given neuromodulator for
+// given receiving layer, which is 0 if the layer is not subscribed.
+func (nb *NeuromodBus) Value(layer string, mod Neuromods) float32 {
+	gs, ok := nb.Gains[layer]
+	if !ok {
+		return 0
+	}
+	return gs[mod] * nb.Values[mod]
+}
+
+// String returns a summary of the current values.
+func (nb *NeuromodBus) String() string {
+	str := ""
+	for i, v := range nb.Values {
+		str += fmt.Sprintf("%s: %g\t", Neuromods(i).String(), v)
+	}
+	return str
+}
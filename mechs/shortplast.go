@@ -0,0 +1,162 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+// ShortPlastAlgos are the algorithms for short-term synaptic plasticity.
+type ShortPlastAlgos int32 //enums:enum
+
+const (
+	// STPCycles updates the synaptic state every cycle, using the sending
+	// spike (or rate-code activation as the expected spikes per cycle),
+	// as in the Hennig (2013) model.
+	STPCycles ShortPlastAlgos = iota
+
+	// STPTrialBinary updates the synaptic state once per trial, based on
+	// whether the sending activation is above the TrialThr threshold,
+	// which is a simpler, more abstract model for rate-code networks.
+	STPTrialBinary
+)
+
+// ShortPlastSpec has parameters for short-term synaptic plasticity
+// (facilitation and depression), based on the Hennig (2013) model,
+// as in the C++ emergent ShortPlastSpec, for working memory and
+// adaptation models. The synaptic state is represented by three
+// variables per synapse (or per sending unit, as it only depends
+// on the sender): Nr = the fraction of available (recovered) vesicles,
+// which is depleted by release and recovers with RecTau (depression);
+// Pr = the probability of release, which increases with each spike
+// and decays back to P0 with FacTau (facilitation); and Tr = the
+// resulting transmission efficacy, which multiplies the sending
+// activation, and is normalized to 1 at baseline (Pr = P0, Nr = 1).
+// Call Init when initializing weights, and UpdateCycle every cycle,
+// or UpdateTrial at the end of each trial, depending on the Algo.
+type ShortPlastSpec struct {
+
+	// On enables short-term plasticity.
+	On bool
+
+	// Algo is the algorithm for updating the synaptic state.
+	Algo ShortPlastAlgos `default:"STPCycles"`
+
+	// P0 is the baseline probability of release.
+	P0 float32 `default:"0.2" min:"0.001" max:"1"`
+
+	// Fac is the amount of facilitation per spike, as the proportion of
+	// the remaining distance to 1 that the probability of release increases.
+	Fac float32 `default:"0.2" min:"0" max:"1"`
+
+	// RecTau is the time constant for recovery of available vesicles,
+	// in cycles for STPCycles, or trials for STPTrialBinary.
+	RecTau float32 `default:"200,2" min:"1"`
+
+	// FacTau is the time constant for decay of the probability of release
+	// back to P0, in cycles for STPCycles, or trials for STPTrialBinary.
+	FacTau float32 `default:"50,1" min:"1"`
+
+	// TrialThr is the threshold on the sending activation for
+	// counting as a spike in the STPTrialBinary algorithm.
+	TrialThr float32 `default:"0.5"`
+
+	// RecDt is the rate constant = 1 / RecTau
+	RecDt float32 `display:"-"`
+
+	// FacDt is the rate constant = 1 / FacTau
+	FacDt float32 `display:"-"`
+}
+
+func (sp *ShortPlastSpec) Defaults() {
+	sp.Algo = STPCycles
+	sp.P0 = 0.2
+	sp.Fac = 0.2
+	sp.RecTau = 200
+	sp.FacTau = 50
+	sp.TrialThr = 0.5
+	sp.Update()
+}
+
+func (sp *ShortPlastSpec) Update() {
+	sp.RecDt = 1 / sp.RecTau
+	sp.FacDt = 1 / sp.FacTau
+}
+
+func (sp *ShortPlastSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	case "TrialThr":
+		return sp.On && sp.Algo == STPTrialBinary
+	default:
+		return sp.On
+	}
+}
+
+// Init initializes the synaptic state to baseline.
+func (sp *ShortPlastSpec) Init(tr, nr, pr *float32) {
+	*tr = 1
+	*nr = 1
+	*pr = sp.P0
+}
+
+// update updates the synaptic state given the spike value.
+func (sp *ShortPlastSpec) update(tr, nr, pr *float32, spike float32) {
+	rel := *pr * *nr * spike
+	*nr += sp.RecDt*(1-*nr) - rel
+	*pr += sp.FacDt*(sp.P0-*pr) + sp.Fac*(1-*pr)*spike
+	*tr = (*pr * *nr) / sp.P0
+}
+
+// UpdateCycle updates the synaptic state for one cycle, given the
+// sending spike (1 or 0), or rate-code activation as the expected number
+// of spikes per cycle, for the STPCycles algorithm, if On.
+func (sp *ShortPlastSpec) UpdateCycle(tr, nr, pr *float32, spike float32) {
+	if !sp.On || sp.Algo != STPCycles {
+		return
+	}
+	sp.update(tr, nr, pr, spike)
+}
+
+// UpdateTrial updates the synaptic state at the end of a trial,
+// given the sending activation, which counts as a spike if it is above
+// TrialThr, for the STPTrialBinary algorithm, if On.
+func (sp *ShortPlastSpec) UpdateTrial(tr, nr, pr *float32, act float32) {
+	if !sp.On || sp.Algo != STPTrialBinary {
+		return
+	}
+	spike := float32(0)
+	if act > sp.TrialThr {
+		spike = 1
+	}
+	sp.update(tr, nr, pr, spike)
+}
+
+// InitValues initializes the synaptic state for all
+// synapses (or sending units) in given slices.
+func (sp *ShortPlastSpec) InitValues(trs, nrs, prs []float32) {
+	for i := range trs {
+		sp.Init(&trs[i], &nrs[i], &prs[i])
+	}
+}
+
+// UpdateCycleValues calls UpdateCycle for all sending units
+// in given slices, with corresponding spikes.
+func (sp *ShortPlastSpec) UpdateCycleValues(trs, nrs, prs, spikes []float32) {
+	if !sp.On || sp.Algo != STPCycles {
+		return
+	}
+	for i, spk := range spikes {
+		sp.update(&trs[i], &nrs[i], &prs[i], spk)
+	}
+}
+
+// UpdateTrialValues calls UpdateTrial for all sending units
+// in given slices, with corresponding activations.
+func (sp *ShortPlastSpec) UpdateTrialValues(trs, nrs, prs, acts []float32) {
+	if !sp.On || sp.Algo != STPTrialBinary {
+		return
+	}
+	for i, act := range acts {
+		sp.UpdateTrial(&trs[i], &nrs[i], &prs[i], act)
+	}
+}
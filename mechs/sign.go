@@ -0,0 +1,103 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+//go:generate core generate -add-types
+
+// Signs are the sign constraints on synaptic weights,
+// used for implementing Dale's law, where each neuron is either
+// excitatory or inhibitory in all of its sending synapses.
+type Signs int32 //enums:enum
+
+const (
+	// Unsigned places no constraint on the sign of the weights.
+	Unsigned Signs = iota
+
+	// Excitatory constrains weights to be positive (>= 0).
+	Excitatory
+
+	// Inhibitory constrains weights to be negative (<= 0).
+	Inhibitory
+)
+
+// SignSpec specifies a sign constraint on the weights of a pathway,
+// for biologically constrained circuit models that obey Dale's law.
+// The algorithm calls ConstrainDWt when applying weight changes,
+// and Constrain when initializing or loading weights.
+type SignSpec struct {
+
+	// Sign is the sign constraint on the weights.
+	Sign Signs `default:"Unsigned"`
+
+	// MinAbs is the minimum absolute value of the weights,
+	// which prevents synapses from becoming permanently silent
+	// by being pinned at zero by the constraint.
+	MinAbs float32 `default:"0"`
+}
+
+func (ss *SignSpec) Defaults() {
+	ss.Sign = Unsigned
+	ss.MinAbs = 0
+}
+
+func (ss *SignSpec) Update() {
+}
+
+func (ss *SignSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "MinAbs":
+		return ss.Sign != Unsigned
+	default:
+		return true
+	}
+}
+
+// Constrain returns the weight value constrained to the sign.
+func (ss *SignSpec) Constrain(wt float32) float32 {
+	switch ss.Sign {
+	case Excitatory:
+		return max(wt, ss.MinAbs)
+	case Inhibitory:
+		return min(wt, -ss.MinAbs)
+	}
+	return wt
+}
+
+// ConstrainDWt returns the weight change that can be applied to
+// the given current weight without violating the sign constraint.
+// Weight changes that would cross the boundary are truncated at it.
+func (ss *SignSpec) ConstrainDWt(wt, dwt float32) float32 {
+	if ss.Sign == Unsigned {
+		return dwt
+	}
+	nw := wt + dwt
+	cw := ss.Constrain(nw)
+	if cw == nw {
+		return dwt
+	}
+	return cw - wt
+}
+
+// IsValid returns true if the given weight satisfies the constraint.
+func (ss *SignSpec) IsValid(wt float32) bool {
+	return ss.Constrain(wt) == wt
+}
+
+// ConstrainValues applies Constrain to each of the given weight values,
+// returning the number of values that were changed.
+func (ss *SignSpec) ConstrainValues(wts []float32) int {
+	n := 0
+	if ss.Sign == Unsigned {
+		return n
+	}
+	for i, wt := range wts {
+		cw := ss.Constrain(wt)
+		if cw != wt {
+			wts[i] = cw
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"math/rand"
+
+	"cogentcore.org/core/math32"
+	"cogentcore.org/lab/base/randx"
+)
+
+// ClampSpikes are the ways of generating spikes for clamped (input)
+// units in spiking mode, from the clamped activation value.
+type ClampSpikes int32 //enums:enum
+
+const (
+	// Poisson generates spikes with a probability per cycle of
+	// ClampMaxP times the clamped activation.
+	Poisson ClampSpikes = iota
+
+	// Regular generates spikes at regular intervals, with an
+	// inter-spike interval of 1 / (ClampMaxP times the clamped activation).
+	Regular
+
+	// Clamped uses the clamped activation directly as the spike,
+	// spiking when it is > 0.5, for externally generated spike trains.
+	Clamped
+)
+
+// SpikeMiscSpec has miscellaneous options for spiking activation,
+// as in the C++ emergent SpikeMiscSpec, including the exponential
+// upswing of the adaptive exponential (AdEx) model (Brette & Gerstner,
+// 2005), an explicit refractory period after each spike, and the
+// generation of spikes for clamped input units.
+// None of the networks in this module (bp, hebb) have spiking activation,
+// so this is only used by spiking algorithms in other modules,
+// which call its methods from their own spiking update.
+type SpikeMiscSpec struct {
+
+	// Exp uses the exponential upswing of membrane potential from the AdEx
+	// model, via ExpDrive, where spiking occurs at ExpThr instead of Thr.
+	Exp bool
+
+	// ExpSlope is the slope in Vm for the exponential upswing,
+	// which determines how sharply it rises.
+	ExpSlope float32 `default:"0.02"`
+
+	// ExpThr is the membrane potential threshold for spiking
+	// when using the exponential upswing.
+	ExpThr float32 `default:"1.2"`
+
+	// Thr is the membrane potential threshold for spiking
+	// without the exponential upswing, and the point where
+	// the exponential upswing is centered.
+	Thr float32 `default:"0.5"`
+
+	// VmR is the membrane potential after a spike (reset).
+	VmR float32 `default:"0.3"`
+
+	// Tr is the refractory period in cycles after a spike, during which
+	// the membrane potential is held at VmR and no spikes occur.
+	Tr int `default:"3" min:"0"`
+
+	// ClampType is how spikes are generated for clamped units.
+	ClampType ClampSpikes `default:"Poisson"`
+
+	// ClampMaxP is the maximum probability of spiking per cycle for
+	// clamped units, for a clamped activation of 1.
+	ClampMaxP float32 `default:"0.12" min:"0" max:"1"`
+}
+
+func (sm *SpikeMiscSpec) Defaults() {
+	sm.ExpSlope = 0.02
+	sm.ExpThr = 1.2
+	sm.Thr = 0.5
+	sm.VmR = 0.3
+	sm.Tr = 3
+	sm.ClampType = Poisson
+	sm.ClampMaxP = 0.12
+}
+
+func (sm *SpikeMiscSpec) Update() {
+}
+
+func (sm *SpikeMiscSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "ExpSlope", "ExpThr":
+		return sm.Exp
+	case "ClampMaxP":
+		return sm.ClampType != Clamped
+	default:
+		return true
+	}
+}
+
+// SpikeThr returns the membrane potential threshold for spiking.
+func (sm *SpikeMiscSpec) SpikeThr() float32 {
+	if sm.Exp {
+		return sm.ExpThr
+	}
+	return sm.Thr
+}
+
+// ExpDrive returns the exponential upswing term for the membrane
+// potential, which is added to the other currents (multiplied by the
+// leak conductance) when Exp is on, and 0 otherwise.
+func (sm *SpikeMiscSpec) ExpDrive(vm float32) float32 {
+	if !sm.Exp {
+		return 0
+	}
+	return sm.ExpSlope * math32.FastExp((vm-sm.Thr)/sm.ExpSlope)
+}
+
+// InRefractory returns true if a unit is in the refractory period,
+// given the number of cycles since its last spike (negative = no spike yet).
+func (sm *SpikeMiscSpec) InRefractory(isi int) bool {
+	return isi >= 0 && isi < sm.Tr
+}
+
+// Spike updates the membrane potential and the count of cycles since
+// the last spike, returning true if the unit spikes. During the
+// refractory period, vm is held at VmR and no spikes occur.
+// Call once per cycle after updating vm.
+func (sm *SpikeMiscSpec) Spike(vm *float32, isi *int) bool {
+	if sm.InRefractory(*isi) {
+		*vm = sm.VmR
+		*isi++
+		return false
+	}
+	if *vm > sm.SpikeThr() {
+		*vm = sm.VmR
+		*isi = 0
+		return true
+	}
+	if *isi >= 0 {
+		*isi++
+	}
+	return false
+}
+
+// ClampSpike returns true if a clamped unit with given activation
+// spikes at given cycle, according to ClampType, using given random
+// number source for Poisson spikes (nil = global source).
+func (sm *SpikeMiscSpec) ClampSpike(act float32, cycle int, rnd randx.Rand) bool {
+	switch sm.ClampType {
+	case Clamped:
+		return act > 0.5
+	case Regular:
+		p := sm.ClampMaxP * act
+		if p <= 0 {
+			return false
+		}
+		return int(float32(cycle+1)*p) > int(float32(cycle)*p)
+	}
+	p := sm.ClampMaxP * act
+	if rnd == nil {
+		return rand.Float32() < p
+	}
+	return rnd.Float32() < p
+}
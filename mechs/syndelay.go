@@ -0,0 +1,121 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"github.com/emer/emergent/v2/ringidx"
+)
+
+// SynDelaySpec specifies a transmission delay in cycles for the sending
+// activations of a pathway, as in the C++ emergent SynDelaySpec, to model
+// axonal conduction delays, e.g., in recurrent self-projections, which
+// is needed for some oscillation and timing models.
+// The algorithm keeps a [SynDelayBuffer] for each pathway, and calls
+// Send each cycle with the current sender activations, using the
+// returned delayed activations to compute the input to the receivers.
+type SynDelaySpec struct {
+
+	// On enables the synaptic delay.
+	On bool
+
+	// Delay is the number of cycles of delay in transmitting activations
+	// from the sender to the receiver. 0 = no delay.
+	Delay int `default:"2" min:"0"`
+}
+
+func (sd *SynDelaySpec) Defaults() {
+	sd.Delay = 2
+}
+
+func (sd *SynDelaySpec) Update() {
+	sd.Delay = max(sd.Delay, 0)
+}
+
+func (sd *SynDelaySpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "Delay":
+		return sd.On
+	default:
+		return true
+	}
+}
+
+// Send records the current sender activations in the buffer, and returns
+// the activations from Delay cycles ago, if On, or the current activations
+// otherwise. The buffer is initialized as needed for the number of units
+// and Delay. Returned values are only valid until the next Send.
+func (sd *SynDelaySpec) Send(buf *SynDelayBuffer, acts []float32) []float32 {
+	if !sd.On || sd.Delay == 0 {
+		return acts
+	}
+	if buf.NUnits != len(acts) || buf.Delay != sd.Delay {
+		buf.Init(len(acts), sd.Delay)
+	}
+	buf.Push(acts)
+	return buf.Delayed()
+}
+
+// SynDelayBuffer is a ring buffer of the sender activations for a pathway
+// with a [SynDelaySpec], holding the values for the most recent Delay+1
+// cycles, so that no copying is needed to shift values over time.
+// Until Delay cycles have been recorded, delayed values are 0,
+// as nothing has yet arrived at the receivers.
+type SynDelayBuffer struct {
+
+	// Delay is the number of cycles of delay.
+	Delay int
+
+	// NUnits is the number of sending units.
+	NUnits int
+
+	// Ring is the ring index over cycles.
+	Ring ringidx.Index
+
+	// Values are the recorded activations, [Delay+1][NUnits].
+	Values []float32
+
+	// zeros are returned as the delayed values before Delay cycles.
+	zeros []float32
+}
+
+// Init initializes the buffer for given number of sending units and delay.
+func (sb *SynDelayBuffer) Init(nunits, delay int) {
+	sb.NUnits = nunits
+	sb.Delay = delay
+	sb.Ring.Max = delay + 1
+	sb.Values = make([]float32, sb.Ring.Max*nunits)
+	sb.zeros = make([]float32, nunits)
+	sb.Ring.Reset()
+}
+
+// Reset clears the recorded activations, e.g., at the start of a trial
+// when activations are initialized, so that nothing is in transit.
+func (sb *SynDelayBuffer) Reset() {
+	sb.Ring.Reset()
+	clear(sb.Values)
+}
+
+// Push records the current activations of the sending units,
+// overwriting the oldest values.
+func (sb *SynDelayBuffer) Push(acts []float32) {
+	sb.Ring.Add(1)
+	st := sb.Ring.LastIndex() * sb.NUnits
+	copy(sb.Values[st:st+sb.NUnits], acts)
+}
+
+// Delayed returns the activations recorded Delay cycles before the
+// most recent Push, which are 0 if fewer than Delay+1 have been recorded.
+func (sb *SynDelayBuffer) Delayed() []float32 {
+	if sb.Ring.Len < sb.Ring.Max {
+		return sb.zeros
+	}
+	st := sb.Ring.Index(0) * sb.NUnits
+	return sb.Values[st : st+sb.NUnits]
+}
+
+// DelayedValue returns the delayed activation of given sending unit index.
+func (sb *SynDelayBuffer) DelayedValue(ni int) float32 {
+	return sb.Delayed()[ni]
+}
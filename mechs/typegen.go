@@ -0,0 +1,71 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package mechs
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.Signs", IDName: "signs", Doc: "Signs are the sign constraints on synaptic weights,\nused for implementing Dale's law, where each neuron is either\nexcitatory or inhibitory in all of its sending synapses."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.SignSpec", IDName: "sign-spec", Doc: "SignSpec specifies a sign constraint on the weights of a pathway,\nfor biologically constrained circuit models that obey Dale's law.\nThe algorithm calls ConstrainDWt when applying weight changes,\nand Constrain when initializing or loading weights.", Fields: []types.Field{{Name: "Sign", Doc: "Sign is the sign constraint on the weights."}, {Name: "MinAbs", Doc: "MinAbs is the minimum absolute value of the weights,\nwhich prevents synapses from becoming permanently silent\nby being pinned at zero by the constraint."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.InhibRegimes", IDName: "inhib-regimes", Doc: "InhibRegimes are the ways of implementing inhibition within a layer."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.Builder", IDName: "builder", Doc: "Builder is the interface that an algorithm implements to support\ngeneric network construction helpers, such as AddInterneurons.\nLayer and pathway types are given using the algorithm's type names\n(i.e., the TypeName() strings), which the implementation\nis responsible for converting into its own types.", Methods: []types.Method{{Name: "AddLayer", Doc: "AddLayer adds a new layer with given name, shape and type name.", Args: []string{"name", "shape", "typ"}, Returns: []string{"Layer"}}, {Name: "ConnectLayers", Doc: "ConnectLayers adds a new pathway between given layers,\nwith given pattern of connectivity and type name.", Args: []string{"send", "recv", "pat", "typ"}, Returns: []string{"Path"}}, {Name: "SetFFFB", Doc: "SetFFFB turns the FFFB inhibition function on or off for given layer.", Args: []string{"ly", "on"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.InterneuronSpec", IDName: "interneuron-spec", Doc: "InterneuronSpec has parameters for constructing a paired layer of\nexplicit inhibitory interneurons for a given excitatory layer.", Fields: []types.Field{{Name: "Prop", Doc: "Prop is the number of inhibitory units as a proportion of the number\nof excitatory units. In the neocortex, roughly 20% of neurons\nare inhibitory. For 4D layers, this applies within each pool."}, {Name: "Suffix", Doc: "Suffix is added to the name of the excitatory layer\nto name the inhibitory layer."}, {Name: "LayerType", Doc: "LayerType is the algorithm type name for the inhibitory layer."}, {Name: "EToIType", Doc: "EToIType is the algorithm type name for the E->I pathway."}, {Name: "IToEType", Doc: "IToEType is the algorithm type name for the I->E pathway,\nwhich must be an inhibitory pathway type."}, {Name: "EToIClass", Doc: "EToIClass is the parameter class added to the E->I pathway."}, {Name: "IToEClass", Doc: "IToEClass is the parameter class added to the I->E pathway."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.InhibPair", IDName: "inhib-pair", Doc: "InhibPair holds an excitatory layer and its paired\ninhibitory interneuron layer and pathways.", Fields: []types.Field{{Name: "Exc", Doc: "Exc is the excitatory layer."}, {Name: "Inh", Doc: "Inh is the inhibitory interneuron layer."}, {Name: "EToI", Doc: "EToI is the pathway from excitatory to inhibitory layer."}, {Name: "IToE", Doc: "IToE is the pathway from inhibitory to excitatory layer."}, {Name: "Regime", Doc: "Regime is the current inhibition regime."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.Neuromods", IDName: "neuromods", Doc: "Neuromods are the neuromodulatory signals broadcast on a NeuromodBus."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.NeuromodSource", IDName: "neuromod-source", Doc: "NeuromodSource is a source of a neuromodulatory signal,\nwhich is updated by calling its Func.", Fields: []types.Field{{Name: "Mod", Doc: "Mod is the neuromodulator that this source publishes."}, {Name: "Name", Doc: "Name is the name of the source, typically the layer name."}, {Name: "Func", Doc: "Func computes the current value of the signal."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.NeuromodBus", IDName: "neuromod-bus", Doc: "NeuromodBus is a network-level broadcast mechanism for neuromodulatory\nsignals (DA, ACh, NE, Ser): designated sources publish scalar values,\nand receiving layers subscribe with a per-layer gain on each signal.\nCall Update at the appropriate time (e.g., each trial or cycle)\nto update the values from the sources, and then receiving layers\nget their gain-weighted value using Value.\nValues can also be directly set using Publish.", Fields: []types.Field{{Name: "Values", Doc: "Values are the current values of each neuromodulator."}, {Name: "Sources", Doc: "Sources are the sources of neuromodulatory signals."}, {Name: "Gains", Doc: "Gains are the subscription gains for each receiving layer, by name,\non each neuromodulator."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.GainModSpec", IDName: "gain-mod-spec", Doc: "GainModSpec has parameters for the modulation of the activation function\ngain of layers by a network-level scalar arousal level, simulating\nthe effects of norepinephrine (NE) from the locus coeruleus (LC),\nas in LC-NE models of attention and arousal (Aston-Jones & Cohen, 2005).\nThe gain multiplier is 1 at the Base level, and increases linearly\nwith Sens as the level goes above Base, clipped to the Min..Max range.", Fields: []types.Field{{Name: "On", Doc: "On enables gain modulation."}, {Name: "Layers", Doc: "Layers is a space-separated list of selectors for the layers that\nare modulated, using params selector syntax: .Class or #Name.\nAn empty list applies to all layers."}, {Name: "Base", Doc: "Base is the baseline arousal level where the gain multiplier is 1."}, {Name: "Sens", Doc: "Sens is the sensitivity of the gain multiplier to the difference\nof the arousal level from Base."}, {Name: "Min", Doc: "Min is the minimum gain multiplier."}, {Name: "Max", Doc: "Max is the maximum gain multiplier."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.GainMod", IDName: "gain-mod", Doc: "GainMod holds the current arousal level and resulting gain multiplier,\naccording to the GainModSpec.  Typically the Level is set per trial\nfrom the environment or a model signal (e.g., NE on a NeuromodBus),\nand the algorithm multiplies its activation function gain by Gain\nfor each layer that the spec Applies to.\nThe Level and Gain values can be logged as stats.", Embeds: []types.Field{{Name: "GainModSpec"}}, Fields: []types.Field{{Name: "Level", Doc: "Level is the current arousal level."}, {Name: "Gain", Doc: "Gain is the current gain multiplier, computed from Level."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.HomeostasisSpec", IDName: "homeostasis-spec", Doc: "HomeostasisSpec has parameters for a slow homeostatic adjustment of the\nintrinsic excitability (bias) of each unit toward a target average\nactivation, which keeps long-running models (e.g., continual learning)\nfrom drifting into silent or saturated regimes.  This operates on the\nunit bias directly, in addition to any BCM-style long-term average\n(AvgL) modulation of learning in the algorithm.\nTypically called at the end of each trial: UpdateAvg updates the running\naverage activity of each unit, and Adapt adjusts the bias accordingly.", Fields: []types.Field{{Name: "On", Doc: "On enables homeostatic adaptation of unit excitability."}, {Name: "Target", Doc: "Target is the target average activity level for each unit."}, {Name: "AvgTau", Doc: "AvgTau is the time constant for integrating the running average\nactivity of each unit, in terms of the number of UpdateAvg calls\n(typically trials)."}, {Name: "Rate", Doc: "Rate is the rate of change in the bias per UpdateAvg call,\nas a proportion of the difference between Target and average activity."}, {Name: "Min", Doc: "Min is the minimum bias value."}, {Name: "Max", Doc: "Max is the maximum bias value."}, {Name: "AvgDt", Doc: "AvgDt is the rate constant = 1 / AvgTau"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.ImportanceMeasures", IDName: "importance-measures", Doc: "ImportanceMeasures are the ways of estimating the importance of\neach synapse for the tasks learned so far."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.ConsolidateSpec", IDName: "consolidate-spec", Doc: "ConsolidateSpec has parameters for continual learning consolidation,\nwhere the importance of each synapse is tracked across a task block,\nand important synapses have reduced plasticity, and are pulled back\ntoward their prior values, in subsequent tasks, to reduce\ncatastrophic interference.", Fields: []types.Field{{Name: "On", Doc: "On enables consolidation."}, {Name: "Measure", Doc: "Measure is how synapse importance is estimated."}, {Name: "Strength", Doc: "Strength is the strength of consolidation: weight changes are\nmultiplied by 1 / (1 + Strength * importance), and the penalty\npulling weights back to their prior values is\nPenalty * Strength * importance * (weight - prior)."}, {Name: "Penalty", Doc: "Penalty is the proportion of the EWC-style penalty that pulls\nweights back toward their values at the last task boundary.\nIf 0, only plasticity is reduced."}, {Name: "Decay", Doc: "Decay is the proportion of prior importance that is retained at\neach task boundary, when adding the importance from the new task."}, {Name: "Norm", Doc: "Norm normalizes the importance from each task by its maximum value\nin each pathway, so that Strength has a consistent meaning."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.SynImportance", IDName: "syn-importance", Doc: "SynImportance holds the synapse importance tracking state for one pathway.", Fields: []types.Field{{Name: "Acc", Doc: "Acc is the importance accumulated over the current task."}, {Name: "Imp", Doc: "Imp is the consolidated importance from prior tasks,\nwhich determines the reduction in plasticity."}, {Name: "Anchor", Doc: "Anchor are the weights at the last task boundary."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.Consolidation", IDName: "consolidation", Doc: "Consolidation manages synapse importance tracking for all pathways\nin a network, using the generic synapse variable access methods\nof the emer.Path interface.  Call Accum after each weight change\ncomputation (i.e., when DWt values are available), and TaskBoundary\nwhen a new task starts.  The algorithm then calls ConsolidateDWts\non the SynImportance for each pathway (see PathImportance) prior to\napplying the weight changes.", Fields: []types.Field{{Name: "Spec", Doc: "Spec has the consolidation parameters."}, {Name: "WtVar", Doc: "WtVar is the name of the synapse weight variable."}, {Name: "DWtVar", Doc: "DWtVar is the name of the synapse weight change variable."}, {Name: "Task", Doc: "Task is the number of task boundaries so far."}, {Name: "Paths", Doc: "Paths has the synapse importance state for each pathway.\nPathway names are not unique across layers, so the pathways\nthemselves are the keys."}, {Name: "vals", Doc: "vals is a temporary buffer for synapse values."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.SynDelaySpec", IDName: "syn-delay-spec", Doc: "SynDelaySpec specifies a transmission delay in cycles for the sending\nactivations of a pathway, as in the C++ emergent SynDelaySpec, to model\naxonal conduction delays, e.g., in recurrent self-projections, which\nis needed for some oscillation and timing models.\nThe algorithm keeps a [SynDelayBuffer] for each pathway, and calls\nSend each cycle with the current sender activations, using the\nreturned delayed activations to compute the input to the receivers.", Fields: []types.Field{{Name: "On", Doc: "On enables the synaptic delay."}, {Name: "Delay", Doc: "Delay is the number of cycles of delay in transmitting activations\nfrom the sender to the receiver. 0 = no delay."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.SynDelayBuffer", IDName: "syn-delay-buffer", Doc: "SynDelayBuffer is a ring buffer of the sender activations for a pathway\nwith a [SynDelaySpec], holding the values for the most recent Delay+1\ncycles, so that no copying is needed to shift values over time.\nUntil Delay cycles have been recorded, delayed values are 0,\nas nothing has yet arrived at the receivers.", Fields: []types.Field{{Name: "Delay", Doc: "Delay is the number of cycles of delay."}, {Name: "NUnits", Doc: "NUnits is the number of sending units."}, {Name: "Ring", Doc: "Ring is the ring index over cycles."}, {Name: "Values", Doc: "Values are the recorded activations, [Delay+1][NUnits]."}, {Name: "zeros", Doc: "zeros are returned as the delayed values before Delay cycles."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.ShortPlastAlgos", IDName: "short-plast-algos", Doc: "ShortPlastAlgos are the algorithms for short-term synaptic plasticity."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.ShortPlastSpec", IDName: "short-plast-spec", Doc: "ShortPlastSpec has parameters for short-term synaptic plasticity\n(facilitation and depression), based on the Hennig (2013) model,\nas in the C++ emergent ShortPlastSpec, for working memory and\nadaptation models. The synaptic state is represented by three\nvariables per synapse (or per sending unit, as it only depends\non the sender): Nr = the fraction of available (recovered) vesicles,\nwhich is depleted by release and recovers with RecTau (depression);\nPr = the probability of release, which increases with each spike\nand decays back to P0 with FacTau (facilitation); and Tr = the\nresulting transmission efficacy, which multiplies the sending\nactivation, and is normalized to 1 at baseline (Pr = P0, Nr = 1).\nCall Init when initializing weights, and UpdateCycle every cycle,\nor UpdateTrial at the end of each trial, depending on the Algo.", Fields: []types.Field{{Name: "On", Doc: "On enables short-term plasticity."}, {Name: "Algo", Doc: "Algo is the algorithm for updating the synaptic state."}, {Name: "P0", Doc: "P0 is the baseline probability of release."}, {Name: "Fac", Doc: "Fac is the amount of facilitation per spike, as the proportion of\nthe remaining distance to 1 that the probability of release increases."}, {Name: "RecTau", Doc: "RecTau is the time constant for recovery of available vesicles,\nin cycles for STPCycles, or trials for STPTrialBinary."}, {Name: "FacTau", Doc: "FacTau is the time constant for decay of the probability of release\nback to P0, in cycles for STPCycles, or trials for STPTrialBinary."}, {Name: "TrialThr", Doc: "TrialThr is the threshold on the sending activation for\ncounting as a spike in the STPTrialBinary algorithm."}, {Name: "RecDt", Doc: "RecDt is the rate constant = 1 / RecTau"}, {Name: "FacDt", Doc: "FacDt is the rate constant = 1 / FacTau"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.ClampSpikes", IDName: "clamp-spikes", Doc: "ClampSpikes are the ways of generating spikes for clamped (input)\nunits in spiking mode, from the clamped activation value."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.SpikeMiscSpec", IDName: "spike-misc-spec", Doc: "SpikeMiscSpec has miscellaneous options for spiking activation,\nas in the C++ emergent SpikeMiscSpec, including the exponential\nupswing of the adaptive exponential (AdEx) model (Brette & Gerstner,\n2005), an explicit refractory period after each spike, and the\ngeneration of spikes for clamped input units.\nNone of the networks in this module (bp, hebb) have spiking activation,\nso this is only used by spiking algorithms in other modules,\nwhich call its methods from their own spiking update.", Fields: []types.Field{{Name: "Exp", Doc: "Exp uses the exponential upswing of membrane potential from the AdEx\nmodel, via ExpDrive, where spiking occurs at ExpThr instead of Thr."}, {Name: "ExpSlope", Doc: "ExpSlope is the slope in Vm for the exponential upswing,\nwhich determines how sharply it rises."}, {Name: "ExpThr", Doc: "ExpThr is the membrane potential threshold for spiking\nwhen using the exponential upswing."}, {Name: "Thr", Doc: "Thr is the membrane potential threshold for spiking\nwithout the exponential upswing, and the point where\nthe exponential upswing is centered."}, {Name: "VmR", Doc: "VmR is the membrane potential after a spike (reset)."}, {Name: "Tr", Doc: "Tr is the refractory period in cycles after a spike, during which\nthe membrane potential is held at VmR and no spikes occur."}, {Name: "ClampType", Doc: "ClampType is how spikes are generated for clamped units."}, {Name: "ClampMaxP", Doc: "ClampMaxP is the maximum probability of spiking per cycle for\nclamped units, for a clamped activation of 1."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.AdaptWtScaleSpec", IDName: "adapt-wt-scale-spec", Doc: "AdaptWtScaleSpec has parameters for an adaptive scale factor for each\nsynapse, as in the C++ emergent AdaptWtScaleSpec, which slowly drifts\ntoward LoScale when the normalized (0-1) weight is below LoThr, and\ntoward HiScale when it is above HiThr, so that synapses that are\nconsistently weak become weaker, and those that are consistently strong\nbecome stronger, beyond the range of the learned weight itself.\nThe effective weight is Scale * Wt. The algorithm stores Scale as a\nper-synapse variable (initialized to 1), named \"Scale\" so that it can be\nviewed and logged like any other synapse variable, and calls Adapt\n(or AdaptValues) after updating the weights.", Fields: []types.Field{{Name: "On", Doc: "On enables adaptive weight scaling."}, {Name: "Tau", Doc: "Tau is the time constant for the scale to adapt,\nin terms of the number of weight updates."}, {Name: "LoThr", Doc: "LoThr is the low threshold on the normalized weight,\nbelow which the scale adapts toward LoScale."}, {Name: "HiThr", Doc: "HiThr is the high threshold on the normalized weight,\nabove which the scale adapts toward HiScale."}, {Name: "LoScale", Doc: "LoScale is the scale value for weights below LoThr."}, {Name: "HiScale", Doc: "HiScale is the scale value for weights above HiThr."}, {Name: "Dt", Doc: "Dt is the rate constant = 1 / Tau"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.DWtShareSpec", IDName: "d-wt-share-spec", Doc: "DWtShareSpec has parameters for sharing weight changes among neighboring\nsynapses, as in the C++ emergent dwt_share option, where each synapse\nhas a probability PShare of averaging its weight change with a random\nneighbor within Neigh sending units. This produces topographic smoothing\nof the learned weights, e.g., in self-organizing map models, where the\nsending units are arranged topographically in index order.\nThe algorithm calls Share on the weight changes of each receiving unit,\nordered by sending unit index, before applying them.", Fields: []types.Field{{Name: "On", Doc: "On enables sharing of weight changes."}, {Name: "Neigh", Doc: "Neigh is the number of neighboring sending units on either side\nof each synapse that it can share weight changes with."}, {Name: "PShare", Doc: "PShare is the probability for each synapse to\nshare its weight change with a neighbor."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.UnlearnableSpec", IDName: "unlearnable-spec", Doc: "UnlearnableSpec has parameters for flagging unlearnable trials,\nbased on their predictability, as the cosine difference (CosDiff)\nbetween the minus and plus phase activations of a layer, z-normalized\nrelative to its running average and variance across trials, as in the\nunlearnable_trial mechanism in C++ emergent. Trials that are much less\npredictable than usual (z below -ZThr) are flagged as unlearnable, and\nlearning can be skipped on them, by multiplying the learning rate by\nLrateMod, so that noisy or inherently unpredictable trials do not\ndisrupt learning. The algorithm calls Trial with the CosDiff of the\nlayer at the end of each trial, before computing weight changes.", Fields: []types.Field{{Name: "On", Doc: "On enables flagging of unlearnable trials."}, {Name: "Skip", Doc: "Skip skips learning on unlearnable trials, via LrateMod,\ninstead of only flagging them for logging."}, {Name: "ZThr", Doc: "ZThr is the threshold on the z-normalized CosDiff, below the\nnegative of which a trial is flagged as unlearnable."}, {Name: "Tau", Doc: "Tau is the time constant in trials for integrating the\nrunning average and variance of CosDiff."}, {Name: "MinTrials", Doc: "MinTrials is the number of trials for the running average and\nvariance to warm up, before any trials are flagged."}, {Name: "Dt", Doc: "Dt is the rate constant = 1 / Tau"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.CosDiffStats", IDName: "cos-diff-stats", Doc: "CosDiffStats are the running statistics of the CosDiff predictability\nof a layer across trials, for an [UnlearnableSpec], which can be logged.", Fields: []types.Field{{Name: "Avg", Doc: "Avg is the running average of CosDiff."}, {Name: "Var", Doc: "Var is the running variance of CosDiff."}, {Name: "Z", Doc: "Z is the z-normalized CosDiff of the current trial."}, {Name: "Unlearnable", Doc: "Unlearnable is true if the current trial is flagged as unlearnable."}, {Name: "NTrials", Doc: "NTrials is the number of trials integrated."}, {Name: "NUnlearnable", Doc: "NUnlearnable is the number of trials flagged as unlearnable since\nthe last ResetCount, e.g., for logging per epoch."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.DropoutSpec", IDName: "dropout-spec", Doc: "DropoutSpec has parameters for stochastic silencing of units in a layer\n(test-time dropout), where each unit has probability P of having its\nactivation zeroed on each trial, only during the evaluation modes listed\nin Modes (e.g., Test), to measure the robustness and redundancy of\nthe representations. The silenced units are determined once per trial\nfrom a seed computed from Seed and the trial number, which is recorded\nin [DropoutState] for logging, so that results can be reproduced exactly.\nThe algorithm calls NewTrial at the start of each trial, and Apply\non the unit activations after they are computed each cycle.", Fields: []types.Field{{Name: "On", Doc: "On enables stochastic silencing."}, {Name: "P", Doc: "P is the probability of silencing each unit on each trial."}, {Name: "Modes", Doc: "Modes is a space-separated list of the evaluation mode names\n(e.g., Test, Validate) in which silencing is applied.\nIt is never applied in modes not on this list."}, {Name: "Seed", Doc: "Seed is the base random seed, combined with the trial number\nto determine the units silenced on each trial."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.DropoutState", IDName: "dropout-state", Doc: "DropoutState is the per-layer state for a [DropoutSpec].", Fields: []types.Field{{Name: "Active", Doc: "Active is true if silencing is active for the current trial."}, {Name: "TrialSeed", Doc: "TrialSeed is the random seed used for the current trial,\nwhich can be logged to reproduce the silenced units."}, {Name: "Silenced", Doc: "Silenced has a flag for each unit, true if it is silenced\non the current trial."}, {Name: "NSilenced", Doc: "NSilenced is the number of silenced units on the current trial."}, {Name: "rand", Doc: "rand is the random number generator."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.ActRegTargets", IDName: "act-reg-targets", Doc: "ActRegTargets are the unit parameters adapted by activity regularization."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.ActRegSpec", IDName: "act-reg-spec", Doc: "ActRegSpec has parameters for activity regularization, which nudges\nthe average activity of a layer toward a target sparsity level, by\nadapting a layer-wide offset to the bias or threshold of all units.\nThis complements the FFFB inhibition function for models that need\nprecise control of sparseness, and differs from [HomeostasisSpec] in\noperating on the layer average instead of individual units.\nThe algorithm calls Adapt at the end of each trial with the layer\naverage activity, and adds [ActRegState.Offset] to the bias or\nthreshold (according to Target) of all units in the layer.", Fields: []types.Field{{Name: "On", Doc: "On enables activity regularization."}, {Name: "Target", Doc: "Target is the unit parameter that is adapted."}, {Name: "Sparsity", Doc: "Sparsity is the target average activity of the layer\n(proportion of units active)."}, {Name: "Tol", Doc: "Tol is the tolerance around Sparsity within which no adaptation\noccurs, as a proportion of Sparsity."}, {Name: "AvgTau", Doc: "AvgTau is the time constant for integrating the running average\nactivity of the layer, in terms of the number of Adapt calls\n(typically trials)."}, {Name: "Rate", Doc: "Rate is the rate of change in the offset per Adapt call,\nas a proportion of the difference between the running average\nactivity and Sparsity."}, {Name: "Max", Doc: "Max is the maximum magnitude of the offset."}, {Name: "AvgDt", Doc: "AvgDt is the rate constant = 1 / AvgTau"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.ActRegState", IDName: "act-reg-state", Doc: "ActRegState is the per-layer state for an [ActRegSpec].", Fields: []types.Field{{Name: "Avg", Doc: "Avg is the running average activity of the layer."}, {Name: "Offset", Doc: "Offset is the current offset added to the bias or\nthreshold of all units in the layer."}, {Name: "Penalty", Doc: "Penalty is the squared difference between the running average\nactivity and the target sparsity, which can be logged."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/mechs.BiasLearnSpec", IDName: "bias-learn-spec", Doc: "BiasLearnSpec has per-layer parameters for learning of the bias weights\nof units, which can be set via params (e.g., to turn off bias learning\nor use a slower learning rate for specific layers), separately from the\nlearning rate of the synaptic weights. The algorithm exposes the bias\nweights as a unit variable (canonically \"Bias\", see [emer.CanonicalUnitVars]),\nso they can be viewed in the NetView and logged (e.g., using estats.BiasStat).\nThe algorithm computes the bias weight change as usual, and calls DWt\nto apply it.", Fields: []types.Field{{Name: "Learn", Doc: "Learn enables learning of the bias weights."}, {Name: "Lrate", Doc: "Lrate is the learning rate for the bias weights, which multiplies\nthe bias weight change computed by the algorithm (in addition to\nany learning rate already applied there)."}, {Name: "Max", Doc: "Max is the maximum magnitude of the bias weights (0 = no limit)."}}})
@@ -0,0 +1,136 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mechs
+
+import (
+	"cogentcore.org/core/math32"
+)
+
+// UnlearnableSpec has parameters for flagging unlearnable trials,
+// based on their predictability, as the cosine difference (CosDiff)
+// between the minus and plus phase activations of a layer, z-normalized
+// relative to its running average and variance across trials, as in the
+// unlearnable_trial mechanism in C++ emergent. Trials that are much less
+// predictable than usual (z below -ZThr) are flagged as unlearnable, and
+// learning can be skipped on them, by multiplying the learning rate by
+// LrateMod, so that noisy or inherently unpredictable trials do not
+// disrupt learning. The algorithm calls Trial with the CosDiff of the
+// layer at the end of each trial, before computing weight changes.
+type UnlearnableSpec struct {
+
+	// On enables flagging of unlearnable trials.
+	On bool
+
+	// Skip skips learning on unlearnable trials, via LrateMod,
+	// instead of only flagging them for logging.
+	Skip bool `default:"true"`
+
+	// ZThr is the threshold on the z-normalized CosDiff, below the
+	// negative of which a trial is flagged as unlearnable.
+	ZThr float32 `default:"2" min:"0"`
+
+	// Tau is the time constant in trials for integrating the
+	// running average and variance of CosDiff.
+	Tau float32 `default:"100" min:"1"`
+
+	// MinTrials is the number of trials for the running average and
+	// variance to warm up, before any trials are flagged.
+	MinTrials int `default:"20"`
+
+	// Dt is the rate constant = 1 / Tau
+	Dt float32 `display:"-"`
+}
+
+func (us *UnlearnableSpec) Defaults() {
+	us.Skip = true
+	us.ZThr = 2
+	us.Tau = 100
+	us.MinTrials = 20
+	us.Update()
+}
+
+func (us *UnlearnableSpec) Update() {
+	us.Dt = 1 / us.Tau
+}
+
+func (us *UnlearnableSpec) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	default:
+		return us.On
+	}
+}
+
+// CosDiffStats are the running statistics of the CosDiff predictability
+// of a layer across trials, for an [UnlearnableSpec], which can be logged.
+type CosDiffStats struct {
+
+	// Avg is the running average of CosDiff.
+	Avg float32
+
+	// Var is the running variance of CosDiff.
+	Var float32
+
+	// Z is the z-normalized CosDiff of the current trial.
+	Z float32
+
+	// Unlearnable is true if the current trial is flagged as unlearnable.
+	Unlearnable bool
+
+	// NTrials is the number of trials integrated.
+	NTrials int
+
+	// NUnlearnable is the number of trials flagged as unlearnable since
+	// the last ResetCount, e.g., for logging per epoch.
+	NUnlearnable int
+}
+
+// Init initializes the running statistics.
+func (cs *CosDiffStats) Init() {
+	*cs = CosDiffStats{}
+}
+
+// ResetCount returns the number of unlearnable trials since the
+// last reset, and resets the count, e.g., at the end of each epoch.
+func (cs *CosDiffStats) ResetCount() int {
+	n := cs.NUnlearnable
+	cs.NUnlearnable = 0
+	return n
+}
+
+// Trial computes the z-normalized CosDiff for the current trial,
+// flags the trial as unlearnable if On and z is below -ZThr, and then
+// updates the running average and variance. Returns Unlearnable.
+func (us *UnlearnableSpec) Trial(cs *CosDiffStats, cosDiff float32) bool {
+	cs.Z = 0
+	cs.Unlearnable = false
+	if cs.NTrials > 0 && cs.Var > 0 {
+		cs.Z = (cosDiff - cs.Avg) / math32.Sqrt(cs.Var)
+	}
+	if us.On && cs.NTrials >= us.MinTrials && cs.Z < -us.ZThr {
+		cs.Unlearnable = true
+		cs.NUnlearnable++
+	}
+	if cs.NTrials == 0 {
+		cs.Avg = cosDiff
+	} else {
+		del := cosDiff - cs.Avg
+		incr := us.Dt * del
+		cs.Avg += incr
+		cs.Var = (1 - us.Dt) * (cs.Var + del*incr)
+	}
+	cs.NTrials++
+	return cs.Unlearnable
+}
+
+// LrateMod returns the learning rate multiplier for the current trial:
+// 0 if it is unlearnable and Skip is on, and 1 otherwise.
+func (us *UnlearnableSpec) LrateMod(cs *CosDiffStats) float32 {
+	if us.On && us.Skip && cs.Unlearnable {
+		return 0
+	}
+	return 1
+}
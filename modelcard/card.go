@@ -0,0 +1,426 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package modelcard
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/plot"
+	_ "cogentcore.org/lab/plot/plots"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensorfs"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// Curve is a training curve plotted in the report.
+type Curve struct {
+
+	// Name of the curve, used as the title and the image file name.
+	Name string
+
+	// Table has the log data.
+	Table *table.Table
+
+	// X is the name of the column for the X axis, e.g., Epoch.
+	X string
+
+	// Y are the names of the columns plotted on the Y axis.
+	Y []string
+}
+
+// Image is an image file included in the report, such as
+// a receptive field snapshot or a netview export.
+type Image struct {
+
+	// Caption is shown with the image.
+	Caption string
+
+	// File is the path to the image file,
+	// which is copied to the report directory.
+	File string
+}
+
+// Card is the configuration for a model card report, which summarizes a
+// trained model in Markdown (WriteMarkdown) or HTML (WriteHTML), with the
+// sections: Architecture, Parameters, Training Curves, Final Stats,
+// Images, and Weight Statistics. Sections without data are omitted.
+type Card struct {
+
+	// Title of the report, typically the name of the model.
+	Title string
+
+	// Doc is a description of the model, included as a paragraph
+	// at the start of the report.
+	Doc string
+
+	// Net is the network, used for the architecture,
+	// parameters and weight statistics.
+	Net emer.Network
+
+	// AllParams includes all of the parameters, instead of
+	// only those that differ from their defaults.
+	AllParams bool
+
+	// WtVar is the synaptic variable for the weight statistics.
+	WtVar string
+
+	// Curves are the training curves.
+	Curves []Curve
+
+	// RunStats has the final stats for each run, one row per run, which
+	// are summarized across runs for each numeric column, e.g., the
+	// last row of each run from the Run log.
+	RunStats *table.Table
+
+	// Images are the image files included in the report.
+	Images []Image
+
+	// PlotSize is the size of the training curve plot images.
+	PlotSize image.Point
+}
+
+// NewCard returns a new [Card] with given title, for given network.
+func NewCard(title string, net emer.Network) *Card {
+	return &Card{Title: title, Net: net, WtVar: "Wt", PlotSize: image.Point{640, 480}}
+}
+
+// AddCurve adds a training curve from given log table, plotting
+// given Y columns as a function of given X column.
+func (cd *Card) AddCurve(name string, dt *table.Table, x string, ys ...string) *Card {
+	cd.Curves = append(cd.Curves, Curve{Name: name, Table: dt, X: x, Y: ys})
+	return cd
+}
+
+// AddCurveDir adds a training curve from given log directory.
+// See [Card.AddCurve].
+func (cd *Card) AddCurveDir(name string, dir *tensorfs.Node, x string, ys ...string) *Card {
+	return cd.AddCurve(name, tensorfs.DirTable(dir, nil), x, ys...)
+}
+
+// AddImage adds given image file with given caption.
+func (cd *Card) AddImage(caption, file string) *Card {
+	cd.Images = append(cd.Images, Image{Caption: caption, File: file})
+	return cd
+}
+
+// WriteMarkdown writes the report to README.md in given directory,
+// which is created if needed, along with the training curve plots
+// and copies of the images. The architecture diagram uses mermaid,
+// which is rendered by GitHub and many other Markdown viewers.
+func (cd *Card) WriteMarkdown(dir string) error {
+	return cd.writeFile(dir, "README.md", &mdWriter{})
+}
+
+// WriteHTML writes the report to index.html in given directory,
+// which is created if needed, along with the training curve plots
+// and copies of the images.
+func (cd *Card) WriteHTML(dir string) error {
+	return cd.writeFile(dir, "index.html", &htmlWriter{})
+}
+
+// writeFile writes the report to given file in given directory,
+// using given writer.
+func (cd *Card) writeFile(dir, fname string, w writer) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	var b strings.Builder
+	w.start(&b, cd.Title)
+	err := cd.write(w, dir)
+	w.end()
+	if werr := os.WriteFile(filepath.Join(dir, fname), []byte(b.String()), 0644); werr != nil {
+		return werr
+	}
+	return err
+}
+
+// write writes all the sections of the report, saving
+// the plots and images into given directory.
+func (cd *Card) write(w writer, dir string) error {
+	var errs []error
+	w.heading(1, cd.Title)
+	if cd.Doc != "" {
+		w.para(cd.Doc)
+	}
+	if cd.Net != nil {
+		w.heading(2, "Architecture")
+		w.diagram(Diagram(cd.Net))
+		w.table(ArchTable(cd.Net))
+		w.heading(2, "Parameters")
+		nb := cd.Net.AsEmer()
+		if cd.AllParams {
+			w.code(nb.AllParams())
+		} else {
+			w.code(nb.NonDefaultParams())
+		}
+	}
+	if len(cd.Curves) > 0 {
+		w.heading(2, "Training Curves")
+		for _, cv := range cd.Curves {
+			fn := fileName(cv.Name) + ".png"
+			if err := cd.savePlot(&cv, filepath.Join(dir, fn)); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			w.image(cv.Name, fn)
+		}
+	}
+	if cd.RunStats != nil && cd.RunStats.NumRows() > 0 {
+		w.heading(2, "Final Stats")
+		w.para(fmt.Sprintf("Summary across %d runs.", cd.RunStats.NumRows()))
+		w.table(RunStatsSummary(cd.RunStats))
+	}
+	if len(cd.Images) > 0 {
+		w.heading(2, "Images")
+		for _, im := range cd.Images {
+			fn := filepath.Base(im.File)
+			if err := copyFile(im.File, filepath.Join(dir, fn)); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			w.image(im.Caption, fn)
+		}
+	}
+	if cd.Net != nil {
+		if dt := WeightStats(cd.Net, cd.WtVar); dt.NumRows() > 0 {
+			w.heading(2, "Weight Statistics")
+			w.table(dt)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// savePlot saves a plot of given curve to given png file.
+func (cd *Card) savePlot(cv *Curve, fname string) error {
+	dt := table.New()
+	nr := cv.Table.NumRows()
+	for i, cn := range append([]string{cv.X}, cv.Y...) {
+		sc, err := cv.Table.ColumnTry(cn)
+		if err != nil {
+			return fmt.Errorf("modelcard: curve %q: %w", cv.Name, err)
+		}
+		dc := dt.AddFloat64Column(cn)
+		dc.SetNumRows(nr)
+		for r := range nr {
+			dc.SetFloatRow(sc.FloatRow(r, 0), r, 0)
+		}
+		plot.SetStyle(dc, func(s *plot.Style) {
+			if i == 0 {
+				s.Role = plot.X
+				return
+			}
+			s.On = true
+			s.Role = plot.Y
+		})
+	}
+	pl, err := plot.NewTablePlot(dt)
+	if err != nil {
+		return fmt.Errorf("modelcard: curve %q: %w", cv.Name, err)
+	}
+	pl.Title.Text = cv.Name
+	pl.Resize(cd.PlotSize)
+	pl.Draw()
+	return pl.SaveImage(fname)
+}
+
+// Diagram returns a mermaid flowchart diagram of the layers
+// and pathways of given network, from the bottom up.
+func Diagram(net emer.Network) string {
+	var b strings.Builder
+	b.WriteString("graph BT\n")
+	ids := make(map[string]string)
+	for li := range net.NumLayers() {
+		lb := net.EmerLayer(li).AsEmer()
+		id := fmt.Sprintf("L%d", li)
+		ids[lb.Name] = id
+		fmt.Fprintf(&b, "    %s[\"%s %v\"]\n", id, lb.Name, lb.Shape.Sizes)
+	}
+	for li := range net.NumLayers() {
+		ly := net.EmerLayer(li)
+		for pi := range ly.NumRecvPaths() {
+			pt := ly.RecvPath(pi)
+			if pt.AsEmer().Off {
+				continue
+			}
+			fmt.Fprintf(&b, "    %s -->|%s| %s\n", ids[pt.SendLayer().Label()], pt.TypeName(), ids[ly.Label()])
+		}
+	}
+	return b.String()
+}
+
+// ArchTable returns a table of the layers of given network, with columns
+// Layer, Type, Shape, Units, and Receives (the sending layers).
+func ArchTable(net emer.Network) *table.Table {
+	dt := table.New()
+	metadata.SetName(dt, "Architecture")
+	dt.AddStringColumn("Layer")
+	dt.AddStringColumn("Type")
+	dt.AddStringColumn("Shape")
+	dt.AddIntColumn("Units")
+	dt.AddStringColumn("Receives")
+	nl := net.NumLayers()
+	dt.SetNumRows(nl)
+	for li := range nl {
+		ly := net.EmerLayer(li)
+		lb := ly.AsEmer()
+		var snds []string
+		for pi := range ly.NumRecvPaths() {
+			pt := ly.RecvPath(pi)
+			if !pt.AsEmer().Off {
+				snds = append(snds, pt.SendLayer().Label())
+			}
+		}
+		dt.Column("Layer").SetStringRow(lb.Name, li, 0)
+		dt.Column("Type").SetStringRow(ly.TypeName(), li, 0)
+		dt.Column("Shape").SetStringRow(fmt.Sprint(lb.Shape.Sizes), li, 0)
+		dt.Column("Units").SetIntRow(lb.NumUnits(), li, 0)
+		dt.Column("Receives").SetStringRow(strings.Join(snds, ", "), li, 0)
+	}
+	return dt
+}
+
+// WeightStats returns a table of statistics of given synaptic variable
+// (e.g., Wt) for each pathway of given network, with columns Path, N,
+// Mean, SD, Min, and Max, skipping NaN values and pathways that are Off
+// or do not have the variable.
+func WeightStats(net emer.Network, wtVar string) *table.Table {
+	dt := table.New()
+	metadata.SetName(dt, "WeightStats")
+	dt.AddStringColumn("Path")
+	dt.AddIntColumn("N")
+	dt.AddFloat64Column("Mean")
+	dt.AddFloat64Column("SD")
+	dt.AddFloat64Column("Min")
+	dt.AddFloat64Column("Max")
+	var vals []float32
+	for li := range net.NumLayers() {
+		ly := net.EmerLayer(li)
+		for pi := range ly.NumRecvPaths() {
+			pt := ly.RecvPath(pi)
+			if pt.AsEmer().Off || pt.SynValues(&vals, wtVar) != nil {
+				continue
+			}
+			fv := make([]float64, len(vals))
+			for i, v := range vals {
+				fv[i] = float64(v)
+			}
+			n, mean, sd, mn, mx := describe(fv)
+			row := dt.NumRows()
+			dt.SetNumRows(row + 1)
+			dt.Column("Path").SetStringRow(pt.AsEmer().Name, row, 0)
+			dt.Column("N").SetIntRow(n, row, 0)
+			dt.Column("Mean").SetFloatRow(mean, row, 0)
+			dt.Column("SD").SetFloatRow(sd, row, 0)
+			dt.Column("Min").SetFloatRow(mn, row, 0)
+			dt.Column("Max").SetFloatRow(mx, row, 0)
+		}
+	}
+	return dt
+}
+
+// RunStatsSummary returns a table summarizing each numeric scalar column
+// of given table of final stats per run, with columns Stat, N, Mean, SEM,
+// Min, and Max, skipping NaN values.
+func RunStatsSummary(runs *table.Table) *table.Table {
+	dt := table.New()
+	metadata.SetName(dt, "FinalStats")
+	dt.AddStringColumn("Stat")
+	dt.AddIntColumn("N")
+	dt.AddFloat64Column("Mean")
+	dt.AddFloat64Column("SEM")
+	dt.AddFloat64Column("Min")
+	dt.AddFloat64Column("Max")
+	nr := runs.NumRows()
+	for ci, cl := range runs.Columns.Values {
+		if cl.IsString() || cl.NumDims() > 1 {
+			continue
+		}
+		fv := make([]float64, nr)
+		for r := range nr {
+			fv[r] = cl.FloatRow(r, 0)
+		}
+		n, mean, sd, mn, mx := describe(fv)
+		sem := 0.0
+		if n > 0 {
+			sem = sd / math.Sqrt(float64(n))
+		}
+		row := dt.NumRows()
+		dt.SetNumRows(row + 1)
+		dt.Column("Stat").SetStringRow(runs.Columns.Keys[ci], row, 0)
+		dt.Column("N").SetIntRow(n, row, 0)
+		dt.Column("Mean").SetFloatRow(mean, row, 0)
+		dt.Column("SEM").SetFloatRow(sem, row, 0)
+		dt.Column("Min").SetFloatRow(mn, row, 0)
+		dt.Column("Max").SetFloatRow(mx, row, 0)
+	}
+	return dt
+}
+
+// describe returns the number, mean, standard deviation, min and max
+// of the non-NaN values. The stats are NaN if there are no values.
+func describe(vals []float64) (n int, mean, sd, mn, mx float64) {
+	var sum, ssq float64
+	mn, mx = math.Inf(1), math.Inf(-1)
+	for _, v := range vals {
+		if math.IsNaN(v) {
+			continue
+		}
+		n++
+		sum += v
+		ssq += v * v
+		mn = min(mn, v)
+		mx = max(mx, v)
+	}
+	if n == 0 {
+		nan := math.NaN()
+		return 0, nan, nan, nan, nan
+	}
+	mean = sum / float64(n)
+	sd = math.Sqrt(max(ssq/float64(n)-mean*mean, 0))
+	return
+}
+
+// fileName returns a file name for given name, replacing
+// characters other than letters, digits, - and _ with _.
+func fileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// copyFile copies the src file to dst, unless they are the same file.
+func copyFile(src, dst string) error {
+	if sa, err := filepath.Abs(src); err == nil {
+		if da, err := filepath.Abs(dst); err == nil && sa == da {
+			return nil
+		}
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package modelcard generates a Markdown or HTML summary report (model card)
+of a trained model, for sharing with collaborators: the architecture as a
+diagram and table, the parameters, training curves, final stats across runs,
+receptive field and other image snapshots, and weight statistics, assembled
+from the network and the standard logs and netview exports.
+*/
+package modelcard
+
+//go:generate core generate -add-types
@@ -0,0 +1,117 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package modelcard
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cogentcore.org/lab/table"
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestNet returns a bp network with an Input layer
+// that projects to a Hidden layer, with weights 0.2 to 0.8.
+func newTestNet(t *testing.T) *bp.Network {
+	net := bp.NewNetwork("test")
+	in := net.AddLayer2D("Input", 1, 1, bp.InputLayer)
+	hid := net.AddLayer2D("Hidden", 2, 2, bp.HiddenLayer)
+	pt := net.ConnectLayers(in, hid, paths.NewFull(), bp.ForwardPath)
+	assert.NoError(t, net.Build())
+	copy(pt.Wts, []float32{0.2, 0.4, 0.6, 0.8})
+	return net
+}
+
+func TestTables(t *testing.T) {
+	nt := newTestNet(t)
+	dg := Diagram(nt)
+	assert.Contains(t, dg, `L0["Input [1 1]"]`)
+	assert.Contains(t, dg, "L0 -->|ForwardPath| L1")
+
+	at := ArchTable(nt)
+	assert.Equal(t, 2, at.NumRows())
+	assert.Equal(t, "Input", at.Column("Receives").StringRow(1, 0))
+	assert.Equal(t, 4, at.Column("Units").IntRow(1, 0))
+
+	ws := WeightStats(nt, "Wt")
+	assert.Equal(t, 1, ws.NumRows())
+	assert.Equal(t, 4, ws.Column("N").IntRow(0, 0))
+	assert.InDelta(t, 0.5, ws.Column("Mean").FloatRow(0, 0), 1.0e-6)
+	assert.InDelta(t, 0.8, ws.Column("Max").FloatRow(0, 0), 1.0e-6)
+	assert.Equal(t, 0, WeightStats(nt, "Foo").NumRows())
+
+	runs := table.New()
+	runs.AddStringColumn("Name")
+	runs.AddFloat64Column("Err")
+	runs.SetNumRows(3)
+	for r, v := range []float64{0.1, 0.3, math.NaN()} {
+		runs.Column("Err").SetFloatRow(v, r, 0)
+	}
+	rs := RunStatsSummary(runs)
+	assert.Equal(t, 1, rs.NumRows())
+	assert.Equal(t, "Err", rs.Column("Stat").StringRow(0, 0))
+	assert.Equal(t, 2, rs.Column("N").IntRow(0, 0))
+	assert.InDelta(t, 0.2, rs.Column("Mean").FloatRow(0, 0), 1.0e-6)
+	assert.InDelta(t, 0.1/math.Sqrt(2), rs.Column("SEM").FloatRow(0, 0), 1.0e-6)
+}
+
+func TestWrite(t *testing.T) {
+	nt := newTestNet(t)
+	dir := t.TempDir()
+	img := filepath.Join(dir, "rf.png")
+	assert.NoError(t, os.WriteFile(img, []byte("png"), 0644))
+
+	epc := table.New()
+	epc.AddIntColumn("Epoch")
+	epc.AddFloat64Column("Err")
+	epc.SetNumRows(5)
+	for i := range 5 {
+		epc.Column("Epoch").SetIntRow(i, i, 0)
+		epc.Column("Err").SetFloatRow(1/float64(i+1), i, 0)
+	}
+	runs := table.New()
+	runs.AddFloat64Column("Err")
+	runs.SetNumRows(2)
+
+	cd := NewCard("Test Model", nt)
+	cd.Doc = "A <test> model."
+	cd.AddCurve("Train Epoch", epc, "Epoch", "Err").AddImage("Hidden RFs", img)
+	cd.RunStats = runs
+
+	out := filepath.Join(dir, "card")
+	assert.NoError(t, cd.WriteMarkdown(out))
+	md, err := os.ReadFile(filepath.Join(out, "README.md"))
+	assert.NoError(t, err)
+	s := string(md)
+	assert.Contains(t, s, "# Test Model\n\nA <test> model.")
+	assert.Contains(t, s, "```mermaid\ngraph BT\n")
+	assert.Contains(t, s, "| Layer | Type | Shape | Units | Receives |")
+	assert.Contains(t, s, "## Parameters")
+	assert.NotContains(t, s, "Type: HiddenLayer") // all at defaults
+	assert.Contains(t, s, "![Train Epoch](Train_Epoch.png)")
+	assert.Contains(t, s, "Summary across 2 runs.")
+	assert.Contains(t, s, "![Hidden RFs](rf.png)")
+	assert.Contains(t, s, "| InputToHidden | 4 | 0.5 |")
+	assert.FileExists(t, filepath.Join(out, "Train_Epoch.png"))
+	assert.FileExists(t, filepath.Join(out, "rf.png"))
+
+	cd.AllParams = true
+	assert.NoError(t, cd.WriteHTML(out))
+	ht, err := os.ReadFile(filepath.Join(out, "index.html"))
+	assert.NoError(t, err)
+	s = string(ht)
+	assert.Contains(t, s, "<title>Test Model</title>")
+	assert.Contains(t, s, "<p>A &lt;test&gt; model.</p>")
+	assert.Contains(t, s, `<pre class="mermaid">`)
+	assert.Contains(t, s, "Layer: Hidden\tType: HiddenLayer")
+	assert.Contains(t, s, `<img src="Train_Epoch.png" alt="Train Epoch">`)
+
+	cd.AddCurve("Bad", epc, "Epoch", "Foo")
+	assert.Error(t, cd.WriteMarkdown(out))
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package modelcard
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"strings"
+
+	"cogentcore.org/lab/table"
+)
+
+// writer renders the elements of a report in a given format.
+type writer interface {
+	start(b *strings.Builder, title string)
+	end()
+	heading(level int, text string)
+	para(text string)
+	code(text string)
+	diagram(mermaid string)
+	table(dt *table.Table)
+	image(caption, file string)
+}
+
+// cellString returns the string for given cell of given table,
+// with floats formatted with 4 significant digits.
+func cellString(dt *table.Table, ci, row int) string {
+	cl := dt.Columns.Values[ci]
+	if k := cl.DataType(); k != reflect.Float32 && k != reflect.Float64 {
+		return cl.StringRow(row, 0)
+	}
+	return fmt.Sprintf("%.4g", cl.FloatRow(row, 0))
+}
+
+// mdWriter renders the report as Markdown.
+type mdWriter struct {
+	b *strings.Builder
+}
+
+func (w *mdWriter) start(b *strings.Builder, title string) { w.b = b }
+
+func (w *mdWriter) end() {}
+
+func (w *mdWriter) heading(level int, text string) {
+	fmt.Fprintf(w.b, "%s %s\n\n", strings.Repeat("#", level), text)
+}
+
+func (w *mdWriter) para(text string) {
+	fmt.Fprintf(w.b, "%s\n\n", text)
+}
+
+func (w *mdWriter) code(text string) {
+	fmt.Fprintf(w.b, "```\n%s\n```\n\n", strings.TrimRight(text, "\n"))
+}
+
+func (w *mdWriter) diagram(mermaid string) {
+	fmt.Fprintf(w.b, "```mermaid\n%s```\n\n", mermaid)
+}
+
+func (w *mdWriter) table(dt *table.Table) {
+	cell := func(s string) string { return strings.ReplaceAll(s, "|", "\\|") }
+	nc := dt.NumColumns()
+	w.b.WriteString("|")
+	for _, k := range dt.Columns.Keys {
+		w.b.WriteString(" " + cell(k) + " |")
+	}
+	w.b.WriteString("\n|" + strings.Repeat(" --- |", nc) + "\n")
+	for row := range dt.NumRows() {
+		w.b.WriteString("|")
+		for ci := range nc {
+			w.b.WriteString(" " + cell(cellString(dt, ci, row)) + " |")
+		}
+		w.b.WriteString("\n")
+	}
+	w.b.WriteString("\n")
+}
+
+func (w *mdWriter) image(caption, file string) {
+	fmt.Fprintf(w.b, "![%s](%s)\n\n%s\n\n", caption, file, caption)
+}
+
+// htmlWriter renders the report as a standalone HTML page,
+// using the mermaid script to render the diagram.
+type htmlWriter struct {
+	b *strings.Builder
+}
+
+func (w *htmlWriter) start(b *strings.Builder, title string) {
+	w.b = b
+	fmt.Fprintf(b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(title))
+	b.WriteString("<style>\nbody { font-family: sans-serif; max-width: 960px; margin: auto; }\ntable { border-collapse: collapse; }\nth, td { border: 1px solid #ccc; padding: 2px 8px; }\nimg { max-width: 100%; }\n</style>\n")
+	b.WriteString("<script type=\"module\">import mermaid from \"https://cdn.jsdelivr.net/npm/mermaid@11/dist/mermaid.esm.min.mjs\";</script>\n")
+	b.WriteString("</head>\n<body>\n")
+}
+
+func (w *htmlWriter) end() {
+	w.b.WriteString("</body>\n</html>\n")
+}
+
+func (w *htmlWriter) heading(level int, text string) {
+	fmt.Fprintf(w.b, "<h%d>%s</h%d>\n", level, html.EscapeString(text), level)
+}
+
+func (w *htmlWriter) para(text string) {
+	fmt.Fprintf(w.b, "<p>%s</p>\n", html.EscapeString(text))
+}
+
+func (w *htmlWriter) code(text string) {
+	fmt.Fprintf(w.b, "<pre>%s</pre>\n", html.EscapeString(text))
+}
+
+func (w *htmlWriter) diagram(mermaid string) {
+	fmt.Fprintf(w.b, "<pre class=\"mermaid\">\n%s</pre>\n", html.EscapeString(mermaid))
+}
+
+func (w *htmlWriter) table(dt *table.Table) {
+	w.b.WriteString("<table>\n<tr>")
+	for _, k := range dt.Columns.Keys {
+		fmt.Fprintf(w.b, "<th>%s</th>", html.EscapeString(k))
+	}
+	w.b.WriteString("</tr>\n")
+	for row := range dt.NumRows() {
+		w.b.WriteString("<tr>")
+		for ci := range dt.NumColumns() {
+			fmt.Fprintf(w.b, "<td>%s</td>", html.EscapeString(cellString(dt, ci, row)))
+		}
+		w.b.WriteString("</tr>\n")
+	}
+	w.b.WriteString("</table>\n")
+}
+
+func (w *htmlWriter) image(caption, file string) {
+	fmt.Fprintf(w.b, "<figure>\n<img src=\"%s\" alt=\"%s\">\n<figcaption>%s</figcaption>\n</figure>\n", html.EscapeString(file), html.EscapeString(caption), html.EscapeString(caption))
+}
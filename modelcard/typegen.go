@@ -0,0 +1,13 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package modelcard
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/modelcard.Curve", IDName: "curve", Doc: "Curve is a training curve plotted in the report.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Fields: []types.Field{{Name: "Name", Doc: "Name of the curve, used as the title and the image file name."}, {Name: "Table", Doc: "Table has the log data."}, {Name: "X", Doc: "X is the name of the column for the X axis, e.g., Epoch."}, {Name: "Y", Doc: "Y are the names of the columns plotted on the Y axis."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/modelcard.Image", IDName: "image", Doc: "Image is an image file included in the report, such as\na receptive field snapshot or a netview export.", Fields: []types.Field{{Name: "Caption", Doc: "Caption is shown with the image."}, {Name: "File", Doc: "File is the path to the image file,\nwhich is copied to the report directory."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/modelcard.Card", IDName: "card", Doc: "Card is the configuration for a model card report, which summarizes a\ntrained model in Markdown (WriteMarkdown) or HTML (WriteHTML), with the\nsections: Architecture, Parameters, Training Curves, Final Stats,\nImages, and Weight Statistics. Sections without data are omitted.", Fields: []types.Field{{Name: "Title", Doc: "Title of the report, typically the name of the model."}, {Name: "Doc", Doc: "Doc is a description of the model, included as a paragraph\nat the start of the report."}, {Name: "Net", Doc: "Net is the network, used for the architecture,\nparameters and weight statistics."}, {Name: "AllParams", Doc: "AllParams includes all of the parameters, instead of\nonly those that differ from their defaults."}, {Name: "WtVar", Doc: "WtVar is the synaptic variable for the weight statistics."}, {Name: "Curves", Doc: "Curves are the training curves."}, {Name: "RunStats", Doc: "RunStats has the final stats for each run, one row per run, which\nare summarized across runs for each numeric column, e.g., the\nlast row of each run from the Run log."}, {Name: "Images", Doc: "Images are the image files included in the report."}, {Name: "PlotSize", Doc: "PlotSize is the size of the training curve plot images."}}})
@@ -0,0 +1,14 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package netcheck provides sanity checks on the state of a network,
+which can be run every cycle or trial while debugging, to catch
+numerical problems and parameter misconfigurations early, with
+clear messages about where they occurred, instead of having them
+silently corrupt an entire run.
+*/
+package netcheck
+
+//go:generate core generate -add-types
@@ -0,0 +1,210 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netcheck
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/emer/emergent/v2/emer"
+)
+
+// NaNReport has the details of the first NaN or Inf value
+// found by a [NaNGuard], and implements the error interface.
+type NaNReport struct {
+
+	// Layer is the name of the layer with the unit, or the
+	// receiving layer of the pathway with the synapse.
+	Layer string
+
+	// Path is the name of the pathway for a synapse variable,
+	// empty for a unit variable.
+	Path string
+
+	// Var is the name of the variable.
+	Var string
+
+	// Value is the NaN or Inf value.
+	Value float32
+
+	// Unit is the 1D index of the unit within the layer,
+	// for a unit variable, or the receiving unit for a synapse.
+	Unit int
+
+	// SendUnit is the 1D index of the sending unit for a synapse,
+	// or -1 for a unit variable.
+	SendUnit int
+
+	// Syn is the index of the synapse within the pathway,
+	// or -1 for a unit variable.
+	Syn int
+
+	// Di is the data parallel index, for a unit variable.
+	Di int
+
+	// Counters are the counters at the time of the check
+	// (e.g., Epoch, Trial, Cycle), as passed to Check.
+	Counters string
+
+	// Params are the non-default parameters of the layer,
+	// or all the parameters of the pathway, for context.
+	Params string
+}
+
+func (nr *NaNReport) Error() string {
+	var b strings.Builder
+	if nr.Path == "" {
+		fmt.Fprintf(&b, "netcheck: %v in %s of layer %s, unit %d, data %d", nr.Value, nr.Var, nr.Layer, nr.Unit, nr.Di)
+	} else {
+		fmt.Fprintf(&b, "netcheck: %v in %s of path %s, synapse %d (send unit %d, recv unit %d)", nr.Value, nr.Var, nr.Path, nr.Syn, nr.SendUnit, nr.Unit)
+	}
+	if nr.Counters != "" {
+		fmt.Fprintf(&b, " at %s", nr.Counters)
+	}
+	if nr.Params != "" {
+		fmt.Fprintf(&b, "\nparams:\n%s", nr.Params)
+	}
+	return b.String()
+}
+
+// NaNGuard scans the key state variables of all layers and pathways
+// in a network for NaN or Inf values, which can be called every
+// cycle or trial (e.g., while debugging), stopping at the first one
+// found, and reporting the exact layer, unit or synapse, along with
+// the parameters of the layer or pathway, as a [NaNReport] error.
+// The simulation should then halt, instead of silently corrupting
+// the entire run. Variables that do not exist on a given layer or
+// pathway are skipped, and the canonical names are used for variables
+// that exist under different names (see [emer.UnitVarIndex]).
+type NaNGuard struct {
+
+	// On enables the checks.
+	On bool
+
+	// UnitVars are the unit variables to check.
+	UnitVars []string
+
+	// SynVars are the synapse variables to check.
+	SynVars []string
+
+	// NoParams does not include the parameters in the report,
+	// which can be long.
+	NoParams bool
+
+	// Report is the report for the first NaN or Inf found,
+	// nil if none have been found.
+	Report *NaNReport `display:"-"`
+
+	vals []float32
+}
+
+// NewNaNGuard returns a new [NaNGuard] that is On, checking the
+// Act, Ge, and Vm unit variables, and the Wt and DWt synapse variables.
+func NewNaNGuard() *NaNGuard {
+	return &NaNGuard{On: true, UnitVars: []string{"Act", "Ge", "Vm"}, SynVars: []string{"Wt", "DWt"}}
+}
+
+// isBad returns true if the value is NaN or Inf.
+func isBad(v float32) bool {
+	return math.IsNaN(float64(v)) || math.IsInf(float64(v), 0)
+}
+
+// Check scans the network for NaN or Inf values if On, returning the
+// [NaNReport] for the first one found as an error (also stored in Report),
+// with given counters string (e.g., Epoch, Trial, Cycle) for context.
+// Only the unit variables are checked if syns is false, which is useful
+// for per-cycle checks, while synapses are checked per trial.
+func (ng *NaNGuard) Check(net emer.Network, counters string, syns bool) error {
+	if !ng.On {
+		return nil
+	}
+	nd := max(net.NParallelData(), 1)
+	nl := net.NumLayers()
+	for li := range nl {
+		ly := net.EmerLayer(li)
+		lb := ly.AsEmer()
+		if lb.Off {
+			continue
+		}
+		for _, vnm := range ng.UnitVars {
+			if _, err := emer.UnitVarIndex(ly, vnm); err != nil {
+				continue
+			}
+			for di := range nd {
+				lb.UnitValues(&ng.vals, vnm, di)
+				for ui, v := range ng.vals {
+					if !isBad(v) {
+						continue
+					}
+					ng.Report = &NaNReport{Layer: lb.Name, Var: vnm, Value: v, Unit: ui, SendUnit: -1, Syn: -1, Di: di, Counters: counters}
+					if !ng.NoParams {
+						ng.Report.Params = ly.NonDefaultParams()
+					}
+					return ng.Report
+				}
+			}
+		}
+	}
+	if !syns {
+		return nil
+	}
+	for li := range nl {
+		ly := net.EmerLayer(li)
+		if ly.AsEmer().Off {
+			continue
+		}
+		for pi := range ly.NumRecvPaths() {
+			pt := ly.RecvPath(pi)
+			if pt.AsEmer().Off {
+				continue
+			}
+			if err := ng.checkPath(pt, counters); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkPath checks the synapse variables of given pathway.
+func (ng *NaNGuard) checkPath(pt emer.Path, counters string) error {
+	ns := pt.NumSyns()
+	for _, vnm := range ng.SynVars {
+		vidx, err := emer.SynVarIndex(pt, vnm)
+		if err != nil {
+			continue
+		}
+		for si := range ns {
+			v := pt.SynValue1D(vidx, si)
+			if !isBad(v) {
+				continue
+			}
+			send, recv := synUnits(pt, si)
+			ng.Report = &NaNReport{Layer: pt.RecvLayer().Label(), Path: pt.AsEmer().Name, Var: vnm, Value: v, Unit: recv, SendUnit: send, Syn: si, Counters: counters}
+			if !ng.NoParams {
+				ng.Report.Params = pt.AllParams()
+			}
+			return ng.Report
+		}
+	}
+	return nil
+}
+
+// synUnits returns the sending and receiving unit indexes for given
+// synapse index in given pathway, by searching all unit pairs,
+// or -1, -1 if not found.
+func synUnits(pt emer.Path, si int) (send, recv int) {
+	nsu := pt.SendLayer().AsEmer().NumUnits()
+	nru := pt.RecvLayer().AsEmer().NumUnits()
+	for ri := range nru {
+		for sj := range nsu {
+			if pt.SynIndex(sj, ri) == si {
+				return sj, ri
+			}
+		}
+	}
+	return -1, -1
+}
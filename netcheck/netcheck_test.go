@@ -0,0 +1,89 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netcheck
+
+import (
+	"math"
+	"testing"
+
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestNet returns a bp network with one Hidden layer of given
+// number of units, with a full recurrent pathway, and all values 0.
+func newTestNet(t *testing.T, nu int) *bp.Network {
+	net := bp.NewNetwork("test")
+	hid := net.AddLayer2D("Hidden", 1, nu, bp.HiddenLayer)
+	pt := net.ConnectLayers(hid, hid, paths.NewFull(), bp.RecurrentPath)
+	assert.NoError(t, net.Build())
+	clear(hid.Bias)
+	clear(pt.Wts)
+	return net
+}
+
+func TestNaNGuard(t *testing.T) {
+	nt := newTestNet(t, 4)
+	hid, pt := nt.Layers[0], nt.Paths[0]
+	ng := NewNaNGuard()
+	ng.UnitVars = []string{"Act", "Net"}
+	assert.NoError(t, ng.Check(nt, "Trial: 0", true))
+	assert.Nil(t, ng.Report)
+
+	pt.Wts[6] = float32(math.Inf(1))
+	assert.NoError(t, ng.Check(nt, "Trial: 1", false)) // units only
+	err := ng.Check(nt, "Trial: 1", true)
+	assert.Error(t, err)
+	rp := ng.Report
+	assert.Equal(t, "HiddenToHidden", rp.Path)
+	assert.Equal(t, 6, rp.Syn)
+	assert.Equal(t, 0, rp.SendUnit) // no self connections: 3 per unit
+	assert.Equal(t, 2, rp.Unit)
+	assert.Equal(t, 6, pt.SynIndex(rp.SendUnit, rp.Unit))
+	assert.Contains(t, err.Error(), "Path: HiddenToHidden")
+
+	hid.Net[3] = float32(math.NaN())
+	err = ng.Check(nt, "Trial: 2", true)
+	rp = ng.Report
+	assert.Equal(t, "Net", rp.Var)
+	assert.Equal(t, 3, rp.Unit)
+	assert.Equal(t, "", rp.Path)
+	assert.Contains(t, err.Error(), "Trial: 2")
+
+	ng.On = false
+	assert.NoError(t, ng.Check(nt, "", true))
+}
+
+func TestRangeChecks(t *testing.T) {
+	nt := newTestNet(t, 4)
+	hid := nt.Layers[0]
+	rc := NewRangeChecks()
+	rc.Add("Act", 0, 1, "").Add("Net", 0, 2, "#Hidden").Add("Net", 0, 0.5, "#Output").Add("Ge", 0, 1, "")
+	assert.Equal(t, 0, rc.Check(nt, "Trial: 0"))
+	hid.Act[1] = 1.5
+	hid.Act[2] = -0.1
+	hid.Net[3] = 1 // ok for Hidden
+	assert.Equal(t, 2, rc.Check(nt, "Trial: 1"))
+	assert.Equal(t, 2, rc.Check(nt, "Trial: 2"))
+	assert.Equal(t, 4, rc.Counts["Hidden_Act"])
+	assert.Len(t, rc.Messages, 1)
+	assert.Contains(t, rc.Messages[0], "Hidden Act = 1.5 at unit 1")
+	assert.Contains(t, rc.Messages[0], "Trial: 1")
+
+	stats := map[string]float64{}
+	rc.SetStats("Epc", func(name string, val float64) { stats[name] = val })
+	assert.Equal(t, 4.0, stats["EpcHidden_Act"])
+
+	dt := rc.Table(nt)
+	assert.Equal(t, 2, dt.NumRows()) // Output and Ge not applicable
+	assert.Equal(t, 4, dt.Column("Violations").IntRow(0, 0))
+	assert.Equal(t, 0, dt.Column("Violations").IntRow(1, 0))
+
+	rc.ResetCounts()
+	assert.Equal(t, 0, rc.Counts["Hidden_Act"])
+	rc.On = false
+	assert.Equal(t, 0, rc.Check(nt, ""))
+}
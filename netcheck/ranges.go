@@ -0,0 +1,174 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netcheck
+
+import (
+	"fmt"
+	"log"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/mechs"
+)
+
+// Range is a sanity assertion that the values of a unit variable
+// are within a given range, on a given set of layers.
+type Range struct {
+
+	// Var is the unit variable, e.g., Act.
+	Var string
+
+	// Min is the minimum valid value.
+	Min float32
+
+	// Max is the maximum valid value.
+	Max float32
+
+	// Layers is a space-separated list of selectors for the layers
+	// that are checked, using params selector syntax: .Class or #Name,
+	// or a layer type name. An empty list checks all layers.
+	Layers string
+}
+
+// RangeChecks has a list of [Range] assertions that are checked on
+// the units of a network, e.g., every trial in a debug mode, counting
+// the number of violations per layer and variable, which can be logged,
+// and logging a clear message with the first violation of each, so that
+// parameter misconfigurations are caught early, instead of manifesting
+// as strange learning curves. NaN values count as violations.
+type RangeChecks struct {
+
+	// On enables the checks.
+	On bool
+
+	// Ranges are the range assertions.
+	Ranges []Range
+
+	// Counts has the number of violations since the last ResetCounts,
+	// keyed by Layer_Var.
+	Counts map[string]int `display:"-"`
+
+	// Messages has the message for the first violation of each
+	// Layer_Var, since the last ResetCounts, which are also logged.
+	Messages []string `display:"-"`
+
+	vals []float32
+}
+
+// NewRangeChecks returns new [RangeChecks] that are On.
+func NewRangeChecks() *RangeChecks {
+	return &RangeChecks{On: true}
+}
+
+// Add adds a range assertion for given unit variable, range, and
+// layer selectors (empty for all layers). Returns the RangeChecks
+// so calls can be chained.
+func (rc *RangeChecks) Add(varNm string, min, max float32, layers string) *RangeChecks {
+	rc.Ranges = append(rc.Ranges, Range{Var: varNm, Min: min, Max: max, Layers: layers})
+	return rc
+}
+
+// ResetCounts resets the violation counts and messages,
+// e.g., at the start of each epoch.
+func (rc *RangeChecks) ResetCounts() {
+	rc.Counts = make(map[string]int)
+	rc.Messages = nil
+}
+
+// Check checks all range assertions on the units of given network if On,
+// for all data parallel indexes, with given counters string
+// (e.g., Epoch, Trial) for the messages. Returns the number
+// of violations found.
+func (rc *RangeChecks) Check(net emer.Network, counters string) int {
+	if !rc.On {
+		return 0
+	}
+	if rc.Counts == nil {
+		rc.ResetCounts()
+	}
+	nd := max(net.NParallelData(), 1)
+	nviol := 0
+	for li := range net.NumLayers() {
+		ly := net.EmerLayer(li)
+		lb := ly.AsEmer()
+		if lb.Off {
+			continue
+		}
+		for _, rg := range rc.Ranges {
+			if !mechs.LayerSelMatch(rg.Layers, ly) {
+				continue
+			}
+			if _, err := emer.UnitVarIndex(ly, rg.Var); err != nil {
+				continue
+			}
+			key := lb.Name + "_" + rg.Var
+			for di := range nd {
+				lb.UnitValues(&rc.vals, rg.Var, di)
+				for ui, v := range rc.vals {
+					if v >= rg.Min && v <= rg.Max {
+						continue
+					}
+					nviol++
+					if rc.Counts[key] == 0 {
+						msg := fmt.Sprintf("netcheck: %s %s = %g at unit %d (data %d) is outside of the range [%g, %g]", lb.Name, rg.Var, v, ui, di, rg.Min, rg.Max)
+						if counters != "" {
+							msg += " at " + counters
+						}
+						log.Println(msg)
+						rc.Messages = append(rc.Messages, msg)
+					}
+					rc.Counts[key]++
+				}
+			}
+		}
+	}
+	return nviol
+}
+
+// SetStats calls given function (e.g., estats.Stats SetFloat) with the
+// violation count for each layer and variable that has any violations,
+// with names of the form prefix + Layer_Var (e.g., "Epc" + "Hidden_Act"),
+// for logging.
+func (rc *RangeChecks) SetStats(prefix string, set func(name string, val float64)) {
+	for key, n := range rc.Counts {
+		set(prefix+key, float64(n))
+	}
+}
+
+// Table returns a table of the violation counts for each layer and
+// range assertion in given network, with columns Layer, Var, Min,
+// Max, and Violations, including those with no violations.
+func (rc *RangeChecks) Table(net emer.Network) *table.Table {
+	dt := table.New()
+	metadata.SetName(dt, "RangeChecks")
+	tensor.SetPrecision(dt, 4)
+	dt.AddStringColumn("Layer")
+	dt.AddStringColumn("Var")
+	dt.AddFloat64Column("Min")
+	dt.AddFloat64Column("Max")
+	dt.AddIntColumn("Violations")
+	for li := range net.NumLayers() {
+		ly := net.EmerLayer(li)
+		lb := ly.AsEmer()
+		for _, rg := range rc.Ranges {
+			if !mechs.LayerSelMatch(rg.Layers, ly) {
+				continue
+			}
+			if _, err := emer.UnitVarIndex(ly, rg.Var); err != nil {
+				continue
+			}
+			row := dt.NumRows()
+			dt.SetNumRows(row + 1)
+			dt.Column("Layer").SetStringRow(lb.Name, row, 0)
+			dt.Column("Var").SetStringRow(rg.Var, row, 0)
+			dt.Column("Min").SetFloatRow(float64(rg.Min), row, 0)
+			dt.Column("Max").SetFloatRow(float64(rg.Max), row, 0)
+			dt.Column("Violations").SetIntRow(rc.Counts[lb.Name+"_"+rg.Var], row, 0)
+		}
+	}
+	return dt
+}
@@ -0,0 +1,15 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package netcheck
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/netcheck.NaNReport", IDName: "na-n-report", Doc: "NaNReport has the details of the first NaN or Inf value\nfound by a [NaNGuard], and implements the error interface.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Fields: []types.Field{{Name: "Layer", Doc: "Layer is the name of the layer with the unit, or the\nreceiving layer of the pathway with the synapse."}, {Name: "Path", Doc: "Path is the name of the pathway for a synapse variable,\nempty for a unit variable."}, {Name: "Var", Doc: "Var is the name of the variable."}, {Name: "Value", Doc: "Value is the NaN or Inf value."}, {Name: "Unit", Doc: "Unit is the 1D index of the unit within the layer,\nfor a unit variable, or the receiving unit for a synapse."}, {Name: "SendUnit", Doc: "SendUnit is the 1D index of the sending unit for a synapse,\nor -1 for a unit variable."}, {Name: "Syn", Doc: "Syn is the index of the synapse within the pathway,\nor -1 for a unit variable."}, {Name: "Di", Doc: "Di is the data parallel index, for a unit variable."}, {Name: "Counters", Doc: "Counters are the counters at the time of the check\n(e.g., Epoch, Trial, Cycle), as passed to Check."}, {Name: "Params", Doc: "Params are the non-default parameters of the layer,\nor all the parameters of the pathway, for context."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/netcheck.NaNGuard", IDName: "na-n-guard", Doc: "NaNGuard scans the key state variables of all layers and pathways\nin a network for NaN or Inf values, which can be called every\ncycle or trial (e.g., while debugging), stopping at the first one\nfound, and reporting the exact layer, unit or synapse, along with\nthe parameters of the layer or pathway, as a [NaNReport] error.\nThe simulation should then halt, instead of silently corrupting\nthe entire run. Variables that do not exist on a given layer or\npathway are skipped, and the canonical names are used for variables\nthat exist under different names (see [emer.UnitVarIndex]).", Fields: []types.Field{{Name: "On", Doc: "On enables the checks."}, {Name: "UnitVars", Doc: "UnitVars are the unit variables to check."}, {Name: "SynVars", Doc: "SynVars are the synapse variables to check."}, {Name: "NoParams", Doc: "NoParams does not include the parameters in the report,\nwhich can be long."}, {Name: "Report", Doc: "Report is the report for the first NaN or Inf found,\nnil if none have been found."}, {Name: "vals"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/netcheck.Range", IDName: "range", Doc: "Range is a sanity assertion that the values of a unit variable\nare within a given range, on a given set of layers.", Fields: []types.Field{{Name: "Var", Doc: "Var is the unit variable, e.g., Act."}, {Name: "Min", Doc: "Min is the minimum valid value."}, {Name: "Max", Doc: "Max is the maximum valid value."}, {Name: "Layers", Doc: "Layers is a space-separated list of selectors for the layers\nthat are checked, using params selector syntax: .Class or #Name,\nor a layer type name. An empty list checks all layers."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/netcheck.RangeChecks", IDName: "range-checks", Doc: "RangeChecks has a list of [Range] assertions that are checked on\nthe units of a network, e.g., every trial in a debug mode, counting\nthe number of violations per layer and variable, which can be logged,\nand logging a clear message with the first violation of each, so that\nparameter misconfigurations are caught early, instead of manifesting\nas strange learning curves. NaN values count as violations.", Fields: []types.Field{{Name: "On", Doc: "On enables the checks."}, {Name: "Ranges", Doc: "Ranges are the range assertions."}, {Name: "Counts", Doc: "Counts has the number of violations since the last ResetCounts,\nkeyed by Layer_Var."}, {Name: "Messages", Doc: "Messages has the message for the first violation of each\nLayer_Var, since the last ResetCounts, which are also logged."}, {Name: "vals"}}})
@@ -0,0 +1,246 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netin
+
+import (
+	"fmt"
+	"math"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/plot"
+	"cogentcore.org/lab/plot/plots"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/mechs"
+)
+
+// PathContrib has the contribution of one pathway to the
+// net input of its receiving layer.
+type PathContrib struct {
+
+	// Layer is the name of the receiving layer.
+	Layer string
+
+	// Path is the name of the pathway.
+	Path string
+
+	// Contrib is the average contribution of the pathway to the
+	// net input of each receiving unit, as of the last Compute.
+	Contrib float32
+
+	// Prop is the proportion of the total net input of the
+	// receiving layer contributed by this pathway.
+	Prop float32
+
+	// Imbalanced is true if Prop is above the Imbalance threshold.
+	Imbalanced bool
+
+	// sum is the sum of the contribution since the last Reset.
+	sum float64
+
+	// n is the number of measurements in the sum.
+	n int
+
+	// pt is the pathway.
+	pt emer.Path
+
+	// syns are the cached synapse indexes.
+	syns []synIndex
+}
+
+// synIndex is the index of a synapse, with its sending unit.
+type synIndex struct {
+	send, syn int32
+}
+
+// Contribs decomposes the average net input (excitatory conductance) of
+// each layer into the contributions from each of its receiving pathways,
+// computed as the scale of the pathway times the sum of the sending
+// activity times the weight over its synapses, averaged over receiving
+// units, and flags imbalances where one pathway dominates the net input
+// of a layer, helping to understand why a layer is dominated by a
+// particular input. Measure is called every trial, and Compute at the end
+// of each epoch, followed by Table or SetStats for logging, and Reset.
+// The synapse indexes of each pathway are cached on the first Measure,
+// which can be slow for large layers, and Init must be called if the
+// connectivity changes.
+type Contribs struct {
+
+	// ActVar is the unit variable for the sending activity.
+	ActVar string
+
+	// WtVar is the synaptic variable for the weights.
+	WtVar string
+
+	// Layers is a space-separated list of selectors for the receiving
+	// layers, using params selector syntax: .Class or #Name, or a layer
+	// type name. An empty list includes all layers with receiving pathways.
+	Layers string
+
+	// Imbalance is the threshold proportion of the net input of a layer
+	// contributed by one pathway above which it is flagged as imbalanced.
+	Imbalance float32 `default:"0.8"`
+
+	// GetScale returns the scale of given pathway, which multiplies its
+	// contribution. If nil, the scale is 1 for all pathways.
+	GetScale func(pt emer.Path) float32 `display:"-"`
+
+	// Paths are the contributions for each pathway, in order of the
+	// receiving layers and their pathways.
+	Paths []PathContrib
+
+	vals []float32
+}
+
+// NewContribs returns a new [Contribs] with default
+// ActVar = Act, WtVar = Wt, and Imbalance = 0.8.
+func NewContribs() *Contribs {
+	return &Contribs{ActVar: "Act", WtVar: "Wt", Imbalance: 0.8}
+}
+
+// Init configures the Paths for given network, caching the synapse
+// indexes of each pathway, which searches for synapses between all pairs
+// of sending and receiving units, so it can be slow for large layers.
+// It is called automatically by Measure the first time.
+func (cs *Contribs) Init(net emer.Network) {
+	cs.Paths = nil
+	for li := range net.NumLayers() {
+		ly := net.EmerLayer(li)
+		lb := ly.AsEmer()
+		if lb.Off || !mechs.LayerSelMatch(cs.Layers, ly) {
+			continue
+		}
+		for _, pt := range recvPaths(ly) {
+			pc := PathContrib{Layer: lb.Name, Path: pt.AsEmer().Name, pt: pt}
+			ns := pt.SendLayer().AsEmer().NumUnits()
+			for ri := range lb.NumUnits() {
+				for si := range ns {
+					if syi := pt.SynIndex(si, ri); syi >= 0 {
+						pc.syns = append(pc.syns, synIndex{send: int32(si), syn: int32(syi)})
+					}
+				}
+			}
+			cs.Paths = append(cs.Paths, pc)
+		}
+	}
+}
+
+// Measure adds the current contribution of each pathway to its sums,
+// over all data parallel indexes, calling Init first if needed.
+func (cs *Contribs) Measure(net emer.Network) error {
+	if cs.Paths == nil {
+		cs.Init(net)
+	}
+	nd := max(net.NParallelData(), 1)
+	for i := range cs.Paths {
+		pc := &cs.Paths[i]
+		wi, err := pc.pt.SynVarIndex(cs.WtVar)
+		if err != nil {
+			return fmt.Errorf("netin.Contribs: path %q: %w", pc.Path, err)
+		}
+		scale := float32(1)
+		if cs.GetScale != nil {
+			scale = cs.GetScale(pc.pt)
+		}
+		nr := pc.pt.RecvLayer().AsEmer().NumUnits()
+		sl := pc.pt.SendLayer().AsEmer()
+		for di := range nd {
+			if err := sl.UnitValues(&cs.vals, cs.ActVar, di); err != nil {
+				return fmt.Errorf("netin.Contribs: layer %q: %w", sl.Name, err)
+			}
+			sum := float64(0)
+			for _, sy := range pc.syns {
+				v := cs.vals[sy.send] * pc.pt.SynValue1D(wi, int(sy.syn))
+				if math.IsNaN(float64(v)) {
+					continue
+				}
+				sum += float64(v)
+			}
+			if nr > 0 {
+				pc.sum += float64(scale) * sum / float64(nr)
+			}
+			pc.n++
+		}
+	}
+	return nil
+}
+
+// Compute computes the average Contrib of each pathway since the last
+// Reset, and its Prop of the total for its receiving layer, returning
+// a message for each pathway that is Imbalanced.
+func (cs *Contribs) Compute() []string {
+	tots := make(map[string]float32)
+	for i := range cs.Paths {
+		pc := &cs.Paths[i]
+		pc.Contrib = 0
+		if pc.n > 0 {
+			pc.Contrib = float32(pc.sum / float64(pc.n))
+		}
+		tots[pc.Layer] += pc.Contrib
+	}
+	var msgs []string
+	for i := range cs.Paths {
+		pc := &cs.Paths[i]
+		pc.Prop = 0
+		if tot := tots[pc.Layer]; tot > 0 {
+			pc.Prop = pc.Contrib / tot
+		}
+		pc.Imbalanced = pc.Prop > cs.Imbalance
+		if pc.Imbalanced {
+			msgs = append(msgs, fmt.Sprintf("netin.Contribs: layer %s: path %s contributes %.0f%% of the net input", pc.Layer, pc.Path, 100*pc.Prop))
+		}
+	}
+	return msgs
+}
+
+// Reset resets the sums for a new epoch.
+func (cs *Contribs) Reset() {
+	for i := range cs.Paths {
+		cs.Paths[i].sum = 0
+		cs.Paths[i].n = 0
+	}
+}
+
+// SetStats calls the given set function with the Prop of each
+// pathway as of the last Compute, named prefix + path name,
+// e.g., for recording in the stats for logging.
+func (cs *Contribs) SetStats(prefix string, set func(name string, val float64)) {
+	for _, pc := range cs.Paths {
+		set(prefix+pc.Path, float64(pc.Prop))
+	}
+}
+
+// Table returns the contributions as of the last Compute as a table,
+// with columns Layer, Path, Contrib, Prop, and Imbalanced, where Prop
+// is plotted as a bar plot.
+func (cs *Contribs) Table() *table.Table {
+	dt := table.New()
+	metadata.SetName(dt, "NetInContribs")
+	tensor.SetPrecision(dt, 4)
+	dt.AddStringColumn("Layer")
+	dt.AddStringColumn("Path")
+	dt.AddFloat64Column("Contrib")
+	prop := dt.AddFloat64Column("Prop")
+	dt.AddIntColumn("Imbalanced")
+	plot.SetStyle(prop, func(s *plot.Style) {
+		s.On = true
+		s.Plotter = plots.BarType
+		s.Range.SetMin(0).SetMax(1)
+	})
+	dt.SetNumRows(len(cs.Paths))
+	for i, pc := range cs.Paths {
+		dt.Column("Layer").SetStringRow(pc.Layer, i, 0)
+		dt.Column("Path").SetStringRow(pc.Path, i, 0)
+		dt.Column("Contrib").SetFloatRow(float64(pc.Contrib), i, 0)
+		dt.Column("Prop").SetFloatRow(float64(pc.Prop), i, 0)
+		imb := 0
+		if pc.Imbalanced {
+			imb = 1
+		}
+		dt.Column("Imbalanced").SetIntRow(imb, i, 0)
+	}
+	return dt
+}
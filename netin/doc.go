@@ -0,0 +1,13 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package netin provides algorithm-independent tools for calibrating and
+diagnosing the scaling of the net input (excitatory conductance) that
+each layer receives from its pathways, which is one of the most tedious
+parts of building a new network architecture.
+*/
+package netin
+
+//go:generate core generate -add-types
@@ -0,0 +1,142 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netin
+
+import (
+	"testing"
+
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+// testNet is a bp network with two input layers that fully connect
+// to a hidden layer, with all weights 0.5 and no biases, and scales for
+// the pathways, which multiply the weights when they are set by setScale.
+type testNet struct {
+	*bp.Network
+	scales map[emer.Path]float32
+}
+
+func newTestNet(t *testing.T, nu int) *testNet {
+	net := bp.NewNetwork("test")
+	in1 := net.AddLayer2D("Input1", 1, nu, bp.InputLayer)
+	in2 := net.AddLayer2D("Input2", 1, nu, bp.InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, nu, bp.HiddenLayer)
+	net.ConnectLayers(in1, hid, paths.NewFull(), bp.ForwardPath)
+	net.ConnectLayers(in2, hid, paths.NewFull(), bp.ForwardPath)
+	assert.NoError(t, net.Build())
+	nt := &testNet{Network: net, scales: map[emer.Path]float32{}}
+	clear(hid.Bias)
+	for _, pt := range net.Paths {
+		for i := range pt.Wts {
+			pt.Wts[i] = 0.5
+		}
+		nt.scales[pt] = 1
+	}
+	return nt
+}
+
+func (nt *testNet) getScale(pt emer.Path) float32 { return nt.scales[pt] }
+
+// setScale sets the scale of given pathway, multiplying its weights
+// by the change in scale.
+func (nt *testNet) setScale(pt emer.Path, scale float32) {
+	fact := scale / nt.scales[pt]
+	for i := range pt.(*bp.Path).Wts {
+		pt.(*bp.Path).Wts[i] *= fact
+	}
+	nt.scales[pt] = scale
+}
+
+// run runs given number of trials with all inputs active.
+func (nt *testNet) run(ntrials int, measure func()) {
+	ctx := nt.NewContext()
+	pat := []float32{1, 1, 1, 1}
+	for range ntrials {
+		nt.InitSeq()
+		nt.ApplyInput("Input1", pat)
+		nt.ApplyInput("Input2", pat)
+		nt.Forward(ctx)
+		measure()
+	}
+}
+
+func TestTuner(t *testing.T) {
+	nt := newTestNet(t, 4)
+	nt.setScale(nt.Paths[1], 2) // Net = 4 * 0.5 + 4 * 1
+	tn := NewTuner(0.2, 0.3)
+	tn.Var = "Net"
+	tn.Run = func(measure func()) { nt.run(3, measure) }
+	tn.GetScale = nt.getScale
+	tn.SetScale = nt.setScale
+	assert.NoError(t, tn.Tune(nt))
+
+	assert.Len(t, tn.Results, 2)
+	r0, r1 := tn.Results[0], tn.Results[1]
+	assert.Equal(t, "Hidden", r0.Layer)
+	assert.Equal(t, "Input1ToHidden", r0.Path)
+	assert.Equal(t, float32(1), r0.OrigScale)
+	assert.Equal(t, float32(2), r1.OrigScale)
+	assert.InDelta(t, 0.25, r0.NetIn, 1.0e-6)
+	assert.InDelta(t, 2*r0.Scale, r1.Scale, 1.0e-6) // relative scales preserved
+	assert.InDelta(t, 1.0/24.0, r0.Scale, 1.0e-6)
+
+	dt := tn.Table()
+	assert.Equal(t, 2, dt.NumRows())
+	assert.InDelta(t, 2, dt.Column("OrigScale").FloatRow(1, 0), 1.0e-6)
+
+	ps := tn.ParamsString("*axon.PathParams", "PathScale.Abs")
+	assert.Contains(t, ps, `{Sel: "#Input2ToHidden", Doc: "tuned by netin.Tuner from 2",`)
+	assert.Contains(t, ps, "pt.PathScale.Abs = 0.08333")
+
+	// no input: cannot be tuned
+	tn.Run = func(measure func()) { measure() }
+	hid, _ := nt.LayerByName("Hidden")
+	clear(hid.Net)
+	err := tn.Tune(nt)
+	assert.ErrorContains(t, err, "Hidden: 0")
+
+	tn.Run = nil
+	assert.Error(t, tn.Tune(nt))
+}
+
+func TestContribs(t *testing.T) {
+	nt := newTestNet(t, 4)
+	nt.scales[nt.Paths[1]] = 9 // scale without changing the weights
+	cs := NewContribs()
+	cs.GetScale = nt.getScale
+	nt.run(2, func() { assert.NoError(t, cs.Measure(nt)) })
+	msgs := cs.Compute()
+	assert.Len(t, cs.Paths, 2)
+	p0, p1 := cs.Paths[0], cs.Paths[1]
+	assert.Equal(t, "Hidden", p0.Layer)
+	assert.InDelta(t, 2, p0.Contrib, 1.0e-6) // 4 * 0.5 * 1
+	assert.InDelta(t, 18, p1.Contrib, 1.0e-6)
+	assert.InDelta(t, 0.1, p0.Prop, 1.0e-6)
+	assert.InDelta(t, 0.9, p1.Prop, 1.0e-6)
+	assert.False(t, p0.Imbalanced)
+	assert.True(t, p1.Imbalanced)
+	assert.Len(t, msgs, 1)
+	assert.Contains(t, msgs[0], "path Input2ToHidden contributes 90%")
+
+	stats := map[string]float64{}
+	cs.SetStats("Epc", func(name string, val float64) { stats[name] = val })
+	assert.InDelta(t, 0.9, stats["EpcInput2ToHidden"], 1.0e-6)
+
+	dt := cs.Table()
+	assert.Equal(t, 2, dt.NumRows())
+	assert.Equal(t, 1, dt.Column("Imbalanced").IntRow(1, 0))
+
+	cs.Reset()
+	nt.scales[nt.Paths[1]] = 1
+	nt.run(1, func() { assert.NoError(t, cs.Measure(nt)) })
+	assert.Nil(t, cs.Compute())
+	assert.InDelta(t, 0.5, cs.Paths[1].Prop, 1.0e-6)
+
+	cs.WtVar = "Foo"
+	assert.Error(t, cs.Measure(nt))
+}
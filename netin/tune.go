@@ -0,0 +1,252 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netin
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/mechs"
+)
+
+// TuneResult is the result of tuning the scale of one pathway.
+type TuneResult struct {
+
+	// Layer is the name of the receiving layer.
+	Layer string
+
+	// Path is the name of the pathway.
+	Path string
+
+	// OrigScale is the scale of the pathway before tuning.
+	OrigScale float32
+
+	// Scale is the tuned scale of the pathway.
+	Scale float32
+
+	// NetIn is the average net input of the receiving layer
+	// after tuning.
+	NetIn float32
+}
+
+// Tuner adjusts the scales of the pathways into each layer so that the
+// average net input of each layer falls within a target range, by running
+// a few calibration trials, measuring the average net input, multiplying
+// the scales of all the receiving pathways of each layer that is out of
+// range by the factor needed to reach the middle of the range, and
+// repeating until all layers are in range. The relative scales of the
+// pathways into each layer are preserved, so this is typically applied to
+// the absolute scale. The tuned values are reported in Results, for
+// inclusion in the params. Because the scales are specific to each
+// algorithm, they are accessed through the GetScale and SetScale functions,
+// which must be set, along with the Run function.
+type Tuner struct {
+
+	// Var is the unit variable for the net input.
+	Var string
+
+	// Min is the minimum of the target range for the average net input.
+	Min float32
+
+	// Max is the maximum of the target range for the average net input.
+	Max float32
+
+	// Layers is a space-separated list of selectors for the layers that
+	// are tuned, using params selector syntax: .Class or #Name, or a layer
+	// type name. An empty list tunes all layers with receiving pathways.
+	Layers string
+
+	// MaxIters is the maximum number of calibration iterations.
+	MaxIters int
+
+	// MaxFactor is the maximum factor by which the scales can be
+	// changed on each iteration, up or down.
+	MaxFactor float32
+
+	// Run runs the calibration trials (e.g., several trials in Test mode,
+	// without learning), calling the given measure function at the point
+	// in each trial where the net input should be measured
+	// (e.g., at the end of the minus phase).
+	Run func(measure func()) `display:"-"`
+
+	// GetScale returns the current scale of given pathway.
+	GetScale func(pt emer.Path) float32 `display:"-"`
+
+	// SetScale sets the scale of given pathway, updating any
+	// values that depend on it.
+	SetScale func(pt emer.Path, scale float32) `display:"-"`
+
+	// Results are the tuned scales for each pathway.
+	Results []TuneResult
+
+	// sums are the sums of the net input for each layer.
+	sums map[string]float64
+
+	// ns are the numbers of values in the sums.
+	ns map[string]int
+
+	vals []float32
+}
+
+// NewTuner returns a new [Tuner] with given target range,
+// and the default Var = Ge, MaxIters = 10, MaxFactor = 4.
+// The Run, GetScale and SetScale functions must be set.
+func NewTuner(min, max float32) *Tuner {
+	return &Tuner{Var: "Ge", Min: min, Max: max, MaxIters: 10, MaxFactor: 4}
+}
+
+// tuneLayers returns the layers that are tuned.
+func (tn *Tuner) tuneLayers(net emer.Network) []emer.Layer {
+	var lays []emer.Layer
+	for li := range net.NumLayers() {
+		ly := net.EmerLayer(li)
+		if ly.AsEmer().Off || !mechs.LayerSelMatch(tn.Layers, ly) {
+			continue
+		}
+		if len(recvPaths(ly)) == 0 {
+			continue
+		}
+		if _, err := emer.UnitVarIndex(ly, tn.Var); err != nil {
+			continue
+		}
+		lays = append(lays, ly)
+	}
+	return lays
+}
+
+// recvPaths returns the receiving pathways of given layer that are not Off.
+func recvPaths(ly emer.Layer) []emer.Path {
+	var pts []emer.Path
+	for pi := range ly.NumRecvPaths() {
+		pt := ly.RecvPath(pi)
+		if !pt.AsEmer().Off {
+			pts = append(pts, pt)
+		}
+	}
+	return pts
+}
+
+// measure adds the current net input of given layers to the sums,
+// over all units and data parallel indexes, skipping NaN values.
+func (tn *Tuner) measure(net emer.Network, lays []emer.Layer) {
+	nd := max(net.NParallelData(), 1)
+	for _, ly := range lays {
+		lb := ly.AsEmer()
+		for di := range nd {
+			lb.UnitValues(&tn.vals, tn.Var, di)
+			for _, v := range tn.vals {
+				if math.IsNaN(float64(v)) {
+					continue
+				}
+				tn.sums[lb.Name] += float64(v)
+				tn.ns[lb.Name]++
+			}
+		}
+	}
+}
+
+// Tune runs the calibration iterations on given network, adjusting the
+// scales of the pathways into each layer that is out of the target range,
+// until all layers are in range or MaxIters is reached, and records the
+// Results. Returns an error listing the layers that are not in range
+// at the end, or that cannot be tuned because they have no net input.
+func (tn *Tuner) Tune(net emer.Network) error {
+	if tn.Run == nil || tn.GetScale == nil || tn.SetScale == nil {
+		return errors.New("netin.Tuner: Run, GetScale and SetScale must be set")
+	}
+	lays := tn.tuneLayers(net)
+	orig := make(map[string]float32)
+	for _, ly := range lays {
+		for _, pt := range recvPaths(ly) {
+			orig[pt.AsEmer().Name] = tn.GetScale(pt)
+		}
+	}
+	avgs := make(map[string]float32)
+	var bad []string
+	for iter := 0; ; iter++ {
+		tn.sums = make(map[string]float64)
+		tn.ns = make(map[string]int)
+		tn.Run(func() { tn.measure(net, lays) })
+		bad = bad[:0]
+		tgt := 0.5 * (tn.Min + tn.Max)
+		for _, ly := range lays {
+			nm := ly.AsEmer().Name
+			avg := float32(0)
+			if n := tn.ns[nm]; n > 0 {
+				avg = float32(tn.sums[nm] / float64(n))
+			}
+			avgs[nm] = avg
+			if avg >= tn.Min && avg <= tn.Max {
+				continue
+			}
+			bad = append(bad, fmt.Sprintf("%s: %g", nm, avg))
+			if iter == tn.MaxIters || avg <= 0 {
+				continue
+			}
+			fact := min(max(tgt/avg, 1/tn.MaxFactor), tn.MaxFactor)
+			for _, pt := range recvPaths(ly) {
+				tn.SetScale(pt, fact*tn.GetScale(pt))
+			}
+		}
+		if len(bad) == 0 || iter == tn.MaxIters {
+			break
+		}
+	}
+	tn.Results = nil
+	for _, ly := range lays {
+		nm := ly.AsEmer().Name
+		for _, pt := range recvPaths(ly) {
+			pnm := pt.AsEmer().Name
+			tn.Results = append(tn.Results, TuneResult{Layer: nm, Path: pnm, OrigScale: orig[pnm], Scale: tn.GetScale(pt), NetIn: avgs[nm]})
+		}
+	}
+	if len(bad) > 0 {
+		return fmt.Errorf("netin.Tuner: average net input not in range [%g, %g] for layers: %s", tn.Min, tn.Max, strings.Join(bad, ", "))
+	}
+	return nil
+}
+
+// Table returns the Results as a table, with columns
+// Layer, Path, OrigScale, Scale, and NetIn.
+func (tn *Tuner) Table() *table.Table {
+	dt := table.New()
+	metadata.SetName(dt, "NetInTune")
+	tensor.SetPrecision(dt, 4)
+	dt.AddStringColumn("Layer")
+	dt.AddStringColumn("Path")
+	dt.AddFloat64Column("OrigScale")
+	dt.AddFloat64Column("Scale")
+	dt.AddFloat64Column("NetIn")
+	dt.SetNumRows(len(tn.Results))
+	for i, rs := range tn.Results {
+		dt.Column("Layer").SetStringRow(rs.Layer, i, 0)
+		dt.Column("Path").SetStringRow(rs.Path, i, 0)
+		dt.Column("OrigScale").SetFloatRow(float64(rs.OrigScale), i, 0)
+		dt.Column("Scale").SetFloatRow(float64(rs.Scale), i, 0)
+		dt.Column("NetIn").SetFloatRow(float64(rs.NetIn), i, 0)
+	}
+	return dt
+}
+
+// ParamsString returns the tuned scales that differ from the original
+// ones as params Sel entries, one per line, for inclusion in the params
+// of the sim, with given path params type (e.g., "*axon.PathParams")
+// and scale field (e.g., "PathScale.Abs").
+func (tn *Tuner) ParamsString(typ, field string) string {
+	var b strings.Builder
+	for _, rs := range tn.Results {
+		if rs.Scale == rs.OrigScale {
+			continue
+		}
+		fmt.Fprintf(&b, "{Sel: \"#%s\", Doc: \"tuned by netin.Tuner from %.4g\",\n\tSet: func(pt %s) {\n\t\tpt.%s = %.4g\n\t}},\n", rs.Path, rs.OrigScale, typ, field, rs.Scale)
+	}
+	return b.String()
+}
@@ -0,0 +1,15 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package netin
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/netin.PathContrib", IDName: "path-contrib", Doc: "PathContrib has the contribution of one pathway to the\nnet input of its receiving layer.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Fields: []types.Field{{Name: "Layer", Doc: "Layer is the name of the receiving layer."}, {Name: "Path", Doc: "Path is the name of the pathway."}, {Name: "Contrib", Doc: "Contrib is the average contribution of the pathway to the\nnet input of each receiving unit, as of the last Compute."}, {Name: "Prop", Doc: "Prop is the proportion of the total net input of the\nreceiving layer contributed by this pathway."}, {Name: "Imbalanced", Doc: "Imbalanced is true if Prop is above the Imbalance threshold."}, {Name: "sum", Doc: "sum is the sum of the contribution since the last Reset."}, {Name: "n", Doc: "n is the number of measurements in the sum."}, {Name: "pt", Doc: "pt is the pathway."}, {Name: "syns", Doc: "syns are the cached synapse indexes."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/netin.Contribs", IDName: "contribs", Doc: "Contribs decomposes the average net input (excitatory conductance) of\neach layer into the contributions from each of its receiving pathways,\ncomputed as the scale of the pathway times the sum of the sending\nactivity times the weight over its synapses, averaged over receiving\nunits, and flags imbalances where one pathway dominates the net input\nof a layer, helping to understand why a layer is dominated by a\nparticular input. Measure is called every trial, and Compute at the end\nof each epoch, followed by Table or SetStats for logging, and Reset.\nThe synapse indexes of each pathway are cached on the first Measure,\nwhich can be slow for large layers, and Init must be called if the\nconnectivity changes.", Fields: []types.Field{{Name: "ActVar", Doc: "ActVar is the unit variable for the sending activity."}, {Name: "WtVar", Doc: "WtVar is the synaptic variable for the weights."}, {Name: "Layers", Doc: "Layers is a space-separated list of selectors for the receiving\nlayers, using params selector syntax: .Class or #Name, or a layer\ntype name. An empty list includes all layers with receiving pathways."}, {Name: "Imbalance", Doc: "Imbalance is the threshold proportion of the net input of a layer\ncontributed by one pathway above which it is flagged as imbalanced."}, {Name: "GetScale", Doc: "GetScale returns the scale of given pathway, which multiplies its\ncontribution. If nil, the scale is 1 for all pathways."}, {Name: "Paths", Doc: "Paths are the contributions for each pathway, in order of the\nreceiving layers and their pathways."}, {Name: "vals"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/netin.TuneResult", IDName: "tune-result", Doc: "TuneResult is the result of tuning the scale of one pathway.", Fields: []types.Field{{Name: "Layer", Doc: "Layer is the name of the receiving layer."}, {Name: "Path", Doc: "Path is the name of the pathway."}, {Name: "OrigScale", Doc: "OrigScale is the scale of the pathway before tuning."}, {Name: "Scale", Doc: "Scale is the tuned scale of the pathway."}, {Name: "NetIn", Doc: "NetIn is the average net input of the receiving layer\nafter tuning."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/netin.Tuner", IDName: "tuner", Doc: "Tuner adjusts the scales of the pathways into each layer so that the\naverage net input of each layer falls within a target range, by running\na few calibration trials, measuring the average net input, multiplying\nthe scales of all the receiving pathways of each layer that is out of\nrange by the factor needed to reach the middle of the range, and\nrepeating until all layers are in range. The relative scales of the\npathways into each layer are preserved, so this is typically applied to\nthe absolute scale. The tuned values are reported in Results, for\ninclusion in the params. Because the scales are specific to each\nalgorithm, they are accessed through the GetScale and SetScale functions,\nwhich must be set, along with the Run function.", Fields: []types.Field{{Name: "Var", Doc: "Var is the unit variable for the net input."}, {Name: "Min", Doc: "Min is the minimum of the target range for the average net input."}, {Name: "Max", Doc: "Max is the maximum of the target range for the average net input."}, {Name: "Layers", Doc: "Layers is a space-separated list of selectors for the layers that\nare tuned, using params selector syntax: .Class or #Name, or a layer\ntype name. An empty list tunes all layers with receiving pathways."}, {Name: "MaxIters", Doc: "MaxIters is the maximum number of calibration iterations."}, {Name: "MaxFactor", Doc: "MaxFactor is the maximum factor by which the scales can be\nchanged on each iteration, up or down."}, {Name: "Run", Doc: "Run runs the calibration trials (e.g., several trials in Test mode,\nwithout learning), calling the given measure function at the point\nin each trial where the net input should be measured\n(e.g., at the end of the minus phase)."}, {Name: "GetScale", Doc: "GetScale returns the current scale of given pathway."}, {Name: "SetScale", Doc: "SetScale sets the scale of given pathway, updating any\nvalues that depend on it."}, {Name: "Results", Doc: "Results are the tuned scales for each pathway."}, {Name: "sums", Doc: "sums are the sums of the net input for each layer."}, {Name: "ns", Doc: "ns are the numbers of values in the sums."}, {Name: "vals"}}})
@@ -0,0 +1,165 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"math"
+
+	"cogentcore.org/core/base/errors"
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/core/core"
+	"cogentcore.org/core/styles"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"cogentcore.org/lab/tensorcore"
+)
+
+// LayerDiff returns the difference map (B - A) of the values of given unit
+// variable for given layer, between two recorded records, recA and recB
+// (-1 for the current, last record, or in [0..Len-1] for prior records),
+// for given data parallel index, with the same shape as the layer.
+// Values that are unavailable (NaN) in either record are NaN.
+func (nd *NetData) LayerDiff(laynm, vnm string, recA, recB, di int) (*tensor.Float32, error) {
+	if nd.Ring.Len == 0 {
+		return nil, fmt.Errorf("netview.LayerDiff: no data recorded")
+	}
+	if _, ok := nd.UnVarIndexes[vnm]; !ok {
+		return nil, fmt.Errorf("netview.LayerDiff: variable %q not recorded", vnm)
+	}
+	ly, err := nd.Net.AsEmer().EmerLayerByName(laynm)
+	if err != nil {
+		return nil, err
+	}
+	ld, ok := nd.LayData[laynm]
+	if !ok {
+		return nil, fmt.Errorf("netview.LayerDiff: layer %q not recorded", laynm)
+	}
+	ria := nd.RecIndex(recA)
+	rib := nd.RecIndex(recB)
+	tsr := tensor.NewFloat32(ly.AsEmer().Shape.Sizes...)
+	for ui := range ld.NUnits {
+		a, oka := nd.UnitValueIndex(laynm, vnm, ui, ria, di)
+		b, okb := nd.UnitValueIndex(laynm, vnm, ui, rib, di)
+		if !oka || !okb {
+			tsr.SetFloat1D(math.NaN(), ui)
+			continue
+		}
+		tsr.SetFloat1D(float64(b-a), ui)
+	}
+	return tsr, nil
+}
+
+// DiffStats returns a table of summary statistics for the changes in
+// given unit variable between two recorded records, recA and recB
+// (-1 for the current, last record), for given data parallel index,
+// with one row per layer, sorted in network order, with columns:
+// Layer, MeanAbs and MaxAbs of the differences, RMS (root mean square),
+// Changed = proportion of units with an absolute difference > thr,
+// and MaxUnit = the 1D index of the unit with the largest change.
+// This helps to localize where a specific input altered processing.
+func (nd *NetData) DiffStats(vnm string, recA, recB, di int, thr float32) (*table.Table, error) {
+	dt := table.New()
+	metadata.SetName(dt, fmt.Sprintf("NetView Diff: %s %d-%d", vnm, recA, recB))
+	metadata.Set(dt, "read-only", true)
+	tensor.SetPrecision(dt, 4)
+	dt.AddStringColumn("Layer")
+	mabs := dt.AddFloat64Column("MeanAbs")
+	xabs := dt.AddFloat64Column("MaxAbs")
+	rms := dt.AddFloat64Column("RMS")
+	chg := dt.AddFloat64Column("Changed")
+	xun := dt.AddIntColumn("MaxUnit")
+	nlay := nd.Net.NumLayers()
+	for li := range nlay {
+		laynm := nd.Net.EmerLayer(li).Label()
+		tsr, err := nd.LayerDiff(laynm, vnm, recA, recB, di)
+		if err != nil {
+			return nil, err
+		}
+		var sum, ssq, mx float64
+		n, nchg, mxi := 0, 0, -1
+		for ui := range tsr.Len() {
+			d := tsr.Float1D(ui)
+			if math.IsNaN(d) {
+				continue
+			}
+			ad := math.Abs(d)
+			sum += ad
+			ssq += d * d
+			n++
+			if ad > float64(thr) {
+				nchg++
+			}
+			if mxi < 0 || ad > mx {
+				mx = ad
+				mxi = ui
+			}
+		}
+		row := dt.NumRows()
+		dt.SetNumRows(row + 1)
+		dt.Column("Layer").SetStringRow(laynm, row, 0)
+		xun.SetIntRow(mxi, row, 0)
+		if n == 0 {
+			continue
+		}
+		mabs.SetFloatRow(sum/float64(n), row, 0)
+		xabs.SetFloatRow(mx, row, 0)
+		rms.SetFloatRow(math.Sqrt(ssq/float64(n)), row, 0)
+		chg.SetFloatRow(float64(nchg)/float64(n), row, 0)
+	}
+	return dt, nil
+}
+
+// DiffRecords opens a window showing the per-layer difference maps (B - A)
+// of given unit variable between two recorded records, recA and recB
+// (-1 for the current, last record, or in [0..Len-1] for prior records,
+// as shown in the record number in the NetView toolbar), along with the
+// [NetData.DiffStats] summary of changes per layer, using 0.01 as the
+// change threshold. The difference maps all use the same symmetric
+// color scale, so that the magnitudes can be compared across layers.
+func (nv *NetView) DiffRecords(varName string, recA, recB int) error { //types:add
+	nd := &nv.Data
+	dt, err := nd.DiffStats(varName, recA, recB, nv.Di, 0.01)
+	if errors.Log(err) != nil {
+		return err
+	}
+	mx := 0.0
+	for ri := range dt.NumRows() {
+		mx = max(mx, dt.Column("MaxAbs").FloatRow(ri, 0))
+	}
+	if mx == 0 {
+		mx = 1
+	}
+	title := fmt.Sprintf("NetView Diff: %s [%s] - [%s]", varName, nd.CounterRec(recB), nd.CounterRec(recA))
+	b := core.NewBody("netview-diff").SetTitle(title)
+	tb := tensorcore.NewTable(b)
+	tb.SetTable(dt)
+	maps := core.NewFrame(b)
+	maps.Styler(func(s *styles.Style) {
+		s.Wrap = true
+		s.Grow.Set(1, 1)
+		s.Overflow.Set(styles.OverflowAuto)
+	})
+	nlay := nd.Net.NumLayers()
+	for li := range nlay {
+		laynm := nd.Net.EmerLayer(li).Label()
+		tsr, err := nd.LayerDiff(laynm, varName, recA, recB, nv.Di)
+		if err != nil {
+			continue
+		}
+		tensorcore.AddGridStylerTo(tsr, func(s *tensorcore.GridStyle) {
+			s.Range.SetMin(-mx).SetMax(mx)
+			s.ColorMap = "ColdHot"
+		})
+		fr := core.NewFrame(maps)
+		fr.Styler(func(s *styles.Style) {
+			s.Direction = styles.Column
+		})
+		core.NewText(fr).SetText(laynm)
+		tensorcore.NewTensorGrid(fr).SetTensor(tsr)
+	}
+	b.RunWindow()
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayerDiff(t *testing.T) {
+	net := newTestNet(t)
+	in, _ := net.LayerByName("Input")
+	nd := &NetData{}
+	nd.Init(net, 4, true, 1)
+	_, err := nd.LayerDiff("Input", "Act", 0, 1, 0)
+	assert.ErrorContains(t, err, "no data recorded")
+
+	copy(in.Act, []float32{0, 0, 0, 0})
+	nd.Record("A", -1, 10)
+	copy(in.Act, []float32{1, 0, -0.5, 0})
+	nd.Record("B", -1, 10)
+
+	df, err := nd.LayerDiff("Input", "Act", 0, 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 2}, df.ShapeSizes())
+	assert.Equal(t, []float32{1, 0, -0.5, 0}, df.Values)
+	df, err = nd.LayerDiff("Input", "Act", 1, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{-1, 0, 0.5, 0}, df.Values)
+	df, err = nd.LayerDiff("Input", "Act", 0, -1, 0) // -1 = last record
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1, 0, -0.5, 0}, df.Values)
+
+	_, err = nd.LayerDiff("Input", "Foo", 0, 1, 0)
+	assert.ErrorContains(t, err, `variable "Foo" not recorded`)
+	_, err = nd.LayerDiff("Foo", "Act", 0, 1, 0)
+	assert.Error(t, err)
+}
+
+func TestDiffStats(t *testing.T) {
+	net := newTestNet(t)
+	in, _ := net.LayerByName("Input")
+	nd := &NetData{}
+	nd.Init(net, 4, true, 1)
+	copy(in.Act, []float32{0, 0, 0, 0})
+	nd.Record("A", -1, 10)
+	copy(in.Act, []float32{1, 0, -0.5, 0.05})
+	nd.Record("B", -1, 10)
+
+	dt, err := nd.DiffStats("Act", 0, 1, 0, 0.1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, dt.NumRows())
+	assert.Equal(t, "Input", dt.Column("Layer").StringRow(0, 0))
+	assert.InDelta(t, 1.55/4, dt.Column("MeanAbs").FloatRow(0, 0), 1.0e-6)
+	assert.InDelta(t, 1, dt.Column("MaxAbs").FloatRow(0, 0), 1.0e-6)
+	assert.InDelta(t, math.Sqrt(1.2525/4), dt.Column("RMS").FloatRow(0, 0), 1.0e-6)
+	assert.Equal(t, 0.5, dt.Column("Changed").FloatRow(0, 0)) // 0.05 < thr
+	assert.Equal(t, 0, dt.Column("MaxUnit").IntRow(0, 0))
+
+	assert.Equal(t, "Output", dt.Column("Layer").StringRow(2, 0))
+	assert.Equal(t, 0.0, dt.Column("MaxAbs").FloatRow(2, 0))
+	assert.Equal(t, 0.0, dt.Column("Changed").FloatRow(2, 0))
+
+	_, err = nd.DiffStats("Foo", 0, 1, 0, 0.1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/emer/emergent/v2/emer"
+)
+
+// LayerGroup is a named collection of layers, e.g., "Visual" or "PFC",
+// which can be hidden or collapsed as a unit, to make large models
+// readable and faster to render.
+type LayerGroup struct { //types:add
+
+	// name of the group, which is shown in place of the layers when collapsed
+	Name string
+
+	// names of the layers in the group
+	Layers []string
+
+	// hide all of the layers in the group, and their pathways
+	Hide bool
+
+	// collapse the group, so that its layers and their pathways are not
+	// displayed, and only the group name is shown at the position of the
+	// first layer in the group
+	Collapse bool
+}
+
+// layerStates are the display states of layers.
+type layerStates int32
+
+const (
+	// layerShown is a normally displayed layer.
+	layerShown layerStates = iota
+
+	// layerHidden is a hidden layer.
+	layerHidden
+
+	// layerCollapsed is a layer in a collapsed group, that is not displayed.
+	layerCollapsed
+
+	// layerGroupName is the first layer in a collapsed group,
+	// where the name of the group is displayed.
+	layerGroupName
+)
+
+// AddLayerGroup adds a named group of layers to the Options.LayerGroups,
+// returning the group so it can be hidden or collapsed.
+func (nv *NetView) AddLayerGroup(name string, layers ...string) *LayerGroup {
+	lg := &LayerGroup{Name: name, Layers: layers}
+	nv.Options.LayerGroups = append(nv.Options.LayerGroups, lg)
+	return lg
+}
+
+// LayerGroup returns the layer group with given name, or nil if not found.
+func (nv *NetView) LayerGroup(name string) *LayerGroup {
+	for _, lg := range nv.Options.LayerGroups {
+		if lg.Name == name {
+			return lg
+		}
+	}
+	return nil
+}
+
+// SetLayerGroupHide sets whether the layer group with given name is hidden,
+// and updates the display.
+func (nv *NetView) SetLayerGroupHide(name string, hide bool) {
+	if lg := nv.LayerGroup(name); lg != nil {
+		lg.Hide = hide
+		nv.UpdateView()
+	}
+}
+
+// SetLayerGroupCollapse sets whether the layer group with given name
+// is collapsed, and updates the display.
+func (nv *NetView) SetLayerGroupCollapse(name string, collapse bool) {
+	if lg := nv.LayerGroup(name); lg != nil {
+		lg.Collapse = collapse
+		nv.UpdateView()
+	}
+}
+
+// ShowAllLayers shows all layers, clearing the Options.HideLayers and
+// the hidden and collapsed status of all layer groups.
+func (nv *NetView) ShowAllLayers() { //types:add
+	nv.Options.HideLayers = ""
+	for _, lg := range nv.Options.LayerGroups {
+		lg.Hide = false
+		lg.Collapse = false
+	}
+	nv.Toolbar().Update()
+	nv.UpdateView()
+}
+
+// LayerIsShown returns true if the given layer is displayed,
+// i.e., it is not hidden or in a collapsed group.
+func (nv *NetView) LayerIsShown(ly emer.Layer) bool {
+	return nv.layerState(ly) == layerShown
+}
+
+// layerState returns the display state of given layer.
+func (nv *NetView) layerState(ly emer.Layer) layerStates {
+	lb := ly.AsEmer()
+	if nv.Options.HideLayers != "" {
+		cls := strings.Fields(lb.Class)
+		for _, nm := range strings.Fields(nv.Options.HideLayers) {
+			if nm == lb.Name || slices.Contains(cls, nm) {
+				return layerHidden
+			}
+		}
+	}
+	for _, lg := range nv.Options.LayerGroups {
+		li := slices.Index(lg.Layers, lb.Name)
+		if li < 0 {
+			continue
+		}
+		switch {
+		case lg.Hide:
+			return layerHidden
+		case lg.Collapse && li == 0:
+			return layerGroupName
+		case lg.Collapse:
+			return layerCollapsed
+		}
+	}
+	return layerShown
+}
+
+// layerStates returns the display states of all layers.
+func (nv *NetView) layerStates() []layerStates {
+	nlay := nv.Net.NumLayers()
+	sts := make([]layerStates, nlay)
+	for li := range nlay {
+		sts[li] = nv.layerState(nv.Net.EmerLayer(li))
+	}
+	return sts
+}
+
+// layerGroupName returns the name of the collapsed group
+// for which given layer is the first layer.
+func (nv *NetView) layerGroupName(ly emer.Layer) string {
+	nm := ly.Label()
+	for _, lg := range nv.Options.LayerGroups {
+		if lg.Collapse && len(lg.Layers) > 0 && lg.Layers[0] == nm {
+			return lg.Name
+		}
+	}
+	return nm
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"testing"
+
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestNet(t *testing.T) *bp.Network {
+	net := bp.NewNetwork("NetView")
+	in := net.AddLayer2D("Input", 2, 2, bp.InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 3, bp.HiddenLayer)
+	out := net.AddLayer2D("Output", 1, 2, bp.TargetLayer)
+	net.ConnectLayers(in, hid, paths.NewFull(), bp.ForwardPath)
+	net.ConnectLayers(hid, out, paths.NewFull(), bp.ForwardPath)
+	assert.NoError(t, net.Build())
+	return net
+}
+
+func TestLayerGroups(t *testing.T) {
+	net := newTestNet(t)
+	in, _ := net.LayerByName("Input")
+	hid, _ := net.LayerByName("Hidden")
+	out, _ := net.LayerByName("Output")
+	nv := &NetView{Net: net}
+	assert.Equal(t, []layerStates{layerShown, layerShown, layerShown}, nv.layerStates())
+
+	lg := nv.AddLayerGroup("Deep", "Hidden", "Output")
+	assert.Equal(t, lg, nv.LayerGroup("Deep"))
+	assert.Nil(t, nv.LayerGroup("Foo"))
+	assert.True(t, nv.LayerIsShown(hid))
+
+	lg.Collapse = true
+	assert.Equal(t, []layerStates{layerShown, layerGroupName, layerCollapsed}, nv.layerStates())
+	assert.False(t, nv.LayerIsShown(hid))
+	assert.Equal(t, "Deep", nv.layerGroupName(hid))
+	assert.Equal(t, "Output", nv.layerGroupName(out))
+
+	lg.Hide = true // hide takes precedence over collapse
+	assert.Equal(t, []layerStates{layerShown, layerHidden, layerHidden}, nv.layerStates())
+
+	lg.Hide, lg.Collapse = false, false
+	nv.Options.HideLayers = "Input"
+	assert.False(t, nv.LayerIsShown(in))
+	assert.True(t, nv.LayerIsShown(out))
+	in.Class = "Vis"
+	nv.Options.HideLayers = "Foo Vis" // classes also match
+	assert.Equal(t, []layerStates{layerHidden, layerShown, layerShown}, nv.layerStates())
+}
@@ -12,11 +12,14 @@ import (
 	"image/color"
 	"log"
 	"log/slog"
+	"maps"
 	"reflect"
 	"strings"
 	"sync"
 	"time"
 
+	"cogentcore.org/core/base/errors"
+	"cogentcore.org/core/base/iox/jsonx"
 	"cogentcore.org/core/colors"
 	"cogentcore.org/core/colors/colormap"
 	"cogentcore.org/core/core"
@@ -29,6 +32,7 @@ import (
 	"cogentcore.org/core/tree"
 	"cogentcore.org/core/types"
 	"cogentcore.org/core/xyz"
+	"cogentcore.org/lab/tensor"
 	"github.com/emer/emergent/v2/emer"
 )
 
@@ -90,6 +94,22 @@ type NetView struct {
 	hasPaths           bool
 	pathTypeShown      string
 	pathWidthShown     float32
+	layerStatesShown   []layerStates
+
+	// curColorMap is the color map for the CurVarOptions.
+	curColorMap *colormap.Map
+
+	// varOptionsPresets are the variable options loaded from a settings
+	// file by OpenVarOptions, which are applied to the matching variables
+	// whenever the list of variables is updated.
+	varOptionsPresets map[string]*VarOptions
+
+	// copiedVarOptions are the variable options copied by CopyVarOptions.
+	copiedVarOptions *VarOptions
+
+	// overlays are the static per-unit overlay values set by SetOverlay,
+	// keyed by overlay variable name and then layer name.
+	overlays map[string]map[string]tensor.Tensor
 }
 
 func (nv *NetView) Init() {
@@ -277,7 +297,7 @@ func (nv *NetView) UpdateImpl() {
 		log.Printf("NetView: %v variable: %v not found\n", nv.Name, nv.Var)
 		return
 	}
-	nv.CurVarOptions = vp
+	nv.setCurVarOptions(vp)
 
 	if !vp.Range.FixMin || !vp.Range.FixMax {
 		needUpdate := false
@@ -482,6 +502,7 @@ func (nv *NetView) NetVarsList(net emer.Network, layEven bool) (nvars, synvars [
 // VarsListUpdate updates the list of network variables
 func (nv *NetView) VarsListUpdate() {
 	nvars, synvars := nv.NetVarsList(nv.Net, true) // true = layEven
+	nvars = append(nvars, nv.overlayVars()...)
 	if len(nvars) == len(nv.Vars) {
 		return
 	}
@@ -508,6 +529,12 @@ func (nv *NetView) VarsListUpdate() {
 		if vtag != "" {
 			vp.SetProps(vtag)
 		}
+		if strings.HasPrefix(nm, OverlayPrefix) {
+			nv.overlayRange(vp)
+		}
+		if pv, ok := nv.varOptionsPresets[nm]; ok {
+			vp.CopyFrom(pv)
+		}
 		nv.VarOptions[nm] = vp
 	}
 }
@@ -527,6 +554,9 @@ func (nv *NetView) makeVars(netframe *core.Frame) {
 			{"Wt", "connection weight variables"},
 		}
 	}
+	if len(nv.overlays) > 0 {
+		cats = append(append([]emer.VarCategory{}, cats...), overlayCat)
+	}
 	tree.AddChildAt(netframe, "vars", func(w *core.Tabs) {
 		w.Styler(func(s *styles.Style) {
 			s.Grow.Set(0, 1)
@@ -549,7 +579,9 @@ func (nv *NetView) makeVars(netframe *core.Frame) {
 			cat := ""
 			pstr := ""
 			doc := ""
-			if strings.HasPrefix(vn, "r.") || strings.HasPrefix(vn, "s.") {
+			if strings.HasPrefix(vn, OverlayPrefix) {
+				cat = overlayCat.Cat
+			} else if strings.HasPrefix(vn, "r.") || strings.HasPrefix(vn, "s.") {
 				pstr = pathprops[vn[2:]]
 				cat = "Wt" // default
 			} else {
@@ -617,6 +649,8 @@ func (nv *NetView) UnitValue(lay emer.Layer, idx []int) (raw, scaled float32, cl
 	idx1d := lb.Shape.IndexTo1D(idx...)
 	if idx1d >= lb.Shape.Len() {
 		raw, hasval = 0, false
+	} else if strings.HasPrefix(nv.Var, OverlayPrefix) {
+		raw, hasval = nv.overlayValue(lb.Name, idx1d)
 	} else {
 		raw, hasval = nv.Data.UnitValue(lb.Name, nv.Var, idx1d, nv.RecNo, nv.Di)
 	}
@@ -634,6 +668,8 @@ func (nv *NetView) UnitValRaster(lay emer.Layer, idx []int, rCtr int) (raw, scal
 	if len(ridx) == 0 { // no rep
 		if idx1d >= lb.Shape.Len() {
 			raw, hasval = 0, false
+		} else if strings.HasPrefix(nv.Var, OverlayPrefix) {
+			raw, hasval = nv.overlayValue(lb.Name, idx1d)
 		} else {
 			raw, hasval = nv.Data.UnitValRaster(lb.Name, nv.Var, idx1d, rCtr, nv.Di)
 		}
@@ -642,7 +678,11 @@ func (nv *NetView) UnitValRaster(lay emer.Layer, idx []int, rCtr int) (raw, scal
 			raw, hasval = 0, false
 		} else {
 			idx1d = ridx[idx1d]
-			raw, hasval = nv.Data.UnitValRaster(lb.Name, nv.Var, idx1d, rCtr, nv.Di)
+			if strings.HasPrefix(nv.Var, OverlayPrefix) {
+				raw, hasval = nv.overlayValue(lb.Name, idx1d)
+			} else {
+				raw, hasval = nv.Data.UnitValRaster(lb.Name, nv.Var, idx1d, rCtr, nv.Di)
+			}
 		}
 	}
 	scaled, clr = nv.UnitValColor(lay, idx1d, raw, hasval)
@@ -654,12 +694,12 @@ var NilColor = color.RGBA{0x20, 0x20, 0x20, 0x40}
 // UnitValColor returns the raw value, scaled value, and color representation
 // for given unit of given layer. scaled is in range -1..1
 func (nv *NetView) UnitValColor(lay emer.Layer, idx1d int, raw float32, hasval bool) (scaled float32, clr color.RGBA) {
-	if nv.CurVarOptions == nil || nv.CurVarOptions.Var != nv.Var {
-		ok := false
-		nv.CurVarOptions, ok = nv.VarOptions[nv.Var]
+	if nv.CurVarOptions == nil || nv.CurVarOptions.Var != nv.Var || nv.curColorMap == nil {
+		vp, ok := nv.VarOptions[nv.Var]
 		if !ok {
 			return
 		}
+		nv.setCurVarOptions(vp)
 	}
 	if !hasval {
 		scaled = 0
@@ -679,11 +719,29 @@ func (nv *NetView) UnitValColor(lay emer.Layer, idx1d int, raw float32, hasval b
 			scaled = float32(norm)
 			op = (nv.Options.ZeroAlpha + (1-nv.Options.ZeroAlpha)*0.8) // no meaningful alpha -- just set at 80\%
 		}
-		clr = colors.WithAF32(nv.ColorMap.Map(norm), op)
+		clr = colors.WithAF32(nv.curColorMap.Map(norm), op)
 	}
 	return
 }
 
+// setCurVarOptions sets the CurVarOptions and the corresponding color map.
+func (nv *NetView) setCurVarOptions(vp *VarOptions) {
+	nv.CurVarOptions = vp
+	nv.curColorMap = nv.VarColorMap(vp)
+}
+
+// VarColorMap returns the color map to use for given variable options,
+// which is the ColorMap for the variable if set, and otherwise the
+// default ColorMap from the Options.
+func (nv *NetView) VarColorMap(vp *VarOptions) *colormap.Map {
+	if vp != nil && vp.ColorMap != "" {
+		if cmap, ok := colormap.AvailableMaps[string(vp.ColorMap)]; ok {
+			return cmap
+		}
+	}
+	return nv.ColorMap
+}
+
 func (nv *NetView) Labels() *xyz.Group {
 	se := nv.SceneXYZ()
 	lgpi := se.ChildByName("Labels", 1)
@@ -765,6 +823,61 @@ func (nv *NetView) OpenWeights(filename core.Filename) { //types:add
 	nv.Net.AsEmer().OpenWeightsJSON(filename)
 }
 
+// SaveVarOptions saves the display options (color map, range, and zero
+// centering) for all variables to a JSON view settings file, which can
+// be opened in other sims using the same variables with OpenVarOptions.
+func (nv *NetView) SaveVarOptions(filename core.Filename) error { //types:add
+	return errors.Log(jsonx.SaveIndent(nv.VarOptions, string(filename)))
+}
+
+// OpenVarOptions opens the display options for variables from a JSON
+// view settings file saved by SaveVarOptions, and applies them to the
+// variables with matching names, now and whenever the variables
+// are updated.
+func (nv *NetView) OpenVarOptions(filename core.Filename) error { //types:add
+	presets := make(map[string]*VarOptions)
+	if err := jsonx.Open(&presets, string(filename)); err != nil {
+		return errors.Log(err)
+	}
+	if nv.varOptionsPresets == nil {
+		nv.varOptionsPresets = presets
+	} else {
+		maps.Copy(nv.varOptionsPresets, presets)
+	}
+	for nm, pv := range presets {
+		if vp, ok := nv.VarOptions[nm]; ok {
+			vp.CopyFrom(pv)
+		}
+	}
+	nv.Toolbar().Update()
+	nv.UpdateView()
+	return nil
+}
+
+// CopyVarOptions copies the display options (color map, range, and zero
+// centering) of the current variable, for pasting into other variables
+// with PasteVarOptions.
+func (nv *NetView) CopyVarOptions() { //types:add
+	vp, ok := nv.VarOptions[nv.Var]
+	if !ok {
+		return
+	}
+	nv.copiedVarOptions = &VarOptions{}
+	nv.copiedVarOptions.CopyFrom(vp)
+}
+
+// PasteVarOptions sets the display options of the current variable to
+// those copied by CopyVarOptions.
+func (nv *NetView) PasteVarOptions() { //types:add
+	vp, ok := nv.VarOptions[nv.Var]
+	if !ok || nv.copiedVarOptions == nil {
+		return
+	}
+	vp.CopyFrom(nv.copiedVarOptions)
+	nv.Toolbar().Update()
+	nv.UpdateView()
+}
+
 // ShowNonDefaultParams shows a dialog of all the parameters that
 // are not at their default values in the network.  Useful for setting params.
 func (nv *NetView) ShowNonDefaultParams() string { //types:add
@@ -795,3 +908,11 @@ func (nv *NetView) ShowKeyPathParams() string { //types:add
 	texteditor.TextDialog(nv, "Key Path Params: "+nv.Name, nds)
 	return nds
 }
+
+// ShowDescription shows a dialog with a summary of the structure of the
+// network: its layers and pathways, and the parameter sheets applied.
+func (nv *NetView) ShowDescription() string { //types:add
+	nds := nv.Net.AsEmer().Describe().String()
+	texteditor.TextDialog(nv, "Description: "+nv.Name, nds)
+	return nds
+}
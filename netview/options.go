@@ -62,6 +62,14 @@ type Options struct { //types:add
 	// width of the path arrows, in normalized units
 	PathWidth float32 `min:"0.0001" max:".05" step:"0.001" default:"0.002"`
 
+	// HideLayers has name(s) of layers to hide (space separated),
+	// along with their pathways, which can also be parameter Class names.
+	HideLayers string
+
+	// LayerGroups are named collections of layers that can be hidden
+	// or collapsed as a unit.
+	LayerGroups []*LayerGroup
+
 	// raster plot parameters
 	Raster RasterOptions `display:"inline"`
 
@@ -129,8 +137,12 @@ type VarOptions struct { //types:add
 	// range to display
 	Range minmax.Range32 `display:"inline"`
 
+	// name of color map to use for this variable, overriding the
+	// Options.ColorMap if set
+	ColorMap core.ColorMapName
+
 	// if not using fixed range, this is the actual range of data
-	MinMax minmax.F32 `display:"inline"`
+	MinMax minmax.F32 `display:"inline" json:"-"`
 }
 
 // Defaults sets default values if otherwise not set
@@ -142,6 +154,14 @@ func (vp *VarOptions) Defaults() {
 	}
 }
 
+// CopyFrom copies the display settings (ZeroCtr, Range, ColorMap)
+// from given other variable options, keeping the Var name.
+func (vp *VarOptions) CopyFrom(fm *VarOptions) {
+	vp.ZeroCtr = fm.ZeroCtr
+	vp.Range = fm.Range
+	vp.ColorMap = fm.ColorMap
+}
+
 // SetProps parses Go struct-tag style properties for variable and sets values accordingly
 // for customized defaults
 func (vp *VarOptions) SetProps(pstr string) {
@@ -0,0 +1,59 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"path/filepath"
+	"testing"
+
+	"cogentcore.org/core/base/iox/jsonx"
+	"cogentcore.org/core/colors/colormap"
+	"cogentcore.org/core/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVarOptionsCopy(t *testing.T) {
+	act := &VarOptions{Var: "Act"}
+	act.Defaults()
+	assert.True(t, act.ZeroCtr)
+	ge := &VarOptions{Var: "Ge", ColorMap: "Viridis"}
+	ge.Range.SetMin(0).SetMax(2)
+	act.CopyFrom(ge)
+	assert.Equal(t, "Act", act.Var)
+	assert.False(t, act.ZeroCtr)
+	assert.Equal(t, float32(2), act.Range.Max)
+	assert.Equal(t, core.ColorMapName("Viridis"), act.ColorMap)
+
+	nv := &NetView{VarOptions: map[string]*VarOptions{"Act": act, "Ge": ge}}
+	nv.Var = "Ge"
+	nv.CopyVarOptions()
+	ge.Range.SetMax(5) // copy is independent of the source
+	assert.Equal(t, float32(2), nv.copiedVarOptions.Range.Max)
+	assert.Equal(t, "", nv.copiedVarOptions.Var)
+}
+
+func TestVarColorMap(t *testing.T) {
+	nv := &NetView{ColorMap: colormap.AvailableMaps["ColdHot"]}
+	assert.Equal(t, nv.ColorMap, nv.VarColorMap(nil))
+	assert.Equal(t, nv.ColorMap, nv.VarColorMap(&VarOptions{}))
+	assert.Equal(t, nv.ColorMap, nv.VarColorMap(&VarOptions{ColorMap: "NoSuchMap"}))
+	assert.Equal(t, colormap.AvailableMaps["Viridis"], nv.VarColorMap(&VarOptions{ColorMap: "Viridis"}))
+}
+
+func TestSaveVarOptions(t *testing.T) {
+	act := &VarOptions{Var: "Act", ColorMap: "Viridis"}
+	act.Defaults()
+	act.MinMax.Set(0, 0.8)
+	nv := &NetView{VarOptions: map[string]*VarOptions{"Act": act}}
+	fn := filepath.Join(t.TempDir(), "vars.json")
+	assert.NoError(t, nv.SaveVarOptions(core.Filename(fn)))
+	presets := make(map[string]*VarOptions)
+	assert.NoError(t, jsonx.Open(&presets, fn))
+	pv := presets["Act"]
+	assert.Equal(t, act.Range, pv.Range)
+	assert.Equal(t, act.ColorMap, pv.ColorMap)
+	assert.True(t, pv.ZeroCtr)
+	assert.Zero(t, pv.MinMax.Max) // not saved
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// OverlayPrefix is the prefix for the names of overlay variables
+// in the list of variables.
+const OverlayPrefix = "o."
+
+// overlayCat is the variable category for overlay variables.
+var overlayCat = emer.VarCategory{"Overlay", "static per-unit overlay values, e.g., connectivity statistics"}
+
+// SetOverlay sets a static per-unit overlay variable with given name,
+// for given layer, with one value per unit in the given tensor, in 1D
+// unit index order (e.g., with the same shape as the layer). Overlay
+// variables are displayed like other unit variables, with the name
+// prefixed by [OverlayPrefix], e.g., o.FanIn, and do not change over
+// time. They are useful for displaying derived per-unit values such as
+// connectivity statistics. Call Update to update the list of variables.
+func (nv *NetView) SetOverlay(name, layer string, vals tensor.Tensor) {
+	if nv.overlays == nil {
+		nv.overlays = make(map[string]map[string]tensor.Tensor)
+	}
+	ov := nv.overlays[name]
+	if ov == nil {
+		ov = make(map[string]tensor.Tensor)
+		nv.overlays[name] = ov
+		nv.Vars = nil // force update of the list of variables
+	}
+	ov[layer] = vals
+	if vp, ok := nv.VarOptions[OverlayPrefix+name]; ok {
+		nv.overlayRange(vp)
+	}
+}
+
+// DeleteOverlay deletes the overlay variable with given name.
+// Call Update to update the list of variables.
+func (nv *NetView) DeleteOverlay(name string) {
+	if _, ok := nv.overlays[name]; !ok {
+		return
+	}
+	delete(nv.overlays, name)
+	nv.Vars = nil
+	if nv.Var == OverlayPrefix+name {
+		if vars, _ := nv.NetVarsList(nv.Net, true); len(vars) > 0 {
+			nv.Var = vars[0]
+		}
+	}
+}
+
+// overlayVars returns the sorted list of overlay variable names,
+// including the OverlayPrefix.
+func (nv *NetView) overlayVars() []string {
+	vars := make([]string, 0, len(nv.overlays))
+	for nm := range nv.overlays {
+		vars = append(vars, OverlayPrefix+nm)
+	}
+	slices.Sort(vars)
+	return vars
+}
+
+// overlayValue returns the value of the current overlay variable
+// for given layer and unit index.
+func (nv *NetView) overlayValue(laynm string, idx1d int) (float32, bool) {
+	tsr, ok := nv.overlays[strings.TrimPrefix(nv.Var, OverlayPrefix)][laynm]
+	if !ok || idx1d >= tsr.Len() {
+		return 0, false
+	}
+	val := tsr.Float1D(idx1d)
+	if math.IsNaN(val) {
+		return 0, false
+	}
+	return float32(val), true
+}
+
+// overlayRange sets the range of given overlay variable options
+// to the range of its values across all layers.
+func (nv *NetView) overlayRange(vp *VarOptions) {
+	ov := nv.overlays[strings.TrimPrefix(vp.Var, OverlayPrefix)]
+	mn, mx := math.Inf(1), math.Inf(-1)
+	for _, tsr := range ov {
+		for i := range tsr.Len() {
+			v := tsr.Float1D(i)
+			if math.IsNaN(v) {
+				continue
+			}
+			mn = min(mn, v)
+			mx = max(mx, v)
+		}
+	}
+	if mn > mx {
+		return
+	}
+	vp.ZeroCtr = mn < 0
+	vp.Range.SetMin(float32(mn)).SetMax(float32(mx))
+}
+
+// UnitGroupPrefix is the prefix for the names of the overlay variables
+// set by [NetView.SetUnitGroupOverlays], after the [OverlayPrefix].
+const UnitGroupPrefix = "Group:"
+
+// SetUnitGroupOverlays sets an overlay variable for each named unit
+// group in the layers of the network (see emer.LayerBase.AddUnitGroup),
+// named by the group name with the [UnitGroupPrefix], e.g., o.Group:Exc,
+// with 1 for the units in the group and 0 for the other units of its
+// layers, so that each group is highlighted by selecting its variable.
+// Call Update to update the list of variables.
+func (nv *NetView) SetUnitGroupOverlays() {
+	for li := range nv.Net.NumLayers() {
+		lb := nv.Net.EmerLayer(li).AsEmer()
+		for _, gnm := range lb.UnitGroupNames() {
+			mask, _ := lb.UnitGroupMask(gnm)
+			nv.SetOverlay(UnitGroupPrefix+gnm, lb.Name, mask)
+		}
+	}
+}
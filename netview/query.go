@@ -0,0 +1,107 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package netview
+
+import (
+	"fmt"
+	"math"
+
+	"cogentcore.org/lab/tensor"
+)
+
+// NumRecords returns the number of records currently stored.
+func (nd *NetData) NumRecords() int {
+	return nd.Ring.Len
+}
+
+// recRange returns the validated record range for given start and end
+// record numbers, where end < 0 means through the last record.
+func (nd *NetData) recRange(stRec, edRec int) (int, int, error) {
+	if nd.Ring.Len == 0 {
+		return 0, 0, fmt.Errorf("netview: no data recorded")
+	}
+	if edRec < 0 || edRec > nd.Ring.Len {
+		edRec = nd.Ring.Len
+	}
+	if stRec < 0 || stRec >= edRec {
+		return 0, 0, fmt.Errorf("netview: invalid record range [%d, %d) for %d records", stRec, edRec, nd.Ring.Len)
+	}
+	return stRec, edRec, nil
+}
+
+// UnitHistory returns the recorded values of given unit variable for given
+// layer, for the units with 1D indexes in [stUnit, edUnit), and records in
+// [stRec, edRec), for given data parallel index, as a tensor with shape
+// [records][units]. Record numbers are in [0..NumRecords-1], from oldest
+// to most recent, and an end of -1 means through the last unit or record.
+// Values that are unavailable are NaN. This allows analyses to reuse
+// the data recorded for the NetView.
+func (nd *NetData) UnitHistory(laynm, vnm string, stUnit, edUnit, stRec, edRec, di int) (*tensor.Float32, error) {
+	stRec, edRec, err := nd.recRange(stRec, edRec)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := nd.UnVarIndexes[vnm]; !ok {
+		return nil, fmt.Errorf("netview.UnitHistory: variable %q not recorded", vnm)
+	}
+	ld, ok := nd.LayData[laynm]
+	if !ok {
+		return nil, fmt.Errorf("netview.UnitHistory: layer %q not recorded", laynm)
+	}
+	if di < 0 || di >= nd.MaxData {
+		return nil, fmt.Errorf("netview.UnitHistory: data index %d out of range [0, %d)", di, nd.MaxData)
+	}
+	if edUnit < 0 || edUnit > ld.NUnits {
+		edUnit = ld.NUnits
+	}
+	if stUnit < 0 || stUnit >= edUnit {
+		return nil, fmt.Errorf("netview.UnitHistory: invalid unit range [%d, %d) for %d units", stUnit, edUnit, ld.NUnits)
+	}
+	nu := edUnit - stUnit
+	tsr := tensor.NewFloat32(edRec-stRec, nu)
+	for ri := stRec; ri < edRec; ri++ {
+		ridx := nd.Ring.Index(ri)
+		for ui := stUnit; ui < edUnit; ui++ {
+			v, ok := nd.UnitValueIndex(laynm, vnm, ui, ridx, di)
+			if !ok {
+				tsr.Values[(ri-stRec)*nu+ui-stUnit] = float32(math.NaN())
+				continue
+			}
+			tsr.Values[(ri-stRec)*nu+ui-stUnit] = v
+		}
+	}
+	return tsr, nil
+}
+
+// LayerHistory returns all the recorded values of given unit variable
+// for given layer, for given data parallel index, as a tensor with the
+// record as the outermost dimension, followed by the layer shape,
+// from oldest to most recent record. See [NetData.UnitHistory].
+func (nd *NetData) LayerHistory(laynm, vnm string, di int) (*tensor.Float32, error) {
+	tsr, err := nd.UnitHistory(laynm, vnm, 0, -1, 0, -1, di)
+	if err != nil {
+		return nil, err
+	}
+	ly, err := nd.Net.AsEmer().EmerLayerByName(laynm)
+	if err != nil {
+		return nil, err
+	}
+	tsr.SetShapeSizes(append([]int{tsr.DimSize(0)}, ly.AsEmer().Shape.Sizes...)...)
+	return tsr, nil
+}
+
+// CounterHistory returns the counter strings for records in
+// [stRec, edRec), where an end of -1 means through the last record.
+func (nd *NetData) CounterHistory(stRec, edRec int) ([]string, error) {
+	stRec, edRec, err := nd.recRange(stRec, edRec)
+	if err != nil {
+		return nil, err
+	}
+	ctrs := make([]string, edRec-stRec)
+	for ri := stRec; ri < edRec; ri++ {
+		ctrs[ri-stRec] = nd.Counters[nd.Ring.Index(ri)]
+	}
+	return ctrs, nil
+}
@@ -44,8 +44,12 @@ func (nv *NetView) UpdateLayers() {
 		layConfig.Add(types.For[xyz.Group](), ly.Label())
 	}
 
-	if !tree.Update(laysGp, layConfig) && nv.layerNameSizeShown == nv.Options.LayerNameSize {
+	layStates := nv.layerStates()
+	if !tree.Update(laysGp, layConfig) && nv.layerNameSizeShown == nv.Options.LayerNameSize && slices.Equal(nv.layerStatesShown, layStates) {
 		for li := range laysGp.Children {
+			if layStates[li] != layerShown {
+				continue
+			}
 			ly := nv.Net.EmerLayer(li)
 			lmesh := errors.Log1(se.MeshByName(ly.Label()))
 			se.SetMesh(lmesh) // does update
@@ -57,6 +61,7 @@ func (nv *NetView) UpdateLayers() {
 		return
 	}
 	nv.layerNameSizeShown = nv.Options.LayerNameSize
+	nv.layerStatesShown = layStates
 
 	gpConfig := tree.TypePlan{}
 	gpConfig.Add(types.For[LayObj](), "layer")
@@ -80,6 +85,8 @@ func (nv *NetView) UpdateLayers() {
 		lg := lgi.(*xyz.Group)
 		gpConfig[1].Name = ly.Label() // text2d textures use obj name, so must be unique
 		tree.Update(lg, gpConfig)
+		lst := layStates[li]
+		lg.Invisible = lst == layerHidden || lst == layerCollapsed
 		lp := lb.Pos.Pos
 		lp.Y = -lp.Y // reverse direction
 		lp = lp.Sub(nmin).Mul(nsc).Sub(poff)
@@ -95,13 +102,18 @@ func (nv *NetView) UpdateLayers() {
 		lo.Material.Reflective = 8
 		lo.Material.Bright = 8
 		lo.Material.Shiny = 30
+		lo.Invisible = lst == layerGroupName
 		// note: would actually be better to NOT cull back so you can view underneath
 		// but then the front and back fight against each other, causing flickering
 
 		txt := lg.Child(1).(*LayName)
 		txt.Defaults()
 		txt.NetView = nv
-		txt.SetText(ly.Label())
+		if lst == layerGroupName {
+			txt.SetText("[" + nv.layerGroupName(ly) + "]")
+		} else {
+			txt.SetText(ly.Label())
+		}
 		txt.Pose.Scale = math32.Vector3Scalar(nv.Options.LayerNameSize).Div(lg.Pose.Scale)
 		txt.Styles.Background = colors.Uniform(colors.Transparent)
 		txt.Styles.Text.Align = styles.Start
@@ -229,7 +241,7 @@ func (nv *NetView) UpdatePaths() {
 		npt := sl.NumSendPaths()
 		for pi := range npt {
 			pt := sl.SendPath(pi)
-			if !nv.pathTypeNameMatch(pt) {
+			if !nv.pathTypeNameMatch(pt) || !nv.LayerIsShown(sl) || !nv.LayerIsShown(pt.RecvLayer()) {
 				continue
 			}
 			rb := pt.RecvLayer().AsEmer()
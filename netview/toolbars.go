@@ -53,6 +53,7 @@ func (nv *NetView) MakeToolbar(p *tree.Plan) {
 			core.NewFuncButton(m).SetFunc(nv.ShowAllParams).SetIcon(icons.Info)
 			core.NewFuncButton(m).SetFunc(nv.ShowKeyLayerParams).SetIcon(icons.Info)
 			core.NewFuncButton(m).SetFunc(nv.ShowKeyPathParams).SetIcon(icons.Info)
+			core.NewFuncButton(m).SetFunc(nv.ShowDescription).SetIcon(icons.Info)
 		})
 	})
 	tree.Add(p, func(w *core.Button) {
@@ -61,9 +62,33 @@ func (nv *NetView) MakeToolbar(p *tree.Plan) {
 			core.NewFuncButton(m).SetFunc(nv.Data.OpenJSON).SetText("Open Net Data").SetIcon(icons.Open)
 			core.NewSeparator(m)
 			core.NewFuncButton(m).SetFunc(nv.PlotSelectedUnit).SetIcon(icons.Open)
+			fb := core.NewFuncButton(m).SetFunc(nv.DiffRecords)
+			fb.SetIcon(icons.Difference).SetTooltip("show the per-layer differences in a variable between two records, for the previous vs. current record by default")
+			fb.Args[0].Value = nv.Var
+			fb.Args[1].Value = max(nv.Data.Ring.Len-2, 0)
+			fb.Args[2].Value = -1
 		})
 	})
 	tree.Add(p, func(w *core.Separator) {})
+	tree.Add(p, func(w *core.Button) {
+		w.SetText("Layers").SetIcon(icons.Layers).SetMenu(func(m *core.Scene) {
+			core.NewFuncButton(m).SetFunc(nv.ShowAllLayers).SetIcon(icons.Visibility)
+			for _, lg := range nv.Options.LayerGroups {
+				core.NewSeparator(m)
+				hsw := core.NewSwitch(m).SetText("Hide " + lg.Name).SetChecked(lg.Hide)
+				hsw.OnChange(func(e events.Event) {
+					lg.Hide = hsw.IsChecked()
+					nv.UpdateView()
+				})
+				csw := core.NewSwitch(m).SetText("Collapse " + lg.Name).SetChecked(lg.Collapse)
+				csw.OnChange(func(e events.Event) {
+					lg.Collapse = csw.IsChecked()
+					nv.UpdateView()
+				})
+			}
+		})
+		w.SetTooltip("show, hide, and collapse groups of layers, which are configured in the Options along with individual layers to hide")
+	})
 	tree.Add(p, func(w *core.Switch) {
 		w.SetText("Paths").SetChecked(nv.Options.Paths).
 			SetTooltip("Toggles whether pathways between layers are shown or not").
@@ -170,19 +195,29 @@ func (nv *NetView) MakeToolbar(p *tree.Plan) {
 	tree.AddAt(p, "cmap", func(w *core.ColorMapButton) {
 		nv.ColorMapButton = w
 		w.MapName = string(nv.Options.ColorMap)
-		w.SetTooltip("Color map for translating values into colors -- click to select alternative.")
+		if vp.ColorMap != "" {
+			w.MapName = string(vp.ColorMap)
+		}
+		w.SetTooltip("Color map for translating values into colors for the current variable -- click to select alternative.")
 		w.Styler(func(s *styles.Style) {
 			s.Min.X.Em(10)
 			s.Min.Y.Em(1.2)
 			s.Grow.Set(0, 1)
 		})
 		w.OnChange(func(e events.Event) {
-			cmap, ok := colormap.AvailableMaps[string(nv.ColorMapButton.MapName)]
-			if ok {
-				nv.ColorMap = cmap
+			if _, ok := colormap.AvailableMaps[w.MapName]; ok {
+				if vp := nv.VarOptions[nv.Var]; vp != nil {
+					vp.ColorMap = core.ColorMapName(w.MapName)
+				}
 			}
 			nv.UpdateView()
 		})
+		w.Updater(func() {
+			w.MapName = string(nv.Options.ColorMap)
+			if vp := nv.VarOptions[nv.Var]; vp != nil && vp.ColorMap != "" {
+				w.MapName = string(vp.ColorMap)
+			}
+		})
 	})
 
 	tree.AddAt(p, "maxSwitch", func(w *core.Switch) {
@@ -245,6 +280,21 @@ func (nv *NetView) MakeToolbar(p *tree.Plan) {
 			}
 		})
 	})
+
+	tree.Add(p, func(w *core.Button) {
+		w.SetText("Var").SetIcon(icons.Settings).SetMenu(func(m *core.Scene) {
+			core.NewFuncButton(m).SetFunc(nv.CopyVarOptions).SetIcon(icons.Copy)
+			core.NewFuncButton(m).SetFunc(nv.PasteVarOptions).SetIcon(icons.Paste)
+			core.NewSeparator(m)
+			fb := core.NewFuncButton(m).SetFunc(nv.SaveVarOptions)
+			fb.SetIcon(icons.Save)
+			fb.Args[0].SetTag(`extension:".json"`)
+			fb = core.NewFuncButton(m).SetFunc(nv.OpenVarOptions)
+			fb.SetIcon(icons.Open)
+			fb.Args[0].SetTag(`extension:".json"`)
+		})
+		w.SetTooltip("copy and paste the display options (color map, range, zero centering) across variables, and save and open them as view settings files shared across sims")
+	})
 }
 
 func (nv *NetView) MakeViewbar(p *tree.Plan) {
@@ -0,0 +1,177 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package neurosig
+
+import (
+	"fmt"
+	"math"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+// BOLD has parameters for simulating the fMRI BOLD signal from the
+// aggregate activity of layers (or pools), by convolving it with the
+// canonical double-gamma hemodynamic response function (HRF), and
+// sampling the result once per TR (repetition time), to produce
+// signals comparable to fMRI data. The activity is first averaged
+// within MicroBins bins per TR, at which the HRF is computed.
+type BOLD struct {
+
+	// TR is the repetition time, i.e., the sampling interval of the
+	// BOLD signal, in seconds.
+	TR float64 `default:"2"`
+
+	// SampleRate is the number of samples of activity per second,
+	// which is 1000 for signals recorded every cycle, with cycles of 1 msec.
+	SampleRate float64 `default:"1000"`
+
+	// MicroBins is the number of time bins per TR over which the activity
+	// is averaged and convolved with the HRF, with the BOLD signal sampled
+	// at the middle bin of each TR.
+	MicroBins int `default:"16"`
+
+	// Peak is the time to the peak of the response gamma function, in seconds.
+	Peak float64 `default:"6"`
+
+	// Undershoot is the time to the peak of the undershoot gamma function,
+	// in seconds.
+	Undershoot float64 `default:"16"`
+
+	// Ratio is the ratio of the undershoot to the response.
+	Ratio float64 `default:"0.1667"`
+
+	// Length is the duration of the HRF kernel, in seconds.
+	Length float64 `default:"32"`
+}
+
+// NewBOLD returns a new [BOLD] for given TR, in seconds,
+// with default parameters.
+func NewBOLD(tr float64) *BOLD {
+	return &BOLD{TR: tr, SampleRate: 1000, MicroBins: 16, Peak: 6, Undershoot: 16, Ratio: 1.0 / 6, Length: 32}
+}
+
+// gammaPDF returns the gamma probability density at t,
+// with given shape and a scale of 1.
+func gammaPDF(t, shape float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	lg, _ := math.Lgamma(shape)
+	return math.Exp((shape-1)*math.Log(t) - t - lg)
+}
+
+// HRF returns the hemodynamic response function sampled every
+// given number of seconds, normalized to sum to 1.
+func (bd *BOLD) HRF(dt float64) []float64 {
+	n := int(bd.Length/dt) + 1
+	h := make([]float64, n)
+	sum := 0.0
+	for i := range h {
+		t := float64(i) * dt
+		h[i] = gammaPDF(t, bd.Peak) - bd.Ratio*gammaPDF(t, bd.Undershoot)
+		sum += h[i]
+	}
+	if sum != 0 {
+		for i := range h {
+			h[i] /= sum
+		}
+	}
+	return h
+}
+
+// Signal returns the simulated BOLD signal for given activity signal,
+// with one value per complete TR of the activity.
+func (bd *BOLD) Signal(x []float64) []float64 {
+	mb := max(bd.MicroBins, 1)
+	spb := bd.SampleRate * bd.TR / float64(mb) // samples per bin
+	nv := int(float64(len(x)) / (spb * float64(mb)))
+	nb := nv * mb
+	bins := make([]float64, nb)
+	cnt := make([]int, nb)
+	for i, v := range x {
+		bi := int(float64(i) / spb)
+		if bi >= nb {
+			break
+		}
+		if math.IsNaN(v) {
+			continue
+		}
+		bins[bi] += v
+		cnt[bi]++
+	}
+	for bi := range bins {
+		if cnt[bi] > 0 {
+			bins[bi] /= float64(cnt[bi])
+		}
+	}
+	hrf := bd.HRF(bd.TR / float64(mb))
+	bold := make([]float64, nv)
+	for vi := range bold {
+		bi := vi*mb + mb/2
+		sum := 0.0
+		for k, h := range hrf {
+			if k > bi {
+				break
+			}
+			sum += h * bins[bi-k]
+		}
+		bold[vi] = sum
+	}
+	return bold
+}
+
+// Signals returns the simulated BOLD signals for given activity signals
+// (e.g., the layers of a [Recorder]).
+func (bd *BOLD) Signals(signals [][]float64) [][]float64 {
+	bold := make([][]float64, len(signals))
+	for i, x := range signals {
+		bold[i] = bd.Signal(x)
+	}
+	return bold
+}
+
+// Table returns a region x time table of the simulated BOLD signals of
+// the activity in given columns of given table (e.g., from
+// [Recorder.Table], or a cycle-level log), with one row per TR,
+// and columns: Volume, Time (in seconds, at the middle of the TR),
+// and a column for each region, named as the activity column.
+func (bd *BOLD) Table(dt *table.Table, cols ...string) (*table.Table, error) {
+	signals := make([][]float64, len(cols))
+	for i, cn := range cols {
+		cl := dt.Column(cn)
+		if cl == nil {
+			return nil, fmt.Errorf("neurosig.BOLD: column %q not found", cn)
+		}
+		x := make([]float64, dt.NumRows())
+		for ri := range x {
+			x[ri] = cl.FloatRow(ri, 0)
+		}
+		signals[i] = x
+	}
+	bold := bd.Signals(signals)
+	nv := 0
+	if len(bold) > 0 {
+		nv = len(bold[0])
+	}
+	bt := table.New()
+	metadata.SetName(bt, "BOLD")
+	tensor.SetPrecision(bt, 4)
+	bt.AddIntColumn("Volume")
+	bt.AddFloat64Column("Time")
+	for _, cn := range cols {
+		bt.AddFloat64Column(cn)
+	}
+	bt.SetNumRows(nv)
+	for vi := range nv {
+		bt.Column("Volume").SetFloatRow(float64(vi), vi, 0)
+		bt.Column("Time").SetFloatRow((float64(vi)+0.5)*bd.TR, vi, 0)
+		for i, cn := range cols {
+			bt.Column(cn).SetFloatRow(bold[i][vi], vi, 0)
+		}
+	}
+	return bt, nil
+}
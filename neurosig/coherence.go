@@ -0,0 +1,218 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package neurosig
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+// Coherence has parameters for computing the frequency-resolved coupling
+// between signals, in terms of the phase-locking value (PLV) and the
+// magnitude-squared coherence, using a complex Morlet wavelet transform
+// of each signal at each of the Freqs. Samples within half a wavelet of
+// either end of the signals are excluded, if the signals are long enough.
+type Coherence struct {
+
+	// Freqs are the frequencies to analyze, in Hz.
+	Freqs []float64
+
+	// SampleRate is the number of samples per second, which is 1000
+	// for signals recorded every cycle, with cycles of 1 msec.
+	SampleRate float64 `default:"1000"`
+
+	// Cycles is the number of oscillation cycles in the standard deviation
+	// of the wavelets, times 2 pi, which trades off temporal resolution
+	// (fewer) against frequency resolution (more).
+	Cycles float64 `default:"5"`
+}
+
+// NewCoherence returns a new [Coherence] for given frequencies,
+// with default parameters.
+func NewCoherence(freqs ...float64) *Coherence {
+	return &Coherence{Freqs: freqs, SampleRate: 1000, Cycles: 5}
+}
+
+// wavelet returns the complex Morlet wavelet for given frequency,
+// which extends 3 standard deviations on either side of 0.
+func (ch *Coherence) wavelet(freq float64) []complex128 {
+	sd := ch.Cycles / (2 * math.Pi * freq) * ch.SampleRate // in samples
+	hw := int(math.Ceil(3 * sd))
+	w := make([]complex128, 2*hw+1)
+	norm := 0.0
+	for i := range w {
+		t := float64(i - hw)
+		g := math.Exp(-t * t / (2 * sd * sd))
+		w[i] = complex(g, 0) * cmplx.Exp(complex(0, 2*math.Pi*freq*t/ch.SampleRate))
+		norm += g
+	}
+	for i := range w {
+		w[i] /= complex(norm, 0)
+	}
+	return w
+}
+
+// Transform returns the complex Morlet wavelet transform of given signal
+// at each of the Freqs, after subtracting the mean of the signal,
+// with the same number of samples as the signal, and the index range
+// of the samples that are not affected by the ends of the signal,
+// for the lowest frequency whose wavelet fits within the signal.
+func (ch *Coherence) Transform(x []float64) (wt [][]complex128, st, ed int) {
+	n := len(x)
+	m := 0.0
+	for _, v := range x {
+		m += v
+	}
+	if n > 0 {
+		m /= float64(n)
+	}
+	st, ed = 0, n
+	wt = make([][]complex128, len(ch.Freqs))
+	for fi, f := range ch.Freqs {
+		w := ch.wavelet(f)
+		hw := len(w) / 2
+		if n > 2*hw && hw > st {
+			st, ed = hw, n-hw
+		}
+		out := make([]complex128, n)
+		for t := range n {
+			var sum complex128
+			for k := range w {
+				si := t + hw - k
+				if si < 0 || si >= n {
+					continue
+				}
+				sum += complex(x[si]-m, 0) * w[k]
+			}
+			out[t] = sum
+		}
+		wt[fi] = out
+	}
+	return
+}
+
+// coupling returns the PLV and coherence from given wavelet transforms
+// of two signals, over given range of samples.
+func coupling(wx, wy []complex128, st, ed int) (plv, coh float64) {
+	var cross, phase complex128
+	px, py := 0.0, 0.0
+	n := 0
+	for t := st; t < ed; t++ {
+		c := wx[t] * cmplx.Conj(wy[t])
+		cross += c
+		px += real(wx[t] * cmplx.Conj(wx[t]))
+		py += real(wy[t] * cmplx.Conj(wy[t]))
+		if a := cmplx.Abs(c); a > 0 {
+			phase += c / complex(a, 0)
+			n++
+		}
+	}
+	if n == 0 || px == 0 || py == 0 {
+		return math.NaN(), math.NaN()
+	}
+	plv = cmplx.Abs(phase) / float64(n)
+	ac := cmplx.Abs(cross)
+	coh = ac * ac / (px * py)
+	return
+}
+
+// PLV returns the phase-locking value between the two given signals at
+// each of the Freqs, which is the magnitude of the mean over time of the
+// unit vector of their phase difference, from 0 (no consistent phase
+// relationship) to 1 (constant phase difference).
+func (ch *Coherence) PLV(x, y []float64) []float64 {
+	plv, _ := ch.Pair(x, y)
+	return plv
+}
+
+// Pair returns the phase-locking value and the magnitude-squared
+// coherence between the two given signals at each of the Freqs.
+// Coherence is the squared magnitude of the cross-spectrum, normalized
+// by the power of each signal, so that, unlike the PLV, it is weighted
+// by the amplitude, from 0 to 1.
+func (ch *Coherence) Pair(x, y []float64) (plv, coh []float64) {
+	wx, st, ed := ch.Transform(x)
+	wy, _, _ := ch.Transform(y[:min(len(x), len(y))])
+	ed = min(ed, len(y))
+	plv = make([]float64, len(ch.Freqs))
+	coh = make([]float64, len(ch.Freqs))
+	for fi := range ch.Freqs {
+		plv[fi], coh[fi] = coupling(wx[fi], wy[fi], st, ed)
+	}
+	return
+}
+
+// Matrices returns the frequency-resolved coupling matrices between all
+// pairs of given signals (e.g., the layers of a [Recorder]), with shape
+// [Freqs, signals, signals], for the phase-locking value and coherence.
+// The diagonal is 1.
+func (ch *Coherence) Matrices(signals [][]float64) (plv, coh *tensor.Float64) {
+	nf, ns := len(ch.Freqs), len(signals)
+	plv = tensor.NewFloat64(nf, ns, ns)
+	coh = tensor.NewFloat64(nf, ns, ns)
+	wts := make([][][]complex128, ns)
+	st, ed := 0, math.MaxInt
+	for si, x := range signals {
+		var s, e int
+		wts[si], s, e = ch.Transform(x)
+		st, ed = max(st, s), min(ed, e)
+	}
+	for fi := range nf {
+		for i := range ns {
+			plv.Set(1, fi, i, i)
+			coh.Set(1, fi, i, i)
+			for j := range i {
+				p, c := coupling(wts[i][fi], wts[j][fi], st, ed)
+				plv.Set(p, fi, i, j)
+				plv.Set(p, fi, j, i)
+				coh.Set(c, fi, i, j)
+				coh.Set(c, fi, j, i)
+			}
+		}
+	}
+	return
+}
+
+// Table returns a table of the frequency-resolved coupling between all
+// pairs of the signals in given columns of given table (e.g., from
+// [Recorder.Table], or a cycle-level log), with one row per frequency,
+// and columns: Freq, and PLV and Coherence with the [signals, signals]
+// coupling matrices, with the signals in the order of the columns.
+func (ch *Coherence) Table(dt *table.Table, cols ...string) (*table.Table, error) {
+	signals := make([][]float64, len(cols))
+	for i, cn := range cols {
+		cl := dt.Column(cn)
+		if cl == nil {
+			return nil, fmt.Errorf("neurosig.Coherence: column %q not found", cn)
+		}
+		x := make([]float64, dt.NumRows())
+		for ri := range x {
+			x[ri] = cl.FloatRow(ri, 0)
+		}
+		signals[i] = x
+	}
+	plv, coh := ch.Matrices(signals)
+	nf, ns := len(ch.Freqs), len(cols)
+	ct := table.New()
+	metadata.SetName(ct, "Coherence")
+	tensor.SetPrecision(ct, 4)
+	ct.AddFloat64Column("Freq")
+	ct.AddFloat64Column("PLV", ns, ns)
+	ct.AddFloat64Column("Coherence", ns, ns)
+	ct.SetNumRows(nf)
+	for fi, f := range ch.Freqs {
+		ct.Column("Freq").SetFloatRow(f, fi, 0)
+		for i := range ns * ns {
+			ct.Column("PLV").SetFloatRow(plv.Float1D(fi*ns*ns+i), fi, i)
+			ct.Column("Coherence").SetFloatRow(coh.Float1D(fi*ns*ns+i), fi, i)
+		}
+	}
+	return ct, nil
+}
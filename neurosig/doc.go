@@ -0,0 +1,15 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package neurosig computes simulated neural signals and their analyses
+from the aggregate activity of layers recorded on each cycle, for
+comparing models with neural data, including the coherence and
+phase-locking between layers, for models of inter-areal communication,
+EEG / MEG-like event-related potentials, and the simulated fMRI BOLD
+signal of each layer.
+*/
+package neurosig
+
+//go:generate core generate -add-types
@@ -0,0 +1,230 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package neurosig
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// ERP extracts EEG / MEG-like event-related potential (ERP) waveforms,
+// as a proxy for the summed synaptic currents of given layers, in terms
+// of the sum over their units of a conductance variable (Ge by default),
+// recorded on each cycle and averaged over trials, time-locked to the
+// start of each trial, separately for each condition.
+// Call StartTrial at the start of each trial, and Record on each cycle.
+type ERP struct {
+
+	// Var is the unit variable that is summed, as a proxy for the
+	// synaptic currents.
+	Var string
+
+	// Layers are the names of the layers that contribute to the signal.
+	Layers []string
+
+	// Weights are the weights of the contribution of each layer, e.g.,
+	// with a negative sign for layers whose dipoles are oriented in the
+	// opposite direction relative to a sensor. Layers without a weight
+	// have a weight of 1.
+	Weights []float64
+
+	// Baseline is the number of cycles at the start of each trial whose
+	// mean is subtracted from the waveforms, if > 0.
+	Baseline int
+
+	// Conds are the conditions, in the order of their first trial.
+	Conds []string `edit:"-"`
+
+	// Diffs are the difference waves to compute.
+	Diffs []ERPDiff
+
+	// sums are the sums over trials of the signal, per condition and cycle.
+	sums [][]float64
+
+	// counts are the numbers of trials recorded, per condition and cycle.
+	counts [][]int
+
+	// cond is the index of the condition of the current trial.
+	cond int
+
+	// cycle is the cycle within the current trial.
+	cycle int
+
+	// vals are the unit values for a layer.
+	vals []float32
+}
+
+// ERPDiff is a difference wave between the ERPs of two conditions.
+type ERPDiff struct {
+
+	// Name is the name of the difference wave.
+	Name string
+
+	// A is the condition whose ERP the ERP of B is subtracted from.
+	A string
+
+	// B is the condition whose ERP is subtracted.
+	B string
+}
+
+// NewERP returns a new [ERP] of the Ge variable of given layers.
+func NewERP(layers ...string) *ERP {
+	return &ERP{Var: "Ge", Layers: layers, cond: -1}
+}
+
+// AddDiff adds a difference wave of given name, for the ERP of
+// condition a minus that of condition b.
+func (ep *ERP) AddDiff(name, a, b string) {
+	ep.Diffs = append(ep.Diffs, ERPDiff{Name: name, A: a, B: b})
+}
+
+// Reset resets all of the recorded trials and conditions.
+func (ep *ERP) Reset() {
+	ep.Conds = nil
+	ep.sums = nil
+	ep.counts = nil
+	ep.cond = -1
+}
+
+// StartTrial starts a new trial of given condition,
+// with subsequent calls to Record starting at cycle 0.
+func (ep *ERP) StartTrial(cond string) {
+	ep.cond = slices.Index(ep.Conds, cond)
+	if ep.cond < 0 {
+		ep.cond = len(ep.Conds)
+		ep.Conds = append(ep.Conds, cond)
+		ep.sums = append(ep.sums, nil)
+		ep.counts = append(ep.counts, nil)
+	}
+	ep.cycle = 0
+}
+
+// Record records the weighted sum of the unit variable over the layers
+// in given network, for given data parallel index, at the current cycle
+// of the current trial, skipping NaN values.
+func (ep *ERP) Record(net emer.Network, di int) error {
+	if ep.cond < 0 {
+		return fmt.Errorf("neurosig.ERP: StartTrial must be called before Record")
+	}
+	sum := 0.0
+	for li, lnm := range ep.Layers {
+		ly, err := net.AsEmer().EmerLayerByName(lnm)
+		if err != nil {
+			return fmt.Errorf("neurosig.ERP: %w", err)
+		}
+		if err := ly.AsEmer().UnitValues(&ep.vals, ep.Var, di); err != nil {
+			return err
+		}
+		ls := 0.0
+		for _, v := range ep.vals {
+			if !math.IsNaN(float64(v)) {
+				ls += float64(v)
+			}
+		}
+		if li < len(ep.Weights) {
+			ls *= ep.Weights[li]
+		}
+		sum += ls
+	}
+	ci := ep.cond
+	if ep.cycle >= len(ep.sums[ci]) {
+		ep.sums[ci] = append(ep.sums[ci], 0)
+		ep.counts[ci] = append(ep.counts[ci], 0)
+	}
+	ep.sums[ci][ep.cycle] += sum
+	ep.counts[ci][ep.cycle]++
+	ep.cycle++
+	return nil
+}
+
+// Trials returns the number of trials recorded for given condition.
+func (ep *ERP) Trials(cond string) int {
+	ci := slices.Index(ep.Conds, cond)
+	if ci < 0 || len(ep.counts[ci]) == 0 {
+		return 0
+	}
+	return ep.counts[ci][0]
+}
+
+// Waveform returns the ERP of given condition, which is the mean over
+// trials at each cycle, minus the mean of the Baseline cycles,
+// or nil if the condition has not been recorded.
+func (ep *ERP) Waveform(cond string) []float64 {
+	ci := slices.Index(ep.Conds, cond)
+	if ci < 0 {
+		return nil
+	}
+	wv := make([]float64, len(ep.sums[ci]))
+	for i, s := range ep.sums[ci] {
+		wv[i] = s / float64(ep.counts[ci][i])
+	}
+	if nb := min(ep.Baseline, len(wv)); nb > 0 {
+		base := 0.0
+		for _, v := range wv[:nb] {
+			base += v
+		}
+		base /= float64(nb)
+		for i := range wv {
+			wv[i] -= base
+		}
+	}
+	return wv
+}
+
+// Difference returns the difference wave of the ERP of condition a
+// minus that of condition b, over the cycles recorded for both.
+func (ep *ERP) Difference(a, b string) []float64 {
+	wa, wb := ep.Waveform(a), ep.Waveform(b)
+	df := make([]float64, min(len(wa), len(wb)))
+	for i := range df {
+		df[i] = wa[i] - wb[i]
+	}
+	return df
+}
+
+// Table returns a table of the ERP waveforms, with one row per cycle,
+// and columns: Cycle, and a column for the ERP of each condition,
+// and for each of the Diffs, which are NaN beyond the cycles recorded.
+func (ep *ERP) Table() *table.Table {
+	waves := make([][]float64, 0, len(ep.Conds)+len(ep.Diffs))
+	names := make([]string, 0, cap(waves))
+	for _, cn := range ep.Conds {
+		waves = append(waves, ep.Waveform(cn))
+		names = append(names, cn)
+	}
+	for _, df := range ep.Diffs {
+		waves = append(waves, ep.Difference(df.A, df.B))
+		names = append(names, df.Name)
+	}
+	n := 0
+	for _, wv := range waves {
+		n = max(n, len(wv))
+	}
+	dt := table.New()
+	metadata.SetName(dt, "ERP")
+	tensor.SetPrecision(dt, 4)
+	dt.AddIntColumn("Cycle")
+	for _, nm := range names {
+		dt.AddFloat64Column(nm)
+	}
+	dt.SetNumRows(n)
+	for i := range n {
+		dt.Column("Cycle").SetFloatRow(float64(i), i, 0)
+		for wi, wv := range waves {
+			v := math.NaN()
+			if i < len(wv) {
+				v = wv[i]
+			}
+			dt.Column(names[wi]).SetFloatRow(v, i, 0)
+		}
+	}
+	return dt
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package neurosig
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/hebb"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+// sine returns n samples of a sine wave at given frequency and phase,
+// at 1000 samples per second, plus uniform noise of given amplitude.
+func sine(n int, freq, phase, noise float64, rnd *rand.Rand) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Sin(2*math.Pi*freq*float64(i)/1000+phase) + noise*(2*rnd.Float64()-1)
+	}
+	return x
+}
+
+func TestCoherence(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	ch := NewCoherence(10, 40)
+	// x and y are both 40 Hz with a fixed phase lag, and z is 10 Hz
+	x := sine(1000, 40, 0, 0.5, rnd)
+	y := sine(1000, 40, 1, 0.5, rnd)
+	z := sine(1000, 10, 0, 0.5, rnd)
+	for i := range z {
+		z[i] += 0.1 * rnd.NormFloat64()
+	}
+	plv, coh := ch.Pair(x, y)
+	assert.Less(t, plv[0], 0.5)
+	assert.Greater(t, plv[1], 0.95)
+	assert.Greater(t, coh[1], 0.9)
+
+	// independent noise has low coupling
+	n1, n2 := sine(1000, 40, 0, 0, rnd), sine(1000, 40, 0, 0, rnd)
+	for i := range n1 {
+		n1[i], n2[i] = rnd.NormFloat64(), rnd.NormFloat64()
+	}
+	plv, coh = ch.Pair(n1, n2)
+	assert.Less(t, plv[1], 0.3)
+	assert.Less(t, coh[1], 0.1)
+
+	pm, cm := ch.Matrices([][]float64{x, y, z})
+	assert.Equal(t, []int{2, 3, 3}, pm.Shape().Sizes)
+	assert.Equal(t, 1.0, pm.Value(1, 2, 2))
+	assert.Equal(t, pm.Value(1, 0, 1), pm.Value(1, 1, 0))
+	assert.Greater(t, pm.Value(1, 0, 1), 0.95)
+	assert.Less(t, cm.Value(1, 0, 2), 0.1)
+
+	rc := &Recorder{Names: []string{"X", "Y", "Z"}, Signals: [][]float64{x, y, z}}
+	ct, err := ch.Table(rc.Table(), "X", "Y", "Z")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ct.NumRows())
+	assert.Equal(t, 40.0, ct.Column("Freq").FloatRow(1, 0))
+	assert.Equal(t, pm.Value(1, 0, 1), ct.Column("PLV").FloatRow(1, 1))
+	assert.Equal(t, cm.Value(1, 0, 2), ct.Column("Coherence").FloatRow(1, 2))
+	_, err = ch.Table(rc.Table(), "X", "W")
+	assert.Error(t, err)
+}
+
+func TestRecorder(t *testing.T) {
+	net := hebb.NewNetwork("Rec")
+	in := net.AddLayer("Input", []int{2, 2, 1, 2}, hebb.InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 2, hebb.HiddenLayer)
+	net.ConnectLayers(in, hid, paths.NewFull())
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+
+	rc := NewRecorder("Input", "Hidden")
+	rc.Pools = true
+	assert.NoError(t, rc.Init(net))
+	assert.Equal(t, []string{"Input_0", "Input_1", "Input_2", "Input_3", "Hidden"}, rc.Names)
+	for range 3 {
+		net.ApplyExt("Input", tensor.NewFloat32FromValues(1, 1, 0, 1, 0, 0, 1, 0))
+		net.Cycle(ctx)
+		assert.NoError(t, rc.Record(net, 0))
+	}
+	assert.Equal(t, 3, rc.Len())
+	assert.Equal(t, []float64{1, 1, 1}, rc.Signal("Input_0"))
+	assert.Equal(t, []float64{0.5, 0.5, 0.5}, rc.Signal("Input_1"))
+	assert.Equal(t, []float64{0, 0, 0}, rc.Signal("Input_2"))
+	assert.Equal(t, []float64{0.5, 0.5, 0.5}, rc.Signal("Hidden"))
+	dt := rc.Table()
+	assert.Equal(t, 3, dt.NumRows())
+	assert.Equal(t, 0.5, dt.Column("Input_3").FloatRow(2, 0))
+
+	rc = NewRecorder("Missing")
+	assert.Error(t, rc.Init(net))
+}
+
+func TestBOLD(t *testing.T) {
+	bd := NewBOLD(1)
+	hrf := bd.HRF(0.1)
+	sum := 0.0
+	pk := 0
+	for i, h := range hrf {
+		sum += h
+		if h > hrf[pk] {
+			pk = i
+		}
+	}
+	assert.InDelta(t, 1, sum, 1.0e-9)
+	assert.InDelta(t, 5, float64(pk)*0.1, 0.5)
+	assert.Less(t, hrf[150], 0.0) // undershoot
+
+	// brief burst of activity at the start, and sustained activity
+	burst := make([]float64, 20000)
+	sust := make([]float64, 60500)
+	for i := range 200 {
+		burst[i] = 1
+	}
+	for i := range sust {
+		sust[i] = 0.5
+	}
+	bs := bd.Signal(burst)
+	assert.Equal(t, 20, len(bs))
+	pv := 0
+	for i, v := range bs {
+		if v > bs[pv] {
+			pv = i
+		}
+	}
+	assert.Equal(t, 5, pv)
+	assert.Less(t, bs[15], 0.0)
+	ss := bd.Signal(sust)
+	assert.Equal(t, 60, len(ss))
+	assert.Less(t, ss[1], 0.1)
+	assert.InDelta(t, 0.5, ss[59], 1.0e-3)
+
+	rc := &Recorder{Names: []string{"Burst", "Sustained"}, Signals: [][]float64{burst, sust[:20000]}}
+	bt, err := bd.Table(rc.Table(), "Burst", "Sustained")
+	assert.NoError(t, err)
+	assert.Equal(t, 20, bt.NumRows())
+	assert.Equal(t, 5.5, bt.Column("Time").FloatRow(5, 0))
+	assert.Equal(t, bs[5], bt.Column("Burst").FloatRow(5, 0))
+	assert.Equal(t, ss[10], bt.Column("Sustained").FloatRow(10, 0))
+	_, err = bd.Table(rc.Table(), "Missing")
+	assert.Error(t, err)
+}
+
+func TestERP(t *testing.T) {
+	net := hebb.NewNetwork("ERP")
+	in := net.AddLayer2D("Input", 1, 4, hebb.InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 2, hebb.HiddenLayer)
+	pt := net.ConnectLayers(in, hid, paths.NewFull())
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+	for i := range pt.Wts {
+		pt.Wts[i] = 0.5
+	}
+
+	ep := NewERP("Hidden")
+	ep.Baseline = 2
+	ep.AddDiff("Strong-Weak", "Strong", "Weak")
+	assert.Error(t, ep.Record(net, 0))
+	trial := func(cond string, inp []float32, ncyc int) {
+		ep.StartTrial(cond)
+		net.InitExt()
+		for cyc := range ncyc {
+			if cyc == 2 { // stimulus onset
+				net.ApplyInput("Input", inp)
+			}
+			net.Cycle(ctx)
+			assert.NoError(t, ep.Record(net, 0))
+		}
+	}
+	for range 3 {
+		trial("Strong", []float32{1, 1, 1, 1}, 4)
+		trial("Weak", []float32{1, 0, 0, 0}, 5)
+	}
+	assert.Equal(t, []string{"Strong", "Weak"}, ep.Conds)
+	assert.Equal(t, 3, ep.Trials("Weak"))
+	assert.Equal(t, 0, ep.Trials("Missing"))
+	// 2 hidden units each receive 0.5 per active input unit
+	assert.Equal(t, []float64{0, 0, 4, 4}, ep.Waveform("Strong"))
+	assert.Equal(t, []float64{0, 0, 1, 1, 1}, ep.Waveform("Weak"))
+	assert.Equal(t, []float64{0, 0, 3, 3}, ep.Difference("Strong", "Weak"))
+
+	dt := ep.Table()
+	assert.Equal(t, 5, dt.NumRows())
+	assert.Equal(t, 4.0, dt.Column("Strong").FloatRow(3, 0))
+	assert.True(t, math.IsNaN(dt.Column("Strong").FloatRow(4, 0)))
+	assert.Equal(t, 3.0, dt.Column("Strong-Weak").FloatRow(2, 0))
+
+	ep.Weights = []float64{-1}
+	trial("Strong", []float32{1, 1, 1, 1}, 4)
+	assert.Equal(t, 4, ep.Trials("Strong"))
+	assert.Equal(t, []float64{0, 0, 2, 2}, ep.Waveform("Strong"))
+	ep.Reset()
+	assert.Nil(t, ep.Waveform("Strong"))
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package neurosig
+
+import (
+	"fmt"
+	"math"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// Recorder records the mean activity of layers, or of each pool of
+// 4D layers, as a signal with one value per call to Record,
+// which is typically called on every cycle.
+type Recorder struct {
+
+	// Var is the unit variable that is averaged.
+	Var string
+
+	// Layers are the names of the layers to record.
+	Layers []string
+
+	// Pools records the mean of each pool of 4D layers separately,
+	// as a signal named Layer_Pool, where Pool is the pool index.
+	Pools bool
+
+	// Names are the names of the signals, set by Init.
+	Names []string `edit:"-"`
+
+	// Signals are the recorded signals, in the order of the Names.
+	Signals [][]float64 `display:"-"`
+
+	// vals are the unit values for a layer.
+	vals []float32
+}
+
+// NewRecorder returns a new [Recorder] of the Act variable
+// of given layers.
+func NewRecorder(layers ...string) *Recorder {
+	return &Recorder{Var: "Act", Layers: layers}
+}
+
+// Init sets the signal names for given network, checking that the layers
+// exist, and resets the signals.
+func (rc *Recorder) Init(net emer.Network) error {
+	rc.Names = rc.Names[:0]
+	for _, lnm := range rc.Layers {
+		ly, err := net.AsEmer().EmerLayerByName(lnm)
+		if err != nil {
+			return fmt.Errorf("neurosig.Recorder: %w", err)
+		}
+		if _, err := emer.UnitVarIndex(ly, rc.Var); err != nil {
+			return fmt.Errorf("neurosig.Recorder: layer %q: %w", lnm, err)
+		}
+		lb := ly.AsEmer()
+		if !rc.Pools || !lb.Is4D() {
+			rc.Names = append(rc.Names, lnm)
+			continue
+		}
+		for pi := range lb.NumPools() {
+			rc.Names = append(rc.Names, fmt.Sprintf("%s_%d", lnm, pi))
+		}
+	}
+	rc.Reset()
+	return nil
+}
+
+// Reset resets the recorded signals.
+func (rc *Recorder) Reset() {
+	rc.Signals = make([][]float64, len(rc.Names))
+}
+
+// Len returns the number of recorded values of each signal.
+func (rc *Recorder) Len() int {
+	if len(rc.Signals) == 0 {
+		return 0
+	}
+	return len(rc.Signals[0])
+}
+
+// Record records the mean activity of each layer (or pool)
+// in given network, for given data parallel index,
+// skipping NaN values. Init must have been called.
+func (rc *Recorder) Record(net emer.Network, di int) error {
+	if len(rc.Names) == 0 && len(rc.Layers) > 0 {
+		if err := rc.Init(net); err != nil {
+			return err
+		}
+	}
+	si := 0
+	for _, lnm := range rc.Layers {
+		ly, err := net.AsEmer().EmerLayerByName(lnm)
+		if err != nil {
+			return fmt.Errorf("neurosig.Recorder: %w", err)
+		}
+		lb := ly.AsEmer()
+		if err := lb.UnitValues(&rc.vals, rc.Var, di); err != nil {
+			return err
+		}
+		np, pn := 1, len(rc.vals)
+		if rc.Pools && lb.Is4D() {
+			np = lb.NumPools()
+			pn = len(rc.vals) / np
+		}
+		for pi := range np {
+			rc.Signals[si] = append(rc.Signals[si], mean(rc.vals[pi*pn:(pi+1)*pn]))
+			si++
+		}
+	}
+	return nil
+}
+
+// Signal returns the recorded signal of given name, or nil if not found.
+func (rc *Recorder) Signal(name string) []float64 {
+	for i, nm := range rc.Names {
+		if nm == name {
+			return rc.Signals[i]
+		}
+	}
+	return nil
+}
+
+// Table returns a table of the recorded signals, with a Cycle column
+// and a column for each signal, with one row per recorded value.
+func (rc *Recorder) Table() *table.Table {
+	dt := table.New()
+	metadata.SetName(dt, "Signals")
+	tensor.SetPrecision(dt, 4)
+	n := rc.Len()
+	dt.AddIntColumn("Cycle")
+	for _, nm := range rc.Names {
+		dt.AddFloat64Column(nm)
+	}
+	dt.SetNumRows(n)
+	for i := range n {
+		dt.Column("Cycle").SetFloatRow(float64(i), i, 0)
+		for si, nm := range rc.Names {
+			dt.Column(nm).SetFloatRow(rc.Signals[si][i], i, 0)
+		}
+	}
+	return dt
+}
+
+// mean returns the mean of given values, skipping NaN values.
+func mean(vals []float32) float64 {
+	sum := 0.0
+	n := 0
+	for _, v := range vals {
+		if math.IsNaN(float64(v)) {
+			continue
+		}
+		sum += float64(v)
+		n++
+	}
+	if n == 0 {
+		return math.NaN()
+	}
+	return sum / float64(n)
+}
@@ -0,0 +1,17 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package neurosig
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/neurosig.BOLD", IDName: "bold", Doc: "BOLD has parameters for simulating the fMRI BOLD signal from the\naggregate activity of layers (or pools), by convolving it with the\ncanonical double-gamma hemodynamic response function (HRF), and\nsampling the result once per TR (repetition time), to produce\nsignals comparable to fMRI data. The activity is first averaged\nwithin MicroBins bins per TR, at which the HRF is computed.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Fields: []types.Field{{Name: "TR", Doc: "TR is the repetition time, i.e., the sampling interval of the\nBOLD signal, in seconds."}, {Name: "SampleRate", Doc: "SampleRate is the number of samples of activity per second,\nwhich is 1000 for signals recorded every cycle, with cycles of 1 msec."}, {Name: "MicroBins", Doc: "MicroBins is the number of time bins per TR over which the activity\nis averaged and convolved with the HRF, with the BOLD signal sampled\nat the middle bin of each TR."}, {Name: "Peak", Doc: "Peak is the time to the peak of the response gamma function, in seconds."}, {Name: "Undershoot", Doc: "Undershoot is the time to the peak of the undershoot gamma function,\nin seconds."}, {Name: "Ratio", Doc: "Ratio is the ratio of the undershoot to the response."}, {Name: "Length", Doc: "Length is the duration of the HRF kernel, in seconds."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/neurosig.Coherence", IDName: "coherence", Doc: "Coherence has parameters for computing the frequency-resolved coupling\nbetween signals, in terms of the phase-locking value (PLV) and the\nmagnitude-squared coherence, using a complex Morlet wavelet transform\nof each signal at each of the Freqs. Samples within half a wavelet of\neither end of the signals are excluded, if the signals are long enough.", Fields: []types.Field{{Name: "Freqs", Doc: "Freqs are the frequencies to analyze, in Hz."}, {Name: "SampleRate", Doc: "SampleRate is the number of samples per second, which is 1000\nfor signals recorded every cycle, with cycles of 1 msec."}, {Name: "Cycles", Doc: "Cycles is the number of oscillation cycles in the standard deviation\nof the wavelets, times 2 pi, which trades off temporal resolution\n(fewer) against frequency resolution (more)."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/neurosig.ERP", IDName: "erp", Doc: "ERP extracts EEG / MEG-like event-related potential (ERP) waveforms,\nas a proxy for the summed synaptic currents of given layers, in terms\nof the sum over their units of a conductance variable (Ge by default),\nrecorded on each cycle and averaged over trials, time-locked to the\nstart of each trial, separately for each condition.\nCall StartTrial at the start of each trial, and Record on each cycle.", Fields: []types.Field{{Name: "Var", Doc: "Var is the unit variable that is summed, as a proxy for the\nsynaptic currents."}, {Name: "Layers", Doc: "Layers are the names of the layers that contribute to the signal."}, {Name: "Weights", Doc: "Weights are the weights of the contribution of each layer, e.g.,\nwith a negative sign for layers whose dipoles are oriented in the\nopposite direction relative to a sensor. Layers without a weight\nhave a weight of 1."}, {Name: "Baseline", Doc: "Baseline is the number of cycles at the start of each trial whose\nmean is subtracted from the waveforms, if > 0."}, {Name: "Conds", Doc: "Conds are the conditions, in the order of their first trial."}, {Name: "Diffs", Doc: "Diffs are the difference waves to compute."}, {Name: "sums", Doc: "sums are the sums over trials of the signal, per condition and cycle."}, {Name: "counts", Doc: "counts are the numbers of trials recorded, per condition and cycle."}, {Name: "cond", Doc: "cond is the index of the condition of the current trial."}, {Name: "cycle", Doc: "cycle is the cycle within the current trial."}, {Name: "vals", Doc: "vals are the unit values for a layer."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/neurosig.ERPDiff", IDName: "erp-diff", Doc: "ERPDiff is a difference wave between the ERPs of two conditions.", Fields: []types.Field{{Name: "Name", Doc: "Name is the name of the difference wave."}, {Name: "A", Doc: "A is the condition whose ERP the ERP of B is subtracted from."}, {Name: "B", Doc: "B is the condition whose ERP is subtracted."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/neurosig.Recorder", IDName: "recorder", Doc: "Recorder records the mean activity of layers, or of each pool of\n4D layers, as a signal with one value per call to Record,\nwhich is typically called on every cycle.", Fields: []types.Field{{Name: "Var", Doc: "Var is the unit variable that is averaged."}, {Name: "Layers", Doc: "Layers are the names of the layers to record."}, {Name: "Pools", Doc: "Pools records the mean of each pool of 4D layers separately,\nas a signal named Layer_Pool, where Pool is the pool index."}, {Name: "Names", Doc: "Names are the names of the signals, set by Init."}, {Name: "Signals", Doc: "Signals are the recorded signals, in the order of the Names."}, {Name: "vals", Doc: "vals are the unit values for a layer."}}})
@@ -5,10 +5,9 @@
 package params
 
 import (
-	"errors"
-	"fmt"
-	"log"
 	"strings"
+
+	"github.com/emer/emergent/v2/problems"
 )
 
 // Apply checks if Sel selector applies to this object according to (.Class, #Name, Type)
@@ -75,21 +74,19 @@ func (ps *Sheet[T]) SelMatchReset() {
 	}
 }
 
-// SelNoMatchWarn issues warning messages for any Sel selectors that had no
-// matches during the last Apply process -- see SelMatchReset.
+// SelNoMatchWarn reports a warning to [problems.Default] for any Sel
+// selectors that had no matches during the last Apply process -- see SelMatchReset.
 // The setName and objName provide info about the Set and obj being applied.
-// Returns an error message with the non-matching sets if any, else nil.
+// Returns a [*NoMatchError] with the non-matching selectors if any, else nil.
 func (ps *Sheet[T]) SelNoMatchWarn(setName, objName string) error {
-	msg := ""
+	var sels []string
 	for _, sl := range *ps {
 		if sl.NMatch == 0 {
-			msg += "\tSel: " + sl.Sel + "\n"
+			sels = append(sels, sl.Sel)
 		}
 	}
-	if msg != "" {
-		msg = fmt.Sprintf("param.Sheet from Set: %s for object: %s had the following non-matching Selectors:\n%s", setName, objName, msg)
-		log.Println(msg) // todo: slog?
-		return errors.New(msg)
+	if len(sels) == 0 {
+		return nil
 	}
-	return nil
+	return problems.Warn("params", setName, &NoMatchError{SetName: setName, ObjName: objName, Sels: sels})
 }
@@ -0,0 +1,118 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"cogentcore.org/core/base/reflectx"
+)
+
+// Defaulter is an object with a Defaults method that sets
+// its hard-coded default parameters, e.g., a *Params struct.
+type Defaulter interface {
+	Defaults()
+}
+
+// Updater is an object with an Update method that updates
+// computed values after parameters have been set.
+type Updater interface {
+	Update()
+}
+
+// CheckDefaults verifies, for each given object (a pointer to a struct),
+// that calling its Defaults method (if defined), followed by its Update
+// method (if defined), produces field values that are consistent with
+// the default:"val1[,val2...]" struct field tags, recursing into struct
+// fields that do not themselves have a default tag. This catches drift
+// between the documented and actual default values, and is intended to be
+// called in unit tests on all the *Params structs of an algorithm package.
+// Fields with a display:"-" tag are skipped, as they are typically
+// computed values. Returns an error listing each inconsistent field,
+// or nil if all are consistent.
+func CheckDefaults(objs ...any) error {
+	var errs []error
+	for _, obj := range objs {
+		if df, ok := obj.(Defaulter); ok {
+			df.Defaults()
+		}
+		if up, ok := obj.(Updater); ok {
+			up.Update()
+		}
+		val := reflectx.Underlying(reflect.ValueOf(obj))
+		if val.Kind() != reflect.Struct {
+			errs = append(errs, fmt.Errorf("params.CheckDefaults: object of type %T is not a struct", obj))
+			continue
+		}
+		errs = append(errs, checkDefaults(val, val.Type().Name())...)
+	}
+	return errors.Join(errs...)
+}
+
+// checkDefaults checks the fields of given struct value, with given path
+// name for the struct, returning an error for each inconsistent field.
+func checkDefaults(val reflect.Value, path string) []error {
+	var errs []error
+	typ := val.Type()
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		if !f.IsExported() || f.Tag.Get("display") == "-" {
+			continue
+		}
+		fv := val.Field(i)
+		def := f.Tag.Get("default")
+		if def == "" {
+			if fv.Kind() == reflect.Struct {
+				errs = append(errs, checkDefaults(fv, path+"."+f.Name)...)
+			}
+			continue
+		}
+		if !reflectx.ValueIsDefault(fv, def) {
+			errs = append(errs, fmt.Errorf("%s.%s = %v after Defaults is not consistent with its default tag: %q", path, f.Name, fv.Interface(), def))
+		}
+	}
+	return errs
+}
+
+// MissingDefaults returns the path names of all the numeric and bool
+// fields of given object (a pointer to a struct) that do not have a
+// default:"..." struct field tag, recursing into struct fields.
+// Fields with a display:"-" tag are skipped, along with the On field
+// that typically enables a mechanism, and is not considered a parameter.
+// This can be used in unit tests to flag parameters that are missing
+// their documented default values.
+func MissingDefaults(obj any) []string {
+	val := reflectx.Underlying(reflect.ValueOf(obj))
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+	return missingDefaults(val, val.Type().Name())
+}
+
+// missingDefaults returns the missing default fields of given struct value.
+func missingDefaults(val reflect.Value, path string) []string {
+	var miss []string
+	typ := val.Type()
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		if !f.IsExported() || f.Name == "On" || f.Tag.Get("display") == "-" {
+			continue
+		}
+		if f.Tag.Get("default") != "" {
+			continue
+		}
+		fv := val.Field(i)
+		kind := fv.Kind()
+		switch {
+		case kind == reflect.Struct:
+			miss = append(miss, missingDefaults(fv, path+"."+f.Name)...)
+		case kind == reflect.Bool || (kind >= reflect.Int && kind <= reflect.Float64):
+			miss = append(miss, path+"."+f.Name)
+		}
+	}
+	return miss
+}
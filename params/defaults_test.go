@@ -0,0 +1,53 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSubParams struct {
+	Tau float32 `default:"10,20"`
+	Dt  float32 `display:"-"`
+	N   int
+}
+
+func (sp *testSubParams) Update() {
+	sp.Dt = 1 / sp.Tau
+}
+
+type testParams struct {
+	On    bool
+	Gain  float32 `default:"2"`
+	Clamp bool    `default:"true"`
+	Mode  string  `default:"Test"`
+	Sub   testSubParams
+}
+
+func (tp *testParams) Defaults() {
+	tp.Gain = 2
+	tp.Clamp = true
+	tp.Mode = "Test"
+	tp.Sub.Tau = 20
+}
+
+func (tp *testParams) Update() {
+	tp.Sub.Update()
+}
+
+func TestCheckDefaults(t *testing.T) {
+	tp := &testParams{}
+	assert.NoError(t, CheckDefaults(tp))
+	assert.Equal(t, float32(0.05), tp.Sub.Dt)
+
+	sp := &testSubParams{} // no Defaults method, so Tau = 0
+	err := CheckDefaults(sp)
+	assert.ErrorContains(t, err, "testSubParams.Tau = 0")
+
+	assert.Equal(t, []string{"testParams.Sub.N"}, MissingDefaults(tp))
+	assert.Error(t, CheckDefaults(&[]int{}))
+}
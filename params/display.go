@@ -0,0 +1,140 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"cogentcore.org/core/base/reflectx"
+)
+
+// ShouldDisplayer is the interface for conditional visibility of the
+// fields of a params struct, which is the single supported mechanism for
+// showing and hiding fields depending on the values of other fields
+// (e.g., only showing the parameters of a mechanism when it is On).
+// It is the same interface as core.ShouldDisplayer, used by the GUI forms,
+// and is defined here so that conditions can be evaluated without the GUI.
+type ShouldDisplayer interface {
+
+	// ShouldDisplay returns whether the given named field should be displayed.
+	ShouldDisplay(field string) bool
+}
+
+// ShouldDisplay returns whether the field at given path (e.g., "Act.Spike.Tr")
+// within given object (a pointer to a struct) should be displayed in a params
+// editor, which is false if any struct along the path returns false from its
+// [ShouldDisplayer] ShouldDisplay method for the next field on the path,
+// or if any field on the path has a display:"-" tag. Returns an error if
+// the path does not exist.
+func ShouldDisplay(obj any, path string) (bool, error) {
+	show := true
+	val := reflectx.Underlying(reflect.ValueOf(obj))
+	for _, fnm := range strings.Split(path, ".") {
+		if val.Kind() != reflect.Struct {
+			return false, fmt.Errorf("params.ShouldDisplay: path %q is not valid in object of type %T", path, obj)
+		}
+		fld, ok := val.Type().FieldByName(fnm)
+		if !ok {
+			return false, fmt.Errorf("params.ShouldDisplay: field %q not found in path %q in object of type %T", fnm, path, obj)
+		}
+		if fld.Tag.Get("display") == "-" {
+			show = false
+		}
+		if sd, ok := reflectx.UnderlyingPointer(val).Interface().(ShouldDisplayer); ok && show {
+			show = sd.ShouldDisplay(fnm)
+		}
+		val = reflectx.Underlying(val.FieldByIndex(fld.Index))
+	}
+	return show, nil
+}
+
+// DisplayedFields returns the paths of all the non-struct exported fields of
+// given object (a pointer to a struct) that should currently be displayed,
+// according to [ShouldDisplay], recursing into struct fields.
+// This can be used to test the conditional visibility of params structs,
+// and to show only the relevant parameters, e.g., in logs.
+func DisplayedFields(obj any) []string {
+	val := reflectx.Underlying(reflect.ValueOf(obj))
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+	return displayedFields(val, "")
+}
+
+// displayedFields returns the displayed fields of given struct value,
+// with given path prefix.
+func displayedFields(val reflect.Value, path string) []string {
+	var flds []string
+	sd, _ := reflectx.UnderlyingPointer(val).Interface().(ShouldDisplayer)
+	typ := val.Type()
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		if !f.IsExported() || f.Tag.Get("display") == "-" {
+			continue
+		}
+		if sd != nil && !sd.ShouldDisplay(f.Name) {
+			continue
+		}
+		fv := reflectx.Underlying(val.Field(i))
+		if fv.Kind() == reflect.Struct {
+			flds = append(flds, displayedFields(fv, path+f.Name+".")...)
+			continue
+		}
+		flds = append(flds, path+f.Name)
+	}
+	return flds
+}
+
+// CheckShouldDisplay audits the conditional visibility of given objects
+// (pointers to structs), and of all their struct fields, returning an
+// error for each [ShouldDisplayer] that hides a bool field named On,
+// which must always be displayed so that the mechanism can be turned
+// back on in the GUI. This is intended to be called in unit tests.
+func CheckShouldDisplay(objs ...any) error {
+	var errs []error
+	for _, obj := range objs {
+		val := reflectx.Underlying(reflect.ValueOf(obj))
+		if val.Kind() != reflect.Struct {
+			errs = append(errs, fmt.Errorf("params.CheckShouldDisplay: object of type %T is not a struct", obj))
+			continue
+		}
+		errs = append(errs, checkShouldDisplay(val, val.Type().Name())...)
+	}
+	return errors.Join(errs...)
+}
+
+// checkShouldDisplay checks the On field of given struct value,
+// and recurses into its struct fields.
+func checkShouldDisplay(val reflect.Value, path string) []error {
+	var errs []error
+	typ := val.Type()
+	if sd, ok := reflectx.UnderlyingPointer(val).Interface().(ShouldDisplayer); ok {
+		if on, ok := typ.FieldByName("On"); ok && on.Type.Kind() == reflect.Bool {
+			onv := val.FieldByIndex(on.Index)
+			prev := onv.Bool()
+			for _, b := range []bool{false, true} {
+				onv.SetBool(b)
+				if !sd.ShouldDisplay("On") {
+					errs = append(errs, fmt.Errorf("%s.On is not displayed when it is %v", path, b))
+				}
+			}
+			onv.SetBool(prev)
+		}
+	}
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		if !f.IsExported() || f.Tag.Get("display") == "-" {
+			continue
+		}
+		fv := val.Field(i)
+		if fv.Kind() == reflect.Struct {
+			errs = append(errs, checkShouldDisplay(fv, path+"."+f.Name)...)
+		}
+	}
+	return errs
+}
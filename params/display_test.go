@@ -0,0 +1,79 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMech struct {
+	On   bool
+	Gain float32
+	Tau  float32
+	Dt   float32 `display:"-"`
+}
+
+func (tm *testMech) ShouldDisplay(field string) bool {
+	switch field {
+	case "On":
+		return true
+	default:
+		return tm.On
+	}
+}
+
+type testBadMech struct {
+	On   bool
+	Gain float32
+}
+
+func (tm *testBadMech) ShouldDisplay(field string) bool {
+	return tm.On
+}
+
+type testLayerParams struct {
+	Code  int
+	Sigma float32
+	Mech  testMech
+}
+
+func (tl *testLayerParams) ShouldDisplay(field string) bool {
+	switch field {
+	case "Sigma":
+		return tl.Code == 1
+	default:
+		return true
+	}
+}
+
+func TestShouldDisplay(t *testing.T) {
+	tl := &testLayerParams{}
+	assert.Equal(t, []string{"Code", "Mech.On"}, DisplayedFields(tl))
+	tl.Code = 1
+	tl.Mech.On = true
+	assert.Equal(t, []string{"Code", "Sigma", "Mech.On", "Mech.Gain", "Mech.Tau"}, DisplayedFields(tl))
+
+	show, err := ShouldDisplay(tl, "Mech.Gain")
+	assert.NoError(t, err)
+	assert.True(t, show)
+	tl.Mech.On = false
+	show, _ = ShouldDisplay(tl, "Mech.Gain")
+	assert.False(t, show)
+	show, _ = ShouldDisplay(tl, "Mech.On")
+	assert.True(t, show)
+	show, _ = ShouldDisplay(tl, "Mech.Dt")
+	assert.False(t, show)
+	_, err = ShouldDisplay(tl, "Mech.Gain.X")
+	assert.Error(t, err)
+	_, err = ShouldDisplay(tl, "Mech.Bogus")
+	assert.Error(t, err)
+
+	assert.NoError(t, CheckShouldDisplay(tl))
+	bad := &testBadMech{}
+	assert.ErrorContains(t, CheckShouldDisplay(bad), "testBadMech.On is not displayed when it is false")
+	assert.False(t, bad.On)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package params
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NotFoundError is returned when a [Sheet] or [Sel] is not found by name.
+type NotFoundError struct {
+
+	// Kind is the kind of thing not found: "Sheet" or "Sel".
+	Kind string
+
+	// Name is the name that was looked for.
+	Name string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("params: %s named %q not found", e.Kind, e.Name)
+}
+
+// NoMatchError is returned by [Sheet.SelNoMatchWarn] when some of the
+// selectors of a sheet did not match anything during the last Apply.
+type NoMatchError struct {
+
+	// SetName is the name of the set (sheet) that was applied.
+	SetName string
+
+	// ObjName is the name of the object it was applied to.
+	ObjName string
+
+	// Sels are the selectors that did not match.
+	Sels []string
+}
+
+func (e *NoMatchError) Error() string {
+	return fmt.Sprintf("param.Sheet from Set: %s for object: %s had the following non-matching Selectors:\n\tSel: %s", e.SetName, e.ObjName, strings.Join(e.Sels, "\n\tSel: "))
+}
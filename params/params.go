@@ -6,11 +6,7 @@ package params
 
 //go:generate core generate -add-types
 
-import (
-	"fmt"
-
-	"cogentcore.org/core/base/errors"
-)
+import "github.com/emer/emergent/v2/problems"
 
 // Sel specifies a selector for the scope of application of a set of
 // parameters, using standard css selector syntax (. prefix = class, # prefix = name,
@@ -63,14 +59,15 @@ func (sh *Sheet[T]) ElemLabel(idx int) string {
 }
 
 // SelByName returns given selector within the Sheet, by Name.
-// Returns and logs error if not found.
+// Returns a [*NotFoundError] if not found, which is also reported
+// to [problems.Default].
 func (sh *Sheet[T]) SelByName(sel string) (*Sel[T], error) {
 	for _, sl := range *sh {
 		if sl.Sel == sel {
 			return sl, nil
 		}
 	}
-	return nil, errors.Log(fmt.Errorf("params.Sheet: Sel named %v not found", sel))
+	return nil, problems.Err("params", sel, &NotFoundError{Kind: "Sel", Name: sel})
 }
 
 ////////
@@ -82,11 +79,12 @@ func (sh *Sheet[T]) SelByName(sel string) (*Sel[T], error) {
 type Sheets[T Styler] map[string]*Sheet[T]
 
 // SheetByName tries to find given set by name.
-// Returns and logs error if not found.
+// Returns a [*NotFoundError] if not found, which is also reported
+// to [problems.Default].
 func (ps *Sheets[T]) SheetByName(name string) (*Sheet[T], error) {
 	st, ok := (*ps)[name]
 	if ok {
 		return st, nil
 	}
-	return nil, errors.Log(fmt.Errorf("params.Sheets: Param Sheet named %q not found", name))
+	return nil, problems.Err("params", name, &NotFoundError{Kind: "Sheet", Name: name})
 }
@@ -5,8 +5,10 @@
 package params
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/emer/emergent/v2/problems"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -74,3 +76,31 @@ func TestSet(t *testing.T) {
 	paramSets["NoMomentum"].Apply(tf)
 	assert.Equal(t, false, tf.Norm)
 }
+
+func TestErrors(t *testing.T) {
+	problems.Default.Reset()
+	defer problems.Default.Reset()
+
+	_, err := paramSets.SheetByName("Missing")
+	var nf *NotFoundError
+	assert.True(t, errors.As(err, &nf))
+	assert.Equal(t, "Sheet", nf.Kind)
+	assert.Equal(t, "Missing", nf.Name)
+	_, err = paramSets["Base"].SelByName("#Missing")
+	assert.True(t, errors.As(err, &nf))
+	assert.Equal(t, "Sel", nf.Kind)
+
+	tf := &test{Name: "Forward"}
+	sh := paramSets["Base"]
+	sh.SelMatchReset()
+	sh.Apply(tf)
+	err = sh.SelNoMatchWarn("Base", "Network")
+	var nm *NoMatchError
+	assert.True(t, errors.As(err, &nm))
+	assert.Equal(t, []string{".Back", "#ToOutput"}, nm.Sels)
+	assert.Equal(t, "param.Sheet from Set: Base for object: Network had the following non-matching Selectors:\n\tSel: .Back\n\tSel: #ToOutput", nm.Error())
+
+	assert.Equal(t, 3, problems.Default.Len())
+	assert.Len(t, problems.Default.Level(problems.Warning), 1)
+	assert.ErrorAs(t, problems.Default.Err(), &nf)
+}
@@ -6,6 +6,12 @@ import (
 	"cogentcore.org/core/types"
 )
 
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/params.Defaulter", IDName: "defaulter", Doc: "Defaulter is an object with a Defaults method that sets\nits hard-coded default parameters, e.g., a *Params struct.", Methods: []types.Method{{Name: "Defaults"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/params.Updater", IDName: "updater", Doc: "Updater is an object with an Update method that updates\ncomputed values after parameters have been set.", Methods: []types.Method{{Name: "Update"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/params.ShouldDisplayer", IDName: "should-displayer", Doc: "ShouldDisplayer is the interface for conditional visibility of the\nfields of a params struct, which is the single supported mechanism for\nshowing and hiding fields depending on the values of other fields\n(e.g., only showing the parameters of a mechanism when it is On).\nIt is the same interface as core.ShouldDisplayer, used by the GUI forms,\nand is defined here so that conditions can be evaluated without the GUI.", Methods: []types.Method{{Name: "ShouldDisplay", Doc: "ShouldDisplay returns whether the given named field should be displayed.", Args: []string{"field"}, Returns: []string{"bool"}}}})
+
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/params.FlexVal", IDName: "flex-val", Doc: "FlexVal is a specific flexible value for the Flex parameter map\nthat implements the StylerObject interface for CSS-style selection logic.\nThe field names are abbreviated because full names are used in StylerObject.", Fields: []types.Field{{Name: "Name", Doc: "name of this specific object, matches #Name selections"}, {Name: "Type", Doc: "type name of this object, matches plain TypeName selections"}, {Name: "Class", Doc: "space-separated list of class name(s), match the .Class selections"}, {Name: "Object", Doc: "actual object with data that is set by the parameters"}, {Name: "History", Doc: "History of params applied"}}})
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/params.Flex", IDName: "flex", Doc: "Flex supports arbitrary named parameter values that can be set\nby a Set of parameters, as a map of any objects.\nFirst initialize the map with set of names and a type to create\nblank values, then apply the Set to it."})
@@ -33,3 +39,7 @@ var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/params.Styl
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/params.StylerObject", IDName: "styler-object", Doc: "The params.StylerObject interface extends Styler to include an arbitary\nfunction to access the underlying object type.", Methods: []types.Method{{Name: "StyleObject", Doc: "StyleObject returns the object that will have its field values set by\nthe params specifications.", Returns: []string{"any"}}}})
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/params.Tweaks", IDName: "tweaks", Doc: "Tweaks holds parameter tweak values associated with one parameter selector.\nHas all the object values affected for a given parameter within one\nselector, that has a tweak hyperparameter set.", Fields: []types.Field{{Name: "Param", Doc: "the parameter path for this param"}, {Name: "Sel", Doc: "the param selector that set the specific value upon which tweak is based"}, {Name: "Search", Doc: "the search values for all objects covered by this selector"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/params.NotFoundError", IDName: "not-found-error", Doc: "NotFoundError is returned when a [Sheet] or [Sel] is not found by name.", Fields: []types.Field{{Name: "Kind", Doc: "Kind is the kind of thing not found: \"Sheet\" or \"Sel\"."}, {Name: "Name", Doc: "Name is the name that was looked for."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/params.NoMatchError", IDName: "no-match-error", Doc: "NoMatchError is returned by [Sheet.SelNoMatchWarn] when some of the\nselectors of a sheet did not match anything during the last Apply.", Fields: []types.Field{{Name: "SetName", Doc: "SetName is the name of the set (sheet) that was applied."}, {Name: "ObjName", Doc: "ObjName is the name of the object it was applied to."}, {Name: "Sels", Doc: "Sels are the selectors that did not match."}}})
@@ -0,0 +1,65 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package patgen
+
+import (
+	"cogentcore.org/lab/tensor"
+)
+
+// Occlude sets a contiguous band of pct proportion of the columns
+// (innermost dimension) of the given tensor to offVal, at a random
+// position, simulating occlusion of part of the input.
+func Occlude(tsr tensor.Tensor, pct float32, offVal float64) {
+	ln := tsr.Len()
+	if ln == 0 {
+		return
+	}
+	shp := tsr.ShapeSizes()
+	nx := shp[len(shp)-1]
+	w := NFromPct(pct, nx)
+	if w <= 0 {
+		return
+	}
+	st := 0
+	if w < nx {
+		st = RandSource.Intn(nx - w + 1)
+	}
+	for i := 0; i < ln; i++ {
+		x := i % nx
+		if x >= st && x < st+w {
+			tsr.SetFloat1D(offVal, i)
+		}
+	}
+}
+
+// OccludeRows applies [Occlude] to each row of the given tensor,
+// iterating over the outer-most tensor dimension as rows,
+// with a different random position for each row.
+func OccludeRows(tsr tensor.Values, pct float32, offVal float64) {
+	rows, _ := tsr.Shape().RowCellSize()
+	for i := 0; i < rows; i++ {
+		trow := tsr.SubSpace(i)
+		Occlude(trow, pct, offVal)
+	}
+}
+
+// NoiseRows flips pct proportion of the bits that are On to Off, and
+// the same number of Off bits to On, in each row of the given tensor,
+// iterating over the outer-most tensor dimension as rows.
+// This preserves the number of active bits in each pattern.
+func NoiseRows(tsr tensor.Values, pct float32, onVal, offVal float64) {
+	rows, _ := tsr.Shape().RowCellSize()
+	for i := 0; i < rows; i++ {
+		trow := tsr.SubSpace(i)
+		non := 0
+		for j := range trow.Len() {
+			if trow.Float1D(j) != offVal {
+				non++
+			}
+		}
+		n := NFromPct(pct, non)
+		FlipBits(trow, n, n, onVal, offVal)
+	}
+}
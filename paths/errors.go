@@ -0,0 +1,17 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package paths
+
+import "errors"
+
+var (
+	// ErrNoTopoWeights is returned by TopoWeights methods when none of
+	// the Gauss or Sig topographic weight params are turned on.
+	ErrNoTopoWeights = errors.New("TopoWeights no Gauss or Sig params turned on")
+
+	// ErrRecipShared is returned by SharedIndexes for Recip pathways,
+	// for which shared weights are not supported.
+	ErrRecipShared = errors.New("SharedIndexes not supported for Recip pathways")
+)
@@ -6,7 +6,6 @@ package paths
 
 import (
 	"fmt"
-	"log"
 
 	"cogentcore.org/core/math32"
 	"cogentcore.org/core/math32/minmax"
@@ -14,6 +13,7 @@ import (
 	"cogentcore.org/lab/tensor"
 	"github.com/emer/emergent/v2/edge"
 	"github.com/emer/emergent/v2/efuns"
+	"github.com/emer/emergent/v2/problems"
 )
 
 // PoolTile implements tiled 2D connectivity between pools within layers, where
@@ -56,6 +56,16 @@ type PoolTile struct {
 
 	// min..max range of topographic weight values to generate
 	TopoRange minmax.F32
+
+	// Shared specifies that all receptive fields share one weight kernel,
+	// i.e., true convolution semantics, where the same weights are used at
+	// every position that the receptive field is tiled over.  Algorithms
+	// use NewSharedSyns (or SharedIndexes) to map each synapse onto the
+	// shared kernel, to tie the initial weights and accumulate weight
+	// changes across positions.  This dramatically reduces the number of
+	// free parameters and enforces translation invariance.
+	// Not supported for Recip.
+	Shared bool
 }
 
 func NewPoolTile() *PoolTile {
@@ -245,9 +255,206 @@ func (pt *PoolTile) TopoWeights(send, recv *tensor.Shape, wts *tensor.Float32) e
 			return pt.TopoWeightsSigmoid4D(send, recv, wts)
 		}
 	}
-	err := fmt.Errorf("PoolTile:TopoWeights no Gauss or Sig params turned on")
-	log.Println(err)
-	return err
+	return problems.Err("paths", pt.Name(), fmt.Errorf("PoolTile: %w", ErrNoTopoWeights))
+}
+
+/////////////////////////////////////////////////////
+// Shared Weights
+
+// SharedKernelShape returns the shape of the shared weight kernel
+// used when Shared is on, which has the same organization as the
+// TopoWeights tensor: Y, X of units within recv pool, then
+// receptive field Size Y, X, then Y, X of units within sending pool.
+// A 2D recv layer is treated as one pool, and a 2D sending layer
+// is treated as a single pool.
+func (pt *PoolTile) SharedKernelShape(send, recv *tensor.Shape) []int {
+	rNuY, rNuX := recv.DimSize(0), recv.DimSize(1)
+	if recv.NumDims() == 4 {
+		rNuY, rNuX = recv.DimSize(2), recv.DimSize(3)
+	}
+	sNuY, sNuX := send.DimSize(0), send.DimSize(1)
+	if send.NumDims() == 4 {
+		sNuY, sNuX = send.DimSize(2), send.DimSize(3)
+	}
+	return []int{rNuY, rNuX, pt.Size.Y, pt.Size.X, sNuY, sNuX}
+}
+
+// SharedIndexes returns a tensor with the same recv + send shape as
+// the cons connectivity tensor returned by Connect, with the 1D index
+// into the shared weight kernel (see SharedKernelShape) for each
+// connection, and -1 for non-connected units.  This is used by
+// algorithms to implement shared weights, where every synapse reads
+// its weight from the kernel, and weight changes for all synapses
+// with the same index are summed into the kernel.
+// Only the feedforward (non-Recip) case is supported.
+func (pt *PoolTile) SharedIndexes(send, recv *tensor.Shape) (*tensor.Int32, error) {
+	if pt.Recip {
+		return nil, problems.Err("paths", pt.Name(), fmt.Errorf("PoolTile: %w", ErrRecipShared))
+	}
+	idxs := tensor.NewInt32(tensor.AddShapes(recv, send).Sizes...)
+	for i := range idxs.Values {
+		idxs.Values[i] = -1
+	}
+	sNtot := send.Len()
+	sNpY := send.DimSize(0)
+	sNpX := send.DimSize(1)
+	rNpY := recv.DimSize(0)
+	rNpX := recv.DimSize(1)
+	sNu := 1
+	rNu := 1
+	if send.NumDims() == 4 {
+		sNu = send.DimSize(2) * send.DimSize(3)
+	} else {
+		sNpY = 1
+		sNpX = 1
+		sNu = send.DimSize(0) * send.DimSize(1)
+	}
+	if recv.NumDims() == 4 {
+		rNu = recv.DimSize(2) * recv.DimSize(3)
+	} else {
+		rNpY = 1
+		rNpX = 1
+		rNu = recv.DimSize(0) * recv.DimSize(1)
+	}
+	var clip bool
+	for rpy := 0; rpy < rNpY; rpy++ {
+		for rpx := 0; rpx < rNpX; rpx++ {
+			rpi := rpy*rNpX + rpx
+			ris := rpi * rNu
+			for fy := 0; fy < pt.Size.Y; fy++ {
+				spy := pt.Start.Y + rpy*pt.Skip.Y + fy
+				if spy, clip = edge.Edge(spy, sNpY, pt.Wrap); clip {
+					continue
+				}
+				for fx := 0; fx < pt.Size.X; fx++ {
+					spx := pt.Start.X + rpx*pt.Skip.X + fx
+					if spx, clip = edge.Edge(spx, sNpX, pt.Wrap); clip {
+						continue
+					}
+					spi := spy*sNpX + spx
+					sis := spi * sNu
+					fi := fy*pt.Size.X + fx
+					for rui := 0; rui < rNu; rui++ {
+						ri := ris + rui
+						for sui := 0; sui < sNu; sui++ {
+							si := sis + sui
+							off := ri*sNtot + si
+							if off < idxs.Len() {
+								idxs.Values[off] = int32((rui*pt.Size.Y*pt.Size.X+fi)*sNu + sui)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return idxs, nil
+}
+
+// SharedKernelSum sums the values in full, which has the recv + send
+// shape of the connectivity (e.g., weight changes for each synapse),
+// into the shared kernel tensor, according to the SharedIndexes idxs.
+// The kernel is set to SharedKernelShape and zeroed first.
+// This is used for accumulating weight changes across positions.
+func (pt *PoolTile) SharedKernelSum(send, recv *tensor.Shape, idxs *tensor.Int32, full, kernel *tensor.Float32) {
+	kernel.SetShapeSizes(pt.SharedKernelShape(send, recv)...)
+	for i := range kernel.Values {
+		kernel.Values[i] = 0
+	}
+	for i, ki := range idxs.Values {
+		if ki < 0 {
+			continue
+		}
+		kernel.Values[ki] += full.Values[i]
+	}
+}
+
+// SharedKernelApply sets the values in full, which has the recv + send
+// shape of the connectivity, from the shared kernel tensor, according
+// to the SharedIndexes idxs.  Non-connected values are not set.
+// This is used for setting the synaptic weights from the kernel.
+func (pt *PoolTile) SharedKernelApply(idxs *tensor.Int32, kernel, full *tensor.Float32) {
+	full.SetShapeSizes(idxs.ShapeSizes()...)
+	for i, ki := range idxs.Values {
+		if ki < 0 {
+			continue
+		}
+		full.Values[i] = kernel.Values[ki]
+	}
+}
+
+// SharedSyns maps the synapses of a pathway with a Shared PoolTile
+// pattern onto the shared weight kernel, for algorithms to tie the
+// weights of all synapses at the same kernel position, in the
+// standard receiver-based order of synapses: for each receiving unit,
+// the connected sending units in order.
+type SharedSyns struct {
+
+	// Index is the kernel index for each synapse.
+	Index []int32
+
+	// N is the number of synapses at each kernel index.
+	N []int32
+
+	// kernel has temporary values for each kernel index.
+	kernel []float32
+}
+
+// NewSharedSyns returns the SharedSyns for given pattern and
+// connectivity cons returned by its Connect method, or nil if the
+// pattern is not a PoolTile with Shared on (Recip is not supported).
+func NewSharedSyns(pat Pattern, send, recv *tensor.Shape, cons *tensor.Bool) *SharedSyns {
+	pt, ok := pat.(*PoolTile)
+	if !ok || !pt.Shared || pt.Recip {
+		return nil
+	}
+	idxs, _ := pt.SharedIndexes(send, recv)
+	nk := 1
+	for _, sz := range pt.SharedKernelShape(send, recv) {
+		nk *= sz
+	}
+	ss := &SharedSyns{N: make([]int32, nk), kernel: make([]float32, nk)}
+	for i, ki := range idxs.Values {
+		if ki >= 0 && cons.Value1D(i) {
+			ss.Index = append(ss.Index, ki)
+			ss.N[ki]++
+		}
+	}
+	return ss
+}
+
+// Tie sets the values of all the synapses at each kernel position
+// to the value of the first one, e.g., after random initialization.
+func (ss *SharedSyns) Tie(vals []float32) {
+	set := make([]bool, len(ss.kernel))
+	for i, ki := range ss.Index {
+		if !set[ki] {
+			ss.kernel[ki] = vals[i]
+			set[ki] = true
+		}
+		vals[i] = ss.kernel[ki]
+	}
+}
+
+// Sum sets the values of all the synapses at each kernel position
+// to their sum, e.g., for weight changes accumulated across positions.
+func (ss *SharedSyns) Sum(vals []float32) {
+	clear(ss.kernel)
+	for i, ki := range ss.Index {
+		ss.kernel[ki] += vals[i]
+	}
+	for i, ki := range ss.Index {
+		vals[i] = ss.kernel[ki]
+	}
+}
+
+// Mean sets the values of all the synapses at each kernel position
+// to their mean, e.g., for weight changes averaged across positions.
+func (ss *SharedSyns) Mean(vals []float32) {
+	ss.Sum(vals)
+	for i, ki := range ss.Index {
+		vals[i] /= float32(ss.N[ki])
+	}
 }
 
 /////////////////////////////////////////////////////
@@ -6,7 +6,6 @@ package paths
 
 import (
 	"fmt"
-	"log"
 
 	"cogentcore.org/core/math32"
 	"cogentcore.org/core/math32/minmax"
@@ -14,6 +13,7 @@ import (
 	"cogentcore.org/lab/tensor"
 	"github.com/emer/emergent/v2/edge"
 	"github.com/emer/emergent/v2/efuns"
+	"github.com/emer/emergent/v2/problems"
 )
 
 // PoolTileSub implements tiled 2D connectivity between pools within layers, where
@@ -274,9 +274,7 @@ func (pt *PoolTileSub) TopoWeights(send, recv *tensor.Shape, wts *tensor.Float32
 			return pt.TopoWeightsSigmoid4D(send, recv, wts)
 		}
 	}
-	err := fmt.Errorf("PoolTileSub:TopoWeights no Gauss or Sig params turned on")
-	log.Println(err)
-	return err
+	return problems.Err("paths", pt.Name(), fmt.Errorf("PoolTileSub: %w", ErrNoTopoWeights))
 }
 
 // GaussOff turns off gaussian weights
@@ -7,7 +7,9 @@ package paths
 import (
 	"testing"
 
+	"cogentcore.org/core/math32"
 	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/params"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -223,6 +225,94 @@ func TestPoolTile(t *testing.T) {
 	// fmt.Printf("topo wts\n%v\n", wts)
 }
 
+func TestPoolTileShared(t *testing.T) {
+	send := tensor.NewShape(4, 4, 1, 2)
+	recv := tensor.NewShape(2, 2, 1, 3)
+
+	pj := NewPoolTile()
+	pj.Size.Set(2, 2)
+	pj.Skip.Set(2, 2)
+	pj.Start.Set(0, 0)
+	pj.Shared = true
+	_, _, cons := pj.Connect(send, recv, false)
+	idxs, err := pj.SharedIndexes(send, recv)
+	assert.NoError(t, err)
+
+	ksh := pj.SharedKernelShape(send, recv)
+	assert.Equal(t, []int{1, 3, 2, 2, 1, 2}, ksh)
+	ksz := 3 * 2 * 2 * 2
+
+	// every connection has a kernel index, and vice-versa
+	counts := make([]int, ksz)
+	for i, ki := range idxs.Values {
+		assert.Equal(t, cons.Value1D(i), ki >= 0)
+		if ki >= 0 {
+			counts[ki]++
+		}
+	}
+	// each kernel weight is shared across all 4 recv pools
+	for _, c := range counts {
+		assert.Equal(t, 4, c)
+	}
+
+	full := tensor.NewFloat32(idxs.ShapeSizes()...)
+	for i := range full.Values {
+		full.Values[i] = 1
+	}
+	kernel := &tensor.Float32{}
+	pj.SharedKernelSum(send, recv, idxs, full, kernel)
+	assert.Equal(t, ksh, kernel.ShapeSizes())
+	for _, v := range kernel.Values {
+		assert.Equal(t, float32(4), v)
+	}
+	for i := range kernel.Values {
+		kernel.Values[i] = float32(i)
+	}
+	wts := &tensor.Float32{}
+	pj.SharedKernelApply(idxs, kernel, wts)
+	for i, ki := range idxs.Values {
+		if ki >= 0 {
+			assert.Equal(t, float32(ki), wts.Values[i])
+		}
+	}
+
+	ss := NewSharedSyns(pj, send, recv, cons)
+	assert.Len(t, ss.Index, 4*ksz)
+	vals := make([]float32, len(ss.Index))
+	for i := range vals {
+		vals[i] = float32(i)
+	}
+	ss.Tie(vals)
+	first := make(map[int32]float32)
+	for i, ki := range ss.Index {
+		if _, ok := first[ki]; !ok {
+			first[ki] = vals[i]
+		}
+		assert.Equal(t, first[ki], vals[i])
+	}
+	for i := range vals {
+		vals[i] = 1
+	}
+	ss.Sum(vals)
+	for _, v := range vals {
+		assert.Equal(t, float32(4), v)
+	}
+	ss.Mean(vals)
+	for _, v := range vals {
+		assert.Equal(t, float32(4), v)
+	}
+	assert.Nil(t, NewSharedSyns(NewFull(), send, recv, cons))
+
+	pj.Recip = true
+	_, err = pj.SharedIndexes(send, recv)
+	assert.ErrorIs(t, err, ErrRecipShared)
+	assert.Nil(t, NewSharedSyns(pj, send, recv, cons))
+
+	pj.GaussOff()
+	pj.SigFull.On, pj.SigInPool.On = false, false
+	assert.ErrorIs(t, pj.TopoWeights(send, recv, wts), ErrNoTopoWeights)
+}
+
 func TestPoolTileRecip(t *testing.T) {
 	send := tensor.NewShape(4, 4, 1, 2)
 	recv := tensor.NewShape(2, 2, 1, 3)
@@ -882,3 +972,107 @@ func TestPoolRect(t *testing.T) {
 	CheckAllN(sendn, 2*4, t)
 	CheckAllN(recvn, 2*4, t)
 }
+
+func TestPoolTileDisplay(t *testing.T) {
+	pj := NewPoolTile()
+	assert.NoError(t, params.CheckShouldDisplay(pj))
+	pj.GaussFull.On = false
+	show, err := params.ShouldDisplay(pj, "GaussFull.Sigma")
+	assert.NoError(t, err)
+	assert.False(t, show)
+	show, _ = params.ShouldDisplay(pj, "GaussFull.On")
+	assert.True(t, show)
+	assert.Contains(t, params.DisplayedFields(pj), "GaussInPool.Sigma")
+	assert.NotContains(t, params.DisplayedFields(pj), "GaussFull.Sigma")
+}
+
+func TestUniformRandN(t *testing.T) {
+	send := tensor.NewShape(2, 5)
+	recv := tensor.NewShape(4, 5)
+	for _, replace := range []bool{false, true} {
+		pj := NewUniformRandN()
+		pj.RandSeed = 10
+		pj.N = 4
+		pj.Replace = replace
+		sendn, recvn, cons := pj.Connect(send, recv, false)
+		CheckAllN(recvn, 4, t)
+		for ri := range recv.Len() {
+			n := 0
+			for si := range send.Len() {
+				if cons.Values.Index(ri*send.Len() + si) {
+					n++
+				}
+			}
+			assert.Equal(t, 4, n)
+		}
+		if !replace { // 80 connections evenly over 10 senders
+			CheckAllN(sendn, 8, t)
+		}
+	}
+
+	self := tensor.NewShape(3, 4)
+	pj := NewUniformRandN()
+	pj.RandSeed = 5
+	pj.N = 11 // all others
+	sendn, recvn, cons := pj.Connect(self, self, true)
+	CheckAllN(recvn, 11, t)
+	CheckAllN(sendn, 11, t)
+	for i := range self.Len() {
+		assert.False(t, cons.Values.Index(i*self.Len()+i))
+	}
+	pj.N = 20 // limited to number of senders
+	pj.SelfCon = true
+	_, recvn, _ = pj.Connect(self, self, true)
+	CheckAllN(recvn, 12, t)
+}
+
+func TestTopoRand(t *testing.T) {
+	pj := NewTopoRand()
+	assert.NoError(t, params.CheckDefaults(pj))
+
+	send := tensor.NewShape(20, 20)
+	recv := tensor.NewShape(10, 10)
+	pj.RandSeed = 10
+	pj.Sigma.Set(0.1, 0.2)
+	sendn, recvn, cons := pj.Connect(send, recv, false)
+	// recv unit in the middle: connections near center, more spread in Y
+	ri := tensor.Projection2DIndex(recv, false, 5, 5)
+	nx, ny := 0, 0
+	for si := range send.Len() {
+		if !cons.Values.Index(ri*send.Len() + si) {
+			continue
+		}
+		sy, sx := si/20, si%20
+		nx += max(sx-10, 10-sx-1)
+		ny += max(sy-10, 10-sy-1)
+	}
+	assert.Greater(t, ny, nx)
+	assert.Greater(t, recvn.Values[ri], int32(10))
+	total := 0
+	for _, n := range sendn.Values {
+		total += int(n)
+	}
+	rtotal := 0
+	for _, n := range recvn.Values {
+		rtotal += int(n)
+	}
+	assert.Equal(t, total, rtotal)
+
+	// corner unit has fewer connections without wrap
+	ci := tensor.Projection2DIndex(recv, false, 0, 0)
+	assert.Less(t, recvn.Values[ci], recvn.Values[ri])
+	pj.Wrap = true
+	_, recvn, _ = pj.Connect(send, recv, false)
+	assert.InDelta(t, float64(recvn.Values[ri]), float64(recvn.Values[ci]), 15)
+
+	assert.InDelta(t, 1, pj.ConProb(math32.Vec2(0.5, 0.5), math32.Vec2(0.5, 0.5)), 1.0e-6)
+	assert.InDelta(t, 1, pj.ConProb(math32.Vec2(0.01, 0.5), math32.Vec2(0.99, 0.5)), 0.05)
+	pj.Wrap = false
+	assert.Less(t, pj.ConProb(math32.Vec2(0.01, 0.5), math32.Vec2(0.99, 0.5)), float32(0.01))
+
+	self := tensor.NewShape(5, 5)
+	_, _, cons = pj.Connect(self, self, true)
+	for i := range self.Len() {
+		assert.False(t, cons.Values.Index(i*self.Len()+i))
+	}
+}
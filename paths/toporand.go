@@ -0,0 +1,130 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package paths
+
+import (
+	"math/rand"
+
+	"cogentcore.org/core/math32"
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/edge"
+)
+
+// TopoRand implements a random pattern of connectivity between two layers
+// where the probability of connection decays with the topographic distance
+// between the sending unit and the position of the receiving unit projected
+// onto the sending layer, according to a Gaussian kernel with separate widths
+// for each dimension, for modeling distance-dependent cortical connectivity
+// statistics. Positions are normalized to the 0-1 range across each layer,
+// so the receiving layer is mapped onto the entire sending layer, as in
+// PoolTile with CtrMove = 1. 4D layers are automatically flattened to 2D.
+// It maintains its own local random number source and seed,
+// which are initialized if Rand == nil.
+type TopoRand struct {
+
+	// PCon is the probability of connection at zero distance,
+	// which is multiplied by the Gaussian of the distance.
+	PCon float32 `default:"1" min:"0" max:"1"`
+
+	// Sigma is the Gaussian width (standard deviation) of the connection
+	// probability as a function of distance, in each dimension (X, Y),
+	// as a proportion of the size of the sending layer in that dimension
+	// (default 0.2).
+	Sigma math32.Vector2
+
+	// Wrap computes distances with wrap-around at the edges of the
+	// sending layer, so that there are no edge effects.
+	Wrap bool
+
+	// if true, and connecting layer to itself (self pathway), then make a self-connection from unit to itself
+	SelfCon bool
+
+	// random number source -- is created with its own separate source if nil
+	Rand randx.Rand `display:"-"`
+
+	// the current random seed -- will be initialized to a new random number from the global random stream when Rand is created.
+	RandSeed int64 `display:"-"`
+}
+
+func NewTopoRand() *TopoRand {
+	tr := &TopoRand{}
+	tr.Defaults()
+	return tr
+}
+
+func (tr *TopoRand) Defaults() {
+	tr.PCon = 1
+	tr.Sigma.SetScalar(0.2)
+}
+
+func (tr *TopoRand) Name() string {
+	return "TopoRand"
+}
+
+func (tr *TopoRand) InitRand() {
+	if tr.Rand != nil {
+		tr.Rand.Seed(tr.RandSeed)
+		return
+	}
+	if tr.RandSeed == 0 {
+		tr.RandSeed = int64(rand.Uint64())
+	}
+	tr.Rand = randx.NewSysRand(tr.RandSeed)
+}
+
+// normPos returns the normalized 0-1 position of the center of given
+// unit in a 2D layer of given size.
+func normPos(y, x, ny, nx int) math32.Vector2 {
+	return math32.Vec2((float32(x)+0.5)/float32(nx), (float32(y)+0.5)/float32(ny))
+}
+
+// ConProb returns the probability of connection between sending and receiving
+// units at given normalized 0-1 positions within their respective layers.
+func (tr *TopoRand) ConProb(sPos, rPos math32.Vector2) float32 {
+	d := sPos.Sub(rPos)
+	if tr.Wrap {
+		d.X = edge.WrapMinDist(sPos.X, 1, rPos.X) - rPos.X
+		d.Y = edge.WrapMinDist(sPos.Y, 1, rPos.Y) - rPos.Y
+	}
+	dx := d.X / tr.Sigma.X
+	dy := d.Y / tr.Sigma.Y
+	return tr.PCon * math32.Exp(-0.5*(dx*dx+dy*dy))
+}
+
+func (tr *TopoRand) Connect(send, recv *tensor.Shape, same bool) (sendn, recvn *tensor.Int32, cons *tensor.Bool) {
+	sendn, recvn, cons = NewTensors(send, recv)
+	sNy, sNx, _, _ := tensor.Projection2DShape(send, false)
+	rNy, rNx, _, _ := tensor.Projection2DShape(recv, false)
+
+	rnv := recvn.Values
+	snv := sendn.Values
+	sNtot := send.Len()
+
+	tr.InitRand()
+
+	for ry := 0; ry < rNy; ry++ {
+		for rx := 0; rx < rNx; rx++ {
+			ri := tensor.Projection2DIndex(recv, false, ry, rx)
+			rpos := normPos(ry, rx, rNy, rNx)
+			for sy := 0; sy < sNy; sy++ {
+				for sx := 0; sx < sNx; sx++ {
+					si := tensor.Projection2DIndex(send, false, sy, sx)
+					if !tr.SelfCon && same && ri == si {
+						continue
+					}
+					p := tr.ConProb(normPos(sy, sx, sNy, sNx), rpos)
+					if tr.Rand.Float32() >= p {
+						continue
+					}
+					cons.Values.Set(true, ri*sNtot+si)
+					rnv[ri]++
+					snv[si]++
+				}
+			}
+		}
+	}
+	return
+}
@@ -20,7 +20,9 @@ var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/paths.PoolR
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/paths.PoolSameUnit", IDName: "pool-same-unit", Doc: "PoolSameUnit connects a given unit to the unit at the same index\nacross all the pools in a layer.\nPools are the outer-most two dimensions of a 4D layer shape.\nThis is most sensible when pools have same numbers of units in send and recv.\nThis is typically used for lateral topography-inducing connectivity\nand can also serve to reduce a pooled layer down to a single pool.\nThe logic works if either layer does not have pools.\nIf neither is 4D, then it is equivalent to OneToOne.", Fields: []types.Field{{Name: "SelfCon", Doc: "if true, and connecting layer to itself (self pathway), then make a self-connection from unit to itself"}}})
 
-var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/paths.PoolTile", IDName: "pool-tile", Doc: "PoolTile implements tiled 2D connectivity between pools within layers, where\na 2D rectangular receptive field (defined over pools, not units) is tiled\nacross the sending layer pools, with specified level of overlap.\nPools are the outer-most two dimensions of a 4D layer shape.\n2D layers are assumed to have 1x1 pool.\nThis is a standard form of convolutional connectivity, where pools are\nthe filters and the outer dims are locations filtered.\nVarious initial weight / scaling patterns are also available -- code\nmust specifically apply these to the receptive fields.", Fields: []types.Field{{Name: "Recip", Doc: "reciprocal topographic connectivity -- logic runs with recv <-> send -- produces symmetric back-pathway or topo path when sending layer is larger than recv"}, {Name: "Size", Doc: "size of receptive field tile, in terms of pools on the sending layer"}, {Name: "Skip", Doc: "how many pools to skip in tiling over sending layer -- typically 1/2 of Size"}, {Name: "Start", Doc: "starting pool offset for lower-left corner of first receptive field in sending layer"}, {Name: "Wrap", Doc: "if true, pool coordinates wrap around sending shape -- otherwise truncated at edges, which can lead to assymmetries in connectivity etc"}, {Name: "GaussFull", Doc: "gaussian topographic weights / scaling parameters for full receptive field width. multiplies any other factors present"}, {Name: "GaussInPool", Doc: "gaussian topographic weights / scaling parameters within individual sending pools (i.e., unit positions within their parent pool drive distance for gaussian) -- this helps organize / differentiate units more within pools, not just across entire receptive field. multiplies any other factors present"}, {Name: "SigFull", Doc: "sigmoidal topographic weights / scaling parameters for full receptive field width.  left / bottom half have increasing sigmoids, and second half decrease.  Multiplies any other factors present (only used if Gauss versions are not On!)"}, {Name: "SigInPool", Doc: "sigmoidal topographic weights / scaling parameters within individual sending pools (i.e., unit positions within their parent pool drive distance for sigmoid) -- this helps organize / differentiate units more within pools, not just across entire receptive field. multiplies any other factors present  (only used if Gauss versions are not On!).  left / bottom half have increasing sigmoids, and second half decrease."}, {Name: "TopoRange", Doc: "min..max range of topographic weight values to generate"}}})
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/paths.PoolTile", IDName: "pool-tile", Doc: "PoolTile implements tiled 2D connectivity between pools within layers, where\na 2D rectangular receptive field (defined over pools, not units) is tiled\nacross the sending layer pools, with specified level of overlap.\nPools are the outer-most two dimensions of a 4D layer shape.\n2D layers are assumed to have 1x1 pool.\nThis is a standard form of convolutional connectivity, where pools are\nthe filters and the outer dims are locations filtered.\nVarious initial weight / scaling patterns are also available -- code\nmust specifically apply these to the receptive fields.", Fields: []types.Field{{Name: "Recip", Doc: "reciprocal topographic connectivity -- logic runs with recv <-> send -- produces symmetric back-pathway or topo path when sending layer is larger than recv"}, {Name: "Size", Doc: "size of receptive field tile, in terms of pools on the sending layer"}, {Name: "Skip", Doc: "how many pools to skip in tiling over sending layer -- typically 1/2 of Size"}, {Name: "Start", Doc: "starting pool offset for lower-left corner of first receptive field in sending layer"}, {Name: "Wrap", Doc: "if true, pool coordinates wrap around sending shape -- otherwise truncated at edges, which can lead to assymmetries in connectivity etc"}, {Name: "GaussFull", Doc: "gaussian topographic weights / scaling parameters for full receptive field width. multiplies any other factors present"}, {Name: "GaussInPool", Doc: "gaussian topographic weights / scaling parameters within individual sending pools (i.e., unit positions within their parent pool drive distance for gaussian) -- this helps organize / differentiate units more within pools, not just across entire receptive field. multiplies any other factors present"}, {Name: "SigFull", Doc: "sigmoidal topographic weights / scaling parameters for full receptive field width.  left / bottom half have increasing sigmoids, and second half decrease.  Multiplies any other factors present (only used if Gauss versions are not On!)"}, {Name: "SigInPool", Doc: "sigmoidal topographic weights / scaling parameters within individual sending pools (i.e., unit positions within their parent pool drive distance for sigmoid) -- this helps organize / differentiate units more within pools, not just across entire receptive field. multiplies any other factors present  (only used if Gauss versions are not On!).  left / bottom half have increasing sigmoids, and second half decrease."}, {Name: "TopoRange", Doc: "min..max range of topographic weight values to generate"}, {Name: "Shared", Doc: "Shared specifies that all receptive fields share one weight kernel,\ni.e., true convolution semantics, where the same weights are used at\nevery position that the receptive field is tiled over.  Algorithms\nuse NewSharedSyns (or SharedIndexes) to map each synapse onto the\nshared kernel, to tie the initial weights and accumulate weight\nchanges across positions.  This dramatically reduces the number of\nfree parameters and enforces translation invariance.\nNot supported for Recip."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/paths.SharedSyns", IDName: "shared-syns", Doc: "SharedSyns maps the synapses of a pathway with a Shared PoolTile\npattern onto the shared weight kernel, for algorithms to tie the\nweights of all synapses at the same kernel position, in the\nstandard receiver-based order of synapses: for each receiving unit,\nthe connected sending units in order.", Fields: []types.Field{{Name: "Index", Doc: "Index is the kernel index for each synapse."}, {Name: "N", Doc: "N is the number of synapses at each kernel index."}, {Name: "kernel", Doc: "kernel has temporary values for each kernel index."}}})
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/paths.GaussTopo", IDName: "gauss-topo", Doc: "GaussTopo has parameters for Gaussian topographic weights or scaling factors", Fields: []types.Field{{Name: "On", Doc: "use gaussian topographic weights / scaling values"}, {Name: "Sigma", Doc: "gaussian sigma (width) in normalized units where entire distance across relevant dimension is 1.0 -- typical useful values range from .3 to 1.5, with .6 default"}, {Name: "Wrap", Doc: "wrap the gaussian around on other sides of the receptive field, with the closest distance being used -- this removes strict topography but ensures a more uniform distribution of weight values so edge units don't have weaker overall weights"}, {Name: "CtrMove", Doc: "proportion to move gaussian center relative to the position of the receiving unit within its pool: 1.0 = centers span the entire range of the receptive field.  Typically want to use 1.0 for Wrap = true, and 0.8 for false"}}})
 
@@ -32,4 +34,8 @@ var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/paths.PoolU
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/paths.Rect", IDName: "rect", Doc: "Rect implements a rectangular pattern of connectivity between two layers\nwhere the lower-left corner moves in proportion to receiver position with offset\nand multiplier factors (with wrap-around optionally).\n4D layers are automatically flattened to 2D for this pathway.", Fields: []types.Field{{Name: "Size", Doc: "size of rectangle in sending layer that each receiving unit receives from"}, {Name: "Start", Doc: "starting offset in sending layer, for computing the corresponding sending lower-left corner relative to given recv unit position"}, {Name: "Scale", Doc: "scaling to apply to receiving unit position to compute corresponding position in sending layer of the lower-left corner of rectangle"}, {Name: "AutoScale", Doc: "auto-set the Scale as function of the relative sizes of send and recv layers (e.g., if sending layer is 2x larger than receiving, Scale = 2)"}, {Name: "RoundScale", Doc: "if true, use Round when applying scaling factor -- otherwise uses Floor which makes Scale work like a grouping factor -- e.g., .25 will effectively group 4 recv units with same send position"}, {Name: "Wrap", Doc: "if true, connectivity wraps around all edges if it would otherwise go off the edge -- if false, then edges are clipped"}, {Name: "SelfCon", Doc: "if true, and connecting layer to itself (self pathway), then make a self-connection from unit to itself"}, {Name: "Recip", Doc: "make the reciprocal of the specified connections -- i.e., symmetric for swapping recv and send"}, {Name: "RecvStart", Doc: "starting position in receiving layer -- if > 0 then units below this starting point remain unconnected"}, {Name: "RecvN", Doc: "number of units in receiving layer to connect -- if 0 then all (remaining after RecvStart) are connected -- otherwise if < remaining then those beyond this point remain unconnected"}}})
 
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/paths.TopoRand", IDName: "topo-rand", Doc: "TopoRand implements a random pattern of connectivity between two layers\nwhere the probability of connection decays with the topographic distance\nbetween the sending unit and the position of the receiving unit projected\nonto the sending layer, according to a Gaussian kernel with separate widths\nfor each dimension, for modeling distance-dependent cortical connectivity\nstatistics. Positions are normalized to the 0-1 range across each layer,\nso the receiving layer is mapped onto the entire sending layer, as in\nPoolTile with CtrMove = 1. 4D layers are automatically flattened to 2D.\nIt maintains its own local random number source and seed,\nwhich are initialized if Rand == nil.", Fields: []types.Field{{Name: "PCon", Doc: "PCon is the probability of connection at zero distance,\nwhich is multiplied by the Gaussian of the distance."}, {Name: "Sigma", Doc: "Sigma is the Gaussian width (standard deviation) of the connection\nprobability as a function of distance, in each dimension (X, Y),\nas a proportion of the size of the sending layer in that dimension\n(default 0.2)."}, {Name: "Wrap", Doc: "Wrap computes distances with wrap-around at the edges of the\nsending layer, so that there are no edge effects."}, {Name: "SelfCon", Doc: "if true, and connecting layer to itself (self pathway), then make a self-connection from unit to itself"}, {Name: "Rand", Doc: "random number source -- is created with its own separate source if nil"}, {Name: "RandSeed", Doc: "the current random seed -- will be initialized to a new random number from the global random stream when Rand is created."}}})
+
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/paths.UniformRand", IDName: "uniform-rand", Doc: "UniformRand implements uniform random pattern of connectivity between two layers\nusing a permuted (shuffled) list for without-replacement randomness,\nand maintains its own local random number source and seed\nwhich are initialized if Rand == nil -- usually best to keep this\nspecific to each instance of a pathway so it is fully reproducible\nand doesn't interfere with other random number streams.", Fields: []types.Field{{Name: "PCon", Doc: "probability of connection (0-1)"}, {Name: "SelfCon", Doc: "if true, and connecting layer to itself (self pathway), then make a self-connection from unit to itself"}, {Name: "Recip", Doc: "reciprocal connectivity: if true, switch the sending and receiving layers to create a symmetric top-down pathway -- ESSENTIAL to use same RandSeed between two paths to ensure symmetry"}, {Name: "Rand", Doc: "random number source -- is created with its own separate source if nil"}, {Name: "RandSeed", Doc: "the current random seed -- will be initialized to a new random number from the global random stream when Rand is created."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/paths.UniformRandN", IDName: "uniform-rand-n", Doc: "UniformRandN implements a uniform random pattern of connectivity between\ntwo layers with an exact number N of connections per receiving unit\n(fixed fan-in), instead of a probability of connection, because variance\nin fan-in confounds the scaling of net input in small networks.\nThe N sending units for each receiving unit are always distinct.\nIf Replace is true, the sending units are sampled independently for each\nreceiving unit (i.e., with replacement across receiving units), so the\nnumber of connections per sending unit (fan-out) varies randomly.\nOtherwise, they are drawn without replacement from a shuffled list of all\nsending units, which is only reshuffled when used up, so the fan-out is\nalso as even as possible. It maintains its own local random number source\nand seed, which are initialized if Rand == nil.", Fields: []types.Field{{Name: "N", Doc: "N is the number of sending connections per receiving unit,\nwhich is limited to the number of sending units."}, {Name: "Replace", Doc: "Replace samples the sending units independently for each receiving\nunit, so the number of connections per sending unit varies randomly.\nOtherwise, the number of connections per sending unit is as even\nas possible."}, {Name: "SelfCon", Doc: "if true, and connecting layer to itself (self pathway), then make a self-connection from unit to itself"}, {Name: "Rand", Doc: "random number source -- is created with its own separate source if nil"}, {Name: "RandSeed", Doc: "the current random seed -- will be initialized to a new random number from the global random stream when Rand is created."}}})
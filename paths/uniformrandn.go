@@ -0,0 +1,145 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package paths
+
+import (
+	"math/rand"
+	"sort"
+
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/tensor"
+)
+
+// UniformRandN implements a uniform random pattern of connectivity between
+// two layers with an exact number N of connections per receiving unit
+// (fixed fan-in), instead of a probability of connection, because variance
+// in fan-in confounds the scaling of net input in small networks.
+// The N sending units for each receiving unit are always distinct.
+// If Replace is true, the sending units are sampled independently for each
+// receiving unit (i.e., with replacement across receiving units), so the
+// number of connections per sending unit (fan-out) varies randomly.
+// Otherwise, they are drawn without replacement from a shuffled list of all
+// sending units, which is only reshuffled when used up, so the fan-out is
+// also as even as possible. It maintains its own local random number source
+// and seed, which are initialized if Rand == nil.
+type UniformRandN struct {
+
+	// N is the number of sending connections per receiving unit,
+	// which is limited to the number of sending units.
+	N int `min:"1"`
+
+	// Replace samples the sending units independently for each receiving
+	// unit, so the number of connections per sending unit varies randomly.
+	// Otherwise, the number of connections per sending unit is as even
+	// as possible.
+	Replace bool
+
+	// if true, and connecting layer to itself (self pathway), then make a self-connection from unit to itself
+	SelfCon bool
+
+	// random number source -- is created with its own separate source if nil
+	Rand randx.Rand `display:"-"`
+
+	// the current random seed -- will be initialized to a new random number from the global random stream when Rand is created.
+	RandSeed int64 `display:"-"`
+}
+
+func NewUniformRandN() *UniformRandN {
+	return &UniformRandN{N: 10}
+}
+
+func (ur *UniformRandN) Name() string {
+	return "UniformRandN"
+}
+
+func (ur *UniformRandN) InitRand() {
+	if ur.Rand != nil {
+		ur.Rand.Seed(ur.RandSeed)
+		return
+	}
+	if ur.RandSeed == 0 {
+		ur.RandSeed = int64(rand.Uint64())
+	}
+	ur.Rand = randx.NewSysRand(ur.RandSeed)
+}
+
+func (ur *UniformRandN) Connect(send, recv *tensor.Shape, same bool) (sendn, recvn *tensor.Int32, cons *tensor.Bool) {
+	sendn, recvn, cons = NewTensors(send, recv)
+	slen := send.Len()
+	rlen := recv.Len()
+
+	noself := same && !ur.SelfCon
+	navail := slen
+	if noself {
+		navail--
+	}
+	nsend := max(min(ur.N, navail), 0)
+
+	rnv := recvn.Values
+	for i := range rnv {
+		rnv[i] = int32(nsend)
+	}
+
+	ur.InitRand()
+
+	sorder := ur.Rand.Perm(slen)
+	slist := make([]int, nsend)
+	used := make([]bool, slen) // used for current recv unit
+	pos := 0                   // position in sorder for Replace = false
+	for ri := 0; ri < rlen; ri++ {
+		if ur.Replace {
+			randx.PermuteInts(sorder, ur.Rand)
+			pos = 0
+		}
+		for si := 0; si < nsend; si++ {
+			ix := ur.nextSend(sorder, pos, used, noself, ri)
+			if ix < 0 { // remaining senders all used or self: reshuffle
+				randx.PermuteInts(sorder, ur.Rand)
+				pos = 0
+				ix = ur.nextSend(sorder, pos, used, noself, ri)
+			}
+			sorder[pos], sorder[ix] = sorder[ix], sorder[pos]
+			sidx := sorder[pos]
+			slist[si] = sidx
+			used[sidx] = true
+			pos++
+			if pos == slen {
+				randx.PermuteInts(sorder, ur.Rand)
+				pos = 0
+			}
+		}
+		sort.Ints(slist) // keep list sorted for more efficient memory traversal etc
+		for _, sidx := range slist {
+			cons.Values.Set(true, ri*slen+sidx)
+			used[sidx] = false
+		}
+	}
+
+	snv := sendn.Values
+	for si := range snv {
+		nr := 0
+		for ri := 0; ri < rlen; ri++ {
+			if cons.Values.Index(ri*slen + si) {
+				nr++
+			}
+		}
+		snv[si] = int32(nr)
+	}
+	return
+}
+
+// nextSend returns the index of the first sending unit in sorder
+// starting at pos that has not been used for the current receiving
+// unit ri, and is not ri if noself, or -1 if none.
+func (ur *UniformRandN) nextSend(sorder []int, pos int, used []bool, noself bool, ri int) int {
+	for ix := pos; ix < len(sorder); ix++ {
+		sidx := sorder[ix]
+		if used[sidx] || (noself && sidx == ri) {
+			continue
+		}
+		return ix
+	}
+	return -1
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package probes provides a reusable battery of generalization probes
+that are run on a model after training, e.g., novel combinations of
+patterns from patgen recipes, and noisy or occluded versions of the
+training patterns, to report standardized generalization metrics
+as a per-probe accuracy table. It also maps the sensitivity of a
+model to occluding each patch of an input, as a heatmap.
+*/
+package probes
+
+//go:generate core generate -add-types
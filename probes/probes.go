@@ -0,0 +1,155 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package probes
+
+import (
+	"fmt"
+	"math"
+
+	"cogentcore.org/core/base/errors"
+	"cogentcore.org/core/core"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/patgen"
+)
+
+// ScoreFunc is the function that runs the model on the given row of
+// the given probe patterns table, and returns the score for that trial,
+// typically 1 for correct and 0 for incorrect, or any other accuracy
+// measure in the 0-1 range.
+type ScoreFunc func(pb *Probe, pats *table.Table, row int) float64
+
+// Probe is one generalization probe, with a table of patterns to test.
+type Probe struct {
+
+	// Name of the probe, used in the results table.
+	Name string
+
+	// Doc has a description of the probe.
+	Doc string
+
+	// Patterns has the probe patterns, with the same columns
+	// as the training patterns.
+	Patterns *table.Table
+}
+
+// NewNovel returns a new probe for the given table of novel patterns,
+// e.g., novel combinations of vocabulary items configured using the
+// patgen MixPats functions.
+func NewNovel(name string, pats *table.Table) *Probe {
+	return &Probe{Name: name, Doc: "novel patterns", Patterns: pats}
+}
+
+// NewNoise returns a new probe with a copy of the given source patterns,
+// where pct proportion of the active bits in the given columns are
+// flipped to inactive, and the same number of inactive bits to active,
+// using [patgen.NoiseRows].  Values are assumed to be binary 1 / 0.
+func NewNoise(name string, src *table.Table, pct float32, cols ...string) *Probe {
+	pats := CloneTable(src)
+	for _, cn := range cols {
+		col, err := pats.ColumnTry(cn)
+		if errors.Log(err) != nil {
+			continue
+		}
+		patgen.NoiseRows(col.Tensor, pct, 1, 0)
+	}
+	return &Probe{Name: name, Doc: fmt.Sprintf("noise: %g", pct), Patterns: pats}
+}
+
+// NewOcclude returns a new probe with a copy of the given source patterns,
+// where pct proportion of the given columns are occluded (set to 0),
+// using [patgen.OccludeRows].
+func NewOcclude(name string, src *table.Table, pct float32, cols ...string) *Probe {
+	pats := CloneTable(src)
+	for _, cn := range cols {
+		col, err := pats.ColumnTry(cn)
+		if errors.Log(err) != nil {
+			continue
+		}
+		patgen.OccludeRows(col.Tensor, pct, 0)
+	}
+	return &Probe{Name: name, Doc: fmt.Sprintf("occlude: %g", pct), Patterns: pats}
+}
+
+// Run runs the probe using given score function, returning
+// the mean and standard error of the mean of the scores,
+// and the number of trials.
+func (pb *Probe) Run(score ScoreFunc) (mean, sem float64, n int) {
+	n = pb.Patterns.NumRows()
+	if n == 0 {
+		return
+	}
+	var sum, ssq float64
+	for row := range n {
+		s := score(pb, pb.Patterns, row)
+		sum += s
+		ssq += s * s
+	}
+	mean = sum / float64(n)
+	vr := ssq/float64(n) - mean*mean
+	sem = math.Sqrt(max(vr, 0) / float64(n))
+	return
+}
+
+// Battery is a battery of generalization probes, which are all run
+// using the same score function, with the results recorded in a table.
+type Battery struct {
+
+	// Probes are the probes to run, in order.
+	Probes []*Probe
+
+	// Results has the results of the last Run, with one row per probe,
+	// and columns: Probe, Doc, N, Accuracy, SEM.
+	Results *table.Table
+}
+
+// Add adds probe(s) to the battery.
+func (bt *Battery) Add(pbs ...*Probe) {
+	bt.Probes = append(bt.Probes, pbs...)
+}
+
+// Run runs all of the probes using given score function,
+// recording the per-probe accuracy in the Results table.
+func (bt *Battery) Run(score ScoreFunc) *table.Table {
+	dt := table.New("Probes")
+	dt.AddStringColumn("Probe")
+	dt.AddStringColumn("Doc")
+	dt.AddIntColumn("N")
+	dt.AddFloat64Column("Accuracy")
+	dt.AddFloat64Column("SEM")
+	dt.SetNumRows(len(bt.Probes))
+	for i, pb := range bt.Probes {
+		mean, sem, n := pb.Run(score)
+		dt.Column("Probe").SetStringRow(pb.Name, i, 0)
+		dt.Column("Doc").SetStringRow(pb.Doc, i, 0)
+		dt.Column("N").SetIntRow(n, i, 0)
+		dt.Column("Accuracy").SetFloatRow(mean, i, 0)
+		dt.Column("SEM").SetFloatRow(sem, i, 0)
+	}
+	bt.Results = dt
+	return dt
+}
+
+// SaveCSV saves the Results to given file, tab separated.
+func (bt *Battery) SaveCSV(fname core.Filename) error {
+	if bt.Results == nil {
+		return fmt.Errorf("probes.Battery: no Results to save: call Run first")
+	}
+	return bt.Results.SaveCSV(fname, tensor.Tab, true)
+}
+
+// CloneTable returns a copy of the given table, with clones of all
+// of the column data, so that it can be modified without affecting
+// the original.
+func CloneTable(src *table.Table) *table.Table {
+	dt := table.New()
+	for i, nm := range src.Columns.Keys {
+		dt.AddColumn(nm, src.Columns.Values[i].Clone())
+	}
+	if src.Indexes != nil {
+		dt.Indexes = append([]int(nil), src.Indexes...)
+	}
+	return dt
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package probes
+
+import (
+	"testing"
+
+	"cogentcore.org/lab/stats/stats"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/patgen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBattery(t *testing.T) {
+	patgen.NewRand(10)
+	dt := table.New()
+	dt.AddStringColumn("Name")
+	inp := dt.AddFloat32Column("Input", 4, 5)
+	dt.SetNumRows(10)
+	patgen.PermutedBinaryRows(inp, 6, 1, 0)
+
+	bt := &Battery{}
+	bt.Add(NewNovel("Train", dt), NewNoise("Noise", dt, 0.5, "Input"), NewOcclude("Occlude", dt, 0.4, "Input"))
+	// source patterns are not modified
+	assert.Equal(t, 6.0, stats.Sum(tensor.As1D(inp.RowTensor(0))).Float1D(0))
+
+	// score = proportion of active bits that match the original pattern
+	score := func(pb *Probe, pats *table.Table, row int) float64 {
+		pat := pats.Column("Input").RowTensor(row)
+		orig := inp.RowTensor(row)
+		n := 0.0
+		for i := range pat.Len() {
+			if orig.Float1D(i) == 1 && pat.Float1D(i) == 1 {
+				n++
+			}
+		}
+		return n / 6
+	}
+	res := bt.Run(score)
+	assert.Equal(t, 3, res.NumRows())
+	assert.Equal(t, "Noise", res.Column("Probe").StringRow(1, 0))
+	assert.Equal(t, 10, res.Column("N").IntRow(1, 0))
+	assert.Equal(t, 1.0, res.Column("Accuracy").FloatRow(0, 0))
+	assert.Equal(t, 0.0, res.Column("SEM").FloatRow(0, 0))
+	assert.Equal(t, 0.5, res.Column("Accuracy").FloatRow(1, 0))
+	assert.Less(t, res.Column("Accuracy").FloatRow(2, 0), 1.0)
+}
+
+func TestSensitivity(t *testing.T) {
+	dt := table.New()
+	inp := dt.AddFloat32Column("Input", 4, 4, 2)
+	dt.SetNumRows(2)
+	for i := range inp.Len() {
+		inp.SetFloat1D(1, i)
+	}
+	pb := NewNovel("Train", dt)
+	// score depends only on the top-left 2x3 region, and is 0 for row 1
+	score := func(pb *Probe, pats *table.Table, row int) float64 {
+		if row == 1 {
+			return 0
+		}
+		pat := pats.Column("Input").RowTensor(row).(*tensor.Float32)
+		sum := 0.0
+		for y := range 2 {
+			for x := range 3 {
+				sum += pat.Float(y, x, 0) + pat.Float(y, x, 1)
+			}
+		}
+		return sum / 12
+	}
+	sn := NewSensitivity("Input", 2, 2)
+	hm, base, err := sn.Map(pb, score, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, base)
+	assert.Equal(t, []int{4, 4}, hm.Shape().Sizes)
+	assert.InDelta(t, 8.0/12, hm.Value(0, 0), 1e-9)
+	assert.InDelta(t, 4.0/12, hm.Value(1, 2), 1e-9)
+	assert.InDelta(t, 4.0/12, hm.Value(1, 3), 1e-9) // same patch
+	assert.Equal(t, 0.0, hm.Value(2, 0))
+	// original patterns are not modified
+	assert.Equal(t, 32.0, stats.Sum(tensor.As1D(inp.RowTensor(0))).Float1D(0))
+
+	// overlapping patches are averaged
+	sn.Stride = [2]int{1, 1}
+	hm, _, err = sn.Map(pb, score, 0)
+	assert.NoError(t, err)
+	assert.InDelta(t, 8.0/12, hm.Value(0, 1), 1e-9)
+	assert.InDelta(t, (4.0/12+8.0/12)/2, hm.Value(0, 2), 1e-9)
+
+	res, err := sn.Run(pb, score)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.NumRows())
+	assert.Equal(t, 0.0, res.Column("Baseline").FloatRow(1, 0))
+	assert.Equal(t, hm.Value(0, 2), res.Column("Sensitivity").FloatRow(0, 2))
+	assert.Equal(t, hm.Value(0, 2)/2, sn.Mean.Value(0, 2))
+
+	sn.Column = "Missing"
+	_, err = sn.Run(pb, score)
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package probes
+
+import (
+	"fmt"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+// Sensitivity maps the sensitivity of a model to each region of an
+// input, by systematically occluding patches of the input pattern in
+// Column (setting them to Value), rerunning the model with the score
+// function on each occluded pattern, and recording the decrease in the
+// score relative to the unoccluded pattern, which produces a heatmap
+// of the regions that the score depends on. The patches are over the
+// first two dimensions of the cell shape of the column, which are the
+// spatial Y, X dimensions of 2D images, 3D images with channels as the
+// innermost dimension, and 4D pooled layouts, covering all of the values
+// in any further dimensions, so the heatmap has the shape of the first
+// two dimensions. The value for each cell of the heatmap is the mean
+// over the patches that cover it.
+type Sensitivity struct {
+
+	// Column is the input column of the patterns that is occluded.
+	Column string
+
+	// Patch is the Y, X size of the occluded patches.
+	Patch [2]int
+
+	// Stride is the Y, X offset between patches,
+	// which is the Patch size if 0.
+	Stride [2]int
+
+	// Value is the value of the occluded inputs.
+	Value float64
+
+	// Mean is the mean heatmap over all rows, from the last Run.
+	Mean *tensor.Float64 `display:"-"`
+}
+
+// NewSensitivity returns a new [Sensitivity] for given input column,
+// with non-overlapping patches of given Y, X size, occluded with 0.
+func NewSensitivity(col string, patchY, patchX int) *Sensitivity {
+	return &Sensitivity{Column: col, Patch: [2]int{patchY, patchX}}
+}
+
+// spatial returns the Y, X size of the spatial dimensions of the
+// cell shape, and the number of values per spatial location.
+func spatial(cshp []int) (ny, nx, inner int) {
+	ny, nx, inner = 1, 1, 1
+	switch len(cshp) {
+	case 0:
+	case 1:
+		nx = cshp[0]
+	default:
+		ny, nx = cshp[0], cshp[1]
+		for _, s := range cshp[2:] {
+			inner *= s
+		}
+	}
+	return
+}
+
+// Map returns the sensitivity heatmap for given row of the patterns
+// of given probe, using given score function, and the baseline score
+// for the unoccluded pattern. The patterns are not modified, as the
+// occluded patterns are in a copy of the table.
+func (sn *Sensitivity) Map(pb *Probe, score ScoreFunc, row int) (*tensor.Float64, float64, error) {
+	pats := CloneTable(pb.Patterns)
+	return sn.mapRow(pb, pats, score, row)
+}
+
+// mapRow returns the heatmap for given row of given copy of the patterns.
+func (sn *Sensitivity) mapRow(pb *Probe, pats *table.Table, score ScoreFunc, row int) (*tensor.Float64, float64, error) {
+	col, err := pats.ColumnTry(sn.Column)
+	if err != nil {
+		return nil, 0, fmt.Errorf("probes.Sensitivity: %w", err)
+	}
+	ny, nx, inner := spatial(col.ShapeSizes()[1:])
+	py, px := max(sn.Patch[0], 1), max(sn.Patch[1], 1)
+	sy, sx := sn.Stride[0], sn.Stride[1]
+	if sy <= 0 {
+		sy = py
+	}
+	if sx <= 0 {
+		sx = px
+	}
+	cell := col.RowTensor(row)
+	orig := make([]float64, cell.Len())
+	for i := range orig {
+		orig[i] = cell.Float1D(i)
+	}
+	base := score(pb, pats, row)
+	hm := tensor.NewFloat64(ny, nx)
+	cnt := make([]int, ny*nx)
+	for y0 := 0; y0 < ny; y0 += sy {
+		for x0 := 0; x0 < nx; x0 += sx {
+			ey, ex := min(y0+py, ny), min(x0+px, nx)
+			for y := y0; y < ey; y++ {
+				for x := x0; x < ex; x++ {
+					for i := range inner {
+						cell.SetFloat1D(sn.Value, (y*nx+x)*inner+i)
+					}
+				}
+			}
+			d := base - score(pb, pats, row)
+			for y := y0; y < ey; y++ {
+				for x := x0; x < ex; x++ {
+					hm.Values[y*nx+x] += d
+					cnt[y*nx+x]++
+					for i := range inner {
+						ci := (y*nx+x)*inner + i
+						cell.SetFloat1D(orig[ci], ci)
+					}
+				}
+			}
+		}
+	}
+	for i, n := range cnt {
+		if n > 0 {
+			hm.Values[i] /= float64(n)
+		}
+	}
+	return hm, base, nil
+}
+
+// Run computes the sensitivity heatmaps for all rows of the patterns of
+// given probe, using given score function, and returns a table with one
+// row per pattern, with columns: Row, Baseline (the unoccluded score),
+// and Sensitivity with the heatmap, also setting the Mean heatmap.
+func (sn *Sensitivity) Run(pb *Probe, score ScoreFunc) (*table.Table, error) {
+	pats := CloneTable(pb.Patterns)
+	n := pats.NumRows()
+	dt := table.New()
+	metadata.SetName(dt, "Sensitivity: "+pb.Name)
+	tensor.SetPrecision(dt, 4)
+	dt.AddIntColumn("Row")
+	dt.AddFloat64Column("Baseline")
+	sn.Mean = nil
+	for row := range n {
+		hm, base, err := sn.mapRow(pb, pats, score, row)
+		if err != nil {
+			return nil, err
+		}
+		if row == 0 {
+			dt.AddFloat64Column("Sensitivity", hm.Shape().Sizes...)
+			dt.SetNumRows(n)
+			sn.Mean = tensor.NewFloat64(hm.Shape().Sizes...)
+		}
+		dt.Column("Row").SetIntRow(row, row, 0)
+		dt.Column("Baseline").SetFloatRow(base, row, 0)
+		for i, v := range hm.Values {
+			dt.Column("Sensitivity").SetFloatRow(v, row, i)
+			sn.Mean.Values[i] += v / float64(n)
+		}
+	}
+	return dt, nil
+}
@@ -0,0 +1,15 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package probes
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/probes.ScoreFunc", IDName: "score-func", Doc: "ScoreFunc is the function that runs the model on the given row of\nthe given probe patterns table, and returns the score for that trial,\ntypically 1 for correct and 0 for incorrect, or any other accuracy\nmeasure in the 0-1 range."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/probes.Probe", IDName: "probe", Doc: "Probe is one generalization probe, with a table of patterns to test.", Fields: []types.Field{{Name: "Name", Doc: "Name of the probe, used in the results table."}, {Name: "Doc", Doc: "Doc has a description of the probe."}, {Name: "Patterns", Doc: "Patterns has the probe patterns, with the same columns\nas the training patterns."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/probes.Battery", IDName: "battery", Doc: "Battery is a battery of generalization probes, which are all run\nusing the same score function, with the results recorded in a table.", Fields: []types.Field{{Name: "Probes", Doc: "Probes are the probes to run, in order."}, {Name: "Results", Doc: "Results has the results of the last Run, with one row per probe,\nand columns: Probe, Doc, N, Accuracy, SEM."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/probes.Sensitivity", IDName: "sensitivity", Doc: "Sensitivity maps the sensitivity of a model to each region of an\ninput, by systematically occluding patches of the input pattern in\nColumn (setting them to Value), rerunning the model with the score\nfunction on each occluded pattern, and recording the decrease in the\nscore relative to the unoccluded pattern, which produces a heatmap\nof the regions that the score depends on. The patches are over the\nfirst two dimensions of the cell shape of the column, which are the\nspatial Y, X dimensions of 2D images, 3D images with channels as the\ninnermost dimension, and 4D pooled layouts, covering all of the values\nin any further dimensions, so the heatmap has the shape of the first\ntwo dimensions. The value for each cell of the heatmap is the mean\nover the patches that cover it.", Fields: []types.Field{{Name: "Column", Doc: "Column is the input column of the patterns that is occluded."}, {Name: "Patch", Doc: "Patch is the Y, X size of the occluded patches."}, {Name: "Stride", Doc: "Stride is the Y, X offset between patches,\nwhich is the Patch size if 0."}, {Name: "Value", Doc: "Value is the value of the occluded inputs."}, {Name: "Mean", Doc: "Mean is the mean heatmap over all rows, from the last Run."}}})
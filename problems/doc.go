@@ -0,0 +1,14 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package problems provides a central, structured collector for the
+warnings and errors reported by the emergent packages (params, weights,
+paths, emer etc), which previously were only printed to the log.
+Sims can query the [Default] [Collector] after configuration and fail
+fast in headless runs, or show all of the problems in a GUI panel.
+*/
+package problems
+
+//go:generate core generate -add-types
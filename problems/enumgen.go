@@ -0,0 +1,48 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package problems
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _LevelsValues = []Levels{0, 1}
+
+// LevelsN is the highest valid value for type Levels, plus one.
+const LevelsN Levels = 2
+
+var _LevelsValueMap = map[string]Levels{`Warning`: 0, `Error`: 1}
+
+var _LevelsDescMap = map[Levels]string{0: `Warning is a problem that does not prevent a sim from running, but likely indicates a misconfiguration, such as a parameter selector that did not match anything.`, 1: `Error is a problem that prevents something from being done as requested, such as a layer or parameter sheet not being found, or weights that could not be read.`}
+
+var _LevelsMap = map[Levels]string{0: `Warning`, 1: `Error`}
+
+// String returns the string representation of this Levels value.
+func (i Levels) String() string { return enums.String(i, _LevelsMap) }
+
+// SetString sets the Levels value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Levels) SetString(s string) error {
+	return enums.SetString(i, s, _LevelsValueMap, "Levels")
+}
+
+// Int64 returns the Levels value as an int64.
+func (i Levels) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Levels value from an int64.
+func (i *Levels) SetInt64(in int64) { *i = Levels(in) }
+
+// Desc returns the description of the Levels value.
+func (i Levels) Desc() string { return enums.Desc(i, _LevelsDescMap) }
+
+// LevelsValues returns all possible values for the type Levels.
+func LevelsValues() []Levels { return _LevelsValues }
+
+// Values returns all possible values for the type Levels.
+func (i Levels) Values() []enums.Enum { return enums.Values(_LevelsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Levels) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Levels) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Levels") }
@@ -0,0 +1,221 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package problems
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"cogentcore.org/lab/table"
+)
+
+// Levels are the levels of severity of a [Problem].
+type Levels int32 //enums:enum
+
+const (
+	// Warning is a problem that does not prevent a sim from running,
+	// but likely indicates a misconfiguration, such as a parameter
+	// selector that did not match anything.
+	Warning Levels = iota
+
+	// Error is a problem that prevents something from being done
+	// as requested, such as a layer or parameter sheet not being found,
+	// or weights that could not be read.
+	Error
+)
+
+// Problem is a warning or error reported to a [Collector].
+type Problem struct {
+
+	// Level is the severity of the problem.
+	Level Levels
+
+	// Source is the package or subsystem that reported the problem,
+	// e.g., "params" or "weights".
+	Source string
+
+	// Object is the name of the object that the problem is about,
+	// e.g., a layer or parameter sheet, if applicable.
+	Object string
+
+	// Err is the error describing the problem, which can be a typed
+	// error defined by the source package, for [errors.As].
+	Err error
+
+	// Time is when the problem was reported.
+	Time time.Time
+}
+
+// Error returns the error message of the problem.
+func (pr *Problem) Error() string {
+	return pr.Err.Error()
+}
+
+// Unwrap returns the error of the problem, for [errors.Is] and [errors.As].
+func (pr *Problem) Unwrap() error {
+	return pr.Err
+}
+
+// String returns the problem as a single log line, with its level,
+// source and object.
+func (pr *Problem) String() string {
+	if pr.Object == "" {
+		return fmt.Sprintf("%s: %s: %v", pr.Level, pr.Source, pr.Err)
+	}
+	return fmt.Sprintf("%s: %s: %s: %v", pr.Level, pr.Source, pr.Object, pr.Err)
+}
+
+// Collector collects the problems reported during a run, so that they can
+// be checked and displayed together. It is safe for concurrent use.
+type Collector struct {
+
+	// Max is the maximum number of problems to keep; any further problems
+	// are counted in Dropped but not kept. 0 means no limit.
+	Max int
+
+	// Log prints each problem to the log as it is added, as was done
+	// before problems were collected.
+	Log bool
+
+	// OnAdd, if set, is called with each problem as it is added,
+	// e.g., to update a GUI panel.
+	OnAdd func(pr *Problem) `display:"-"`
+
+	// Problems are the problems that have been added, in order.
+	Problems []Problem
+
+	// Dropped is the number of problems that were not kept because of Max.
+	Dropped int
+
+	// mu protects the problems.
+	mu sync.Mutex
+}
+
+// NewCollector returns a new collector that keeps up to 1000 problems,
+// and logs them as they are added.
+func NewCollector() *Collector {
+	return &Collector{Max: 1000, Log: true}
+}
+
+// Default is the collector to which the emergent packages report
+// their problems.
+var Default = NewCollector()
+
+// Add adds a problem at given level, from given source package and about
+// given object (which can be empty), if err is not nil, and returns err,
+// so that it can be used to both report and return an error.
+func (pc *Collector) Add(level Levels, source, object string, err error) error {
+	if err == nil {
+		return nil
+	}
+	pr := Problem{Level: level, Source: source, Object: object, Err: err, Time: time.Now()}
+	pc.mu.Lock()
+	if pc.Max > 0 && len(pc.Problems) >= pc.Max {
+		pc.Dropped++
+	} else {
+		pc.Problems = append(pc.Problems, pr)
+	}
+	onAdd := pc.OnAdd
+	pc.mu.Unlock()
+	if pc.Log {
+		log.Println(pr.String())
+	}
+	if onAdd != nil {
+		onAdd(&pr)
+	}
+	return err
+}
+
+// Warn adds a [Warning] problem to the [Default] collector,
+// if err is not nil, and returns err.
+func Warn(source, object string, err error) error {
+	return Default.Add(Warning, source, object, err)
+}
+
+// Err adds an [Error] problem to the [Default] collector,
+// if err is not nil, and returns err.
+func Err(source, object string, err error) error {
+	return Default.Add(Error, source, object, err)
+}
+
+// All returns a copy of all of the problems.
+func (pc *Collector) All() []Problem {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return append([]Problem(nil), pc.Problems...)
+}
+
+// Level returns a copy of the problems at given level.
+func (pc *Collector) Level(level Levels) []Problem {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	var prs []Problem
+	for _, pr := range pc.Problems {
+		if pr.Level == level {
+			prs = append(prs, pr)
+		}
+	}
+	return prs
+}
+
+// Len returns the number of problems that have been kept.
+func (pc *Collector) Len() int {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	return len(pc.Problems)
+}
+
+// Err returns all of the [Error] level problems joined into one error,
+// or nil if there are none, so that headless runs can fail fast
+// after configuration, e.g.:
+//
+//	if err := problems.Default.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+func (pc *Collector) Err() error {
+	prs := pc.Level(Error)
+	if len(prs) == 0 {
+		return nil
+	}
+	errs := make([]error, len(prs))
+	for i := range prs {
+		errs[i] = &prs[i]
+	}
+	return errors.Join(errs...)
+}
+
+// Reset removes all of the problems.
+func (pc *Collector) Reset() {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.Problems = nil
+	pc.Dropped = 0
+}
+
+// Table returns a table of the problems, with one row per problem,
+// and columns Level, Source, Object, Message and Time, for display
+// as a consolidated problems panel in a GUI.
+func (pc *Collector) Table() *table.Table {
+	prs := pc.All()
+	dt := table.New()
+	dt.AddStringColumn("Level")
+	dt.AddStringColumn("Source")
+	dt.AddStringColumn("Object")
+	dt.AddStringColumn("Message")
+	dt.AddStringColumn("Time")
+	dt.SetNumRows(len(prs))
+	for i := range prs {
+		pr := &prs[i]
+		dt.Column("Level").SetString1D(pr.Level.String(), i)
+		dt.Column("Source").SetString1D(pr.Source, i)
+		dt.Column("Object").SetString1D(pr.Object, i)
+		dt.Column("Message").SetString1D(pr.Error(), i)
+		dt.Column("Time").SetString1D(pr.Time.Format(time.TimeOnly), i)
+	}
+	return dt
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package problems
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTest = errors.New("test error")
+
+func TestCollector(t *testing.T) {
+	pc := &Collector{Max: 3}
+	assert.NoError(t, pc.Add(Error, "test", "obj", nil))
+	assert.Equal(t, 0, pc.Len())
+	assert.NoError(t, pc.Err())
+
+	var added []string
+	pc.OnAdd = func(pr *Problem) { added = append(added, pr.String()) }
+	assert.EqualError(t, pc.Add(Warning, "params", "Base", errors.New("no match")), "no match")
+	assert.NoError(t, pc.Err())
+	assert.Equal(t, errTest, pc.Add(Error, "weights", "", errTest))
+	assert.Equal(t, []string{"Warning: params: Base: no match", "Error: weights: test error"}, added)
+	assert.Len(t, pc.Level(Warning), 1)
+	assert.Len(t, pc.Level(Error), 1)
+
+	err := pc.Err()
+	assert.ErrorIs(t, err, errTest)
+	var pr *Problem
+	assert.True(t, errors.As(err, &pr))
+	assert.Equal(t, "weights", pr.Source)
+
+	pc.Add(Error, "paths", "", errTest)
+	pc.Add(Error, "paths", "", errTest)
+	assert.Equal(t, 3, pc.Len())
+	assert.Equal(t, 1, pc.Dropped)
+
+	dt := pc.Table()
+	assert.Equal(t, 3, dt.NumRows())
+	assert.Equal(t, "Warning", dt.Column("Level").String1D(0))
+	assert.Equal(t, "no match", dt.Column("Message").String1D(0))
+	assert.Equal(t, "Base", dt.Column("Object").String1D(0))
+
+	pc.Reset()
+	assert.Equal(t, 0, pc.Len())
+	assert.Equal(t, 0, pc.Dropped)
+}
@@ -0,0 +1,13 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package problems
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/problems.Levels", IDName: "levels", Doc: "Levels are the levels of severity of a [Problem].", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/problems.Problem", IDName: "problem", Doc: "Problem is a warning or error reported to a [Collector].", Fields: []types.Field{{Name: "Level", Doc: "Level is the severity of the problem."}, {Name: "Source", Doc: "Source is the package or subsystem that reported the problem,\ne.g., \"params\" or \"weights\"."}, {Name: "Object", Doc: "Object is the name of the object that the problem is about,\ne.g., a layer or parameter sheet, if applicable."}, {Name: "Err", Doc: "Err is the error describing the problem, which can be a typed\nerror defined by the source package, for [errors.As]."}, {Name: "Time", Doc: "Time is when the problem was reported."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/problems.Collector", IDName: "collector", Doc: "Collector collects the problems reported during a run, so that they can\nbe checked and displayed together. It is safe for concurrent use.", Fields: []types.Field{{Name: "Max", Doc: "Max is the maximum number of problems to keep; any further problems\nare counted in Dropped but not kept. 0 means no limit."}, {Name: "Log", Doc: "Log prints each problem to the log as it is added, as was done\nbefore problems were collected."}, {Name: "OnAdd", Doc: "OnAdd, if set, is called with each problem as it is added,\ne.g., to update a GUI panel."}, {Name: "Problems", Doc: "Problems are the problems that have been added, in order."}, {Name: "Dropped", Doc: "Dropped is the number of problems that were not kept because of Max."}, {Name: "mu", Doc: "mu protects the problems."}}})
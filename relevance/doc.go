@@ -0,0 +1,14 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package relevance computes gradient-free input attribution maps using
+layer-wise relevance propagation (LRP), which distributes the activation
+of a target unit or layer backwards through the weights and activations
+of a network on a given trial, as layer-shaped tensors that can be
+displayed in a grid view, for interpreting trained models.
+*/
+package relevance
+
+//go:generate core generate -add-types
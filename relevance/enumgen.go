@@ -0,0 +1,46 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package relevance
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _RulesValues = []Rules{0, 1}
+
+// RulesN is the highest valid value for type Rules, plus one.
+const RulesN Rules = 2
+
+var _RulesValueMap = map[string]Rules{`Epsilon`: 0, `ZPlus`: 1}
+
+var _RulesDescMap = map[Rules]string{0: `Epsilon distributes the relevance in proportion to the contribution of each sending unit (activation times weight) to the net input of the receiving unit, with the small Epsilon added to the magnitude of the net input for stability. The contributions can be negative.`, 1: `ZPlus only uses the positive contributions, so that relevance is always positive, which is appropriate for networks with positive activations and mixed-sign weights.`}
+
+var _RulesMap = map[Rules]string{0: `Epsilon`, 1: `ZPlus`}
+
+// String returns the string representation of this Rules value.
+func (i Rules) String() string { return enums.String(i, _RulesMap) }
+
+// SetString sets the Rules value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Rules) SetString(s string) error { return enums.SetString(i, s, _RulesValueMap, "Rules") }
+
+// Int64 returns the Rules value as an int64.
+func (i Rules) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Rules value from an int64.
+func (i *Rules) SetInt64(in int64) { *i = Rules(in) }
+
+// Desc returns the description of the Rules value.
+func (i Rules) Desc() string { return enums.Desc(i, _RulesDescMap) }
+
+// RulesValues returns all possible values for the type Rules.
+func RulesValues() []Rules { return _RulesValues }
+
+// Values returns all possible values for the type Rules.
+func (i Rules) Values() []enums.Enum { return enums.Values(_RulesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Rules) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Rules) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Rules") }
@@ -0,0 +1,226 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package relevance
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// Rules are the LRP rules for distributing the relevance of a
+// receiving unit among its sending units.
+type Rules int32 //enums:enum
+
+const (
+	// Epsilon distributes the relevance in proportion to the contribution
+	// of each sending unit (activation times weight) to the net input of
+	// the receiving unit, with the small Epsilon added to the magnitude of
+	// the net input for stability. The contributions can be negative.
+	Epsilon Rules = iota
+
+	// ZPlus only uses the positive contributions, so that relevance is
+	// always positive, which is appropriate for networks with positive
+	// activations and mixed-sign weights.
+	ZPlus
+)
+
+// Relevance computes layer-wise relevance propagation (LRP) maps,
+// distributing the activation of a target unit or layer backwards
+// through the forward pathways of a network, from each receiving unit
+// to its sending units in proportion to their contributions, down to the
+// input layers. Forward pathways are those from a layer earlier in the
+// network (with a lower index) to a later one, which are processed in
+// reverse order of the layers. Each layer has a map of the relevance
+// passed to it, and for a feedforward chain of layers, the total relevance
+// of each layer is the same (for the Epsilon rule, with a small Epsilon).
+type Relevance struct {
+
+	// Rule is the rule for distributing the relevance.
+	Rule Rules
+
+	// Var is the unit variable for the activations.
+	Var string
+
+	// WtVar is the synaptic variable for the weights.
+	WtVar string
+
+	// Epsilon is added to the magnitude of the net input of each
+	// receiving unit for the Epsilon rule, for stability.
+	Epsilon float64 `default:"0.01"`
+
+	// Maps are the relevance maps of each layer, from the last Compute,
+	// with the same shape as the layer.
+	Maps map[string]*tensor.Float64 `display:"-"`
+
+	// acts are the activations of each layer.
+	acts map[string][]float32
+
+	// vals are the unit values for a layer.
+	vals []float32
+}
+
+// New returns a new [Relevance] using the Epsilon rule,
+// with the Act and Wt variables.
+func New() *Relevance {
+	return &Relevance{Rule: Epsilon, Var: "Act", WtVar: "Wt", Epsilon: 0.01}
+}
+
+// Compute computes the relevance maps for the current state of given
+// network, for given data parallel index, starting from the activation
+// of given unit of the target layer, or of all of its units if unit is -1,
+// which must be called after running the trial of interest.
+func (rv *Relevance) Compute(net emer.Network, target string, unit, di int) error {
+	tl, err := net.AsEmer().EmerLayerByName(target)
+	if err != nil {
+		return fmt.Errorf("relevance.Compute: %w", err)
+	}
+	nlay := net.NumLayers()
+	rv.Maps = make(map[string]*tensor.Float64, nlay)
+	rv.acts = make(map[string][]float32, nlay)
+	for li := range nlay {
+		lb := net.EmerLayer(li).AsEmer()
+		rv.Maps[lb.Name] = tensor.NewFloat64(lb.Shape.Sizes...)
+	}
+	tb := tl.AsEmer()
+	tacts, err := rv.layerActs(tl, di)
+	if err != nil {
+		return err
+	}
+	trel := rv.Maps[tb.Name].Values
+	if unit >= len(trel) {
+		return fmt.Errorf("relevance.Compute: unit %d is out of range for layer %q with %d units", unit, tb.Name, len(trel))
+	}
+	for ui, act := range tacts {
+		if unit < 0 || ui == unit {
+			trel[ui] = float64(act)
+		}
+	}
+	for li := tb.Index; li > 0; li-- {
+		ly := net.EmerLayer(li)
+		if err := rv.propagate(ly, di); err != nil {
+			return err
+		}
+	}
+	rv.acts = nil
+	return nil
+}
+
+// layerActs returns the activations of given layer, getting them if needed.
+func (rv *Relevance) layerActs(ly emer.Layer, di int) ([]float32, error) {
+	lb := ly.AsEmer()
+	if acts, ok := rv.acts[lb.Name]; ok {
+		return acts, nil
+	}
+	if err := lb.UnitValues(&rv.vals, rv.Var, di); err != nil {
+		return nil, fmt.Errorf("relevance.Compute: layer %q: %w", lb.Name, err)
+	}
+	acts := slices.Clone(rv.vals)
+	rv.acts[lb.Name] = acts
+	return acts, nil
+}
+
+// forwardPaths returns the forward receiving pathways of given layer.
+func forwardPaths(ly emer.Layer) []emer.Path {
+	var pts []emer.Path
+	idx := ly.AsEmer().Index
+	for pi := range ly.NumRecvPaths() {
+		pt := ly.RecvPath(pi)
+		if !pt.AsEmer().Off && pt.SendLayer().AsEmer().Index < idx {
+			pts = append(pts, pt)
+		}
+	}
+	return pts
+}
+
+// contrib returns the contribution of given activation and weight
+// to the net input, according to the Rule.
+func (rv *Relevance) contrib(act, wt float32) float64 {
+	z := float64(act) * float64(wt)
+	if math.IsNaN(z) || (rv.Rule == ZPlus && z < 0) {
+		return 0
+	}
+	return z
+}
+
+// propagate distributes the relevance of the units of given layer
+// to the sending units of its forward pathways.
+func (rv *Relevance) propagate(ly emer.Layer, di int) error {
+	lb := ly.AsEmer()
+	rel := rv.Maps[lb.Name].Values
+	pts := forwardPaths(ly)
+	if len(pts) == 0 {
+		return nil
+	}
+	zs := make([]float64, len(rel))
+	for _, pt := range pts {
+		sacts, err := rv.layerActs(pt.SendLayer(), di)
+		if err != nil {
+			return err
+		}
+		err = pt.AsEmer().RangeSyns(rv.WtVar, func(sidx, ridx int, wt float32) bool {
+			zs[ridx] += rv.contrib(sacts[sidx], wt)
+			return true
+		})
+		if err != nil {
+			return fmt.Errorf("relevance.Compute: pathway %q: %w", pt.Label(), err)
+		}
+	}
+	for ri, z := range zs {
+		switch {
+		case rv.Rule == Epsilon && z >= 0:
+			zs[ri] = z + rv.Epsilon
+		case rv.Rule == Epsilon:
+			zs[ri] = z - rv.Epsilon
+		}
+	}
+	for _, pt := range pts {
+		sacts := rv.acts[pt.SendLayer().Label()]
+		srel := rv.Maps[pt.SendLayer().Label()].Values
+		pt.AsEmer().RangeSyns(rv.WtVar, func(sidx, ridx int, wt float32) bool {
+			if zs[ridx] != 0 {
+				srel[sidx] += rv.contrib(sacts[sidx], wt) / zs[ridx] * rel[ridx]
+			}
+			return true
+		})
+	}
+	return nil
+}
+
+// Map returns the relevance map of given layer, from the last Compute,
+// with the same shape as the layer, or nil if not found.
+func (rv *Relevance) Map(layer string) *tensor.Float64 {
+	return rv.Maps[layer]
+}
+
+// Table returns a table with one row, and a column with the relevance
+// map of each of given layers (e.g., the input layers), with the same
+// shape as the layer, for display in a grid view.
+func (rv *Relevance) Table(layers ...string) (*table.Table, error) {
+	dt := table.New()
+	metadata.SetName(dt, "Relevance")
+	tensor.SetPrecision(dt, 4)
+	for _, lnm := range layers {
+		mp := rv.Maps[lnm]
+		if mp == nil {
+			return nil, fmt.Errorf("relevance.Table: layer %q not found", lnm)
+		}
+		dt.AddFloat64Column(lnm, mp.Shape().Sizes...)
+	}
+	dt.SetNumRows(1)
+	for _, lnm := range layers {
+		mp := rv.Maps[lnm]
+		cl := dt.Column(lnm)
+		for i, v := range mp.Values {
+			cl.SetFloatRow(v, 0, i)
+		}
+	}
+	return dt, nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package relevance
+
+import (
+	"testing"
+
+	"github.com/emer/emergent/v2/bp"
+	"github.com/emer/emergent/v2/paths"
+	"github.com/stretchr/testify/assert"
+)
+
+// newNet returns a bp network with weights set from the given
+// weights, by receiving then sending unit.
+func newNet(t *testing.T, ih, ho [][]float32) *bp.Network {
+	net := bp.NewNetwork("LRP")
+	in := net.AddLayer2D("Input", 1, 2, bp.InputLayer)
+	hid := net.AddLayer2D("Hidden", 1, 2, bp.HiddenLayer)
+	out := net.AddLayer2D("Output", 1, 2, bp.TargetLayer)
+	pih := net.ConnectLayers(in, hid, paths.NewFull(), bp.ForwardPath)
+	pho := net.ConnectLayers(hid, out, paths.NewFull(), bp.ForwardPath)
+	net.ConnectLayers(out, hid, paths.NewFull(), bp.RecurrentPath)
+	assert.NoError(t, net.Build())
+	ctx := net.NewContext()
+	for _, pw := range []struct {
+		pt  *bp.Path
+		wts [][]float32
+	}{{pih, ih}, {pho, ho}} {
+		for ri, rw := range pw.wts {
+			for si, wt := range rw {
+				pw.pt.Wts[pw.pt.SynIndex(si, ri)] = wt
+			}
+		}
+	}
+	assert.NoError(t, net.ApplyInput("Input", []float32{1, 0.5}))
+	net.Forward(ctx)
+	return net
+}
+
+func TestEpsilon(t *testing.T) {
+	net := newNet(t, [][]float32{{0.4, 0.8}, {1, -1}}, [][]float32{{1, 2}, {0.5, 0.5}})
+	rv := New()
+	rv.Epsilon = 1e-9
+	assert.NoError(t, rv.Compute(net, "Output", 0, 0))
+	hid, _ := net.LayerByName("Hidden")
+	out, _ := net.LayerByName("Output")
+	y := float64(out.Act[0])
+	h0, h1 := float64(hid.Act[0]), float64(hid.Act[1])
+	hrel := rv.Map("Hidden").Values
+	assert.InDelta(t, y*h0/(h0+2*h1), hrel[0], 1e-6)
+	assert.InDelta(t, y*2*h1/(h0+2*h1), hrel[1], 1e-6)
+	assert.Equal(t, []float64{y, 0}, rv.Map("Output").Values)
+
+	// input relevance of hidden unit 0 is split equally (0.4 vs 0.5 * 0.8)
+	// and hidden unit 1 has contributions 1 and -0.5
+	irel := rv.Map("Input").Values
+	assert.InDelta(t, hrel[0]/2+hrel[1]*2, irel[0], 1e-6)
+	assert.InDelta(t, hrel[0]/2-hrel[1], irel[1], 1e-6)
+	assert.InDelta(t, y, irel[0]+irel[1], 1e-6) // conserved
+	assert.Equal(t, []int{1, 2}, rv.Map("Input").Shape().Sizes)
+
+	// all output units
+	assert.NoError(t, rv.Compute(net, "Output", -1, 0))
+	irel = rv.Map("Input").Values
+	assert.InDelta(t, y+float64(out.Act[1]), irel[0]+irel[1], 1e-6)
+
+	dt, err := rv.Table("Input", "Hidden")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dt.NumRows())
+	assert.Equal(t, irel[1], dt.Column("Input").FloatRow(0, 1))
+	_, err = rv.Table("Missing")
+	assert.Error(t, err)
+
+	assert.Error(t, rv.Compute(net, "Missing", 0, 0))
+	assert.Error(t, rv.Compute(net, "Output", 2, 0))
+	rv.WtVar = "Missing"
+	assert.Error(t, rv.Compute(net, "Output", 0, 0))
+}
+
+func TestZPlus(t *testing.T) {
+	net := newNet(t, [][]float32{{0.4, 0.8}, {1, -1}}, [][]float32{{1, 2}, {0.5, 0.5}})
+	rv := New()
+	rv.Rule = ZPlus
+	assert.NoError(t, rv.Compute(net, "Output", 0, 0))
+	hrel := rv.Map("Hidden").Values
+	irel := rv.Map("Input").Values
+	// hidden unit 1 only passes relevance to input unit 0
+	assert.InDelta(t, hrel[0]/2+hrel[1], irel[0], 1e-6)
+	assert.InDelta(t, hrel[0]/2, irel[1], 1e-6)
+	for _, r := range irel {
+		assert.GreaterOrEqual(t, r, 0.0)
+	}
+}
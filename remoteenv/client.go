@@ -0,0 +1,150 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package remoteenv
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/env"
+)
+
+// Env is a client for a remote env served by a [Server], which implements
+// [env.Env] by calling the corresponding methods on the server, so that it
+// can be used locally like any other env. The env.Env methods do not return
+// errors, so the last error is recorded in Err, and Step returns false and
+// State returns nil when the call fails.
+type Env struct {
+
+	// Name is the Label of the remote env.
+	Name string
+
+	// Desc is the String description of the current state of the remote env,
+	// as of the last Init or Step.
+	Desc string
+
+	// Counters are the counters of the remote env by name,
+	// as of the last Init or Step.
+	Counters map[string]env.Counter
+
+	// Err is the last error from calling the server, if any.
+	Err error `display:"-"`
+
+	conn net.Conn
+	rd   *bufio.Reader
+	mu   sync.Mutex
+}
+
+// Dial connects to the server at given network type ("unix" or "tcp")
+// and address, returning an [Env] with the Label, String and Counters
+// of the remote env.
+func Dial(network, address string) (*Env, error) {
+	c, err := net.Dial(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("remoteenv.Dial: %w", err)
+	}
+	ev := &Env{conn: c, rd: bufio.NewReaderSize(c, 1<<16)}
+	resp, err := ev.Do(&Request{Method: MethodInfo})
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("remoteenv.Dial: %w", err)
+	}
+	ev.setInfo(resp)
+	return ev, nil
+}
+
+// Close closes the connection to the server.
+func (ev *Env) Close() error {
+	return ev.conn.Close()
+}
+
+// Do sends given request to the server and returns the response,
+// with an error if it could not be sent or the method failed.
+func (ev *Env) Do(req *Request) (*Response, error) {
+	ev.mu.Lock()
+	defer ev.mu.Unlock()
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ev.conn.Write(append(b, '\n')); err != nil {
+		return nil, err
+	}
+	line, err := ev.rd.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	resp := &Response{}
+	if err := json.Unmarshal(line, resp); err != nil {
+		return nil, err
+	}
+	if !resp.OK {
+		return resp, errors.New(resp.Error)
+	}
+	return resp, nil
+}
+
+// call calls Do, recording any error in Err.
+func (ev *Env) call(req *Request) *Response {
+	resp, err := ev.Do(req)
+	if err != nil {
+		ev.Err = fmt.Errorf("remoteenv.Env %q: %s: %w", ev.Name, req.Method, err)
+		return nil
+	}
+	return resp
+}
+
+// setInfo sets the info from given response.
+func (ev *Env) setInfo(resp *Response) {
+	ev.Name, ev.Desc, ev.Counters = resp.Label, resp.String, resp.Counters
+}
+
+func (ev *Env) Label() string  { return ev.Name }
+func (ev *Env) String() string { return ev.Desc }
+
+func (ev *Env) Init(run int) {
+	ev.Err = nil
+	if resp := ev.call(&Request{Method: MethodInit, Run: run}); resp != nil {
+		ev.setInfo(resp)
+	}
+}
+
+func (ev *Env) Step() bool {
+	resp := ev.call(&Request{Method: MethodStep})
+	if resp == nil {
+		return false
+	}
+	ev.setInfo(resp)
+	return resp.Step
+}
+
+// State returns a copy of the values of given element of the remote env,
+// as a [tensor.Float64], or a [tensor.String] for strings.
+func (ev *Env) State(element string) tensor.Values {
+	resp := ev.call(&Request{Method: MethodState, Element: element})
+	if resp == nil {
+		return nil
+	}
+	return resp.State.Tensor()
+}
+
+func (ev *Env) Action(element string, input tensor.Values) {
+	ev.call(&Request{Method: MethodAction, Element: element, Input: NewTensor(input)})
+}
+
+// Counter returns the remote env counter with given name (e.g., Trial),
+// as of the last Init or Step, and false if not found.
+func (ev *Env) Counter(name string) (env.Counter, bool) {
+	ct, ok := ev.Counters[name]
+	return ct, ok
+}
+
+// Compile-time check that implements Env interface
+var _ env.Env = (*Env)(nil)
@@ -0,0 +1,16 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package remoteenv provides a client / server protocol mirroring [env.Env]
+(Init, Step, State, Action, and the env counters), so that heavy
+environments (game engines, robotics sims) can run in a separate process,
+or on another machine. The [Server] serves any env.Env on a unix socket or
+TCP address, and the client [Env] implements env.Env by calling a remote
+server. Each [Request] and [Response] is one line of JSON, so servers
+can also be written in other languages (e.g., Python).
+*/
+package remoteenv
+
+//go:generate core generate -add-types
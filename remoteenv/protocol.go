@@ -0,0 +1,205 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package remoteenv
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/env"
+)
+
+// Methods are the names of the methods in a [Request],
+// corresponding to the methods of [env.Env].
+const (
+	// MethodInfo returns the Label, String and Counters of the env.
+	MethodInfo = "info"
+
+	// MethodInit calls Init with the Run of the request,
+	// and returns the same as MethodInfo.
+	MethodInit = "init"
+
+	// MethodStep calls Step, returning its result as Step,
+	// and the same as MethodInfo.
+	MethodStep = "step"
+
+	// MethodState returns the State of the Element of the request,
+	// which is nil if not found.
+	MethodState = "state"
+
+	// MethodAction calls Action with the Element and Input of the request.
+	MethodAction = "action"
+)
+
+// Request is a call of a method of the env, encoded as one line of JSON,
+// e.g., {"Method": "state", "Element": "Input"}
+type Request struct {
+
+	// Method is the name of the method: info, init, step, state, or action.
+	Method string
+
+	// Run is the run number for init.
+	Run int `json:",omitempty"`
+
+	// Element is the name of the State or Action element.
+	Element string `json:",omitempty"`
+
+	// Input are the values for action.
+	Input *Tensor `json:",omitempty"`
+}
+
+// Response is the response to a [Request], encoded as one line of JSON.
+type Response struct {
+
+	// OK is true if the method succeeded.
+	OK bool
+
+	// Error is the error message if the method failed.
+	Error string `json:",omitempty"`
+
+	// Label is the Label of the env, for info, init and step.
+	Label string `json:",omitempty"`
+
+	// String is the String description of the current state of the env,
+	// for info, init and step.
+	String string `json:",omitempty"`
+
+	// Step is the result of step, which is false if there are
+	// no further inputs available.
+	Step bool `json:",omitempty"`
+
+	// Counters are the env counters by name, for info, init and step,
+	// which are the exported fields of type [env.Counter] in the env.
+	Counters map[string]env.Counter `json:",omitempty"`
+
+	// State are the values for state, which is nil if not found.
+	State *Tensor `json:",omitempty"`
+}
+
+// Tensor is the JSON encoding of tensor values, with the shape sizes,
+// and either the numeric Values, where NaN is encoded as null,
+// or the Strings of a string tensor.
+type Tensor struct {
+
+	// Shape are the sizes of the dimensions of the tensor.
+	Shape []int
+
+	// Values are the numeric values, for a numeric tensor.
+	Values []float64
+
+	// Strings are the string values, for a string tensor.
+	Strings []string
+}
+
+// NewTensor returns a new [Tensor] with a copy of given tensor values,
+// or nil if it is nil.
+func NewTensor(vals tensor.Values) *Tensor {
+	if vals == nil {
+		return nil
+	}
+	tr := &Tensor{Shape: vals.ShapeSizes()}
+	n := vals.Len()
+	if vals.IsString() {
+		tr.Strings = make([]string, n)
+		for i := range n {
+			tr.Strings[i] = vals.String1D(i)
+		}
+		return tr
+	}
+	tr.Values = make([]float64, n)
+	for i := range n {
+		tr.Values[i] = vals.Float1D(i)
+	}
+	return tr
+}
+
+// Tensor returns the values as a new tensor: a [tensor.String] for
+// string values, and otherwise a [tensor.Float64], or nil if tr is nil.
+func (tr *Tensor) Tensor() tensor.Values {
+	if tr == nil {
+		return nil
+	}
+	if tr.Strings != nil {
+		ts := tensor.NewString(tr.Shape...)
+		copy(ts.Values, tr.Strings)
+		return ts
+	}
+	tf := tensor.NewFloat64(tr.Shape...)
+	copy(tf.Values, tr.Values)
+	return tf
+}
+
+// jsonTensor is the JSON encoding of a [Tensor], with null for NaN values.
+type jsonTensor struct {
+	Shape   []int
+	Values  []*float64 `json:",omitempty"`
+	Strings []string   `json:",omitempty"`
+}
+
+// MarshalJSON encodes the tensor, with null for NaN values,
+// which are not valid JSON numbers.
+func (tr *Tensor) MarshalJSON() ([]byte, error) {
+	jt := jsonTensor{Shape: tr.Shape, Strings: tr.Strings}
+	if tr.Values != nil {
+		jt.Values = make([]*float64, len(tr.Values))
+		for i := range tr.Values {
+			if v := tr.Values[i]; !math.IsNaN(v) && !math.IsInf(v, 0) {
+				jt.Values[i] = &tr.Values[i]
+			}
+		}
+	}
+	return json.Marshal(jt)
+}
+
+// UnmarshalJSON decodes the tensor, with NaN for null values.
+func (tr *Tensor) UnmarshalJSON(b []byte) error {
+	var jt jsonTensor
+	if err := json.Unmarshal(b, &jt); err != nil {
+		return err
+	}
+	tr.Shape, tr.Strings, tr.Values = jt.Shape, jt.Strings, nil
+	if jt.Values != nil {
+		tr.Values = make([]float64, len(jt.Values))
+		for i, v := range jt.Values {
+			if v == nil {
+				tr.Values[i] = math.NaN()
+			} else {
+				tr.Values[i] = *v
+			}
+		}
+	}
+	return nil
+}
+
+// counterType is the type of [env.Counter].
+var counterType = reflect.TypeOf(env.Counter{})
+
+// Counters returns the exported fields of type [env.Counter] of
+// given env, which must be a pointer to a struct, by field name,
+// including those of embedded structs, or nil if there are none.
+func Counters(ev env.Env) map[string]env.Counter {
+	v := reflect.ValueOf(ev)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	v = v.Elem()
+	var cts map[string]env.Counter
+	for _, f := range reflect.VisibleFields(v.Type()) {
+		if !f.IsExported() || f.Type != counterType {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		if !fv.CanInterface() {
+			continue
+		}
+		if cts == nil {
+			cts = make(map[string]env.Counter)
+		}
+		cts[f.Name] = fv.Interface().(env.Counter)
+	}
+	return cts
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package remoteenv
+
+import (
+	"encoding/json"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/env"
+	"github.com/stretchr/testify/assert"
+)
+
+// actEnv is a FixedTable that records its last action.
+type actEnv struct {
+	env.FixedTable
+	Act tensor.Values
+}
+
+func (ev *actEnv) Action(element string, input tensor.Values) {
+	ev.Act = input
+}
+
+func newEnv() *actEnv {
+	dt := table.New()
+	dt.AddStringColumn("Name")
+	dt.AddFloat32Column("Input", 2, 2)
+	dt.SetNumRows(3)
+	for row := range 3 {
+		dt.Column("Name").SetStringRow([]string{"A", "B", "C"}[row], row, 0)
+		for i := range 4 {
+			dt.Column("Input").SetFloatRow(float64(row*4+i), row, i)
+		}
+	}
+	dt.Column("Input").SetFloatRow(math.NaN(), 2, 3)
+	ev := &actEnv{}
+	ev.Name = "Train"
+	ev.Config(table.NewView(dt))
+	ev.Sequential = true
+	return ev
+}
+
+func TestRemote(t *testing.T) {
+	for _, network := range []string{"unix", "tcp"} {
+		addr := "127.0.0.1:0"
+		if network == "unix" {
+			addr = filepath.Join(t.TempDir(), "env.sock")
+		}
+		lev := newEnv()
+		sv := NewServer(network, addr, lev)
+		assert.NoError(t, sv.Start())
+
+		ev, err := Dial(network, sv.Addr())
+		assert.NoError(t, err)
+		assert.Equal(t, "Train", ev.Label())
+
+		ev.Init(0)
+		assert.NoError(t, ev.Err)
+		for row := range 3 {
+			assert.True(t, ev.Step())
+			ct, ok := ev.Counter("Trial")
+			assert.True(t, ok)
+			assert.Equal(t, row, ct.Cur)
+			assert.Equal(t, []string{"A", "B", "C"}[row], ev.String())
+			st := ev.State("Input")
+			assert.Equal(t, []int{2, 2}, st.ShapeSizes())
+			for i := range 4 {
+				if row == 2 && i == 3 {
+					assert.True(t, math.IsNaN(st.Float1D(i)))
+					continue
+				}
+				assert.Equal(t, float64(row*4+i), st.Float1D(i))
+			}
+			nm := ev.State("Name")
+			assert.True(t, nm.IsString())
+			assert.Equal(t, []string{"A", "B", "C"}[row], nm.String1D(0))
+		}
+		assert.Nil(t, ev.State("Bad"))
+
+		ev.Action("Output", tensor.NewFloat32FromValues(1, 0))
+		assert.NoError(t, ev.Err)
+		assert.Equal(t, []float64{1, 0}, lev.Act.(*tensor.Float64).Values)
+
+		resp, err := ev.Do(&Request{Method: "jump"})
+		assert.Error(t, err)
+		assert.Contains(t, resp.Error, "unknown method")
+
+		assert.NoError(t, ev.Close())
+		assert.NoError(t, sv.Close())
+		ev.Step()
+		assert.Error(t, ev.Err)
+	}
+}
+
+func TestTensorJSON(t *testing.T) {
+	tr := NewTensor(tensor.NewFloat64FromValues(1, math.NaN(), 3))
+	b, err := json.Marshal(tr)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Shape":[3],"Values":[1,null,3]}`, string(b))
+	var rt Tensor
+	assert.NoError(t, json.Unmarshal(b, &rt))
+	assert.Equal(t, 1.0, rt.Values[0])
+	assert.True(t, math.IsNaN(rt.Values[1]))
+	assert.Nil(t, NewTensor(nil).Tensor())
+}
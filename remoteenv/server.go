@@ -0,0 +1,192 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package remoteenv
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/emer/emergent/v2/env"
+)
+
+// Server serves an [env.Env] on a unix socket or TCP address, executing
+// each [Request] encoded as one line of JSON by calling the corresponding
+// method of the env, and writing back a [Response] as one line of JSON.
+// Requests from all connections are executed one at a time, so multiple
+// clients share the same env state.
+type Server struct {
+
+	// Network is the network type: "unix" for a unix domain socket,
+	// or "tcp" for a TCP address.
+	Network string
+
+	// Address is the socket file name for unix, or host:port for tcp
+	// (use a port of 0 to pick an available port, and see Addr).
+	Address string
+
+	// Env is the env that is served.
+	Env env.Env
+
+	listener net.Listener
+	envMu    sync.Mutex
+	mu       sync.Mutex
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+// NewServer returns a new [Server] for given network type ("unix" or "tcp"),
+// address, and env. Call Start to start it.
+func NewServer(network, address string, ev env.Env) *Server {
+	return &Server{Network: network, Address: address, Env: ev}
+}
+
+// Start starts listening for connections, which are served in separate
+// goroutines, returning an error if the listener cannot be created.
+// For a unix socket, any existing file at Address is removed first.
+func (sv *Server) Start() error {
+	if sv.Network == "unix" {
+		os.Remove(sv.Address)
+	}
+	ln, err := net.Listen(sv.Network, sv.Address)
+	if err != nil {
+		return fmt.Errorf("remoteenv.Server: %w", err)
+	}
+	sv.listener = ln
+	sv.conns = make(map[net.Conn]struct{})
+	sv.wg.Add(1)
+	go sv.accept()
+	return nil
+}
+
+// Addr returns the address the server is listening on,
+// which is useful for a tcp port of 0. Returns "" if not started.
+func (sv *Server) Addr() string {
+	if sv.listener == nil {
+		return ""
+	}
+	return sv.listener.Addr().String()
+}
+
+// Close stops listening, closes all connections, and waits for them to finish.
+func (sv *Server) Close() error {
+	if sv.listener == nil {
+		return nil
+	}
+	err := sv.listener.Close()
+	sv.mu.Lock()
+	for c := range sv.conns {
+		c.Close()
+	}
+	sv.mu.Unlock()
+	sv.wg.Wait()
+	sv.listener = nil
+	if sv.Network == "unix" {
+		os.Remove(sv.Address)
+	}
+	return err
+}
+
+// accept accepts connections until the listener is closed.
+func (sv *Server) accept() {
+	defer sv.wg.Done()
+	for {
+		c, err := sv.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Println("remoteenv.Server:", err)
+			}
+			return
+		}
+		sv.mu.Lock()
+		sv.conns[c] = struct{}{}
+		sv.mu.Unlock()
+		sv.wg.Add(1)
+		go sv.serve(c)
+	}
+}
+
+// serve reads requests from given connection and writes the responses.
+func (sv *Server) serve(c net.Conn) {
+	defer func() {
+		sv.mu.Lock()
+		delete(sv.conns, c)
+		sv.mu.Unlock()
+		c.Close()
+		sv.wg.Done()
+	}()
+	sc := bufio.NewScanner(c)
+	sc.Buffer(nil, 64<<20)
+	for sc.Scan() {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var req Request
+		var resp Response
+		if err := json.Unmarshal(sc.Bytes(), &req); err != nil {
+			resp = Response{Error: "invalid request: " + err.Error()}
+		} else {
+			resp = sv.Do(&req)
+		}
+		b, err := json.Marshal(resp)
+		if err != nil {
+			b, _ = json.Marshal(Response{Error: "invalid result: " + err.Error()})
+		}
+		if _, err := c.Write(append(b, '\n')); err != nil {
+			return
+		}
+	}
+}
+
+// Do executes given request on the env, returning the response. This is
+// called for each request received by the server, and can also be called
+// directly. Only one request is executed at a time, and a panic in the
+// env is returned as an error. For envs that implement [env.Shaper],
+// the existence of an element is checked before getting its State.
+func (sv *Server) Do(req *Request) (resp Response) {
+	sv.envMu.Lock()
+	defer sv.envMu.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			resp = Response{Error: fmt.Sprintf("%s: panic: %v", req.Method, r)}
+		}
+	}()
+	ev := sv.Env
+	switch req.Method {
+	case MethodInfo:
+	case MethodInit:
+		ev.Init(req.Run)
+	case MethodStep:
+		ok := ev.Step()
+		resp := sv.info()
+		resp.Step = ok
+		return resp
+	case MethodState:
+		if shp, ok := ev.(env.Shaper); ok && shp.StateShape(req.Element) == nil {
+			return Response{OK: true}
+		}
+		return Response{OK: true, State: NewTensor(ev.State(req.Element))}
+	case MethodAction:
+		if req.Input == nil {
+			return Response{Error: fmt.Sprintf("action %q: no Input values", req.Element)}
+		}
+		ev.Action(req.Element, req.Input.Tensor())
+		return Response{OK: true}
+	default:
+		return Response{Error: fmt.Sprintf("unknown method %q: must be one of: info, init, step, state, action", req.Method)}
+	}
+	return sv.info()
+}
+
+// info returns the response for the info method.
+func (sv *Server) info() Response {
+	ev := sv.Env
+	return Response{OK: true, Label: ev.Label(), String: ev.String(), Counters: Counters(ev)}
+}
@@ -0,0 +1,17 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package remoteenv
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/remoteenv.Env", IDName: "env", Doc: "Env is a client for a remote env served by a [Server], which implements\n[env.Env] by calling the corresponding methods on the server, so that it\ncan be used locally like any other env. The env.Env methods do not return\nerrors, so the last error is recorded in Err, and Step returns false and\nState returns nil when the call fails.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Fields: []types.Field{{Name: "Name", Doc: "Name is the Label of the remote env."}, {Name: "Desc", Doc: "Desc is the String description of the current state of the remote env,\nas of the last Init or Step."}, {Name: "Counters", Doc: "Counters are the counters of the remote env by name,\nas of the last Init or Step."}, {Name: "Err", Doc: "Err is the last error from calling the server, if any."}, {Name: "conn"}, {Name: "rd"}, {Name: "mu"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/remoteenv.Request", IDName: "request", Doc: "Request is a call of a method of the env, encoded as one line of JSON,\ne.g., {\"Method\": \"state\", \"Element\": \"Input\"}", Fields: []types.Field{{Name: "Method", Doc: "Method is the name of the method: info, init, step, state, or action."}, {Name: "Run", Doc: "Run is the run number for init."}, {Name: "Element", Doc: "Element is the name of the State or Action element."}, {Name: "Input", Doc: "Input are the values for action."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/remoteenv.Response", IDName: "response", Doc: "Response is the response to a [Request], encoded as one line of JSON.", Fields: []types.Field{{Name: "OK", Doc: "OK is true if the method succeeded."}, {Name: "Error", Doc: "Error is the error message if the method failed."}, {Name: "Label", Doc: "Label is the Label of the env, for info, init and step."}, {Name: "String", Doc: "String is the String description of the current state of the env,\nfor info, init and step."}, {Name: "Step", Doc: "Step is the result of step, which is false if there are\nno further inputs available."}, {Name: "Counters", Doc: "Counters are the env counters by name, for info, init and step,\nwhich are the exported fields of type [env.Counter] in the env."}, {Name: "State", Doc: "State are the values for state, which is nil if not found."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/remoteenv.Server", IDName: "server", Doc: "Server serves an [env.Env] on a unix socket or TCP address, executing\neach [Request] encoded as one line of JSON by calling the corresponding\nmethod of the env, and writing back a [Response] as one line of JSON.\nRequests from all connections are executed one at a time, so multiple\nclients share the same env state.", Fields: []types.Field{{Name: "Network", Doc: "Network is the network type: \"unix\" for a unix domain socket,\nor \"tcp\" for a TCP address."}, {Name: "Address", Doc: "Address is the socket file name for unix, or host:port for tcp\n(use a port of 0 to pick an available port, and see Addr)."}, {Name: "Env", Doc: "Env is the env that is served."}, {Name: "listener"}, {Name: "envMu"}, {Name: "mu"}, {Name: "conns"}, {Name: "wg"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/remoteenv.Tensor", IDName: "tensor", Doc: "Tensor is the JSON encoding of tensor values, with the shape sizes,\nand either the numeric Values, where NaN is encoded as null,\nor the Strings of a string tensor.", Fields: []types.Field{{Name: "Shape", Doc: "Shape are the sizes of the dimensions of the tensor."}, {Name: "Values", Doc: "Values are the numeric values, for a numeric tensor."}, {Name: "Strings", Doc: "Strings are the string values, for a string tensor."}}})
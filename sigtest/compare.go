@@ -0,0 +1,121 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sigtest
+
+import (
+	"fmt"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+// Compare has parameters for comparing a column of results (e.g., the
+// final performance of each run) grouped by condition in a table, for
+// all pairs of conditions, using [Compare.Table].
+type Compare struct {
+
+	// Paired uses paired tests (paired t-test and signed-rank), where
+	// the values for each condition are paired in row order
+	// (e.g., by run, with the same random seeds across conditions),
+	// so that each condition must have the same number of values.
+	Paired bool
+
+	// NBoot is the number of bootstrap resamples for the confidence
+	// interval of the difference in means.
+	NBoot int `default:"1000"`
+
+	// Conf is the confidence level for the bootstrap confidence interval.
+	Conf float64 `default:"0.95"`
+
+	// Rand is the random number source for bootstrapping (nil = global).
+	Rand randx.Rand `display:"-"`
+}
+
+// NewCompare returns a new [Compare] with default parameters.
+func NewCompare(paired bool) *Compare {
+	return &Compare{Paired: paired, NBoot: 1000, Conf: 0.95}
+}
+
+// Groups returns the values in given value column for each condition
+// in given condition column of given table, and the conditions in
+// the order first encountered.
+func Groups(dt *table.Table, condCol, valCol string) (map[string][]float64, []string, error) {
+	cc := dt.Column(condCol)
+	if cc == nil {
+		return nil, nil, fmt.Errorf("sigtest.Groups: condition column %q not found", condCol)
+	}
+	vc := dt.Column(valCol)
+	if vc == nil {
+		return nil, nil, fmt.Errorf("sigtest.Groups: value column %q not found", valCol)
+	}
+	groups := make(map[string][]float64)
+	var conds []string
+	for ri := range dt.NumRows() {
+		cond := cc.StringRow(ri, 0)
+		if _, has := groups[cond]; !has {
+			conds = append(conds, cond)
+		}
+		groups[cond] = append(groups[cond], vc.FloatRow(ri, 0))
+	}
+	return groups, conds, nil
+}
+
+// Table returns a table of the results of comparing the values in given
+// value column between each pair of conditions in given condition column
+// of given table, with columns: CondA, CondB, NA, NB, MeanA, MeanB,
+// Diff (MeanA - MeanB), CILo and CIHi (the bootstrap confidence interval
+// of Diff), T, DF, and PT (t-test), and W, Z, and PW (Wilcoxon rank-sum,
+// or signed-rank if Paired).
+func (cp *Compare) Table(dt *table.Table, condCol, valCol string) (*table.Table, error) {
+	groups, conds, err := Groups(dt, condCol, valCol)
+	if err != nil {
+		return nil, err
+	}
+	rt := table.New()
+	metadata.SetName(rt, fmt.Sprintf("sigtest: %s by %s", valCol, condCol))
+	tensor.SetPrecision(rt, 4)
+	rt.AddStringColumn("CondA")
+	rt.AddStringColumn("CondB")
+	rt.AddIntColumn("NA")
+	rt.AddIntColumn("NB")
+	fcols := []string{"MeanA", "MeanB", "Diff", "CILo", "CIHi", "T", "DF", "PT", "W", "Z", "PW"}
+	for _, nm := range fcols {
+		rt.AddFloat64Column(nm)
+	}
+	for i, ca := range conds {
+		for _, cb := range conds[i+1:] {
+			a, b := groups[ca], groups[cb]
+			if cp.Paired && len(a) != len(b) {
+				return nil, fmt.Errorf("sigtest.Compare: paired conditions %q and %q have different numbers of values: %d != %d", ca, cb, len(a), len(b))
+			}
+			ma, _ := MeanVar(a)
+			mb, _ := MeanVar(b)
+			var tt, wt Result
+			var lo, hi float64
+			if cp.Paired {
+				tt = PairedTTest(a, b)
+				wt = SignedRank(a, b)
+				lo, hi = BootstrapCI(diffs(a, b), cp.NBoot, cp.Conf, cp.Rand)
+			} else {
+				tt = TTest(a, b)
+				wt = RankSum(a, b)
+				lo, hi = BootstrapDiffCI(a, b, cp.NBoot, cp.Conf, cp.Rand)
+			}
+			row := rt.NumRows()
+			rt.SetNumRows(row + 1)
+			rt.Column("CondA").SetStringRow(ca, row, 0)
+			rt.Column("CondB").SetStringRow(cb, row, 0)
+			rt.Column("NA").SetIntRow(len(a), row, 0)
+			rt.Column("NB").SetIntRow(len(b), row, 0)
+			vals := []float64{ma, mb, ma - mb, lo, hi, tt.Stat, tt.DF, tt.P, wt.Stat, wt.Z, wt.P}
+			for ci, nm := range fcols {
+				rt.Column(nm).SetFloatRow(vals[ci], row, 0)
+			}
+		}
+	}
+	return rt, nil
+}
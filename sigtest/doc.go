@@ -0,0 +1,17 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package sigtest provides simple significance testing utilities for
+comparing model results across conditions, such as the final
+performance of each run under different parameters: unpaired (Welch)
+and paired t-tests, Wilcoxon rank-sum and signed-rank tests, and
+bootstrap confidence intervals, along with Compare, which applies
+these to a column of results grouped by condition in a table, producing
+a table of results, so that basic model-comparison statistics can be
+computed without exporting the data to R or Python.
+*/
+package sigtest
+
+//go:generate core generate -add-types
@@ -0,0 +1,236 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sigtest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+
+	"cogentcore.org/lab/base/randx"
+)
+
+// Result is the result of a significance test.
+type Result struct {
+
+	// Stat is the test statistic: t for t-tests, U for the rank-sum test,
+	// and W+ (the sum of positive ranks) for the signed-rank test.
+	Stat float64
+
+	// DF is the degrees of freedom for t-tests.
+	DF float64
+
+	// Z is the z score of the normal approximation for rank tests.
+	Z float64
+
+	// P is the two-tailed p value.
+	P float64
+}
+
+// String returns a summary of the result.
+func (rs *Result) String() string {
+	return fmt.Sprintf("Stat: %.4g, DF: %.4g, Z: %.4g, P: %.4g", rs.Stat, rs.DF, rs.Z, rs.P)
+}
+
+// MeanVar returns the mean and the (n-1) sample variance of given values.
+func MeanVar(vals []float64) (mean, vr float64) {
+	n := float64(len(vals))
+	if n == 0 {
+		return math.NaN(), math.NaN()
+	}
+	for _, v := range vals {
+		mean += v
+	}
+	mean /= n
+	if n < 2 {
+		return mean, 0
+	}
+	for _, v := range vals {
+		vr += (v - mean) * (v - mean)
+	}
+	vr /= n - 1
+	return
+}
+
+// TTest returns the unpaired (Welch's) t-test comparing the means of
+// a and b, which does not assume equal variances, with t positive when
+// the mean of a is greater than that of b.
+func TTest(a, b []float64) Result {
+	ma, va := MeanVar(a)
+	mb, vb := MeanVar(b)
+	na, nb := float64(len(a)), float64(len(b))
+	sa, sb := va/na, vb/nb
+	se := math.Sqrt(sa + sb)
+	rs := Result{Stat: (ma - mb) / se}
+	rs.DF = (sa + sb) * (sa + sb) / (sa*sa/(na-1) + sb*sb/(nb-1))
+	rs.P = StudentTP(rs.Stat, rs.DF)
+	return rs
+}
+
+// PairedTTest returns the paired t-test comparing the values of a and b,
+// which must have the same length, with pairs at the same index
+// (e.g., the same random seed), with t positive when a is greater than b.
+func PairedTTest(a, b []float64) Result {
+	d := diffs(a, b)
+	md, vd := MeanVar(d)
+	n := float64(len(d))
+	rs := Result{Stat: md / math.Sqrt(vd/n), DF: n - 1}
+	rs.P = StudentTP(rs.Stat, rs.DF)
+	return rs
+}
+
+// RankSum returns the Wilcoxon rank-sum (Mann-Whitney U) test comparing
+// the distributions of a and b, using the normal approximation with a
+// correction for ties, with Z positive when a tends to be greater than b.
+func RankSum(a, b []float64) Result {
+	na, nb := float64(len(a)), float64(len(b))
+	all := append(slices.Clone(a), b...)
+	rk, tie := ranks(all)
+	w := 0.0
+	for i := range a {
+		w += rk[i]
+	}
+	n := na + nb
+	rs := Result{Stat: w - na*(na+1)/2}
+	mu := na * nb / 2
+	sd := math.Sqrt(na * nb / 12 * ((n + 1) - tie/(n*(n-1))))
+	rs.Z = (rs.Stat - mu) / sd
+	rs.P = NormalP(rs.Z)
+	return rs
+}
+
+// SignedRank returns the Wilcoxon signed-rank test comparing the values
+// of a and b, which must have the same length, with pairs at the same
+// index, using the normal approximation with a correction for ties,
+// and zero differences dropped, with Z positive when a is greater than b.
+func SignedRank(a, b []float64) Result {
+	var d []float64
+	for _, v := range diffs(a, b) {
+		if v != 0 {
+			d = append(d, v)
+		}
+	}
+	abs := make([]float64, len(d))
+	for i, v := range d {
+		abs[i] = math.Abs(v)
+	}
+	rk, tie := ranks(abs)
+	rs := Result{}
+	for i, v := range d {
+		if v > 0 {
+			rs.Stat += rk[i]
+		}
+	}
+	n := float64(len(d))
+	mu := n * (n + 1) / 4
+	sd := math.Sqrt(n*(n+1)*(2*n+1)/24 - tie/48)
+	rs.Z = (rs.Stat - mu) / sd
+	rs.P = NormalP(rs.Z)
+	return rs
+}
+
+// diffs returns a - b for each pair of values.
+func diffs(a, b []float64) []float64 {
+	n := min(len(a), len(b))
+	d := make([]float64, n)
+	for i := range n {
+		d[i] = a[i] - b[i]
+	}
+	return d
+}
+
+// ranks returns the ranks of given values (starting at 1), with tied values
+// getting their average rank, and the tie correction sum of (t^3 - t)
+// over groups of t tied values.
+func ranks(vals []float64) ([]float64, float64) {
+	n := len(vals)
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return vals[idx[i]] < vals[idx[j]] })
+	rk := make([]float64, n)
+	tie := 0.0
+	for i := 0; i < n; {
+		j := i + 1
+		for j < n && vals[idx[j]] == vals[idx[i]] {
+			j++
+		}
+		avg := 0.5 * float64(i+j+1)
+		for k := i; k < j; k++ {
+			rk[idx[k]] = avg
+		}
+		t := float64(j - i)
+		tie += t*t*t - t
+		i = j
+	}
+	return rk, tie
+}
+
+// BootstrapCI returns the bootstrap confidence interval, at given
+// confidence level (e.g., 0.95), for the mean of given values, using
+// the percentile method with given number of resamples, and given random
+// number source (nil = global source).
+func BootstrapCI(vals []float64, nboot int, conf float64, rnd randx.Rand) (lo, hi float64) {
+	intn := rand.Intn
+	if rnd != nil {
+		intn = rnd.Intn
+	}
+	n := len(vals)
+	if n == 0 || nboot < 1 {
+		return math.NaN(), math.NaN()
+	}
+	means := make([]float64, nboot)
+	for bi := range nboot {
+		sum := 0.0
+		for range n {
+			sum += vals[intn(n)]
+		}
+		means[bi] = sum / float64(n)
+	}
+	return percentiles(means, conf)
+}
+
+// BootstrapDiffCI returns the bootstrap confidence interval, at given
+// confidence level (e.g., 0.95), for the difference in the means of
+// a and b (a - b), resampling each independently, using the percentile
+// method with given number of resamples, and given random number
+// source (nil = global source). For paired values, use [BootstrapCI]
+// on the differences.
+func BootstrapDiffCI(a, b []float64, nboot int, conf float64, rnd randx.Rand) (lo, hi float64) {
+	intn := rand.Intn
+	if rnd != nil {
+		intn = rnd.Intn
+	}
+	na, nb := len(a), len(b)
+	if na == 0 || nb == 0 || nboot < 1 {
+		return math.NaN(), math.NaN()
+	}
+	ds := make([]float64, nboot)
+	for bi := range nboot {
+		sa, sb := 0.0, 0.0
+		for range na {
+			sa += a[intn(na)]
+		}
+		for range nb {
+			sb += b[intn(nb)]
+		}
+		ds[bi] = sa/float64(na) - sb/float64(nb)
+	}
+	return percentiles(ds, conf)
+}
+
+// percentiles returns the lower and upper percentiles of given values
+// for a central interval with given confidence level.
+func percentiles(vals []float64, conf float64) (lo, hi float64) {
+	slices.Sort(vals)
+	n := len(vals)
+	alpha := 0.5 * (1 - conf)
+	li := int(math.Floor(alpha * float64(n)))
+	hix := int(math.Ceil((1-alpha)*float64(n))) - 1
+	return vals[min(max(li, 0), n-1)], vals[min(max(hix, 0), n-1)]
+}
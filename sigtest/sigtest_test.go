@@ -0,0 +1,118 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sigtest
+
+import (
+	"math"
+	"testing"
+
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/table"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDists(t *testing.T) {
+	assert.InDelta(t, 0.05, NormalP(1.96), 0.0005)
+	assert.InDelta(t, 1.0, NormalP(0), 1e-10)
+	assert.InDelta(t, 0.05, StudentTP(2.228, 10), 0.0005)
+	assert.InDelta(t, 0.05, StudentTP(-2.086, 20), 0.0005)
+	assert.InDelta(t, 0.01, StudentTP(3.169, 10), 0.0005)
+	assert.InDelta(t, 1.0, StudentTP(0, 5), 1e-10)
+}
+
+func TestTTests(t *testing.T) {
+	a := []float64{5.1, 4.9, 5.6, 5.8, 6.0, 5.5, 5.3, 5.7}
+	b := []float64{4.2, 4.8, 4.4, 4.9, 4.1, 4.6, 4.5, 4.3}
+	rs := TTest(a, b)
+	assert.Greater(t, rs.Stat, 0.0)
+	assert.Less(t, rs.P, 0.001)
+	assert.Less(t, rs.DF, 14.0)
+	rs = TTest(b, a)
+	assert.Less(t, rs.Stat, 0.0)
+
+	rs = PairedTTest([]float64{2, 3, 4, 5}, []float64{1, 2, 4, 3})
+	// diffs 1, 1, 0, 2: mean 1, var 2/3, t = 1 / sqrt(1/6)
+	assert.InDelta(t, math.Sqrt(6), rs.Stat, 1e-10)
+	assert.Equal(t, 3.0, rs.DF)
+
+	rs = TTest([]float64{1, 2, 3}, []float64{1, 2, 3})
+	assert.Equal(t, 0.0, rs.Stat)
+	assert.InDelta(t, 1.0, rs.P, 1e-10)
+}
+
+func TestRankTests(t *testing.T) {
+	a := []float64{6, 7, 8, 9, 10}
+	b := []float64{1, 2, 3, 4, 5}
+	rs := RankSum(a, b)
+	assert.Equal(t, 25.0, rs.Stat)
+	assert.InDelta(t, 12.5/math.Sqrt(25.0*11/12), rs.Z, 1e-10)
+	assert.Less(t, rs.P, 0.01)
+	rs = RankSum(b, a)
+	assert.Equal(t, 0.0, rs.Stat)
+	assert.Less(t, rs.Z, 0.0)
+
+	rk, tie := ranks([]float64{3, 1, 3, 2})
+	assert.Equal(t, []float64{3.5, 1, 3.5, 2}, rk)
+	assert.Equal(t, 6.0, tie)
+
+	a = []float64{11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 5}
+	b = []float64{10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 5}
+	rs = SignedRank(a, b) // zero difference dropped, n = 10
+	assert.Equal(t, 55.0, rs.Stat)
+	assert.InDelta(t, 27.5/math.Sqrt(10.0*11*21/24), rs.Z, 1e-10)
+	assert.Less(t, rs.P, 0.01)
+}
+
+func TestBootstrap(t *testing.T) {
+	rnd := randx.NewSysRand(1)
+	vals := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	lo, hi := BootstrapCI(vals, 1000, 0.95, rnd)
+	assert.Less(t, lo, 5.5)
+	assert.Greater(t, hi, 5.5)
+	assert.Greater(t, lo, 1.0)
+	assert.Less(t, hi, 10.0)
+
+	lo, hi = BootstrapDiffCI([]float64{10, 11, 12, 13}, []float64{1, 2, 3, 4}, 1000, 0.95, rnd)
+	assert.Less(t, lo, 9.0)
+	assert.Greater(t, hi, 9.0)
+	assert.Greater(t, lo, 7.0)
+}
+
+func TestCompare(t *testing.T) {
+	dt := table.New()
+	dt.AddStringColumn("Cond")
+	dt.AddFloat64Column("Err")
+	conds := []string{"A", "B", "C"}
+	errs := [][]float64{{.10, .12, .11, .09, .13}, {.20, .22, .19, .21, .23}, {.11, .12, .10, .10, .12}}
+	dt.SetNumRows(15)
+	for run := range 5 {
+		for ci, cond := range conds {
+			row := run*3 + ci
+			dt.Column("Cond").SetStringRow(cond, row, 0)
+			dt.Column("Err").SetFloatRow(errs[ci][run], row, 0)
+		}
+	}
+	for _, paired := range []bool{false, true} {
+		cp := NewCompare(paired)
+		cp.Rand = randx.NewSysRand(1)
+		rt, err := cp.Table(dt, "Cond", "Err")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, rt.NumRows())
+		assert.Equal(t, "A", rt.Column("CondA").StringRow(0, 0))
+		assert.Equal(t, "B", rt.Column("CondB").StringRow(0, 0))
+		assert.Equal(t, "C", rt.Column("CondB").StringRow(2, 0))
+		assert.Equal(t, 5, rt.Column("NA").IntRow(0, 0))
+		assert.InDelta(t, -0.1, rt.Column("Diff").FloatRow(0, 0), 1e-10)
+		assert.Less(t, rt.Column("PT").FloatRow(0, 0), 0.001)
+		assert.Less(t, rt.Column("CIHi").FloatRow(0, 0), 0.0)
+		assert.Greater(t, rt.Column("PT").FloatRow(1, 0), 0.05)
+	}
+
+	_, err := NewCompare(false).Table(dt, "Cond", "Missing")
+	assert.Error(t, err)
+	dt.SetNumRows(14)
+	_, err = NewCompare(true).Table(dt, "Cond", "Err")
+	assert.Error(t, err)
+}
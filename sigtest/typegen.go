@@ -0,0 +1,11 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package sigtest
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/sigtest.Compare", IDName: "compare", Doc: "Compare has parameters for comparing a column of results (e.g., the\nfinal performance of each run) grouped by condition in a table, for\nall pairs of conditions, using [Compare.Table].", Fields: []types.Field{{Name: "Paired", Doc: "Paired uses paired tests (paired t-test and signed-rank), where\nthe values for each condition are paired in row order\n(e.g., by run, with the same random seeds across conditions),\nso that each condition must have the same number of values."}, {Name: "NBoot", Doc: "NBoot is the number of bootstrap resamples for the confidence\ninterval of the difference in means."}, {Name: "Conf", Doc: "Conf is the confidence level for the bootstrap confidence interval."}, {Name: "Rand", Doc: "Rand is the random number source for bootstrapping (nil = global)."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/sigtest.Result", IDName: "result", Doc: "Result is the result of a significance test.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Fields: []types.Field{{Name: "Stat", Doc: "Stat is the test statistic: t for t-tests, U for the rank-sum test,\nand W+ (the sum of positive ranks) for the signed-rank test."}, {Name: "DF", Doc: "DF is the degrees of freedom for t-tests."}, {Name: "Z", Doc: "Z is the z score of the normal approximation for rank tests."}, {Name: "P", Doc: "P is the two-tailed p value."}}})
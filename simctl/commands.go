@@ -0,0 +1,235 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simctl
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"cogentcore.org/core/base/reflectx"
+	"cogentcore.org/core/core"
+	"cogentcore.org/core/enums"
+	"github.com/emer/emergent/v2/emer"
+	"github.com/emer/emergent/v2/estats"
+	"github.com/emer/emergent/v2/looper"
+	"github.com/emer/emergent/v2/params"
+)
+
+// AddLoops adds the standard commands for controlling given looper Stacks:
+//   - pause: stops running at the end of the innermost level (as the GUI Stop).
+//   - run: runs given mode (default current) in the background.
+//   - step: steps given mode (default current) n times (default 1) at
+//     given level (default the step level of the stack), in the background.
+//   - status: returns whether it is running, the mode, and the counters.
+//
+// The optional stopped function is called when running stops,
+// e.g., egui.GUI.Stopped to update the GUI.
+func (sv *Server) AddLoops(ls *looper.Stacks, stopped func(mode, level enums.Enum)) *Server {
+	var running atomic.Bool
+	start := func(args map[string]string, step bool) (any, error) {
+		mode, err := loopMode(ls, args["mode"])
+		if err != nil {
+			return nil, err
+		}
+		st := ls.Stacks[mode]
+		level := st.StepLevel
+		if lnm, ok := args["level"]; ok {
+			if level, err = loopLevel(st, lnm); err != nil {
+				return nil, err
+			}
+		}
+		n := 1
+		if ns, ok := args["n"]; ok {
+			if n, err = strconv.Atoi(ns); err != nil {
+				return nil, fmt.Errorf("step: invalid n: %w", err)
+			}
+		}
+		if !running.CompareAndSwap(false, true) {
+			return nil, fmt.Errorf("already running")
+		}
+		go func() {
+			var stop enums.Enum
+			if step {
+				stop = ls.Step(mode, n, level)
+			} else {
+				stop = ls.Run(mode)
+			}
+			running.Store(false)
+			if stopped != nil {
+				stopped(mode, stop)
+			}
+		}()
+		return nil, nil
+	}
+	sv.Handle("pause", "stops running at the end of the innermost level", func(args map[string]string) (any, error) {
+		if st := ls.ModeStack(); st != nil && len(st.Order) > 0 {
+			ls.Stop(st.Order[len(st.Order)-1])
+		}
+		return nil, nil
+	})
+	sv.Handle("run", "runs in the background; args: mode (default current)", func(args map[string]string) (any, error) {
+		return start(args, false)
+	})
+	sv.Handle("step", "steps in the background; args: mode (default current), level (default step level), n (default 1)", func(args map[string]string) (any, error) {
+		return start(args, true)
+	})
+	sv.Handle("status", "returns Running, Mode, and Counters", func(args map[string]string) (any, error) {
+		res := map[string]any{"Running": running.Load() || ls.IsRunning()}
+		if st := ls.ModeStack(); st != nil {
+			res["Mode"] = ls.Mode.String()
+			res["Counters"] = st.CountersString()
+		}
+		return res, nil
+	})
+	return sv
+}
+
+// loopMode returns the mode with given name, or the current mode if empty.
+func loopMode(ls *looper.Stacks, nm string) (enums.Enum, error) {
+	if nm == "" {
+		if ls.Mode == nil {
+			return nil, fmt.Errorf("no current mode: specify a mode")
+		}
+		return ls.Mode, nil
+	}
+	for _, m := range ls.Modes() {
+		if m.String() == nm {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("mode %q not found", nm)
+}
+
+// loopLevel returns the level with given name in given stack.
+func loopLevel(st *looper.Stack, nm string) (enums.Enum, error) {
+	for _, l := range st.Order {
+		if l.String() == nm {
+			return l, nil
+		}
+	}
+	return nil, fmt.Errorf("level %q not found in mode %s", nm, st.Mode)
+}
+
+// AddNetwork adds the save-weights and open-weights commands for given
+// network, with a file argument (a .wts.gz extension is compressed).
+// The sim should be paused before opening weights.
+func (sv *Server) AddNetwork(net emer.Network) *Server {
+	nb := net.AsEmer()
+	sv.Handle("save-weights", "saves the network weights; args: file", func(args map[string]string) (any, error) {
+		fn, ok := args["file"]
+		if !ok {
+			return nil, fmt.Errorf("save-weights: file argument is required")
+		}
+		return nil, nb.SaveWeightsJSON(core.Filename(fn))
+	})
+	sv.Handle("open-weights", "opens the network weights; args: file", func(args map[string]string) (any, error) {
+		fn, ok := args["file"]
+		if !ok {
+			return nil, fmt.Errorf("open-weights: file argument is required")
+		}
+		return nil, nb.OpenWeightsJSON(core.Filename(fn))
+	})
+	return sv
+}
+
+// AddParams adds the set-param and get-param commands for the fields of
+// given params objects (pointers to structs, e.g., the sim Config,
+// or the parameters of a layer), by name. The set-param command sets the
+// field at given path (e.g., "Act.Gain") to given value, calls the Update
+// method of the object if defined, and then calls the onSet function
+// if non-nil (e.g., to update the network parameters).
+// The sim should be paused before setting parameters.
+func (sv *Server) AddParams(objs map[string]any, onSet func()) *Server {
+	field := func(args map[string]string) (reflect.Value, any, error) {
+		onm := args["obj"]
+		obj, ok := objs[onm]
+		if !ok {
+			nms := make([]string, 0, len(objs))
+			for nm := range objs {
+				nms = append(nms, nm)
+			}
+			slices.Sort(nms)
+			return reflect.Value{}, nil, fmt.Errorf("object %q not found; available: %s", onm, strings.Join(nms, ", "))
+		}
+		fv, err := fieldByPath(obj, args["path"])
+		return fv, obj, err
+	}
+	sv.Handle("set-param", "sets a parameter; args: obj, path (e.g., Act.Gain), value", func(args map[string]string) (any, error) {
+		fv, obj, err := field(args)
+		if err != nil {
+			return nil, err
+		}
+		if err := reflectx.SetRobust(fv.Addr().Interface(), args["value"]); err != nil {
+			return nil, err
+		}
+		if up, ok := obj.(params.Updater); ok {
+			up.Update()
+		}
+		if onSet != nil {
+			onSet()
+		}
+		return fv.Interface(), nil
+	})
+	sv.Handle("get-param", "gets a parameter; args: obj, path (e.g., Act.Gain)", func(args map[string]string) (any, error) {
+		fv, _, err := field(args)
+		if err != nil {
+			return nil, err
+		}
+		return fv.Interface(), nil
+	})
+	return sv
+}
+
+// fieldByPath returns the field at given dot-separated path in given object.
+func fieldByPath(obj any, path string) (reflect.Value, error) {
+	if path == "" {
+		return reflect.Value{}, fmt.Errorf("path argument is required")
+	}
+	val := reflectx.Underlying(reflect.ValueOf(obj))
+	for _, fnm := range strings.Split(path, ".") {
+		if val.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("path %q is not valid", path)
+		}
+		fv := val.FieldByName(fnm)
+		if !fv.IsValid() || !fv.CanSet() {
+			return reflect.Value{}, fmt.Errorf("field %q in path %q not found", fnm, path)
+		}
+		val = reflectx.Underlying(fv)
+	}
+	return val, nil
+}
+
+// AddStats adds the dump-stats command, which returns the current values
+// of all the Floats, Strings, and Ints in given stats.
+func (sv *Server) AddStats(stats *estats.Stats) *Server {
+	sv.Handle("dump-stats", "returns the current values of all the stats", func(args map[string]string) (any, error) {
+		res := anyMap(stats.Strings)
+		maps.Copy(res, anyMap(stats.Ints))
+		for nm, v := range stats.Floats {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				res[nm] = nil // not valid in JSON
+				continue
+			}
+			res[nm] = v
+		}
+		return res, nil
+	})
+	return sv
+}
+
+// anyMap returns a copy of given map with values of type any.
+func anyMap[V any](m map[string]V) map[string]any {
+	am := make(map[string]any, len(m))
+	for k, v := range m {
+		am[k] = v
+	}
+	return am
+}
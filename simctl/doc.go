@@ -0,0 +1,14 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package simctl provides a small control server that a running sim can
+enable, listening on a unix socket or TCP address for JSON commands,
+one per line, such as pause, step, set-param, save-weights, and dump-stats,
+so that external orchestration scripts and notebooks can drive
+long-running jobs interactively.
+*/
+package simctl
+
+//go:generate core generate -add-types
@@ -0,0 +1,243 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simctl
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"slices"
+	"sync"
+)
+
+// Request is a command sent to the [Server], encoded as one line of JSON,
+// e.g., {"Cmd": "step", "Args": {"mode": "Train", "level": "Epoch", "n": "2"}}
+type Request struct {
+
+	// Cmd is the name of the command.
+	Cmd string
+
+	// Args are the named arguments for the command, as strings.
+	Args map[string]string
+}
+
+// Response is the response to a [Request], encoded as one line of JSON.
+type Response struct {
+
+	// OK is true if the command succeeded.
+	OK bool
+
+	// Error is the error message if the command failed.
+	Error string `json:",omitempty"`
+
+	// Result is the result of the command, if any.
+	Result any `json:",omitempty"`
+}
+
+// Handler is a function that executes a command with given arguments,
+// returning a result, which must be encodable as JSON (can be nil),
+// and an error if the command failed.
+type Handler func(args map[string]string) (any, error)
+
+// Command is a named command handled by the [Server].
+type Command struct {
+
+	// Name is the name of the command, as used in the Request Cmd.
+	Name string
+
+	// Doc describes the command and its arguments, for the help command.
+	Doc string
+
+	// Func is the function that executes the command.
+	Func Handler
+}
+
+// Server is a control server that a running sim can enable, listening
+// on a unix socket or TCP address for [Request] commands encoded as JSON,
+// one per line, which are executed by the registered [Command] handlers,
+// writing back a [Response] as one line of JSON for each. Commands are
+// executed one at a time, in the goroutine of the connection, concurrently
+// with the running sim, so handlers that access sim state should only do so
+// when it is safe (e.g., the standard commands only set flags on the loops,
+// and the sim should be paused before setting parameters).
+// There is a help command that lists all the commands.
+type Server struct {
+
+	// Network is the network type: "unix" for a unix domain socket,
+	// or "tcp" for a TCP address.
+	Network string
+
+	// Address is the socket file name for unix, or host:port for tcp
+	// (use a port of 0 to pick an available port, and see Addr).
+	// For security, tcp should typically only listen on localhost.
+	Address string
+
+	// Commands are the registered commands, by name.
+	Commands map[string]*Command
+
+	listener net.Listener
+	cmdMu    sync.Mutex
+	mu       sync.Mutex
+	conns    map[net.Conn]struct{}
+	wg       sync.WaitGroup
+}
+
+// NewServer returns a new [Server] for given network type ("unix" or "tcp")
+// and address, with the help command registered. Call Start to start it.
+func NewServer(network, address string) *Server {
+	sv := &Server{Network: network, Address: address}
+	sv.Commands = make(map[string]*Command)
+	sv.Handle("help", "lists the commands and their documentation", func(args map[string]string) (any, error) {
+		return sv.Help(), nil
+	})
+	return sv
+}
+
+// Handle registers a command with given name, documentation,
+// and handler function, replacing any existing one with the same name.
+// Returns the server so calls can be chained.
+func (sv *Server) Handle(name, doc string, fun Handler) *Server {
+	sv.Commands[name] = &Command{Name: name, Doc: doc, Func: fun}
+	return sv
+}
+
+// Help returns a map of the command names and their documentation.
+func (sv *Server) Help() map[string]string {
+	help := make(map[string]string, len(sv.Commands))
+	for nm, cmd := range sv.Commands {
+		help[nm] = cmd.Doc
+	}
+	return help
+}
+
+// CommandNames returns the sorted names of the registered commands.
+func (sv *Server) CommandNames() []string {
+	nms := make([]string, 0, len(sv.Commands))
+	for nm := range sv.Commands {
+		nms = append(nms, nm)
+	}
+	slices.Sort(nms)
+	return nms
+}
+
+// Start starts listening for connections, which are served in separate
+// goroutines, returning an error if the listener cannot be created.
+// For a unix socket, any existing file at Address is removed first.
+func (sv *Server) Start() error {
+	if sv.Network == "unix" {
+		os.Remove(sv.Address)
+	}
+	ln, err := net.Listen(sv.Network, sv.Address)
+	if err != nil {
+		return fmt.Errorf("simctl.Server: %w", err)
+	}
+	sv.listener = ln
+	sv.conns = make(map[net.Conn]struct{})
+	sv.wg.Add(1)
+	go sv.accept()
+	return nil
+}
+
+// Addr returns the address the server is listening on,
+// which is useful for a tcp port of 0. Returns "" if not started.
+func (sv *Server) Addr() string {
+	if sv.listener == nil {
+		return ""
+	}
+	return sv.listener.Addr().String()
+}
+
+// Close stops listening, closes all connections, and waits for them to finish.
+func (sv *Server) Close() error {
+	if sv.listener == nil {
+		return nil
+	}
+	err := sv.listener.Close()
+	sv.mu.Lock()
+	for c := range sv.conns {
+		c.Close()
+	}
+	sv.mu.Unlock()
+	sv.wg.Wait()
+	sv.listener = nil
+	if sv.Network == "unix" {
+		os.Remove(sv.Address)
+	}
+	return err
+}
+
+// accept accepts connections until the listener is closed.
+func (sv *Server) accept() {
+	defer sv.wg.Done()
+	for {
+		c, err := sv.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Println("simctl.Server:", err)
+			}
+			return
+		}
+		sv.mu.Lock()
+		sv.conns[c] = struct{}{}
+		sv.mu.Unlock()
+		sv.wg.Add(1)
+		go sv.serve(c)
+	}
+}
+
+// serve reads requests from given connection and writes the responses.
+func (sv *Server) serve(c net.Conn) {
+	defer func() {
+		sv.mu.Lock()
+		delete(sv.conns, c)
+		sv.mu.Unlock()
+		c.Close()
+		sv.wg.Done()
+	}()
+	sc := bufio.NewScanner(c)
+	for sc.Scan() {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var req Request
+		var resp Response
+		if err := json.Unmarshal(sc.Bytes(), &req); err != nil {
+			resp = Response{Error: "invalid request: " + err.Error()}
+		} else {
+			resp = sv.Do(&req)
+		}
+		b, err := json.Marshal(resp)
+		if err != nil {
+			b, _ = json.Marshal(Response{Error: "invalid result: " + err.Error()})
+		}
+		if _, err := c.Write(append(b, '\n')); err != nil {
+			return
+		}
+	}
+}
+
+// Do executes given request, returning the response. This is called for
+// each request received by the server, and can also be called directly.
+// Only one command is executed at a time.
+func (sv *Server) Do(req *Request) Response {
+	cmd, ok := sv.Commands[req.Cmd]
+	if !ok {
+		return Response{Error: fmt.Sprintf("unknown command %q: use help for the list of commands", req.Cmd)}
+	}
+	if req.Args == nil {
+		req.Args = map[string]string{}
+	}
+	sv.cmdMu.Lock()
+	defer sv.cmdMu.Unlock()
+	res, err := cmd.Func(req.Args)
+	if err != nil {
+		return Response{Error: err.Error()}
+	}
+	return Response{OK: true, Result: res}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package simctl
+
+import (
+	"bufio"
+	"encoding/json"
+	"math"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cogentcore.org/core/enums"
+	"github.com/emer/emergent/v2/estats"
+	"github.com/emer/emergent/v2/looper"
+	"github.com/emer/emergent/v2/looper/levels"
+	"github.com/stretchr/testify/assert"
+)
+
+type testParams struct {
+	Gain float32
+	Tau  float32
+	Dt   float32
+	Sub  struct{ N int }
+}
+
+func (tp *testParams) Update() {
+	tp.Dt = 1 / tp.Tau
+}
+
+// send sends given request on given connection and returns the response.
+func send(t *testing.T, c net.Conn, rd *bufio.Reader, req *Request) Response {
+	b, _ := json.Marshal(req)
+	_, err := c.Write(append(b, '\n'))
+	assert.NoError(t, err)
+	line, err := rd.ReadBytes('\n')
+	assert.NoError(t, err)
+	var resp Response
+	assert.NoError(t, json.Unmarshal(line, &resp))
+	return resp
+}
+
+func TestServer(t *testing.T) {
+	for _, network := range []string{"unix", "tcp"} {
+		addr := "127.0.0.1:0"
+		if network == "unix" {
+			addr = filepath.Join(t.TempDir(), "sim.sock")
+		}
+		tp := &testParams{Gain: 1, Tau: 10}
+		nset := 0
+		stats := estats.Stats{}
+		stats.Init()
+		stats.SetFloat("PctErr", 0.25)
+		stats.SetFloat("Bad", math.NaN())
+		stats.SetString("TrialName", "A")
+
+		sv := NewServer(network, addr)
+		sv.AddParams(map[string]any{"Params": tp}, func() { nset++ }).AddStats(&stats)
+		assert.NoError(t, sv.Start())
+		c, err := net.Dial(network, sv.Addr())
+		assert.NoError(t, err)
+		rd := bufio.NewReader(c)
+
+		resp := send(t, c, rd, &Request{Cmd: "help"})
+		assert.True(t, resp.OK)
+		assert.Contains(t, resp.Result, "set-param")
+
+		resp = send(t, c, rd, &Request{Cmd: "set-param", Args: map[string]string{"obj": "Params", "path": "Tau", "value": "20"}})
+		assert.True(t, resp.OK, resp.Error)
+		assert.Equal(t, float32(20), tp.Tau)
+		assert.Equal(t, float32(0.05), tp.Dt)
+		assert.Equal(t, 1, nset)
+
+		resp = send(t, c, rd, &Request{Cmd: "set-param", Args: map[string]string{"obj": "Params", "path": "Sub.N", "value": "3"}})
+		assert.True(t, resp.OK, resp.Error)
+		assert.Equal(t, 3, tp.Sub.N)
+
+		resp = send(t, c, rd, &Request{Cmd: "get-param", Args: map[string]string{"obj": "Params", "path": "Gain"}})
+		assert.Equal(t, 1.0, resp.Result)
+
+		resp = send(t, c, rd, &Request{Cmd: "set-param", Args: map[string]string{"obj": "Params", "path": "Bogus", "value": "3"}})
+		assert.False(t, resp.OK)
+		assert.Contains(t, resp.Error, "Bogus")
+		resp = send(t, c, rd, &Request{Cmd: "get-param", Args: map[string]string{"obj": "Other", "path": "Gain"}})
+		assert.Contains(t, resp.Error, "available: Params")
+
+		resp = send(t, c, rd, &Request{Cmd: "dump-stats"})
+		assert.True(t, resp.OK, resp.Error)
+		res := resp.Result.(map[string]any)
+		assert.Equal(t, 0.25, res["PctErr"])
+		assert.Equal(t, "A", res["TrialName"])
+		assert.Nil(t, res["Bad"])
+
+		resp = send(t, c, rd, &Request{Cmd: "bogus"})
+		assert.Contains(t, resp.Error, "unknown command")
+
+		_, err = c.Write([]byte("not json\n"))
+		assert.NoError(t, err)
+		line, _ := rd.ReadBytes('\n')
+		assert.Contains(t, string(line), "invalid request")
+
+		assert.NoError(t, sv.Close())
+		c.Close()
+	}
+}
+
+func TestLoops(t *testing.T) {
+	trials := 0
+	ls := looper.NewStacks()
+	ls.AddStack(levels.Train, levels.Trial).
+		AddLevel(levels.Epoch, 3).
+		AddLevel(levels.Trial, 4)
+	ls.Loop(levels.Train, levels.Trial).OnStart.Add("Count", func() { trials++ })
+	ls.Mode = levels.Train
+
+	done := make(chan bool)
+	sv := NewServer("tcp", "127.0.0.1:0")
+	sv.AddLoops(ls, func(mode, level enums.Enum) { done <- true })
+
+	resp := sv.Do(&Request{Cmd: "step", Args: map[string]string{"level": "Trial", "n": "2"}})
+	assert.True(t, resp.OK, resp.Error)
+	waitDone(t, done)
+	assert.Equal(t, 2, trials)
+
+	resp = sv.Do(&Request{Cmd: "step", Args: map[string]string{"mode": "Train", "level": "Epoch"}})
+	assert.True(t, resp.OK, resp.Error)
+	waitDone(t, done)
+	assert.Equal(t, 4, trials)
+
+	resp = sv.Do(&Request{Cmd: "status"})
+	res := resp.Result.(map[string]any)
+	assert.Equal(t, false, res["Running"])
+	assert.Equal(t, "Train", res["Mode"])
+
+	resp = sv.Do(&Request{Cmd: "step", Args: map[string]string{"level": "Cycle"}})
+	assert.Contains(t, resp.Error, "not found")
+	resp = sv.Do(&Request{Cmd: "run", Args: map[string]string{"mode": "Test"}})
+	assert.Contains(t, resp.Error, "not found")
+
+	resp = sv.Do(&Request{Cmd: "run"})
+	assert.True(t, resp.OK, resp.Error)
+	waitDone(t, done)
+	assert.Equal(t, 12, trials)
+}
+
+func waitDone(t *testing.T, done chan bool) {
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for loops to stop")
+	}
+}
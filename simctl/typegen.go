@@ -0,0 +1,17 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package simctl
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/simctl.Request", IDName: "request", Doc: "Request is a command sent to the [Server], encoded as one line of JSON,\ne.g., {\"Cmd\": \"step\", \"Args\": {\"mode\": \"Train\", \"level\": \"Epoch\", \"n\": \"2\"}}", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}, Fields: []types.Field{{Name: "Cmd", Doc: "Cmd is the name of the command."}, {Name: "Args", Doc: "Args are the named arguments for the command, as strings."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/simctl.Response", IDName: "response", Doc: "Response is the response to a [Request], encoded as one line of JSON.", Fields: []types.Field{{Name: "OK", Doc: "OK is true if the command succeeded."}, {Name: "Error", Doc: "Error is the error message if the command failed."}, {Name: "Result", Doc: "Result is the result of the command, if any."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/simctl.Handler", IDName: "handler", Doc: "Handler is a function that executes a command with given arguments,\nreturning a result, which must be encodable as JSON (can be nil),\nand an error if the command failed."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/simctl.Command", IDName: "command", Doc: "Command is a named command handled by the [Server].", Fields: []types.Field{{Name: "Name", Doc: "Name is the name of the command, as used in the Request Cmd."}, {Name: "Doc", Doc: "Doc describes the command and its arguments, for the help command."}, {Name: "Func", Doc: "Func is the function that executes the command."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/simctl.Server", IDName: "server", Doc: "Server is a control server that a running sim can enable, listening\non a unix socket or TCP address for [Request] commands encoded as JSON,\none per line, which are executed by the registered [Command] handlers,\nwriting back a [Response] as one line of JSON for each. Commands are\nexecuted one at a time, in the goroutine of the connection, concurrently\nwith the running sim, so handlers that access sim state should only do so\nwhen it is safe (e.g., the standard commands only set flags on the loops,\nand the sim should be paused before setting parameters).\nThere is a help command that lists all the commands.", Fields: []types.Field{{Name: "Network", Doc: "Network is the network type: \"unix\" for a unix domain socket,\nor \"tcp\" for a TCP address."}, {Name: "Address", Doc: "Address is the socket file name for unix, or host:port for tcp\n(use a port of 0 to pick an available port, and see Addr).\nFor security, tcp should typically only listen on localhost."}, {Name: "Commands", Doc: "Commands are the registered commands, by name."}, {Name: "listener"}, {Name: "cmdMu"}, {Name: "mu"}, {Name: "conns"}, {Name: "wg"}}})
@@ -0,0 +1,244 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package tablejson provides typed JSON reading and writing of [table.Table]
+data, preserving the data type and tensor cell shape of each column, so
+that tables round-trip losslessly.  The format is the JSON Table Schema
+"table" orientation that is read directly by pandas.read_json(orient="table"),
+so data can flow to pandas / polars:
+
+	{"schema": {"fields": [{"name": "Name", "type": "string", "dtype": "string"},
+	  {"name": "Input", "type": "any", "dtype": "float32", "shape": [5, 5]}]},
+	 "data": [{"Name": "a", "Input": [[0, 1, ...], ...]}, ...]}
+
+Tensor cells are written as nested arrays according to the cell shape,
+and NaN values are written as null.
+*/
+package tablejson
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+//go:generate core generate -add-types
+
+// Field is the schema for one column of the table.
+type Field struct {
+
+	// Name is the column name.
+	Name string `json:"name"`
+
+	// Type is the JSON Table Schema type (string, number, integer,
+	// boolean), or any for tensor-valued cells.
+	Type string `json:"type"`
+
+	// DType is the Go data type of the column (string, bool, float32,
+	// float64, int, int32, uint8).
+	DType string `json:"dtype"`
+
+	// Shape is the cell shape for tensor-valued columns.
+	Shape []int `json:"shape,omitempty"`
+}
+
+// Schema is the schema for the table.
+type Schema struct {
+
+	// Fields has the schema for each column, in order.
+	Fields []Field `json:"fields"`
+}
+
+// Table is the JSON representation of a table.
+type Table struct {
+
+	// Schema describes the columns.
+	Schema Schema `json:"schema"`
+
+	// Data has one object per row, with values for each column by name.
+	Data []map[string]any `json:"data"`
+}
+
+// kinds are the supported data types.
+var kinds = map[string]reflect.Kind{"string": reflect.String, "bool": reflect.Bool, "float32": reflect.Float32, "float64": reflect.Float64, "int": reflect.Int, "int32": reflect.Int32, "uint8": reflect.Uint8}
+
+// schemaType returns the JSON Table Schema type for given kind and shape.
+func schemaType(kind reflect.Kind, shape []int) string {
+	switch {
+	case len(shape) > 0:
+		return "any"
+	case kind == reflect.String:
+		return "string"
+	case kind == reflect.Bool:
+		return "boolean"
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return "number"
+	}
+	return "integer"
+}
+
+// FromTable returns the JSON representation of given table,
+// in the current order of its indexed view.
+func FromTable(dt *table.Table) *Table {
+	jt := &Table{}
+	cols := make([]tensor.Values, dt.NumColumns())
+	for ci, nm := range dt.Columns.Keys {
+		col := dt.Columns.Values[ci]
+		cols[ci] = col
+		shp := col.ShapeSizes()[1:]
+		kind := col.DataType()
+		jt.Schema.Fields = append(jt.Schema.Fields, Field{Name: nm, Type: schemaType(kind, shp), DType: kind.String(), Shape: shp})
+	}
+	nr := dt.NumRows()
+	jt.Data = make([]map[string]any, nr)
+	for ri := range nr {
+		row := dt.RowIndex(ri)
+		rd := make(map[string]any, len(cols))
+		for ci, col := range cols {
+			fl := &jt.Schema.Fields[ci]
+			_, csz := col.Shape().RowCellSize()
+			st := row * csz
+			rd[fl.Name] = cellValue(col, fl.Shape, &st)
+		}
+		jt.Data[ri] = rd
+	}
+	return jt
+}
+
+// cellValue returns the value of the cell at given starting 1D index,
+// as nested arrays for the given cell shape, advancing the index.
+func cellValue(col tensor.Values, shape []int, idx *int) any {
+	if len(shape) == 0 {
+		i := *idx
+		*idx++
+		switch col.DataType() {
+		case reflect.String:
+			return col.String1D(i)
+		case reflect.Bool:
+			return col.Float1D(i) != 0
+		case reflect.Float32, reflect.Float64:
+			v := col.Float1D(i)
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return nil
+			}
+			return v
+		}
+		return col.Int1D(i)
+	}
+	vals := make([]any, shape[0])
+	for i := range vals {
+		vals[i] = cellValue(col, shape[1:], idx)
+	}
+	return vals
+}
+
+// ToTable returns a new table from the JSON representation.
+func (jt *Table) ToTable() (*table.Table, error) {
+	dt := table.New()
+	for _, fl := range jt.Schema.Fields {
+		kind, ok := kinds[fl.DType]
+		if !ok {
+			return nil, fmt.Errorf("tablejson: column %q has unsupported dtype: %q", fl.Name, fl.DType)
+		}
+		dt.AddColumnOfType(fl.Name, kind, fl.Shape...)
+	}
+	dt.SetNumRows(len(jt.Data))
+	for ci, fl := range jt.Schema.Fields {
+		col := dt.Columns.Values[ci]
+		_, csz := col.Shape().RowCellSize()
+		for ri, rd := range jt.Data {
+			st := ri * csz
+			if err := setCellValue(col, fl.Shape, &st, rd[fl.Name]); err != nil {
+				return nil, fmt.Errorf("tablejson: column %q row %d: %w", fl.Name, ri, err)
+			}
+		}
+	}
+	return dt, nil
+}
+
+// setCellValue sets the value of the cell at given starting 1D index
+// from the nested arrays for the given cell shape, advancing the index.
+func setCellValue(col tensor.Values, shape []int, idx *int, val any) error {
+	if len(shape) == 0 {
+		i := *idx
+		*idx++
+		switch v := val.(type) {
+		case nil:
+			if col.IsString() {
+				col.SetString1D("", i)
+			} else {
+				col.SetFloat1D(math.NaN(), i)
+			}
+		case string:
+			col.SetString1D(v, i)
+		case bool:
+			if v {
+				col.SetFloat1D(1, i)
+			} else {
+				col.SetFloat1D(0, i)
+			}
+		case float64:
+			col.SetFloat1D(v, i)
+		default:
+			return fmt.Errorf("invalid value: %v", val)
+		}
+		return nil
+	}
+	vals, ok := val.([]any)
+	if !ok || len(vals) != shape[0] {
+		return fmt.Errorf("value does not match cell shape %v", shape)
+	}
+	for _, v := range vals {
+		if err := setCellValue(col, shape[1:], idx, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write writes the given table to the writer in typed JSON format.
+func Write(dt *table.Table, w io.Writer) error {
+	return json.NewEncoder(w).Encode(FromTable(dt))
+}
+
+// Read reads a table in typed JSON format from the reader.
+func Read(r io.Reader) (*table.Table, error) {
+	jt := &Table{}
+	if err := json.NewDecoder(r).Decode(jt); err != nil {
+		return nil, err
+	}
+	return jt.ToTable()
+}
+
+// Save saves the given table to the given file in typed JSON format.
+func Save(dt *table.Table, filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	bw := bufio.NewWriter(f)
+	if err := Write(dt, bw); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// Open opens a table from the given file in typed JSON format.
+func Open(filename string) (*table.Table, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(bufio.NewReader(f))
+}
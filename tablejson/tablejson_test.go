@@ -0,0 +1,59 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablejson
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTrip(t *testing.T) {
+	dt := table.New()
+	nm := dt.AddStringColumn("Name")
+	ep := dt.AddIntColumn("Epoch")
+	er := dt.AddFloat64Column("Err")
+	in := dt.AddFloat32Column("Input", 2, 3)
+	ok := dt.AddColumnOfType("Correct", reflect.Bool).(tensor.Values)
+	dt.SetNumRows(3)
+	for r := range 3 {
+		nm.SetStringRow(string(rune('a'+r)), r, 0)
+		ep.SetIntRow(r*10, r, 0)
+		er.SetFloatRow(0.25*float64(r), r, 0)
+		ok.SetFloatRow(float64(r%2), r, 0)
+		for i := range 6 {
+			in.SetFloatRow(float64(r*6+i)+0.5, r, i)
+		}
+	}
+	er.SetFloatRow(math.NaN(), 2, 0)
+
+	var b bytes.Buffer
+	assert.NoError(t, Write(dt, &b))
+	assert.Contains(t, b.String(), `{"name":"Input","type":"any","dtype":"float32","shape":[2,3]}`)
+	assert.Contains(t, b.String(), `"Input":[[6.5,7.5,8.5],[9.5,10.5,11.5]]`)
+	rt, err := Read(&b)
+	assert.NoError(t, err)
+	assert.Equal(t, dt.Columns.Keys, rt.Columns.Keys)
+	assert.Equal(t, 3, rt.NumRows())
+	for ci, col := range dt.Columns.Values {
+		rc := rt.Columns.Values[ci]
+		assert.Equal(t, col.DataType(), rc.DataType())
+		assert.Equal(t, col.ShapeSizes(), rc.ShapeSizes())
+		for i := range col.Len() {
+			if col.IsString() {
+				assert.Equal(t, col.String1D(i), rc.String1D(i))
+			} else if v := col.Float1D(i); !math.IsNaN(v) {
+				assert.Equal(t, v, rc.Float1D(i))
+			} else {
+				assert.True(t, math.IsNaN(rc.Float1D(i)))
+			}
+		}
+	}
+}
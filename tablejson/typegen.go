@@ -0,0 +1,13 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package tablejson
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/tablejson.Field", IDName: "field", Doc: "Field is the schema for one column of the table.", Fields: []types.Field{{Name: "Name", Doc: "Name is the column name."}, {Name: "Type", Doc: "Type is the JSON Table Schema type (string, number, integer,\nboolean), or any for tensor-valued cells."}, {Name: "DType", Doc: "DType is the Go data type of the column (string, bool, float32,\nfloat64, int, int32, uint8)."}, {Name: "Shape", Doc: "Shape is the cell shape for tensor-valued columns."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/tablejson.Schema", IDName: "schema", Doc: "Schema is the schema for the table.", Fields: []types.Field{{Name: "Fields", Doc: "Fields has the schema for each column, in order."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/tablejson.Table", IDName: "table", Doc: "Table is the JSON representation of a table.", Fields: []types.Field{{Name: "Schema", Doc: "Schema describes the columns."}, {Name: "Data", Doc: "Data has one object per row, with values for each column by name."}}})
@@ -0,0 +1,262 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablestream
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"cogentcore.org/lab/stats/stats"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+// moments accumulates the statistics of a stream of values,
+// using the Welford algorithm for the variance.
+type moments struct {
+	n, sum, sumSq, l1, mean, m2 float64
+	min, max, minAbs, maxAbs    float64
+	prod, first, final          float64
+}
+
+func (m *moments) add(v float64) {
+	if math.IsNaN(v) {
+		return
+	}
+	av := math.Abs(v)
+	if m.n == 0 {
+		m.min, m.max, m.first = v, v, v
+		m.minAbs, m.maxAbs, m.prod = av, av, 1
+	}
+	m.n++
+	m.sum += v
+	m.sumSq += v * v
+	m.l1 += av
+	m.prod *= v
+	m.min = min(m.min, v)
+	m.max = max(m.max, v)
+	m.minAbs = min(m.minAbs, av)
+	m.maxAbs = max(m.maxAbs, av)
+	m.final = v
+	d := v - m.mean
+	m.mean += d / m.n
+	m.m2 += d * (v - m.mean)
+}
+
+// value returns the given statistic.
+func (m *moments) value(st stats.Stats) float64 {
+	if m.n == 0 && st != stats.StatCount && st != stats.StatSum {
+		return math.NaN()
+	}
+	varSamp := func() float64 {
+		if m.n < 2 {
+			return 0
+		}
+		return m.m2 / (m.n - 1)
+	}
+	switch st {
+	case stats.StatCount:
+		return m.n
+	case stats.StatSum:
+		return m.sum
+	case stats.StatL1Norm:
+		return m.l1
+	case stats.StatProd:
+		return m.prod
+	case stats.StatMin:
+		return m.min
+	case stats.StatMax:
+		return m.max
+	case stats.StatMinAbs:
+		return m.minAbs
+	case stats.StatMaxAbs:
+		return m.maxAbs
+	case stats.StatMean:
+		return m.mean
+	case stats.StatVar:
+		return varSamp()
+	case stats.StatStd:
+		return math.Sqrt(varSamp())
+	case stats.StatSem:
+		return math.Sqrt(varSamp() / m.n)
+	case stats.StatSumSq:
+		return m.sumSq
+	case stats.StatL2Norm:
+		return math.Sqrt(m.sumSq)
+	case stats.StatVarPop:
+		return m.m2 / m.n
+	case stats.StatStdPop:
+		return math.Sqrt(m.m2 / m.n)
+	case stats.StatSemPop:
+		return math.Sqrt(m.m2/m.n) / math.Sqrt(m.n)
+	case stats.StatFirst:
+		return m.first
+	case stats.StatFinal:
+		return m.final
+	}
+	return math.NaN()
+}
+
+// StatSupported returns true if the given statistic can be computed
+// incrementally by [GroupAgg]. Order statistics such as Median and
+// quartiles require all of the data, and are not supported.
+func StatSupported(st stats.Stats) bool {
+	switch st {
+	case stats.StatMedian, stats.StatQ1, stats.StatQ3:
+		return false
+	}
+	return true
+}
+
+// GroupAgg computes grouped aggregate statistics incrementally over
+// chunks of rows, e.g., from a [Reader], for each unique combination
+// of values in the Groups columns.
+type GroupAgg struct {
+
+	// Groups are the names of the columns to group by.
+	Groups []string
+
+	// Values are the names of the columns to aggregate.
+	// If empty, all numeric columns not in Groups are used,
+	// determined from the first chunk.
+	Values []string
+
+	// Stats are the statistics to compute on each value column.
+	Stats []stats.Stats
+
+	// keys maps from the group key to the group index.
+	keys map[string]int
+
+	// groupVals are the values of the Groups columns for each group.
+	groupVals [][]string
+
+	// groupString records whether each Groups column is a string.
+	groupString []bool
+
+	// moments are the accumulators for each group and value column.
+	moments [][]moments
+}
+
+// NewGroupAgg returns a new GroupAgg for given group columns,
+// value columns, and stats, which must be supported (see [StatSupported]).
+func NewGroupAgg(groups, values []string, sts ...stats.Stats) (*GroupAgg, error) {
+	for _, st := range sts {
+		if !StatSupported(st) {
+			return nil, fmt.Errorf("tablestream.NewGroupAgg: stat %s cannot be computed incrementally", st)
+		}
+	}
+	if len(sts) == 0 {
+		sts = []stats.Stats{stats.StatMean}
+	}
+	return &GroupAgg{Groups: groups, Values: values, Stats: sts, keys: make(map[string]int)}, nil
+}
+
+// Add accumulates the statistics for all rows in the given table chunk.
+func (ga *GroupAgg) Add(dt *table.Table) error {
+	gcs := make([]*tensor.Rows, len(ga.Groups))
+	for i, nm := range ga.Groups {
+		col, err := dt.ColumnTry(nm)
+		if err != nil {
+			return err
+		}
+		gcs[i] = col
+	}
+	if ga.groupString == nil {
+		ga.groupString = make([]bool, len(gcs))
+		for i, col := range gcs {
+			ga.groupString[i] = col.IsString()
+		}
+		if len(ga.Values) == 0 {
+			for ci, nm := range dt.Columns.Keys {
+				col := dt.Columns.Values[ci]
+				if col.IsString() || col.NumDims() > 1 {
+					continue
+				}
+				isGp := false
+				for _, gn := range ga.Groups {
+					isGp = isGp || gn == nm
+				}
+				if !isGp {
+					ga.Values = append(ga.Values, nm)
+				}
+			}
+		}
+	}
+	vcs := make([]*tensor.Rows, len(ga.Values))
+	for i, nm := range ga.Values {
+		col, err := dt.ColumnTry(nm)
+		if err != nil {
+			return err
+		}
+		vcs[i] = col
+	}
+	gvals := make([]string, len(gcs))
+	var key strings.Builder
+	for ri := range dt.NumRows() {
+		key.Reset()
+		for i, col := range gcs {
+			gvals[i] = col.StringRow(ri, 0)
+			key.WriteString(gvals[i])
+			key.WriteByte(0)
+		}
+		gi, ok := ga.keys[key.String()]
+		if !ok {
+			gi = len(ga.groupVals)
+			ga.keys[key.String()] = gi
+			ga.groupVals = append(ga.groupVals, append([]string(nil), gvals...))
+			ga.moments = append(ga.moments, make([]moments, len(vcs)))
+		}
+		ms := ga.moments[gi]
+		for i, col := range vcs {
+			ms[i].add(col.FloatRow(ri, 0))
+		}
+	}
+	return nil
+}
+
+// NumGroups returns the number of unique groups so far.
+func (ga *GroupAgg) NumGroups() int {
+	return len(ga.groupVals)
+}
+
+// Table returns the results as a table, with a row for each group in
+// order of first appearance, the Groups columns, and then a float64
+// column for each value column and stat, named by the value column if
+// there is only one stat, and Value/Stat otherwise.
+func (ga *GroupAgg) Table() *table.Table {
+	dt := table.New()
+	for i, nm := range ga.Groups {
+		if i < len(ga.groupString) && !ga.groupString[i] {
+			dt.AddFloat64Column(nm)
+		} else {
+			dt.AddStringColumn(nm)
+		}
+	}
+	for _, vn := range ga.Values {
+		for _, st := range ga.Stats {
+			if len(ga.Stats) == 1 {
+				dt.AddFloat64Column(vn)
+			} else {
+				dt.AddFloat64Column(vn + "/" + st.String())
+			}
+		}
+	}
+	ng := len(ga.Groups)
+	dt.SetNumRows(ga.NumGroups())
+	for gi, gvals := range ga.groupVals {
+		for i, v := range gvals {
+			dt.ColumnByIndex(i).SetStringRow(v, gi, 0)
+		}
+		ci := ng
+		for vi := range ga.Values {
+			for _, st := range ga.Stats {
+				dt.ColumnByIndex(ci).SetFloatRow(ga.moments[gi][vi].value(st), gi, 0)
+				ci++
+			}
+		}
+	}
+	return dt
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package tablestream provides out-of-core processing of large table files
+(e.g., cycle-level logs from long runs), by reading rows in chunks from
+disk, and computing grouped aggregate statistics incrementally over the
+chunks, without loading the full table into memory.
+*/
+package tablestream
+
+//go:generate core generate -add-types
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+)
+
+// Reader reads rows from a TSV / CSV file with typed table headers
+// (as written by [table.Table.SaveCSV]), in chunks of rows.
+type Reader struct {
+
+	// Chunk is the number of rows to read in each chunk.
+	Chunk int
+
+	// Table is the current chunk of rows, which is configured from the
+	// headers, and re-used for each chunk.
+	Table *table.Table
+
+	// Rows is the total number of rows read so far.
+	Rows int
+
+	// cr is the csv reader.
+	cr *csv.Reader
+
+	// file is the open file if opened with Open.
+	file *os.File
+}
+
+// DetectDelim returns the delimiter for given first line of a file:
+// Tab if it contains a tab, and Comma otherwise.
+func DetectDelim(line string) tensor.Delims {
+	if strings.Contains(line, "\t") {
+		return tensor.Tab
+	}
+	return tensor.Comma
+}
+
+// NewReader returns a new Reader for given source, reading the headers
+// from the first line, with the delimiter detected from that line.
+func NewReader(r io.Reader, chunk int) (*Reader, error) {
+	if chunk <= 0 {
+		return nil, errors.New("tablestream.NewReader: chunk must be > 0")
+	}
+	br := bufio.NewReader(r)
+	line, err := br.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return nil, fmt.Errorf("tablestream.NewReader: reading headers: %w", err)
+	}
+	rd := &Reader{Chunk: chunk}
+	rd.cr = csv.NewReader(io.MultiReader(strings.NewReader(line), br))
+	rd.cr.Comma = DetectDelim(line).Rune()
+	rd.cr.ReuseRecord = true
+	hdrs, err := rd.cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("tablestream.NewReader: reading headers: %w", err)
+	}
+	if !table.DetectTableHeaders(hdrs) {
+		return nil, errors.New("tablestream.NewReader: file does not have typed table headers")
+	}
+	rd.Table = table.New()
+	if err := table.ConfigFromTableHeaders(rd.Table, hdrs); err != nil {
+		return nil, err
+	}
+	return rd, nil
+}
+
+// Open returns a new Reader for given file. Call Close when done.
+func Open(filename string, chunk int) (*Reader, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	rd, err := NewReader(f, chunk)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	rd.file = f
+	return rd, nil
+}
+
+// Next reads the next chunk of up to Chunk rows into the Table, which is
+// returned. Returns [io.EOF] when there are no more rows.
+func (rd *Reader) Next() (*table.Table, error) {
+	dt := rd.Table
+	dt.SetNumRows(rd.Chunk)
+	n := 0
+	for n < rd.Chunk {
+		rec, err := rd.cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			dt.SetNumRows(n)
+			return dt, err
+		}
+		if len(rec) == 0 || (len(rec) == 1 && rec[0] == "") {
+			continue
+		}
+		dt.ReadCSVRow(rec, n)
+		n++
+	}
+	dt.SetNumRows(n)
+	rd.Rows += n
+	if n == 0 {
+		return dt, io.EOF
+	}
+	return dt, nil
+}
+
+// Close closes the file if opened with Open.
+func (rd *Reader) Close() error {
+	if rd.file == nil {
+		return nil
+	}
+	return rd.file.Close()
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablestream
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cogentcore.org/lab/stats/stats"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupAgg(t *testing.T) {
+	dt := table.New()
+	cond := dt.AddStringColumn("Cond")
+	cyc := dt.AddIntColumn("Cycle")
+	act := dt.AddFloat64Column("Act")
+	dt.SetNumRows(20)
+	for r := range 20 {
+		cond.SetStringRow([]string{"A", "B"}[r%2], r, 0)
+		cyc.SetIntRow(r/2, r, 0)
+		act.SetFloatRow(float64(r*r)/10, r, 0)
+	}
+	var b bytes.Buffer
+	assert.NoError(t, dt.WriteCSV(&b, tensor.Comma, table.Headers))
+
+	rd, err := NewReader(&b, 7)
+	assert.NoError(t, err)
+	_, err = NewGroupAgg([]string{"Cond"}, nil, stats.StatMedian)
+	assert.Error(t, err)
+	ga, err := NewGroupAgg([]string{"Cond"}, nil, stats.StatMean, stats.StatStd, stats.StatCount)
+	assert.NoError(t, err)
+	nchunk := 0
+	for {
+		ch, err := rd.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, ch.NumRows(), 7)
+		assert.NoError(t, ga.Add(ch))
+		nchunk++
+	}
+	assert.Equal(t, 3, nchunk)
+	assert.Equal(t, 20, rd.Rows)
+	assert.Equal(t, []string{"Cycle", "Act"}, ga.Values)
+
+	res := ga.Table()
+	assert.Equal(t, 2, res.NumRows())
+	assert.Equal(t, "B", res.Column("Cond").StringRow(1, 0))
+	assert.Equal(t, 10.0, res.Column("Act/Count").FloatRow(0, 0))
+
+	// compare with full in-memory stats
+	for gi, gp := range []string{"A", "B"} {
+		ix := table.NewView(dt)
+		ix.Filter(func(dt *table.Table, row int) bool { return dt.Column("Cond").StringRow(row, 0) == gp })
+		mean := stats.Mean(ix.Column("Act")).Float1D(0)
+		std := stats.Std(ix.Column("Act")).Float1D(0)
+		assert.InDelta(t, mean, res.Column("Act/Mean").FloatRow(gi, 0), 1.0e-9)
+		assert.InDelta(t, std, res.Column("Act/Std").FloatRow(gi, 0), 1.0e-9)
+	}
+	assert.False(t, math.IsNaN(res.Column("Cycle/Mean").FloatRow(0, 0)))
+}
+
+func TestTail(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "run_epoch.tsv")
+	tl := NewTail(fn)
+	n, err := tl.Poll()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	write := func(s string, flag int) {
+		f, err := os.OpenFile(fn, flag|os.O_WRONLY|os.O_CREATE, 0666)
+		assert.NoError(t, err)
+		f.WriteString(s)
+		f.Close()
+	}
+	write("$Name\t#Epoch\t#PctErr\n", os.O_TRUNC)
+	n, err = tl.Poll()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, 3, tl.Table.NumColumns())
+
+	write("A\t0\t0.9\nB\t1\t0.7\nC\t2", os.O_APPEND) // partial row
+	n, err = tl.Poll()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 0.7, tl.Table.Column("PctErr").FloatRow(1, 0))
+
+	write("\t0.5\n", os.O_APPEND)
+	n, _ = tl.Poll()
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 3, tl.Table.NumRows())
+	assert.Equal(t, "C", tl.Table.Column("Name").StringRow(2, 0))
+	assert.Equal(t, 0.5, tl.Table.Column("PctErr").FloatRow(2, 0))
+	n, _ = tl.Poll()
+	assert.Equal(t, 0, n)
+
+	dt := tl.Table
+	write("$Name\t#Epoch\t#PctErr\nD\t0\t1\n", os.O_TRUNC) // new run
+	n, _ = tl.Poll()
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 1, tl.Table.NumRows())
+	assert.Equal(t, dt, tl.Table)
+	assert.Equal(t, "D", tl.Table.Column("Name").StringRow(0, 0))
+
+	write("no\ttypes\n", os.O_TRUNC)
+	_, err = tl.Poll()
+	assert.Error(t, err)
+}
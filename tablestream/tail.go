@@ -0,0 +1,139 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tablestream
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"slices"
+
+	"cogentcore.org/lab/table"
+)
+
+// Tail follows a TSV / CSV log file with typed table headers that is
+// being written by another process (e.g., a headless run), appending
+// the new rows to the Table each time Poll is called, like tail -f.
+// The file does not need to exist yet, and partially written rows are
+// only read once they are complete. If the file is truncated or replaced
+// by a shorter one (e.g., a new run), the Table is reset to 0 rows.
+// The same Table is used throughout, so it can be displayed in a plot.
+type Tail struct {
+
+	// Filename is the name of the file being followed.
+	Filename string
+
+	// Table has all the rows read so far, which is configured
+	// from the headers when they are first read.
+	Table *table.Table
+
+	// offset is the file offset through the last complete line read.
+	offset int64
+
+	// comma is the delimiter, detected from the headers.
+	comma rune
+
+	// headers is the header line, once read.
+	headers []string
+}
+
+// NewTail returns a new [Tail] for given file name,
+// which does not need to exist yet.
+func NewTail(filename string) *Tail {
+	return &Tail{Filename: filename, Table: table.New()}
+}
+
+// Poll reads any new complete lines that have been written to the file
+// since the last call, appending them to the Table, and returns the
+// number of new rows. If the file does not exist yet, it returns 0 and
+// no error. If the file is shorter than the amount already read, the
+// Table is reset to 0 rows and the file is read from the start.
+func (tl *Tail) Poll() (int, error) {
+	f, err := os.Open(tl.Filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if st.Size() < tl.offset {
+		tl.offset = 0
+		tl.Table.SetNumRows(0)
+	}
+	if st.Size() == tl.offset {
+		return 0, nil
+	}
+	if _, err := f.Seek(tl.offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	buf, err := io.ReadAll(f)
+	if err != nil {
+		return 0, err
+	}
+	end := bytes.LastIndexByte(buf, '\n')
+	if end < 0 { // no complete lines yet
+		return 0, nil
+	}
+	buf = buf[:end+1]
+	if tl.offset == 0 {
+		hend := bytes.IndexByte(buf, '\n')
+		if err := tl.readHeaders(string(buf[:hend+1])); err != nil {
+			return 0, err
+		}
+		tl.offset = int64(hend + 1)
+		buf = buf[hend+1:]
+	}
+	cr := csv.NewReader(bytes.NewReader(buf))
+	cr.Comma = tl.comma
+	cr.FieldsPerRecord = -1
+	dt := tl.Table
+	n := 0
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return n, err
+		}
+		if len(rec) == 0 || (len(rec) == 1 && rec[0] == "") {
+			continue
+		}
+		row := dt.NumRows()
+		dt.SetNumRows(row + 1)
+		dt.ReadCSVRow(rec, row)
+		n++
+	}
+	tl.offset += int64(len(buf))
+	return n, nil
+}
+
+// readHeaders reads the given header line, configuring the Table
+// if it has not already been configured with the same headers.
+func (tl *Tail) readHeaders(line string) error {
+	tl.comma = DetectDelim(line).Rune()
+	cr := csv.NewReader(bytes.NewReader([]byte(line)))
+	cr.Comma = tl.comma
+	hdrs, err := cr.Read()
+	if err != nil {
+		return err
+	}
+	if !table.DetectTableHeaders(hdrs) {
+		return errors.New("tablestream.Tail: file does not have typed table headers")
+	}
+	if tl.headers != nil && slices.Equal(tl.headers, hdrs) {
+		return nil
+	}
+	tl.headers = hdrs
+	tl.Table.DeleteAll()
+	return table.ConfigFromTableHeaders(tl.Table, hdrs)
+}
@@ -0,0 +1,15 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package tablestream
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/tablestream.moments", IDName: "moments", Doc: "moments accumulates the statistics of a stream of values,\nusing the Welford algorithm for the variance.", Fields: []types.Field{{Name: "n"}, {Name: "sum"}, {Name: "sumSq"}, {Name: "l1"}, {Name: "mean"}, {Name: "m2"}, {Name: "min"}, {Name: "max"}, {Name: "minAbs"}, {Name: "maxAbs"}, {Name: "prod"}, {Name: "first"}, {Name: "final"}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/tablestream.GroupAgg", IDName: "group-agg", Doc: "GroupAgg computes grouped aggregate statistics incrementally over\nchunks of rows, e.g., from a [Reader], for each unique combination\nof values in the Groups columns.", Fields: []types.Field{{Name: "Groups", Doc: "Groups are the names of the columns to group by."}, {Name: "Values", Doc: "Values are the names of the columns to aggregate.\nIf empty, all numeric columns not in Groups are used,\ndetermined from the first chunk."}, {Name: "Stats", Doc: "Stats are the statistics to compute on each value column."}, {Name: "keys", Doc: "keys maps from the group key to the group index."}, {Name: "groupVals", Doc: "groupVals are the values of the Groups columns for each group."}, {Name: "groupString", Doc: "groupString records whether each Groups column is a string."}, {Name: "moments", Doc: "moments are the accumulators for each group and value column."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/tablestream.Reader", IDName: "reader", Doc: "Reader reads rows from a TSV / CSV file with typed table headers\n(as written by [table.Table.SaveCSV]), in chunks of rows.", Fields: []types.Field{{Name: "Chunk", Doc: "Chunk is the number of rows to read in each chunk."}, {Name: "Table", Doc: "Table is the current chunk of rows, which is configured from the\nheaders, and re-used for each chunk."}, {Name: "Rows", Doc: "Rows is the total number of rows read so far."}, {Name: "cr", Doc: "cr is the csv reader."}, {Name: "file", Doc: "file is the open file if opened with Open."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/tablestream.Tail", IDName: "tail", Doc: "Tail follows a TSV / CSV log file with typed table headers that is\nbeing written by another process (e.g., a headless run), appending\nthe new rows to the Table each time Poll is called, like tail -f.\nThe file does not need to exist yet, and partially written rows are\nonly read once they are complete. If the file is truncated or replaced\nby a shorter one (e.g., a new run), the Table is reset to 0 rows.\nThe same Table is used throughout, so it can be displayed in a plot.", Fields: []types.Field{{Name: "Filename", Doc: "Filename is the name of the file being followed."}, {Name: "Table", Doc: "Table has all the rows read so far, which is configured\nfrom the headers when they are first read."}, {Name: "offset", Doc: "offset is the file offset through the last complete line read."}, {Name: "comma", Doc: "comma is the delimiter, detected from the headers."}, {Name: "headers", Doc: "headers is the header line, once read."}}})
@@ -0,0 +1,13 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package trajectory projects the activity states of a layer across a
+sequence of trials or cycles into 2D, using PCA or a simplified UMAP-style
+neighbor embedding, and animates the resulting trajectory in a plot,
+for visualizing attractor dynamics and state transitions in recurrent models.
+*/
+package trajectory
+
+//go:generate core generate -add-types
@@ -0,0 +1,50 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package trajectory
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _ProjectionsValues = []Projections{0, 1}
+
+// ProjectionsN is the highest valid value for type Projections, plus one.
+const ProjectionsN Projections = 2
+
+var _ProjectionsValueMap = map[string]Projections{`PCA`: 0, `NeighborEmbed`: 1}
+
+var _ProjectionsDescMap = map[Projections]string{0: `PCA projects onto the first two principal components, which is linear and fast, and preserves global structure.`, 1: `NeighborEmbed starts from the PCA projection, and then optimizes the 2D positions so that the nearest neighbors of each state in the original space are also near in 2D, with repulsion from other states, as in a simplified version of UMAP. This better preserves local structure, such as the separate attractor basins visited by a trajectory.`}
+
+var _ProjectionsMap = map[Projections]string{0: `PCA`, 1: `NeighborEmbed`}
+
+// String returns the string representation of this Projections value.
+func (i Projections) String() string { return enums.String(i, _ProjectionsMap) }
+
+// SetString sets the Projections value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Projections) SetString(s string) error {
+	return enums.SetString(i, s, _ProjectionsValueMap, "Projections")
+}
+
+// Int64 returns the Projections value as an int64.
+func (i Projections) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Projections value from an int64.
+func (i *Projections) SetInt64(in int64) { *i = Projections(in) }
+
+// Desc returns the description of the Projections value.
+func (i Projections) Desc() string { return enums.Desc(i, _ProjectionsDescMap) }
+
+// ProjectionsValues returns all possible values for the type Projections.
+func ProjectionsValues() []Projections { return _ProjectionsValues }
+
+// Values returns all possible values for the type Projections.
+func (i Projections) Values() []enums.Enum { return enums.Values(_ProjectionsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Projections) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Projections) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "Projections")
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trajectory
+
+import (
+	"time"
+
+	"cogentcore.org/core/colors"
+	"cogentcore.org/lab/plot"
+	"cogentcore.org/lab/plot/plots"
+	"cogentcore.org/lab/plotcore"
+)
+
+// Frame returns a plot of the projected trajectory up through given
+// step, for one frame of an animation: the full trajectory is drawn
+// as a faint dashed line, the states up to step as a line with points,
+// and the state at step is labeled. Use a step of Len() - 1 (or -1)
+// to show the whole trajectory. Project must have been called.
+func (tr *Trajectory) Frame(step int) *plot.Plot {
+	pl := plot.New()
+	pl.Title.Text = tr.Name
+	pl.X.Label.Text = "X"
+	pl.Y.Label.Text = "Y"
+	n := tr.Len()
+	if tr.Proj == nil || n == 0 {
+		return pl
+	}
+	n = min(n, tr.Proj.DimSize(0))
+	if step < 0 || step >= n {
+		step = n - 1
+	}
+	xs := make(plot.Values, n)
+	ys := make(plot.Values, n)
+	for i := range n {
+		xs[i] = tr.Proj.Values[2*i]
+		ys[i] = tr.Proj.Values[2*i+1]
+	}
+	all := plots.NewLine(plot.Data{plot.X: xs, plot.Y: ys})
+	all.Styler(func(s *plot.Style) {
+		s.Line.Color = colors.Uniform(colors.Gray)
+		s.Line.Dashes = []float32{2, 4}
+		s.Line.NegativeX = true
+		s.NoLegend = true
+	})
+	path := plots.NewLine(plot.Data{plot.X: xs[:step+1], plot.Y: ys[:step+1]})
+	path.Styler(func(s *plot.Style) {
+		s.Line.NegativeX = true
+		s.Point.On = plot.On
+		s.NoLegend = true
+	})
+	cur := plots.NewLabels(plot.Data{plot.X: xs[step : step+1], plot.Y: ys[step : step+1], plot.Label: plot.Labels{tr.Labels[step]}})
+	pl.Add(all, path, cur)
+	return pl
+}
+
+// Animate shows the projected trajectory in given plot widget,
+// one step at a time with given delay between steps, calling
+// Project first if needed. This should be called in a separate
+// goroutine from the GUI.
+func (tr *Trajectory) Animate(pw *plotcore.Plot, delay time.Duration) error {
+	if tr.Proj == nil {
+		if err := tr.Project(); err != nil {
+			return err
+		}
+	}
+	for step := range tr.Len() {
+		pw.AsyncLock()
+		pw.SetPlot(tr.Frame(step))
+		pw.Update()
+		pw.AsyncUnlock()
+		time.Sleep(delay)
+	}
+	return nil
+}
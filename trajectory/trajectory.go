@@ -0,0 +1,276 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trajectory
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"cogentcore.org/core/base/metadata"
+	"cogentcore.org/lab/base/randx"
+	"cogentcore.org/lab/matrix"
+	"cogentcore.org/lab/stats/metric"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/emer/emergent/v2/emer"
+)
+
+// Projections are the methods for projecting states into 2D.
+type Projections int32 //enums:enum
+
+const (
+	// PCA projects onto the first two principal components,
+	// which is linear and fast, and preserves global structure.
+	PCA Projections = iota
+
+	// NeighborEmbed starts from the PCA projection, and then optimizes
+	// the 2D positions so that the nearest neighbors of each state in
+	// the original space are also near in 2D, with repulsion from other
+	// states, as in a simplified version of UMAP. This better preserves
+	// local structure, such as the separate attractor basins
+	// visited by a trajectory.
+	NeighborEmbed
+)
+
+// Trajectory records the activity states of a layer (or any vector
+// of values) across a sequence of trials or cycles, and projects
+// them into 2D, for visualizing attractor dynamics and state
+// transitions in recurrent models, as a table ([Trajectory.Table])
+// or as an animated plot ([Trajectory.Frame], [Trajectory.Animate]).
+type Trajectory struct {
+
+	// Name of the trajectory, used as the plot title.
+	Name string
+
+	// Projection is the method for projecting into 2D.
+	Projection Projections
+
+	// MaxStates is the maximum number of states to record, after which
+	// the oldest are dropped (0 = no limit).
+	MaxStates int
+
+	// NNeighbors is the number of nearest neighbors for NeighborEmbed.
+	NNeighbors int `default:"8"`
+
+	// NIters is the number of optimization iterations for NeighborEmbed.
+	NIters int `default:"200"`
+
+	// Rand is the random number source for NeighborEmbed (nil = global).
+	Rand randx.Rand `display:"-"`
+
+	// States are the recorded activity states.
+	States [][]float32 `display:"-"`
+
+	// Labels are the labels for each state, e.g., the trial name.
+	Labels []string `display:"-"`
+
+	// Proj is the 2D projection of the States, as [n][2],
+	// computed by Project.
+	Proj *tensor.Float64 `display:"-"`
+}
+
+// New returns a new [Trajectory] with given name and projection method.
+func New(name string, proj Projections) *Trajectory {
+	tr := &Trajectory{Name: name, Projection: proj}
+	tr.Defaults()
+	return tr
+}
+
+func (tr *Trajectory) Defaults() {
+	tr.NNeighbors = 8
+	tr.NIters = 200
+}
+
+// Reset removes all recorded states.
+func (tr *Trajectory) Reset() {
+	tr.States = nil
+	tr.Labels = nil
+	tr.Proj = nil
+}
+
+// Len returns the number of recorded states.
+func (tr *Trajectory) Len() int {
+	return len(tr.States)
+}
+
+// Add records a copy of given activity state values, with given label.
+func (tr *Trajectory) Add(vals []float32, label string) error {
+	if len(tr.States) > 0 && len(vals) != len(tr.States[0]) {
+		return fmt.Errorf("trajectory.Add: state size %d != previous size %d", len(vals), len(tr.States[0]))
+	}
+	if tr.MaxStates > 0 && len(tr.States) >= tr.MaxStates {
+		nd := len(tr.States) - tr.MaxStates + 1
+		tr.States = tr.States[nd:]
+		tr.Labels = tr.Labels[nd:]
+	}
+	tr.States = append(tr.States, append([]float32(nil), vals...))
+	tr.Labels = append(tr.Labels, label)
+	tr.Proj = nil
+	return nil
+}
+
+// AddLayer records the values of given unit variable on given layer,
+// for given data parallel index, with given label.
+func (tr *Trajectory) AddLayer(ly emer.Layer, varNm string, di int, label string) error {
+	var vals []float32
+	if err := ly.AsEmer().UnitValues(&vals, varNm, di); err != nil {
+		return err
+	}
+	return tr.Add(vals, label)
+}
+
+// Project computes the 2D projection of the recorded states into Proj,
+// using the Projection method. Adding a state resets Proj to nil.
+func (tr *Trajectory) Project() error {
+	n := tr.Len()
+	if n < 2 {
+		return fmt.Errorf("trajectory.Project: need at least 2 states, have %d", n)
+	}
+	tr.pca()
+	if tr.Projection == NeighborEmbed {
+		tr.neighborEmbed()
+	}
+	return nil
+}
+
+// pca computes the projection onto the first two principal components.
+func (tr *Trajectory) pca() {
+	n := tr.Len()
+	dim := len(tr.States[0])
+	data := tensor.NewFloat64(n, dim)
+	mean := make([]float64, dim)
+	for i, st := range tr.States {
+		for j, v := range st {
+			data.Values[i*dim+j] = float64(v)
+			mean[j] += float64(v)
+		}
+	}
+	for j := range mean {
+		mean[j] /= float64(n)
+	}
+	tr.Proj = tensor.NewFloat64(n, 2)
+	if dim == 1 {
+		for i := range n {
+			tr.Proj.Values[2*i] = data.Values[i] - mean[0]
+		}
+		return
+	}
+	cov := metric.CovarianceMatrix(metric.Covariance, data)
+	vecs, _ := matrix.SVD(cov)
+	for i := range n {
+		for c := range 2 {
+			sum := 0.0
+			for j := range dim {
+				sum += (data.Values[i*dim+j] - mean[j]) * vecs.Float(j, c)
+			}
+			tr.Proj.Values[2*i+c] = sum
+		}
+	}
+}
+
+// neighborEmbed optimizes the PCA projection to preserve the nearest
+// neighbors of each state, using attraction toward neighbors and
+// repulsion from randomly sampled other states, with the UMAP
+// gradients for a = b = 1.
+func (tr *Trajectory) neighborEmbed() {
+	n := tr.Len()
+	nbrs := tr.neighbors()
+	y := tr.Proj.Values
+	// scale to unit SD so the step sizes are well defined
+	ss := 0.0
+	for _, v := range y {
+		ss += v * v
+	}
+	if sd := math.Sqrt(ss / float64(n)); sd > 0 {
+		for i := range y {
+			y[i] /= sd
+		}
+	}
+	intn := rand.Intn
+	if tr.Rand != nil {
+		intn = tr.Rand.Intn
+	}
+	clip := func(g float64) float64 { return max(-4, min(4, g)) }
+	for it := range tr.NIters {
+		lr := 1 - float64(it)/float64(tr.NIters)
+		for i := range n {
+			for _, j := range nbrs[i] {
+				dx, dy := y[2*i]-y[2*j], y[2*i+1]-y[2*j+1]
+				c := -2 / (1 + dx*dx + dy*dy)
+				y[2*i] += lr * clip(c*dx)
+				y[2*i+1] += lr * clip(c*dy)
+				l := intn(n)
+				if l == i {
+					continue
+				}
+				dx, dy = y[2*i]-y[2*l], y[2*i+1]-y[2*l+1]
+				d2 := dx*dx + dy*dy
+				c = 2 / ((0.001 + d2) * (1 + d2))
+				y[2*i] += lr * clip(c*dx)
+				y[2*i+1] += lr * clip(c*dy)
+			}
+		}
+	}
+}
+
+// neighbors returns the indexes of the NNeighbors nearest neighbors of
+// each state, in terms of Euclidean distance.
+func (tr *Trajectory) neighbors() [][]int {
+	n := tr.Len()
+	k := min(tr.NNeighbors, n-1)
+	nbrs := make([][]int, n)
+	dists := make([]float64, n)
+	for i, si := range tr.States {
+		for j, sj := range tr.States {
+			d := 0.0
+			for u, v := range si {
+				dv := float64(v - sj[u])
+				d += dv * dv
+			}
+			dists[j] = d
+		}
+		dists[i] = math.Inf(1)
+		nb := make([]int, 0, k)
+		for range k {
+			mj := 0
+			for j, d := range dists {
+				if d < dists[mj] {
+					mj = j
+				}
+			}
+			nb = append(nb, mj)
+			dists[mj] = math.Inf(1)
+		}
+		nbrs[i] = nb
+	}
+	return nbrs
+}
+
+// Table returns a table of the 2D projection, with columns
+// Step, Label, X, and Y, calling Project if needed.
+func (tr *Trajectory) Table() (*table.Table, error) {
+	if tr.Proj == nil {
+		if err := tr.Project(); err != nil {
+			return nil, err
+		}
+	}
+	dt := table.New()
+	metadata.SetName(dt, tr.Name)
+	tensor.SetPrecision(dt, 4)
+	dt.AddIntColumn("Step")
+	dt.AddStringColumn("Label")
+	dt.AddFloat64Column("X")
+	dt.AddFloat64Column("Y")
+	n := tr.Len()
+	dt.SetNumRows(n)
+	for i := range n {
+		dt.Column("Step").SetIntRow(i, i, 0)
+		dt.Column("Label").SetStringRow(tr.Labels[i], i, 0)
+		dt.Column("X").SetFloatRow(tr.Proj.Values[2*i], i, 0)
+		dt.Column("Y").SetFloatRow(tr.Proj.Values[2*i+1], i, 0)
+	}
+	return dt, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trajectory
+
+import (
+	"math"
+	"testing"
+
+	"cogentcore.org/lab/base/randx"
+	"github.com/stretchr/testify/assert"
+)
+
+// twoBasins adds states that alternate between settling into
+// two attractor basins, with small variations around each.
+func twoBasins(tr *Trajectory) {
+	for i := range 20 {
+		v := float32(i%5) * 0.01
+		if (i/5)%2 == 0 {
+			tr.Add([]float32{1 + v, 0, 1, 0 - v}, "A")
+		} else {
+			tr.Add([]float32{0, 1 - v, 0 + v, 1}, "B")
+		}
+	}
+}
+
+func TestPCA(t *testing.T) {
+	tr := New("test", PCA)
+	assert.Error(t, tr.Project())
+	twoBasins(tr)
+	assert.Error(t, tr.Add([]float32{1}, "bad"))
+	dt, err := tr.Table()
+	assert.NoError(t, err)
+	assert.Equal(t, 20, dt.NumRows())
+	xa := dt.Column("X").FloatRow(0, 0)
+	xb := dt.Column("X").FloatRow(5, 0)
+	assert.Greater(t, math.Abs(xa-xb), 1.0) // basins separated on PC1
+	assert.Equal(t, "B", dt.Column("Label").StringRow(5, 0))
+
+	tr.MaxStates = 10
+	tr.Add([]float32{1, 0, 1, 0}, "A")
+	assert.Equal(t, 10, tr.Len())
+	assert.Nil(t, tr.Proj)
+
+	pl := tr.Frame(3)
+	assert.Equal(t, "test", pl.Title.Text)
+}
+
+func TestNeighborEmbed(t *testing.T) {
+	tr := New("test", NeighborEmbed)
+	tr.NNeighbors = 4
+	tr.Rand = randx.NewSysRand(1)
+	twoBasins(tr)
+	assert.NoError(t, tr.Project())
+	// mean within-basin distance is less than between-basin distance
+	var within, between float64
+	var nw, nb int
+	for i := range 20 {
+		for j := range i {
+			dx := tr.Proj.Values[2*i] - tr.Proj.Values[2*j]
+			dy := tr.Proj.Values[2*i+1] - tr.Proj.Values[2*j+1]
+			d := math.Sqrt(dx*dx + dy*dy)
+			if tr.Labels[i] == tr.Labels[j] {
+				within += d
+				nw++
+			} else {
+				between += d
+				nb++
+			}
+		}
+	}
+	assert.Less(t, within/float64(nw), between/float64(nb))
+}
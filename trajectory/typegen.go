@@ -0,0 +1,11 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package trajectory
+
+import (
+	"cogentcore.org/core/types"
+)
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/trajectory.Projections", IDName: "projections", Doc: "Projections are the methods for projecting states into 2D.", Directives: []types.Directive{{Tool: "go", Directive: "generate", Args: []string{"core", "generate", "-add-types"}}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/trajectory.Trajectory", IDName: "trajectory", Doc: "Trajectory records the activity states of a layer (or any vector\nof values) across a sequence of trials or cycles, and projects\nthem into 2D, for visualizing attractor dynamics and state\ntransitions in recurrent models, as a table ([Trajectory.Table])\nor as an animated plot ([Trajectory.Frame], [Trajectory.Animate]).", Fields: []types.Field{{Name: "Name", Doc: "Name of the trajectory, used as the plot title."}, {Name: "Projection", Doc: "Projection is the method for projecting into 2D."}, {Name: "MaxStates", Doc: "MaxStates is the maximum number of states to record, after which\nthe oldest are dropped (0 = no limit)."}, {Name: "NNeighbors", Doc: "NNeighbors is the number of nearest neighbors for NeighborEmbed."}, {Name: "NIters", Doc: "NIters is the number of optimization iterations for NeighborEmbed."}, {Name: "Rand", Doc: "Rand is the random number source for NeighborEmbed (nil = global)."}, {Name: "States", Doc: "States are the recorded activity states."}, {Name: "Labels", Doc: "Labels are the labels for each state, e.g., the trial name."}, {Name: "Proj", Doc: "Proj is the 2D projection of the States, as [n][2],\ncomputed by Project."}}})
@@ -7,14 +7,17 @@ package weights
 import (
 	"bufio"
 	"errors"
-	"fmt"
 	"io"
-	"log"
 	"strconv"
 	"strings"
 )
 
-// NetReadCpp reads weights for entire network from old emergent C++ format
+// errUnrecognized is the error for unrecognized input in NetReadCpp.
+var errUnrecognized = errors.New("unrecognized input")
+
+// NetReadCpp reads weights for entire network from old emergent C++ format.
+// Any errors are returned joined together, each as a [*ParseError]
+// with the line number of the input.
 func NetReadCpp(r io.Reader) (*Network, error) {
 	nw := &Network{}
 	var (
@@ -28,14 +31,19 @@ func NetReadCpp(r io.Reader) (*Network, error) {
 		err      error
 		errlist  []error
 	)
+	line := 0
+	bs := ""
+	perr := func(err error) {
+		errlist = append(errlist, &ParseError{Format: "cpp", Line: line, Input: bs, Err: err})
+	}
 	scan := bufio.NewScanner(r) // line at a time
 	for scan.Scan() {
+		line++
 		if skipnext {
 			skipnext = false
 			continue
 		}
-		b := scan.Bytes()
-		bs := string(b)
+		bs = scan.Text()
 		switch {
 		case strings.HasPrefix(bs, "</"): // don't care about any ending tags
 			continue
@@ -63,7 +71,7 @@ func NetReadCpp(r io.Reader) (*Network, error) {
 			uss := strings.Split(us, " ") // includes unit name
 			ri, err = strconv.Atoi(uss[0])
 			if err != nil {
-				errlist = append(errlist, err)
+				perr(err)
 			}
 			continue
 		case strings.HasPrefix(bs, "<Cg "):
@@ -71,7 +79,7 @@ func NetReadCpp(r io.Reader) (*Network, error) {
 			css := strings.Split(cs, " ")
 			pi, err = strconv.Atoi(css[0])
 			if err != nil {
-				errlist = append(errlist, err)
+				perr(err)
 			}
 			fm := strings.TrimPrefix(css[1], "From:")
 			if len(lw.Paths) < pi+1 {
@@ -83,7 +91,7 @@ func NetReadCpp(r io.Reader) (*Network, error) {
 			us := strings.TrimSuffix(strings.TrimPrefix(bs, "<Cn "), ">")
 			nc, err := strconv.Atoi(us)
 			if err != nil {
-				errlist = append(errlist, err)
+				perr(err)
 			}
 			if len(pw.Rs) < ri+1 {
 				pw.Rs = append(pw.Rs, Recv{Ri: ri, N: nc})
@@ -99,9 +107,7 @@ func NetReadCpp(r io.Reader) (*Network, error) {
 		case strings.HasPrefix(bs, "<"): // misc meta
 			kvl := strings.Split(bs, " ")
 			if len(kvl) != 2 {
-				err = fmt.Errorf("NetReadCpp: unrecognized input: %v", bs)
-				errlist = append(errlist, err)
-				log.Println(err)
+				perr(errUnrecognized)
 				continue
 			}
 			ky := strings.TrimPrefix(kvl[0], "<")
@@ -126,11 +132,11 @@ func NetReadCpp(r io.Reader) (*Network, error) {
 			case 2:
 				si, err := strconv.Atoi(siwts[0])
 				if err != nil {
-					errlist = append(errlist, err)
+					perr(err)
 				}
 				wt, err := strconv.ParseFloat(siwts[1], 32)
 				if err != nil {
-					errlist = append(errlist, err)
+					perr(err)
 				}
 				rw.Si[cidx] = si
 				rw.Wt[cidx] = float32(wt)
@@ -139,35 +145,25 @@ func NetReadCpp(r io.Reader) (*Network, error) {
 			case 3:
 				si, err := strconv.Atoi(siwts[0])
 				if err != nil {
-					errlist = append(errlist, err)
+					perr(err)
 				}
 				wt, err := strconv.ParseFloat(siwts[1], 32)
 				if err != nil {
-					errlist = append(errlist, err)
+					perr(err)
 				}
 				scale, err := strconv.ParseFloat(siwts[2], 32)
 				if err != nil {
-					errlist = append(errlist, err)
+					perr(err)
 				}
 				rw.Si[cidx] = si
 				rw.Wt[cidx] = float32(wt)
 				rw.Wt1[cidx] = float32(scale)
 				cidx++
 			default:
-				err = fmt.Errorf("NetReadCpp: unrecognized input: %v", bs)
-				errlist = append(errlist, err)
-				log.Println(err)
+				perr(errUnrecognized)
 				continue
 			}
 		}
 	}
-	var eall error
-	if len(errlist) > 0 {
-		es := ""
-		for _, er := range errlist {
-			es = es + er.Error() + "\n"
-		}
-		eall = errors.New(es)
-	}
-	return nw, eall
+	return nw, errors.Join(errlist...)
 }
@@ -0,0 +1,91 @@
+// Code generated by "core generate -add-types"; DO NOT EDIT.
+
+package weights
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _WtFormatsValues = []WtFormats{0, 1, 2}
+
+// WtFormatsN is the highest valid value for type WtFormats, plus one.
+const WtFormatsN WtFormats = 3
+
+var _WtFormatsValueMap = map[string]WtFormats{`WtFloat32`: 0, `WtFloat16`: 1, `WtBFloat16`: 2}
+
+var _WtFormatsDescMap = map[WtFormats]string{0: `WtFloat32 writes full float32 weight values as text, in the Wt field.`, 1: `WtFloat16 writes Float16 weight values, base64 encoded in the Wt16 field, for much smaller weights files.`, 2: `WtBFloat16 writes BFloat16 weight values, base64 encoded in the WtB16 field, for much smaller weights files.`}
+
+var _WtFormatsMap = map[WtFormats]string{0: `WtFloat32`, 1: `WtFloat16`, 2: `WtBFloat16`}
+
+// String returns the string representation of this WtFormats value.
+func (i WtFormats) String() string { return enums.String(i, _WtFormatsMap) }
+
+// SetString sets the WtFormats value from its string representation,
+// and returns an error if the string is invalid.
+func (i *WtFormats) SetString(s string) error {
+	return enums.SetString(i, s, _WtFormatsValueMap, "WtFormats")
+}
+
+// Int64 returns the WtFormats value as an int64.
+func (i WtFormats) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the WtFormats value from an int64.
+func (i *WtFormats) SetInt64(in int64) { *i = WtFormats(in) }
+
+// Desc returns the description of the WtFormats value.
+func (i WtFormats) Desc() string { return enums.Desc(i, _WtFormatsDescMap) }
+
+// WtFormatsValues returns all possible values for the type WtFormats.
+func WtFormatsValues() []WtFormats { return _WtFormatsValues }
+
+// Values returns all possible values for the type WtFormats.
+func (i WtFormats) Values() []enums.Enum { return enums.Values(_WtFormatsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i WtFormats) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *WtFormats) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "WtFormats")
+}
+
+var _RemapsValues = []Remaps{0, 1, 2, 3}
+
+// RemapsN is the highest valid value for type Remaps, plus one.
+const RemapsN Remaps = 4
+
+var _RemapsValueMap = map[string]Remaps{`Nearest`: 0, `Bilinear`: 1, `Truncate`: 2, `Tile`: 3}
+
+var _RemapsDescMap = map[Remaps]string{0: `Nearest uses the weight of the nearest saved unit, over topographic coordinates.`, 1: `Bilinear linearly interpolates between the weights of the (up to four) nearest saved units, over topographic coordinates.`, 2: `Truncate uses the weight of the saved unit at the same coordinates, and no weight (leaving the initial weight) for units outside of the saved shape, dropping saved units outside of the new shape.`, 3: `Tile repeats the saved weights over the new shape, with coordinates wrapping around the saved shape.`}
+
+var _RemapsMap = map[Remaps]string{0: `Nearest`, 1: `Bilinear`, 2: `Truncate`, 3: `Tile`}
+
+// String returns the string representation of this Remaps value.
+func (i Remaps) String() string { return enums.String(i, _RemapsMap) }
+
+// SetString sets the Remaps value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Remaps) SetString(s string) error {
+	return enums.SetString(i, s, _RemapsValueMap, "Remaps")
+}
+
+// Int64 returns the Remaps value as an int64.
+func (i Remaps) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Remaps value from an int64.
+func (i *Remaps) SetInt64(in int64) { *i = Remaps(in) }
+
+// Desc returns the description of the Remaps value.
+func (i Remaps) Desc() string { return enums.Desc(i, _RemapsDescMap) }
+
+// RemapsValues returns all possible values for the type Remaps.
+func RemapsValues() []Remaps { return _RemapsValues }
+
+// Values returns all possible values for the type Remaps.
+func (i Remaps) Values() []enums.Enum { return enums.Values(_RemapsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Remaps) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Remaps) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Remaps") }
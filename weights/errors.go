@@ -0,0 +1,37 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import "fmt"
+
+// ParseError is returned when weights could not be read,
+// with the underlying error.
+type ParseError struct {
+
+	// Format is the format being read: "json" or "cpp".
+	Format string
+
+	// Line is the line number of the input at which the error
+	// occurred, if known, starting at 1.
+	Line int
+
+	// Input is the input line that could not be parsed, if known.
+	Input string
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("weights: %s line %d: %q: %v", e.Format, e.Line, e.Input, e.Err)
+	}
+	return fmt.Sprintf("weights: %s: %v", e.Format, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	var pe *ParseError
+	nw, err := NetReadJSON(strings.NewReader(`{"MetaData": 3, "Network": "Net"}`))
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, "json", pe.Format)
+	// the values that could be decoded are returned with the error
+	assert.Equal(t, "Net", nw.Network)
+
+	// empty input is not an error, but there are no weights
+	lw, err := LayReadJSON(strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Nil(t, lw)
+	_, err = LayReadJSON(strings.NewReader("{"))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	_, err = PathReadJSON(strings.NewReader("{"))
+	assert.True(t, errors.As(err, &pe))
+
+	cpp := "<Network>\n<Lay Input>\n<bad meta data>\n<UgUn x>\n"
+	_, err = NetReadCpp(strings.NewReader(cpp))
+	assert.True(t, errors.As(err, &pe))
+	assert.Equal(t, "cpp", pe.Format)
+	assert.Equal(t, 3, pe.Line)
+	assert.Equal(t, "<bad meta data>", pe.Input)
+	assert.ErrorIs(t, err, errUnrecognized)
+	assert.Len(t, strings.Split(err.Error(), "\n"), 2)
+}
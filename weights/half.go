@@ -0,0 +1,297 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"cogentcore.org/core/base/indent"
+)
+
+// WtFormats are the formats for writing synaptic weight values
+// in weights files.
+type WtFormats int32 //enums:enum
+
+const (
+	// WtFloat32 writes full float32 weight values as text, in the Wt field.
+	WtFloat32 WtFormats = iota
+
+	// WtFloat16 writes Float16 weight values, base64 encoded in the
+	// Wt16 field, for much smaller weights files.
+	WtFloat16
+
+	// WtBFloat16 writes BFloat16 weight values, base64 encoded in the
+	// WtB16 field, for much smaller weights files.
+	WtBFloat16
+)
+
+// WtFormat is the format for writing weight values in weights files,
+// via WriteWtJSON.  Reading weights files supports all of the formats.
+var WtFormat = WtFloat32
+
+// WriteWtJSON writes the given weight values for one receiving unit
+// in a JSON text format, as the last field in the Recv, according to
+// the WtFormat.
+func WriteWtJSON(w io.Writer, depth int, wts []float32) {
+	w.Write(indent.TabBytes(depth))
+	switch WtFormat {
+	case WtFloat16:
+		b := make([]byte, 2*len(wts))
+		for i, v := range wts {
+			binary.LittleEndian.PutUint16(b[2*i:], uint16(NewFloat16(v)))
+		}
+		fmt.Fprintf(w, "\"Wt16\": %q\n", base64.StdEncoding.EncodeToString(b))
+	case WtBFloat16:
+		b := make([]byte, 2*len(wts))
+		for i, v := range wts {
+			binary.LittleEndian.PutUint16(b[2*i:], uint16(NewBFloat16(v)))
+		}
+		fmt.Fprintf(w, "\"WtB16\": %q\n", base64.StdEncoding.EncodeToString(b))
+	default:
+		w.Write([]byte("\"Wt\": [ "))
+		for i, v := range wts {
+			w.Write([]byte(fmt.Sprintf("%g", v)))
+			if i < len(wts)-1 {
+				w.Write([]byte(", "))
+			}
+		}
+		w.Write([]byte(" ]\n"))
+	}
+}
+
+// decodeHalf sets the Wt values from the Wt16 or WtB16 encoded
+// half-precision values, if present.
+func (rw *Recv) decodeHalf() error {
+	enc, bf := rw.Wt16, false
+	if enc == "" {
+		enc, bf = rw.WtB16, true
+	}
+	if enc == "" {
+		return nil
+	}
+	b, err := base64.StdEncoding.DecodeString(enc)
+	if err != nil {
+		return err
+	}
+	if len(b)%2 != 0 {
+		return fmt.Errorf("odd number of bytes in half-precision weights for recv unit %d", rw.Ri)
+	}
+	rw.Wt = make([]float32, len(b)/2)
+	for i := range rw.Wt {
+		h := binary.LittleEndian.Uint16(b[2*i:])
+		if bf {
+			rw.Wt[i] = BFloat16(h).Float32()
+		} else {
+			rw.Wt[i] = Float16(h).Float32()
+		}
+	}
+	rw.Wt16, rw.WtB16 = "", ""
+	return nil
+}
+
+// decodeHalf decodes any half-precision weight values in the pathway.
+func (pw *Path) decodeHalf() error {
+	for i := range pw.Rs {
+		if err := pw.Rs[i].decodeHalf(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeHalf decodes any half-precision weight values in the layer.
+func (lw *Layer) decodeHalf() error {
+	for i := range lw.Paths {
+		if err := lw.Paths[i].decodeHalf(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeHalf decodes any half-precision weight values in the network.
+func (nw *Network) decodeHalf() error {
+	for i := range nw.Layers {
+		if err := nw.Layers[i].decodeHalf(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Float16 is an IEEE 754 half-precision (binary16) floating point value,
+// for storing synaptic weight values in 16 bits, as used by [WtFloat16]
+// weights files.  It is not used to store weights in memory: bp and hebb
+// always keep float32 weights.  Float16 has 10 bits of mantissa precision
+// (about 3 decimal digits) and a max value of 65504, which is
+// sufficient for weights in the typical 0-1 range.
+type Float16 uint16
+
+// NewFloat16 returns the Float16 value closest to given float32,
+// using round-to-nearest-even, as in standard hardware conversion.
+// Values beyond the Float16 range become +/- Inf.
+func NewFloat16(f float32) Float16 {
+	b := math.Float32bits(f)
+	sign := uint16((b >> 16) & 0x8000)
+	exp := int((b >> 23) & 0xff)
+	man := b & 0x7fffff
+	if exp == 0xff { // inf or nan
+		if man != 0 {
+			return Float16(sign | 0x7e00)
+		}
+		return Float16(sign | 0x7c00)
+	}
+	exp = exp - 127 + 15
+	if exp >= 0x1f { // overflow
+		return Float16(sign | 0x7c00)
+	}
+	if exp <= 0 { // subnormal or zero
+		if exp < -10 {
+			return Float16(sign)
+		}
+		man |= 0x800000 // implicit leading bit
+		shift := uint32(14 - exp)
+		h := man >> shift
+		rem := man & ((1 << shift) - 1)
+		half := uint32(1) << (shift - 1)
+		if rem > half || (rem == half && h&1 == 1) {
+			h++
+		}
+		return Float16(sign | uint16(h))
+	}
+	h := uint32(exp)<<10 | man>>13
+	rem := man & 0x1fff
+	if rem > 0x1000 || (rem == 0x1000 && h&1 == 1) {
+		h++ // note: carry into exponent is correct, including to Inf
+	}
+	return Float16(sign | uint16(h))
+}
+
+// Float32 returns the value as a float32, which is exact.
+func (h Float16) Float32() float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	man := uint32(h & 0x3ff)
+	switch {
+	case exp == 0x1f: // inf or nan
+		return math.Float32frombits(sign | 0x7f800000 | man<<13)
+	case exp == 0:
+		if man == 0 {
+			return math.Float32frombits(sign)
+		}
+		// subnormal: normalize
+		exp = 127 - 15 + 1
+		for man&0x400 == 0 {
+			man <<= 1
+			exp--
+		}
+		man &= 0x3ff
+		return math.Float32frombits(sign | exp<<23 | man<<13)
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | man<<13)
+}
+
+// BFloat16 is a "brain" floating point value, which is the upper
+// 16 bits of a float32: it has the same 8 bit exponent range as
+// float32 but only 7 bits of mantissa precision (about 2 decimal digits).
+// It is faster to convert than Float16, and never overflows, but is
+// less precise.  See Float16 for more info.
+type BFloat16 uint16
+
+// NewBFloat16 returns the BFloat16 value closest to given float32,
+// using round-to-nearest-even.
+func NewBFloat16(f float32) BFloat16 {
+	b := math.Float32bits(f)
+	if b&0x7f800000 == 0x7f800000 && b&0x7fffff != 0 { // nan: keep quiet nan
+		return BFloat16(b>>16 | 0x40)
+	}
+	b += 0x7fff + (b>>16)&1
+	return BFloat16(b >> 16)
+}
+
+// Float32 returns the value as a float32, which is exact.
+func (h BFloat16) Float32() float32 {
+	return math.Float32frombits(uint32(h) << 16)
+}
+
+// Float16sFromFloat32 converts float32 values in src into Float16
+// values in dst, which is resized as needed and returned.
+func Float16sFromFloat32(dst []Float16, src []float32) []Float16 {
+	if cap(dst) < len(src) {
+		dst = make([]Float16, len(src))
+	}
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = NewFloat16(v)
+	}
+	return dst
+}
+
+// Float16sToFloat32 converts Float16 values in src into float32
+// values in dst, which is resized as needed and returned.
+func Float16sToFloat32(dst []float32, src []Float16) []float32 {
+	if cap(dst) < len(src) {
+		dst = make([]float32, len(src))
+	}
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = v.Float32()
+	}
+	return dst
+}
+
+// BFloat16sFromFloat32 converts float32 values in src into BFloat16
+// values in dst, which is resized as needed and returned.
+func BFloat16sFromFloat32(dst []BFloat16, src []float32) []BFloat16 {
+	if cap(dst) < len(src) {
+		dst = make([]BFloat16, len(src))
+	}
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = NewBFloat16(v)
+	}
+	return dst
+}
+
+// BFloat16sToFloat32 converts BFloat16 values in src into float32
+// values in dst, which is resized as needed and returned.
+func BFloat16sToFloat32(dst []float32, src []BFloat16) []float32 {
+	if cap(dst) < len(src) {
+		dst = make([]float32, len(src))
+	}
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = v.Float32()
+	}
+	return dst
+}
+
+// Float16MaxError returns the maximum absolute error from storing
+// the given values as Float16, for validating that the reduced
+// precision is acceptable for a given set of weights.
+func Float16MaxError(vals []float32) float32 {
+	var mx float32
+	for _, v := range vals {
+		d := v - NewFloat16(v).Float32()
+		mx = max(mx, max(d, -d))
+	}
+	return mx
+}
+
+// BFloat16MaxError returns the maximum absolute error from storing
+// the given values as BFloat16, for validating that the reduced
+// precision is acceptable for a given set of weights.
+func BFloat16MaxError(vals []float32) float32 {
+	var mx float32
+	for _, v := range vals {
+		d := v - NewBFloat16(v).Float32()
+		mx = max(mx, max(d, -d))
+	}
+	return mx
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestFloat16(t *testing.T) {
+	tests := []struct {
+		f float32
+		h Float16
+	}{
+		{0, 0x0000},
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{0.5, 0x3800},
+		{65504, 0x7bff},
+		{1e6, 0x7c00},
+		{float32(math.Inf(-1)), 0xfc00},
+		{5.960464477539063e-08, 0x0001}, // smallest subnormal
+		{6.097555160522461e-05, 0x03ff}, // largest subnormal
+	}
+	for _, tt := range tests {
+		h := NewFloat16(tt.f)
+		if h != tt.h {
+			t.Errorf("NewFloat16(%g) = %#04x, want %#04x", tt.f, h, tt.h)
+		}
+		if tt.f < 65520 && tt.f > -65520 && h.Float32() != tt.f {
+			t.Errorf("Float16(%#04x).Float32() = %g, want %g", h, h.Float32(), tt.f)
+		}
+	}
+	if !math.IsNaN(float64(NewFloat16(float32(math.NaN())).Float32())) {
+		t.Errorf("Float16 NaN not preserved")
+	}
+	// all finite values round trip exactly
+	for i := range 0x7c00 {
+		h := Float16(i)
+		if NewFloat16(h.Float32()) != h {
+			t.Errorf("Float16 round trip failed for %#04x", i)
+		}
+	}
+}
+
+func TestBFloat16(t *testing.T) {
+	tests := []struct {
+		f float32
+		h BFloat16
+	}{
+		{0, 0x0000},
+		{1, 0x3f80},
+		{-2, 0xc000},
+		{1e30, 0x714a},
+		{float32(math.Inf(1)), 0x7f80},
+	}
+	for _, tt := range tests {
+		h := NewBFloat16(tt.f)
+		if h != tt.h {
+			t.Errorf("NewBFloat16(%g) = %#04x, want %#04x", tt.f, h, tt.h)
+		}
+	}
+	if !math.IsNaN(float64(NewBFloat16(float32(math.NaN())).Float32())) {
+		t.Errorf("BFloat16 NaN not preserved")
+	}
+}
+
+func TestHalfWeightsAccuracy(t *testing.T) {
+	wts := make([]float32, 10000)
+	for i := range wts {
+		wts[i] = rand.Float32()
+	}
+	h := Float16sFromFloat32(nil, wts)
+	bf := BFloat16sFromFloat32(nil, wts)
+	hw := Float16sToFloat32(nil, h)
+	bw := BFloat16sToFloat32(nil, bf)
+	if len(hw) != len(wts) || len(bw) != len(wts) {
+		t.Fatalf("length mismatch")
+	}
+	// 0-1 weights: error is at most 1/2 ulp at the top of the range.
+	if e := Float16MaxError(wts); e > 1.0/2048 {
+		t.Errorf("Float16MaxError too large: %g", e)
+	}
+	if e := BFloat16MaxError(wts); e > 1.0/256 {
+		t.Errorf("BFloat16MaxError too large: %g", e)
+	}
+	for i, w := range wts {
+		if d := math.Abs(float64(w - hw[i])); d > 1.0/2048 {
+			t.Errorf("Float16 weight %d: %g vs %g", i, w, hw[i])
+		}
+		if d := math.Abs(float64(w - bw[i])); d > 1.0/256 {
+			t.Errorf("BFloat16 weight %d: %g vs %g", i, w, bw[i])
+		}
+	}
+}
+
+func TestWriteWtJSON(t *testing.T) {
+	defer func() { WtFormat = WtFloat32 }()
+	wts := []float32{0, 0.25, 0.3333, 0.9, 1}
+	for _, ft := range WtFormatsValues() {
+		WtFormat = ft
+		var b bytes.Buffer
+		b.WriteString("{\"From\": \"Input\", \"Rs\": [ {\"Ri\": 0, \"N\": 5, \"Si\": [ 0, 1, 2, 3, 4 ],\n")
+		WriteWtJSON(&b, 1, wts)
+		b.WriteString("} ] }\n")
+		pw, err := PathReadJSON(&b)
+		if err != nil {
+			t.Fatalf("%v: %v", ft, err)
+		}
+		rw := &pw.Rs[0]
+		if rw.Wt16 != "" || rw.WtB16 != "" {
+			t.Errorf("%v: encoded weights not cleared after decoding", ft)
+		}
+		if len(rw.Wt) != len(wts) {
+			t.Fatalf("%v: decoded %d weights, want %d", ft, len(rw.Wt), len(wts))
+		}
+		tol := map[WtFormats]float64{WtFloat32: 1.0e-6, WtFloat16: 1.0e-3, WtBFloat16: 4.0e-3}[ft]
+		for i, w := range wts {
+			if math.Abs(float64(rw.Wt[i]-w)) > tol {
+				t.Errorf("%v: Wt[%d] = %g, want %g", ft, i, rw.Wt[i], w)
+			}
+		}
+	}
+	_, err := PathReadJSON(strings.NewReader(`{"Rs": [ {"Ri": 0, "Wt16": "AAA"} ]}`))
+	if err == nil {
+		t.Errorf("expected error for odd number of half-precision bytes")
+	}
+}
+
+func TestHalfWeightsSize(t *testing.T) {
+	defer func() { WtFormat = WtFloat32 }()
+	wts := make([]float32, 1000)
+	for i := range wts {
+		wts[i] = rand.Float32()
+	}
+	sz := make(map[WtFormats]int)
+	for _, ft := range WtFormatsValues() {
+		WtFormat = ft
+		var b bytes.Buffer
+		WriteWtJSON(&b, 0, wts)
+		sz[ft] = b.Len()
+	}
+	if sz[WtFloat16]*3 > sz[WtFloat32] || sz[WtBFloat16]*3 > sz[WtFloat32] {
+		t.Errorf("unexpected sizes: %s", fmt.Sprint(sz))
+	}
+}
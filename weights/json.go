@@ -7,7 +7,6 @@ package weights
 import (
 	"encoding/json"
 	"io"
-	"log"
 )
 
 // Prec is the precision for weight output in text formats.
@@ -15,7 +14,9 @@ import (
 // May need to increase for other models.
 var Prec = 4
 
-// NetReadJSON reads weights for entire network in a JSON format into Network structure
+// NetReadJSON reads weights for entire network in a JSON format into Network structure.
+// Returns nil, nil if the input is empty. If it cannot be decoded,
+// it returns the values that were decoded, with a [*ParseError].
 func NetReadJSON(r io.Reader) (*Network, error) {
 	nw := &Network{}
 	dec := json.NewDecoder(r)
@@ -24,12 +25,17 @@ func NetReadJSON(r io.Reader) (*Network, error) {
 		return nil, nil
 	}
 	if err != nil {
-		log.Println(err)
+		return nw, &ParseError{Format: "json", Err: err}
+	}
+	if err := nw.decodeHalf(); err != nil {
+		return nw, &ParseError{Format: "json", Err: err}
 	}
 	return nw, nil
 }
 
-// LayReadJSON reads weights for layer in a JSON format into Layer structure
+// LayReadJSON reads weights for layer in a JSON format into Layer structure.
+// Returns nil, nil if the input is empty. If it cannot be decoded,
+// it returns the values that were decoded, with a [*ParseError].
 func LayReadJSON(r io.Reader) (*Layer, error) {
 	lw := &Layer{}
 	dec := json.NewDecoder(r)
@@ -38,12 +44,17 @@ func LayReadJSON(r io.Reader) (*Layer, error) {
 		return nil, nil
 	}
 	if err != nil {
-		log.Println(err)
+		return lw, &ParseError{Format: "json", Err: err}
+	}
+	if err := lw.decodeHalf(); err != nil {
+		return lw, &ParseError{Format: "json", Err: err}
 	}
 	return lw, nil
 }
 
-// PathReadJSON reads weights for path in a JSON format into Path structure
+// PathReadJSON reads weights for path in a JSON format into Path structure.
+// Returns nil, nil if the input is empty. If it cannot be decoded,
+// it returns the values that were decoded, with a [*ParseError].
 func PathReadJSON(r io.Reader) (*Path, error) {
 	pw := &Path{}
 	dec := json.NewDecoder(r)
@@ -52,7 +63,10 @@ func PathReadJSON(r io.Reader) (*Path, error) {
 		return nil, nil
 	}
 	if err != nil {
-		log.Println(err)
+		return pw, &ParseError{Format: "json", Err: err}
+	}
+	if err := pw.decodeHalf(); err != nil {
+		return pw, &ParseError{Format: "json", Err: err}
 	}
 	return pw, nil
 }
@@ -0,0 +1,110 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// Provenance is the training history of a pathway, which is recorded
+// in the MetaData of its weights, so that composite models assembled
+// from separately trained pieces can document where each pathway
+// came from.
+type Provenance struct {
+
+	// Source is the model (or weights file) that the pathway was trained in.
+	Source string
+
+	// NTrials is the number of trials that the pathway was trained on.
+	NTrials int
+
+	// LRate is the last learning rate used for training.
+	LRate float32
+
+	// ParamHash is a hash of the parameters of the pathway
+	// when it was trained (see [ParamHash]).
+	ParamHash string
+
+	// Frozen indicates that learning is turned off for the pathway,
+	// e.g., to preserve the trained weights in a composite model.
+	Frozen bool
+}
+
+// ProvenancePrefix is the prefix of the MetaData keys for the
+// [Provenance] fields, e.g., Provenance.NTrials.
+const ProvenancePrefix = "Provenance."
+
+// ParamHash returns a short hash of given parameter listing
+// (e.g., from AllParams), for recording in [Provenance].
+func ParamHash(params string) string {
+	h := sha256.Sum256([]byte(params))
+	return hex.EncodeToString(h[:8])
+}
+
+// SetMetaData sets the [Provenance] fields in given metadata map.
+func (pv *Provenance) SetMetaData(md map[string]string) {
+	md[ProvenancePrefix+"Source"] = pv.Source
+	md[ProvenancePrefix+"NTrials"] = strconv.Itoa(pv.NTrials)
+	md[ProvenancePrefix+"LRate"] = strconv.FormatFloat(float64(pv.LRate), 'g', -1, 32)
+	md[ProvenancePrefix+"ParamHash"] = pv.ParamHash
+	md[ProvenancePrefix+"Frozen"] = strconv.FormatBool(pv.Frozen)
+}
+
+// ProvenanceFromMetaData returns the [Provenance] recorded in given
+// metadata map, or nil if there is none, with an error for invalid values.
+func ProvenanceFromMetaData(md map[string]string) (*Provenance, error) {
+	found := false
+	pv := &Provenance{}
+	var err error
+	for k, v := range md {
+		switch k {
+		case ProvenancePrefix + "Source":
+			pv.Source = v
+		case ProvenancePrefix + "NTrials":
+			pv.NTrials, err = strconv.Atoi(v)
+		case ProvenancePrefix + "LRate":
+			var lr float64
+			lr, err = strconv.ParseFloat(v, 32)
+			pv.LRate = float32(lr)
+		case ProvenancePrefix + "ParamHash":
+			pv.ParamHash = v
+		case ProvenancePrefix + "Frozen":
+			pv.Frozen, err = strconv.ParseBool(v)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("weights.Provenance: %s: %w", k, err)
+		}
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+	return pv, nil
+}
+
+// String returns the provenance as a string of the form:
+// Source: x NTrials: 100 LRate: 0.04 ParamHash: 1a2b... Frozen: false
+func (pv *Provenance) String() string {
+	return fmt.Sprintf("Source: %s NTrials: %d LRate: %g ParamHash: %s Frozen: %v", pv.Source, pv.NTrials, pv.LRate, pv.ParamHash, pv.Frozen)
+}
+
+// SetProvenance records given [Provenance] in the path MetaData.
+func (pj *Path) SetProvenance(pv *Provenance) {
+	if pj.MetaData == nil {
+		pj.MetaData = make(map[string]string)
+	}
+	pv.SetMetaData(pj.MetaData)
+}
+
+// Provenance returns the [Provenance] recorded in the path MetaData,
+// or nil if there is none, with an error for invalid values.
+func (pj *Path) Provenance() (*Provenance, error) {
+	return ProvenanceFromMetaData(pj.MetaData)
+}
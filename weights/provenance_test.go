@@ -0,0 +1,45 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvenance(t *testing.T) {
+	pj := &Path{From: "Input"}
+	pj.SetMetaData("GScale", "0.5")
+	pv, err := pj.Provenance()
+	assert.NoError(t, err)
+	assert.Nil(t, pv)
+
+	h := ParamHash("Path: InputToHidden\tLRate: 0.04")
+	assert.Len(t, h, 16)
+	assert.Equal(t, h, ParamHash("Path: InputToHidden\tLRate: 0.04"))
+	assert.NotEqual(t, h, ParamHash("Path: InputToHidden\tLRate: 0.02"))
+
+	opv := &Provenance{Source: "vision.wts.gz", NTrials: 12000, LRate: 0.04, ParamHash: h, Frozen: true}
+	pj.SetProvenance(opv)
+	assert.Equal(t, "0.5", pj.MetaData["GScale"])
+	assert.Equal(t, "12000", pj.MetaData["Provenance.NTrials"])
+	assert.Equal(t, "0.04", pj.MetaData["Provenance.LRate"])
+
+	var b bytes.Buffer
+	assert.NoError(t, json.NewEncoder(&b).Encode(pj))
+	rpj, err := PathReadJSON(&b)
+	assert.NoError(t, err)
+	pv, err = rpj.Provenance()
+	assert.NoError(t, err)
+	assert.Equal(t, opv, pv)
+	assert.Equal(t, "Source: vision.wts.gz NTrials: 12000 LRate: 0.04 ParamHash: "+h+" Frozen: true", pv.String())
+
+	rpj.MetaData["Provenance.NTrials"] = "many"
+	_, err = rpj.Provenance()
+	assert.ErrorContains(t, err, "Provenance.NTrials")
+}
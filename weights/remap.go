@@ -0,0 +1,251 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"fmt"
+	"math"
+)
+
+// Remaps are the methods for remapping weights between different
+// layer shapes, for transfer learning with scaled-up architectures.
+type Remaps int32 //enums:enum
+
+const (
+	// Nearest uses the weight of the nearest saved unit,
+	// over topographic coordinates.
+	Nearest Remaps = iota
+
+	// Bilinear linearly interpolates between the weights of the
+	// (up to four) nearest saved units, over topographic coordinates.
+	Bilinear
+
+	// Truncate uses the weight of the saved unit at the same
+	// coordinates, and no weight (leaving the initial weight) for units
+	// outside of the saved shape, dropping saved units outside of the
+	// new shape.
+	Truncate
+
+	// Tile repeats the saved weights over the new shape,
+	// with coordinates wrapping around the saved shape.
+	Tile
+)
+
+// Remap configures the remapping of weights when the shapes of
+// the layers in the saved weights differ from the new network.
+type Remap struct {
+
+	// Method is the remapping method.
+	Method Remaps
+
+	// Shapes are the shapes of the saved layers, by layer name,
+	// which are not recorded in the weights files. Layers without
+	// a shape here have the same shape as in the new network.
+	Shapes map[string][]int
+}
+
+// UnitWeight is a saved unit that a new unit maps to,
+// with the weight of its contribution.
+type UnitWeight struct {
+
+	// Index is the 1D index of the saved unit.
+	Index int
+
+	// Weight is the weight of the saved unit.
+	Weight float32
+}
+
+// grid returns the Y, X size of the topographic grid of units
+// for given layer shape: 1D is a single row, 2D is Y, X, and 4D is
+// pools of units, with pool and unit coordinates combined.
+func grid(shape []int) (ny, nx int) {
+	switch len(shape) {
+	case 0:
+		return 1, 1
+	case 1:
+		return 1, shape[0]
+	case 4:
+		return shape[0] * shape[2], shape[1] * shape[3]
+	}
+	ny = 1
+	for _, s := range shape[:len(shape)-1] {
+		ny *= s
+	}
+	return ny, shape[len(shape)-1]
+}
+
+// gridIndex returns the 1D unit index for given grid coordinates.
+func gridIndex(shape []int, y, x int) int {
+	if len(shape) != 4 {
+		_, nx := grid(shape)
+		return y*nx + x
+	}
+	py, uy := y/shape[2], y%shape[2]
+	px, ux := x/shape[3], x%shape[3]
+	return ((py*shape[1]+px)*shape[2]+uy)*shape[3] + ux
+}
+
+// coords returns the saved coordinates and their weights
+// for given new coordinate, for sizes nf saved and nt new.
+func (mt Remaps) coords(t, nf, nt int) ([]int, []float32) {
+	switch mt {
+	case Truncate:
+		if t >= nf {
+			return nil, nil
+		}
+		return []int{t}, []float32{1}
+	case Tile:
+		return []int{t % nf}, []float32{1}
+	}
+	f := (float64(t)+0.5)*float64(nf)/float64(nt) - 0.5
+	f = min(max(f, 0), float64(nf-1))
+	if mt == Nearest {
+		return []int{min(int(math.Round(f)), nf-1)}, []float32{1}
+	}
+	i0 := int(math.Floor(f))
+	i1 := min(i0+1, nf-1)
+	w := float32(f - float64(i0))
+	if i1 == i0 || w == 0 {
+		return []int{i0}, []float32{1}
+	}
+	return []int{i0, i1}, []float32{1 - w, w}
+}
+
+// UnitMap returns the saved units that each unit of a layer with the
+// new shape maps to, for a layer with the saved shape, using given method,
+// over the topographic grid of units (see [Remaps]). Units map to nothing
+// if they are outside of the saved shape for Truncate.
+func UnitMap(from, to []int, method Remaps) [][]UnitWeight {
+	fy, fx := grid(from)
+	ty, tx := grid(to)
+	um := make([][]UnitWeight, ty*tx)
+	for y := range ty {
+		ys, yw := method.coords(y, fy, ty)
+		for x := range tx {
+			xs, xw := method.coords(x, fx, tx)
+			var uw []UnitWeight
+			for i, sy := range ys {
+				for j, sx := range xs {
+					uw = append(uw, UnitWeight{Index: gridIndex(from, sy, sx), Weight: yw[i] * xw[j]})
+				}
+			}
+			um[gridIndex(to, y, x)] = uw
+		}
+	}
+	return um
+}
+
+// RemapUnits returns the values for the units of a layer with the new
+// shape, remapped from given values for the saved shape according to
+// given unit map, with NaN for units that map to nothing.
+func RemapUnits(vals []float32, um [][]UnitWeight) []float32 {
+	rv := make([]float32, len(um))
+	for i, uw := range um {
+		var sum, wsum float32
+		for _, u := range uw {
+			if u.Index < len(vals) {
+				sum += u.Weight * vals[u.Index]
+				wsum += u.Weight
+			}
+		}
+		if wsum > 0 {
+			rv[i] = sum / wsum
+		} else {
+			rv[i] = float32(math.NaN())
+		}
+	}
+	return rv
+}
+
+// IndexMap returns the index of the unit at the same topographic
+// coordinates in a layer with the saved shape, for each unit of a layer
+// with the new shape, or -1 for units outside of the saved shape,
+// as in [Truncate], e.g., to keep the existing units of a layer that is
+// resized in place.
+func IndexMap(from, to []int) []int {
+	um := UnitMap(from, to, Truncate)
+	im := make([]int, len(um))
+	for i, uw := range um {
+		im[i] = -1
+		if len(uw) > 0 {
+			im[i] = uw[0].Index
+		}
+	}
+	return im
+}
+
+// RemapPath returns a copy of the pathway weights remapped to the new
+// shapes of the sending and receiving layers, according to given unit maps
+// (from [UnitMap]) and the number of saved sending units, for the synapses
+// of the new pathway given by syns: the sending unit indexes for each
+// receiving unit. The weight of each new synapse is the weighted average
+// of the saved synapses between the units that its units map to, and new
+// synapses without any such saved synapses are not included, which leaves
+// their initial weights. Also returns the number of new synapses without
+// saved synapses.
+func (pj *Path) RemapPath(sendMap, recvMap [][]UnitWeight, nsFrom int, syns [][]int) (*Path, int, error) {
+	if len(recvMap) != len(syns) {
+		return nil, 0, fmt.Errorf("weights.RemapPath: from %s: %d receiving units in the unit map, but %d in the synapses", pj.From, len(recvMap), len(syns))
+	}
+	type saved struct{ wt, wt1, wt2 float32 }
+	dense := make(map[int]saved)
+	for i := range pj.Rs {
+		rw := &pj.Rs[i]
+		for si, s := range rw.Si {
+			sv := saved{wt: rw.Wt[si]}
+			if si < len(rw.Wt1) {
+				sv.wt1 = rw.Wt1[si]
+			}
+			if si < len(rw.Wt2) {
+				sv.wt2 = rw.Wt2[si]
+			}
+			dense[rw.Ri*nsFrom+s] = sv
+		}
+	}
+	hasWt1 := len(pj.Rs) > 0 && len(pj.Rs[0].Wt1) > 0
+	hasWt2 := len(pj.Rs) > 0 && len(pj.Rs[0].Wt2) > 0
+	rp := &Path{From: pj.From, MetaData: pj.MetaData, MetaValues: pj.MetaValues}
+	nmiss := 0
+	for ri, sis := range syns {
+		rw := Recv{Ri: ri}
+		for _, si := range sis {
+			if si >= len(sendMap) {
+				return nil, 0, fmt.Errorf("weights.RemapPath: from %s: sending unit %d is out of range of the unit map", pj.From, si)
+			}
+			var sum saved
+			var wsum float32
+			for _, ru := range recvMap[ri] {
+				for _, su := range sendMap[si] {
+					sv, ok := dense[ru.Index*nsFrom+su.Index]
+					if !ok {
+						continue
+					}
+					w := ru.Weight * su.Weight
+					sum.wt += w * sv.wt
+					sum.wt1 += w * sv.wt1
+					sum.wt2 += w * sv.wt2
+					wsum += w
+				}
+			}
+			if wsum == 0 {
+				nmiss++
+				continue
+			}
+			rw.Si = append(rw.Si, si)
+			rw.Wt = append(rw.Wt, sum.wt/wsum)
+			if hasWt1 {
+				rw.Wt1 = append(rw.Wt1, sum.wt1/wsum)
+			}
+			if hasWt2 {
+				rw.Wt2 = append(rw.Wt2, sum.wt2/wsum)
+			}
+		}
+		rw.N = len(rw.Si)
+		if rw.N > 0 {
+			rp.Rs = append(rp.Rs, rw)
+		}
+	}
+	return rp, nmiss, nil
+}
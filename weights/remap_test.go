@@ -0,0 +1,73 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package weights
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// indexes returns the saved unit indexes of each unit of given unit map.
+func indexes(um [][]UnitWeight) [][]int {
+	idx := make([][]int, len(um))
+	for i, uw := range um {
+		for _, u := range uw {
+			idx[i] = append(idx[i], u.Index)
+		}
+	}
+	return idx
+}
+
+func TestUnitMap(t *testing.T) {
+	um := UnitMap([]int{2}, []int{4}, Nearest)
+	assert.Equal(t, [][]int{{0}, {0}, {1}, {1}}, indexes(um))
+	um = UnitMap([]int{4}, []int{2}, Nearest)
+	assert.Len(t, um, 2)
+
+	um = UnitMap([]int{2}, []int{4}, Bilinear)
+	assert.Equal(t, [][]int{{0}, {0, 1}, {0, 1}, {1}}, indexes(um))
+	assert.InDelta(t, 0.75, um[1][0].Weight, 1.0e-6)
+	assert.InDelta(t, 0.25, um[1][1].Weight, 1.0e-6)
+
+	um = UnitMap([]int{2, 2}, []int{2, 3}, Truncate)
+	assert.Equal(t, [][]int{{0}, {1}, nil, {2}, {3}, nil}, indexes(um))
+
+	assert.Equal(t, []int{0, 1, -1, 2, 3, -1}, IndexMap([]int{2, 2}, []int{2, 3}))
+	assert.Equal(t, []int{0, 1, 2, 3}, IndexMap([]int{2, 2}, []int{2, 2}))
+
+	um = UnitMap([]int{1, 2}, []int{2, 4}, Tile)
+	assert.Equal(t, [][]int{{0}, {1}, {0}, {1}, {0}, {1}, {0}, {1}}, indexes(um))
+
+	// 4D: pools of units, over the combined grid
+	um = UnitMap([]int{1, 1, 2, 2}, []int{1, 2, 2, 2}, Tile)
+	assert.Equal(t, [][]int{{0}, {1}, {2}, {3}, {0}, {1}, {2}, {3}}, indexes(um))
+	um = UnitMap([]int{1, 1, 2, 2}, []int{2, 2}, Nearest)
+	assert.Equal(t, [][]int{{0}, {1}, {2}, {3}}, indexes(um))
+
+	vals := RemapUnits([]float32{1, 3}, UnitMap([]int{2}, []int{4}, Bilinear))
+	assert.Equal(t, []float32{1, 1.5, 2.5, 3}, vals)
+	vals = RemapUnits([]float32{1, 3}, UnitMap([]int{2}, []int{3}, Truncate))
+	assert.True(t, math.IsNaN(float64(vals[2])))
+}
+
+func TestRemapPath(t *testing.T) {
+	// 2 sending to 1 receiving unit, missing synapse from 1
+	pj := &Path{From: "Input"}
+	pj.SetMetaData("GScale", "0.5")
+	pj.Rs = []Recv{{Ri: 0, N: 1, Si: []int{0}, Wt: []float32{0.8}}}
+	smap := UnitMap([]int{2}, []int{4}, Nearest)
+	rmap := UnitMap([]int{1}, []int{2}, Nearest)
+	syns := [][]int{{0, 1, 2, 3}, {1, 2}}
+	rp, nmiss, err := pj.RemapPath(smap, rmap, 2, syns)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, nmiss)
+	assert.Equal(t, "0.5", rp.MetaData["GScale"])
+	assert.Equal(t, []Recv{{Ri: 0, N: 2, Si: []int{0, 1}, Wt: []float32{0.8, 0.8}}, {Ri: 1, N: 1, Si: []int{1}, Wt: []float32{0.8}}}, rp.Rs)
+
+	_, _, err = pj.RemapPath(smap, rmap, 2, syns[:1])
+	assert.Error(t, err)
+}
@@ -12,4 +12,20 @@ var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.Lay
 
 var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.Path", IDName: "path", Doc: "Path is temp structure for holding decoded weights, one for each pathway", Fields: []types.Field{{Name: "From"}, {Name: "MetaData"}, {Name: "MetaValues"}, {Name: "Rs"}}})
 
-var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.Recv", IDName: "recv", Doc: "Recv is temp structure for holding decoded weights, one for each recv unit", Fields: []types.Field{{Name: "Ri"}, {Name: "N"}, {Name: "Si"}, {Name: "Wt"}, {Name: "Wt1"}, {Name: "Wt2"}}})
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.Recv", IDName: "recv", Doc: "Recv is temp structure for holding decoded weights, one for each recv unit", Fields: []types.Field{{Name: "Ri"}, {Name: "N"}, {Name: "Si"}, {Name: "Wt"}, {Name: "Wt1"}, {Name: "Wt2"}, {Name: "Wt16", Doc: "Wt16 has the Wt values as base64 encoded Float16 values,\nwritten with WtFloat16, which are decoded into Wt on reading."}, {Name: "WtB16", Doc: "WtB16 has the Wt values as base64 encoded BFloat16 values,\nwritten with WtBFloat16, which are decoded into Wt on reading."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.WtFormats", IDName: "wt-formats", Doc: "WtFormats are the formats for writing synaptic weight values\nin weights files."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.Float16", IDName: "float16", Doc: "Float16 is an IEEE 754 half-precision (binary16) floating point value,\nfor storing synaptic weight values in 16 bits, as used by [WtFloat16]\nweights files.  It is not used to store weights in memory: bp and hebb\nalways keep float32 weights.  Float16 has 10 bits of mantissa precision\n(about 3 decimal digits) and a max value of 65504, which is\nsufficient for weights in the typical 0-1 range."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.BFloat16", IDName: "b-float16", Doc: "BFloat16 is a \"brain\" floating point value, which is the upper\n16 bits of a float32: it has the same 8 bit exponent range as\nfloat32 but only 7 bits of mantissa precision (about 2 decimal digits).\nIt is faster to convert than Float16, and never overflows, but is\nless precise.  See Float16 for more info."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.Provenance", IDName: "provenance", Doc: "Provenance is the training history of a pathway, which is recorded\nin the MetaData of its weights, so that composite models assembled\nfrom separately trained pieces can document where each pathway\ncame from.", Fields: []types.Field{{Name: "Source", Doc: "Source is the model (or weights file) that the pathway was trained in."}, {Name: "NTrials", Doc: "NTrials is the number of trials that the pathway was trained on."}, {Name: "LRate", Doc: "LRate is the last learning rate used for training."}, {Name: "ParamHash", Doc: "ParamHash is a hash of the parameters of the pathway\nwhen it was trained (see [ParamHash])."}, {Name: "Frozen", Doc: "Frozen indicates that learning is turned off for the pathway,\ne.g., to preserve the trained weights in a composite model."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.Remaps", IDName: "remaps", Doc: "Remaps are the methods for remapping weights between different\nlayer shapes, for transfer learning with scaled-up architectures."})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.Remap", IDName: "remap", Doc: "Remap configures the remapping of weights when the shapes of\nthe layers in the saved weights differ from the new network.", Fields: []types.Field{{Name: "Method", Doc: "Method is the remapping method."}, {Name: "Shapes", Doc: "Shapes are the shapes of the saved layers, by layer name,\nwhich are not recorded in the weights files. Layers without\na shape here have the same shape as in the new network."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.UnitWeight", IDName: "unit-weight", Doc: "UnitWeight is a saved unit that a new unit maps to,\nwith the weight of its contribution.", Fields: []types.Field{{Name: "Index", Doc: "Index is the 1D index of the saved unit."}, {Name: "Weight", Doc: "Weight is the weight of the saved unit."}}})
+
+var _ = types.AddType(&types.Type{Name: "github.com/emer/emergent/v2/weights.ParseError", IDName: "parse-error", Doc: "ParseError is returned when weights could not be read,\nwith the underlying error.", Fields: []types.Field{{Name: "Format", Doc: "Format is the format being read: \"json\" or \"cpp\"."}, {Name: "Line", Doc: "Line is the line number of the input at which the error\noccurred, if known, starting at 1."}, {Name: "Input", Doc: "Input is the input line that could not be parsed, if known."}, {Name: "Err", Doc: "Err is the underlying error."}}})
@@ -58,4 +58,12 @@ type Recv struct {
 	Wt  []float32
 	Wt1 []float32 // call extra synapse-level vars 1,2..
 	Wt2 []float32 // call extra synapse-level vars 1,2..
+
+	// Wt16 has the Wt values as base64 encoded Float16 values,
+	// written with WtFloat16, which are decoded into Wt on reading.
+	Wt16 string `json:",omitempty"`
+
+	// WtB16 has the Wt values as base64 encoded BFloat16 values,
+	// written with WtBFloat16, which are decoded into Wt on reading.
+	WtB16 string `json:",omitempty"`
 }